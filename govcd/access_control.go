@@ -17,8 +17,8 @@ import (
 )
 
 // orgInfoCache is a cache to save org information, avoid repeated calls to compute the same result.
-// The keys to this map are the requesting objects IDs.
-var orgInfoCache = make(map[string]*TenantContext)
+// The keys to this cache are the requesting objects IDs. It is not used when Client.DisableTenantContextCache is set.
+var orgInfoCache = newTenantContextCache(defaultTenantContextCacheSize)
 
 // GetAccessControl retrieves the access control information for the requested entity
 func (client Client) GetAccessControl(ctx context.Context, href, entityType, entityName string, headerValues map[string]string) (*types.ControlAccessParams, error) {
@@ -28,7 +28,7 @@ func (client Client) GetAccessControl(ctx context.Context, href, entityType, ent
 
 	acUrl, err := url.ParseRequestURI(href)
 	if err != nil {
-		return nil, fmt.Errorf("[client.GetAccessControl] error parsing HREF %s: %s", href, err)
+		return nil, fmt.Errorf("[client.GetAccessControl] error parsing HREF %s: %w", href, err)
 	}
 	var additionalHeader = make(http.Header)
 
@@ -50,13 +50,13 @@ func (client Client) GetAccessControl(ctx context.Context, href, entityType, ent
 
 	resp, err := checkResp(client.Http.Do(req))
 	if err != nil {
-		return nil, fmt.Errorf("[client.GetAccessControl] error checking response to request %s: %s", href, err)
+		return nil, fmt.Errorf("[client.GetAccessControl] error checking response to request %s: %w", href, err)
 	}
 	if resp == nil {
 		return nil, fmt.Errorf("[client.GetAccessControl] nil response received")
 	}
 	if err = decodeBody(types.BodyTypeXML, resp, &controlAccess); err != nil {
-		return nil, fmt.Errorf("[client.GetAccessControl] error decoding response: %s", err)
+		return nil, fmt.Errorf("[client.GetAccessControl] error decoding response: %w", err)
 	}
 
 	return &controlAccess, nil
@@ -99,7 +99,7 @@ func (client *Client) setAccessControlWithHttpMethod(ctx context.Context, httpMe
 	accessControl.Xmlns = types.XMLNamespaceVCloud
 	queryUrl, err := url.ParseRequestURI(href)
 	if err != nil {
-		return fmt.Errorf("[client.SetAccessControl] error parsing HREF %s: %s", href, err)
+		return fmt.Errorf("[client.SetAccessControl] error parsing HREF %s: %w", href, err)
 	}
 
 	var header = make(http.Header)
@@ -111,7 +111,7 @@ func (client *Client) setAccessControlWithHttpMethod(ctx context.Context, httpMe
 
 	marshaledXml, err := xml.MarshalIndent(accessControl, "  ", "    ")
 	if err != nil {
-		return fmt.Errorf("[client.SetAccessControl] error marshalling xml data: %s", err)
+		return fmt.Errorf("[client.SetAccessControl] error marshalling xml data: %w", err)
 	}
 	body := bytes.NewBufferString(xml.Header + string(marshaledXml))
 
@@ -129,7 +129,7 @@ func (client *Client) setAccessControlWithHttpMethod(ctx context.Context, httpMe
 	resp, err := checkResp(client.Http.Do(req))
 
 	if err != nil {
-		return fmt.Errorf("[client.SetAccessControl] error checking response to HREF %s: %s", href, err)
+		return fmt.Errorf("[client.SetAccessControl] error checking response to HREF %s: %w", href, err)
 	}
 	if resp == nil {
 		return fmt.Errorf("[client.SetAccessControl] nil response received")
@@ -244,7 +244,7 @@ func (adminCatalog AdminCatalog) IsShared(ctx context.Context, useTenantContext
 func (vdc *Vdc) GetVappAccessControl(ctx context.Context, vappIdentifier string, useTenantContext bool) (*types.ControlAccessParams, error) {
 	vapp, err := vdc.GetVAppByNameOrId(ctx, vappIdentifier, true)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving vApp %s: %s", vappIdentifier, err)
+		return nil, fmt.Errorf("error retrieving vApp %s: %w", vappIdentifier, err)
 	}
 	return vapp.GetAccessControl(ctx, useTenantContext)
 }
@@ -255,7 +255,7 @@ func (vdc *Vdc) GetVappAccessControl(ctx context.Context, vappIdentifier string,
 func (org *AdminOrg) GetCatalogAccessControl(ctx context.Context, catalogIdentifier string, useTenantContext bool) (*types.ControlAccessParams, error) {
 	catalog, err := org.GetAdminCatalogByNameOrId(ctx, catalogIdentifier, true)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving catalog %s: %s", catalogIdentifier, err)
+		return nil, fmt.Errorf("error retrieving catalog %s: %w", catalogIdentifier, err)
 	}
 	return catalog.GetAccessControl(ctx, useTenantContext)
 }
@@ -266,7 +266,7 @@ func (org *AdminOrg) GetCatalogAccessControl(ctx context.Context, catalogIdentif
 func (org *Org) GetCatalogAccessControl(ctx context.Context, catalogIdentifier string, useTenantContext bool) (*types.ControlAccessParams, error) {
 	catalog, err := org.GetCatalogByNameOrId(ctx, catalogIdentifier, true)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving catalog %s: %s", catalogIdentifier, err)
+		return nil, fmt.Errorf("error retrieving catalog %s: %w", catalogIdentifier, err)
 	}
 	return catalog.GetAccessControl(ctx, useTenantContext)
 }
@@ -320,6 +320,105 @@ func (catalog Catalog) IsShared(ctx context.Context, useTenantContext bool) (boo
 	return settings.AccessSettings != nil, nil
 }
 
+// GetAccessControl retrieves the access control information for this vApp template.
+// Sharing of single catalog items, without sharing the whole catalog, is only supported by recent VCD versions
+func (vAppTemplate VAppTemplate) GetAccessControl(ctx context.Context, useTenantContext bool) (*types.ControlAccessParams, error) {
+
+	if vAppTemplate.VAppTemplate.HREF == "" {
+		return nil, fmt.Errorf("vApp template HREF is empty")
+	}
+	// if useTenantContext is false, we use an empty header (= default behavior)
+	// if it is true, we use a header populated with tenant context values
+	accessControlHeader, err := vAppTemplate.getAccessControlHeader(ctx, useTenantContext)
+	if err != nil {
+		return nil, err
+	}
+	return vAppTemplate.client.GetAccessControl(ctx, vAppTemplate.VAppTemplate.HREF, "vAppTemplate", vAppTemplate.VAppTemplate.Name, accessControlHeader)
+}
+
+// SetAccessControl changes the access control information for this vApp template, so that it can be shared
+// with specific users without sharing the catalog that contains it
+func (vAppTemplate VAppTemplate) SetAccessControl(ctx context.Context, accessControl *types.ControlAccessParams, useTenantContext bool) error {
+
+	if vAppTemplate.VAppTemplate.HREF == "" {
+		return fmt.Errorf("vApp template HREF is empty")
+	}
+
+	// if useTenantContext is false, we use an empty header (= default behavior)
+	// if it is true, we use a header populated with tenant context values
+	accessControlHeader, err := vAppTemplate.getAccessControlHeader(ctx, useTenantContext)
+	if err != nil {
+		return err
+	}
+	return vAppTemplate.client.SetAccessControl(ctx, accessControl, vAppTemplate.VAppTemplate.HREF, "vAppTemplate", vAppTemplate.VAppTemplate.Name, accessControlHeader)
+}
+
+// RemoveAccessControl is a shortcut to SetAccessControl with all access disabled
+func (vAppTemplate VAppTemplate) RemoveAccessControl(ctx context.Context, useTenantContext bool) error {
+	return vAppTemplate.SetAccessControl(ctx, &types.ControlAccessParams{IsSharedToEveryone: false}, useTenantContext)
+}
+
+// IsShared shows whether a vApp template is shared or not, regardless of the number of subjects sharing it
+func (vAppTemplate VAppTemplate) IsShared(ctx context.Context, useTenantContext bool) (bool, error) {
+	settings, err := vAppTemplate.GetAccessControl(ctx, useTenantContext)
+	if err != nil {
+		return false, err
+	}
+	if settings.IsSharedToEveryone {
+		return true, nil
+	}
+	return settings.AccessSettings != nil, nil
+}
+
+// GetAccessControl retrieves the access control information for the vApp template of this catalog item
+func (catalogItem CatalogItem) GetAccessControl(ctx context.Context, useTenantContext bool) (*types.ControlAccessParams, error) {
+	vAppTemplate, err := catalogItem.getVAppTemplateForAccessControl(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return vAppTemplate.GetAccessControl(ctx, useTenantContext)
+}
+
+// SetAccessControl changes the access control information for the vApp template of this catalog item
+func (catalogItem CatalogItem) SetAccessControl(ctx context.Context, accessControl *types.ControlAccessParams, useTenantContext bool) error {
+	vAppTemplate, err := catalogItem.getVAppTemplateForAccessControl(ctx)
+	if err != nil {
+		return err
+	}
+	return vAppTemplate.SetAccessControl(ctx, accessControl, useTenantContext)
+}
+
+// RemoveAccessControl is a shortcut to SetAccessControl with all access disabled
+func (catalogItem CatalogItem) RemoveAccessControl(ctx context.Context, useTenantContext bool) error {
+	return catalogItem.SetAccessControl(ctx, &types.ControlAccessParams{IsSharedToEveryone: false}, useTenantContext)
+}
+
+// IsShared shows whether a catalog item is shared or not, regardless of the number of subjects sharing it
+func (catalogItem CatalogItem) IsShared(ctx context.Context, useTenantContext bool) (bool, error) {
+	vAppTemplate, err := catalogItem.getVAppTemplateForAccessControl(ctx)
+	if err != nil {
+		return false, err
+	}
+	return vAppTemplate.IsShared(ctx, useTenantContext)
+}
+
+// getVAppTemplateForAccessControl retrieves the vApp template of the catalog item, as only vApp templates
+// can be shared individually
+func (catalogItem *CatalogItem) getVAppTemplateForAccessControl(ctx context.Context) (*VAppTemplate, error) {
+	if catalogItem.CatalogItem.Entity == nil || catalogItem.CatalogItem.Entity.HREF == "" {
+		return nil, fmt.Errorf("catalog item %s has no entity", catalogItem.CatalogItem.Name)
+	}
+	if catalogItem.CatalogItem.Entity.Type != types.MimeVAppTemplate {
+		return nil, fmt.Errorf("catalog item %s is not a vApp template: access control can't be set on entity type %s",
+			catalogItem.CatalogItem.Name, catalogItem.CatalogItem.Entity.Type)
+	}
+	vAppTemplate, err := catalogItem.GetVAppTemplate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &vAppTemplate, nil
+}
+
 // getAccessControlHeader builds the data needed to set the header when tenant context is required.
 // If useTenantContext is false, it returns an empty map.
 // Otherwise, it finds the Org ID and name (going up in the hierarchy through the VDC)
@@ -335,6 +434,21 @@ func (vapp *VApp) getAccessControlHeader(ctx context.Context, useTenantContext b
 	return map[string]string{types.HeaderTenantContext: orgInfo.OrgId, types.HeaderAuthContext: orgInfo.OrgName}, nil
 }
 
+// getAccessControlHeader builds the data needed to set the header when tenant context is required.
+// If useTenantContext is false, it returns an empty map.
+// Otherwise, it finds the Org ID and name (going up in the hierarchy through the vApp template record)
+// and creates the header data
+func (vAppTemplate *VAppTemplate) getAccessControlHeader(ctx context.Context, useTenantContext bool) (map[string]string, error) {
+	if !useTenantContext {
+		return map[string]string{}, nil
+	}
+	orgInfo, err := vAppTemplate.getOrgInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{types.HeaderTenantContext: orgInfo.OrgId, types.HeaderAuthContext: orgInfo.OrgName}, nil
+}
+
 // getAccessControlHeader builds the data needed to set the header when tenant context is required.
 // If useTenantContext is false, it returns an empty map.
 // Otherwise, it finds the Org ID and name and creates the header data
@@ -376,7 +490,7 @@ func (vdc *Vdc) GetControlAccess(ctx context.Context, useTenantContext bool) (*t
 	if useTenantContext {
 		tenantContext, err := vdc.getTenantContext()
 		if err != nil {
-			return nil, fmt.Errorf("error getting the tenant context - %s", err)
+			return nil, fmt.Errorf("error getting the tenant context - %w", err)
 		}
 
 		tenantContextHeaders = getTenantContextHeader(tenantContext)
@@ -384,7 +498,7 @@ func (vdc *Vdc) GetControlAccess(ctx context.Context, useTenantContext bool) (*t
 
 	controlAccessParams, err := vdc.client.GetAccessControl(ctx, vdc.Vdc.HREF, "vdc", vdc.Vdc.Name, tenantContextHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("there was an error when retrieving VDC control access params - %s", err)
+		return nil, fmt.Errorf("there was an error when retrieving VDC control access params - %w", err)
 	}
 
 	return controlAccessParams, nil
@@ -429,7 +543,7 @@ func (vdc *Vdc) SetControlAccess(ctx context.Context, isSharedToEveryOne bool, e
 	if useTenantContext {
 		tenantContext, err := vdc.getTenantContext()
 		if err != nil {
-			return nil, fmt.Errorf("error getting the tenant context - %s", err)
+			return nil, fmt.Errorf("error getting the tenant context - %w", err)
 		}
 
 		tenantContextHeaders = getTenantContextHeader(tenantContext)
@@ -437,7 +551,7 @@ func (vdc *Vdc) SetControlAccess(ctx context.Context, isSharedToEveryOne bool, e
 
 	err = vdc.client.setAccessControlWithHttpMethod(ctx, http.MethodPut, accessControl, vdc.Vdc.HREF, "vdc", vdc.Vdc.Name, tenantContextHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("there was an error when setting VDC control access params - %s", err)
+		return nil, fmt.Errorf("there was an error when setting VDC control access params - %w", err)
 	}
 
 	return vdc.GetControlAccess(ctx, useTenantContext)
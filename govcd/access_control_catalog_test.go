@@ -368,3 +368,68 @@ func (vcd *TestVCD) testCatalogAccessControl(adminOrg *AdminOrg, catalog accessC
 	checkEmpty()
 
 }
+
+// GetId completes the implementation of interface accessControlType
+func (vAppTemplate VAppTemplate) GetId() string {
+	return vAppTemplate.VAppTemplate.ID
+}
+
+// GetId completes the implementation of interface accessControlType
+func (catalogItem CatalogItem) GetId() string {
+	return catalogItem.CatalogItem.ID
+}
+
+func (vcd *TestVCD) Test_CatalogItemAccessControl(check *C) {
+	if vcd.config.VCD.Org == "" || vcd.config.VCD.Catalog.Name == "" || vcd.config.VCD.Catalog.CatalogItem == "" {
+		check.Skip("Test_CatalogItemAccessControl: Org, Catalog or Catalog item not given.")
+		return
+	}
+	vcd.checkSkipWhenApiToken(check)
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	catalog, err := adminOrg.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+	catalogItem, err := catalog.GetCatalogItemByName(ctx, vcd.config.VCD.Catalog.CatalogItem, false)
+	check.Assert(err, IsNil)
+	vAppTemplate, err := catalogItem.GetVAppTemplate(ctx)
+	check.Assert(err, IsNil)
+
+	userName := "ac-item-user1"
+	user, err := adminOrg.CreateUserSimple(ctx, OrgUserConfiguration{
+		Name: userName, Password: userName, RoleName: OrgUserRoleVappAuthor, IsEnabled: true,
+	})
+	check.Assert(err, IsNil)
+	AddToCleanupList(userName, "user", vcd.config.VCD.Org, check.TestName())
+	defer func() {
+		err = user.Delete(ctx, false)
+		check.Assert(err, IsNil)
+	}()
+
+	oneUserSettings := types.ControlAccessParams{
+		IsSharedToEveryone: false,
+		AccessSettings: &types.AccessSettingList{
+			AccessSetting: []*types.AccessSetting{
+				{
+					Subject: &types.LocalSubject{
+						HREF: user.User.Href,
+						Name: user.User.Name,
+						Type: user.User.Type,
+					},
+					AccessLevel: types.ControlAccessReadOnly,
+				},
+			},
+		},
+	}
+
+	for _, accessible := range []accessControlType{vAppTemplate, *catalogItem} {
+		err = testAccessControl(ctx, vcd.config.VCD.Catalog.CatalogItem+" one user", accessible, oneUserSettings, oneUserSettings, true, catalogTenantContext, check)
+		check.Assert(err, IsNil)
+
+		err = accessible.RemoveAccessControl(ctx, catalogTenantContext)
+		check.Assert(err, IsNil)
+		shared, err := accessible.IsShared(ctx, catalogTenantContext)
+		check.Assert(err, IsNil)
+		check.Assert(shared, Equals, false)
+	}
+}
@@ -50,6 +50,13 @@ func (adminCatalog *AdminCatalog) Delete(ctx context.Context, force, recursive b
 	return catalog.Delete(ctx, force, recursive)
 }
 
+// DeleteWithOptions deletes the Catalog, passing options.Force and options.Recursive to VCD
+func (adminCatalog *AdminCatalog) DeleteWithOptions(ctx context.Context, options DeleteOptions) error {
+	catalog := NewCatalog(adminCatalog.client)
+	catalog.Catalog = &adminCatalog.AdminCatalog.Catalog
+	return catalog.DeleteWithOptions(ctx, options)
+}
+
 // Update updates the Catalog definition from current Catalog struct contents.
 // Any differences that may be legally applied will be updated.
 // Returns an error if the call to vCD fails. Update automatically performs
@@ -84,6 +91,25 @@ func (adminCatalog *AdminCatalog) UploadOvf(ctx context.Context, ovaFileName, it
 	return catalog.UploadOvf(ctx, ovaFileName, itemName, description, uploadPieceSize)
 }
 
+// UploadOvfWithOptions is the same as UploadOvf, with a progress callback, the parallel upload and the retry of
+// failed pieces and the cancellation of the upload through ctx, as defined in options
+func (adminCatalog *AdminCatalog) UploadOvfWithOptions(ctx context.Context, ovaFileName, itemName, description string, options UploadOptions) (UploadTask, error) {
+	catalog := NewCatalog(adminCatalog.client)
+	catalog.parent = adminCatalog.parent
+	catalog.Catalog = &adminCatalog.AdminCatalog.Catalog
+	return catalog.UploadOvfWithOptions(ctx, ovaFileName, itemName, description, options)
+}
+
+// UploadOvfByLink uploads an OVF file to the catalog from a remote HTTP or HTTPS URL. VCD retrieves the OVF
+// descriptor and the files it references directly, without transferring them through the client.
+// On upload fail client may need to remove VCD catalog item which is in failed state.
+func (adminCatalog *AdminCatalog) UploadOvfByLink(ctx context.Context, ovfUrl, itemName, description string) (Task, error) {
+	catalog := NewCatalog(adminCatalog.client)
+	catalog.parent = adminCatalog.parent
+	catalog.Catalog = &adminCatalog.AdminCatalog.Catalog
+	return catalog.UploadOvfByLink(ctx, ovfUrl, itemName, description)
+}
+
 // Refresh fetches a fresh copy of the Admin Catalog
 func (adminCatalog *AdminCatalog) Refresh(ctx context.Context) error {
 	if *adminCatalog == (AdminCatalog{}) || adminCatalog.AdminCatalog.HREF == "" {
@@ -120,7 +146,7 @@ func (cat *AdminCatalog) PublishToExternalOrganizations(ctx context.Context, pub
 
 	tenantContext, err := cat.getTenantContext()
 	if err != nil {
-		return fmt.Errorf("cannot publish to external organization, tenant context error: %s", err)
+		return fmt.Errorf("cannot publish to external organization, tenant context error: %w", err)
 	}
 
 	err = publishToExternalOrganizations(ctx, cat.client, url, tenantContext, publishExternalCatalog)
@@ -186,7 +212,7 @@ func (org *AdminOrg) CreateCatalogFromSubscriptionAsync(ctx context.Context, sub
 	// Before returning, check that there are no failing tasks
 	err = adminCatalog.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing subscribed catalog %s: %s", catalogName, err)
+		return nil, fmt.Errorf("error refreshing subscribed catalog %s: %w", catalogName, err)
 	}
 	if adminCatalog.AdminCatalog.Tasks != nil {
 		msg := ""
@@ -330,6 +356,75 @@ func (cat *AdminCatalog) LaunchSync(ctx context.Context) (*Task, error) {
 	return elementLaunchSync(ctx, cat.client, catalogHref, "admin catalog")
 }
 
+// CatalogItemCopyOptions are the optional settings of AdminCatalog.CopyItem
+type CatalogItemCopyOptions struct {
+	// Description of the new catalog item. When empty, the description of the source catalog item is used
+	Description string
+}
+
+// CopyItem copies sourceCatalogItem into this catalog with the name targetName, waits for the task to complete and
+// returns the new catalog item. The source catalog item can belong to a catalog of another Org, when that catalog
+// is shared or published to the Org of this catalog.
+// The copy is performed by VCD, without downloading and uploading the vApp template or media item again.
+func (adminCatalog *AdminCatalog) CopyItem(ctx context.Context, sourceCatalogItem *CatalogItem, targetName string, options CatalogItemCopyOptions) (*CatalogItem, error) {
+	if sourceCatalogItem == nil || sourceCatalogItem.CatalogItem == nil || sourceCatalogItem.CatalogItem.HREF == "" {
+		return nil, fmt.Errorf("source catalog item cannot be empty")
+	}
+	if targetName == "" {
+		return nil, fmt.Errorf("name of the new catalog item cannot be empty")
+	}
+	if findCatalogItemReference(adminCatalog.AdminCatalog.CatalogItems, targetName) != nil {
+		return nil, fmt.Errorf("catalog item '%s' already exists in catalog '%s'", targetName, adminCatalog.AdminCatalog.Name)
+	}
+
+	catalogHref, err := adminCatalog.GetCatalogHref()
+	if err != nil {
+		return nil, err
+	}
+	description := options.Description
+	if description == "" {
+		description = sourceCatalogItem.CatalogItem.Description
+	}
+
+	task, err := adminCatalog.client.ExecuteTaskRequest(ctx, catalogHref+"/action/copy", http.MethodPost,
+		types.MimeCopyOrMoveCatalogItemParams, "error copying catalog item: %s", &types.CopyOrMoveCatalogItemParams{
+			Xmlns:       types.XMLNamespaceVCloud,
+			Name:        targetName,
+			Description: description,
+			Source:      &types.Reference{HREF: sourceCatalogItem.CatalogItem.HREF},
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error copying catalog item '%s' to '%s': %w", sourceCatalogItem.CatalogItem.Name, targetName, err)
+	}
+
+	err = adminCatalog.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reference := findCatalogItemReference(adminCatalog.AdminCatalog.CatalogItems, targetName)
+	if reference == nil {
+		return nil, fmt.Errorf("%s: catalog item '%s' not found in catalog '%s' after copy", ErrorEntityNotFound,
+			targetName, adminCatalog.AdminCatalog.Name)
+	}
+	return adminCatalog.GetCatalogItemByHref(ctx, reference.HREF)
+}
+
+// findCatalogItemReference returns the reference to the catalog item with the given name, or nil if not found
+func findCatalogItemReference(catalogItems []*types.CatalogItems, name string) *types.Reference {
+	for _, items := range catalogItems {
+		for _, item := range items.CatalogItem {
+			if item.Name == name && item.Type == types.MimeCatalogItem {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
 // GetCatalogHref retrieves the regular catalog HREF from an admin catalog
 func (cat *AdminCatalog) GetCatalogHref() (string, error) {
 	href := ""
@@ -350,11 +445,23 @@ func (catalog *AdminCatalog) QueryVappTemplateList(ctx context.Context) ([]*type
 	return queryVappTemplateListWithFilter(ctx, catalog.client, map[string]string{"catalogName": catalog.AdminCatalog.Name})
 }
 
+// QueryVappTemplateListWithMetadata returns a list of vApp templates for the given catalog, filtered server side
+// by the metadata filters of options and with the requested metadata fields populated in each record
+func (catalog *AdminCatalog) QueryVappTemplateListWithMetadata(ctx context.Context, options QueryMetadataOptions) ([]*types.QueryResultVappTemplateType, error) {
+	return queryVappTemplateListWithMetadata(ctx, catalog.client, map[string]string{"catalogName": catalog.AdminCatalog.Name}, options)
+}
+
 // QueryMediaList retrieves a list of media items for the Admin Catalog
 func (catalog *AdminCatalog) QueryMediaList(ctx context.Context) ([]*types.MediaRecordType, error) {
 	return queryMediaList(ctx, catalog.client, catalog.AdminCatalog.HREF)
 }
 
+// QueryMediaListWithMetadata retrieves a list of media items for the Admin Catalog, filtered server side by the
+// metadata filters of options and with the requested metadata fields populated in each record
+func (catalog *AdminCatalog) QueryMediaListWithMetadata(ctx context.Context, options QueryMetadataOptions) ([]*types.MediaRecordType, error) {
+	return queryMediaListWithMetadata(ctx, catalog.client, "catalog=="+url.QueryEscape(catalog.AdminCatalog.HREF), options)
+}
+
 // LaunchSynchronisationVappTemplates starts synchronisation of a list of vApp templates
 func (cat *AdminCatalog) LaunchSynchronisationVappTemplates(ctx context.Context, nameList []string) ([]*Task, error) {
 	return launchSynchronisationVappTemplates(ctx, cat, nameList, true)
@@ -398,7 +505,7 @@ func launchSynchronisationVappTemplates(ctx context.Context, cat *AdminCatalog,
 		} else {
 			queryResultCatalogItem, err = cat.QueryCatalogItem(ctx, element)
 			if err != nil {
-				return nil, fmt.Errorf("error retrieving catalog item %s: %s", element, err)
+				return nil, fmt.Errorf("error retrieving catalog item %s: %w", element, err)
 			}
 		}
 		task, err := queryResultCatalogItemToCatalogItem(cat.client, queryResultCatalogItem).LaunchSync(ctx)
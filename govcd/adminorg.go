@@ -73,7 +73,7 @@ func (adminOrg *AdminOrg) GetAllVDCs(ctx context.Context, refresh bool) ([]*Vdc,
 	for vdcIndex, vdc := range adminOrg.AdminOrg.Vdcs.Vdcs {
 		vdc, err := adminOrg.GetVDCByHref(ctx, vdc.HREF)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving VDC '%s': %s", vdc.Vdc.Name, err)
+			return nil, fmt.Errorf("error retrieving VDC '%s': %w", vdc.Vdc.Name, err)
 		}
 		allVdcs[vdcIndex] = vdc
 
@@ -94,7 +94,7 @@ func (adminOrg *AdminOrg) GetAllStorageProfileReferences(ctx context.Context, re
 
 	allVdcs, err := adminOrg.GetAllVDCs(ctx, refresh)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve storage profile references: %s", err)
+		return nil, fmt.Errorf("could not retrieve storage profile references: %w", err)
 	}
 
 	allStorageProfileReferences := make([]*types.Reference, 0)
@@ -111,7 +111,7 @@ func (adminOrg *AdminOrg) GetAllStorageProfileReferences(ctx context.Context, re
 func (adminOrg *AdminOrg) GetStorageProfileReferenceById(ctx context.Context, id string, refresh bool) (*types.Reference, error) {
 	allStorageProfiles, err := adminOrg.GetAllStorageProfileReferences(ctx, refresh)
 	if err != nil {
-		return nil, fmt.Errorf("error getting all storage profiles: %s", err)
+		return nil, fmt.Errorf("error getting all storage profiles: %w", err)
 	}
 
 	for _, storageProfileReference := range allStorageProfiles {
@@ -131,38 +131,38 @@ func (adminOrg *AdminOrg) Delete(ctx context.Context, force bool, recursive bool
 		//undeploys vapps
 		err := adminOrg.undeployAllVApps(ctx)
 		if err != nil {
-			return fmt.Errorf("error could not undeploy: %s", err)
+			return fmt.Errorf("error could not undeploy: %w", err)
 		}
 		//removes vapps
 		err = adminOrg.removeAllVApps(ctx)
 		if err != nil {
-			return fmt.Errorf("error could not remove vapp: %s", err)
+			return fmt.Errorf("error could not remove vapp: %w", err)
 		}
 		//removes catalogs
 		err = adminOrg.removeCatalogs(ctx)
 		if err != nil {
-			return fmt.Errorf("error could not remove all catalogs: %s", err)
+			return fmt.Errorf("error could not remove all catalogs: %w", err)
 		}
 		//removes networks
 		err = adminOrg.removeAllOrgNetworks(ctx)
 		if err != nil {
-			return fmt.Errorf("error could not remove all networks: %s", err)
+			return fmt.Errorf("error could not remove all networks: %w", err)
 		}
 		//removes org vdcs
 		err = adminOrg.removeAllOrgVDCs(ctx)
 		if err != nil {
-			return fmt.Errorf("error could not remove all vdcs: %s", err)
+			return fmt.Errorf("error could not remove all vdcs: %w", err)
 		}
 	}
 	// Disable org
 	err := adminOrg.Disable(ctx)
 	if err != nil {
-		return fmt.Errorf("error disabling Org %s: %s", adminOrg.AdminOrg.Name, err)
+		return fmt.Errorf("error disabling Org %s: %w", adminOrg.AdminOrg.Name, err)
 	}
 	// Get admin HREF
 	orgHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return fmt.Errorf("error getting AdminOrg HREF %s : %s", adminOrg.AdminOrg.HREF, err)
+		return fmt.Errorf("error getting AdminOrg HREF %s : %w", adminOrg.AdminOrg.HREF, err)
 	}
 	req := adminOrg.client.NewRequest(ctx, map[string]string{
 		"force":     strconv.FormatBool(force),
@@ -170,14 +170,19 @@ func (adminOrg *AdminOrg) Delete(ctx context.Context, force bool, recursive bool
 	}, http.MethodDelete, *orgHREF, nil)
 	resp, err := checkResp(adminOrg.client.Http.Do(req))
 	if err != nil {
-		return fmt.Errorf("error deleting Org %s: %s", adminOrg.AdminOrg.ID, err)
+		return fmt.Errorf("error deleting Org %s: %w", adminOrg.AdminOrg.ID, err)
 	}
 
 	task := NewTask(adminOrg.client)
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return fmt.Errorf("error decoding task response: %s", err)
+		return fmt.Errorf("error decoding task response: %w", err)
 	}
-	return task.WaitTaskCompletion(ctx)
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return err
+	}
+	InvalidateTenantContextCacheForOrg(adminOrg.AdminOrg.ID)
+	return nil
 }
 
 // Disables the org. Returns an error if the call to vCD fails.
@@ -185,7 +190,7 @@ func (adminOrg *AdminOrg) Delete(ctx context.Context, force bool, recursive bool
 func (adminOrg *AdminOrg) Disable(ctx context.Context) error {
 	orgHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return fmt.Errorf("error getting AdminOrg HREF %s : %s", adminOrg.AdminOrg.HREF, err)
+		return fmt.Errorf("error getting AdminOrg HREF %s : %w", adminOrg.AdminOrg.HREF, err)
 	}
 	orgHREF.Path += "/action/disable"
 
@@ -195,6 +200,8 @@ func (adminOrg *AdminOrg) Disable(ctx context.Context) error {
 // Updates the Org definition from current org struct contents.
 // Any differences that may be legally applied will be updated.
 // Returns an error if the call to vCD fails.
+// The cached tenant contexts of the Org are invalidated when waiting for the returned task, or tracking it with
+// Task.Future, finds that it succeeded.
 // API Documentation: https://code.vmware.com/apis/220/vcloud#/doc/doc/operations/PUT-Organization.html
 func (adminOrg *AdminOrg) Update(ctx context.Context) (Task, error) {
 	vcomp := &types.AdminOrg{
@@ -212,9 +219,17 @@ func (adminOrg *AdminOrg) Update(ctx context.Context) (Task, error) {
 		vcomp.OrgSettings.OrgGeneralSettings.UseServerBootSequence = true
 	}
 
-	// Return the task
-	return adminOrg.client.ExecuteTaskRequest(ctx, adminOrg.AdminOrg.HREF, http.MethodPut,
+	task, err := adminOrg.client.ExecuteTaskRequest(ctx, adminOrg.AdminOrg.HREF, http.MethodPut,
 		"application/vnd.vmware.admin.organization+xml", "error updating Org: %s", vcomp)
+	if err != nil {
+		return Task{}, err
+	}
+
+	// The Org name could be changing, making cached tenant contexts stale once the update is complete
+	orgId := adminOrg.AdminOrg.ID
+	invalidate := func() { InvalidateTenantContextCacheForOrg(orgId) }
+	task.onSuccess = &invalidate
+	return task, nil
 }
 
 // Undeploys every vapp within an organization
@@ -226,11 +241,11 @@ func (adminOrg *AdminOrg) undeployAllVApps(ctx context.Context) error {
 		}
 		vdc, err := adminOrg.getVdcByAdminHREF(ctx, adminVdcHREF)
 		if err != nil {
-			return fmt.Errorf("error retrieving vapp with url: %s and with error %s", adminVdcHREF.Path, err)
+			return fmt.Errorf("error retrieving vapp with url: %s and with error %w", adminVdcHREF.Path, err)
 		}
 		err = vdc.undeployAllVdcVApps(ctx)
 		if err != nil {
-			return fmt.Errorf("error deleting vapp: %s", err)
+			return fmt.Errorf("error deleting vapp: %w", err)
 		}
 	}
 	return nil
@@ -245,11 +260,11 @@ func (adminOrg *AdminOrg) removeAllVApps(ctx context.Context) error {
 		}
 		vdc, err := adminOrg.getVdcByAdminHREF(ctx, adminVdcHREF)
 		if err != nil {
-			return fmt.Errorf("error retrieving vapp with url: %s and with error %s", adminVdcHREF.Path, err)
+			return fmt.Errorf("error retrieving vapp with url: %s and with error %w", adminVdcHREF.Path, err)
 		}
 		err = vdc.removeAllVdcVApps(ctx)
 		if err != nil {
-			return fmt.Errorf("error deleting vapp: %s", err)
+			return fmt.Errorf("error deleting vapp: %w", err)
 		}
 	}
 	return nil
@@ -309,7 +324,7 @@ func (adminOrg *AdminOrg) removeAllOrgVDCs(ctx context.Context) error {
 		req := adminOrg.client.NewRequest(ctx, map[string]string{}, http.MethodPost, adminVdcUrl, nil)
 		_, err := checkResp(adminOrg.client.Http.Do(req))
 		if err != nil {
-			return fmt.Errorf("error disabling vdc: %s", err)
+			return fmt.Errorf("error disabling vdc: %w", err)
 		}
 		// Get admin vdc HREF for normal deletion
 		adminVdcUrl.Path = strings.Split(adminVdcUrl.Path, "/action/disable")[0]
@@ -319,18 +334,18 @@ func (adminOrg *AdminOrg) removeAllOrgVDCs(ctx context.Context) error {
 		}, http.MethodDelete, adminVdcUrl, nil)
 		resp, err := checkResp(adminOrg.client.Http.Do(req))
 		if err != nil {
-			return fmt.Errorf("error deleting vdc: %s", err)
+			return fmt.Errorf("error deleting vdc: %w", err)
 		}
 		task := NewTask(adminOrg.client)
 		if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-			return fmt.Errorf("error decoding task response: %s", err)
+			return fmt.Errorf("error decoding task response: %w", err)
 		}
 		if task.Task.Status == "error" {
 			return fmt.Errorf("vdc not properly destroyed")
 		}
 		err = task.WaitTaskCompletion(ctx)
 		if err != nil {
-			return fmt.Errorf("couldn't finish removing vdc %s", err)
+			return fmt.Errorf("couldn't finish removing vdc %w", err)
 		}
 
 	}
@@ -356,7 +371,7 @@ func (adminOrg *AdminOrg) removeAllOrgNetworks(ctx context.Context) error {
 		}
 		err = task.WaitTaskCompletion(ctx)
 		if err != nil {
-			return fmt.Errorf("couldn't finish removing network %s", err)
+			return fmt.Errorf("couldn't finish removing network %w", err)
 		}
 	}
 	return nil
@@ -367,7 +382,7 @@ func (adminOrg *AdminOrg) removeCatalogs(ctx context.Context) error {
 	for _, catalog := range adminOrg.AdminOrg.Catalogs.Catalog {
 		isCatalogFromSameOrg, err := isCatalogFromSameOrg(ctx, adminOrg, catalog.Name)
 		if err != nil {
-			return fmt.Errorf("error deleting catalog: %s", err)
+			return fmt.Errorf("error deleting catalog: %w", err)
 		}
 		if isCatalogFromSameOrg {
 			// Get Catalog HREF
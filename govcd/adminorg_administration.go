@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
@@ -26,12 +27,12 @@ func (adminOrg *AdminOrg) LdapConfigure(ctx context.Context, settings *types.Org
 	_, err := adminOrg.client.ExecuteRequest(ctx, href, http.MethodPut, types.MimeOrgLdapSettings,
 		"error updating LDAP settings: %s", settings, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating LDAP mode for Org name '%s': %s", adminOrg.AdminOrg.Name, err)
+		return nil, fmt.Errorf("error updating LDAP mode for Org name '%s': %w", adminOrg.AdminOrg.Name, err)
 	}
 
 	ldapSettings, err := adminOrg.GetLdapConfiguration(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving LDAP configuration:  %s", err)
+		return nil, fmt.Errorf("error retrieving LDAP configuration:  %w", err)
 	}
 
 	return ldapSettings, nil
@@ -60,3 +61,118 @@ func (adminOrg *AdminOrg) GetLdapConfiguration(ctx context.Context) (*types.OrgL
 
 	return ldapSettings, nil
 }
+
+// SetLdapConfiguration validates the LDAP settings according to their mode and applies them to the Org, returning
+// the settings stored by VCD:
+// * NONE disables LDAP
+// * SYSTEM uses the LDAP server of the System Org, optionally restricted to the OU in CustomUsersOu
+// * CUSTOM uses the LDAP server in CustomOrgLdapSettings, which must include the connection, the search base and
+// the attributes used to import users and groups
+func (adminOrg *AdminOrg) SetLdapConfiguration(ctx context.Context, settings *types.OrgLdapSettingsType) (*types.OrgLdapSettingsType, error) {
+	err := validateLdapSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	return adminOrg.LdapConfigure(ctx, settings)
+}
+
+// ResetLdapConfiguration removes the LDAP configuration of the Org, setting the LDAP mode to NONE
+func (adminOrg *AdminOrg) ResetLdapConfiguration(ctx context.Context) error {
+	return adminOrg.LdapDisable(ctx)
+}
+
+// TestLdapConnection tests the connection from VCD to the custom LDAP server of the Org, using the test connection
+// API. It fails when the Org doesn't use a custom LDAP server
+func (adminOrg *AdminOrg) TestLdapConnection(ctx context.Context) (*types.TestConnectionResult, error) {
+	ldapSettings, err := adminOrg.GetLdapConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ldapSettings.OrgLdapMode != types.LdapModeCustom || ldapSettings.CustomOrgLdapSettings == nil {
+		return nil, fmt.Errorf("Org '%s' doesn't use a custom LDAP server (mode %s)", adminOrg.AdminOrg.Name,
+			ldapSettings.OrgLdapMode)
+	}
+	return adminOrg.client.TestConnection(ctx, ldapTestConnection(ldapSettings.CustomOrgLdapSettings))
+}
+
+// ldapTestConnection returns the parameters to test the connection to a custom LDAP server
+func ldapTestConnection(customSettings *types.CustomOrgLdapSettings) types.TestConnection {
+	return types.TestConnection{
+		Host:    customSettings.HostName,
+		Port:    customSettings.Port,
+		Secure:  takeBoolPointer(customSettings.IsSsl),
+		Timeout: 30,
+	}
+}
+
+// validateLdapSettings checks that the LDAP settings are consistent with their mode
+func validateLdapSettings(settings *types.OrgLdapSettingsType) error {
+	if settings == nil {
+		return fmt.Errorf("LDAP settings are empty")
+	}
+	switch settings.OrgLdapMode {
+	case types.LdapModeNone:
+		if settings.CustomOrgLdapSettings != nil || settings.CustomUsersOu != "" {
+			return fmt.Errorf("LDAP mode %s doesn't accept other settings", types.LdapModeNone)
+		}
+		return nil
+	case types.LdapModeSystem:
+		if settings.CustomOrgLdapSettings != nil {
+			return fmt.Errorf("LDAP mode %s doesn't accept custom LDAP settings", types.LdapModeSystem)
+		}
+		return nil
+	case types.LdapModeCustom:
+		return validateCustomLdapSettings(settings.CustomOrgLdapSettings)
+	default:
+		return fmt.Errorf("invalid LDAP mode '%s': must be one of %s, %s, %s", settings.OrgLdapMode,
+			types.LdapModeNone, types.LdapModeSystem, types.LdapModeCustom)
+	}
+}
+
+// validateCustomLdapSettings checks that custom LDAP settings have the fields required by VCD
+func validateCustomLdapSettings(custom *types.CustomOrgLdapSettings) error {
+	if custom == nil {
+		return fmt.Errorf("LDAP mode %s requires custom LDAP settings", types.LdapModeCustom)
+	}
+	var missing []string
+	if custom.HostName == "" {
+		missing = append(missing, "HostName")
+	}
+	if custom.Port <= 0 {
+		missing = append(missing, "Port")
+	}
+	if custom.SearchBase == "" {
+		missing = append(missing, "SearchBase")
+	}
+	if custom.UserAttributes == nil {
+		missing = append(missing, "UserAttributes")
+	}
+	if custom.GroupAttributes == nil {
+		missing = append(missing, "GroupAttributes")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("custom LDAP settings require %s", strings.Join(missing, ", "))
+	}
+
+	switch custom.ConnectorType {
+	case types.LdapConnectorActiveDirectory, types.LdapConnectorOpenLdap:
+	default:
+		return fmt.Errorf("invalid LDAP connector type '%s': must be one of %s, %s", custom.ConnectorType,
+			types.LdapConnectorActiveDirectory, types.LdapConnectorOpenLdap)
+	}
+	switch custom.AuthenticationMechanism {
+	case types.LdapAuthenticationSimple, types.LdapAuthenticationKerberos, types.LdapAuthenticationMd5,
+		types.LdapAuthenticationNtlm:
+	default:
+		return fmt.Errorf("invalid LDAP authentication mechanism '%s': must be one of %s, %s, %s, %s",
+			custom.AuthenticationMechanism, types.LdapAuthenticationSimple, types.LdapAuthenticationKerberos,
+			types.LdapAuthenticationMd5, types.LdapAuthenticationNtlm)
+	}
+	if custom.IsGroupSearchBaseEnabled && custom.GroupSearchBase == "" {
+		return fmt.Errorf("custom LDAP settings with IsGroupSearchBaseEnabled require GroupSearchBase")
+	}
+	if custom.UseExternalKerberos && custom.AuthenticationMechanism != types.LdapAuthenticationKerberos {
+		return fmt.Errorf("external Kerberos requires the %s authentication mechanism", types.LdapAuthenticationKerberos)
+	}
+	return nil
+}
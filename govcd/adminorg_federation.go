@@ -0,0 +1,175 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// GetFederationSettings retrieves the SAML identity provider settings of the Org
+func (adminOrg *AdminOrg) GetFederationSettings(ctx context.Context) (*types.OrgFederationSettings, error) {
+	util.Logger.Printf("[DEBUG] Reading SAML settings for Org name %s", adminOrg.AdminOrg.Name)
+
+	federationSettings := &types.OrgFederationSettings{}
+	href := adminOrg.AdminOrg.HREF + "/settings/federation"
+	_, err := adminOrg.client.ExecuteRequest(ctx, href, http.MethodGet, types.MimeOrgFederationSettings,
+		"error getting SAML settings: %s", nil, federationSettings)
+	if err != nil {
+		return nil, err
+	}
+	return federationSettings, nil
+}
+
+// SetFederationSettings updates the SAML identity provider settings of the Org, returning the settings stored by VCD.
+// When the settings are enabled, they must contain the metadata of the identity provider
+func (adminOrg *AdminOrg) SetFederationSettings(ctx context.Context, settings *types.OrgFederationSettings) (*types.OrgFederationSettings, error) {
+	util.Logger.Printf("[DEBUG] Configuring SAML for Org name %s", adminOrg.AdminOrg.Name)
+
+	if settings == nil {
+		return nil, fmt.Errorf("SAML settings are empty")
+	}
+	if settings.Enabled {
+		err := validateSamlMetadata(settings.SAMLMetadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+	settings.Xmlns = types.XMLNamespaceVCloud
+
+	href := adminOrg.AdminOrg.HREF + "/settings/federation"
+	_, err := adminOrg.client.ExecuteRequest(ctx, href, http.MethodPut, types.MimeOrgFederationSettings,
+		"error updating SAML settings: %s", settings, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating SAML settings for Org name '%s': %w", adminOrg.AdminOrg.Name, err)
+	}
+
+	return adminOrg.GetFederationSettings(ctx)
+}
+
+// SetSamlMetadata uploads the metadata XML of the SAML identity provider and enables SAML authentication, keeping the
+// other settings (such as the attribute mapping) unchanged
+func (adminOrg *AdminOrg) SetSamlMetadata(ctx context.Context, metadata string) (*types.OrgFederationSettings, error) {
+	err := validateSamlMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := adminOrg.GetFederationSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings.SAMLMetadata = metadata
+	settings.Enabled = true
+	return adminOrg.SetFederationSettings(ctx, settings)
+}
+
+// DisableSaml disables SAML authentication for the Org. The other settings are kept, so that it can be enabled again
+func (adminOrg *AdminOrg) DisableSaml(ctx context.Context) error {
+	settings, err := adminOrg.GetFederationSettings(ctx)
+	if err != nil {
+		return err
+	}
+	settings.Enabled = false
+	_, err = adminOrg.SetFederationSettings(ctx, settings)
+	return err
+}
+
+// GetOidcSettings retrieves the OpenID Connect identity provider settings of the Org
+func (adminOrg *AdminOrg) GetOidcSettings(ctx context.Context) (*types.OrgOAuthSettings, error) {
+	util.Logger.Printf("[DEBUG] Reading OpenID Connect settings for Org name %s", adminOrg.AdminOrg.Name)
+
+	oAuthSettings := &types.OrgOAuthSettings{}
+	href := adminOrg.AdminOrg.HREF + "/settings/oauth"
+	_, err := adminOrg.client.ExecuteRequest(ctx, href, http.MethodGet, types.MimeOrgOAuthSettings,
+		"error getting OpenID Connect settings: %s", nil, oAuthSettings)
+	if err != nil {
+		return nil, err
+	}
+	return oAuthSettings, nil
+}
+
+// SetOidcSettings updates the OpenID Connect identity provider settings of the Org, returning the settings stored by
+// VCD. When the settings are enabled, the client ID and the authorization and token endpoints are required
+func (adminOrg *AdminOrg) SetOidcSettings(ctx context.Context, settings *types.OrgOAuthSettings) (*types.OrgOAuthSettings, error) {
+	util.Logger.Printf("[DEBUG] Configuring OpenID Connect for Org name %s", adminOrg.AdminOrg.Name)
+
+	err := validateOidcSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	settings.Xmlns = types.XMLNamespaceVCloud
+
+	href := adminOrg.AdminOrg.HREF + "/settings/oauth"
+	_, err = adminOrg.client.ExecuteRequest(ctx, href, http.MethodPut, types.MimeOrgOAuthSettings,
+		"error updating OpenID Connect settings: %s", settings, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating OpenID Connect settings for Org name '%s': %s",
+			adminOrg.AdminOrg.Name, err)
+	}
+
+	return adminOrg.GetOidcSettings(ctx)
+}
+
+// DeleteOidcSettings removes the OpenID Connect identity provider settings of the Org, which disables OpenID Connect
+// authentication
+func (adminOrg *AdminOrg) DeleteOidcSettings(ctx context.Context) error {
+	util.Logger.Printf("[DEBUG] Removing OpenID Connect settings for Org name %s", adminOrg.AdminOrg.Name)
+
+	href := adminOrg.AdminOrg.HREF + "/settings/oauth"
+	err := adminOrg.client.ExecuteRequestWithoutResponse(ctx, href, http.MethodDelete, types.MimeOrgOAuthSettings,
+		"error removing OpenID Connect settings: %s", nil)
+	if err != nil {
+		return fmt.Errorf("error removing OpenID Connect settings for Org name '%s': %w", adminOrg.AdminOrg.Name, err)
+	}
+	return nil
+}
+
+// validateSamlMetadata checks that the metadata is an XML document with an EntityDescriptor root element, so that
+// invalid metadata is reported before being sent to VCD
+func validateSamlMetadata(metadata string) error {
+	if strings.TrimSpace(metadata) == "" {
+		return fmt.Errorf("SAML metadata is empty")
+	}
+	entityDescriptor := &types.VcdSamlMetadata{}
+	err := xml.Unmarshal([]byte(metadata), entityDescriptor)
+	if err != nil {
+		return fmt.Errorf("SAML metadata is not an EntityDescriptor XML document: %w", err)
+	}
+	if entityDescriptor.EntityID == "" {
+		return fmt.Errorf("SAML metadata has no entityID")
+	}
+	return nil
+}
+
+// validateOidcSettings checks that enabled OpenID Connect settings have the fields needed to authenticate users
+func validateOidcSettings(settings *types.OrgOAuthSettings) error {
+	if settings == nil {
+		return fmt.Errorf("OpenID Connect settings are empty")
+	}
+	if !settings.Enabled {
+		return nil
+	}
+	var missing []string
+	if settings.ClientId == "" {
+		missing = append(missing, "ClientId")
+	}
+	if settings.UserAuthorizationEndpoint == "" {
+		missing = append(missing, "UserAuthorizationEndpoint")
+	}
+	if settings.AccessTokenEndpoint == "" {
+		missing = append(missing, "AccessTokenEndpoint")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("enabled OpenID Connect settings require %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build user || functional || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+)
+
+// Test_OrgFederationSettings reads the SAML and OpenID Connect settings of the Org. SAML settings are written back
+// unchanged when SAML is disabled, so that the Org configuration is not altered
+func (vcd *TestVCD) Test_OrgFederationSettings(check *C) {
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	check.Assert(adminOrg, NotNil)
+
+	samlSettings, err := adminOrg.GetFederationSettings(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(samlSettings, NotNil)
+	if !samlSettings.Enabled {
+		updatedSettings, err := adminOrg.SetFederationSettings(ctx, samlSettings)
+		check.Assert(err, IsNil)
+		check.Assert(updatedSettings.Enabled, Equals, false)
+		check.Assert(updatedSettings.SamlSPEntityId, Equals, samlSettings.SamlSPEntityId)
+	}
+
+	oidcSettings, err := adminOrg.GetOidcSettings(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(oidcSettings, NotNil)
+	if testVerbose {
+		fmt.Printf("SAML enabled: %t - OpenID Connect enabled: %t\n", samlSettings.Enabled, oidcSettings.Enabled)
+	}
+}
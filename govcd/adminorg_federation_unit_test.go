@@ -0,0 +1,124 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+const testIdpMetadata = `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com/saml"><md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol"/></md:EntityDescriptor>`
+
+func Test_SetSamlMetadata(t *testing.T) {
+	// The server stores the settings it receives, starting with disabled SAML and a group attribute
+	var mutex sync.Mutex
+	stored := `<OrgFederationSettings xmlns="http://www.vmware.com/vcloud/v1.5"><Enabled>false</Enabled><SamlAttributeMapping><GroupAttributeName>groups</GroupAttributeName></SamlAttributeMapping></OrgFederationSettings>`
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if r.URL.Path != "/api/admin/org/org1/settings/federation" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", types.MimeOrgFederationSettings)
+			_, _ = io.WriteString(w, stored)
+		case http.MethodPut:
+			if r.Header.Get("Content-Type") != types.MimeOrgFederationSettings {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			stored = string(body)
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+	adminOrg := NewAdminOrg(client)
+	adminOrg.AdminOrg.HREF = server.URL + "/api/admin/org/org1"
+
+	_, err = adminOrg.SetSamlMetadata(context.Background(), "<NotMetadata/>")
+	if err == nil {
+		t.Fatalf("expected an error for invalid metadata")
+	}
+
+	settings, err := adminOrg.SetSamlMetadata(context.Background(), testIdpMetadata)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !settings.Enabled || settings.SAMLMetadata != testIdpMetadata {
+		t.Errorf("expected enabled settings with the metadata, got %+v", settings)
+	}
+	if settings.SamlAttributeMapping == nil || settings.SamlAttributeMapping.GroupAttributeName != "groups" {
+		t.Errorf("expected the attribute mapping to be kept, got %+v", settings.SamlAttributeMapping)
+	}
+
+	err = adminOrg.DisableSaml(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(stored, "<Enabled>false</Enabled>") {
+		t.Errorf("expected disabled settings to be sent, got %s", stored)
+	}
+}
+
+func Test_OrgOAuthSettingsMarshal(t *testing.T) {
+	settings := &types.OrgOAuthSettings{
+		Xmlns:                     types.XMLNamespaceVCloud,
+		IssuerId:                  "https://idp.example.com",
+		Enabled:                   true,
+		ClientId:                  "vcd",
+		ClientSecret:              "secret",
+		UserAuthorizationEndpoint: "https://idp.example.com/authorize",
+		AccessTokenEndpoint:       "https://idp.example.com/token",
+		Scope:                     []string{"openid", "profile"},
+		OIDCAttributeMapping:      &types.OIDCAttributeMapping{GroupsAttributeName: "groups"},
+	}
+	err := validateOidcSettings(settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	body, err := xml.Marshal(settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `<OrgOAuthSettings xmlns="http://www.vmware.com/vcloud/v1.5"><IssuerId>https://idp.example.com</IssuerId>` +
+		`<Enabled>true</Enabled><ClientId>vcd</ClientId><ClientSecret>secret</ClientSecret>` +
+		`<UserAuthorizationEndpoint>https://idp.example.com/authorize</UserAuthorizationEndpoint>` +
+		`<AccessTokenEndpoint>https://idp.example.com/token</AccessTokenEndpoint><Scope>openid</Scope><Scope>profile</Scope>` +
+		`<OIDCAttributeMapping><GroupsAttributeName>groups</GroupsAttributeName></OIDCAttributeMapping></OrgOAuthSettings>`
+	if string(body) != expected {
+		t.Errorf("unexpected XML:\n%s\nexpected:\n%s", body, expected)
+	}
+
+	settings.AccessTokenEndpoint = ""
+	err = validateOidcSettings(settings)
+	if err == nil || !strings.Contains(err.Error(), "AccessTokenEndpoint") {
+		t.Errorf("expected an error about the missing token endpoint, got %v", err)
+	}
+	settings.Enabled = false
+	err = validateOidcSettings(settings)
+	if err != nil {
+		t.Errorf("disabled settings should not be validated, got %s", err)
+	}
+}
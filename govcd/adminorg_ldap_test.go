@@ -62,6 +62,7 @@ func (vcd *TestVCD) Test_LDAP(check *C) {
 	}()
 
 	// Run tests requiring LDAP from here.
+	vcd.test_LdapConnection(check, adminOrg)
 	vcd.test_GroupCRUD(check)
 	vcd.test_GroupFinderGetGenericEntity(check)
 	vcd.test_GroupUserListIsPopulated(check)
@@ -79,8 +80,8 @@ func configureLdapForOrg(vcd *TestVCD, adminOrg *AdminOrg, ldapHostIp, testName
 			HostName:                ldapHostIp,
 			Port:                    389,
 			SearchBase:              "dc=planetexpress,dc=com",
-			AuthenticationMechanism: "SIMPLE",
-			ConnectorType:           "OPEN_LDAP",
+			AuthenticationMechanism: types.LdapAuthenticationSimple,
+			ConnectorType:           types.LdapConnectorOpenLdap,
 			Username:                "cn=admin,dc=planetexpress,dc=com",
 			Password:                "GoodNewsEveryone",
 			UserAttributes: &types.OrgLdapUserAttributes{
@@ -104,7 +105,7 @@ func configureLdapForOrg(vcd *TestVCD, adminOrg *AdminOrg, ldapHostIp, testName
 		},
 	}
 
-	_, err := adminOrg.LdapConfigure(ctx, ldapSettings)
+	_, err := adminOrg.SetLdapConfiguration(ctx, ldapSettings)
 	if err != nil {
 		return err
 	}
@@ -112,3 +113,17 @@ func configureLdapForOrg(vcd *TestVCD, adminOrg *AdminOrg, ldapHostIp, testName
 	AddToCleanupList("LDAP-configuration", "orgLdapSettings", adminOrg.AdminOrg.Name, testName)
 	return nil
 }
+
+// test_LdapConnection checks the LDAP settings and the connection to the LDAP server configured by Test_LDAP
+func (vcd *TestVCD) test_LdapConnection(check *C, adminOrg *AdminOrg) {
+	ldapSettings, err := adminOrg.GetLdapConfiguration(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(ldapSettings.OrgLdapMode, Equals, types.LdapModeCustom)
+	check.Assert(ldapSettings.CustomOrgLdapSettings, NotNil)
+	check.Assert(ldapSettings.CustomOrgLdapSettings.HostName, Equals, vcd.config.VCD.LdapServer)
+
+	result, err := adminOrg.TestLdapConnection(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(result.TargetProbe, NotNil)
+	check.Assert(result.TargetProbe.CanConnect, Equals, true)
+}
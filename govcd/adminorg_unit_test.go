@@ -126,3 +126,62 @@ func Test_equalIds(t *testing.T) {
 		}
 	}
 }
+
+// Tests that validateLdapSettings accepts only the settings consistent with the LDAP mode
+func Test_validateLdapSettings(t *testing.T) {
+	customSettings := func() *types.CustomOrgLdapSettings {
+		return &types.CustomOrgLdapSettings{
+			HostName:                "ldap.example.com",
+			Port:                    389,
+			SearchBase:              "dc=example,dc=com",
+			AuthenticationMechanism: types.LdapAuthenticationSimple,
+			ConnectorType:           types.LdapConnectorOpenLdap,
+			UserAttributes:          &types.OrgLdapUserAttributes{ObjectClass: "inetOrgPerson"},
+			GroupAttributes:         &types.OrgLdapGroupAttributes{ObjectClass: "group"},
+		}
+	}
+
+	type testData struct {
+		name     string
+		settings *types.OrgLdapSettingsType
+		valid    bool
+	}
+	var testItems = []testData{
+		{"none", &types.OrgLdapSettingsType{OrgLdapMode: types.LdapModeNone}, true},
+		{"none with custom settings", &types.OrgLdapSettingsType{OrgLdapMode: types.LdapModeNone, CustomOrgLdapSettings: customSettings()}, false},
+		{"system with OU", &types.OrgLdapSettingsType{OrgLdapMode: types.LdapModeSystem, CustomUsersOu: "ou=org1"}, true},
+		{"system with custom settings", &types.OrgLdapSettingsType{OrgLdapMode: types.LdapModeSystem, CustomOrgLdapSettings: customSettings()}, false},
+		{"custom", &types.OrgLdapSettingsType{OrgLdapMode: types.LdapModeCustom, CustomOrgLdapSettings: customSettings()}, true},
+		{"custom without settings", &types.OrgLdapSettingsType{OrgLdapMode: types.LdapModeCustom}, false},
+		{"invalid mode", &types.OrgLdapSettingsType{OrgLdapMode: "EXTERNAL"}, false},
+		{"empty", nil, false},
+	}
+
+	invalidCustom := map[string]func(*types.CustomOrgLdapSettings){
+		"custom without host":          func(custom *types.CustomOrgLdapSettings) { custom.HostName = "" },
+		"custom without port":          func(custom *types.CustomOrgLdapSettings) { custom.Port = 0 },
+		"custom without group mapping": func(custom *types.CustomOrgLdapSettings) { custom.GroupAttributes = nil },
+		"custom with invalid connector": func(custom *types.CustomOrgLdapSettings) {
+			custom.ConnectorType = "EDIRECTORY"
+		},
+		"custom without group search base": func(custom *types.CustomOrgLdapSettings) {
+			custom.IsGroupSearchBaseEnabled = true
+		},
+		"custom with external Kerberos": func(custom *types.CustomOrgLdapSettings) {
+			custom.UseExternalKerberos = true
+		},
+	}
+	for name, change := range invalidCustom {
+		custom := customSettings()
+		change(custom)
+		testItems = append(testItems, testData{name,
+			&types.OrgLdapSettingsType{OrgLdapMode: types.LdapModeCustom, CustomOrgLdapSettings: custom}, false})
+	}
+
+	for _, item := range testItems {
+		err := validateLdapSettings(item.settings)
+		if item.valid != (err == nil) {
+			t.Errorf("%s: expected valid %t, got error %v", item.name, item.valid, err)
+		}
+	}
+}
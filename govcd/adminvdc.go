@@ -157,10 +157,15 @@ func (adminOrg *AdminOrg) CreateVdc(ctx context.Context, vdcConfiguration *types
 	}
 
 	vdcConfiguration.Xmlns = types.XMLNamespaceVCloud
+	for _, storageProfile := range vdcConfiguration.VdcStorageProfile {
+		if storageProfile.IopsSettings != nil {
+			storageProfile.IopsSettings.Xmlns = types.XMLNamespaceVCloud
+		}
+	}
 
 	vdcCreateHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error parsing admin org url: %s", err)
+		return Task{}, fmt.Errorf("error parsing admin org url: %w", err)
 	}
 	vdcCreateHREF.Path += "/vdcsparams"
 
@@ -187,7 +192,7 @@ func (adminOrg *AdminOrg) CreateVdcWait(ctx context.Context, vdcDefinition *type
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("couldn't finish creating VDC %s", err)
+		return fmt.Errorf("couldn't finish creating VDC %w", err)
 	}
 	return nil
 }
@@ -339,7 +344,7 @@ func createVdcV97(ctx context.Context, adminOrg *AdminOrg, vdcConfiguration *typ
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't finish creating VDC %s", err)
+		return nil, fmt.Errorf("couldn't finish creating VDC %w", err)
 	}
 
 	vdc, err := adminOrg.GetVDCByName(ctx, vdcConfiguration.Name, true)
@@ -359,10 +364,15 @@ func createVdcAsyncV97(ctx context.Context, adminOrg *AdminOrg, vdcConfiguration
 	}
 
 	vdcConfiguration.Xmlns = types.XMLNamespaceVCloud
+	for _, storageProfile := range vdcConfiguration.VdcStorageProfile {
+		if storageProfile.IopsSettings != nil {
+			storageProfile.IopsSettings.Xmlns = types.XMLNamespaceVCloud
+		}
+	}
 
 	vdcCreateHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error parsing admin org url: %s", err)
+		return Task{}, fmt.Errorf("error parsing admin org url: %w", err)
 	}
 	vdcCreateHREF.Path += "/vdcsparams"
 
@@ -424,7 +434,7 @@ func (vdc *AdminVdc) UpdateStorageProfile(ctx context.Context, storageProfileId
 	_, err := vdc.client.ExecuteRequest(ctx, queryUrl.String(), http.MethodPut,
 		types.MimeStorageProfile, "error updating VDC storage profile: %s", storageProfile, updateAdminVdcStorageProfile)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update VDC storage profile, error: %s", err)
+		return nil, fmt.Errorf("cannot update VDC storage profile, error: %w", err)
 	}
 
 	return updateAdminVdcStorageProfile, err
@@ -449,7 +459,7 @@ func (vdc *AdminVdc) AddStorageProfile(ctx context.Context, storageProfile *type
 	task, err := vdc.client.ExecuteTaskRequest(ctx, href, http.MethodPost,
 		types.MimeUpdateVdcStorageProfiles, "error adding VDC storage profile: %s", &updateStorageProfile)
 	if err != nil {
-		return Task{}, fmt.Errorf("cannot add VDC storage profile, error: %s", err)
+		return Task{}, fmt.Errorf("cannot add VDC storage profile, error: %w", err)
 	}
 
 	return task, nil
@@ -486,7 +496,7 @@ func (vdc *AdminVdc) RemoveStorageProfile(ctx context.Context, storageProfileNam
 
 	vdcStorageProfileDetails, err := vdc.client.GetStorageProfileByHref(ctx, storageProfile.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("cannot retrieve VDC storage profile '%s' details: %s", storageProfileName, err)
+		return Task{}, fmt.Errorf("cannot retrieve VDC storage profile '%s' details: %w", storageProfileName, err)
 	}
 	if vdcStorageProfileDetails.Enabled != nil && *vdcStorageProfileDetails.Enabled {
 		_, err = vdc.UpdateStorageProfile(ctx, extractUuid(storageProfile.HREF), &types.AdminVdcStorageProfile{
@@ -501,7 +511,7 @@ func (vdc *AdminVdc) RemoveStorageProfile(ctx context.Context, storageProfileNam
 		},
 		)
 		if err != nil {
-			return Task{}, fmt.Errorf("cannot disable VDC storage profile '%s': %s", storageProfileName, err)
+			return Task{}, fmt.Errorf("cannot disable VDC storage profile '%s': %w", storageProfileName, err)
 		}
 	}
 
@@ -517,7 +527,7 @@ func (vdc *AdminVdc) RemoveStorageProfile(ctx context.Context, storageProfileNam
 	task, err := vdc.client.ExecuteTaskRequest(ctx, href, http.MethodPost,
 		types.MimeUpdateVdcStorageProfiles, "error removing VDC storage profile: %s", &updateStorageProfile)
 	if err != nil {
-		return Task{}, fmt.Errorf("cannot remove VDC storage profile, error: %s", err)
+		return Task{}, fmt.Errorf("cannot remove VDC storage profile, error: %w", err)
 	}
 
 	return task, nil
@@ -555,7 +565,7 @@ func (vdc *AdminVdc) SetDefaultStorageProfile(ctx context.Context, storageProfil
 
 	vdcStorageProfileDetails, err := vdc.client.GetStorageProfileByHref(ctx, storageProfile.HREF)
 	if err != nil {
-		return fmt.Errorf("cannot retrieve VDC storage profile '%s' details: %s", storageProfileName, err)
+		return fmt.Errorf("cannot retrieve VDC storage profile '%s' details: %w", storageProfileName, err)
 	}
 	_, err = vdc.UpdateStorageProfile(ctx, extractUuid(storageProfile.HREF), &types.AdminVdcStorageProfile{
 		Name:    vdcStorageProfileDetails.Name,
@@ -569,7 +579,7 @@ func (vdc *AdminVdc) SetDefaultStorageProfile(ctx context.Context, storageProfil
 	},
 	)
 	if err != nil {
-		return fmt.Errorf("cannot set VDC default storage profile '%s': %s", storageProfileName, err)
+		return fmt.Errorf("cannot set VDC default storage profile '%s': %w", storageProfileName, err)
 	}
 	return vdc.Refresh(ctx)
 }
@@ -583,7 +593,7 @@ func (adminVdc *AdminVdc) GetDefaultStorageProfileReference(ctx context.Context)
 	for _, sp := range adminVdc.AdminVdc.VdcStorageProfiles.VdcStorageProfile {
 		fullSp, err := adminVdc.client.GetStorageProfileByHref(ctx, sp.HREF)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving storage profile %s for VDC %s: %s", sp.Name, adminVdc.AdminVdc.Name, err)
+			return nil, fmt.Errorf("error retrieving storage profile %s for VDC %s: %w", sp.Name, adminVdc.AdminVdc.Name, err)
 		}
 		if fullSp.Default {
 			if defaultSp != nil {
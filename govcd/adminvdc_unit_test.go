@@ -0,0 +1,89 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_validateVdcStorageProfiles(t *testing.T) {
+	newProfile := func(isDefault bool, enabled bool, iops *types.VdcStorageProfileIopsSettings) *types.VdcStorageProfileConfiguration {
+		return &types.VdcStorageProfileConfiguration{
+			Enabled:                   &enabled,
+			Units:                     "MB",
+			Limit:                     1024,
+			Default:                   isDefault,
+			ProviderVdcStorageProfile: &types.Reference{HREF: "https://vcd.example.com/api/admin/pvdcStorageProfile/1"},
+			IopsSettings:              iops,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		profiles []*types.VdcStorageProfileConfiguration
+		wantErr  bool
+	}{
+		{
+			name:     "SingleDefault",
+			profiles: []*types.VdcStorageProfileConfiguration{newProfile(true, true, nil), newProfile(false, false, nil)},
+		},
+		{
+			name: "ValidIops",
+			profiles: []*types.VdcStorageProfileConfiguration{newProfile(true, true, &types.VdcStorageProfileIopsSettings{
+				Enabled: true, DiskIopsMax: 1000, DiskIopsDefault: 500, StorageProfileIopsLimit: 5000,
+			})},
+		},
+		{
+			name:     "TwoDefaults",
+			profiles: []*types.VdcStorageProfileConfiguration{newProfile(true, true, nil), newProfile(true, true, nil)},
+			wantErr:  true,
+		},
+		{
+			name:     "DisabledDefault",
+			profiles: []*types.VdcStorageProfileConfiguration{newProfile(true, false, nil)},
+			wantErr:  true,
+		},
+		{
+			name: "MissingProviderVdcStorageProfile",
+			profiles: []*types.VdcStorageProfileConfiguration{{
+				Units: "MB",
+			}},
+			wantErr: true,
+		},
+		{
+			name: "DefaultIopsAboveMax",
+			profiles: []*types.VdcStorageProfileConfiguration{newProfile(true, true, &types.VdcStorageProfileIopsSettings{
+				Enabled: true, DiskIopsMax: 500, DiskIopsDefault: 1000,
+			})},
+			wantErr: true,
+		},
+		{
+			name: "MaxIopsAboveLimit",
+			profiles: []*types.VdcStorageProfileConfiguration{newProfile(true, true, &types.VdcStorageProfileIopsSettings{
+				Enabled: true, DiskIopsMax: 6000, StorageProfileIopsLimit: 5000,
+			})},
+			wantErr: true,
+		},
+		{
+			name: "NegativeIops",
+			profiles: []*types.VdcStorageProfileConfiguration{newProfile(true, true, &types.VdcStorageProfileIopsSettings{
+				DiskIopsPerGbMax: -1,
+			})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVdcStorageProfiles(tt.profiles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateVdcStorageProfiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -50,12 +50,49 @@ type Client struct {
 	// ID is used as Relaying Party Trust identifier.
 	CustomAdfsRptId string
 
+	// ServiceAccountTokenFile is the file holding the API token of a Service Account, used for authentication
+	// instead of the user and password. The API token is rotated, and the file updated, at each authentication
+	ServiceAccountTokenFile string
+
 	// UserAgent to send for API queries. Standard format is described as:
 	// "User-Agent: <product> / <product-version> <comment>"
 	UserAgent string
 
+	// SortResultsBy enables client-side sorting of the results returned by OpenAPI GetAll* functions, so that
+	// their order does not change between calls. Valid values are SortResultsByName and SortResultsById. When empty
+	// (default), results are returned in the order provided by the API. Sorting is skipped when the caller
+	// explicitly requests server-side sorting with 'sortAsc' or 'sortDesc' query parameters.
+	SortResultsBy string
+
+	// SerializeObjectOperations enables a process wide lock for each object, so that create, update and delete
+	// operations targeting the same object (e.g. rules of the same Edge Gateway) are performed one at a time within
+	// the program, instead of being rejected by VCD because the object is busy.
+	// The OpenAPI helpers that wait for the task (OpenApiPostItem, OpenApiPutItem, OpenApiDeleteItem and their
+	// variants) hold the lock until the operation is complete. The helpers that return a task, such as
+	// OpenApiPostItemAsync, OpenApiPutItemAsync and the XML API helpers (ExecuteTaskRequest and the like), hold it
+	// only while the request is sent: callers that need to serialize the whole operation wrap both the request and
+	// Task.WaitTaskCompletion with LockObject, passing the context it returns to both.
+	SerializeObjectOperations bool
+
+	// DisableTenantContextCache prevents the SDK from caching the Org name and ID used to build tenant context
+	// headers for vApps and vApp templates. When caching is enabled, the cache is invalidated when an Org is
+	// updated or deleted through the SDK (see also InvalidateTenantContextCacheForOrg).
+	DisableTenantContextCache bool
+
+	// MaxRequestBodySize is the maximum size in bytes of the body of OpenAPI POST and PUT requests, including streamed
+	// ones (see OpenApiStreamBody). Requests with larger bodies fail with ErrorRequestBodyTooLarge. When 0 (default),
+	// there is no limit.
+	MaxRequestBodySize int64
+
 	supportedVersions SupportedVersions // Versions from /api/versions endpoint
 	customHeader      http.Header
+
+	// pinnedCertificates contains the SHA-256 fingerprints of the accepted server certificates (see
+	// WithPinnedCertificate)
+	pinnedCertificates map[string]bool
+
+	// capabilities caches the availability of optional components (see VCDClient.HasNsxt)
+	capabilities *capabilityCache
 }
 
 // AuthorizationHeader header key used by default to set the authorization token.
@@ -67,6 +104,12 @@ const BearerTokenHeader = "X-Vmware-Vcloud-Access-Token"
 
 const ApiTokenHeader = "API-token"
 
+// Values for Client.SortResultsBy
+const (
+	SortResultsByName = "name"
+	SortResultsById   = "id"
+)
+
 // General purpose error to be used whenever an entity is not found from a "GET" request
 // Allows a simpler checking of the call result
 // such as
@@ -256,6 +299,7 @@ func (client *Client) newRequest(ctx context.Context, params map[string]string,
 			}
 		}
 	}
+	setContextHeaders(req)
 
 	setHttpUserAgent(client.UserAgent, req)
 
@@ -345,7 +389,7 @@ func indentJsonBody(body []byte) ([]byte, error) {
 	var prettyJSON bytes.Buffer
 	err := json.Indent(&prettyJSON, body, "", "  ")
 	if err != nil {
-		return nil, fmt.Errorf("error indenting response JSON: %s", err)
+		return nil, fmt.Errorf("error indenting response JSON: %w", err)
 	}
 	body = prettyJSON.Bytes()
 	return body, nil
@@ -362,6 +406,7 @@ func checkResp(resp *http.Response, err error) (*http.Response, error) {
 // checkRespWithErrType allows to specify custom error errType for checkResp unmarshaling
 // the error.
 func checkRespWithErrType(bodyType types.BodyType, resp *http.Response, err, errType error) (*http.Response, error) {
+	collectResponseHeaders(resp)
 	if err != nil {
 		return resp, err
 	}
@@ -403,10 +448,10 @@ func checkRespWithErrType(bodyType types.BodyType, resp *http.Response, err, err
 		http.StatusInternalServerError,          // 500
 		http.StatusServiceUnavailable,           // 503
 		http.StatusGatewayTimeout:               // 504
-		return nil, ParseErr(bodyType, resp, errType)
+		return nil, newResponseAPIError(resp, ParseErr(bodyType, resp, errType))
 	// Unhandled response.
 	default:
-		return nil, fmt.Errorf("unhandled API response, please report this issue, status code: %s", resp.Status)
+		return nil, newResponseAPIError(resp, fmt.Errorf("unhandled API response, please report this issue, status code: %s", resp.Status))
 	}
 }
 
@@ -449,18 +494,18 @@ func (client *Client) executeTaskRequest(ctx context.Context, pathURL, requestTy
 
 	resp, err := executeRequestWithApiVersion(ctx, pathURL, requestType, contentType, payload, client, apiVersion)
 	if err != nil {
-		return Task{}, fmt.Errorf(errorMessage, err)
+		return Task{}, wrapErrorMessage(errorMessage, err)
 	}
 
 	task := NewTask(client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return Task{}, fmt.Errorf(errorMessage, err)
+		return Task{}, wrapErrorMessage(errorMessage, err)
 	}
 
 	// The request was successful
@@ -506,7 +551,7 @@ func (client *Client) executeRequestWithoutResponse(ctx context.Context, pathURL
 
 	resp, err := executeRequestWithApiVersion(ctx, pathURL, requestType, contentType, payload, client, apiVersion)
 	if err != nil {
-		return fmt.Errorf(errorMessage, err)
+		return wrapErrorMessage(errorMessage, err)
 	}
 
 	// log response explicitly because decodeBody() was not triggered
@@ -515,7 +560,7 @@ func (client *Client) executeRequestWithoutResponse(ctx context.Context, pathURL
 	debugShowResponse(resp, []byte("SKIPPED RESPONSE"))
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// The request was successful
@@ -567,16 +612,16 @@ func (client *Client) executeRequest(ctx context.Context, pathURL, requestType,
 
 	resp, err := executeRequestWithApiVersion(ctx, pathURL, requestType, contentType, payload, client, apiVersion)
 	if err != nil {
-		return resp, fmt.Errorf(errorMessage, err)
+		return resp, wrapErrorMessage(errorMessage, err)
 	}
 
 	if err = decodeBody(types.BodyTypeXML, resp, out); err != nil {
-		return resp, fmt.Errorf("error decoding response: %s", err)
+		return resp, fmt.Errorf("error decoding response: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return resp, fmt.Errorf("error closing response body: %s", err)
+		return resp, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// The request was successful
@@ -602,7 +647,7 @@ func (client *Client) ExecuteParamRequestWithCustomError(ctx context.Context, pa
 
 	resp, err := executeRequestCustomErr(ctx, pathURL, params, requestType, contentType, payload, client, errType, client.APIVersion)
 	if err != nil {
-		return &http.Response{}, fmt.Errorf(errorMessage, err)
+		return &http.Response{}, wrapErrorMessage(errorMessage, err)
 	}
 
 	// read from resp.Body io.Reader for debug output if it has body
@@ -610,7 +655,7 @@ func (client *Client) ExecuteParamRequestWithCustomError(ctx context.Context, pa
 	if resp.Body != nil {
 		bodyBytes, err = io.ReadAll(resp.Body)
 		if err != nil {
-			return &http.Response{}, fmt.Errorf("could not read response body: %s", err)
+			return &http.Response{}, fmt.Errorf("could not read response body: %w", err)
 		}
 		// Restore the io.ReadCloser to its original state with no-op closer
 		resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
@@ -631,7 +676,7 @@ func executeRequestWithApiVersion(ctx context.Context, pathURL, requestType, con
 func executeRequestCustomErr(ctx context.Context, pathURL string, params map[string]string, requestType, contentType string, payload interface{}, client *Client, errType error, apiVersion string) (*http.Response, error) {
 	requestURI, err := url.ParseRequestURI(pathURL)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't parse path request URI '%s': %s", pathURL, err)
+		return nil, fmt.Errorf("couldn't parse path request URI '%s': %w", pathURL, err)
 	}
 
 	var req *http.Request
@@ -641,7 +686,7 @@ func executeRequestCustomErr(ctx context.Context, pathURL string, params map[str
 	case payload != nil:
 		marshaledXml, err := xml.MarshalIndent(payload, "  ", "    ")
 		if err != nil {
-			return &http.Response{}, fmt.Errorf("error marshalling xml data %s", err)
+			return &http.Response{}, fmt.Errorf("error marshalling xml data %w", err)
 		}
 		body := bytes.NewBufferString(xml.Header + string(marshaledXml))
 		req = client.NewRequestWithApiVersion(ctx, params, requestType, *requestURI, body, apiVersion)
@@ -656,6 +701,12 @@ func executeRequestCustomErr(ctx context.Context, pathURL string, params map[str
 
 	setHttpUserAgent(client.UserAgent, req)
 
+	unlock, err := client.lockObjectForRequestIfEnabled(ctx, requestType, requestURI.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	resp, err := client.Http.Do(req)
 	if err != nil {
 		return resp, err
@@ -822,7 +873,7 @@ func (client *Client) TestConnection(ctx context.Context, testConnection types.T
 
 	err = client.OpenApiPostItem(ctx, apiVersion, urlRef, nil, testConnection, returnTestConnectionResult, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error performing test connection: %s", err)
+		return nil, fmt.Errorf("error performing test connection: %w", err)
 	}
 
 	return returnTestConnectionResult, nil
@@ -835,9 +886,33 @@ func (client *Client) TestConnectionWithDefaults(ctx context.Context, subscripti
 		return false, fmt.Errorf("TestConnectionWithDefaults needs to be passed a host. i.e. my-host.vmware.com")
 	}
 
-	url, err := url.Parse(subscriptionURL)
+	testConnectionConfig, err := buildTestConnectionFromUrl(subscriptionURL)
+	if err != nil {
+		return false, err
+	}
+
+	testConnectionResult, err := client.TestConnection(ctx, testConnectionConfig)
+	if err != nil {
+		return false, err
+	}
+
+	if !testConnectionResult.TargetProbe.CanConnect {
+		return false, fmt.Errorf("the remote host is not reachable")
+	}
+
+	if !testConnectionResult.TargetProbe.SSLHandshake {
+		return true, fmt.Errorf("unsupported or unrecognized SSL message")
+	}
+
+	return true, nil
+}
+
+// buildTestConnectionFromUrl creates a TestConnection payload with the default values used by VCD UI for the host
+// and port of the given URL. When the URL has no port, it is derived from the scheme.
+func buildTestConnectionFromUrl(rawUrl string) (types.TestConnection, error) {
+	url, err := url.Parse(rawUrl)
 	if err != nil {
-		return false, fmt.Errorf("unable to parse URL - %s", err)
+		return types.TestConnection{}, fmt.Errorf("unable to parse URL - %w", err)
 	}
 
 	// Get port
@@ -845,7 +920,7 @@ func (client *Client) TestConnectionWithDefaults(ctx context.Context, subscripti
 	if v := url.Port(); v != "" {
 		port, err = strconv.Atoi(v)
 		if err != nil {
-			return false, fmt.Errorf("couldn't parse port provided - %s", err)
+			return types.TestConnection{}, fmt.Errorf("couldn't parse port provided - %w", err)
 		}
 	} else {
 		switch url.Scheme {
@@ -856,27 +931,12 @@ func (client *Client) TestConnectionWithDefaults(ctx context.Context, subscripti
 		}
 	}
 
-	testConnectionConfig := types.TestConnection{
+	return types.TestConnection{
 		Host:    url.Hostname(),
 		Port:    port,
 		Secure:  takeBoolPointer(true), // Default value used by VCD UI
 		Timeout: 30,                    // Default value used by VCD UI
-	}
-
-	testConnectionResult, err := client.TestConnection(ctx, testConnectionConfig)
-	if err != nil {
-		return false, err
-	}
-
-	if !testConnectionResult.TargetProbe.CanConnect {
-		return false, fmt.Errorf("the remote host is not reachable")
-	}
-
-	if !testConnectionResult.TargetProbe.SSLHandshake {
-		return true, fmt.Errorf("unsupported or unrecognized SSL message")
-	}
-
-	return true, nil
+	}, nil
 }
 
 // buildUrl uses the Client base URL to create a customised URL
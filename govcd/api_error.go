@@ -0,0 +1,132 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// APIError is an error reported by VCD, either as the error response to a request or as a task that failed.
+// It can be retrieved with errors.As from the errors returned by ExecuteRequest, ExecuteTaskRequest, the other
+// request helpers, the OpenAPI helpers and Task.WaitTaskCompletion, while Error() keeps the original message:
+//
+//	var apiError *APIError
+//	if errors.As(err, &apiError) && apiError.StatusCode == http.StatusForbidden {
+//	   // do what is needed when access is denied
+//	}
+type APIError struct {
+	StatusCode     int    // HTTP status of the error response. 0 for a task that failed
+	Method         string // HTTP method of the request
+	Url            string // URL of the request
+	MajorErrorCode int    // Major error code returned by VCD (usually the HTTP status)
+	MinorErrorCode string // Minor error code returned by VCD, such as ACCESS_TO_RESOURCE_IS_FORBIDDEN
+	Message        string // Message of the error returned by VCD
+	TaskHref       string // HREF of the task that failed, when the error comes from a task
+	Err            error  // Error returned by VCD, such as *types.Error or *types.OpenApiError
+}
+
+func (apiError *APIError) Error() string {
+	return apiError.Err.Error()
+}
+
+// Unwrap returns the error returned by VCD
+func (apiError *APIError) Unwrap() error {
+	return apiError.Err
+}
+
+// HasAPIErrorStatus returns true when err contains an APIError for an error response with the given HTTP status
+func HasAPIErrorStatus(err error, statusCode int) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == statusCode
+}
+
+// newResponseAPIError returns an APIError for the error response resp, whose body was parsed into err
+func newResponseAPIError(resp *http.Response, err error) *APIError {
+	apiError := &APIError{
+		StatusCode: resp.StatusCode,
+		Err:        err,
+	}
+	if resp.Request != nil {
+		apiError.Method = resp.Request.Method
+		apiError.Url = resp.Request.URL.String()
+	}
+
+	var vcdError *types.Error
+	var openApiError *types.OpenApiError
+	switch {
+	case errors.As(err, &vcdError):
+		apiError.MajorErrorCode = vcdError.MajorErrorCode
+		apiError.MinorErrorCode = vcdError.MinorErrorCode
+		apiError.Message = vcdError.Message
+	case errors.As(err, &openApiError):
+		apiError.MajorErrorCode = resp.StatusCode
+		apiError.MinorErrorCode = openApiError.MinorErrorCode
+		apiError.Message = openApiError.Message
+	}
+	return apiError
+}
+
+// newTaskAPIError returns an APIError with the given message for a task that failed
+func newTaskAPIError(task *types.Task, message string) *APIError {
+	apiError := &APIError{
+		TaskHref: task.HREF,
+		Err:      errors.New(message),
+	}
+	if task.Error != nil {
+		apiError.MajorErrorCode = task.Error.MajorErrorCode
+		apiError.MinorErrorCode = task.Error.MinorErrorCode
+		apiError.Message = task.Error.Message
+	}
+	return apiError
+}
+
+// notFoundAPIError is the APIError of a response that VCD returns both for entities that do not exist and for
+// entities that cannot be accessed, such as HTTP 403 for an OpenAPI GET. It matches ErrorEntityNotFound with
+// errors.Is, and unwraps to the APIError
+type notFoundAPIError struct {
+	message  string
+	apiError *APIError
+}
+
+func (notFound *notFoundAPIError) Error() string {
+	return notFound.message
+}
+
+// Unwrap returns the APIError
+func (notFound *notFoundAPIError) Unwrap() error {
+	return notFound.apiError
+}
+
+// Is returns true for ErrorEntityNotFound
+func (notFound *notFoundAPIError) Is(target error) bool {
+	return target == ErrorEntityNotFound
+}
+
+// wrappedError is an error with a formatted message that keeps the error it was formatted with in its chain
+type wrappedError struct {
+	message string
+	err     error
+}
+
+func (wrapped *wrappedError) Error() string {
+	return wrapped.message
+}
+
+// Unwrap returns the error used to format the message
+func (wrapped *wrappedError) Unwrap() error {
+	return wrapped.err
+}
+
+// wrapErrorMessage formats errorMessage with err like fmt.Errorf, but keeps err in the chain of the returned error,
+// so that an APIError can be retrieved with errors.As. errorMessage is a message with a single placeholder, as given
+// to the request helpers. The placeholder receives the text of err, so that verbs such as %#v don't print the
+// internals of the error
+func wrapErrorMessage(errorMessage string, err error) error {
+	return &wrappedError{message: fmt.Sprintf(errorMessage, err.Error()), err: err}
+}
@@ -0,0 +1,178 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_APIError(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/forbidden":
+			w.Header().Set("Content-Type", types.MimeError)
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<Error xmlns="http://www.vmware.com/vcloud/v1.5" majorErrorCode="403" ` +
+				`minorErrorCode="ACCESS_TO_RESOURCE_IS_FORBIDDEN" message="Access denied"/>`))
+		case "/api/missing":
+			w.Header().Set("Content-Type", types.MimeError)
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error xmlns="http://www.vmware.com/vcloud/v1.5" majorErrorCode="404" ` +
+				`minorErrorCode="RESOURCE_NOT_FOUND" message="Not found"/>`))
+		case "/api/unhandled":
+			w.WriteHeader(http.StatusBadGateway)
+		case "/cloudapi/1.0.0/orgVdcNetworks/urn:vcloud:network:denied":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"minorErrorCode":"ACCESS_TO_RESOURCE_IS_FORBIDDEN","message":"Access denied"}`))
+		case "/cloudapi/1.0.0/orgs/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"minorErrorCode":"NOT_FOUND","message":"Org not found"}`))
+		case "/api/vApp/vapp-1/action/powerOn", "/api/task/failed":
+			w.Header().Set("Content-Type", types.MimeTask)
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusAccepted)
+			}
+			_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="error" ` +
+				`href="` + server.URL + `/api/task/failed" name="task" operationName="vappDeploy">` +
+				`<Error majorErrorCode="500" minorErrorCode="INTERNAL_SERVER_ERROR" message="Not enough resources"/></Task>`))
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"36.0", "37.0"})
+	vcdClient.Client.APIVersion = "37.0"
+	client := &vcdClient.Client
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		path           string
+		wantStatus     int
+		wantMinorCode  string
+		wantMessage    string
+		wantErrMessage string
+	}{
+		{name: "forbidden", path: "/api/forbidden", wantStatus: http.StatusForbidden,
+			wantMinorCode: "ACCESS_TO_RESOURCE_IS_FORBIDDEN", wantMessage: "Access denied",
+			wantErrMessage: "error getting item: API Error: 403: Access denied"},
+		{name: "notFound", path: "/api/missing", wantStatus: http.StatusNotFound,
+			wantMinorCode: "RESOURCE_NOT_FOUND", wantMessage: "Not found",
+			wantErrMessage: "error getting item: API Error: 404: Not found"},
+		{name: "unhandled", path: "/api/unhandled", wantStatus: http.StatusBadGateway,
+			wantErrMessage: "error getting item: unhandled API response, please report this issue, status code: 502 Bad Gateway"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ExecuteRequest(ctx, server.URL+tt.path, http.MethodGet, "", "error getting item: %s", nil,
+				&types.OrgList{})
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			if err.Error() != tt.wantErrMessage {
+				t.Errorf("got error message '%s', expected '%s'", err, tt.wantErrMessage)
+			}
+			var apiError *APIError
+			if !errors.As(err, &apiError) {
+				t.Fatalf("error %v does not contain an APIError", err)
+			}
+			if apiError.StatusCode != tt.wantStatus || apiError.MinorErrorCode != tt.wantMinorCode ||
+				apiError.Message != tt.wantMessage || apiError.Method != http.MethodGet || apiError.Url != server.URL+tt.path {
+				t.Errorf("unexpected APIError: %+v", apiError)
+			}
+			if !HasAPIErrorStatus(err, tt.wantStatus) || HasAPIErrorStatus(err, http.StatusTeapot) {
+				t.Errorf("HasAPIErrorStatus does not match status %d", tt.wantStatus)
+			}
+		})
+	}
+
+	// Messages with other verbs report the text of the error, not its structure
+	_, err = client.ExecuteRequest(ctx, server.URL+"/api/forbidden", http.MethodGet, "", "error getting item: %#v", nil,
+		&types.OrgList{})
+	if err == nil || strings.Contains(err.Error(), "APIError") || !strings.Contains(err.Error(), "Access denied") {
+		t.Errorf("expected error with the message of the API error, got %v", err)
+	}
+
+	// OpenAPI errors
+	endpoint, err := client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, "orgs/missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = client.OpenApiGetItem(ctx, "37.0", endpoint, nil, &types.OpenApiOrgVdcNetwork{}, nil)
+	if !HasAPIErrorStatus(err, http.StatusNotFound) {
+		t.Fatalf("got error %v, expected APIError with status 404", err)
+	}
+	var openApiError *types.OpenApiError
+	if !errors.As(err, &openApiError) || openApiError.MinorErrorCode != "NOT_FOUND" {
+		t.Errorf("got error %v, expected OpenAPI error NOT_FOUND", err)
+	}
+
+	// OpenAPI GET returning 403 is reported as not found, and keeps the APIError
+	endpoint, err = client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointOrgVdcNetworks,
+		"urn:vcloud:network:denied")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = client.OpenApiGetItem(ctx, "37.0", endpoint, nil, &types.OpenApiOrgVdcNetwork{}, nil)
+	if !HasAPIErrorStatus(err, http.StatusForbidden) {
+		t.Fatalf("got error %v, expected APIError with status 403", err)
+	}
+	if !errors.Is(err, ErrorEntityNotFound) || !ContainsNotFound(err) {
+		t.Errorf("got error %v, expected it to match %s", err, ErrorEntityNotFound)
+	}
+	expectedMessage := "[ENF] entity not found: ACCESS_TO_RESOURCE_IS_FORBIDDEN - Access denied [body close error: %!s(<nil>)]"
+	if err.Error() != expectedMessage {
+		t.Errorf("got error message '%s', expected '%s'", err, expectedMessage)
+	}
+
+	// The APIError can be retrieved from the errors of the functions calling the request helpers
+	network := &OpenApiOrgVdcNetwork{
+		OpenApiOrgVdcNetwork: &types.OpenApiOrgVdcNetwork{ID: "urn:vcloud:network:denied"},
+		client:               client,
+	}
+	err = network.Delete(ctx)
+	if !HasAPIErrorStatus(err, http.StatusForbidden) {
+		t.Errorf("got error %v, expected APIError with status 403", err)
+	}
+
+	// Failed tasks
+	task, err := client.ExecuteTaskRequest(ctx, server.URL+"/api/vApp/vapp-1/action/powerOn", http.MethodPost, "",
+		"error powering on: %s", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = task.WaitTaskCompletion(ctx)
+	expectedMessage = "task did not complete successfully:  [500:INTERNAL_SERVER_ERROR] - Not enough resources"
+	if err == nil || err.Error() != expectedMessage {
+		t.Fatalf("got error %v, expected '%s'", err, expectedMessage)
+	}
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("error %v does not contain an APIError", err)
+	}
+	if apiError.TaskHref != server.URL+"/api/task/failed" || apiError.StatusCode != 0 ||
+		apiError.MajorErrorCode != 500 || apiError.MinorErrorCode != "INTERNAL_SERVER_ERROR" {
+		t.Errorf("unexpected APIError for failed task: %+v", apiError)
+	}
+}
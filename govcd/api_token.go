@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -16,12 +17,26 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
 )
 
+// apiTokenTypeRefresh is the type of the API tokens in the tokens endpoint
+const apiTokenTypeRefresh = "REFRESH"
+
+// OAuth errors returned by the token operation of the device authorization flow while access is not yet granted
+const (
+	oauthErrorAuthorizationPending = "authorization_pending"
+	oauthErrorSlowDown             = "slow_down"
+)
+
+// errorOAuthAuthorizationPending is wrapped by getOAuthToken when access was not granted yet in the device
+// authorization flow
+var errorOAuthAuthorizationPending = errors.New("authorization pending")
+
 // SetApiToken behaves similarly to SetToken, with the difference that it will
 // return full information about the bearer token, so that the caller can make decisions about token expiration
 func (vcdClient *VCDClient) SetApiToken(ctx context.Context, org, apiToken string) (*types.ApiTokenRefresh, error) {
@@ -36,58 +51,290 @@ func (vcdClient *VCDClient) SetApiToken(ctx context.Context, org, apiToken strin
 	return tokenRefresh, nil
 }
 
+// serviceAccountTokenFileLock serializes the rotations of Service Account API tokens, so that clients of the same
+// process sharing a token file don't use a token already rotated by another one
+var serviceAccountTokenFileLock sync.Mutex
+
 // SetServiceAccountApiToken reads the current Service Account API token,
 // sets the client's bearer token and fetches a new API token for next
 // authentication request using SetApiToken and overwrites the old file.
 func (vcdClient *VCDClient) SetServiceAccountApiToken(ctx context.Context, org, apiTokenFile string) error {
-	if vcdClient.Client.APIVCDMaxVersionIs(ctx, "< 37.0") {
-		version, err := vcdClient.Client.GetVcdFullVersion(ctx)
-		if err == nil {
-			return fmt.Errorf("minimum version for Service Account authentication is 10.4 - Version detected: %s", version.Version)
-		}
-		// If we can't get the VCD version, we return API version info
-		return fmt.Errorf("minimum API version for Service Account authentication is 37.0 - Version detected: %s", vcdClient.Client.APIVersion)
+	err := vcdClient.checkServiceAccountSupport(ctx)
+	if err != nil {
+		return err
 	}
 
+	serviceAccountTokenFileLock.Lock()
+	defer serviceAccountTokenFileLock.Unlock()
+
 	saApiToken := &types.ApiTokenRefresh{}
 	// Read file contents and unmarshal them to saApiToken
-	err := readFileAndUnmarshalJSON(apiTokenFile, saApiToken)
+	err = readFileAndUnmarshalJSON(apiTokenFile, saApiToken)
 	if err != nil {
 		return err
 	}
+	if saApiToken.RefreshToken == "" {
+		return fmt.Errorf("no Service Account API token found in file %s", apiTokenFile)
+	}
 
-	// Get bearer token and update the refresh token for the next authentication request
+	// Get bearer token and update the refresh token for the next authentication request. The previous API token
+	// is not valid anymore, so the new one must be stored before doing anything else
 	saApiToken, err = vcdClient.SetApiToken(ctx, org, saApiToken.RefreshToken)
 	if err != nil {
 		return err
 	}
+	return SaveServiceAccountApiToken(apiTokenFile, saApiToken, vcdClient.Client.UserAgent)
+}
 
-	// leave only the refresh token to not leave any sensitive information
-	saApiToken = &types.ApiTokenRefresh{
-		RefreshToken: saApiToken.RefreshToken,
+// SaveServiceAccountApiToken writes the refresh token of a Service Account API token to apiTokenFile, in the format
+// read by VCDClient.SetServiceAccountApiToken and WithServiceAccountTokenFile. updatedBy identifies the application
+// writing the file. Only the refresh token is stored, so that no other sensitive information is left in the file.
+// The file is replaced atomically, so that the token is not lost if writing is interrupted
+func SaveServiceAccountApiToken(apiTokenFile string, apiToken *types.ApiTokenRefresh, updatedBy string) error {
+	if apiToken == nil || apiToken.RefreshToken == "" {
+		return fmt.Errorf("no Service Account API token to save")
+	}
+	saApiToken := &types.ApiTokenRefresh{
+		RefreshToken: apiToken.RefreshToken,
 		TokenType:    "Service Account",
-		UpdatedBy:    vcdClient.Client.UserAgent,
+		UpdatedBy:    updatedBy,
 		UpdatedOn:    time.Now().Format(time.RFC3339),
 	}
-	err = marshalJSONAndWriteToFile(apiTokenFile, saApiToken, 0600)
+
+	// The temporary file is created in the same directory, as renaming across file systems is not atomic
+	tempFile := apiTokenFile + ".tmp"
+	err := marshalJSONAndWriteToFile(tempFile, saApiToken, 0600)
 	if err != nil {
 		return err
 	}
-
+	err = os.Rename(tempFile, apiTokenFile)
+	if err != nil {
+		_ = os.Remove(tempFile)
+		return fmt.Errorf("error replacing Service Account API token file %s: %w", apiTokenFile, err)
+	}
 	return nil
 }
 
 // GetBearerTokenFromApiToken uses an API token to retrieve a bearer token
 // using the refresh token operation.
 func (vcdClient *VCDClient) GetBearerTokenFromApiToken(ctx context.Context, org, token string) (*types.ApiTokenRefresh, error) {
+	err := vcdClient.checkApiTokenSupport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := bytes.NewBufferString(fmt.Sprintf("grant_type=refresh_token&refresh_token=%s", token))
+	return vcdClient.getOAuthToken(ctx, org, data, "GetBearerTokenFromApiToken")
+}
+
+// CreateApiToken creates an API token named tokenName for the user authenticated with the client, in the given Org
+// ("System" for provider users), so that automation can rotate its own credentials. VCD doesn't allow creating API
+// tokens for other users. The client must be authenticated with a bearer token.
+// The API token is the RefreshToken field of the returned structure. It is only available at creation: the other
+// operations, such as VCDClient.GetApiTokenByName, only return the token metadata
+func (vcdClient *VCDClient) CreateApiToken(ctx context.Context, org, tokenName string) (*types.ApiTokenRefresh, error) {
+	err := vcdClient.checkApiTokenSupport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tokenName == "" {
+		return nil, fmt.Errorf("API token name is empty")
+	}
+	// The token is used as assertion of the grant, which VCD only accepts for bearer tokens
+	if vcdClient.Client.VCDAuthHeader != BearerTokenHeader || vcdClient.Client.VCDToken == "" {
+		return nil, fmt.Errorf("creating an API token requires a client authenticated with a bearer token")
+	}
+
+	apiTokenClient, err := vcdClient.registerOAuthClient(ctx, org, tokenName, &types.ApiTokenParams{ClientName: tokenName})
+	if err != nil {
+		return nil, err
+	}
+
+	data := bytes.NewBufferString(url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"client_id":  {apiTokenClient.ClientID},
+		"assertion":  {vcdClient.Client.VCDToken},
+	}.Encode())
+	apiToken, err := vcdClient.getOAuthToken(ctx, org, data, "CreateApiToken")
+	if err != nil {
+		return nil, fmt.Errorf("error creating API token '%s': %w", tokenName, err)
+	}
+	if apiToken.RefreshToken == "" {
+		return nil, fmt.Errorf("error creating API token '%s': no refresh token was returned", tokenName)
+	}
+	return apiToken, nil
+}
+
+// ApiToken is the metadata of an API token (refresh token) of a VCD user
+type ApiToken struct {
+	ApiToken *types.ApiToken
+	client   *Client
+}
+
+// GetAllApiTokens retrieves the API tokens visible to the user authenticated with the client, which are the ones of
+// the user itself, or the ones of all users for System administrators.
+// Query parameters can be supplied to perform additional filtering
+func (vcdClient *VCDClient) GetAllApiTokens(ctx context.Context, queryParameters url.Values) ([]*ApiToken, error) {
+	return getAllApiTokens(ctx, &vcdClient.Client, queryParameters)
+}
+
+// GetApiTokenByName retrieves the API token with the given name of the user authenticated with the client
+func (vcdClient *VCDClient) GetApiTokenByName(ctx context.Context, name string) (*ApiToken, error) {
+	sessionInfo, err := vcdClient.Client.GetSessionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving current user: %w", err)
+	}
+
+	slowSearch, queryParams, err := shouldDoSlowSearch(ctx, "name", name, &vcdClient.Client)
+	if err != nil {
+		return nil, err
+	}
+	queryParams = queryParameterFilterAnd("owner.id=="+url.QueryEscape(sessionInfo.User.ID), queryParams)
+	queryParams.Set("filterEncoded", "true")
+	apiTokens, err := vcdClient.GetAllApiTokens(ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	if slowSearch {
+		var foundApiTokens []*ApiToken
+		for _, apiToken := range apiTokens {
+			if apiToken.ApiToken.Name == name {
+				foundApiTokens = append(foundApiTokens, apiToken)
+			}
+		}
+		apiTokens = foundApiTokens
+	}
+	if len(apiTokens) == 0 {
+		return nil, ErrorEntityNotFound
+	}
+	if len(apiTokens) > 1 {
+		return nil, fmt.Errorf("more than one API token found with name '%s'", name)
+	}
+	return apiTokens[0], nil
+}
+
+// GetApiTokenById retrieves an API token by its ID
+func (vcdClient *VCDClient) GetApiTokenById(ctx context.Context, id string) (*ApiToken, error) {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointTokens
+	minimumApiVersion, err := vcdClient.Client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("empty API token id")
+	}
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	apiToken := &ApiToken{
+		ApiToken: &types.ApiToken{},
+		client:   &vcdClient.Client,
+	}
+
+	err = vcdClient.Client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, apiToken.ApiToken, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiToken, nil
+}
+
+// GetAllApiTokens retrieves the API tokens of the user. Unless the user is the one authenticated with the client,
+// it requires System administrator privileges.
+// Query parameters can be supplied to perform additional filtering
+func (user *OrgUser) GetAllApiTokens(ctx context.Context, queryParameters url.Values) ([]*ApiToken, error) {
+	queryParams := queryParameterFilterAnd("owner.id=="+user.User.ID, queryParameters)
+	return getAllApiTokens(ctx, user.client, queryParams)
+}
+
+// Delete revokes the API token, so that it can't be used to authenticate anymore
+func (apiToken *ApiToken) Delete(ctx context.Context) error {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointTokens
+	minimumApiVersion, err := apiToken.client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if apiToken.ApiToken.ID == "" {
+		return fmt.Errorf("cannot revoke API token without id")
+	}
+
+	urlRef, err := apiToken.client.OpenApiBuildEndpoint(endpoint, apiToken.ApiToken.ID)
+	if err != nil {
+		return err
+	}
+
+	err = apiToken.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error revoking API token '%s': %w", apiToken.ApiToken.Name, err)
+	}
+
+	return nil
+}
+
+// getAllApiTokens retrieves the API tokens visible to the client. The tokens endpoint also lists other types of tokens,
+// which are filtered out
+func getAllApiTokens(ctx context.Context, client *Client, queryParameters url.Values) ([]*ApiToken, error) {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointTokens
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := queryParameterFilterAnd("type=="+apiTokenTypeRefresh, queryParameters)
+	typeResponses := []*types.ApiToken{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParams, &typeResponses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	apiTokens := make([]*ApiToken, len(typeResponses))
+	for index := range typeResponses {
+		apiTokens[index] = &ApiToken{
+			ApiToken: typeResponses[index],
+			client:   client,
+		}
+	}
+	return apiTokens, nil
+}
+
+// checkApiTokenSupport returns an error if the VCD doesn't support API tokens
+func (vcdClient *VCDClient) checkApiTokenSupport(ctx context.Context) error {
 	if vcdClient.Client.APIVCDMaxVersionIs(ctx, "< 36.1") {
 		version, err := vcdClient.Client.GetVcdFullVersion(ctx)
 		if err == nil {
-			return nil, fmt.Errorf("minimum version for API token is 10.3.1 - Version detected: %s", version.Version)
+			return fmt.Errorf("minimum version for API token is 10.3.1 - Version detected: %s", version.Version)
 		}
 		// If we can't get the VCD version, we return API version info
-		return nil, fmt.Errorf("minimum API version for API token is 36.1 - Version detected: %s", vcdClient.Client.APIVersion)
+		return fmt.Errorf("minimum API version for API token is 36.1 - Version detected: %s", vcdClient.Client.APIVersion)
 	}
+	return nil
+}
+
+// checkServiceAccountSupport returns an error if the VCD doesn't support Service Accounts
+func (vcdClient *VCDClient) checkServiceAccountSupport(ctx context.Context) error {
+	if vcdClient.Client.APIVCDMaxVersionIs(ctx, "< 37.0") {
+		version, err := vcdClient.Client.GetVcdFullVersion(ctx)
+		if err == nil {
+			return fmt.Errorf("minimum version for Service Account authentication is 10.4 - Version detected: %s", version.Version)
+		}
+		// If we can't get the VCD version, we return API version info
+		return fmt.Errorf("minimum API version for Service Account authentication is 37.0 - Version detected: %s", vcdClient.Client.APIVersion)
+	}
+	return nil
+}
+
+// oauthUrl returns the URL of an OAuth operation (such as "token" or "register") for the users of the given Org
+func (vcdClient *VCDClient) oauthUrl(org, operation string) (*url.URL, error) {
 	var userDef string
 	newUrl := new(url.URL)
 	newUrl.Scheme = vcdClient.Client.VCDHREF.Scheme
@@ -98,15 +345,65 @@ func (vcdClient *VCDClient) GetBearerTokenFromApiToken(ctx context.Context, org,
 	} else {
 		userDef = fmt.Sprintf("tenant/%s", org)
 	}
-	reqUrl := fmt.Sprintf("%s/oauth/%s/token", urlStr, userDef)
+	reqUrl := fmt.Sprintf("%s/oauth/%s/%s", urlStr, userDef, operation)
 	reqHref, err := url.ParseRequestURI(reqUrl)
 	if err != nil {
-		return nil, fmt.Errorf("error getting request URL from %s : %s", reqUrl, err)
+		return nil, fmt.Errorf("error getting request URL from %s : %w", reqUrl, err)
+	}
+	return reqHref, nil
+}
+
+// registerOAuthClient registers the OAuth client of a new API token or Service Account named clientName, described by
+// params, in the given Org
+func (vcdClient *VCDClient) registerOAuthClient(ctx context.Context, org, clientName string, params any) (*types.ApiTokenClientMsg, error) {
+	reqHref, err := vcdClient.oauthUrl(org, "register")
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling OAuth client '%s': %w", clientName, err)
+	}
+	req := vcdClient.Client.NewRequest(ctx, nil, http.MethodPost, *reqHref, bytes.NewReader(payload))
+	req.Header.Set("Accept", "application/json;version=36.1")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := vcdClient.Client.Http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
+	if err != nil {
+		return nil, fmt.Errorf("error registering OAuth client '%s': %w", clientName, err)
+	}
+
+	apiTokenClient := &types.ApiTokenClientMsg{}
+	err = decodeBody(types.BodyTypeJSON, resp, apiTokenClient)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding registered OAuth client '%s': %w", clientName, err)
+	}
+	err = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error closing response body: %w", err)
+	}
+	if apiTokenClient.ClientID == "" {
+		return nil, fmt.Errorf("error registering OAuth client '%s': no client ID was returned", clientName)
+	}
+	return apiTokenClient, nil
+}
+
+// getOAuthToken runs an OAuth token operation for the users of the given Org, with data as form encoded grant,
+// and returns the resulting token. caller is the name of the calling function, used in the logs
+func (vcdClient *VCDClient) getOAuthToken(ctx context.Context, org string, data io.Reader, caller string) (*types.ApiTokenRefresh, error) {
+	reqHref, err := vcdClient.oauthUrl(org, "token")
+	if err != nil {
+		return nil, err
 	}
 
-	data := bytes.NewBufferString(fmt.Sprintf("grant_type=refresh_token&refresh_token=%s", token))
 	req := vcdClient.Client.NewRequest(ctx, nil, http.MethodPost, *reqHref, data)
 	req.Header.Add("Accept", "application/*;version=36.1")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := vcdClient.Client.Http.Do(req)
 	if err != nil {
@@ -125,17 +422,17 @@ func (vcdClient *VCDClient) GetBearerTokenFromApiToken(ctx context.Context, org,
 	if util.LogPasswords {
 		responseData = string(body)
 	}
-	util.ProcessResponseOutput("GetBearerTokenFromApiToken", resp, responseData)
+	util.ProcessResponseOutput(caller, resp, responseData)
 	if len(body) == 0 {
 		return nil, fmt.Errorf("refresh token was empty: %s", resp.Status)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error extracting refresh token: %s", err)
+		return nil, fmt.Errorf("error extracting refresh token: %w", err)
 	}
 
 	err = json.Unmarshal(body, &tokenDef)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding token text: %s", err)
+		return nil, fmt.Errorf("error decoding token text: %w", err)
 	}
 	if tokenDef.AccessToken == "" {
 		// If the access token is empty, the body should contain a composite error message.
@@ -150,6 +447,9 @@ func (vcdClient *VCDClient) GetBearerTokenFromApiToken(ctx context.Context, org,
 				}
 				errorMessage += fmt.Sprintf("%s: %s -  ", k, v)
 			}
+			if errorBody["error"] == oauthErrorAuthorizationPending || errorBody["error"] == oauthErrorSlowDown {
+				return nil, fmt.Errorf("%s: %s: %w", errorMessage, resp.Status, errorOAuthAuthorizationPending)
+			}
 			return nil, fmt.Errorf("%s: %s", errorMessage, resp.Status)
 		}
 
@@ -163,12 +463,12 @@ func (vcdClient *VCDClient) GetBearerTokenFromApiToken(ctx context.Context, org,
 func readFileAndUnmarshalJSON(filename string, object any) error {
 	data, err := os.ReadFile(path.Clean(filename))
 	if err != nil {
-		return fmt.Errorf("failed to read from file: %s", err)
+		return fmt.Errorf("failed to read from file: %w", err)
 	}
 
 	err = json.Unmarshal(data, object)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal file contents to the object: %s", err)
+		return fmt.Errorf("failed to unmarshal file contents to the object: %w", err)
 	}
 
 	return nil
@@ -179,12 +479,12 @@ func readFileAndUnmarshalJSON(filename string, object any) error {
 func marshalJSONAndWriteToFile(filename string, object any, permissions int) error {
 	data, err := json.MarshalIndent(object, " ", " ")
 	if err != nil {
-		return fmt.Errorf("error marshalling object to JSON: %s", err)
+		return fmt.Errorf("error marshalling object to JSON: %w", err)
 	}
 
 	err = os.WriteFile(filename, data, fs.FileMode(permissions))
 	if err != nil {
-		return fmt.Errorf("error writing to the file: %s", err)
+		return fmt.Errorf("error writing to the file: %w", err)
 	}
 
 	return nil
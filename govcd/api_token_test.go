@@ -9,9 +9,12 @@ package govcd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/kr/pretty"
 	. "gopkg.in/check.v1"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
 
 // TestVCDClient_GetBearerTokenFromApiToken tests the token refresh operation
@@ -51,3 +54,101 @@ func (vcd *TestVCD) TestVCDClient_GetBearerTokenFromApiToken(check *C) {
 	check.Assert(tokenInfo.ExpiresIn, Not(Equals), 0)
 	check.Assert(tokenInfo.TokenType, Equals, "Bearer")
 }
+
+// Test_ApiTokenLifecycle creates an API token for the current user, uses it to authenticate a new client and revokes it
+func (vcd *TestVCD) Test_ApiTokenLifecycle(check *C) {
+	// API tokens require VCD 10.3.1
+	skipBelowApiVersion(vcd, check, "36.1")
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	orgName := vcd.config.Provider.SysOrg
+	tokenName := testResourceName(check, "")
+	apiToken, err := vcd.client.CreateApiToken(ctx, orgName, tokenName)
+	check.Assert(err, IsNil)
+	check.Assert(apiToken.RefreshToken, Not(Equals), "")
+
+	token, err := vcd.client.GetApiTokenByName(ctx, tokenName)
+	check.Assert(err, IsNil)
+	check.Assert(token.ApiToken.Name, Equals, tokenName)
+	AddToCleanupListOpenApi(token.ApiToken.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointTokens+token.ApiToken.ID)
+
+	tokenById, err := vcd.client.GetApiTokenById(ctx, token.ApiToken.ID)
+	check.Assert(err, IsNil)
+	check.Assert(tokenById.ApiToken, DeepEquals, token.ApiToken)
+
+	tokenInfo, err := vcd.client.GetBearerTokenFromApiToken(ctx, orgName, apiToken.RefreshToken)
+	check.Assert(err, IsNil)
+	check.Assert(tokenInfo.AccessToken, Not(Equals), "")
+
+	err = token.Delete(ctx)
+	check.Assert(err, IsNil)
+
+	_, err = vcd.client.GetApiTokenByName(ctx, tokenName)
+	check.Assert(ContainsNotFound(err), Equals, true)
+	_, err = vcd.client.GetBearerTokenFromApiToken(ctx, orgName, apiToken.RefreshToken)
+	check.Assert(err, NotNil)
+}
+
+// Test_ServiceAccountLifecycle creates a provider Service Account, completes its device authorization flow and uses
+// its API token to authenticate a new client, which rotates the token at each authentication
+func (vcd *TestVCD) Test_ServiceAccountLifecycle(check *C) {
+	// Service Accounts require VCD 10.4.0
+	skipBelowApiVersion(vcd, check, "37.0")
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	orgName := vcd.config.Provider.SysOrg
+	serviceAccountName := testResourceName(check, "")
+	serviceAccount, err := vcd.client.CreateServiceAccount(ctx, orgName, serviceAccountName, "System Administrator",
+		"12345678-1234-1234-1234-1234567890ab", "1.0", "")
+	check.Assert(err, IsNil)
+	check.Assert(serviceAccount.ServiceAccount.Status, Equals, types.ServiceAccountStatusCreated)
+	AddToCleanupListOpenApi(serviceAccount.ServiceAccount.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointServiceAccounts+serviceAccount.ServiceAccount.ID)
+
+	serviceAccountByName, err := vcd.client.GetServiceAccountByName(ctx, serviceAccountName)
+	check.Assert(err, IsNil)
+	check.Assert(serviceAccountByName.ServiceAccount, DeepEquals, serviceAccount.ServiceAccount)
+
+	deviceAuthorization, err := serviceAccount.Authorize(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(deviceAuthorization.UserCode, Not(Equals), "")
+	check.Assert(serviceAccount.ServiceAccount.Status, Equals, types.ServiceAccountStatusRequested)
+
+	err = serviceAccount.Grant(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(serviceAccount.ServiceAccount.Status, Equals, types.ServiceAccountStatusGranted)
+
+	apiToken, err := serviceAccount.GetInitialApiToken(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(apiToken.RefreshToken, Not(Equals), "")
+
+	tokenFile := filepath.Join(check.MkDir(), "service_account_token.json")
+	err = SaveServiceAccountApiToken(tokenFile, apiToken, check.TestName())
+	check.Assert(err, IsNil)
+
+	saClient := NewVCDClient(vcd.client.Client.VCDHREF, true, WithServiceAccountTokenFile(tokenFile))
+	for i := 0; i < 2; i++ {
+		err = saClient.Authenticate(ctx, "", "", orgName)
+		check.Assert(err, IsNil)
+		storedToken := &types.ApiTokenRefresh{}
+		err = readFileAndUnmarshalJSON(tokenFile, storedToken)
+		check.Assert(err, IsNil)
+		check.Assert(storedToken.RefreshToken, Not(Equals), apiToken.RefreshToken)
+		apiToken = storedToken
+	}
+	sessionInfo, err := saClient.Client.GetSessionInfo(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(sessionInfo.User.Name, Equals, serviceAccountName)
+
+	err = serviceAccount.Revoke(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(serviceAccount.ServiceAccount.Status, Equals, types.ServiceAccountStatusCreated)
+	err = saClient.Authenticate(ctx, "", "", orgName)
+	check.Assert(err, NotNil)
+
+	err = serviceAccount.Delete(ctx)
+	check.Assert(err, IsNil)
+	_, err = vcd.client.GetServiceAccountById(ctx, serviceAccount.ServiceAccount.ID)
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
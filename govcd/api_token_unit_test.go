@@ -7,8 +7,17 @@
 package govcd
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
 
 func Test_readFileAndUnmarshalJSON(t *testing.T) {
@@ -73,3 +82,111 @@ func Test_readFileAndUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func Test_ApiTokenLifecycle(t *testing.T) {
+	bearerToken := strings.Repeat("b", 64)
+	tokenId := "urn:vcloud:token:11111111-1111-1111-1111-111111111111"
+	var requests []string
+	var tokenForm url.Values
+	var tokenFilter string
+	deleted := false
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/oauth/tenant/my-org/register":
+			params := types.ApiTokenParams{}
+			_ = json.NewDecoder(r.Body).Decode(&params)
+			_, _ = fmt.Fprintf(w, `{"client_id":"client-1","client_name":"%s"}`, params.ClientName)
+		case r.Method == http.MethodPost && r.URL.Path == "/oauth/tenant/my-org/token":
+			_ = r.ParseForm()
+			tokenForm = r.PostForm
+			_, _ = w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":3600,"refresh_token":"secret"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin":
+			w.Header().Set("Content-Type", "application/vnd.vmware.admin.vcloud+xml")
+			_, _ = w.Write([]byte(`<VCloud xmlns="http://www.vmware.com/vcloud/v1.5" name="vcd" href="">` +
+				`<Description>10.4.1.21077766 Wed Dec 14 12:56:59 UTC 2022</Description></VCloud>`))
+		case r.Method == http.MethodGet && r.URL.Path == "/cloudapi/1.0.0/sessions/current":
+			_, _ = w.Write([]byte(`{"id":"session-1","user":{"name":"user1","id":"urn:vcloud:user:1"}}`))
+		case r.Method == http.MethodGet && strings.TrimSuffix(r.URL.Path, "/") == "/cloudapi/1.0.0/tokens":
+			tokenFilter = r.URL.Query().Get("filter")
+			if !strings.Contains(tokenFilter, "type==REFRESH") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			// Only the token named 'automation' exists
+			if strings.HasPrefix(tokenFilter, "name==") && !strings.HasPrefix(tokenFilter, "name==automation;") {
+				_, _ = w.Write([]byte(`{"resultTotal":0,"pageCount":0,"page":1,"pageSize":128,"values":[]}`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"resultTotal":1,"pageCount":1,"page":1,"pageSize":128,"values":[`+
+				`{"id":"%s","name":"automation","type":"REFRESH","owner":{"name":"user1","id":"urn:vcloud:user:1"}}]}`, tokenId)
+		case r.Method == http.MethodDelete && r.URL.Path == "/cloudapi/1.0.0/tokens/"+tokenId:
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"36.1", "37.0"})
+	vcdClient.Client.APIVersion = "37.0"
+	ctx := context.Background()
+
+	// The deprecated authorization token can't be used as assertion, whatever its length
+	vcdClient.Client.VCDAuthHeader = AuthorizationHeader
+	vcdClient.Client.VCDToken = bearerToken
+	_, err = vcdClient.CreateApiToken(ctx, "my-org", "automation")
+	if err == nil {
+		t.Fatalf("expected error creating API token without bearer token")
+	}
+
+	vcdClient.Client.VCDAuthHeader = BearerTokenHeader
+	apiToken, err := vcdClient.CreateApiToken(ctx, "my-org", "automation")
+	if err != nil {
+		t.Fatalf("unexpected error creating API token: %s", err)
+	}
+	if apiToken.RefreshToken != "secret" {
+		t.Errorf("got API token '%s', expected 'secret'", apiToken.RefreshToken)
+	}
+	if tokenForm.Get("client_id") != "client-1" || tokenForm.Get("assertion") != bearerToken ||
+		tokenForm.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+		t.Errorf("unexpected token grant: %v", tokenForm)
+	}
+
+	apiTokens, err := vcdClient.GetAllApiTokens(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error listing API tokens: %s", err)
+	}
+	if len(apiTokens) != 1 || apiTokens[0].ApiToken.ID != tokenId || apiTokens[0].ApiToken.Name != "automation" {
+		t.Fatalf("unexpected API tokens: %+v", apiTokens)
+	}
+
+	apiToken2, err := vcdClient.GetApiTokenByName(ctx, "automation")
+	if err != nil || apiToken2.ApiToken.ID != tokenId {
+		t.Fatalf("unexpected result retrieving API token by name: %v", err)
+	}
+	// Names are escaped in the filter, so that they can't add conditions to it
+	_, err = vcdClient.GetApiTokenByName(ctx, "automation;name==other")
+	if !ContainsNotFound(err) {
+		t.Fatalf("expected not found error retrieving API token with a different name, got %v", err)
+	}
+	if !strings.HasPrefix(tokenFilter, "name==automation%3Bname%3D%3Dother;owner.id==") {
+		t.Errorf("unexpected API token filter: %s", tokenFilter)
+	}
+
+	err = apiTokens[0].Delete(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error revoking API token: %s", err)
+	}
+	if !deleted {
+		t.Errorf("API token was not revoked, requests: %v", requests)
+	}
+}
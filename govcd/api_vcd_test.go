@@ -198,6 +198,8 @@ type TestConfig struct {
 			NsxtAlbImportableCloud    string `yaml:"nsxtAlbImportableCloud"`
 			NsxtAlbServiceEngineGroup string `yaml:"nsxtAlbServiceEngineGroup"`
 		} `yaml:"nsxt"`
+		// IsolatedTests makes the tests that support it run in their own Org and VDC
+		IsolatedTests bool `yaml:"isolatedTests,omitempty"`
 	} `yaml:"vcd"`
 	Logging struct {
 		Enabled          bool   `yaml:"enabled,omitempty"`
@@ -238,6 +240,7 @@ type TestVCD struct {
 	config         TestConfig
 	skipVappTests  bool
 	skipAdminTests bool
+	capabilities   testCapabilities
 }
 
 // Cleanup entity structure used by the tear-down procedure
@@ -289,6 +292,8 @@ var connectTenantNum int
 // Makes the name for the cleanup entities persistent file
 // Using a name for each vCD allows us to run tests with different servers
 // and persist the cleanup list for all.
+// When a run prefix is given explicitly, it is also part of the name, so that
+// runs in parallel against the same vCD do not remove each other's entities.
 func makePersistentCleanupFileName() string {
 	var persistentCleanupListMask = "test_cleanup_list-%s.%s"
 	if persistentCleanupIp == "" {
@@ -296,8 +301,11 @@ func makePersistentCleanupFileName() string {
 		os.Exit(1)
 	}
 	reForbiddenChars := regexp.MustCompile(`[/]`)
-	fileName := fmt.Sprintf(persistentCleanupListMask,
-		reForbiddenChars.ReplaceAllString(persistentCleanupIp, ""), "json")
+	fileId := reForbiddenChars.ReplaceAllString(persistentCleanupIp, "")
+	if testRunPrefixExplicit {
+		fileId += "-" + reForbiddenChars.ReplaceAllString(testRunPrefix, "")
+	}
+	fileName := fmt.Sprintf(persistentCleanupListMask, fileId, "json")
 	return fileName
 
 }
@@ -533,6 +541,7 @@ func (vcd *TestVCD) SetUpSuite(check *C) {
 		panic(err)
 	}
 	vcd.config = config
+	setUpTestRun(config)
 
 	// This library sets HTTP User-Agent to be `go-vcloud-director` by default and all HTTP calls
 	// expected to contain this header. An explicit test cannot capture future HTTP requests, but
@@ -605,6 +614,8 @@ func (vcd *TestVCD) SetUpSuite(check *C) {
 	if !vcd.client.Client.IsSysAdmin {
 		vcd.skipAdminTests = true
 	}
+	vcd.capabilities = discoverTestCapabilities(ctx, vcd)
+	fmt.Printf("Capabilities: %s\nRun prefix: %s\n", vcd.capabilities, testRunPrefix)
 
 	// Sets the vCD IP value, removing the elements that would
 	// not be appropriate in a file name
@@ -659,14 +670,14 @@ func (vcd *TestVCD) SetUpSuite(check *C) {
 	if !skipVappCreation && config.VCD.Network.Net1 != "" && config.VCD.StorageProfile.SP1 != "" &&
 		config.VCD.Catalog.Name != "" && config.VCD.Catalog.CatalogItem != "" {
 		// deployVappForTest replaces the old createTestVapp() because it was using bad implemented method vdc.ComposeVApp
-		vcd.vapp, err = deployVappForTest(ctx, vcd, TestSetUpSuite)
+		vcd.vapp, err = deployVappForTest(ctx, vcd, setUpSuiteVappName())
 		// If no vApp is created, we skip all vApp tests
 		if err != nil {
 			fmt.Printf("%s\n", err)
 			panic("Creation failed - Bailing out")
 		}
 		if vcd.vapp == nil {
-			fmt.Printf("Creation of vApp %s failed unexpectedly. No error was reported, but vApp is empty\n", TestSetUpSuite)
+			fmt.Printf("Creation of vApp %s failed unexpectedly. No error was reported, but vApp is empty\n", setUpSuiteVappName())
 			panic("initial vApp is empty - bailing out")
 		}
 	} else {
@@ -1727,6 +1738,34 @@ func (vcd *TestVCD) TestVCDClient_AuthenticateInvalidToken(check *C) {
 	}
 }
 
+func (vcd *TestVCD) Test_CloneWithCredentials(check *C) {
+	if vcd.config.Tenants == nil || len(vcd.config.Tenants) < 1 {
+		check.Skip("no tenants found in configuration")
+	}
+	tenant := vcd.config.Tenants[0]
+	if tenant.User == "" || tenant.Password == "" || tenant.SysOrg == "" {
+		check.Skip("no tenant user found in configuration")
+	}
+
+	tenantClient, err := vcd.client.CloneWithCredentials(ctx, ClientCredentials{
+		Org:      tenant.SysOrg,
+		User:     tenant.User,
+		Password: tenant.Password,
+	})
+	check.Assert(err, IsNil)
+	check.Assert(tenantClient.Client.IsSysAdmin, Equals, false)
+	check.Assert(tenantClient.Client.VCDToken, Not(Equals), vcd.client.Client.VCDToken)
+
+	org, err := tenantClient.GetOrgByName(ctx, tenant.SysOrg)
+	check.Assert(err, IsNil)
+	check.Assert(org.Org.Name, Equals, tenant.SysOrg)
+
+	// The original client keeps its own session
+	check.Assert(vcd.client.Client.IsSysAdmin, Equals, true)
+	_, err = vcd.client.GetOrgByName(ctx, tenant.SysOrg)
+	check.Assert(err, IsNil)
+}
+
 func (vcd *TestVCD) findFirstVm(vapp VApp) (types.Vm, string) {
 	for _, vm := range vapp.VApp.Children.VM {
 		if vm.Name != "" {
@@ -1811,6 +1850,16 @@ func setBoolFlag(varPointer *bool, name, envVar, help string) {
 	flag.BoolVar(varPointer, name, *varPointer, help)
 }
 
+// setStringFlag binds a flag to a string variable (passed as pointer)
+// it also uses an optional environment variable that, if set, will
+// update the variable before binding it to the flag.
+func setStringFlag(varPointer *string, name, envVar, help string) {
+	if envVar != "" && os.Getenv(envVar) != "" {
+		*varPointer = os.Getenv(envVar)
+	}
+	flag.StringVar(varPointer, name, *varPointer, help)
+}
+
 // setTestEnv enables environment variables that are also used in non-test code
 func setTestEnv() {
 	if enableDebug {
@@ -1831,6 +1880,7 @@ func skipWhenMediaPathMissing(vcd *TestVCD, check *C) {
 }
 
 func skipNoNsxtConfiguration(vcd *TestVCD, check *C) {
+	skipWithoutNsxt(vcd, check)
 	generalMessage := "Missing NSX-T config: "
 	if vcd.config.VCD.NsxtProviderVdc.Name == "" {
 		check.Skip(generalMessage + "No provider vdc specified")
@@ -1870,6 +1920,7 @@ func skipNoNsxtConfiguration(vcd *TestVCD, check *C) {
 
 func skipNoNsxtAlbConfiguration(vcd *TestVCD, check *C) {
 	skipNoNsxtConfiguration(vcd, check)
+	skipWithoutAlb(vcd, check)
 	generalMessage := "Missing NSX-T ALB config: "
 
 	if vcd.config.VCD.Nsxt.NsxtAlbControllerUrl == "" {
@@ -0,0 +1,80 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_TlsClientOptions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fingerprint := sha256.Sum256(server.Certificate().Raw)
+	serverFingerprint := hex.EncodeToString(fingerprint[:])
+	var colonFingerprint []string
+	for _, b := range fingerprint {
+		colonFingerprint = append(colonFingerprint, strings.ToUpper(hex.EncodeToString([]byte{b})))
+	}
+	otherFingerprint := strings.Repeat("ab", sha256.Size)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	tests := []struct {
+		name     string
+		insecure bool
+		options  []VCDClientOption
+		wantErr  bool
+	}{
+		{name: "StrictWithoutCA", insecure: true, options: []VCDClientOption{WithStrictTLS()}, wantErr: true},
+		{name: "CACertPool", insecure: true, options: []VCDClientOption{WithCACertPool(pool)}, wantErr: false},
+		{name: "InsecurePinned", insecure: true, options: []VCDClientOption{WithPinnedCertificate(serverFingerprint)}, wantErr: false},
+		{name: "InsecurePinnedWithColons", insecure: true, options: []VCDClientOption{WithPinnedCertificate(strings.Join(colonFingerprint, ":"))}, wantErr: false},
+		{name: "InsecureWrongPin", insecure: true, options: []VCDClientOption{WithPinnedCertificate(otherFingerprint)}, wantErr: true},
+		{name: "AnyOfSeveralPins", insecure: true, options: []VCDClientOption{WithPinnedCertificate(otherFingerprint), WithPinnedCertificate(serverFingerprint)}, wantErr: false},
+		{name: "CACertPoolAndPin", options: []VCDClientOption{WithCACertPool(pool), WithPinnedCertificate(serverFingerprint)}, wantErr: false},
+		{name: "CACertPoolAndWrongPin", options: []VCDClientOption{WithCACertPool(pool), WithPinnedCertificate(otherFingerprint)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vcdClient := NewVCDClient(*serverUrl, tt.insecure, tt.options...)
+			response, err := vcdClient.Client.Http.Get(server.URL)
+			if err == nil {
+				_ = response.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_normalizeCertificateFingerprint(t *testing.T) {
+	valid := strings.Repeat("AB:", sha256.Size-1) + "AB"
+	got, err := normalizeCertificateFingerprint(valid)
+	if err != nil || got != strings.Repeat("ab", sha256.Size) {
+		t.Errorf("unexpected result for %s: %s, %v", valid, got, err)
+	}
+	for _, invalid := range []string{"", "abcd", strings.Repeat("zz", sha256.Size)} {
+		_, err = normalizeCertificateFingerprint(invalid)
+		if err == nil {
+			t.Errorf("expected error for fingerprint '%s'", invalid)
+		}
+	}
+}
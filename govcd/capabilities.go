@@ -0,0 +1,250 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// Optional components of VCD that can be probed with the Has* methods of VCDClient
+const (
+	FeatureNsxt     = "NSX-T"
+	FeatureAlb      = "NSX-T ALB"
+	FeatureIpSpaces = "IP Spaces"
+)
+
+// ipSpacesMinApiVersion is the API version that introduced IP Spaces (VCD 10.4.1)
+const ipSpacesMinApiVersion = "37.1"
+
+// ErrorFeatureNotAvailable is returned when an optional component is not available in VCD or to the current user.
+// It can be checked with errors.Is, so that tools can skip the feature areas that are not supported
+var ErrorFeatureNotAvailable = errors.New("feature not available")
+
+// FeatureNotAvailableError tells which feature is not available and why. It unwraps to ErrorFeatureNotAvailable
+type FeatureNotAvailableError struct {
+	Feature string // The feature or endpoint that is not available, such as FeatureAlb
+	Reason  string
+}
+
+func (featureError *FeatureNotAvailableError) Error() string {
+	return fmt.Sprintf("%s is not available: %s", featureError.Feature, featureError.Reason)
+}
+
+// Unwrap returns ErrorFeatureNotAvailable
+func (featureError *FeatureNotAvailableError) Unwrap() error {
+	return ErrorFeatureNotAvailable
+}
+
+// capabilityCache holds the outcome of the feature probes of a client. A nil error means that the feature is
+// available. Probes that fail for other reasons (e.g. connection errors) are not cached
+type capabilityCache struct {
+	mu      sync.Mutex
+	results map[string]error
+}
+
+// newCapabilityCache creates an empty capability cache
+func newCapabilityCache() *capabilityCache {
+	return &capabilityCache{results: make(map[string]error)}
+}
+
+// capabilityCacheInit protects the lazy creation of the cache of clients not created with NewVCDClient
+var capabilityCacheInit sync.Mutex
+
+// getCapabilityCache returns the capability cache of the client, creating it if needed
+func (client *Client) getCapabilityCache() *capabilityCache {
+	capabilityCacheInit.Lock()
+	defer capabilityCacheInit.Unlock()
+	if client.capabilities == nil {
+		client.capabilities = newCapabilityCache()
+	}
+	return client.capabilities
+}
+
+// HasNsxt checks whether NSX-T is available. For System administrators, at least one NSX-T Manager must be
+// registered. For other users, at least one of the VDCs they can see must be backed by NSX-T.
+// The result is cached in the client (see InvalidateCapabilityCache)
+func (vcdClient *VCDClient) HasNsxt(ctx context.Context) (bool, error) {
+	return vcdClient.Client.hasFeature(ctx, FeatureNsxt)
+}
+
+// HasAlb checks whether the NSX-T Advanced Load Balancer is available. For System administrators, at least one
+// ALB Controller must be registered. For other users, at least one Service Engine Group must be assigned to the Edge
+// Gateways they can see.
+// The result is cached in the client (see InvalidateCapabilityCache)
+func (vcdClient *VCDClient) HasAlb(ctx context.Context) (bool, error) {
+	return vcdClient.Client.hasFeature(ctx, FeatureAlb)
+}
+
+// HasIpSpaces checks whether VCD supports IP Spaces, which were introduced in VCD 10.4.1 (API version 37.1).
+// The result is cached in the client (see InvalidateCapabilityCache)
+func (vcdClient *VCDClient) HasIpSpaces(ctx context.Context) (bool, error) {
+	return vcdClient.Client.hasFeature(ctx, FeatureIpSpaces)
+}
+
+// InvalidateCapabilityCache discards the results of the feature probes, so that they are repeated the next time.
+// It is needed after changing the configuration of VCD outside this client (e.g. registering an NSX-T Manager)
+func (vcdClient *VCDClient) InvalidateCapabilityCache() {
+	vcdClient.Client.invalidateCapability()
+}
+
+// hasFeature returns whether the feature is available, using the cached result if any
+func (client *Client) hasFeature(ctx context.Context, feature string) (bool, error) {
+	err := client.checkFeature(ctx, feature)
+	if errors.Is(err, ErrorFeatureNotAvailable) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkFeature returns nil when the feature is available, a FeatureNotAvailableError when it is not, or the error
+// that prevented the probe
+func (client *Client) checkFeature(ctx context.Context, feature string) error {
+	cache := client.getCapabilityCache()
+	cache.mu.Lock()
+	result, found := cache.results[feature]
+	cache.mu.Unlock()
+	if found {
+		return result
+	}
+
+	// The lock is not held while probing, as probes may depend on other features. Concurrent callers may probe the
+	// same feature more than once, with the same outcome
+	var err error
+	switch feature {
+	case FeatureNsxt:
+		err = client.probeNsxt(ctx)
+	case FeatureAlb:
+		err = client.probeAlb(ctx)
+	case FeatureIpSpaces:
+		err = client.probeIpSpaces(ctx)
+	default:
+		return fmt.Errorf("unknown feature '%s'", feature)
+	}
+	if err == nil || errors.Is(err, ErrorFeatureNotAvailable) {
+		util.Logger.Printf("[DEBUG] checkFeature: caching availability of %s: %v", feature, err)
+		cache.mu.Lock()
+		cache.results[feature] = err
+		cache.mu.Unlock()
+	}
+	return err
+}
+
+// invalidateCapability discards the cached results of the given features, or of all features when none are given
+func (client *Client) invalidateCapability(features ...string) {
+	cache := client.getCapabilityCache()
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if len(features) == 0 {
+		cache.results = make(map[string]error)
+		return
+	}
+	for _, feature := range features {
+		delete(cache.results, feature)
+	}
+}
+
+// featureError returns a FeatureNotAvailableError instead of err when the failure of an operation is explained by the
+// feature not being available. The probe only runs after a failure, so that successful operations are not slowed down
+func (client *Client) featureError(ctx context.Context, feature string, err error) error {
+	if err == nil || errors.Is(err, ErrorFeatureNotAvailable) {
+		return err
+	}
+	if featureErr := client.checkFeature(ctx, feature); errors.Is(featureErr, ErrorFeatureNotAvailable) {
+		return featureErr
+	}
+	return err
+}
+
+// probeNsxt looks for NSX-T Managers (System administrators) or NSX-T backed VDCs (other users)
+func (client *Client) probeNsxt(ctx context.Context) error {
+	if client.IsSysAdmin {
+		results, err := client.QueryWithNotEncodedParams(ctx, nil, map[string]string{
+			"type": "nsxTManager",
+		})
+		if err != nil {
+			return fmt.Errorf("error retrieving NSX-T Managers: %w", err)
+		}
+		if len(results.Results.NsxtManagerRecord) == 0 {
+			return &FeatureNotAvailableError{Feature: FeatureNsxt, Reason: "no NSX-T Manager is registered"}
+		}
+		return nil
+	}
+
+	vdcs, err := queryOrgVdcList(ctx, client, nil)
+	if err != nil {
+		return fmt.Errorf("error retrieving VDCs: %w", err)
+	}
+	for _, vdcRecord := range vdcs {
+		vdc := NewVdc(client)
+		vdc.Vdc.ID = "urn:vcloud:vdc:" + extractUuid(vdcRecord.HREF)
+		capabilities, err := vdc.GetCapabilities(ctx)
+		if err != nil {
+			return fmt.Errorf("error retrieving capabilities of VDC '%s': %w", vdcRecord.Name, err)
+		}
+		if getCapabilityValue(capabilities, "networkProvider") == types.VdcCapabilityNetworkProviderNsxt {
+			return nil
+		}
+	}
+	return &FeatureNotAvailableError{Feature: FeatureNsxt, Reason: "no VDC backed by NSX-T is available"}
+}
+
+// probeAlb looks for ALB Controllers (System administrators) or Service Engine Group assignments (other users)
+func (client *Client) probeAlb(ctx context.Context) error {
+	err := client.checkFeature(ctx, FeatureNsxt)
+	if err != nil {
+		// The NSX-T result is cached separately: ALB is reported as not available for the same reason
+		var featureErr *FeatureNotAvailableError
+		if errors.As(err, &featureErr) {
+			return &FeatureNotAvailableError{Feature: FeatureAlb, Reason: featureErr.Error()}
+		}
+		return err
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroupAssignments
+	reason := "no Service Engine Group is assigned to the available Edge Gateways"
+	if client.IsSysAdmin {
+		endpoint = types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbController
+		reason = "no NSX-T ALB Controller is registered"
+	}
+	apiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Only the number of items is needed
+	var items []map[string]interface{}
+	err = client.OpenApiGetAllItems(ctx, apiVersion, urlRef, nil, &items, nil)
+	if err != nil {
+		return fmt.Errorf("error probing NSX-T ALB: %w", err)
+	}
+	if len(items) == 0 {
+		return &FeatureNotAvailableError{Feature: FeatureAlb, Reason: reason}
+	}
+	return nil
+}
+
+// probeIpSpaces checks the API version supported by VCD
+func (client *Client) probeIpSpaces(ctx context.Context) error {
+	if client.APIVCDMaxVersionIs(ctx, "< "+ipSpacesMinApiVersion) {
+		return &FeatureNotAvailableError{
+			Feature: FeatureIpSpaces,
+			Reason:  fmt.Sprintf("API version %s (VCD 10.4.1) is required", ipSpacesMinApiVersion),
+		}
+	}
+	return nil
+}
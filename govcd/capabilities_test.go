@@ -0,0 +1,45 @@
+//go:build api || functional || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_Capabilities(check *C) {
+	vcd.client.InvalidateCapabilityCache()
+
+	hasNsxt, err := vcd.client.HasNsxt(ctx)
+	check.Assert(err, IsNil)
+	if vcd.client.Client.IsSysAdmin && vcd.config.VCD.Nsxt.Manager != "" {
+		check.Assert(hasNsxt, Equals, true)
+	}
+
+	hasAlb, err := vcd.client.HasAlb(ctx)
+	check.Assert(err, IsNil)
+	if !hasNsxt {
+		check.Assert(hasAlb, Equals, false)
+	}
+
+	hasIpSpaces, err := vcd.client.HasIpSpaces(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(hasIpSpaces, Equals, vcd.client.Client.APIVCDMaxVersionIs(ctx, ">= "+ipSpacesMinApiVersion))
+
+	// Results are cached: the same values are returned
+	hasNsxtCached, err := vcd.client.HasNsxt(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(hasNsxtCached, Equals, hasNsxt)
+
+	if !hasNsxt {
+		_, err = vcd.client.GetAllNsxtEdgeGateways(ctx, nil)
+		if err != nil {
+			check.Assert(errors.Is(err, ErrorFeatureNotAvailable), Equals, true)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_FeatureNotAvailableError(t *testing.T) {
+	err := fmt.Errorf("error retrieving ALB Pools: %w", &FeatureNotAvailableError{Feature: FeatureAlb, Reason: "no NSX-T ALB Controller is registered"})
+	if !errors.Is(err, ErrorFeatureNotAvailable) {
+		t.Errorf("expected error to be ErrorFeatureNotAvailable: %s", err)
+	}
+	var featureErr *FeatureNotAvailableError
+	if !errors.As(err, &featureErr) || featureErr.Feature != FeatureAlb {
+		t.Errorf("expected error to be a FeatureNotAvailableError for %s: %s", FeatureAlb, err)
+	}
+	expected := "error retrieving ALB Pools: NSX-T ALB is not available: no NSX-T ALB Controller is registered"
+	if err.Error() != expected {
+		t.Errorf("expected message '%s', got '%s'", expected, err)
+	}
+}
+
+func Test_capabilityCache(t *testing.T) {
+	// The cache is filled in advance, so that no probe reaches VCD
+	client := &Client{}
+	notAvailable := &FeatureNotAvailableError{Feature: FeatureNsxt, Reason: "no NSX-T Manager is registered"}
+	client.getCapabilityCache().results[FeatureNsxt] = notAvailable
+	client.getCapabilityCache().results[FeatureIpSpaces] = nil
+
+	ctx := context.Background()
+	hasNsxt, err := client.hasFeature(ctx, FeatureNsxt)
+	if err != nil || hasNsxt {
+		t.Errorf("expected NSX-T not to be available, got %t, %v", hasNsxt, err)
+	}
+	hasIpSpaces, err := client.hasFeature(ctx, FeatureIpSpaces)
+	if err != nil || !hasIpSpaces {
+		t.Errorf("expected IP Spaces to be available, got %t, %v", hasIpSpaces, err)
+	}
+	_, err = client.hasFeature(ctx, "unknown")
+	if err == nil {
+		t.Errorf("expected an error for an unknown feature")
+	}
+
+	// featureError replaces errors of unavailable features only
+	operationErr := errors.New("API Error: 404")
+	err = client.featureError(ctx, FeatureNsxt, operationErr)
+	if err != notAvailable {
+		t.Errorf("expected the feature error, got %v", err)
+	}
+	err = client.featureError(ctx, FeatureIpSpaces, operationErr)
+	if err != operationErr {
+		t.Errorf("expected the original error, got %v", err)
+	}
+	if client.featureError(ctx, FeatureNsxt, nil) != nil {
+		t.Errorf("expected no error for a successful operation")
+	}
+
+	client.invalidateCapability(FeatureNsxt)
+	if _, found := client.getCapabilityCache().results[FeatureNsxt]; found {
+		t.Errorf("expected the result of %s to be invalidated", FeatureNsxt)
+	}
+	if _, found := client.getCapabilityCache().results[FeatureIpSpaces]; !found {
+		t.Errorf("expected the result of %s to be kept", FeatureIpSpaces)
+	}
+	client.invalidateCapability()
+	if len(client.getCapabilityCache().results) != 0 {
+		t.Errorf("expected all results to be invalidated")
+	}
+}
@@ -64,18 +64,16 @@ func (catalog *Catalog) Delete(ctx context.Context, force, recursive bool) error
 		}
 	}
 
-	req := catalog.client.NewRequest(ctx, map[string]string{
-		"force":     strconv.FormatBool(force),
-		"recursive": strconv.FormatBool(recursive),
-	}, http.MethodDelete, adminCatalogHREF, nil)
+	req := catalog.client.NewRequest(ctx, DeleteOptions{Force: force, Recursive: recursive}.queryParams(),
+		http.MethodDelete, adminCatalogHREF, nil)
 
 	resp, err := checkResp(catalog.client.Http.Do(req))
 	if err != nil {
-		return fmt.Errorf("error deleting Catalog %s: %s", catalog.Catalog.Name, err)
+		return fmt.Errorf("error deleting Catalog %s: %w", catalog.Catalog.Name, err)
 	}
 	task := NewTask(catalog.client)
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return fmt.Errorf("error decoding task response: %s", err)
+		return fmt.Errorf("error decoding task response: %w", err)
 	}
 	if task.Task.Status == "error" {
 		return fmt.Errorf(combinedTaskErrorMessage(task.Task, fmt.Errorf("catalog %s not properly destroyed", catalog.Catalog.Name)))
@@ -83,6 +81,15 @@ func (catalog *Catalog) Delete(ctx context.Context, force, recursive bool) error
 	return task.WaitTaskCompletion(ctx)
 }
 
+// DeleteWithOptions deletes the Catalog, passing options.Force and options.Recursive to VCD
+func (catalog *Catalog) DeleteWithOptions(ctx context.Context, options DeleteOptions) error {
+	err := options.validate("catalog", true, true)
+	if err != nil {
+		return err
+	}
+	return catalog.Delete(ctx, options.Force, options.Recursive)
+}
+
 // consumeTasks will cancel all catalog tasks and the ones related to its items
 // 1. cancel all tasks associated with the catalog and keep them in a list
 // 2. find a list of all catalog items
@@ -176,6 +183,12 @@ func (cat *Catalog) FindCatalogItem(ctx context.Context, catalogItemName string)
 // remove vCD catalog item which waits for files to be uploaded. Files from ova are extracted to system
 // temp folder "govcd+random number" and left for inspection on error.
 func (cat *Catalog) UploadOvf(ctx context.Context, ovaFileName, itemName, description string, uploadPieceSize int64) (UploadTask, error) {
+	return cat.UploadOvfWithOptions(ctx, ovaFileName, itemName, description, UploadOptions{PieceSize: uploadPieceSize})
+}
+
+// UploadOvfWithOptions is the same as UploadOvf, with a progress callback, the parallel upload and the retry of
+// failed pieces and the cancellation of the upload through ctx, as defined in options
+func (cat *Catalog) UploadOvfWithOptions(ctx context.Context, ovaFileName, itemName, description string, options UploadOptions) (UploadTask, error) {
 
 	//	On a very high level the flow is as follows
 	//	1. Makes a POST call to vCD to create the catalog item (also creates a transfer folder in the spool area and as result will give a sparse catalog item resource XML).
@@ -274,7 +287,7 @@ func (cat *Catalog) UploadOvf(ctx context.Context, ovaFileName, itemName, descri
 		return UploadTask{}, err
 	}
 
-	progressCallBack, uploadProgress := getProgressCallBackFunction()
+	progressCallBack, uploadProgress := getProgressCallBackFunction(options.ProgressFunc)
 
 	uploadError := *new(error)
 
@@ -282,7 +295,8 @@ func (cat *Catalog) UploadOvf(ctx context.Context, ovaFileName, itemName, descri
 	// The error should be captured in uploadError, but just in case, we add a logging for the
 	// main error
 	go func() {
-		err = uploadFiles(ctx, cat.client, vappTemplate, &ovfFileDesc, tmpDir, filesAbsPaths, uploadPieceSize, progressCallBack, &uploadError, isOvf)
+		err := uploadFiles(ctx, cat.client, vappTemplate, &ovfFileDesc, tmpDir, filesAbsPaths, options, progressCallBack, &uploadError, isOvf)
+		cancelImportTasksOnCancellation(ctx, cat.client, vappTemplate.Tasks, err)
 		if err != nil {
 			util.Logger.Println(strings.Repeat("*", 80))
 			util.Logger.Printf("*** [DEBUG - UploadOvf] error calling uploadFiles: %s\n", err)
@@ -319,6 +333,11 @@ func (cat *Catalog) UploadOvfByLink(ctx context.Context, ovfUrl, itemName, descr
 		return Task{}, errors.New("catalog can not be empty or nil")
 	}
 
+	parsedOvfUrl, err := url.ParseRequestURI(ovfUrl)
+	if err != nil || (parsedOvfUrl.Scheme != "http" && parsedOvfUrl.Scheme != "https") {
+		return Task{}, fmt.Errorf("invalid OVF URL '%s': an HTTP or HTTPS URL is required", ovfUrl)
+	}
+
 	for _, catalogItemName := range getExistingCatalogItems(cat) {
 		if catalogItemName == itemName {
 			return Task{}, fmt.Errorf("catalog item '%s' already exists. Upload with different name", itemName)
@@ -367,10 +386,10 @@ func (cat *Catalog) UploadOvfByLink(ctx context.Context, ovfUrl, itemName, descr
 // ovfFileDesc - parsed from xml part containing ova files definition
 // tempPath - path where extracted files are
 // filesAbsPaths - array of extracted files
-// uploadPieceSize - size of chunks in which the file will be uploaded to the catalog.
+// options - piece size and retry settings of the upload
 // callBack a function with signature //function(bytesUpload, totalSize) to let the caller monitor progress of the upload operation.
 // uploadError - error to be ready be task
-func uploadFiles(ctx context.Context, client *Client, vappTemplate *types.VAppTemplate, ovfFileDesc *Envelope, tempPath string, filesAbsPaths []string, uploadPieceSize int64, progressCallBack func(bytesUpload, totalSize int64), uploadError *error, isOvf bool) error {
+func uploadFiles(ctx context.Context, client *Client, vappTemplate *types.VAppTemplate, ovfFileDesc *Envelope, tempPath string, filesAbsPaths []string, options UploadOptions, progressCallBack func(bytesUpload, totalSize int64), uploadError *error, isOvf bool) error {
 	var uploadedBytes int64
 	for _, item := range vappTemplate.Files.File {
 		if item.BytesTransferred == 0 {
@@ -386,11 +405,14 @@ func uploadFiles(ctx context.Context, client *Client, vappTemplate *types.VAppTe
 					uploadLink:               item.Link[0].HREF,
 					uploadedBytes:            uploadedBytes,
 					fileSizeToUpload:         int64(ovfFileDesc.File[number].Size),
-					uploadPieceSize:          uploadPieceSize,
+					uploadPieceSize:          options.PieceSize,
 					uploadedBytesForCallback: uploadedBytes,
 					allFilesSize:             getAllFileSizeSum(ovfFileDesc),
 					callBack:                 progressCallBack,
 					uploadError:              uploadError,
+					maxRetries:               options.MaxRetries,
+					retryDelay:               options.RetryDelay,
+					concurrency:              options.Concurrency,
 				}
 				tempVar, err := uploadMultiPartFile(ctx, client, chunkFilePaths, details)
 				if err != nil {
@@ -404,11 +426,14 @@ func uploadFiles(ctx context.Context, client *Client, vappTemplate *types.VAppTe
 					uploadLink:               item.Link[0].HREF,
 					uploadedBytes:            0,
 					fileSizeToUpload:         item.Size,
-					uploadPieceSize:          uploadPieceSize,
+					uploadPieceSize:          options.PieceSize,
 					uploadedBytesForCallback: uploadedBytes,
 					allFilesSize:             getAllFileSizeSum(ovfFileDesc),
 					callBack:                 progressCallBack,
 					uploadError:              uploadError,
+					maxRetries:               options.MaxRetries,
+					retryDelay:               options.RetryDelay,
+					concurrency:              options.Concurrency,
 				}
 				tempVar, err := uploadFile(ctx, client, findFilePath(filesAbsPaths, item.Name), details)
 				if err != nil {
@@ -480,19 +505,18 @@ func uploadMultiPartFile(ctx context.Context, client *Client, filePaths []string
 // Function waits until vCD provides temporary file upload links.
 func waitForTempUploadLinks(ctx context.Context, client *Client, vappTemplateUrl *url.URL, newItemName string) (*types.VAppTemplate, error) {
 	var vAppTemplate *types.VAppTemplate
-	var err error
-	for {
-		util.Logger.Printf("[TRACE] Sleep... for 5 seconds.\n")
-		time.Sleep(time.Second * 5)
+	err := WaitForState(ctx, func() (bool, error) {
+		var err error
 		vAppTemplate, err = queryVappTemplateAndVerifyTask(ctx, client, vappTemplateUrl, newItemName)
 		if err != nil {
-			return nil, err
-		}
-		if vAppTemplate.Files != nil && len(vAppTemplate.Files.File) > 1 {
-			util.Logger.Printf("[TRACE] upload link prepared.\n")
-			break
+			return false, err
 		}
+		return vAppTemplate.Files != nil && len(vAppTemplate.Files.File) > 1, nil
+	}, WaitOptions{InitialDelay: 5 * time.Second, InitialInterval: 2 * time.Second, MaxInterval: 10 * time.Second})
+	if err != nil {
+		return nil, err
 	}
+	util.Logger.Printf("[TRACE] upload link prepared.\n")
 	return vAppTemplate, nil
 }
 
@@ -653,9 +677,17 @@ func createItemWithLink(ctx context.Context, client *Client, createHREF *url.URL
 	util.Logger.Printf("[TRACE] createItemWithLink: %s, item name: %s, description: %s, vappTemplateRemoteUrl: %s \n",
 		createHREF, catalogItemName, itemDescription, vappTemplateRemoteUrl)
 
-	reqTemplate := `<UploadVAppTemplateParams xmlns="%s" name="%s" sourceHref="%s"><Description>%s</Description></UploadVAppTemplateParams>`
-	reqBody := bytes.NewBufferString(fmt.Sprintf(reqTemplate, types.XMLNamespaceVCloud, catalogItemName, vappTemplateRemoteUrl, itemDescription))
-	request := client.NewRequest(ctx, map[string]string{}, http.MethodPost, *createHREF, reqBody)
+	// The parameters are encoded, as remote URLs often contain characters that must be escaped (e.g. '&')
+	params, err := xml.Marshal(types.UploadVAppTemplateParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        catalogItemName,
+		SourceHref:  vappTemplateRemoteUrl,
+		Description: itemDescription,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding upload parameters: %w", err)
+	}
+	request := client.NewRequest(ctx, map[string]string{}, http.MethodPost, *createHREF, bytes.NewReader(params))
 	request.Header.Add("Content-Type", "application/vnd.vmware.vcloud.uploadVAppTemplateParams+xml")
 
 	response, err := checkResp(client.Http.Do(request))
@@ -828,7 +860,14 @@ func removeCatalogItemOnError(ctx context.Context, client *Client, vappTemplateL
 	}
 }
 
+// UploadMediaImage uploads an ISO file as media item of the catalog
 func (cat *Catalog) UploadMediaImage(ctx context.Context, mediaName, mediaDescription, filePath string, uploadPieceSize int64) (UploadTask, error) {
+	return cat.UploadMediaImageWithOptions(ctx, mediaName, mediaDescription, filePath, UploadOptions{PieceSize: uploadPieceSize})
+}
+
+// UploadMediaImageWithOptions is the same as UploadMediaImage, with a progress callback, the parallel upload and the
+// retry of failed pieces and the cancellation of the upload through ctx, as defined in options
+func (cat *Catalog) UploadMediaImageWithOptions(ctx context.Context, mediaName, mediaDescription, filePath string, options UploadOptions) (UploadTask, error) {
 
 	if *cat == (Catalog{}) {
 		return UploadTask{}, errors.New("catalog can not be empty or nil")
@@ -841,12 +880,12 @@ func (cat *Catalog) UploadMediaImage(ctx context.Context, mediaName, mediaDescri
 
 	isISOGood, err := verifyIso(mediaFilePath)
 	if err != nil || !isISOGood {
-		return UploadTask{}, fmt.Errorf("[ERROR] File %s isn't correct iso file: %#v", mediaFilePath, err)
+		return UploadTask{}, fmt.Errorf("[ERROR] File %s isn't correct iso file: %w", mediaFilePath, err)
 	}
 
 	file, e := os.Stat(mediaFilePath)
 	if e != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Issue finding file: %#v", e)
+		return UploadTask{}, fmt.Errorf("[ERROR] Issue finding file: %w", e)
 	}
 	fileSize := file.Size()
 
@@ -863,7 +902,7 @@ func (cat *Catalog) UploadMediaImage(ctx context.Context, mediaName, mediaDescri
 
 	media, err := createMedia(ctx, cat.client, catalogItemUploadURL.String(), mediaName, mediaDescription, fileSize)
 	if err != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Issue creating media: %#v", err)
+		return UploadTask{}, fmt.Errorf("[ERROR] Issue creating media: %w", err)
 	}
 
 	createdMedia, err := queryMedia(ctx, cat.client, media.Entity.HREF, mediaName)
@@ -871,7 +910,7 @@ func (cat *Catalog) UploadMediaImage(ctx context.Context, mediaName, mediaDescri
 		return UploadTask{}, err
 	}
 
-	return executeUpload(ctx, cat.client, createdMedia, mediaFilePath, mediaName, fileSize, uploadPieceSize)
+	return executeUpload(ctx, cat.client, createdMedia, mediaFilePath, mediaName, fileSize, options)
 }
 
 // Refresh gets a fresh copy of the catalog from vCD
@@ -995,7 +1034,7 @@ func getVAppTemplateById(ctx context.Context, client *Client, vAppTemplateId str
 
 	vappTemplate, err := getVAppTemplateByHref(ctx, client, vappTemplateHref.String())
 	if err != nil {
-		return nil, fmt.Errorf("could not find vApp Template with ID %s: %s", vAppTemplateId, err)
+		return nil, fmt.Errorf("could not find vApp Template with ID %s: %w", vAppTemplateId, err)
 	}
 	return vappTemplate, nil
 }
@@ -1038,7 +1077,7 @@ func (catalog *Catalog) QueryMediaList(ctx context.Context) ([]*types.MediaRecor
 	filter := fmt.Sprintf("catalog==%s", url.QueryEscape(catalog.Catalog.HREF))
 	results, err := catalog.client.QueryWithNotEncodedParams(ctx, nil, map[string]string{"type": typeMedia, "filter": filter, "filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias: %s", err)
+		return nil, fmt.Errorf("error querying medias: %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -1048,6 +1087,12 @@ func (catalog *Catalog) QueryMediaList(ctx context.Context) ([]*types.MediaRecor
 	return mediaResults, nil
 }
 
+// QueryMediaListWithMetadata retrieves a list of media items for the catalog, filtered server side by the
+// metadata filters of options and with the requested metadata fields populated in each record
+func (catalog *Catalog) QueryMediaListWithMetadata(ctx context.Context, options QueryMetadataOptions) ([]*types.MediaRecordType, error) {
+	return queryMediaListWithMetadata(ctx, catalog.client, "catalog=="+url.QueryEscape(catalog.Catalog.HREF), options)
+}
+
 // getOrgInfo finds the organization to which the entity belongs, and returns its name and ID
 // getOrgInfo finds the organization to which the catalog belongs, and returns its name and ID
 func (catalog *Catalog) getOrgInfo() (*TenantContext, error) {
@@ -1121,7 +1166,7 @@ func queryMediaList(ctx context.Context, client *Client, catalogHref string) ([]
 	filter := fmt.Sprintf("catalog==%s", url.QueryEscape(catalogHref))
 	results, err := client.QueryWithNotEncodedParams(ctx, nil, map[string]string{"type": typeMedia, "filter": filter, "filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias: %s", err)
+		return nil, fmt.Errorf("error querying medias: %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -1144,7 +1189,7 @@ func elementLaunchSync(ctx context.Context, client *Client, elementHref, label s
 		// and before we run the request in this function.
 		// In a Terraform vcd_subscribed_catalog operation, the completeness of the synchronisation
 		// will be ensured at the next refresh.
-		if strings.Contains(err.Error(), "LIBRARY_ITEM_SYNC") {
+		if errors.Is(mapServerError(err, serverErrorScopeCatalogSync), errorLibraryItemSyncInProgress) {
 			util.Logger.Printf("[SYNC FAILURE] error when launching synchronisation: %s\n", err)
 			return nil, nil
 		}
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// CatalogExportManifestName is the name of the manifest file written by AdminCatalog.ExportAll
+const CatalogExportManifestName = "catalog.json"
+
+// Types of the items exported by AdminCatalog.ExportAll
+const (
+	CatalogExportItemVAppTemplate = "vAppTemplate"
+	CatalogExportItemMedia        = "media"
+)
+
+// CatalogExportOptions are the optional settings of AdminCatalog.ExportAll
+type CatalogExportOptions struct {
+	// Ova exports the vApp templates as OVA archives instead of OVF descriptors with their files
+	Ova bool
+	// SkipVAppTemplates and SkipMedia leave the corresponding items out of the export
+	SkipVAppTemplates bool
+	SkipMedia         bool
+	// DownloadPieceSize is the size of the ranges in which each file is downloaded. Defaults to 1 MB
+	DownloadPieceSize int64
+	// ContinueOnError keeps exporting the remaining items after a failure, instead of stopping at the first one
+	ContinueOnError bool
+	// Progress, when set, is called after each item is exported, with the number of processed items and the total
+	Progress func(item CatalogExportItem, processed, total int)
+}
+
+// CatalogExportManifest describes a catalog exported by AdminCatalog.ExportAll. It is saved as JSON in the
+// export directory, with the name CatalogExportManifestName
+type CatalogExportManifest struct {
+	Name          string
+	Description   string
+	Metadata      *types.Metadata
+	AccessControl *types.ControlAccessParams
+	Items         []CatalogExportItem
+}
+
+// CatalogExportItem describes a single catalog item exported by AdminCatalog.ExportAll
+type CatalogExportItem struct {
+	Name        string
+	Description string
+	Type        string // One of the CatalogExportItem* constants
+	// Path of the exported item, relative to the export directory. It is the OVF descriptor or the OVA archive for
+	// vApp templates, and the image file for media
+	Path     string
+	Metadata *types.Metadata
+	// Error is set when the item could not be exported
+	Error string `json:",omitempty"`
+}
+
+// ExportAll exports the vApp templates and media of the catalog into directory, which is created if it does not
+// exist, together with a manifest (CatalogExportManifestName) containing the metadata and access settings of
+// the catalog and the metadata of each item, so that the catalog can be backed up or recreated elsewhere.
+//
+// vApp templates are saved under "vAppTemplates", as OVF (one directory per template) or as OVA, and media are
+// saved under "media". Unless options.ContinueOnError is set, the export stops at the first failure. The manifest
+// is written in any case, with the error of each item that could not be exported.
+func (adminCatalog *AdminCatalog) ExportAll(ctx context.Context, directory string, options CatalogExportOptions) (*CatalogExportManifest, error) {
+	if adminCatalog.AdminCatalog == nil || adminCatalog.AdminCatalog.HREF == "" {
+		return nil, fmt.Errorf("catalog is empty")
+	}
+
+	manifest := &CatalogExportManifest{
+		Name:        adminCatalog.AdminCatalog.Name,
+		Description: adminCatalog.AdminCatalog.Description,
+	}
+	var err error
+	manifest.Metadata, err = adminCatalog.GetMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving metadata of catalog %s: %w", adminCatalog.AdminCatalog.Name, err)
+	}
+	manifest.AccessControl, err = adminCatalog.GetAccessControl(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving access control of catalog %s: %w", adminCatalog.AdminCatalog.Name, err)
+	}
+
+	records, err := adminCatalog.QueryCatalogItemList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var itemRecords []*types.QueryResultCatalogItemType
+	for _, record := range records {
+		switch record.EntityType {
+		case types.QtVappTemplate:
+			if options.SkipVAppTemplates {
+				continue
+			}
+		case types.QtMedia:
+			if options.SkipMedia {
+				continue
+			}
+		default:
+			util.Logger.Printf("[WARN] skipping export of catalog item %s of type %s", record.Name, record.EntityType)
+			continue
+		}
+		itemRecords = append(itemRecords, record)
+	}
+
+	err = os.MkdirAll(directory, 0750)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures int
+	for _, record := range itemRecords {
+		item, err := adminCatalog.exportItem(ctx, directory, record, options)
+		if err != nil {
+			failures++
+			item.Error = err.Error()
+			util.Logger.Printf("[ERROR] error exporting catalog item %s: %s", record.Name, err)
+		}
+		manifest.Items = append(manifest.Items, item)
+		if options.Progress != nil {
+			options.Progress(item, len(manifest.Items), len(itemRecords))
+		}
+		if err != nil && !options.ContinueOnError {
+			break
+		}
+	}
+
+	err = writeCatalogExportManifest(directory, manifest)
+	if err != nil {
+		return manifest, err
+	}
+	if failures > 0 {
+		return manifest, fmt.Errorf("%d of %d catalog items could not be exported", failures, len(itemRecords))
+	}
+	return manifest, nil
+}
+
+// exportItem exports a single catalog item into directory
+func (adminCatalog *AdminCatalog) exportItem(ctx context.Context, directory string, record *types.QueryResultCatalogItemType, options CatalogExportOptions) (CatalogExportItem, error) {
+	item := CatalogExportItem{Name: record.Name}
+	catalogItem, err := adminCatalog.GetCatalogItemByHref(ctx, record.HREF)
+	if err != nil {
+		return item, err
+	}
+	item.Description = catalogItem.CatalogItem.Description
+	if catalogItem.CatalogItem.Entity == nil {
+		return item, fmt.Errorf("catalog item %s has no entity", record.Name)
+	}
+	downloadOptions := VAppTemplateDownloadOptions{DownloadPieceSize: options.DownloadPieceSize}
+	fileName := catalogExportFileName(record.Name)
+
+	if record.EntityType == types.QtMedia {
+		item.Type = CatalogExportItemMedia
+		media, err := adminCatalog.GetMediaByHref(ctx, catalogItem.CatalogItem.Entity.HREF)
+		if err != nil {
+			return item, err
+		}
+		item.Metadata, err = media.GetMetadata(ctx)
+		if err != nil {
+			return item, err
+		}
+		item.Path = filepath.Join("media", fileName+"."+strings.ToLower(media.Media.ImageType))
+		return item, writeCatalogExportFile(filepath.Join(directory, item.Path), func(file *os.File) error {
+			return media.Download(ctx, file, downloadOptions)
+		})
+	}
+
+	item.Type = CatalogExportItemVAppTemplate
+	vAppTemplate, err := catalogItem.GetVAppTemplate(ctx)
+	if err != nil {
+		return item, err
+	}
+	item.Metadata, err = vAppTemplate.GetMetadata(ctx)
+	if err != nil {
+		return item, err
+	}
+	if options.Ova {
+		item.Path = filepath.Join("vAppTemplates", fileName+".ova")
+		return item, writeCatalogExportFile(filepath.Join(directory, item.Path), func(file *os.File) error {
+			return vAppTemplate.DownloadOva(ctx, file, downloadOptions)
+		})
+	}
+	descriptorPath, err := vAppTemplate.Download(ctx, filepath.Join(directory, "vAppTemplates", fileName), downloadOptions)
+	if err != nil {
+		return item, err
+	}
+	item.Path, err = filepath.Rel(directory, descriptorPath)
+	return item, err
+}
+
+// writeCatalogExportFile creates the file at filePath, with its parent directory, and fills it with writeFunc
+func writeCatalogExportFile(filePath string, writeFunc func(file *os.File) error) error {
+	err := os.MkdirAll(filepath.Dir(filePath), 0750)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(filepath.Clean(filePath))
+	if err != nil {
+		return err
+	}
+	defer safeClose(file)
+	return writeFunc(file)
+}
+
+// writeCatalogExportManifest saves the manifest of an exported catalog into directory
+func writeCatalogExportManifest(directory string, manifest *CatalogExportManifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding catalog export manifest: %w", err)
+	}
+	return os.WriteFile(filepath.Join(directory, CatalogExportManifestName), content, 0600)
+}
+
+// catalogExportFileName returns a name that can be used as file name for a catalog item
+func catalogExportFileName(itemName string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(itemName)
+}
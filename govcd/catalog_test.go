@@ -8,10 +8,12 @@ package govcd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -198,6 +200,30 @@ func (vcd *TestVCD) Test_DeleteCatalog(check *C) {
 	doesCatalogExist(ctx, check, org)
 }
 
+// Test_DeleteCatalogWithOptions creates a Catalog with a vApp template, checks that DeleteWithOptions fails without
+// options, and then deletes the catalog with Force and Recursive
+func (vcd *TestVCD) Test_DeleteCatalogWithOptions(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	org, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	catalogName := check.TestName()
+	adminCatalog, err := org.CreateCatalog(ctx, catalogName, catalogName)
+	check.Assert(err, IsNil)
+	AddToCleanupList(catalogName, "catalog", vcd.config.VCD.Org, check.TestName())
+
+	checkUploadOvf(ctx, vcd, check, vcd.config.OVA.OvaPath, catalogName, TestUploadOvf+"_"+check.TestName(), false)
+	err = adminCatalog.DeleteWithOptions(ctx, DeleteOptions{})
+	check.Assert(err, NotNil)
+	// Catalog is not empty. An attempt to delete without recursion will fail
+	check.Assert(strings.Contains(err.Error(), "You must remove"), Equals, true)
+
+	err = adminCatalog.DeleteWithOptions(ctx, DeleteOptions{Force: true, Recursive: true})
+	check.Assert(err, IsNil)
+	_, err = org.GetAdminCatalogByName(ctx, catalogName, true)
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
+
 func doesCatalogExist(ctx context.Context, check *C, org *AdminOrg) {
 	var err error
 	var catalog *AdminCatalog
@@ -740,6 +766,89 @@ func (vcd *TestVCD) Test_CatalogGetItem(check *C) {
 	vcd.testFinderGetGenericEntity(def, check)
 }
 
+func (vcd *TestVCD) Test_AdminCatalogCopyItem(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	if vcd.config.VCD.Catalog.Name == "" || vcd.config.VCD.Catalog.CatalogItem == "" {
+		check.Skip("Test_AdminCatalogCopyItem: Catalog or catalog item name not given")
+		return
+	}
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	adminCatalog, err := adminOrg.GetAdminCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+	catalog, err := adminOrg.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+
+	sourceItem, err := catalog.GetCatalogItemByName(ctx, vcd.config.VCD.Catalog.CatalogItem, false)
+	check.Assert(err, IsNil)
+
+	targetName := check.TestName()
+	AddToCleanupList(targetName, "catalogItem", vcd.org.Org.Name+"|"+vcd.config.VCD.Catalog.Name, check.TestName())
+	copiedItem, err := adminCatalog.CopyItem(ctx, sourceItem, targetName, CatalogItemCopyOptions{Description: "copied item"})
+	check.Assert(err, IsNil)
+	check.Assert(copiedItem, NotNil)
+	check.Assert(copiedItem.CatalogItem.Name, Equals, targetName)
+	check.Assert(copiedItem.CatalogItem.Description, Equals, "copied item")
+	check.Assert(copiedItem.CatalogItem.Entity.Type, Equals, sourceItem.CatalogItem.Entity.Type)
+	check.Assert(copiedItem.CatalogItem.Entity.HREF, Not(Equals), sourceItem.CatalogItem.Entity.HREF)
+
+	// A second copy with the same name is rejected
+	_, err = adminCatalog.CopyItem(ctx, sourceItem, targetName, CatalogItemCopyOptions{})
+	check.Assert(err, NotNil)
+
+	err = copiedItem.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+func (vcd *TestVCD) Test_AdminCatalogExportAll(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	if vcd.config.VCD.Catalog.Name == "" || vcd.config.VCD.Catalog.CatalogItem == "" {
+		check.Skip("Test_AdminCatalogExportAll: Catalog or catalog item name not given")
+		return
+	}
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	adminCatalog, err := adminOrg.GetAdminCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+
+	var progressCalls int
+	directory := check.MkDir()
+	manifest, err := adminCatalog.ExportAll(ctx, directory, CatalogExportOptions{
+		Ova: true,
+		Progress: func(item CatalogExportItem, processed, total int) {
+			progressCalls++
+		},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(manifest, NotNil)
+	check.Assert(manifest.Name, Equals, vcd.config.VCD.Catalog.Name)
+	check.Assert(manifest.AccessControl, NotNil)
+	check.Assert(len(manifest.Items) > 0, Equals, true)
+	check.Assert(progressCalls, Equals, len(manifest.Items))
+
+	foundItem := false
+	for _, item := range manifest.Items {
+		check.Assert(item.Error, Equals, "")
+		_, err = os.Stat(filepath.Join(directory, item.Path))
+		check.Assert(err, IsNil)
+		if item.Name == vcd.config.VCD.Catalog.CatalogItem {
+			foundItem = true
+			check.Assert(item.Type, Equals, CatalogExportItemVAppTemplate)
+		}
+	}
+	check.Assert(foundItem, Equals, true)
+
+	content, err := os.ReadFile(filepath.Join(directory, CatalogExportManifestName))
+	check.Assert(err, IsNil)
+	var savedManifest CatalogExportManifest
+	err = json.Unmarshal(content, &savedManifest)
+	check.Assert(err, IsNil)
+	check.Assert(len(savedManifest.Items), Equals, len(manifest.Items))
+}
+
 // TestGetVappTemplateByHref tests that we can find a vApp template using
 // the HREF from the Entity section of a known Catalog Item
 func (vcd *TestVCD) TestGetVappTemplateByHref(check *C) {
@@ -0,0 +1,60 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_createItemWithLink(t *testing.T) {
+	var received types.UploadVAppTemplateParams
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		err := xml.Unmarshal(body, &received)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.vmware.vcloud.catalogItem+xml")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `<CatalogItem xmlns="http://www.vmware.com/vcloud/v1.5" name="item">`+
+			`<Entity href="%s/api/vAppTemplate/vappTemplate-1" type="application/vnd.vmware.vcloud.vAppTemplate+xml"/></CatalogItem>`, server.URL)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+	createHref, err := url.Parse(server.URL + "/api/catalog/1/action/upload")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	sourceUrl := "https://storage.example.com/template.ovf?X-Amz-Expires=3600&X-Amz-Signature=abc"
+	vAppTemplateUrl, err := createItemWithLink(context.Background(), client, createHref, "item <1>", "a & b", sourceUrl)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if vAppTemplateUrl.Path != "/api/vAppTemplate/vappTemplate-1" {
+		t.Errorf("unexpected vApp template URL %s", vAppTemplateUrl)
+	}
+	if received.Name != "item <1>" || received.Description != "a & b" || received.SourceHref != sourceUrl {
+		t.Errorf("unexpected upload parameters: %+v", received)
+	}
+}
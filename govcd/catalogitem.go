@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
@@ -51,6 +52,67 @@ func (catalogItem *CatalogItem) Delete(ctx context.Context) error {
 		"", "error deleting Catalog item: %s", nil)
 }
 
+// Update updates the name, description and properties of the Catalog Item with the values in
+// catalogItem.CatalogItem. VCD increases the version number of the item at each update.
+func (catalogItem *CatalogItem) Update(ctx context.Context) error {
+	if catalogItem.CatalogItem == nil || catalogItem.CatalogItem.HREF == "" {
+		return fmt.Errorf("cannot update, Object is empty")
+	}
+	if catalogItem.CatalogItem.Entity == nil {
+		return fmt.Errorf("cannot update catalog item %s without entity", catalogItem.CatalogItem.Name)
+	}
+
+	catalogItemPayload := &types.CatalogItemForUpdate{
+		Xmlns:       types.XMLNamespaceVCloud,
+		HREF:        catalogItem.CatalogItem.HREF,
+		ID:          catalogItem.CatalogItem.ID,
+		Name:        catalogItem.CatalogItem.Name,
+		Description: catalogItem.CatalogItem.Description,
+		Entity: &types.Reference{
+			HREF: catalogItem.CatalogItem.Entity.HREF,
+			Type: catalogItem.CatalogItem.Entity.Type,
+			Name: catalogItem.CatalogItem.Entity.Name,
+		},
+		Property: catalogItem.CatalogItem.Property,
+	}
+
+	updatedCatalogItem := &types.CatalogItem{}
+	_, err := catalogItem.client.ExecuteRequest(ctx, catalogItem.CatalogItem.HREF, http.MethodPut,
+		types.MimeCatalogItem, "error updating catalog item: %s", catalogItemPayload, updatedCatalogItem)
+	if err != nil {
+		return err
+	}
+	catalogItem.CatalogItem = updatedCatalogItem
+	return nil
+}
+
+// GetProperties returns the user-defined properties of the Catalog Item as a map
+func (catalogItem *CatalogItem) GetProperties() map[string]string {
+	properties := make(map[string]string, len(catalogItem.CatalogItem.Property))
+	for _, property := range catalogItem.CatalogItem.Property {
+		properties[property.Key] = property.Value
+	}
+	return properties
+}
+
+// UpdateProperties replaces the user-defined properties of the Catalog Item. An empty map removes all the
+// properties
+func (catalogItem *CatalogItem) UpdateProperties(ctx context.Context, properties map[string]string) error {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	// Sorted, to send the properties in a stable order
+	sort.Strings(keys)
+
+	catalogItem.CatalogItem.Property = nil
+	for _, key := range keys {
+		catalogItem.CatalogItem.Property = append(catalogItem.CatalogItem.Property,
+			&types.CatalogItemProperty{Key: key, Value: properties[key]})
+	}
+	return catalogItem.Update(ctx)
+}
+
 // queryCatalogItemList returns a list of Catalog Item for the given parent
 func queryCatalogItemList(ctx context.Context, client *Client, parentField, parentValue string) ([]*types.QueryResultCatalogItemType, error) {
 
@@ -66,7 +128,7 @@ func queryCatalogItemList(ctx context.Context, client *Client, parentField, pare
 		"filter": filterText,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error querying catalog items %s", err)
+		return nil, fmt.Errorf("error querying catalog items %w", err)
 	}
 
 	if client.IsSysAdmin {
@@ -101,6 +163,12 @@ func queryVappTemplateListWithParentField(ctx context.Context, client *Client, p
 // queryVappTemplateListWithFilter returns a list of vApp templates filtered by the given filter map.
 // The filter map will build a filter like filterKey==filterValue;filterKey2==filterValue2;...
 func queryVappTemplateListWithFilter(ctx context.Context, client *Client, filter map[string]string) ([]*types.QueryResultVappTemplateType, error) {
+	return queryVappTemplateListWithMetadata(ctx, client, filter, QueryMetadataOptions{})
+}
+
+// queryVappTemplateListWithMetadata returns a list of vApp templates filtered by the given filter map and by the
+// metadata filters of options, with the metadata fields of options populated in each record
+func queryVappTemplateListWithMetadata(ctx context.Context, client *Client, filter map[string]string, options QueryMetadataOptions) ([]*types.QueryResultVappTemplateType, error) {
 	vappTemplateType := types.QtVappTemplate
 	if client.IsSysAdmin {
 		vappTemplateType = types.QtAdminVappTemplate
@@ -109,12 +177,11 @@ func queryVappTemplateListWithFilter(ctx context.Context, client *Client, filter
 	for k, v := range filter {
 		filterEncoded += fmt.Sprintf("%s==%s;", url.QueryEscape(k), url.QueryEscape(v))
 	}
-	results, err := client.cumulativeQuery(ctx, vappTemplateType, nil, map[string]string{
-		"type":   vappTemplateType,
+	results, err := client.queryWithMetadataOptions(ctx, vappTemplateType, map[string]string{
 		"filter": filterEncoded[:len(filterEncoded)-1], // Removes the trailing ';'
-	})
+	}, options)
 	if err != nil {
-		return nil, fmt.Errorf("error querying vApp templates %s", err)
+		return nil, fmt.Errorf("error querying vApp templates %w", err)
 	}
 
 	if client.IsSysAdmin {
@@ -129,6 +196,12 @@ func (vdc *Vdc) QueryVappTemplateList(ctx context.Context) ([]*types.QueryResult
 	return queryVappTemplateListWithParentField(ctx, vdc.client, "vdcName", vdc.Vdc.Name)
 }
 
+// QueryVappTemplateListWithMetadata returns a list of vApp templates for the given VDC, filtered server side by
+// the metadata filters of options and with the requested metadata fields populated in each record
+func (vdc *Vdc) QueryVappTemplateListWithMetadata(ctx context.Context, options QueryMetadataOptions) ([]*types.QueryResultVappTemplateType, error) {
+	return queryVappTemplateListWithMetadata(ctx, vdc.client, map[string]string{"vdcName": vdc.Vdc.Name}, options)
+}
+
 // QueryVappTemplateWithName returns one vApp template for the given VDC with the given name.
 // Returns an error if it finds more than one.
 func (vdc *Vdc) QueryVappTemplateWithName(ctx context.Context, vAppTemplateName string) (*types.QueryResultVappTemplateType, error) {
@@ -153,6 +226,12 @@ func (vdc *AdminVdc) QueryVappTemplateList(ctx context.Context) ([]*types.QueryR
 	return queryVappTemplateListWithParentField(ctx, vdc.client, "vdcName", vdc.AdminVdc.Name)
 }
 
+// QueryVappTemplateListWithMetadata returns a list of vApp templates for the given VDC, filtered server side by
+// the metadata filters of options and with the requested metadata fields populated in each record
+func (vdc *AdminVdc) QueryVappTemplateListWithMetadata(ctx context.Context, options QueryMetadataOptions) ([]*types.QueryResultVappTemplateType, error) {
+	return queryVappTemplateListWithMetadata(ctx, vdc.client, map[string]string{"vdcName": vdc.AdminVdc.Name}, options)
+}
+
 // QueryVappTemplateWithName returns one vApp template for the given VDC with the given name.
 // Returns an error if it finds more than one.
 func (vdc *AdminVdc) QueryVappTemplateWithName(ctx context.Context, vAppTemplateName string) (*types.QueryResultVappTemplateType, error) {
@@ -177,6 +256,12 @@ func (catalog *Catalog) QueryVappTemplateList(ctx context.Context) ([]*types.Que
 	return queryVappTemplateListWithParentField(ctx, catalog.client, "catalogName", catalog.Catalog.Name)
 }
 
+// QueryVappTemplateListWithMetadata returns a list of vApp templates for the given catalog, filtered server side
+// by the metadata filters of options and with the requested metadata fields populated in each record
+func (catalog *Catalog) QueryVappTemplateListWithMetadata(ctx context.Context, options QueryMetadataOptions) ([]*types.QueryResultVappTemplateType, error) {
+	return queryVappTemplateListWithMetadata(ctx, catalog.client, map[string]string{"catalogName": catalog.Catalog.Name}, options)
+}
+
 // QueryVappTemplateWithName returns one vApp template for the given Catalog with the given name.
 // Returns an error if it finds more than one.
 func (catalog *Catalog) QueryVappTemplateWithName(ctx context.Context, vAppTemplateName string) (*types.QueryResultVappTemplateType, error) {
@@ -231,7 +316,7 @@ func queryCatalogItemFilteredList(ctx context.Context, client *Client, filter ma
 	}
 	results, err := client.cumulativeQuery(ctx, catalogItemType, nil, notEncodedParams)
 	if err != nil {
-		return nil, fmt.Errorf("error querying catalog items %s", err)
+		return nil, fmt.Errorf("error querying catalog items %w", err)
 	}
 
 	if client.IsSysAdmin {
@@ -306,10 +391,13 @@ func (item *CatalogItem) LaunchSync(ctx context.Context) (*Task, error) {
 
 // Refresh retrieves a fresh copy of the catalog Item
 func (item *CatalogItem) Refresh(ctx context.Context) error {
+	// Retrieving into a new structure, so that lists, such as the properties, are not appended to the old ones
+	refreshedItem := &types.CatalogItem{}
 	_, err := item.client.ExecuteRequest(ctx, item.CatalogItem.HREF, http.MethodGet,
-		"", "error retrieving catalog item: %s", nil, item.CatalogItem)
+		"", "error retrieving catalog item: %s", nil, refreshedItem)
 	if err != nil {
 		return err
 	}
+	item.CatalogItem = refreshedItem
 	return nil
 }
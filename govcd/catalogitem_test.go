@@ -273,3 +273,91 @@ func (vcd *TestVCD) Test_QueryVappTemplateList(check *C) {
 	check.Assert(vAppTemplates, NotNil)
 	check.Assert(vAppTemplate, DeepEquals, vAppTemplates[0])
 }
+
+func (vcd *TestVCD) Test_QueryVappTemplateListWithMetadata(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	catalogName := vcd.config.VCD.Catalog.Name
+	if catalogName == "" {
+		check.Skip("Test_QueryVappTemplateListWithMetadata: Catalog name not given")
+		return
+	}
+
+	cat, err := vcd.org.GetCatalogByName(ctx, catalogName, false)
+	if err != nil {
+		check.Skip("Test_QueryVappTemplateListWithMetadata: Catalog not found")
+		return
+	}
+
+	vAppTemplate, err := cat.GetVAppTemplateByName(ctx, vcd.config.VCD.Catalog.CatalogItem)
+	check.Assert(err, IsNil)
+
+	key := check.TestName()
+	err = vAppTemplate.AddMetadataEntryWithVisibility(ctx, key, "tagged", types.MetadataStringValue, types.MetadataReadWriteVisibility, false)
+	check.Assert(err, IsNil)
+	defer func() {
+		err = vAppTemplate.DeleteMetadataEntryWithDomain(ctx, key, false)
+		check.Assert(err, IsNil)
+	}()
+
+	// The metadata filter is applied server side and the metadata field is returned in the record
+	vAppTemplates, err := cat.QueryVappTemplateListWithMetadata(ctx, QueryMetadataOptions{
+		Fields:  []string{key},
+		Filters: map[string]MetadataFilter{key: {Type: "STRING", Value: "tagged"}},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(len(vAppTemplates), Equals, 1)
+	check.Assert(vAppTemplates[0].Name, Equals, vAppTemplate.VAppTemplate.Name)
+	check.Assert(vAppTemplates[0].Metadata, NotNil)
+	check.Assert(len(vAppTemplates[0].Metadata.MetadataEntry), Equals, 1)
+	check.Assert(vAppTemplates[0].Metadata.MetadataEntry[0].Key, Equals, key)
+	check.Assert(vAppTemplates[0].Metadata.MetadataEntry[0].TypedValue.Value, Equals, "tagged")
+
+	vAppTemplates, err = cat.QueryVappTemplateListWithMetadata(ctx, QueryMetadataOptions{
+		Filters: map[string]MetadataFilter{key: {Type: "STRING", Value: "not-tagged"}},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(len(vAppTemplates), Equals, 0)
+}
+
+// Test_CatalogItemUpdate updates the description and the properties of a catalog item and checks that its version
+// number increases
+func (vcd *TestVCD) Test_CatalogItemUpdate(check *C) {
+	skipWhenOvaPathMissing(vcd.config.OVA.OvaPath, check)
+	fmt.Printf("Running: %s\n", check.TestName())
+	itemName := check.TestName()
+
+	catalog, err := vcd.org.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+
+	uploadTask, err := catalog.UploadOvf(ctx, vcd.config.OVA.OvaPath, itemName, "catalog item update test", 1024)
+	check.Assert(err, IsNil)
+	err = uploadTask.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+	AddToCleanupList(itemName, "catalogItem", vcd.org.Org.Name+"|"+vcd.config.VCD.Catalog.Name, check.TestName())
+
+	catalogItem, err := catalog.GetCatalogItemByName(ctx, itemName, true)
+	check.Assert(err, IsNil)
+	versionNumber := catalogItem.CatalogItem.VersionNumber
+
+	catalogItem.CatalogItem.Description = "updated description"
+	err = catalogItem.Update(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(catalogItem.CatalogItem.Description, Equals, "updated description")
+	check.Assert(catalogItem.CatalogItem.VersionNumber > versionNumber, Equals, true)
+
+	properties := map[string]string{"release": "2.1", "build": "1234"}
+	err = catalogItem.UpdateProperties(ctx, properties)
+	check.Assert(err, IsNil)
+	err = catalogItem.Refresh(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(catalogItem.GetProperties(), DeepEquals, properties)
+	check.Assert(catalogItem.CatalogItem.Description, Equals, "updated description")
+
+	err = catalogItem.UpdateProperties(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(catalogItem.GetProperties()), Equals, 0)
+
+	err = catalogItem.Delete(ctx)
+	check.Assert(err, IsNil)
+}
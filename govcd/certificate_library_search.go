@@ -0,0 +1,302 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"crypto/sha1" // #nosec G505 -- SHA-1 is only used to compute the thumbprint shown by VCD and vSphere
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CertificateDetails are the details of a certificate library item, decoded from its PEM certificate. When the item
+// contains a chain, they refer to the first certificate of the chain
+type CertificateDetails struct {
+	Subject     string
+	Issuer      string
+	DNSNames    []string // Subject alternative names of DNS type
+	IPAddresses []string // Subject alternative names of IP type
+	NotBefore   time.Time
+	NotAfter    time.Time
+	// Thumbprints are lowercase hexadecimal digits without separators
+	Sha1Thumbprint   string
+	Sha256Thumbprint string
+}
+
+// ExpiringCertificate is a certificate library item returned by the expiry reports
+type ExpiringCertificate struct {
+	Certificate *Certificate
+	Details     *CertificateDetails
+	OrgName     string // Name of the Org that owns the certificate, or empty for the system library
+	DaysLeft    int    // Days left before the certificate expires. It is negative for expired certificates
+}
+
+// GetDetails decodes the PEM certificate of the library item
+func (certificate *Certificate) GetDetails() (*CertificateDetails, error) {
+	if certificate.CertificateLibrary == nil {
+		return nil, fmt.Errorf("certificate library item is empty")
+	}
+	return parseCertificateDetails(certificate.CertificateLibrary.Certificate)
+}
+
+// GetCertificatesFromLibraryBySubjectAlternativeName retrieves the certificates from the system library whose
+// subject alternative names match the given DNS name or IP address. Wildcard names (e.g. *.example.com) match the
+// names they cover
+func (client *Client) GetCertificatesFromLibraryBySubjectAlternativeName(ctx context.Context, name string) ([]*Certificate, error) {
+	return getCertificatesFromLibraryBySubjectAlternativeName(ctx, client, name, nil)
+}
+
+// GetCertificatesFromLibraryBySubjectAlternativeName retrieves the certificates from the Org library whose
+// subject alternative names match the given DNS name or IP address. Wildcard names (e.g. *.example.com) match the
+// names they cover
+func (adminOrg *AdminOrg) GetCertificatesFromLibraryBySubjectAlternativeName(ctx context.Context, name string) ([]*Certificate, error) {
+	tenantContext, err := adminOrg.getTenantContext()
+	if err != nil {
+		return nil, err
+	}
+	return getCertificatesFromLibraryBySubjectAlternativeName(ctx, adminOrg.client, name, getTenantContextHeader(tenantContext))
+}
+
+// GetCertificateFromLibraryByThumbprint retrieves the certificate from the system library with the given SHA-1 or
+// SHA-256 thumbprint, written as hexadecimal digits, optionally separated by colons
+func (client *Client) GetCertificateFromLibraryByThumbprint(ctx context.Context, thumbprint string) (*Certificate, error) {
+	return getCertificateFromLibraryByThumbprint(ctx, client, thumbprint, nil)
+}
+
+// GetCertificateFromLibraryByThumbprint retrieves the certificate from the Org library with the given SHA-1 or
+// SHA-256 thumbprint, written as hexadecimal digits, optionally separated by colons
+func (adminOrg *AdminOrg) GetCertificateFromLibraryByThumbprint(ctx context.Context, thumbprint string) (*Certificate, error) {
+	tenantContext, err := adminOrg.getTenantContext()
+	if err != nil {
+		return nil, err
+	}
+	return getCertificateFromLibraryByThumbprint(ctx, adminOrg.client, thumbprint, getTenantContextHeader(tenantContext))
+}
+
+// GetExpiringCertificatesFromLibrary retrieves the certificates from the system library that expire within the
+// given number of days, including the ones already expired, sorted by expiry date
+func (client *Client) GetExpiringCertificatesFromLibrary(ctx context.Context, days int) ([]*ExpiringCertificate, error) {
+	return getExpiringCertificatesFromLibrary(ctx, client, "", days, nil)
+}
+
+// GetExpiringCertificatesFromLibrary retrieves the certificates from the Org library that expire within the given
+// number of days, including the ones already expired, sorted by expiry date
+func (adminOrg *AdminOrg) GetExpiringCertificatesFromLibrary(ctx context.Context, days int) ([]*ExpiringCertificate, error) {
+	tenantContext, err := adminOrg.getTenantContext()
+	if err != nil {
+		return nil, err
+	}
+	return getExpiringCertificatesFromLibrary(ctx, adminOrg.client, adminOrg.AdminOrg.Name, days,
+		getTenantContextHeader(tenantContext))
+}
+
+// GetExpiringCertificatesFromAllLibraries retrieves the certificates that expire within the given number of days,
+// including the ones already expired, from the system library and from the library of every Org, sorted by expiry
+// date.
+// Note. Requires system administrator privileges
+func (vcdClient *VCDClient) GetExpiringCertificatesFromAllLibraries(ctx context.Context, days int) ([]*ExpiringCertificate, error) {
+	if !vcdClient.Client.IsSysAdmin {
+		return nil, fmt.Errorf("functionality requires System Administrator privileges")
+	}
+
+	expiringCertificates, err := vcdClient.Client.GetExpiringCertificatesFromLibrary(ctx, days)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving system certificates: %w", err)
+	}
+
+	orgList, err := vcdClient.GetOrgList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, org := range orgList.Org {
+		if strings.EqualFold(org.Name, "System") {
+			continue
+		}
+		adminOrg, err := vcdClient.GetAdminOrgByName(ctx, org.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Org '%s': %w", org.Name, err)
+		}
+		orgCertificates, err := adminOrg.GetExpiringCertificatesFromLibrary(ctx, days)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving certificates of Org '%s': %w", org.Name, err)
+		}
+		expiringCertificates = append(expiringCertificates, orgCertificates...)
+	}
+
+	sortExpiringCertificates(expiringCertificates)
+	return expiringCertificates, nil
+}
+
+// getCertificatesFromLibraryBySubjectAlternativeName retrieves all the certificates of a library and keeps the ones
+// matching name. Certificates that cannot be decoded are skipped
+func getCertificatesFromLibraryBySubjectAlternativeName(ctx context.Context, client *Client, name string, additionalHeader map[string]string) ([]*Certificate, error) {
+	if name == "" {
+		return nil, fmt.Errorf("empty subject alternative name")
+	}
+	certificates, err := getAllCertificateFromLibrary(ctx, client, nil, additionalHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	var foundCertificates []*Certificate
+	for _, certificate := range certificates {
+		details, err := certificate.GetDetails()
+		if err != nil {
+			continue
+		}
+		if certificateDetailsMatchName(details, name) {
+			foundCertificates = append(foundCertificates, certificate)
+		}
+	}
+	return foundCertificates, nil
+}
+
+// getCertificateFromLibraryByThumbprint retrieves all the certificates of a library and returns the only one with
+// the given thumbprint
+func getCertificateFromLibraryByThumbprint(ctx context.Context, client *Client, thumbprint string, additionalHeader map[string]string) (*Certificate, error) {
+	normalizedThumbprint := normalizeCertificateThumbprint(thumbprint)
+	if normalizedThumbprint == "" {
+		return nil, fmt.Errorf("empty certificate thumbprint")
+	}
+	certificates, err := getAllCertificateFromLibrary(ctx, client, nil, additionalHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	var foundCertificates []*Certificate
+	for _, certificate := range certificates {
+		details, err := certificate.GetDetails()
+		if err != nil {
+			continue
+		}
+		if details.Sha1Thumbprint == normalizedThumbprint || details.Sha256Thumbprint == normalizedThumbprint {
+			foundCertificates = append(foundCertificates, certificate)
+		}
+	}
+	return oneOrError("thumbprint", thumbprint, foundCertificates)
+}
+
+// getExpiringCertificatesFromLibrary retrieves all the certificates of a library and keeps the ones that expire
+// within the given number of days
+func getExpiringCertificatesFromLibrary(ctx context.Context, client *Client, orgName string, days int, additionalHeader map[string]string) ([]*ExpiringCertificate, error) {
+	if days < 0 {
+		return nil, fmt.Errorf("number of days must be positive, got %d", days)
+	}
+	certificates, err := getAllCertificateFromLibrary(ctx, client, nil, additionalHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expiringCertificates []*ExpiringCertificate
+	for _, certificate := range certificates {
+		details, err := certificate.GetDetails()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding certificate '%s': %w", certificate.CertificateLibrary.Alias, err)
+		}
+		daysLeft := certificateDaysLeft(details.NotAfter, now)
+		if daysLeft > days {
+			continue
+		}
+		expiringCertificates = append(expiringCertificates, &ExpiringCertificate{
+			Certificate: certificate,
+			Details:     details,
+			OrgName:     orgName,
+			DaysLeft:    daysLeft,
+		})
+	}
+
+	sortExpiringCertificates(expiringCertificates)
+	return expiringCertificates, nil
+}
+
+// parseCertificateDetails decodes the first certificate of a PEM text
+func parseCertificateDetails(pemCertificate string) (*CertificateDetails, error) {
+	rest := []byte(pemCertificate)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM certificate found")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing certificate: %w", err)
+		}
+
+		sha1Thumbprint := sha1.Sum(certificate.Raw) // #nosec G401 -- thumbprint only, not used for security
+		sha256Thumbprint := sha256.Sum256(certificate.Raw)
+		details := &CertificateDetails{
+			Subject:          certificate.Subject.String(),
+			Issuer:           certificate.Issuer.String(),
+			DNSNames:         certificate.DNSNames,
+			NotBefore:        certificate.NotBefore,
+			NotAfter:         certificate.NotAfter,
+			Sha1Thumbprint:   hex.EncodeToString(sha1Thumbprint[:]),
+			Sha256Thumbprint: hex.EncodeToString(sha256Thumbprint[:]),
+		}
+		for _, ipAddress := range certificate.IPAddresses {
+			details.IPAddresses = append(details.IPAddresses, ipAddress.String())
+		}
+		return details, nil
+	}
+}
+
+// certificateDetailsMatchName checks whether a DNS name or IP address is covered by the subject alternative names
+// of a certificate. The comparison of DNS names is case-insensitive, and a wildcard covers a single label
+func certificateDetailsMatchName(details *CertificateDetails, name string) bool {
+	if ip := net.ParseIP(name); ip != nil {
+		for _, ipAddress := range details.IPAddresses {
+			if ip.Equal(net.ParseIP(ipAddress)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	for _, dnsName := range details.DNSNames {
+		dnsName = strings.ToLower(strings.TrimSuffix(dnsName, "."))
+		if dnsName == name {
+			return true
+		}
+		if strings.HasPrefix(dnsName, "*.") {
+			labels := strings.SplitN(name, ".", 2)
+			if len(labels) == 2 && labels[0] != "" && labels[1] == dnsName[2:] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeCertificateThumbprint returns a thumbprint as lowercase hexadecimal digits without separators
+func normalizeCertificateThumbprint(thumbprint string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", " ", "").Replace(thumbprint))
+}
+
+// certificateDaysLeft returns the number of whole days from now to notAfter, rounded down, so that a certificate
+// expiring in less than a day has 0 days left and an expired one has a negative number
+func certificateDaysLeft(notAfter, now time.Time) int {
+	return int(math.Floor(notAfter.Sub(now).Hours() / 24))
+}
+
+// sortExpiringCertificates sorts certificates by expiry date, the first expiring first
+func sortExpiringCertificates(certificates []*ExpiringCertificate) {
+	sort.SliceStable(certificates, func(i, j int) bool {
+		return certificates[i].Details.NotAfter.Before(certificates[j].Details.NotAfter)
+	})
+}
@@ -265,7 +265,7 @@ func (certificate *Certificate) Update(ctx context.Context) (*Certificate, error
 	err = certificate.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, certificate.CertificateLibrary,
 		returnCertificate.CertificateLibrary, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating certificate: %s", err)
+		return nil, fmt.Errorf("error updating certificate: %w", err)
 	}
 
 	return returnCertificate, nil
@@ -294,7 +294,7 @@ func (certificate *Certificate) Delete(ctx context.Context) error {
 	err = certificate.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting certificate: %s", err)
+		return fmt.Errorf("error deleting certificate: %w", err)
 	}
 
 	return nil
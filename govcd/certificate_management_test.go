@@ -9,6 +9,8 @@ package govcd
 import (
 	_ "embed"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 
@@ -195,3 +197,68 @@ func (vcd *TestVCD) Test_GetCertificateFromLibraryByName_ValidatesSymbolsInName(
 		check.Assert(err, IsNil)
 	}
 }
+
+func (vcd *TestVCD) Test_CertificateLibrarySearch(check *C) {
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointSSLCertificateLibrary)
+
+	createdCertificate, err := vcd.client.Client.AddCertificateToLibrary(ctx, &types.CertificateLibraryItem{
+		Alias:       testResourceName(check, ""),
+		Certificate: certificate,
+	})
+	check.Assert(err, IsNil)
+	openApiEndpoint, err := getEndpointByVersion(ctx, &vcd.client.Client)
+	check.Assert(err, IsNil)
+	PrependToCleanupListOpenApi(createdCertificate.CertificateLibrary.Alias, check.TestName(),
+		openApiEndpoint+createdCertificate.CertificateLibrary.Id)
+
+	details, err := createdCertificate.GetDetails()
+	check.Assert(err, IsNil)
+	check.Assert(details.Sha256Thumbprint, Not(Equals), "")
+
+	foundCertificate, err := vcd.client.Client.GetCertificateFromLibraryByThumbprint(ctx, details.Sha1Thumbprint)
+	check.Assert(err, IsNil)
+	check.Assert(foundCertificate.CertificateLibrary.Id, Equals, createdCertificate.CertificateLibrary.Id)
+	foundCertificate, err = vcd.client.Client.GetCertificateFromLibraryByThumbprint(ctx, strings.ToUpper(details.Sha256Thumbprint))
+	check.Assert(err, IsNil)
+	check.Assert(foundCertificate.CertificateLibrary.Id, Equals, createdCertificate.CertificateLibrary.Id)
+
+	for _, name := range append(details.DNSNames, details.IPAddresses...) {
+		foundCertificates, err := vcd.client.Client.GetCertificatesFromLibraryBySubjectAlternativeName(ctx, name)
+		check.Assert(err, IsNil)
+		found := false
+		for _, foundCertificate := range foundCertificates {
+			if foundCertificate.CertificateLibrary.Id == createdCertificate.CertificateLibrary.Id {
+				found = true
+			}
+		}
+		check.Assert(found, Equals, true)
+	}
+
+	// The certificate must be reported when the period covers its expiry date, and not before
+	daysLeft := certificateDaysLeft(details.NotAfter, time.Now())
+	if daysLeft >= 0 {
+		expiringCertificates, err := vcd.client.GetExpiringCertificatesFromAllLibraries(ctx, daysLeft+1)
+		check.Assert(err, IsNil)
+		check.Assert(expiringCertificateIds(expiringCertificates)[createdCertificate.CertificateLibrary.Id], Equals, true)
+		if daysLeft > 0 {
+			expiringCertificates, err = vcd.client.Client.GetExpiringCertificatesFromLibrary(ctx, daysLeft-1)
+			check.Assert(err, IsNil)
+			check.Assert(expiringCertificateIds(expiringCertificates)[createdCertificate.CertificateLibrary.Id], Equals, false)
+		}
+	}
+
+	err = createdCertificate.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+// expiringCertificateIds returns the IDs of the given certificates
+func expiringCertificateIds(expiringCertificates []*ExpiringCertificate) map[string]bool {
+	ids := make(map[string]bool)
+	for _, expiringCertificate := range expiringCertificates {
+		ids[expiringCertificate.Certificate.CertificateLibrary.Id] = true
+	}
+	return ids
+}
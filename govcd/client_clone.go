@@ -0,0 +1,83 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ClientCredentials are the credentials of the session created by VCDClient.CloneWithCredentials. Org is always
+// required ("System" for a provider session), together with one of:
+// * User and Password
+// * Token, with the AuthHeader describing it (ApiTokenHeader for an API token, BearerTokenHeader for a bearer
+// token, or AuthorizationHeader for a legacy session token). AuthHeader defaults to BearerTokenHeader
+// * ServiceAccountTokenFile, the file holding the API token of a Service Account (see WithServiceAccountTokenFile)
+type ClientCredentials struct {
+	Org                     string
+	User                    string
+	Password                string
+	Token                   string
+	AuthHeader              string
+	ServiceAccountTokenFile string
+}
+
+// CloneWithCredentials returns a copy of the VCDClient authenticated with other credentials, e.g. to run tenant
+// operations next to the ones of a System administrator in the same program.
+//
+// The copy shares the HTTP transport (and its connections) of the original client, with the options that configured
+// it, such as TLS settings, retries, failover endpoints, middlewares and the scope of a scoped client. It also keeps
+// the timeouts, API version, user agent and custom headers. The session is independent: authenticating, refreshing
+// or disconnecting one of the clients does not affect the other one.
+func (vcdClient *VCDClient) CloneWithCredentials(ctx context.Context, credentials ClientCredentials) (*VCDClient, error) {
+	if credentials.Org == "" {
+		return nil, fmt.Errorf("an Org is required to clone the client")
+	}
+	methods := 0
+	if credentials.User != "" || credentials.Password != "" {
+		methods++
+	}
+	if credentials.Token != "" {
+		methods++
+	}
+	if credentials.ServiceAccountTokenFile != "" {
+		methods++
+	}
+	if methods != 1 {
+		return nil, fmt.Errorf("exactly one of user and password, token or Service Account token file is required " +
+			"to clone the client")
+	}
+
+	clone := *vcdClient
+	if vcdClient.Client.customHeader != nil {
+		clone.Client.customHeader = vcdClient.Client.customHeader.Clone()
+	}
+	// The session and the data depending on the principal are not shared
+	clone.Client.VCDToken = ""
+	clone.Client.VCDAuthHeader = ""
+	clone.Client.IsSysAdmin = false
+	clone.Client.UsingBearerToken = false
+	clone.Client.UsingAccessToken = false
+	clone.Client.ServiceAccountTokenFile = credentials.ServiceAccountTokenFile
+	clone.Client.capabilities = newCapabilityCache()
+	clone.sessionHREF = url.URL{}
+	clone.QueryHREF = url.URL{}
+
+	var err error
+	if credentials.Token != "" {
+		authHeader := credentials.AuthHeader
+		if authHeader == "" {
+			authHeader = BearerTokenHeader
+		}
+		err = clone.SetToken(ctx, credentials.Org, authHeader, credentials.Token)
+	} else {
+		err = clone.Authenticate(ctx, credentials.User, credentials.Password, credentials.Org)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error authenticating cloned client in Org '%s': %w", credentials.Org, err)
+	}
+	return &clone, nil
+}
@@ -0,0 +1,109 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_CloneWithCredentials(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/cloudapi/1.0.0/sessions"):
+			user, password, ok := r.BasicAuth()
+			if !ok || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set(BearerTokenHeader, "token-"+user)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/org":
+			if r.Header.Get(BearerTokenHeader) != "tenant-token" {
+				w.Header().Set("Content-Type", types.MimeError)
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`<Error xmlns="http://www.vmware.com/vcloud/v1.5" majorErrorCode="401" message="Unauthorized"/>`))
+				return
+			}
+			w.Header().Set("Content-Type", types.MimeOrgList)
+			_, _ = w.Write([]byte(`<OrgList xmlns="http://www.vmware.com/vcloud/v1.5"></OrgList>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true, WithHttpUserAgent("clone-test"), WithHttpHeader(map[string]string{"X-Custom": "1"}))
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"37.0"})
+	vcdClient.Client.supportedVersions.VersionInfos[0].LoginUrl = server.URL + "/api/sessions"
+	vcdClient.Client.APIVersion = "37.0"
+	ctx := context.Background()
+
+	err = vcdClient.Authenticate(ctx, "admin", "secret", "System")
+	if err != nil {
+		t.Fatalf("unexpected error authenticating: %s", err)
+	}
+	if !vcdClient.Client.IsSysAdmin || vcdClient.Client.VCDToken != "token-admin@System" {
+		t.Fatalf("unexpected session of original client: %+v", vcdClient.Client)
+	}
+
+	tenantClient, err := vcdClient.CloneWithCredentials(ctx, ClientCredentials{Org: "tenant", User: "user", Password: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error cloning client: %s", err)
+	}
+	if tenantClient.Client.IsSysAdmin || tenantClient.Client.VCDToken != "token-user@tenant" {
+		t.Errorf("unexpected session of cloned client: %+v", tenantClient.Client)
+	}
+	if tenantClient.Client.Http.Transport != vcdClient.Client.Http.Transport ||
+		tenantClient.Client.UserAgent != "clone-test" || tenantClient.Client.customHeader.Get("X-Custom") != "1" {
+		t.Errorf("cloned client does not keep the configuration of the original one")
+	}
+	tenantClient.Client.SetCustomHeader(map[string]string{"X-Tenant": "1"})
+	if vcdClient.Client.customHeader.Get("X-Tenant") != "" {
+		t.Errorf("custom headers of the cloned client changed the original client")
+	}
+	if !vcdClient.Client.IsSysAdmin || vcdClient.Client.VCDToken != "token-admin@System" ||
+		vcdClient.sessionHREF.Path != "/cloudapi/1.0.0/sessions/provider" {
+		t.Errorf("cloning changed the session of the original client: %+v", vcdClient.Client)
+	}
+
+	tokenClient, err := vcdClient.CloneWithCredentials(ctx, ClientCredentials{Org: "tenant", Token: "tenant-token"})
+	if err != nil {
+		t.Fatalf("unexpected error cloning client with token: %s", err)
+	}
+	if tokenClient.Client.VCDAuthHeader != BearerTokenHeader || !tokenClient.Client.UsingBearerToken {
+		t.Errorf("unexpected session of client cloned with token: %+v", tokenClient.Client)
+	}
+
+	invalidCredentials := []ClientCredentials{
+		{User: "user", Password: "secret"},
+		{Org: "tenant"},
+		{Org: "tenant", User: "user", Password: "secret", Token: "tenant-token"},
+		{Org: "tenant", User: "user", Password: "wrong"},
+		{Org: "tenant", Token: "wrong-token"},
+	}
+	for _, credentials := range invalidCredentials {
+		_, err = vcdClient.CloneWithCredentials(ctx, credentials)
+		if err == nil {
+			t.Errorf("expected error cloning client with credentials %+v", credentials)
+		}
+	}
+	if vcdClient.Client.VCDToken != "token-admin@System" {
+		t.Errorf("failed clone changed the session of the original client")
+	}
+}
@@ -711,7 +711,7 @@ func spawnTestVdc(vcd *TestVCD, check *C, adminOrgName string) *Vdc {
 	networkPoolHref := getVdcNetworkPoolHref(vcd, check)
 
 	vdcConfiguration := &types.VdcConfiguration{
-		Name:            check.TestName() + "-VDC",
+		Name:            testResourceName(check, "VDC"),
 		Xmlns:           types.XMLNamespaceVCloud,
 		AllocationModel: "Flex",
 		ComputeCapacity: []*types.ComputeCapacity{
@@ -755,7 +755,7 @@ func spawnTestVdc(vcd *TestVCD, check *C, adminOrgName string) *Vdc {
 	check.Assert(err, IsNil)
 	check.Assert(vdc, NotNil)
 
-	AddToCleanupList(vdcConfiguration.Name, "vdc", vcd.org.Org.Name, check.TestName())
+	AddToCleanupList(vdcConfiguration.Name, "vdc", adminOrgName, check.TestName())
 
 	return vdc
 }
@@ -764,7 +764,7 @@ func spawnTestVdc(vcd *TestVCD, check *C, adminOrgName string) *Vdc {
 func spawnTestOrg(vcd *TestVCD, check *C, nameSuffix string) string {
 	newOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
 	check.Assert(err, IsNil)
-	newOrgName := check.TestName() + "-" + nameSuffix
+	newOrgName := testResourceName(check, nameSuffix)
 	task, err := CreateOrg(ctx, vcd.client, newOrgName, newOrgName, newOrgName, newOrg.AdminOrg.OrgSettings, true)
 	check.Assert(err, IsNil)
 	err = task.WaitTaskCompletion(ctx)
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// ErrorNotModified is returned by the RefreshIfModified methods when the object did not change since it was last
+// retrieved. The object is left untouched in that case.
+var ErrorNotModified = errors.New("object not modified")
+
+// RefreshIfModified refreshes the task only if it changed since the previous call, using the ETag returned by VCD.
+// It returns ErrorNotModified when the task did not change. The first call always retrieves the task.
+func (task *Task) RefreshIfModified(ctx context.Context) error {
+	if task.Task == nil || task.Task.HREF == "" {
+		return fmt.Errorf("cannot refresh, Object is empty")
+	}
+	req := task.client.NewRequest(ctx, map[string]string{}, http.MethodGet, *urlParseRequestURI(task.Task.HREF), nil)
+	refreshedTask := &types.Task{}
+	etag, err := conditionalGet(task.client, req, task.etag, types.BodyTypeXML, &types.Error{}, refreshedTask)
+	if err != nil {
+		return err
+	}
+	task.Task = refreshedTask
+	task.etag = etag
+	return nil
+}
+
+// RefreshIfModified refreshes the vApp only if it changed since the previous call, using the ETag returned by VCD.
+// It returns ErrorNotModified when the vApp did not change. The first call always retrieves the vApp.
+func (vapp *VApp) RefreshIfModified(ctx context.Context) error {
+	if vapp.VApp == nil || vapp.VApp.HREF == "" {
+		return fmt.Errorf("cannot refresh, Object is empty")
+	}
+	req := vapp.client.NewRequest(ctx, map[string]string{}, http.MethodGet, *urlParseRequestURI(vapp.VApp.HREF), nil)
+	refreshedVApp := &types.VApp{}
+	etag, err := conditionalGet(vapp.client, req, vapp.etag, types.BodyTypeXML, &types.Error{}, refreshedVApp)
+	if err != nil {
+		return err
+	}
+	vapp.VApp = refreshedVApp
+	vapp.etag = etag
+	return nil
+}
+
+// RefreshIfModified refreshes the NSX-T Edge Gateway only if it changed since the previous call, using the ETag
+// returned by VCD. It returns ErrorNotModified when the Edge Gateway did not change. The first call always
+// retrieves the Edge Gateway.
+func (egw *NsxtEdgeGateway) RefreshIfModified(ctx context.Context) error {
+	if egw.EdgeGateway == nil || egw.client == nil || egw.EdgeGateway.ID == "" {
+		return fmt.Errorf("cannot refresh Edge Gateway without ID")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGateways
+	apiVersion, err := egw.client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	urlRef, err := egw.client.OpenApiBuildEndpoint(endpoint, egw.EdgeGateway.ID)
+	if err != nil {
+		return err
+	}
+
+	req := egw.client.newOpenApiRequest(ctx, apiVersion, nil, http.MethodGet, urlRef, nil, nil)
+	refreshedEdge := &types.OpenAPIEdgeGateway{}
+	etag, err := conditionalGet(egw.client, req, egw.etag, types.BodyTypeJSON, &types.OpenApiError{}, refreshedEdge)
+	if err != nil {
+		return err
+	}
+	egw.EdgeGateway = refreshedEdge
+	egw.etag = etag
+	return nil
+}
+
+// conditionalGet sends the GET request req with the header If-None-Match set to etag, when not empty.
+// When VCD answers with HTTP 304 (Not Modified), it returns ErrorNotModified and outType is not changed. Otherwise,
+// the response is decoded into outType and its ETag is returned.
+func conditionalGet(client *Client, req *http.Request, etag string, bodyType types.BodyType, errType error, outType interface{}) (string, error) {
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := client.Http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error performing GET request to %s: %w", req.URL.String(), err)
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		util.Logger.Printf("[TRACE] %s not modified (ETag %s)", req.URL.String(), etag)
+		err = resp.Body.Close()
+		if err != nil {
+			return "", fmt.Errorf("error closing response body: %w", err)
+		}
+		return etag, ErrorNotModified
+	}
+
+	resp, err = checkRespWithErrType(bodyType, resp, nil, errType)
+	if err != nil {
+		return "", fmt.Errorf("error in HTTP GET request: %w", err)
+	}
+	err = decodeBody(bodyType, resp, outType)
+	if err != nil {
+		return "", fmt.Errorf("error decoding response after GET: %w", err)
+	}
+	err = resp.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("error closing response body: %w", err)
+	}
+	return resp.Header.Get("Etag"), nil
+}
@@ -0,0 +1,66 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func Test_TaskRefreshIfModified(t *testing.T) {
+	status := "running"
+	etag := `"1"`
+	var ifNoneMatch []string
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Etag", etag)
+		w.Header().Set("Content-Type", "application/vnd.vmware.vcloud.task+xml")
+		_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" href="%s" status="%s"/>`, server.URL+r.URL.Path, status)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	task := NewTask(&NewVCDClient(*serverUrl, true).Client)
+	task.Task.HREF = server.URL + "/api/task/1"
+
+	err = task.RefreshIfModified(context.Background())
+	if err != nil || task.Task.Status != "running" {
+		t.Fatalf("unexpected result of first refresh: %v, status %s", err, task.Task.Status)
+	}
+
+	err = task.RefreshIfModified(context.Background())
+	if !errors.Is(err, ErrorNotModified) {
+		t.Fatalf("expected ErrorNotModified, got: %v", err)
+	}
+	if task.Task == nil || task.Task.Status != "running" {
+		t.Fatalf("task changed after a not modified response")
+	}
+
+	status, etag = "success", `"2"`
+	err = task.RefreshIfModified(context.Background())
+	if err != nil || task.Task.Status != "success" {
+		t.Fatalf("unexpected result after modification: %v, status %s", err, task.Task.Status)
+	}
+
+	expected := []string{"", `"1"`, `"1"`}
+	if fmt.Sprint(ifNoneMatch) != fmt.Sprint(expected) {
+		t.Errorf("expected If-None-Match headers %v, got %v", expected, ifNoneMatch)
+	}
+}
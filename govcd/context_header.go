@@ -0,0 +1,163 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// contextHeadersKey is the key of the headers stored in a context by WithHeaders
+type contextHeadersKey struct{}
+
+// WithHeaders returns a copy of ctx carrying HTTP headers to add to the requests made with it, such as tracing
+// headers or feature toggles needed by a single call. Unlike Client.SetCustomHeader, the headers only apply to the
+// calls receiving the returned context, so they are safe to use with a client shared by many goroutines.
+//
+// The headers are added by the XML (NewRequest) and OpenAPI request builders, replacing the ones with the same name
+// set by the client. Headers of a parent context are kept, unless headers overrides them.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	merged := make(http.Header)
+	for name, values := range headersFromContext(ctx) {
+		merged[name] = values
+	}
+	for name, value := range headers {
+		merged.Set(name, value)
+	}
+	return context.WithValue(ctx, contextHeadersKey{}, merged)
+}
+
+// headersFromContext returns the headers stored in ctx by WithHeaders, or nil
+func headersFromContext(ctx context.Context) http.Header {
+	if ctx == nil {
+		return nil
+	}
+	headers, _ := ctx.Value(contextHeadersKey{}).(http.Header)
+	return headers
+}
+
+// setContextHeaders sets on req the headers stored in its context by WithHeaders
+func setContextHeaders(req *http.Request) {
+	for name, values := range headersFromContext(req.Context()) {
+		req.Header[name] = append([]string(nil), values...)
+	}
+}
+
+// DefaultCollectedResponseHeaders are the response headers gathered by a ResponseHeaderCollector created without
+// header names: request ID and execution time, rate limit hints and deprecation warnings
+var DefaultCollectedResponseHeaders = []string{
+	types.HeaderRequestId,
+	types.HeaderRequestExecutionTime,
+	"Retry-After",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+	"Deprecation",
+	"Sunset",
+	"Warning",
+}
+
+// CollectedResponse holds the selected headers of a response gathered by a ResponseHeaderCollector
+type CollectedResponse struct {
+	Method     string
+	Url        string
+	StatusCode int
+	Header     http.Header // Only the selected headers returned in the response
+}
+
+// ResponseHeaderCollector gathers selected headers of the responses to the requests made with a context returned by
+// WithResponseHeaderCollector. It is safe for concurrent use.
+type ResponseHeaderCollector struct {
+	names     []string
+	lock      sync.Mutex
+	responses []CollectedResponse
+}
+
+// contextResponseHeaderCollectorKey is the key of the collector stored in a context by WithResponseHeaderCollector
+type contextResponseHeaderCollectorKey struct{}
+
+// NewResponseHeaderCollector creates a collector of the response headers with the given names. Without names, it
+// gathers DefaultCollectedResponseHeaders
+func NewResponseHeaderCollector(names ...string) *ResponseHeaderCollector {
+	if len(names) == 0 {
+		names = DefaultCollectedResponseHeaders
+	}
+	collector := &ResponseHeaderCollector{}
+	for _, name := range names {
+		collector.names = append(collector.names, http.CanonicalHeaderKey(name))
+	}
+	return collector
+}
+
+// WithResponseHeaderCollector returns a copy of ctx that makes collector gather the headers of the responses
+// received by the calls using it, such as the request ID to report a problem to the VCD administrators. The return
+// values of the calls are not changed.
+//
+// The responses are gathered by ExecuteRequest and the other XML request helpers, and by the OpenAPI helpers,
+// including the responses with an error status.
+func WithResponseHeaderCollector(ctx context.Context, collector *ResponseHeaderCollector) context.Context {
+	return context.WithValue(ctx, contextResponseHeaderCollectorKey{}, collector)
+}
+
+// Responses returns the responses gathered so far, in the order they were received
+func (collector *ResponseHeaderCollector) Responses() []CollectedResponse {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	responses := make([]CollectedResponse, len(collector.responses))
+	for index, response := range collector.responses {
+		response.Header = response.Header.Clone()
+		responses[index] = response
+	}
+	return responses
+}
+
+// Last returns the value of the header with the given name in the most recent response returning it, or an empty
+// string
+func (collector *ResponseHeaderCollector) Last(name string) string {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	for index := len(collector.responses) - 1; index >= 0; index-- {
+		if value := collector.responses[index].Header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Reset discards the responses gathered so far
+func (collector *ResponseHeaderCollector) Reset() {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.responses = nil
+}
+
+// collectResponseHeaders adds resp to the collector stored in the context of its request by
+// WithResponseHeaderCollector, if any
+func collectResponseHeaders(resp *http.Response) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+	collector, _ := resp.Request.Context().Value(contextResponseHeaderCollectorKey{}).(*ResponseHeaderCollector)
+	if collector == nil {
+		return
+	}
+	response := CollectedResponse{
+		Method:     resp.Request.Method,
+		Url:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     make(http.Header),
+	}
+	for _, name := range collector.names {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			response.Header[name] = append([]string(nil), values...)
+		}
+	}
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.responses = append(collector.responses, response)
+}
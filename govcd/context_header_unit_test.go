@@ -0,0 +1,158 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestWithHeaders(t *testing.T) {
+	reqUrl, err := url.Parse("https://vcd.example.com/api/org")
+	if err != nil {
+		t.Fatalf("error parsing URL: %s", err)
+	}
+	client := &Client{}
+	client.SetCustomHeader(map[string]string{"X-Feature": "client", "X-Client-Only": "yes"})
+
+	parent := WithHeaders(context.Background(), map[string]string{"X-Trace-Id": "parent", "X-Span-Id": "1"})
+	ctx := WithHeaders(parent, map[string]string{"X-Trace-Id": "child", "X-Feature": "call"})
+
+	requests := map[string]*http.Request{
+		"XML":     client.NewRequest(ctx, nil, http.MethodGet, *reqUrl, nil),
+		"OpenAPI": client.newOpenApiRequest(ctx, "37.0", nil, http.MethodGet, reqUrl, nil, nil),
+	}
+	expected := map[string]string{
+		"X-Trace-Id":    "child",
+		"X-Span-Id":     "1",
+		"X-Feature":     "call",
+		"X-Client-Only": "yes",
+	}
+	for name, req := range requests {
+		for header, value := range expected {
+			if got := req.Header.Values(header); len(got) != 1 || got[0] != value {
+				t.Errorf("%s request: expected header %s=%s, got %v", name, header, value, got)
+			}
+		}
+	}
+
+	// The parent context is not changed
+	if got := headersFromContext(parent).Get("X-Trace-Id"); got != "parent" {
+		t.Errorf("expected parent header to be unchanged, got %s", got)
+	}
+
+	// Requests without context headers are not affected
+	req := client.NewRequest(context.Background(), nil, http.MethodGet, *reqUrl, nil)
+	if req.Header.Get("X-Trace-Id") != "" || req.Header.Get("X-Feature") != "client" {
+		t.Errorf("unexpected headers in request without context headers: %v", req.Header)
+	}
+}
+
+func TestWithResponseHeaderCollector(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(types.HeaderRequestId, r.Method+" "+r.URL.Path)
+		w.Header().Set("X-Unselected", "value")
+		switch r.URL.Path {
+		case "/api/org":
+			w.Header().Set("Content-Type", types.MimeOrgList)
+			w.Header().Add("Warning", "299 - first")
+			w.Header().Add("Warning", "299 - second")
+			_, _ = w.Write([]byte(`<OrgList xmlns="http://www.vmware.com/vcloud/v1.5"></OrgList>`))
+		case "/cloudapi/1.0.0/orgs/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"minorErrorCode":"ACCESS_TO_RESOURCE_IS_FORBIDDEN","message":"forbidden"}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"minorErrorCode":"TOO_MANY_REQUESTS","message":"slow down"}`))
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"36.0", "37.0"})
+	vcdClient.Client.APIVersion = "37.0"
+	client := &vcdClient.Client
+
+	collector := NewResponseHeaderCollector()
+	ctx := WithResponseHeaderCollector(context.Background(), collector)
+
+	orgList := &types.OrgList{}
+	_, err = client.ExecuteRequest(ctx, server.URL+"/api/org", http.MethodGet, types.MimeOrgList, "error: %s", nil, orgList)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, path := range []string{"orgs/missing", "orgs/busy"} {
+		endpoint, err := client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, path)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		err = client.OpenApiGetItem(ctx, "37.0", endpoint, nil, &types.OpenApiOrgVdcNetwork{}, nil)
+		if err == nil {
+			t.Fatalf("expected error getting %s", path)
+		}
+	}
+
+	responses := collector.Responses()
+	if len(responses) != 3 {
+		t.Fatalf("got %d responses, expected 3: %+v", len(responses), responses)
+	}
+	if responses[0].Method != http.MethodGet || responses[0].StatusCode != http.StatusOK ||
+		len(responses[0].Header.Values("Warning")) != 2 || responses[0].Header.Get("X-Unselected") != "" {
+		t.Errorf("unexpected first response: %+v", responses[0])
+	}
+	if responses[1].StatusCode != http.StatusForbidden || responses[2].StatusCode != http.StatusTooManyRequests {
+		t.Errorf("unexpected status of error responses: %d, %d", responses[1].StatusCode, responses[2].StatusCode)
+	}
+	if got := collector.Last(types.HeaderRequestId); got != "GET /cloudapi/1.0.0/orgs/busy" {
+		t.Errorf("got last request ID '%s', expected 'GET /cloudapi/1.0.0/orgs/busy'", got)
+	}
+	if got := collector.Last("retry-after"); got != "10" {
+		t.Errorf("got last Retry-After '%s', expected '10'", got)
+	}
+	if got := collector.Last("Warning"); got != "299 - first" {
+		t.Errorf("got last Warning '%s', expected '299 - first'", got)
+	}
+
+	// Only the selected headers are gathered, and calls without the collector are not recorded
+	collector.Reset()
+	selective := NewResponseHeaderCollector("x-unselected")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = client.ExecuteRequest(WithResponseHeaderCollector(context.Background(), selective),
+				server.URL+"/api/org", http.MethodGet, types.MimeOrgList, "error: %s", nil, &types.OrgList{})
+		}()
+	}
+	wg.Wait()
+	if len(collector.Responses()) != 0 {
+		t.Errorf("got responses after reset: %+v", collector.Responses())
+	}
+	responses = selective.Responses()
+	if len(responses) != 4 {
+		t.Fatalf("got %d responses, expected 4", len(responses))
+	}
+	for _, response := range responses {
+		if len(response.Header) != 1 || response.Header.Get("X-Unselected") != "value" {
+			t.Errorf("unexpected headers gathered: %v", response.Header)
+		}
+	}
+}
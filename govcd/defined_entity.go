@@ -6,10 +6,15 @@ package govcd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"io"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
 
 // DefinedEntityType is a type for handling Runtime Defined Entity (RDE) Type definitions.
@@ -342,6 +347,42 @@ func (vcdClient *VCDClient) CreateRde(ctx context.Context, vendor, nss, version
 	return pollPreCreatedRde(ctx, &vcdClient.Client, vendor, nss, version, entity.Name, 5)
 }
 
+// CreateRdeFromReader creates an entity of the type of the receiver Runtime Defined Entity (RDE) type, reading the
+// entity JSON from a reader, so that large contents are streamed to VCD instead of being built in memory.
+// entitySize is the length in bytes of the entity JSON, or -1 when it is not known, in which case the request is sent
+// with chunked transfer encoding. The size of the request can be limited with Client.MaxRequestBodySize.
+// NOTE: After RDE creation, some actor should Resolve it, otherwise the RDE state will be "PRE_CREATED"
+// and the generated VCD task will remain at 1% until resolved.
+func (rdeType *DefinedEntityType) CreateRdeFromReader(ctx context.Context, name string, entity io.Reader, entitySize int64, tenantContext *TenantContext) (*DefinedEntity, error) {
+	if name == "" {
+		return nil, fmt.Errorf("the name of the Runtime Defined Entity is empty")
+	}
+	if entity == nil {
+		return nil, fmt.Errorf("the entity JSON reader is empty")
+	}
+
+	// The entity JSON is streamed between the other fields and the closing brace of the payload
+	header, err := json.Marshal(types.DefinedEntity{EntityType: rdeType.DefinedEntityType.ID, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling Runtime Defined Entity '%s': %w", name, err)
+	}
+	prefix := string(header[:len(header)-1]) + `,"entity":`
+	suffix := "}"
+	body := &OpenApiStreamBody{
+		Reader: io.MultiReader(strings.NewReader(prefix), entity, strings.NewReader(suffix)),
+		Size:   -1,
+	}
+	if entitySize >= 0 {
+		body.Size = int64(len(prefix)) + entitySize + int64(len(suffix))
+	}
+
+	err = postRde(ctx, rdeType.client, rdeType.DefinedEntityType.ID, body, tenantContext)
+	if err != nil {
+		return nil, err
+	}
+	return pollPreCreatedRde(ctx, rdeType.client, rdeType.DefinedEntityType.Vendor, rdeType.DefinedEntityType.Nss, rdeType.DefinedEntityType.Version, name, 5)
+}
+
 // CreateRde creates an entity of the type of the receiver Runtime Defined Entity (RDE) type.
 // The input doesn't need to specify the type ID, as it gets it from the receiver RDE type. If it is specified anyway,
 // it must match the type ID of the receiver RDE type.
@@ -356,18 +397,24 @@ func createRde(ctx context.Context, client *Client, entity types.DefinedEntity,
 		return fmt.Errorf("the entity JSON is empty")
 	}
 
+	return postRde(ctx, client, entity.EntityType, entity, tenantContext)
+}
+
+// postRde sends the creation request of an RDE of the given type. The payload is either a types.DefinedEntity or
+// an *OpenApiStreamBody
+func postRde(ctx context.Context, client *Client, entityType string, payload interface{}, tenantContext *TenantContext) error {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRdeEntityTypes
 	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
 	if err != nil {
 		return err
 	}
 
-	urlRef, err := client.OpenApiBuildEndpoint(endpoint, entity.EntityType)
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, entityType)
 	if err != nil {
 		return err
 	}
 
-	_, err = client.OpenApiPostItemAsyncWithHeaders(ctx, apiVersion, urlRef, nil, entity, getTenantContextHeader(tenantContext))
+	_, err = client.OpenApiPostItemAsyncWithHeaders(ctx, apiVersion, urlRef, nil, payload, getTenantContextHeader(tenantContext))
 	if err != nil {
 		return err
 	}
@@ -379,22 +426,33 @@ func createRde(ctx context.Context, client *Client, entity types.DefinedEntity,
 // This function can be useful on RDE creation, as VCD just returns a task that remains at 1% until the RDE is resolved,
 // hence one needs to re-fetch the recently created RDE manually.
 func pollPreCreatedRde(ctx context.Context, client *Client, vendor, nss, version, name string, tries int) (*DefinedEntity, error) {
-	var rdes []*DefinedEntity
+	var preCreatedRde *DefinedEntity
 	var err error
-	for i := 0; i < tries; i++ {
+	waitErr := WaitForState(ctx, func() (bool, error) {
+		var rdes []*DefinedEntity
 		rdes, err = getRdesByName(ctx, client, vendor, nss, version, name)
-		if err == nil {
-			for _, rde := range rdes {
-				// This doesn't really guarantee that the chosen RDE is the one we want, but there's no other way of
-				// fine-graining
-				if rde.DefinedEntity.State != nil && *rde.DefinedEntity.State == "PRE_CREATED" {
-					return rde, nil
-				}
+		if err != nil {
+			// The RDE may not be retrievable yet
+			return false, nil
+		}
+		for _, rde := range rdes {
+			// This doesn't really guarantee that the chosen RDE is the one we want, but there's no other way of
+			// fine-graining
+			if rde.DefinedEntity.State != nil && *rde.DefinedEntity.State == "PRE_CREATED" {
+				preCreatedRde = rde
+				return true, nil
 			}
 		}
-		time.Sleep(3 * time.Second)
+		return false, nil
+	}, WaitOptions{InitialInterval: 3 * time.Second, Multiplier: 1, MaxAttempts: tries})
+	if waitErr != nil {
+		// When all the attempts were used, the last retrieval error explains better why the RDE was not found
+		if errors.Is(waitErr, ErrorWaitTimeout) && err != nil {
+			waitErr = err
+		}
+		return nil, fmt.Errorf("could not create RDE, failed during retrieval after creation: %w", waitErr)
 	}
-	return nil, fmt.Errorf("could not create RDE, failed during retrieval after creation: %s", err)
+	return preCreatedRde, nil
 }
 
 // Resolve needs to be called after an RDE is successfully created. It makes the receiver RDE usable if the JSON entity
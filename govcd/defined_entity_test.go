@@ -165,6 +165,7 @@ func (vcd *TestVCD) Test_RdeAndRdeType(check *C) {
 	check.Assert(obtainedRdeTypeBySysAdmin.DefinedEntityType.Description, Equals, rdeTypeToCreate.Description+"UpdatedByAdmin")
 
 	testRdeCrudWithGivenType(check, obtainedRdeTypeBySysAdmin)
+	testRdeCreateFromReader(check, obtainedRdeTypeBySysAdmin)
 	testRdeCrudAsTenant(check, obtainedRdeTypeByTenant.DefinedEntityType.Vendor, obtainedRdeTypeByTenant.DefinedEntityType.Nss, obtainedRdeTypeByTenant.DefinedEntityType.Version, vcd.client)
 
 	// We delete it with Sysadmin
@@ -253,6 +254,29 @@ func testRdeCrudWithGivenType(check *C, rdeType *DefinedEntityType) {
 	check.Assert(strings.Contains(err.Error(), ErrorEntityNotFound.Error()), Equals, true)
 }
 
+// testRdeCreateFromReader is a sub-section of Test_Rde that creates RDE instances streaming their contents, with known
+// and unknown size
+func testRdeCreateFromReader(check *C, rdeType *DefinedEntityType) {
+	rdeEntityJson := `{"foo": {"key": "stringValue1"}, "bar": "stringValue2"}`
+
+	for _, size := range []int64{int64(len(rdeEntityJson)), -1} {
+		name := fmt.Sprintf("%s_%d", check.TestName(), size)
+		rde, err := rdeType.CreateRdeFromReader(ctx, name, strings.NewReader(rdeEntityJson), size, nil)
+		check.Assert(err, IsNil)
+		check.Assert(rde.DefinedEntity.Name, Equals, name)
+		check.Assert(*rde.DefinedEntity.State, Equals, "PRE_CREATED")
+
+		err = rde.Resolve(ctx)
+		check.Assert(err, IsNil)
+		AddToCleanupListOpenApi(rde.DefinedEntity.ID, check.TestName(), types.OpenApiPathVersion1_0_0+types.OpenApiEndpointRdeEntities+rde.DefinedEntity.ID)
+		check.Assert(*rde.DefinedEntity.State, Equals, "RESOLVED")
+		check.Assert(rde.DefinedEntity.Entity["bar"], Equals, "stringValue2")
+
+		err = rde.Delete(ctx)
+		check.Assert(err, IsNil)
+	}
+}
+
 // testRdeCrudAsTenant is a sub-section of Test_Rde that is focused on testing all RDE instances casuistics without specifying the
 // RDE type. This would be the viewpoint of a tenant as they can't get RDE types.
 func testRdeCrudAsTenant(check *C, vendor string, namespace string, version string, vcdClient *VCDClient) {
@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"net/url"
-	"strings"
 )
 
 // DefinedInterface is a type for handling Defined Interfaces, from the Runtime Defined Entities framework, in VCD.
@@ -196,8 +195,8 @@ func (di *DefinedInterface) Delete(ctx context.Context) error {
 // amendRdeApiError fixes a wrong type of error returned by VCD API <= v36.0 on GET operations
 // when the defined interface does not exist.
 func amendRdeApiError(client *Client, err error) error {
-	if client.APIClientVersionIs("<= 36.0") && err != nil && strings.Contains(err.Error(), "does not exist") {
-		return fmt.Errorf("%s: %s", ErrorEntityNotFound.Error(), err)
+	if client.APIClientVersionIs("<= 36.0") {
+		return mapServerError(err, serverErrorScopeDefinedInterface)
 	}
 	return err
 }
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrorUnsupportedDeleteOption is returned by the DeleteWithOptions methods when an option is not supported by the
+// deletion of the object type
+var ErrorUnsupportedDeleteOption = errors.New("unsupported delete option")
+
+// DeleteOptions are the options of the DeleteWithOptions methods, which delete an object and wait for the deletion
+// to complete. Each object type honors the options that VCD supports for it, and rejects the others with
+// ErrorUnsupportedDeleteOption, so that an option is never silently ignored:
+//
+//   - VApp: Force undeploys (powering off) the vApp before deleting it. Recursive is accepted, as the VMs are always
+//     deleted with the vApp
+//   - Catalog and AdminCatalog: Force and Recursive are passed to VCD. Force with Recursive also cancels the tasks
+//     of the catalog and of its items
+//   - Vdc: Force and Recursive are passed to VCD
+//   - EdgeGateway (NSX-V): Force and Recursive are passed to VCD
+//   - OpenApiOrgVdcNetwork: Force is passed to VCD, where it implies Recursive. Recursive is only accepted with Force
+//   - OrgVDCNetwork and NsxtEdgeGateway: no option is supported
+type DeleteOptions struct {
+	// Force deletes the object even when it is in a state that would not allow its deletion
+	Force bool
+	// Recursive deletes the objects contained in the object
+	Recursive bool
+}
+
+// validate returns an ErrorUnsupportedDeleteOption when an option is set that is not supported by the object type
+func (options DeleteOptions) validate(objectType string, forceSupported, recursiveSupported bool) error {
+	if options.Force && !forceSupported {
+		return fmt.Errorf("%w: the deletion of %s doesn't support Force", ErrorUnsupportedDeleteOption, objectType)
+	}
+	if options.Recursive && !recursiveSupported {
+		return fmt.Errorf("%w: the deletion of %s doesn't support Recursive", ErrorUnsupportedDeleteOption, objectType)
+	}
+	return nil
+}
+
+// queryParams returns the options as the 'force' and 'recursive' query parameters of the VCD API
+func (options DeleteOptions) queryParams() map[string]string {
+	return map[string]string{
+		"force":     strconv.FormatBool(options.Force),
+		"recursive": strconv.FormatBool(options.Recursive),
+	}
+}
@@ -0,0 +1,59 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func Test_DeleteOptionsValidate(t *testing.T) {
+	type testCase struct {
+		options            DeleteOptions
+		forceSupported     bool
+		recursiveSupported bool
+		wantErr            bool
+	}
+	testCases := []testCase{
+		{DeleteOptions{}, false, false, false},
+		{DeleteOptions{Force: true, Recursive: true}, true, true, false},
+		{DeleteOptions{Force: true}, true, false, false},
+		{DeleteOptions{Force: true}, false, true, true},
+		{DeleteOptions{Recursive: true}, true, false, true},
+		{DeleteOptions{Force: true, Recursive: true}, true, false, true},
+	}
+	for index, tc := range testCases {
+		err := tc.options.validate("object", tc.forceSupported, tc.recursiveSupported)
+		if tc.wantErr != (err != nil) {
+			t.Errorf("test case %d: expected error %t, got %v", index, tc.wantErr, err)
+		}
+		if err != nil && !errors.Is(err, ErrorUnsupportedDeleteOption) {
+			t.Errorf("test case %d: expected ErrorUnsupportedDeleteOption, got %s", index, err)
+		}
+	}
+
+	params := DeleteOptions{Force: true}.queryParams()
+	if params["force"] != "true" || params["recursive"] != "false" {
+		t.Errorf("unexpected query parameters %v", params)
+	}
+}
+
+func Test_DeleteWithUnsupportedOptions(t *testing.T) {
+	// The options are validated before any request, so no client is needed
+	ctx := context.Background()
+	errs := map[string]error{
+		"NsxtEdgeGateway":                 (&NsxtEdgeGateway{}).DeleteWithOptions(ctx, DeleteOptions{Force: true}),
+		"OrgVDCNetwork":                   (&OrgVDCNetwork{}).DeleteWithOptions(ctx, DeleteOptions{Recursive: true}),
+		"OpenApiOrgVdcNetwork, Recursive": (&OpenApiOrgVdcNetwork{}).DeleteWithOptions(ctx, DeleteOptions{Recursive: true}),
+	}
+	for name, err := range errs {
+		if !errors.Is(err, ErrorUnsupportedDeleteOption) {
+			t.Errorf("%s: expected ErrorUnsupportedDeleteOption, got %v", name, err)
+		}
+	}
+}
@@ -126,7 +126,7 @@ func (disk *Disk) Update(ctx context.Context, newDiskInfo *types.Disk) (Task, er
 	// Verify the independent disk is not connected to any VM
 	vmRef, err := disk.AttachedVM(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error find attached VM: %s", err)
+		return Task{}, fmt.Errorf("error find attached VM: %w", err)
 	}
 	if vmRef != nil {
 		return Task{}, errors.New("error disk is attached")
@@ -161,6 +161,12 @@ func (disk *Disk) Update(ctx context.Context, newDiskInfo *types.Disk) (Task, er
 		StorageProfile: newDiskInfo.StorageProfile,
 		Owner:          newDiskInfo.Owner,
 	}
+	// The bus type can only be changed while the disk is detached: it is sent only when it changes, to keep the
+	// payload accepted by VCD versions that don't support changing it
+	if newDiskInfo.BusType != "" && (newDiskInfo.BusType != disk.Disk.BusType || newDiskInfo.BusSubType != disk.Disk.BusSubType) {
+		xmlPayload.BusType = newDiskInfo.BusType
+		xmlPayload.BusSubType = newDiskInfo.BusSubType
+	}
 
 	// Return the task
 	return disk.client.ExecuteTaskRequestWithApiVersion(ctx, updateDiskLink.HREF, http.MethodPut,
@@ -184,7 +190,7 @@ func (disk *Disk) Delete(ctx context.Context) (Task, error) {
 	// Verify the independent disk is not connected to any VM
 	vmRef, err := disk.AttachedVM(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error find attached VM: %s", err)
+		return Task{}, fmt.Errorf("error find attached VM: %w", err)
 	}
 	if vmRef != nil {
 		return Task{}, errors.New("error disk is attached")
@@ -324,7 +330,7 @@ func (vdc *Vdc) QueryDisk(ctx context.Context, diskName string) (DiskRecord, err
 		"filter": "name==" + url.QueryEscape(diskName) + ";vdc==" + vdc.vdcId(), "filterEncoded": "true"},
 		vdc.client.GetSpecificApiVersionOnCondition(ctx, ">= 36.0", "36.0"))
 	if err != nil {
-		return DiskRecord{}, fmt.Errorf("error querying disk %s", err)
+		return DiskRecord{}, fmt.Errorf("error querying disk %w", err)
 	}
 
 	diskResults := results.Results.DiskRecord
@@ -359,7 +365,7 @@ func (vdc *Vdc) QueryDisks(ctx context.Context, diskName string) (*[]*types.Disk
 		"filter": "name==" + url.QueryEscape(diskName) + ";vdc==" + vdc.vdcId(), "filterEncoded": "true"},
 		vdc.client.GetSpecificApiVersionOnCondition(ctx, ">= 36.0", "36.0"))
 	if err != nil {
-		return nil, fmt.Errorf("error querying disks %s", err)
+		return nil, fmt.Errorf("error querying disks %w", err)
 	}
 
 	diskResults := results.Results.DiskRecord
@@ -380,7 +386,7 @@ func (vdc *Vdc) GetDiskByHref(ctx context.Context, diskHref string) (*Disk, erro
 	_, err := vdc.client.ExecuteRequestWithApiVersion(ctx, diskHref, http.MethodGet,
 		"", "error retrieving Disk: %s", nil, Disk.Disk,
 		vdc.client.GetSpecificApiVersionOnCondition(ctx, ">= 36.0", "36.0"))
-	if err != nil && (strings.Contains(err.Error(), "MajorErrorCode:403") || strings.Contains(err.Error(), "does not exist")) {
+	if errors.Is(mapServerError(err, serverErrorScopeDisk), ErrorEntityNotFound) {
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
@@ -485,3 +491,120 @@ func (disk *Disk) GetAttachedVmsHrefs(ctx context.Context) ([]string, error) {
 
 	return vmHrefs, nil
 }
+
+// DiskAttachOptions are the optional settings used by Disk.AttachToVm and Disk.AttachToVms
+type DiskAttachOptions struct {
+	// BusType and BusSubType select the controller used for the disk (e.g. types.DiskBusTypeScsi and
+	// types.DiskBusSubTypeParavirtual). When they differ from the ones of the disk, the disk is updated before being
+	// attached, which requires it to be detached from all VMs. When BusType is empty, the disk keeps its bus type
+	BusType    string
+	BusSubType string
+	// BusNumber and UnitNumber select the position of the disk on the controller. When they are nil, VCD picks the
+	// first free one
+	BusNumber  *int
+	UnitNumber *int
+}
+
+// IsShared returns true when the disk can be attached to several VMs at the same time
+func (disk *Disk) IsShared() bool {
+	return disk.Disk.Shareable ||
+		(disk.Disk.SharingType != "" && disk.Disk.SharingType != types.DiskSharingTypeNone)
+}
+
+// AttachToVm attaches the disk to a VM, optionally selecting the controller bus type and position, and waits for
+// the task to complete
+func (disk *Disk) AttachToVm(ctx context.Context, vm *VM, options *DiskAttachOptions) error {
+	return disk.AttachToVms(ctx, []*VM{vm}, options)
+}
+
+// AttachToVms attaches the disk to one or more VMs, one after the other, with the same options. Attaching the disk
+// to more than one VM requires a shared disk (see Disk.IsShared), which VCD only allows for some controller types
+// (e.g. Paravirtual SCSI).
+// If an attachment fails, the disk remains attached to the VMs processed before, which are listed in the error
+func (disk *Disk) AttachToVms(ctx context.Context, vms []*VM, options *DiskAttachOptions) error {
+	if len(vms) == 0 {
+		return fmt.Errorf("no VMs given to attach disk '%s'", disk.Disk.Name)
+	}
+	if len(vms) > 1 && !disk.IsShared() {
+		return fmt.Errorf("disk '%s' is not shared and cannot be attached to %d VMs", disk.Disk.Name, len(vms))
+	}
+	if options == nil {
+		options = &DiskAttachOptions{}
+	}
+
+	if diskNeedsBusTypeChange(disk.Disk, options) {
+		err := disk.updateBusType(ctx, options.BusType, options.BusSubType)
+		if err != nil {
+			return err
+		}
+	}
+
+	var attachedVms []string
+	for _, vm := range vms {
+		task, err := vm.AttachDisk(ctx, &types.DiskAttachOrDetachParams{
+			Disk:       &types.Reference{HREF: disk.Disk.HREF},
+			BusNumber:  options.BusNumber,
+			UnitNumber: options.UnitNumber,
+		})
+		if err == nil {
+			err = task.WaitTaskCompletion(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("error attaching disk '%s' to VM '%s' (already attached to %v): %s",
+				disk.Disk.Name, vm.VM.Name, attachedVms, err)
+		}
+		attachedVms = append(attachedVms, vm.VM.Name)
+	}
+	return disk.Refresh(ctx)
+}
+
+// GetAttachedVms returns the VMs the disk is attached to, or an empty slice when it is not attached
+func (disk *Disk) GetAttachedVms(ctx context.Context) ([]*VM, error) {
+	vmHrefs, err := disk.GetAttachedVmsHrefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vms := make([]*VM, 0, len(vmHrefs))
+	for _, vmHref := range vmHrefs {
+		vm, err := disk.client.GetVMByHref(ctx, vmHref)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving VM attached to disk '%s': %w", disk.Disk.Name, err)
+		}
+		vms = append(vms, vm)
+	}
+	return vms, nil
+}
+
+// updateBusType changes the bus type of a detached disk and waits for the task to complete
+func (disk *Disk) updateBusType(ctx context.Context, busType, busSubType string) error {
+	vmHrefs, err := disk.GetAttachedVmsHrefs(ctx)
+	if err != nil {
+		return err
+	}
+	if len(vmHrefs) > 0 {
+		return fmt.Errorf("cannot change bus type of disk '%s' while it is attached to %d VMs", disk.Disk.Name, len(vmHrefs))
+	}
+
+	newDiskInfo := *disk.Disk
+	newDiskInfo.BusType = busType
+	newDiskInfo.BusSubType = busSubType
+	task, err := disk.Update(ctx, &newDiskInfo)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return fmt.Errorf("error changing bus type of disk '%s': %w", disk.Disk.Name, err)
+	}
+	return disk.Refresh(ctx)
+}
+
+// diskNeedsBusTypeChange checks whether the options require a bus type other than the one of the disk
+func diskNeedsBusTypeChange(disk *types.Disk, options *DiskAttachOptions) bool {
+	if options.BusType == "" {
+		return false
+	}
+	return options.BusType != disk.BusType ||
+		(options.BusSubType != "" && !strings.EqualFold(options.BusSubType, disk.BusSubType))
+}
@@ -28,11 +28,14 @@ func (vcd *TestVCD) Test_NewDisk(check *C) {
 func (vcd *TestVCD) Test_CreateDisk(check *C) {
 	fmt.Printf("Running: %s\n", check.TestName())
 
+	_, vdc := vcd.isolatedOrgVdc(check)
+	diskName := testResourceName(check, "")
+
 	// Create disk
 	diskCreateParamsDisk := &types.Disk{
-		Name:        TestCreateDisk,
+		Name:        diskName,
 		SizeMb:      11,
-		Description: TestCreateDisk,
+		Description: diskName,
 	}
 
 	diskCreateParams := &types.DiskCreateParams{
@@ -40,7 +43,7 @@ func (vcd *TestVCD) Test_CreateDisk(check *C) {
 	}
 
 	ctx := context.Background()
-	task, err := vcd.vdc.CreateDisk(ctx, diskCreateParams)
+	task, err := vdc.CreateDisk(ctx, diskCreateParams)
 	check.Assert(err, IsNil)
 
 	check.Assert(task.Task.Owner.Type, Equals, types.MimeDisk)
@@ -54,7 +57,7 @@ func (vcd *TestVCD) Test_CreateDisk(check *C) {
 
 	// Verify created disk
 	check.Assert(diskHREF, Not(Equals), "")
-	disk, err := vcd.vdc.GetDiskByHref(ctx, diskHREF)
+	disk, err := vdc.GetDiskByHref(ctx, diskHREF)
 	check.Assert(err, IsNil)
 	check.Assert(disk.Disk.Name, Equals, diskCreateParamsDisk.Name)
 	check.Assert(disk.Disk.SizeMb, Equals, diskCreateParamsDisk.SizeMb)
@@ -71,11 +74,14 @@ func (vcd *TestVCD) Test_CreateDisk(check *C) {
 func (vcd *TestVCD) Test_UpdateDisk(check *C) {
 	fmt.Printf("Running: %s\n", check.TestName())
 
+	_, vdc := vcd.isolatedOrgVdc(check)
+	diskName := testResourceName(check, "")
+
 	// Create disk
 	diskCreateParamsDisk := &types.Disk{
-		Name:        TestUpdateDisk,
+		Name:        diskName,
 		SizeMb:      99,
-		Description: TestUpdateDisk,
+		Description: diskName,
 	}
 
 	diskCreateParams := &types.DiskCreateParams{
@@ -83,7 +89,7 @@ func (vcd *TestVCD) Test_UpdateDisk(check *C) {
 	}
 
 	ctx := context.Background()
-	task, err := vcd.vdc.CreateDisk(ctx, diskCreateParams)
+	task, err := vdc.CreateDisk(ctx, diskCreateParams)
 	check.Assert(err, IsNil)
 
 	check.Assert(task.Task.Owner.Type, Equals, types.MimeDisk)
@@ -97,7 +103,7 @@ func (vcd *TestVCD) Test_UpdateDisk(check *C) {
 
 	// Verify created disk
 	check.Assert(diskHREF, Not(Equals), "")
-	disk, err := vcd.vdc.GetDiskByHref(ctx, diskHREF)
+	disk, err := vdc.GetDiskByHref(ctx, diskHREF)
 	check.Assert(err, IsNil)
 	check.Assert(disk.Disk.Name, Equals, diskCreateParamsDisk.Name)
 	check.Assert(disk.Disk.SizeMb, Equals, diskCreateParamsDisk.SizeMb)
@@ -105,9 +111,9 @@ func (vcd *TestVCD) Test_UpdateDisk(check *C) {
 
 	// Update disk
 	newDiskInfo := &types.Disk{
-		Name:        TestUpdateDisk,
+		Name:        diskName,
 		SizeMb:      102,
-		Description: TestUpdateDisk + "_Update",
+		Description: diskName + "_Update",
 	}
 
 	updateTask, err := disk.Update(ctx, newDiskInfo)
@@ -128,13 +134,16 @@ func (vcd *TestVCD) Test_UpdateDisk(check *C) {
 func (vcd *TestVCD) Test_DeleteDisk(check *C) {
 	fmt.Printf("Running: %s\n", check.TestName())
 
+	_, vdc := vcd.isolatedOrgVdc(check)
+	diskName := testResourceName(check, "")
+
 	var err error
 
 	// Create disk
 	diskCreateParamsDisk := &types.Disk{
-		Name:        TestDeleteDisk,
+		Name:        diskName,
 		SizeMb:      1,
-		Description: TestDeleteDisk,
+		Description: diskName,
 	}
 
 	diskCreateParams := &types.DiskCreateParams{
@@ -142,7 +151,7 @@ func (vcd *TestVCD) Test_DeleteDisk(check *C) {
 	}
 
 	ctx := context.Background()
-	task, err := vcd.vdc.CreateDisk(ctx, diskCreateParams)
+	task, err := vdc.CreateDisk(ctx, diskCreateParams)
 	check.Assert(err, IsNil)
 
 	check.Assert(task.Task.Owner.Type, Equals, types.MimeDisk)
@@ -156,7 +165,7 @@ func (vcd *TestVCD) Test_DeleteDisk(check *C) {
 
 	// Verify created disk
 	check.Assert(diskHREF, Not(Equals), "")
-	disk, err := vcd.vdc.GetDiskByHref(ctx, diskHREF)
+	disk, err := vdc.GetDiskByHref(ctx, diskHREF)
 	check.Assert(err, IsNil)
 	check.Assert(disk.Disk.Name, Equals, diskCreateParamsDisk.Name)
 	check.Assert(disk.Disk.SizeMb, Equals, diskCreateParamsDisk.SizeMb)
@@ -174,11 +183,14 @@ func (vcd *TestVCD) Test_DeleteDisk(check *C) {
 func (vcd *TestVCD) Test_RefreshDisk(check *C) {
 	fmt.Printf("Running: %s\n", check.TestName())
 
+	_, vdc := vcd.isolatedOrgVdc(check)
+	diskName := testResourceName(check, "")
+
 	// Create disk
 	diskCreateParamsDisk := &types.Disk{
-		Name:        TestRefreshDisk,
+		Name:        diskName,
 		SizeMb:      43,
-		Description: TestRefreshDisk,
+		Description: diskName,
 	}
 
 	diskCreateParams := &types.DiskCreateParams{
@@ -186,7 +198,7 @@ func (vcd *TestVCD) Test_RefreshDisk(check *C) {
 	}
 
 	ctx := context.Background()
-	task, err := vcd.vdc.CreateDisk(ctx, diskCreateParams)
+	task, err := vdc.CreateDisk(ctx, diskCreateParams)
 	check.Assert(err, IsNil)
 
 	check.Assert(task.Task.Owner.Type, Equals, types.MimeDisk)
@@ -200,7 +212,7 @@ func (vcd *TestVCD) Test_RefreshDisk(check *C) {
 
 	// Verify created disk
 	check.Assert(diskHREF, Not(Equals), "")
-	disk, err := vcd.vdc.GetDiskByHref(ctx, diskHREF)
+	disk, err := vdc.GetDiskByHref(ctx, diskHREF)
 	check.Assert(err, IsNil)
 	check.Assert(disk.Disk.Name, Equals, diskCreateParamsDisk.Name)
 	check.Assert(disk.Disk.SizeMb, Equals, diskCreateParamsDisk.SizeMb)
@@ -208,9 +220,9 @@ func (vcd *TestVCD) Test_RefreshDisk(check *C) {
 
 	// Update disk
 	newDiskInfo := &types.Disk{
-		Name:        TestRefreshDisk,
+		Name:        diskName,
 		SizeMb:      43,
-		Description: TestRefreshDisk + "_Update",
+		Description: diskName + "_Update",
 	}
 
 	updateTask, err := disk.Update(ctx, newDiskInfo)
@@ -313,6 +325,72 @@ func (vcd *TestVCD) Test_AttachedVMDisk(check *C) {
 	check.Assert(err, IsNil)
 }
 
+// Test_AttachDiskWithBusType tests attaching an independent disk with a chosen bus type and retrieving the
+// attached VMs
+func (vcd *TestVCD) Test_AttachDiskWithBusType(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("skipping test because vApp wasn't properly created")
+	}
+
+	// Find VM
+	vapp := vcd.findFirstVapp(ctx)
+	vmType, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	vm := NewVM(&vcd.client.Client)
+	vm.VM = &vmType
+
+	err := vcd.ensureVappIsSuitableForVMTest(ctx, vapp)
+	check.Assert(err, IsNil)
+	err = vcd.ensureVMIsSuitableForVMTest(ctx, vm)
+	check.Assert(err, IsNil)
+
+	task, err := vcd.vdc.CreateDisk(ctx, &types.DiskCreateParams{
+		Disk: &types.Disk{
+			Name:   check.TestName(),
+			SizeMb: 210,
+		},
+	})
+	check.Assert(err, IsNil)
+	diskHREF := task.Task.Owner.HREF
+	PrependToCleanupList(diskHREF, "disk", "", check.TestName())
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+
+	disk, err := vcd.vdc.GetDiskByHref(ctx, diskHREF)
+	check.Assert(err, IsNil)
+	check.Assert(disk.IsShared(), Equals, false)
+
+	// A disk that is not shared can't be attached to several VMs
+	err = disk.AttachToVms(ctx, []*VM{vm, vm}, nil)
+	check.Assert(err, NotNil)
+
+	err = disk.AttachToVm(ctx, vm, &DiskAttachOptions{
+		BusType:    types.DiskBusTypeScsi,
+		BusSubType: types.DiskBusSubTypeParavirtual,
+	})
+	check.Assert(err, IsNil)
+	check.Assert(disk.Disk.BusType, Equals, types.DiskBusTypeScsi)
+	check.Assert(strings.EqualFold(disk.Disk.BusSubType, types.DiskBusSubTypeParavirtual), Equals, true)
+
+	attachedVms, err := disk.GetAttachedVms(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(len(attachedVms), Equals, 1)
+	check.Assert(attachedVms[0].VM.HREF, Equals, vm.VM.HREF)
+	check.Assert(attachedVms[0].VM.Name, Equals, vm.VM.Name)
+
+	err = vcd.detachIndependentDisk(ctx, Disk{disk.Disk, &vcd.client.Client})
+	check.Assert(err, IsNil)
+
+	attachedVms, err = disk.GetAttachedVms(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(len(attachedVms), Equals, 0)
+}
+
 // Test find Disk by Href in VDC struct
 func (vcd *TestVCD) Test_VdcFindDiskByHREF(check *C) {
 	fmt.Printf("Running: %s\n", check.TestName())
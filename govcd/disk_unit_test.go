@@ -0,0 +1,53 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestDisk_IsShared(t *testing.T) {
+	tests := []struct {
+		disk *types.Disk
+		want bool
+	}{
+		{&types.Disk{}, false},
+		{&types.Disk{SharingType: types.DiskSharingTypeNone}, false},
+		{&types.Disk{Shareable: true}, true},
+		{&types.Disk{SharingType: types.DiskSharingTypeDiskSharing}, true},
+		{&types.Disk{SharingType: types.DiskSharingTypeControllerSharing}, true},
+	}
+	for _, tt := range tests {
+		disk := &Disk{Disk: tt.disk}
+		if got := disk.IsShared(); got != tt.want {
+			t.Errorf("IsShared() for shareable=%t sharingType=%q = %t, want %t",
+				tt.disk.Shareable, tt.disk.SharingType, got, tt.want)
+		}
+	}
+}
+
+func Test_diskNeedsBusTypeChange(t *testing.T) {
+	disk := &types.Disk{BusType: types.DiskBusTypeScsi, BusSubType: types.DiskBusSubTypeLsiLogic}
+	tests := []struct {
+		name    string
+		options DiskAttachOptions
+		want    bool
+	}{
+		{"no bus type", DiskAttachOptions{}, false},
+		{"same bus type", DiskAttachOptions{BusType: types.DiskBusTypeScsi}, false},
+		{"same sub type", DiskAttachOptions{BusType: types.DiskBusTypeScsi, BusSubType: "LSILOGIC"}, false},
+		{"other sub type", DiskAttachOptions{BusType: types.DiskBusTypeScsi, BusSubType: types.DiskBusSubTypeParavirtual}, true},
+		{"other bus type", DiskAttachOptions{BusType: types.DiskBusTypeSata, BusSubType: types.DiskBusSubTypeSata}, true},
+	}
+	for _, tt := range tests {
+		if got := diskNeedsBusTypeChange(disk, &tt.options); got != tt.want {
+			t.Errorf("%s: got %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// downloadLink - vCD created temporary download link
+// fileSize - size of the file, or 0 when it is not known in advance
+// downloadPieceSize - size of the ranges in which the file is downloaded
+// downloadedBytesForCallback all downloaded bytes if multi disk in ovf
+// allFilesSize overall sum of size if multi disk in ovf
+// callBack a function with signature //function(bytesDownloaded, totalSize) to let the caller monitor progress of the download operation.
+type downloadDetails struct {
+	downloadLink                                                          string
+	fileSize, downloadPieceSize, downloadedBytesForCallback, allFilesSize int64
+	callBack                                                              func(bytesDownloaded, totalSize int64)
+}
+
+// ovfDownloadFile is a file referenced by an OVF descriptor that must be downloaded
+type ovfDownloadFile struct {
+	name string // Name of the file, relative to the OVF descriptor
+	link string // Download link of the file
+	size int64  // Size of the file, or 0 when not declared in the descriptor
+}
+
+// downloadFile downloads the file at dDetails.downloadLink into writer, using range requests of size
+// dDetails.downloadPieceSize when the size of the file is known, and reports the progress to dDetails.callBack.
+// It returns the number of bytes written.
+func downloadFile(ctx context.Context, client *Client, writer io.Writer, dDetails downloadDetails) (int64, error) {
+	util.Logger.Printf("[TRACE] Starting download: %s, size: %d\n", dDetails.downloadLink, dDetails.fileSize)
+
+	pieceSize := dDetails.downloadPieceSize
+	// do not allow smaller than 1kb
+	if pieceSize <= 1024 {
+		pieceSize = defaultPieceSize
+	}
+
+	var downloadedBytes int64
+	for dDetails.fileSize == 0 || downloadedBytes < dDetails.fileSize {
+		// Avoids session time out, as the multi part download is treated as one request
+		makeEmptyRequest(ctx, client)
+
+		rangeEnd := downloadedBytes + pieceSize - 1
+		if dDetails.fileSize > 0 && rangeEnd >= dDetails.fileSize {
+			rangeEnd = dDetails.fileSize - 1
+		}
+		count, complete, err := downloadFilePart(ctx, client, writer, dDetails, downloadedBytes, rangeEnd)
+		downloadedBytes += count
+		if err != nil {
+			return downloadedBytes, fmt.Errorf("error downloading '%s': %w", dDetails.downloadLink, err)
+		}
+		if dDetails.callBack != nil {
+			dDetails.callBack(dDetails.downloadedBytesForCallback+downloadedBytes, dDetails.allFilesSize)
+		}
+		if complete {
+			break
+		}
+	}
+	return downloadedBytes, nil
+}
+
+// downloadFilePart downloads the bytes from rangeStart to rangeEnd of a file into writer.
+// When the size of the file is unknown, or the server ignores the range, the whole file is downloaded and complete
+// is true.
+func downloadFilePart(ctx context.Context, client *Client, writer io.Writer, dDetails downloadDetails, rangeStart, rangeEnd int64) (int64, bool, error) {
+	requestUrl, err := url.ParseRequestURI(dDetails.downloadLink)
+	if err != nil {
+		return 0, false, err
+	}
+	request := client.NewRequest(ctx, nil, http.MethodGet, *requestUrl, nil)
+	if dDetails.fileSize > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", rangeStart, rangeEnd))
+	}
+
+	response, err := client.Http.Do(request)
+	// A partial content response is valid for range requests, but not for the generic response check
+	if err == nil && response.StatusCode != http.StatusPartialContent {
+		response, err = checkResp(response, err)
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			util.Logger.Printf("[ERROR] error closing download response: %s", closeErr)
+		}
+	}()
+
+	count, err := io.Copy(writer, response.Body)
+	if err != nil {
+		return count, false, err
+	}
+	complete := dDetails.fileSize == 0 || response.StatusCode != http.StatusPartialContent
+	if !complete && count != rangeEnd-rangeStart+1 {
+		return count, false, fmt.Errorf("expected %d bytes, received %d", rangeEnd-rangeStart+1, count)
+	}
+	return count, complete, nil
+}
+
+// downloadBytes retrieves the whole content at downloadLink, such as an OVF descriptor
+func downloadBytes(ctx context.Context, client *Client, downloadLink string) ([]byte, error) {
+	requestUrl, err := url.ParseRequestURI(downloadLink)
+	if err != nil {
+		return nil, err
+	}
+	response, err := checkResp(client.Http.Do(client.NewRequest(ctx, nil, http.MethodGet, *requestUrl, nil)))
+	if err != nil {
+		return nil, fmt.Errorf("error downloading '%s': %w", downloadLink, err)
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			util.Logger.Printf("[ERROR] error closing download response: %s", closeErr)
+		}
+	}()
+	return io.ReadAll(response.Body)
+}
+
+// getOvfDownloadFiles parses an OVF descriptor and returns the files that it references, with their download links
+// relative to descriptorLink. Files that are split in chunks are returned as one entry per chunk, named as
+// expected by the upload functions (file.vmdk.000000000, file.vmdk.000000001, ...)
+func getOvfDownloadFiles(descriptor []byte, descriptorLink string) ([]ovfDownloadFile, error) {
+	var ovfFileDesc Envelope
+	err := xml.Unmarshal(descriptor, &ovfFileDesc)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OVF descriptor: %w", err)
+	}
+	baseUrl, err := url.ParseRequestURI(descriptorLink)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []ovfDownloadFile
+	for _, item := range ovfFileDesc.File {
+		names := []string{item.HREF}
+		sizes := []int64{int64(item.Size)}
+		if item.ChunkSize != 0 {
+			names = getChunkedFilePaths("", item.HREF, item.Size, item.ChunkSize)
+			sizes = make([]int64, len(names))
+			for index := range names {
+				sizes[index] = int64(item.ChunkSize)
+			}
+			sizes[len(sizes)-1] = int64(item.Size - item.ChunkSize*(len(names)-1))
+		}
+		for index, name := range names {
+			fileUrl := *baseUrl
+			fileUrl.Path = path.Join(path.Dir(baseUrl.Path), name)
+			files = append(files, ovfDownloadFile{name: name, link: fileUrl.String(), size: sizes[index]})
+		}
+	}
+	return files, nil
+}
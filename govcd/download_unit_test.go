@@ -0,0 +1,100 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_getOvfDownloadFiles(t *testing.T) {
+	descriptor := []byte(`<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1">
+	<References>
+		<File href="disk1.vmdk" id="file1" size="2500"/>
+		<File href="disk2.vmdk" id="file2" size="2500" chunkSize="1000"/>
+	</References>
+</Envelope>`)
+
+	files, err := getOvfDownloadFiles(descriptor, "https://vcd.example.com/transfer/abc/descriptor.ovf")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []ovfDownloadFile{
+		{name: "disk1.vmdk", link: "https://vcd.example.com/transfer/abc/disk1.vmdk", size: 2500},
+		{name: "disk2.vmdk.000000000", link: "https://vcd.example.com/transfer/abc/disk2.vmdk.000000000", size: 1000},
+		{name: "disk2.vmdk.000000001", link: "https://vcd.example.com/transfer/abc/disk2.vmdk.000000001", size: 1000},
+		{name: "disk2.vmdk.000000002", link: "https://vcd.example.com/transfer/abc/disk2.vmdk.000000002", size: 500},
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files, got %d: %+v", len(expected), len(files), files)
+	}
+	for index := range expected {
+		if files[index] != expected[index] {
+			t.Errorf("file %d: expected %+v, got %+v", index, expected[index], files[index])
+		}
+	}
+}
+
+func Test_downloadFile(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 500))
+	var ranges []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/disk.vmdk") {
+			// Keep-alive requests made between pieces
+			w.Header().Set("Content-Type", "application/vnd.vmware.vcloud.query.records+xml")
+			_, _ = fmt.Fprint(w, `<QueryResultRecords xmlns="http://www.vmware.com/vcloud/v1.5"/>`)
+			return
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "disk.vmdk", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+
+	for _, fileSize := range []int64{int64(len(content)), 0} {
+		ranges = nil
+		var lastProgress, lastTotal int64
+		var buffer bytes.Buffer
+		count, err := downloadFile(context.Background(), client, &buffer, downloadDetails{
+			downloadLink:      server.URL + "/transfer/disk.vmdk",
+			fileSize:          fileSize,
+			downloadPieceSize: 2048,
+			allFilesSize:      fileSize,
+			callBack: func(bytesDownloaded, totalSize int64) {
+				lastProgress, lastTotal = bytesDownloaded, totalSize
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if count != int64(len(content)) || !bytes.Equal(buffer.Bytes(), content) {
+			t.Errorf("size %d: downloaded content does not match (%d bytes)", fileSize, count)
+		}
+		if lastProgress != int64(len(content)) || lastTotal != fileSize {
+			t.Errorf("size %d: unexpected progress %d/%d", fileSize, lastProgress, lastTotal)
+		}
+		expectedRanges := []string{"bytes=0-2047", "bytes=2048-4095", "bytes=4096-4999"}
+		if fileSize == 0 {
+			expectedRanges = []string{""}
+		}
+		if strings.Join(ranges, ",") != strings.Join(expectedRanges, ",") {
+			t.Errorf("size %d: expected ranges %v, got %v", fileSize, expectedRanges, ranges)
+		}
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +33,9 @@ type SimpleNetworkIdentifier struct {
 
 var reErrorBusy = regexp.MustCompile(`is busy completing an operation.$`)
 
+// edgeGatewayBusyWaitOptions are used to retry the operations rejected while the Edge Gateway is busy
+var edgeGatewayBusyWaitOptions = WaitOptions{InitialInterval: 3 * time.Second, MaxInterval: 15 * time.Second}
+
 func NewEdgeGateway(cli *Client) *EdgeGateway {
 	return &EdgeGateway{
 		EdgeGateway: new(types.EdgeGateway),
@@ -114,11 +116,11 @@ func (egw *EdgeGateway) AddDhcpPool(ctx context.Context, network *types.OrgVDCNe
 
 	output, err := xml.MarshalIndent(newRules, "  ", "    ")
 	if err != nil {
-		return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %s", err)
+		return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %w", err)
 	}
 
 	var resp *http.Response
-	for {
+	err = WaitForState(ctx, func() (bool, error) {
 		buffer := bytes.NewBufferString(xml.Header + string(output))
 
 		apiEndpoint := urlParseRequestURI(egw.EdgeGateway.HREF)
@@ -130,21 +132,25 @@ func (egw *EdgeGateway) AddDhcpPool(ctx context.Context, network *types.OrgVDCNe
 
 		req.Header.Add("Content-Type", "application/vnd.vmware.admin.edgeGatewayServiceConfiguration+xml")
 
+		var err error
 		resp, err = checkResp(egw.client.Http.Do(req))
 		if err != nil {
+			// Retry while the Edge Gateway is busy completing another operation
 			if reErrorBusy.MatchString(err.Error()) {
-				time.Sleep(3 * time.Second)
-				continue
+				return false, nil
 			}
-			return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %s", err)
+			return false, fmt.Errorf("error reconfiguring Edge Gateway: %w", err)
 		}
-		break
+		return true, nil
+	}, edgeGatewayBusyWaitOptions)
+	if err != nil {
+		return Task{}, err
 	}
 
 	task := NewTask(egw.client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	// The request was successful
@@ -213,7 +219,7 @@ func (egw *EdgeGateway) RemoveNATPortMapping(ctx context.Context, natType, exter
 func (egw *EdgeGateway) RemoveNATRule(ctx context.Context, id string) error {
 	task, err := egw.RemoveNATRuleAsync(ctx, id)
 	if err != nil {
-		return fmt.Errorf("error removing DNAT rule: %s", err)
+		return fmt.Errorf("error removing DNAT rule: %w", err)
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
@@ -233,7 +239,7 @@ func (egw *EdgeGateway) RemoveNATRuleAsync(ctx context.Context, id string) (Task
 
 	err := egw.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return Task{}, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	natServiceToUpdate := egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService
@@ -289,7 +295,7 @@ func (egw *EdgeGateway) AddDNATRule(ctx context.Context, ruleDetails NatRule) (*
 	ruleDetails.NatType = "DNAT"
 	task, err := egw.AddNATRuleAsync(ctx, ruleDetails)
 	if err != nil {
-		return nil, fmt.Errorf("error creating DNAT rule: %s", err)
+		return nil, fmt.Errorf("error creating DNAT rule: %w", err)
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
@@ -300,7 +306,7 @@ func (egw *EdgeGateway) AddDNATRule(ctx context.Context, ruleDetails NatRule) (*
 
 	err = egw.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return nil, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	for _, natRule := range egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService.NatRule {
@@ -339,7 +345,7 @@ func (egw *EdgeGateway) AddSNATRule(ctx context.Context, networkHref, externalIP
 		ExternalPort: "any", InternalIP: internalIP, InternalPort: "any",
 		IcmpSubType: "", Protocol: "any", Description: mappingId})
 	if err != nil {
-		return nil, fmt.Errorf("error creating SNAT rule: %s", err)
+		return nil, fmt.Errorf("error creating SNAT rule: %w", err)
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
@@ -350,7 +356,7 @@ func (egw *EdgeGateway) AddSNATRule(ctx context.Context, networkHref, externalIP
 
 	err = egw.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return nil, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	for _, natRule := range egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService.NatRule {
@@ -387,7 +393,7 @@ func getPseudoUuid() (string, error) {
 func (egw *EdgeGateway) UpdateNatRule(ctx context.Context, natRule *types.NatRule) (*types.NatRule, error) {
 	task, err := egw.UpdateNatRuleAsync(ctx, natRule)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NAT rule: %s", err)
+		return nil, fmt.Errorf("error updating NAT rule: %w", err)
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
@@ -409,7 +415,7 @@ func (egw *EdgeGateway) UpdateNatRuleAsync(ctx context.Context, natRule *types.N
 
 	err := egw.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return Task{}, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	natServiceToUpdate := egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService
@@ -441,7 +447,7 @@ func (egw *EdgeGateway) UpdateNatRuleAsync(ctx context.Context, natRule *types.N
 func (egw *EdgeGateway) GetNatRule(ctx context.Context, id string) (*types.NatRule, error) {
 	err := egw.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing edge gateway: %s", err)
+		return nil, fmt.Errorf("error refreshing edge gateway: %w", err)
 	}
 
 	if egw.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.NatService != nil {
@@ -666,7 +672,7 @@ func (egw *EdgeGateway) AddNATPortMappingWithUplink(ctx context.Context, network
 func (egw *EdgeGateway) CreateFirewallRules(ctx context.Context, defaultAction string, rules []*types.FirewallRule) (Task, error) {
 	err := egw.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error: %s", err)
+		return Task{}, fmt.Errorf("error: %w", err)
 	}
 
 	newRules := &types.EdgeGatewayServiceConfiguration{
@@ -681,11 +687,11 @@ func (egw *EdgeGateway) CreateFirewallRules(ctx context.Context, defaultAction s
 
 	output, err := xml.MarshalIndent(newRules, "  ", "    ")
 	if err != nil {
-		return Task{}, fmt.Errorf("error: %s", err)
+		return Task{}, fmt.Errorf("error: %w", err)
 	}
 
 	var resp *http.Response
-	for {
+	err = WaitForState(ctx, func() (bool, error) {
 		buffer := bytes.NewBufferString(xml.Header + string(output))
 
 		apiEndpoint := urlParseRequestURI(egw.EdgeGateway.HREF)
@@ -697,21 +703,25 @@ func (egw *EdgeGateway) CreateFirewallRules(ctx context.Context, defaultAction s
 
 		req.Header.Add("Content-Type", "application/vnd.vmware.admin.edgeGatewayServiceConfiguration+xml")
 
+		var err error
 		resp, err = checkResp(egw.client.Http.Do(req))
 		if err != nil {
+			// Retry while the Edge Gateway is busy completing another operation
 			if reErrorBusy.MatchString(err.Error()) {
-				time.Sleep(3 * time.Second)
-				continue
+				return false, nil
 			}
-			return Task{}, fmt.Errorf("error reconfiguring Edge Gateway: %s", err)
+			return false, fmt.Errorf("error reconfiguring Edge Gateway: %w", err)
 		}
-		break
+		return true, nil
+	}, edgeGatewayBusyWaitOptions)
+	if err != nil {
+		return Task{}, err
 	}
 
 	task := NewTask(egw.client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	// The request was successful
@@ -986,20 +996,18 @@ func (egw *EdgeGateway) DeleteAsync(ctx context.Context, force bool, recursive b
 
 	egwUrl, err := url.ParseRequestURI(egw.EdgeGateway.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error parsing edge gateway url: %s", err)
+		return Task{}, fmt.Errorf("error parsing edge gateway url: %w", err)
 	}
 
-	req := egw.client.NewRequest(ctx, map[string]string{
-		"force":     strconv.FormatBool(force),
-		"recursive": strconv.FormatBool(recursive),
-	}, http.MethodDelete, *egwUrl, nil)
+	req := egw.client.NewRequest(ctx, DeleteOptions{Force: force, Recursive: recursive}.queryParams(),
+		http.MethodDelete, *egwUrl, nil)
 	resp, err := checkResp(egw.client.Http.Do(req))
 	if err != nil {
-		return Task{}, fmt.Errorf("error deleting edge gateway: %s", err)
+		return Task{}, fmt.Errorf("error deleting edge gateway: %w", err)
 	}
 	task := NewTask(egw.client)
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding task response: %w", err)
 	}
 	return *task, err
 }
@@ -1024,6 +1032,15 @@ func (egw *EdgeGateway) Delete(ctx context.Context, force bool, recursive bool)
 	return nil
 }
 
+// DeleteWithOptions deletes the edge gateway, passing options.Force and options.Recursive to VCD
+func (egw *EdgeGateway) DeleteWithOptions(ctx context.Context, options DeleteOptions) error {
+	err := options.validate("edge gateway", true, true)
+	if err != nil {
+		return err
+	}
+	return egw.Delete(ctx, options.Force, options.Recursive)
+}
+
 // GetNetworks returns the list of networks associated with an edge gateway
 // In the return structure, an interfaceType of "uplink" indicates an external network,
 // while "internal" is for Org VDC routed networks
@@ -1078,7 +1095,7 @@ func (egw *EdgeGateway) HasAdvancedNetworking() bool {
 func (egw *EdgeGateway) buildProxiedEdgeEndpointURL(optionalSuffix string) (string, error) {
 	apiEndpoint, err := url.ParseRequestURI(egw.EdgeGateway.HREF)
 	if err != nil {
-		return "", fmt.Errorf("unable to process edge gateway URL: %s", err)
+		return "", fmt.Errorf("unable to process edge gateway URL: %w", err)
 	}
 	edgeID := strings.Split(egw.EdgeGateway.ID, ":")
 	if len(edgeID) != 4 {
@@ -1104,7 +1121,7 @@ func (egw *EdgeGateway) GetLBGeneralParams(ctx context.Context) (*types.LbGenera
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbConfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	loadBalancerConfig := &types.LbGeneralParamsWithXml{}
@@ -1134,7 +1151,7 @@ func (egw *EdgeGateway) UpdateLBGeneralParams(ctx context.Context, enabled, acce
 	// Retrieve load balancer to work on latest configuration
 	currentLb, err := egw.GetLBGeneralParams(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve load balancer before update: %s", err)
+		return nil, fmt.Errorf("unable to retrieve load balancer before update: %w", err)
 	}
 
 	// Check if change is needed. If not - return early.
@@ -1157,7 +1174,7 @@ func (egw *EdgeGateway) UpdateLBGeneralParams(ctx context.Context, enabled, acce
 	// Push updated configuration
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbConfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPut, types.AnyXMLMime,
 		"error while updating load balancer config: %s", currentLb, &types.NSXError{})
@@ -1168,7 +1185,7 @@ func (egw *EdgeGateway) UpdateLBGeneralParams(ctx context.Context, enabled, acce
 	// Retrieve configuration after update
 	updatedLb, err := egw.GetLBGeneralParams(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve load balancer config after update: %s", err)
+		return nil, fmt.Errorf("unable to retrieve load balancer config after update: %w", err)
 	}
 
 	return updatedLb, nil
@@ -1185,7 +1202,7 @@ func (egw *EdgeGateway) GetFirewallConfig(ctx context.Context) (*types.FirewallC
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeFirewallPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	firewallConfig := &types.FirewallConfigWithXml{}
@@ -1216,7 +1233,7 @@ func (egw *EdgeGateway) UpdateFirewallConfig(ctx context.Context, enabled, defau
 	// Retrieve firewall latest configuration
 	currentFw, err := egw.GetFirewallConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve firewall config before update: %s", err)
+		return nil, fmt.Errorf("unable to retrieve firewall config before update: %w", err)
 	}
 
 	// Check if change is needed. If not - return early.
@@ -1236,7 +1253,7 @@ func (egw *EdgeGateway) UpdateFirewallConfig(ctx context.Context, enabled, defau
 	// Push updated configuration
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeFirewallPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPut, types.AnyXMLMime,
 		"error while updating firewall configuration : %s", currentFw, &types.NSXError{})
@@ -1247,7 +1264,7 @@ func (egw *EdgeGateway) UpdateFirewallConfig(ctx context.Context, enabled, defau
 	// Retrieve configuration after update
 	updatedFw, err := egw.GetFirewallConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve firewall after update: %s", err)
+		return nil, fmt.Errorf("unable to retrieve firewall after update: %w", err)
 	}
 
 	return updatedFw, nil
@@ -1272,7 +1289,7 @@ func (egw *EdgeGateway) getVdcNetworks(ctx context.Context) (*types.EdgeGatewayI
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL("/vdcNetworks")
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	vnicConfig := &types.EdgeGatewayInterfaces{}
@@ -1292,7 +1309,7 @@ func (egw *EdgeGateway) getVdcNetworks(ctx context.Context) (*types.EdgeGatewayI
 func (egw *EdgeGateway) GetVnicIndexByNetworkNameAndType(ctx context.Context, networkName, networkType string) (*int, error) {
 	vnics, err := egw.getVdcNetworks(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("cannot retrieve vNic configuration: %s", err)
+		return nil, fmt.Errorf("cannot retrieve vNic configuration: %w", err)
 	}
 	return getVnicIndexByNetworkNameAndType(networkName, networkType, vnics)
 }
@@ -1307,7 +1324,7 @@ func (egw *EdgeGateway) GetVnicIndexByNetworkNameAndType(ctx context.Context, ne
 func (egw *EdgeGateway) GetAnyVnicIndexByNetworkName(ctx context.Context, networkName string) (*int, string, error) {
 	vnics, err := egw.getVdcNetworks(ctx)
 	if err != nil {
-		return nil, "", fmt.Errorf("cannot retrieve vNic configuration: %s", err)
+		return nil, "", fmt.Errorf("cannot retrieve vNic configuration: %w", err)
 	}
 
 	var foundVnicIndex *int
@@ -1336,7 +1353,7 @@ func (egw *EdgeGateway) GetAnyVnicIndexByNetworkName(ctx context.Context, networ
 func (egw *EdgeGateway) GetNetworkNameAndTypeByVnicIndex(ctx context.Context, vNicIndex int) (string, string, error) {
 	vnics, err := egw.getVdcNetworks(ctx)
 	if err != nil {
-		return "", "", fmt.Errorf("cannot retrieve vNic configuration: %s", err)
+		return "", "", fmt.Errorf("cannot retrieve vNic configuration: %w", err)
 	}
 	return getNetworkNameAndTypeByVnicIndex(vNicIndex, vnics)
 }
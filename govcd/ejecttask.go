@@ -48,7 +48,7 @@ func (ejectTask *EjectTask) WaitInspectTaskCompletion(ctx context.Context, isAns
 	for {
 		err := ejectTask.Refresh(ctx)
 		if err != nil {
-			return fmt.Errorf("error retrieving task: %s", err)
+			return fmt.Errorf("error retrieving task: %w", err)
 		}
 
 		// If task is not in a waiting status we're done, check if there's an error and return it.
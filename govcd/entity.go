@@ -60,7 +60,8 @@ func getEntityByNameOrIdSkipNonId(getByName, getById genericGetter, identifier s
 		}
 	}
 
-	if IsNotFound(byIdErr) || byIdErr == nil {
+	// OpenAPI lookups wrap ErrorEntityNotFound, therefore ContainsNotFound is used instead of IsNotFound
+	if ContainsNotFound(byIdErr) || byIdErr == nil {
 		// Not found by ID, try by name
 		entity, byNameErr = getByName(identifier, false)
 		return entity, byNameErr
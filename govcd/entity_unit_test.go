@@ -0,0 +1,61 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_getEntityByNameOrIdSkipNonId(t *testing.T) {
+	const knownId = "urn:vcloud:gateway:7e5a9fba-1a2b-4c3d-9e8f-0a1b2c3d4e5f"
+	const knownName = "known-name"
+
+	entity := &testEntity{Name: knownName}
+	byIdCalls := 0
+	getById := func(id string, refresh bool) (interface{}, error) {
+		byIdCalls++
+		if id == knownId {
+			return entity, nil
+		}
+		// Mimic OpenAPI behavior which wraps ErrorEntityNotFound
+		return nil, fmt.Errorf("%s: API_ERROR", ErrorEntityNotFound)
+	}
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		if name == knownName {
+			return entity, nil
+		}
+		return nil, ErrorEntityNotFound
+	}
+
+	tests := []struct {
+		name          string
+		identifier    string
+		wantFound     bool
+		wantByIdCalls int
+	}{
+		{name: "ById", identifier: knownId, wantFound: true, wantByIdCalls: 1},
+		{name: "ByName", identifier: knownName, wantFound: true, wantByIdCalls: 0},
+		{name: "UnknownIdFallsBackToName", identifier: "urn:vcloud:gateway:00000000-0000-0000-0000-000000000000", wantFound: false, wantByIdCalls: 1},
+		{name: "UnknownName", identifier: "unknown", wantFound: false, wantByIdCalls: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byIdCalls = 0
+			got, err := getEntityByNameOrIdSkipNonId(getByName, getById, tt.identifier, false)
+			if tt.wantFound && (err != nil || got != entity) {
+				t.Errorf("expected to find entity, got %v (error: %v)", got, err)
+			}
+			if !tt.wantFound && !ContainsNotFound(err) {
+				t.Errorf("expected ErrorEntityNotFound, got %v", err)
+			}
+			if byIdCalls != tt.wantByIdCalls {
+				t.Errorf("expected %d lookups by ID, got %d", tt.wantByIdCalls, byIdCalls)
+			}
+		})
+	}
+}
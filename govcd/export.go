@@ -0,0 +1,280 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// Formats supported by ExportTasks and ExportAuditTrail
+const (
+	ExportFormatCsv    = "csv"    // Comma separated values, with a header line
+	ExportFormatNdjson = "ndjson" // One JSON object per line
+)
+
+// exportPageSize is the page size used to retrieve tasks for ExportTasks
+const exportPageSize = 128
+
+var (
+	taskExportHeader = []string{"id", "name", "operation", "status", "startDate", "endDate", "org", "owner",
+		"objectType", "objectName", "object", "message"}
+	auditTrailExportHeader = []string{"eventId", "timestamp", "eventType", "eventStatus", "description", "org",
+		"user", "entityType", "entityName", "entityId", "taskId", "serviceNamespace"}
+)
+
+// ExportTasks writes the tasks matching filter to writer in the given format (ExportFormatCsv or
+// ExportFormatNdjson), one page at a time, so that long time windows can be exported without keeping all the
+// tasks in memory. The filter has the same format used by QueryTaskList. Tasks are sorted by start date.
+// It returns the number of exported tasks, which are already written when an error is returned.
+func (client *Client) ExportTasks(ctx context.Context, writer io.Writer, format string, filter map[string]string) (int, error) {
+	exporter, err := newRecordExporter(writer, format, taskExportHeader)
+	if err != nil {
+		return 0, err
+	}
+
+	taskType := client.GetQueryType(types.QtTask)
+	params := map[string]string{
+		"type":     taskType,
+		"pageSize": strconv.Itoa(exportPageSize),
+		"sortAsc":  "startDate",
+	}
+	filterText := buildFilterTextWithLogicalOr(filter)
+	if filterText != "" {
+		params["filter"] = filterText
+	}
+
+	for page := 1; ; page++ {
+		params["page"] = strconv.Itoa(page)
+		results, err := client.QueryWithNotEncodedParams(ctx, nil, params)
+		if err != nil {
+			return exporter.count, fmt.Errorf("error querying tasks (page %d): %w", page, err)
+		}
+		tasks := results.Results.TaskRecord
+		if client.IsSysAdmin {
+			tasks = results.Results.AdminTaskRecord
+		}
+		for _, task := range tasks {
+			err = exporter.write([]string{task.ID, task.Name, task.OperationFull, task.Status, task.StartDate,
+				task.EndDate, task.OrgName, task.OwnerName, task.ObjectType, task.ObjectName, task.Object, task.Message}, task)
+			if err != nil {
+				return exporter.count, err
+			}
+		}
+		err = exporter.flush()
+		if err != nil {
+			return exporter.count, err
+		}
+		if len(tasks) == 0 || page*results.Results.PageSize >= int(results.Results.Total) {
+			return exporter.count, nil
+		}
+	}
+}
+
+// ExportAuditTrail writes the audit trail events matching queryParameters to writer in the given format
+// (ExportFormatCsv or ExportFormatNdjson), one page at a time, so that long time windows can be exported without
+// keeping all the events in memory. A time window is usually set with a filter on the timestamp, such as
+// "timestamp=gt=2023-01-01T00:00:00.000Z" (see types.FiqlQueryTimestampFormat).
+// With ExportFormatNdjson, the events are written as returned by VCD.
+// It returns the number of exported events, which are already written when an error is returned.
+func (client *Client) ExportAuditTrail(ctx context.Context, writer io.Writer, format string, queryParameters url.Values) (int, error) {
+	exporter, err := newRecordExporter(writer, format, auditTrailExportHeader)
+	if err != nil {
+		return 0, err
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAuditTrail
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return 0, err
+	}
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	err = client.openApiForEachPage(ctx, apiVersion, urlRef, copyOrNewUrlValues(queryParameters), nil, func(page []json.RawMessage) error {
+		for _, rawEvent := range page {
+			event := types.AuditTrailEvent{}
+			err := json.Unmarshal(rawEvent, &event)
+			if err != nil {
+				return fmt.Errorf("error decoding audit trail event: %w", err)
+			}
+			org, user, entity := openApiReferenceOrEmpty(event.OperatingOrg), openApiReferenceOrEmpty(event.User),
+				openApiReferenceOrEmpty(event.EventEntity)
+			err = exporter.write([]string{event.EventID, event.Timestamp, event.EventType, event.EventStatus,
+				event.Description, org.Name, user.Name, entityTypeFromUrn(entity.ID), entity.Name, entity.ID,
+				event.TaskID, event.ServiceNamespace}, rawEvent)
+			if err != nil {
+				return err
+			}
+		}
+		return exporter.flush()
+	})
+	if err != nil {
+		return exporter.count, fmt.Errorf("error exporting audit trail: %w", err)
+	}
+	return exporter.count, nil
+}
+
+// openApiForEachPage retrieves the pages of an OpenAPI list endpoint one at a time, calling pageFunc with the
+// items of each page. Unlike OpenApiGetAllItems, the items are not accumulated. Iteration stops at the first error.
+func (client *Client) openApiForEachPage(ctx context.Context, apiVersion string, urlRef *url.URL, queryParams url.Values,
+	additionalHeader map[string]string, pageFunc func(page []json.RawMessage) error) error {
+	originalParams := queryParams
+	nextUrlRef := copyUrlRef(urlRef)
+	for nextUrlRef != nil {
+		req := client.newOpenApiRequest(ctx, apiVersion, queryParams, http.MethodGet, nextUrlRef, nil, additionalHeader)
+		resp, err := client.Http.Do(req)
+		if err != nil {
+			return err
+		}
+		_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
+		if err != nil {
+			return fmt.Errorf("error in HTTP GET request: %w", err)
+		}
+
+		pages := &types.OpenApiPages{}
+		err = decodeBody(types.BodyTypeJSON, resp, pages)
+		if err != nil {
+			return fmt.Errorf("error decoding JSON page response: %w", err)
+		}
+		err = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error closing response body: %w", err)
+		}
+
+		var items []json.RawMessage
+		err = json.Unmarshal(pages.Values, &items)
+		if err != nil {
+			return fmt.Errorf("error decoding values of page %d: %w", pages.Page, err)
+		}
+		err = pageFunc(items)
+		if err != nil {
+			return err
+		}
+
+		// Same logic as openApiGetAllPages: 'nextPage' link when available, otherwise the next page number
+		nextUrlRef, err = findRelLink("nextPage", resp.Header)
+		if err != nil && !IsNotFound(err) {
+			return fmt.Errorf("error looking for 'nextPage' in 'Link' header: %w", err)
+		}
+		if nextUrlRef != nil {
+			queryParams = url.Values{}
+			continue
+		}
+		if pages.PageSize != 0 && pages.Page != 0 && pages.Page*pages.PageSize < pages.ResultTotal {
+			queryParams = copyOrNewUrlValues(originalParams)
+			queryParams.Set("page", strconv.Itoa(pages.Page+1))
+			nextUrlRef = copyUrlRef(urlRef)
+		}
+	}
+	return nil
+}
+
+// recordExporter writes records to an io.Writer as CSV or NDJSON
+type recordExporter struct {
+	format    string
+	writer    io.Writer
+	csvWriter *csv.Writer
+	count     int
+}
+
+// newRecordExporter creates a recordExporter. For CSV, the header is written immediately
+func newRecordExporter(writer io.Writer, format string, header []string) (*recordExporter, error) {
+	if writer == nil {
+		return nil, fmt.Errorf("no writer provided for export")
+	}
+	exporter := &recordExporter{format: format, writer: writer}
+	switch format {
+	case ExportFormatCsv:
+		exporter.csvWriter = csv.NewWriter(writer)
+		err := exporter.csvWriter.Write(header)
+		if err != nil {
+			return nil, fmt.Errorf("error writing CSV header: %w", err)
+		}
+	case ExportFormatNdjson:
+	default:
+		return nil, fmt.Errorf("unsupported export format '%s'. Use one of '%s' or '%s'", format, ExportFormatCsv, ExportFormatNdjson)
+	}
+	return exporter, nil
+}
+
+// write exports one record: csvRow is used for CSV, jsonValue for NDJSON. When jsonValue is a json.RawMessage,
+// it is written as is, after removing insignificant white space
+func (exporter *recordExporter) write(csvRow []string, jsonValue interface{}) error {
+	if exporter.csvWriter != nil {
+		err := exporter.csvWriter.Write(csvRow)
+		if err != nil {
+			return fmt.Errorf("error writing CSV record: %w", err)
+		}
+		exporter.count++
+		return nil
+	}
+
+	var line []byte
+	if raw, ok := jsonValue.(json.RawMessage); ok {
+		buffer := &bytes.Buffer{}
+		err := json.Compact(buffer, raw)
+		if err != nil {
+			return fmt.Errorf("error compacting JSON record: %w", err)
+		}
+		line = buffer.Bytes()
+	} else {
+		var err error
+		line, err = json.Marshal(jsonValue)
+		if err != nil {
+			return fmt.Errorf("error encoding JSON record: %w", err)
+		}
+	}
+	_, err := exporter.writer.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing JSON record: %w", err)
+	}
+	exporter.count++
+	return nil
+}
+
+// flush writes buffered CSV records to the underlying writer
+func (exporter *recordExporter) flush() error {
+	if exporter.csvWriter == nil {
+		return nil
+	}
+	exporter.csvWriter.Flush()
+	err := exporter.csvWriter.Error()
+	if err != nil {
+		return fmt.Errorf("error writing CSV records: %w", err)
+	}
+	util.Logger.Printf("[TRACE] exported %d records", exporter.count)
+	return nil
+}
+
+// openApiReferenceOrEmpty returns the reference, or an empty one if it is nil
+func openApiReferenceOrEmpty(reference *types.OpenApiReference) types.OpenApiReference {
+	if reference == nil {
+		return types.OpenApiReference{}
+	}
+	return *reference
+}
+
+// entityTypeFromUrn returns the entity type of URNs like "urn:vcloud:vm:<uuid>" ("vm"), or an empty string
+func entityTypeFromUrn(urn string) string {
+	parts := strings.Split(urn, ":")
+	if len(parts) < 4 || parts[0] != "urn" {
+		return ""
+	}
+	return parts[2]
+}
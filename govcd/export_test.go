@@ -0,0 +1,56 @@
+//go:build functional || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_ExportTasks(check *C) {
+	buffer := &bytes.Buffer{}
+	count, err := vcd.client.Client.ExportTasks(ctx, buffer, ExportFormatCsv, map[string]string{"objectType": "vdc"})
+	check.Assert(err, IsNil)
+
+	records, err := csv.NewReader(buffer).ReadAll()
+	check.Assert(err, IsNil)
+	check.Assert(len(records), Equals, count+1)
+	check.Assert(records[0], DeepEquals, taskExportHeader)
+}
+
+func (vcd *TestVCD) Test_ExportAuditTrail(check *C) {
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAuditTrail)
+
+	// At least the login of the test user is in the audit trail of the last few hours
+	queryParams := url.Values{}
+	queryParams.Set("filter", "timestamp=gt="+time.Now().Add(-6*time.Hour).Format(types.FiqlQueryTimestampFormat))
+	queryParams.Set("pageSize", "10")
+
+	buffer := &bytes.Buffer{}
+	count, err := vcd.client.Client.ExportAuditTrail(ctx, buffer, ExportFormatNdjson, queryParams)
+	check.Assert(err, IsNil)
+	check.Assert(count > 0, Equals, true)
+
+	lines := 0
+	scanner := bufio.NewScanner(buffer)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		event := types.AuditTrailEvent{}
+		err = json.Unmarshal(scanner.Bytes(), &event)
+		check.Assert(err, IsNil)
+		check.Assert(event.EventID, Not(Equals), "")
+		lines++
+	}
+	check.Assert(lines, Equals, count)
+}
@@ -0,0 +1,131 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_recordExporter(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	exporter, err := newRecordExporter(buffer, ExportFormatCsv, []string{"id", "name"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = exporter.write([]string{"1", "name, with comma"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = exporter.flush()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := "id,name\n1,\"name, with comma\"\n"
+	if buffer.String() != expected || exporter.count != 1 {
+		t.Errorf("expected CSV %q, got %q (count %d)", expected, buffer.String(), exporter.count)
+	}
+
+	buffer.Reset()
+	exporter, err = newRecordExporter(buffer, ExportFormatNdjson, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = exporter.write(nil, json.RawMessage("{\n  \"id\": 1\n}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = exporter.write(nil, struct {
+		Name string `json:"name"`
+	}{"two"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = "{\"id\":1}\n{\"name\":\"two\"}\n"
+	if buffer.String() != expected || exporter.count != 2 {
+		t.Errorf("expected NDJSON %q, got %q (count %d)", expected, buffer.String(), exporter.count)
+	}
+
+	_, err = newRecordExporter(buffer, "xml", nil)
+	if err == nil {
+		t.Errorf("expected error for unsupported format")
+	}
+}
+
+func Test_openApiForEachPage(t *testing.T) {
+	// Three pages of two items, without 'nextPage' links, so that pages are requested by number
+	var requestedPages []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if r.URL.Query().Get("filter") != "timestamp=gt=2023" {
+			t.Errorf("filter not kept on page %s: %s", page, r.URL.RawQuery)
+		}
+		requestedPages = append(requestedPages, page)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"resultTotal":5,"pageCount":3,"page":%s,"pageSize":2,"values":[{"id":"%s-a"},{"id":"%s-b"}]}`, page, page, page)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+	urlRef, err := client.OpenApiBuildEndpoint("1.0.0/auditTrail/")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var items []string
+	err = client.openApiForEachPage(ctx, "36.0", urlRef, url.Values{"filter": []string{"timestamp=gt=2023"}}, nil,
+		func(page []json.RawMessage) error {
+			for _, item := range page {
+				items = append(items, string(item))
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if strings.Join(requestedPages, ",") != "1,2,3" {
+		t.Errorf("expected pages 1,2,3, got %v", requestedPages)
+	}
+	if len(items) != 6 {
+		t.Errorf("expected 6 items, got %d", len(items))
+	}
+
+	requestedPages = nil
+	err = client.openApiForEachPage(ctx, "36.0", urlRef, url.Values{"filter": []string{"timestamp=gt=2023"}}, nil,
+		func(page []json.RawMessage) error {
+			return fmt.Errorf("stop")
+		})
+	if err == nil || len(requestedPages) != 1 {
+		t.Errorf("expected iteration to stop at first error, got %v after pages %v", err, requestedPages)
+	}
+}
+
+func Test_entityTypeFromUrn(t *testing.T) {
+	for urn, expected := range map[string]string{
+		"urn:vcloud:vm:11111111-2222-3333-4444-555555555555": "vm",
+		"urn:vcloud:org:org1":                                "org",
+		"not-a-urn":                                          "",
+		"":                                                   "",
+	} {
+		if got := entityTypeFromUrn(urn); got != expected {
+			t.Errorf("entityTypeFromUrn(%s): expected %q, got %q", urn, expected, got)
+		}
+	}
+}
@@ -41,7 +41,7 @@ func CreateExternalNetworkV2(ctx context.Context, vcdClient *VCDClient, newExtNe
 
 	err = vcdClient.Client.OpenApiPostItem(ctx, apiVersion, urlRef, nil, newExtNet, returnExtNet.ExternalNetwork, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating external network: %s", err)
+		return nil, fmt.Errorf("error creating external network: %w", err)
 	}
 
 	return returnExtNet, nil
@@ -90,7 +90,7 @@ func GetExternalNetworkV2ByName(ctx context.Context, vcdClient *VCDClient, name
 
 	res, err := GetAllExternalNetworksV2(ctx, vcdClient, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("could not find external network by name: %s", err)
+		return nil, fmt.Errorf("could not find external network by name: %w", err)
 	}
 
 	if len(res) == 0 {
@@ -160,7 +160,7 @@ func (extNet *ExternalNetworkV2) Update(ctx context.Context) (*ExternalNetworkV2
 
 	err = extNet.client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, extNet.ExternalNetwork, returnExtNet.ExternalNetwork, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating external network: %s", err)
+		return nil, fmt.Errorf("error updating external network: %w", err)
 	}
 
 	return returnExtNet, nil
@@ -186,7 +186,7 @@ func (extNet *ExternalNetworkV2) Delete(ctx context.Context) error {
 	err = extNet.client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting extNet: %s", err)
+		return fmt.Errorf("error deleting extNet: %w", err)
 	}
 
 	return nil
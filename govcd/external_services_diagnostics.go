@@ -0,0 +1,198 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Categories of checks performed by VCDClient.RunExternalServicesDiagnostics
+const (
+	DiagnosticsCategoryLdap              = "LDAP"
+	DiagnosticsCategoryAlbController     = "ALB Controller"
+	DiagnosticsCategorySubscribedCatalog = "Subscribed Catalog"
+)
+
+// DiagnosticsCheck is the result of a single connectivity check towards an external service
+type DiagnosticsCheck struct {
+	Category string                      // One of the DiagnosticsCategory* values
+	Name     string                      // Name of the VCD object using the external service (Org, ALB Controller, Catalog)
+	Target   string                      // Host and port (or URL) of the external service
+	Success  bool                        // True if VCD could connect to the external service and complete the SSL handshake
+	Message  string                      // Details about the failure, if any
+	Result   *types.TestConnectionResult // Raw result of the test, if VCD could run it
+}
+
+// DiagnosticsReport aggregates the connectivity checks towards the external services used by VCD
+type DiagnosticsReport struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Checks    []DiagnosticsCheck
+}
+
+// Failed returns the checks in the report that were not successful
+func (report *DiagnosticsReport) Failed() []DiagnosticsCheck {
+	var failed []DiagnosticsCheck
+	for _, check := range report.Checks {
+		if !check.Success {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// RunExternalServicesDiagnostics runs the VCD "test connection" API against the external services that VCD depends
+// on and collects the results in one report, which can be attached to support tickets:
+// * Custom LDAP server of the Org (if configured)
+// * Subscribed catalogs of the Org
+// * ALB Controllers (System Administrator only)
+//
+// orgName is optional. When empty, only the checks that do not depend on an Org are performed.
+// A failure of a single check is recorded in the report and does not stop the others. An error is returned only
+// when the Org cannot be retrieved.
+func (vcdClient *VCDClient) RunExternalServicesDiagnostics(ctx context.Context, orgName string) (*DiagnosticsReport, error) {
+	report := &DiagnosticsReport{StartTime: time.Now()}
+
+	if orgName != "" {
+		adminOrg, err := vcdClient.GetAdminOrgByName(ctx, orgName)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Org '%s' for diagnostics: %w", orgName, err)
+		}
+		report.Checks = append(report.Checks, vcdClient.diagnoseOrgLdap(ctx, adminOrg)...)
+		report.Checks = append(report.Checks, vcdClient.diagnoseSubscribedCatalogs(ctx, adminOrg)...)
+	}
+
+	if vcdClient.Client.IsSysAdmin {
+		report.Checks = append(report.Checks, vcdClient.diagnoseAlbControllers(ctx)...)
+	}
+
+	report.EndTime = time.Now()
+	return report, nil
+}
+
+// diagnoseOrgLdap checks the connectivity to the custom LDAP server of an Org
+func (vcdClient *VCDClient) diagnoseOrgLdap(ctx context.Context, adminOrg *AdminOrg) []DiagnosticsCheck {
+	check := DiagnosticsCheck{Category: DiagnosticsCategoryLdap, Name: adminOrg.AdminOrg.Name}
+
+	ldapSettings, err := adminOrg.GetLdapConfiguration(ctx)
+	if err != nil {
+		check.Message = fmt.Sprintf("error retrieving LDAP configuration: %s", err)
+		return []DiagnosticsCheck{check}
+	}
+	if ldapSettings.OrgLdapMode != types.LdapModeCustom || ldapSettings.CustomOrgLdapSettings == nil {
+		// Nothing to check when the Org does not use its own LDAP server
+		return nil
+	}
+
+	custom := ldapSettings.CustomOrgLdapSettings
+	check.Target = fmt.Sprintf("%s:%d", custom.HostName, custom.Port)
+	vcdClient.runDiagnosticsTestConnection(ctx, &check, ldapTestConnection(custom))
+	return []DiagnosticsCheck{check}
+}
+
+// diagnoseSubscribedCatalogs checks the connectivity to the publishing endpoint of all subscribed catalogs of an Org
+func (vcdClient *VCDClient) diagnoseSubscribedCatalogs(ctx context.Context, adminOrg *AdminOrg) []DiagnosticsCheck {
+	catalogRecords, err := adminOrg.QueryCatalogList(ctx)
+	if err != nil {
+		return []DiagnosticsCheck{{
+			Category: DiagnosticsCategorySubscribedCatalog,
+			Name:     adminOrg.AdminOrg.Name,
+			Message:  fmt.Sprintf("error retrieving catalogs: %s", err),
+		}}
+	}
+
+	var checks []DiagnosticsCheck
+	for _, catalogRecord := range catalogRecords {
+		if catalogRecord.PublishSubscriptionType != "SUBSCRIBED" {
+			continue
+		}
+		check := DiagnosticsCheck{Category: DiagnosticsCategorySubscribedCatalog, Name: catalogRecord.Name}
+
+		adminCatalog, err := adminOrg.GetAdminCatalogByHref(ctx, catalogRecord.HREF)
+		if err != nil {
+			check.Message = fmt.Sprintf("error retrieving catalog: %s", err)
+			checks = append(checks, check)
+			continue
+		}
+		if adminCatalog.AdminCatalog.ExternalCatalogSubscription == nil ||
+			adminCatalog.AdminCatalog.ExternalCatalogSubscription.Location == "" {
+			check.Message = "subscription URL not found"
+			checks = append(checks, check)
+			continue
+		}
+
+		check.Target = adminCatalog.AdminCatalog.ExternalCatalogSubscription.Location
+		testConnection, err := buildTestConnectionFromUrl(check.Target)
+		if err != nil {
+			check.Message = err.Error()
+			checks = append(checks, check)
+			continue
+		}
+		vcdClient.runDiagnosticsTestConnection(ctx, &check, testConnection)
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// diagnoseAlbControllers checks the connectivity to all ALB Controllers
+func (vcdClient *VCDClient) diagnoseAlbControllers(ctx context.Context) []DiagnosticsCheck {
+	controllers, err := vcdClient.GetAllAlbControllers(ctx, nil)
+	if err != nil {
+		return []DiagnosticsCheck{{
+			Category: DiagnosticsCategoryAlbController,
+			Message:  fmt.Sprintf("error retrieving ALB Controllers: %s", err),
+		}}
+	}
+
+	var checks []DiagnosticsCheck
+	for _, controller := range controllers {
+		check := DiagnosticsCheck{
+			Category: DiagnosticsCategoryAlbController,
+			Name:     controller.NsxtAlbController.Name,
+			Target:   controller.NsxtAlbController.Url,
+		}
+		testConnection, err := buildTestConnectionFromUrl(controller.NsxtAlbController.Url)
+		if err != nil {
+			check.Message = err.Error()
+			checks = append(checks, check)
+			continue
+		}
+		vcdClient.runDiagnosticsTestConnection(ctx, &check, testConnection)
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// runDiagnosticsTestConnection runs a test connection and stores its outcome in check
+func (vcdClient *VCDClient) runDiagnosticsTestConnection(ctx context.Context, check *DiagnosticsCheck, testConnection types.TestConnection) {
+	result, err := vcdClient.Client.TestConnection(ctx, testConnection)
+	if err != nil {
+		check.Message = err.Error()
+		return
+	}
+	check.Result = result
+	evaluateDiagnosticsTestConnection(check, testConnection.Secure != nil && *testConnection.Secure)
+}
+
+// evaluateDiagnosticsTestConnection sets Success and Message of check according to its test connection result
+func evaluateDiagnosticsTestConnection(check *DiagnosticsCheck, secure bool) {
+	if check.Result == nil || check.Result.TargetProbe == nil {
+		check.Message = "no test result returned"
+		return
+	}
+	probe := check.Result.TargetProbe
+	switch {
+	case !probe.CanConnect:
+		check.Message = fmt.Sprintf("cannot connect: %s %s", probe.ConnectionResult, probe.Result)
+	case secure && !probe.SSLHandshake:
+		check.Message = fmt.Sprintf("SSL handshake failed: %s %s", probe.SSLResult, probe.Result)
+	default:
+		check.Success = true
+	}
+}
@@ -0,0 +1,36 @@
+//go:build functional || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_RunExternalServicesDiagnostics(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	report, err := vcd.client.RunExternalServicesDiagnostics(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	check.Assert(report, NotNil)
+	check.Assert(report.EndTime.Before(report.StartTime), Equals, false)
+
+	for _, diagnosticsCheck := range report.Checks {
+		check.Assert(diagnosticsCheck.Category, Not(Equals), "")
+		if !diagnosticsCheck.Success {
+			check.Assert(diagnosticsCheck.Message, Not(Equals), "")
+		}
+		if testVerbose {
+			fmt.Printf("%-20s %-30s %-40s %t %s\n", diagnosticsCheck.Category, diagnosticsCheck.Name,
+				diagnosticsCheck.Target, diagnosticsCheck.Success, diagnosticsCheck.Message)
+		}
+	}
+
+	_, err = vcd.client.RunExternalServicesDiagnostics(ctx, "non-existing-org")
+	check.Assert(err, NotNil)
+}
@@ -0,0 +1,74 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_evaluateDiagnosticsTestConnection(t *testing.T) {
+	tests := []struct {
+		name        string
+		probe       *types.ProbeResult
+		secure      bool
+		wantSuccess bool
+	}{
+		{name: "NoResult", probe: nil, wantSuccess: false},
+		{name: "CannotConnect", probe: &types.ProbeResult{ConnectionResult: "ERROR_CANNOT_CONNECT"}, wantSuccess: false},
+		{name: "SslFailed", probe: &types.ProbeResult{CanConnect: true, SSLResult: "ERROR_UNTRUSTED_CERTIFICATE"}, secure: true, wantSuccess: false},
+		{name: "PlainConnection", probe: &types.ProbeResult{CanConnect: true}, secure: false, wantSuccess: true},
+		{name: "SecureConnection", probe: &types.ProbeResult{CanConnect: true, SSLHandshake: true}, secure: true, wantSuccess: true},
+	}
+	report := DiagnosticsReport{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := DiagnosticsCheck{Name: tt.name}
+			if tt.probe != nil {
+				check.Result = &types.TestConnectionResult{TargetProbe: tt.probe}
+			}
+			evaluateDiagnosticsTestConnection(&check, tt.secure)
+			if check.Success != tt.wantSuccess {
+				t.Errorf("got success %t, expected %t (message: %s)", check.Success, tt.wantSuccess, check.Message)
+			}
+			if !check.Success && check.Message == "" {
+				t.Errorf("failed checks must have a message")
+			}
+			report.Checks = append(report.Checks, check)
+		})
+	}
+	if len(report.Failed()) != 3 {
+		t.Errorf("expected 3 failed checks, got %d", len(report.Failed()))
+	}
+}
+
+func Test_buildTestConnectionFromUrl(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantHost string
+		wantPort int
+		wantErr  bool
+	}{
+		{url: "https://alb.example.com", wantHost: "alb.example.com", wantPort: 443},
+		{url: "http://catalog.example.com/vcsp/lib/1", wantHost: "catalog.example.com", wantPort: 80},
+		{url: "https://10.0.0.1:8443/", wantHost: "10.0.0.1", wantPort: 8443},
+		{url: "https://host:port", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := buildTestConnectionFromUrl(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("buildTestConnectionFromUrl(%s) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got.Host != tt.wantHost || got.Port != tt.wantPort {
+			t.Errorf("buildTestConnectionFromUrl(%s) = %s:%d, expected %s:%d", tt.url, got.Host, got.Port, tt.wantHost, tt.wantPort)
+		}
+	}
+}
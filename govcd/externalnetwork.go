@@ -78,7 +78,7 @@ func (externalNetwork *ExternalNetwork) DeleteWait(ctx context.Context) error {
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("couldn't finish removing external network %#v", err)
+		return fmt.Errorf("couldn't finish removing external network %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,265 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// FailoverOptions are the settings of the failover between the endpoints given to WithFailoverEndpoints
+type FailoverOptions struct {
+	// HealthCheckTimeout is the maximum time to wait for the health check of an endpoint. Defaults to 10 seconds
+	HealthCheckTimeout time.Duration
+	// RetryAfter is the time during which an endpoint that failed is not selected again. Defaults to 1 minute
+	RetryAfter time.Duration
+}
+
+// WithFailoverEndpoints adds alternative endpoints of the same VCD installation (e.g. the addresses of single
+// cells, or the sites of an active/standby pair) to the one given to NewVCDClient.
+//
+// All the requests are sent to the active endpoint, which is initially the one given to NewVCDClient. When the
+// active endpoint cannot be reached, or answers with HTTP 502, 503 or 504, the next endpoint that passes a health
+// check ('/api/versions') becomes the active one and the request is sent to it. The selection is sticky: the client
+// keeps using the new endpoint until it fails in turn.
+//
+// Requests to the hosts of any of the endpoints are redirected to the active one, so that the links returned by
+// VCD keep working after a failover.
+//
+// The request that found the active endpoint failed is sent again to the new one only when this cannot repeat an
+// operation: when it uses an idempotent method (GET, HEAD, OPTIONS, PUT, DELETE), or when its connection could not
+// be established. Otherwise, as for a request with a body that cannot be sent again, its error is returned after the
+// failover.
+//
+// Note. The session is not recreated: the endpoints must share the sessions of the same VCD installation.
+func WithFailoverEndpoints(endpoints []url.URL, options FailoverOptions) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		if len(endpoints) == 0 {
+			return fmt.Errorf("no failover endpoints given")
+		}
+		if _, ok := findRoundTripper[*failoverRoundTripper](vcdClient.Client.Http.Transport); ok {
+			return fmt.Errorf("failover endpoints are already configured")
+		}
+		allEndpoints := []url.URL{vcdClient.Client.VCDHREF}
+		for _, endpoint := range endpoints {
+			if endpoint.Scheme == "" || endpoint.Host == "" {
+				return fmt.Errorf("invalid failover endpoint '%s': scheme and host are required", endpoint.String())
+			}
+			allEndpoints = append(allEndpoints, endpoint)
+		}
+
+		if options.HealthCheckTimeout <= 0 {
+			options.HealthCheckTimeout = 10 * time.Second
+		}
+		if options.RetryAfter <= 0 {
+			options.RetryAfter = time.Minute
+		}
+
+		nextTransport := vcdClient.Client.Http.Transport
+		if nextTransport == nil {
+			nextTransport = http.DefaultTransport
+		}
+		vcdClient.Client.Http.Transport = &failoverRoundTripper{
+			next:      nextTransport,
+			endpoints: allEndpoints,
+			options:   options,
+			failedAt:  make(map[int]time.Time),
+		}
+		return nil
+	}
+}
+
+// GetActiveEndpoint returns the endpoint that receives the requests of the client. It differs from the endpoint
+// given to NewVCDClient only after a failover between the endpoints given to WithFailoverEndpoints
+func (vcdClient *VCDClient) GetActiveEndpoint() url.URL {
+	transport, ok := findRoundTripper[*failoverRoundTripper](vcdClient.Client.Http.Transport)
+	if !ok {
+		return vcdClient.Client.VCDHREF
+	}
+	transport.Lock()
+	defer transport.Unlock()
+	return transport.endpoints[transport.active]
+}
+
+// failoverRoundTripper is an http.RoundTripper that sends the requests to the active endpoint, switching to another
+// healthy endpoint when the active one fails
+type failoverRoundTripper struct {
+	next      http.RoundTripper
+	endpoints []url.URL
+	options   FailoverOptions
+
+	sync.Mutex
+	active   int               // index of the active endpoint
+	failedAt map[int]time.Time // time of the last failure of each endpoint
+}
+
+// RoundTrip implements http.RoundTripper
+func (frt *failoverRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !frt.isEndpointHost(req.URL.Host) {
+		return frt.next.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		frt.Lock()
+		active := frt.active
+		frt.Unlock()
+
+		attemptReq, err := frt.requestForEndpoint(req, active, attempt)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := frt.next.RoundTrip(attemptReq)
+		if !isFailoverResponse(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		util.Logger.Printf("[WARN] VCD endpoint %s failed: %s", frt.endpoints[active].Host, failoverReason(resp, err))
+		if attempt == len(frt.endpoints)-1 || !frt.failover(req.Context(), active) || !canResendRequest(req, err) {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+	}
+}
+
+// requestForEndpoint returns a copy of req sent to the endpoint with the given index
+func (frt *failoverRoundTripper) requestForEndpoint(req *http.Request, index, attempt int) (*http.Request, error) {
+	endpoint := frt.endpoints[index]
+	newReq := req.Clone(req.Context())
+	newReq.URL.Scheme = endpoint.Scheme
+	newReq.URL.Host = endpoint.Host
+	newReq.Host = ""
+	if attempt > 0 && req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		newReq.Body = body
+	}
+	return newReq, nil
+}
+
+// failover marks the endpoint with index failed as unavailable and selects the next healthy endpoint. It returns
+// false when no other endpoint is available
+func (frt *failoverRoundTripper) failover(ctx context.Context, failed int) bool {
+	frt.Lock()
+	frt.failedAt[failed] = time.Now()
+	if frt.active != failed {
+		// Another request already switched to a different endpoint
+		frt.Unlock()
+		return true
+	}
+	var candidates []int
+	for offset := 1; offset < len(frt.endpoints); offset++ {
+		index := (failed + offset) % len(frt.endpoints)
+		if failedAt, ok := frt.failedAt[index]; ok && time.Since(failedAt) < frt.options.RetryAfter {
+			continue
+		}
+		candidates = append(candidates, index)
+	}
+	frt.Unlock()
+
+	for _, index := range candidates {
+		if !frt.isHealthy(ctx, index) {
+			frt.Lock()
+			frt.failedAt[index] = time.Now()
+			frt.Unlock()
+			continue
+		}
+		frt.Lock()
+		if frt.active == failed {
+			frt.active = index
+			util.Logger.Printf("[INFO] switched VCD endpoint from %s to %s", frt.endpoints[failed].Host, frt.endpoints[index].Host)
+		}
+		frt.Unlock()
+		return true
+	}
+	return false
+}
+
+// isHealthy checks that the endpoint with the given index answers to the API versions request
+func (frt *failoverRoundTripper) isHealthy(ctx context.Context, index int) bool {
+	ctx, cancel := context.WithTimeout(ctx, frt.options.HealthCheckTimeout)
+	defer cancel()
+
+	endpoint := frt.endpoints[index]
+	healthUrl := url.URL{Scheme: endpoint.Scheme, Host: endpoint.Host, Path: "/api/versions"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthUrl.String(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := frt.next.RoundTrip(req)
+	if err != nil {
+		util.Logger.Printf("[DEBUG] health check of VCD endpoint %s failed: %s", endpoint.Host, err)
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// isEndpointHost returns true when host belongs to one of the endpoints
+func (frt *failoverRoundTripper) isEndpointHost(host string) bool {
+	for _, endpoint := range frt.endpoints {
+		if endpoint.Host == host {
+			return true
+		}
+	}
+	return false
+}
+
+// isFailoverResponse returns true when the outcome of a request shows that the endpoint is not available
+func isFailoverResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// failoverReason describes why an endpoint is considered failed
+func failoverReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+// canResendRequest returns true when req, whose attempt failed with err, can be sent to another endpoint without
+// repeating an operation: either its method is idempotent, or err shows that it never reached the endpoint
+func canResendRequest(req *http.Request, err error) bool {
+	if !canRetryRequest(req) {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return isConnectionError(err)
+}
+
+// isConnectionError returns true when err was raised before a connection was established, such as a failed name
+// resolution or a refused connection
+func isConnectionError(err error) bool {
+	var opError *net.OpError
+	var dnsError *net.DNSError
+	return errors.As(err, &dnsError) || (errors.As(err, &opError) && opError.Op == "dial")
+}
+
+// canRetryRequest returns true when the body of req, if any, can be sent again
+func canRetryRequest(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
@@ -0,0 +1,170 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// hostRoundTripper answers each request with the status configured for its host, or with a connection error
+// when the host is down
+type hostRoundTripper struct {
+	sync.Mutex
+	down     map[string]bool
+	dropped  map[string]bool // hosts that close the connection after receiving the request
+	statuses map[string]int
+	requests []string // host and path of each request
+	bodies   []string // bodies of the requests that were not health checks
+}
+
+func (hrt *hostRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	hrt.Lock()
+	defer hrt.Unlock()
+	hrt.requests = append(hrt.requests, req.URL.Host+req.URL.Path)
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		hrt.bodies = append(hrt.bodies, string(body))
+	}
+	if hrt.down[req.URL.Host] {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}
+	}
+	if hrt.dropped[req.URL.Host] {
+		return nil, io.ErrUnexpectedEOF
+	}
+	status := http.StatusOK
+	if hrt.statuses[req.URL.Host] != 0 {
+		status = hrt.statuses[req.URL.Host]
+	}
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: http.NoBody, Request: req}, nil
+}
+
+func Test_WithFailoverEndpoints(t *testing.T) {
+	next := &hostRoundTripper{
+		down:     map[string]bool{"cell1.example.com": true},
+		statuses: map[string]int{"cell2.example.com": http.StatusServiceUnavailable},
+	}
+	vcdClient := NewVCDClient(url.URL{Scheme: "https", Host: "cell1.example.com", Path: "/api"}, true)
+	vcdClient.Client.Http.Transport = next
+	err := WithFailoverEndpoints([]url.URL{
+		{Scheme: "https", Host: "cell2.example.com", Path: "/api"},
+		{Scheme: "https", Host: "cell3.example.com", Path: "/api"},
+	}, FailoverOptions{})(vcdClient)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "https://cell1.example.com/api/vApp/vapp-1/action/deploy", strings.NewReader("body"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err := vcdClient.Client.Http.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_ = resp.Body.Close()
+
+	expectedRequests := []string{
+		"cell1.example.com/api/vApp/vapp-1/action/deploy",
+		"cell2.example.com/api/versions",
+		"cell3.example.com/api/versions",
+		"cell3.example.com/api/vApp/vapp-1/action/deploy",
+	}
+	if strings.Join(next.requests, ",") != strings.Join(expectedRequests, ",") {
+		t.Errorf("expected requests %v, got %v", expectedRequests, next.requests)
+	}
+	if next.bodies[len(next.bodies)-1] != "body" {
+		t.Errorf("request body was not sent again after the failover: %v", next.bodies)
+	}
+	if vcdClient.GetActiveEndpoint().Host != "cell3.example.com" {
+		t.Errorf("unexpected active endpoint %s", vcdClient.GetActiveEndpoint().Host)
+	}
+
+	// The selection is sticky, and links to the other endpoints are redirected to the active one
+	next.requests = nil
+	resp, err = vcdClient.Client.Http.Get("https://cell2.example.com/api/org")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_ = resp.Body.Close()
+	if strings.Join(next.requests, ",") != "cell3.example.com/api/org" {
+		t.Errorf("unexpected requests after failover: %v", next.requests)
+	}
+
+	// Hosts that are not endpoints are not changed
+	next.requests = nil
+	resp, err = vcdClient.Client.Http.Get("https://transfer.example.com/file")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_ = resp.Body.Close()
+	if strings.Join(next.requests, ",") != "transfer.example.com/file" {
+		t.Errorf("unexpected requests to other hosts: %v", next.requests)
+	}
+
+	// A request that reached the endpoint is sent again only when its method is idempotent
+	transport, _ := findRoundTripper[*failoverRoundTripper](vcdClient.Client.Http.Transport)
+	transport.failedAt = make(map[int]time.Time)
+	next.down = map[string]bool{}
+	next.statuses = nil
+	next.dropped = map[string]bool{"cell3.example.com": true}
+	next.requests = nil
+	request, err = http.NewRequest(http.MethodPost, "https://cell3.example.com/api/vApp/vapp-1/action/deploy", strings.NewReader("body"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = vcdClient.Client.Http.Do(request)
+	if err == nil {
+		t.Fatalf("expected error for a POST request whose connection was dropped")
+	}
+	expectedRequests = []string{
+		"cell3.example.com/api/vApp/vapp-1/action/deploy",
+		"cell1.example.com/api/versions",
+	}
+	if strings.Join(next.requests, ",") != strings.Join(expectedRequests, ",") {
+		t.Errorf("expected requests %v, got %v", expectedRequests, next.requests)
+	}
+	if vcdClient.GetActiveEndpoint().Host != "cell1.example.com" {
+		t.Errorf("unexpected active endpoint %s", vcdClient.GetActiveEndpoint().Host)
+	}
+
+	next.dropped = map[string]bool{"cell1.example.com": true}
+	next.requests = nil
+	resp, err = vcdClient.Client.Http.Get("https://cell1.example.com/api/org")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_ = resp.Body.Close()
+	expectedRequests = []string{
+		"cell1.example.com/api/org",
+		"cell2.example.com/api/versions",
+		"cell2.example.com/api/org",
+	}
+	if strings.Join(next.requests, ",") != strings.Join(expectedRequests, ",") {
+		t.Errorf("expected requests %v, got %v", expectedRequests, next.requests)
+	}
+
+	// When no endpoint is available, the error is returned
+	next.dropped = nil
+	next.down = map[string]bool{"cell1.example.com": true, "cell2.example.com": true, "cell3.example.com": true}
+	_, err = vcdClient.Client.Http.Get("https://cell2.example.com/api/org")
+	if err == nil {
+		t.Fatalf("expected error when no endpoint is available")
+	}
+
+	err = WithFailoverEndpoints(nil, FailoverOptions{})(NewVCDClient(url.URL{Scheme: "https", Host: "vcd.example.com"}, true))
+	if err == nil {
+		t.Fatalf("expected error without failover endpoints")
+	}
+}
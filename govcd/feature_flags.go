@@ -0,0 +1,150 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// FeatureFlag is a VCD feature flag. Some features supported by the SDK (e.g. IP Spaces on some VCD versions)
+// require a feature flag to be enabled before they can be used.
+type FeatureFlag struct {
+	FeatureFlag *types.FeatureFlag
+	client      *Client
+}
+
+// GetAllFeatureFlags retrieves all VCD feature flags
+func (vcdClient *VCDClient) GetAllFeatureFlags(ctx context.Context, queryParameters url.Values) ([]*FeatureFlag, error) {
+	client := vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointFeatureFlags
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.FeatureFlag{{}}
+	err = client.OpenApiGetAllItems(ctx, apiVersion, urlRef, queryParameters, &typeResponses, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving feature flags: %w", err)
+	}
+
+	results := make([]*FeatureFlag, len(typeResponses))
+	for index, typeResponse := range typeResponses {
+		results[index] = &FeatureFlag{
+			FeatureFlag: typeResponse,
+			client:      &vcdClient.Client,
+		}
+	}
+	return results, nil
+}
+
+// GetFeatureFlagById retrieves a VCD feature flag by its ID
+func (vcdClient *VCDClient) GetFeatureFlagById(ctx context.Context, id string) (*FeatureFlag, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty feature flag ID")
+	}
+
+	client := vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointFeatureFlags
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FeatureFlag{
+		FeatureFlag: &types.FeatureFlag{},
+		client:      &vcdClient.Client,
+	}
+	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, result.FeatureFlag, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving feature flag '%s': %w", id, err)
+	}
+	return result, nil
+}
+
+// GetFeatureFlagByName retrieves a VCD feature flag by its name
+func (vcdClient *VCDClient) GetFeatureFlagByName(ctx context.Context, name string) (*FeatureFlag, error) {
+	if name == "" {
+		return nil, fmt.Errorf("empty feature flag name")
+	}
+
+	allFeatureFlags, err := vcdClient.GetAllFeatureFlags(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*FeatureFlag
+	for _, featureFlag := range allFeatureFlags {
+		if featureFlag.FeatureFlag.Name == name {
+			found = append(found, featureFlag)
+		}
+	}
+	return oneOrError("name", name, found)
+}
+
+// IsFeatureFlagEnabled returns whether the VCD feature flag with the given name is enabled
+func (vcdClient *VCDClient) IsFeatureFlagEnabled(ctx context.Context, name string) (bool, error) {
+	featureFlag, err := vcdClient.GetFeatureFlagByName(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return featureFlag.FeatureFlag.Enabled, nil
+}
+
+// Enable enables the feature flag. Only System Administrators can change feature flags.
+func (featureFlag *FeatureFlag) Enable(ctx context.Context) error {
+	return featureFlag.setEnabled(ctx, true)
+}
+
+// Disable disables the feature flag. Only System Administrators can change feature flags.
+func (featureFlag *FeatureFlag) Disable(ctx context.Context) error {
+	return featureFlag.setEnabled(ctx, false)
+}
+
+// setEnabled sets the state of the feature flag and refreshes the receiver with the values returned by VCD
+func (featureFlag *FeatureFlag) setEnabled(ctx context.Context, enabled bool) error {
+	client := featureFlag.client
+	if !client.IsSysAdmin {
+		return fmt.Errorf("only System Administrator can change feature flags")
+	}
+	if featureFlag.FeatureFlag.ID == "" {
+		return fmt.Errorf("cannot update feature flag without ID")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointFeatureFlags
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, featureFlag.FeatureFlag.ID)
+	if err != nil {
+		return err
+	}
+
+	payload := *featureFlag.FeatureFlag
+	payload.Enabled = enabled
+	result := &types.FeatureFlag{}
+	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, &payload, result, nil)
+	if err != nil {
+		return fmt.Errorf("error updating feature flag '%s': %w", featureFlag.FeatureFlag.Name, err)
+	}
+	featureFlag.FeatureFlag = result
+	return nil
+}
@@ -0,0 +1,41 @@
+//go:build api || functional || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_FeatureFlags(check *C) {
+	if !vcd.client.Client.IsSysAdmin {
+		check.Skip("Test_FeatureFlags requires System administrator privileges")
+	}
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointFeatureFlags)
+
+	allFeatureFlags, err := vcd.client.GetAllFeatureFlags(ctx, nil)
+	check.Assert(err, IsNil)
+	if len(allFeatureFlags) == 0 {
+		check.Skip("no feature flags found")
+	}
+
+	featureFlag := allFeatureFlags[0]
+	byId, err := vcd.client.GetFeatureFlagById(ctx, featureFlag.FeatureFlag.ID)
+	check.Assert(err, IsNil)
+	check.Assert(byId.FeatureFlag, DeepEquals, featureFlag.FeatureFlag)
+
+	byName, err := vcd.client.GetFeatureFlagByName(ctx, featureFlag.FeatureFlag.Name)
+	check.Assert(err, IsNil)
+	check.Assert(byName.FeatureFlag, DeepEquals, featureFlag.FeatureFlag)
+
+	enabled, err := vcd.client.IsFeatureFlagEnabled(ctx, featureFlag.FeatureFlag.Name)
+	check.Assert(err, IsNil)
+	check.Assert(enabled, Equals, featureFlag.FeatureFlag.Enabled)
+
+	_, err = vcd.client.GetFeatureFlagByName(ctx, "non-existing-feature-flag")
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
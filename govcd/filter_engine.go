@@ -73,7 +73,7 @@ func searchByFilter(ctx context.Context, queryByMetadata queryByMetadataFunc, qu
 		case types.FilterNameRegex:
 			re, err := regexp.Compile(value)
 			if err != nil {
-				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %s ", value, err)
+				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %w ", value, err)
 			}
 			conditions = append(conditions, conditionDef{key, nameCondition{re}})
 		case types.FilterDate:
@@ -81,7 +81,7 @@ func searchByFilter(ctx context.Context, queryByMetadata queryByMetadataFunc, qu
 		case types.FilterIp:
 			re, err := regexp.Compile(value)
 			if err != nil {
-				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %s ", value, err)
+				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %w ", value, err)
 			}
 			conditions = append(conditions, conditionDef{key, ipCondition{re}})
 		case types.FilterParent:
@@ -128,7 +128,7 @@ func searchByFilter(ctx context.Context, queryByMetadata queryByMetadataFunc, qu
 				// The type must be one of the expected values
 				err := validateMetadataType(cond.Type)
 				if err != nil {
-					return nil, explanation, fmt.Errorf("type '%s' for metadata field '%s' is invalid. :%s", cond.Type, cond.Key, err)
+					return nil, explanation, fmt.Errorf("type '%s' for metadata field '%s' is invalid. :%w", cond.Type, cond.Key, err)
 				}
 				metadataFilter[cond.Key] = MetadataFilter{
 					Type:  cond.Type,
@@ -142,7 +142,7 @@ func searchByFilter(ctx context.Context, queryByMetadata queryByMetadataFunc, qu
 				metadataFields = append(metadataFields, k)
 				re, err := regexp.Compile(v.(string))
 				if err != nil {
-					return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %s ", v, err)
+					return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %w ", v, err)
 				}
 				conditions = append(conditions, conditionDef{"metadata", metadataRegexpCondition{k, re}})
 			}
@@ -163,7 +163,7 @@ func searchByFilter(ctx context.Context, queryByMetadata queryByMetadataFunc, qu
 	}
 
 	if err != nil {
-		return nil, explanation, fmt.Errorf("[SearchByFilter] error retrieving query item list: %s", err)
+		return nil, explanation, fmt.Errorf("[SearchByFilter] error retrieving query item list: %w", err)
 	}
 	if dataInspectionRequested("QE1") {
 		util.Logger.Printf("[INSPECT-QE1-SearchByFilter] list of retrieved items %# v\n", pretty.Formatter(itemResult.Results))
@@ -173,7 +173,7 @@ func searchByFilter(ctx context.Context, queryByMetadata queryByMetadataFunc, qu
 	// Converting the query result into a list of QueryItems
 	itemList, err = converter(queryType, itemResult)
 	if err != nil {
-		return nil, explanation, fmt.Errorf("[SearchByFilter] error converting QueryItem  item list: %s", err)
+		return nil, explanation, fmt.Errorf("[SearchByFilter] error converting QueryItem  item list: %w", err)
 	}
 	if dataInspectionRequested("QE2") {
 		util.Logger.Printf("[INSPECT-QE2-SearchByFilter] list of converted items %# v\n", pretty.Formatter(itemList))
@@ -190,7 +190,7 @@ func searchByFilter(ctx context.Context, queryByMetadata queryByMetadataFunc, qu
 			}
 			result, definition, err := conditionMatches(condition.conditionType, condition.stored, item)
 			if err != nil {
-				return nil, explanation, fmt.Errorf("[SearchByFilter] error applying condition %v: %s", condition, err)
+				return nil, explanation, fmt.Errorf("[SearchByFilter] error applying condition %v: %w", condition, err)
 			}
 
 			// Saves matching information, which will be consolidated in the final explanation text
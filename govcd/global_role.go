@@ -131,7 +131,7 @@ func (client *Client) CreateGlobalRole(ctx context.Context, newGlobalRole *types
 
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, newGlobalRole, returnGlobalRole.GlobalRole, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating global role: %s", err)
+		return nil, fmt.Errorf("error creating global role: %w", err)
 	}
 
 	return returnGlobalRole, nil
@@ -161,12 +161,27 @@ func (globalRole *GlobalRole) Update(ctx context.Context) (*GlobalRole, error) {
 
 	err = globalRole.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, globalRole.GlobalRole, returnGlobalRole.GlobalRole, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating global role: %s", err)
+		return nil, fmt.Errorf("error updating global role: %w", err)
 	}
 
 	return returnGlobalRole, nil
 }
 
+// Refresh reloads the global role from VCD
+func (globalRole *GlobalRole) Refresh(ctx context.Context) error {
+	if globalRole.GlobalRole.Id == "" {
+		return fmt.Errorf("cannot refresh global role without id")
+	}
+
+	refreshedGlobalRole, err := globalRole.client.GetGlobalRoleById(ctx, globalRole.GlobalRole.Id)
+	if err != nil {
+		return fmt.Errorf("error refreshing global role: %w", err)
+	}
+	globalRole.GlobalRole = refreshedGlobalRole.GlobalRole
+
+	return nil
+}
+
 // Delete deletes global role
 func (globalRole *GlobalRole) Delete(ctx context.Context) error {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointGlobalRoles
@@ -187,7 +202,7 @@ func (globalRole *GlobalRole) Delete(ctx context.Context) error {
 	err = globalRole.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting global role: %s", err)
+		return fmt.Errorf("error deleting global role: %w", err)
 	}
 
 	return nil
@@ -271,7 +286,7 @@ func publishContainerToTenants(ctx context.Context, client *Client, containerTyp
 	err = action(ctx, minimumApiVersion, urlRef, nil, &input, &pages, nil)
 
 	if err != nil {
-		return fmt.Errorf("error publishing %s %s to tenants: %s", containerType, name, err)
+		return fmt.Errorf("error publishing %s %s to tenants: %w", containerType, name, err)
 	}
 
 	return nil
@@ -309,7 +324,7 @@ func publishContainerToAllTenants(ctx context.Context, client *Client, container
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, &pages, &pages, nil)
 
 	if err != nil {
-		return fmt.Errorf("error publishing %s %s to tenants: %s", containerType, name, err)
+		return fmt.Errorf("error publishing %s %s to tenants: %w", containerType, name, err)
 	}
 
 	return nil
@@ -339,6 +354,24 @@ func (globalRole *GlobalRole) RemoveAllRights(ctx context.Context) error {
 	return removeAllRightsFromRole(ctx, globalRole.client, "GlobalRole", globalRole.GlobalRole.Name, globalRole.GlobalRole.Id, endpoint, nil)
 }
 
+// AddRightsByName adds the rights with the given names to a global role, together with the rights they imply
+func (globalRole *GlobalRole) AddRightsByName(ctx context.Context, rightNames ...string) error {
+	newRights, err := getRightsSetByName(ctx, globalRole.client, rightNames, true, nil)
+	if err != nil {
+		return err
+	}
+	return globalRole.AddRights(ctx, newRights)
+}
+
+// RemoveRightsByName removes the rights with the given names from a global role. The rights they imply are kept
+func (globalRole *GlobalRole) RemoveRightsByName(ctx context.Context, rightNames ...string) error {
+	removeRights, err := getRightsSetByName(ctx, globalRole.client, rightNames, false, nil)
+	if err != nil {
+		return err
+	}
+	return globalRole.RemoveRights(ctx, removeRights)
+}
+
 // GetRights retrieves all rights belonging to a given Global Role. Query parameters can be supplied to perform additional
 // filtering
 func (globalRole *GlobalRole) GetRights(ctx context.Context, queryParameters url.Values) ([]*types.Right, error) {
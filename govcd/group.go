@@ -41,7 +41,7 @@ func (adminOrg *AdminOrg) CreateGroup(ctx context.Context, group *types.Group) (
 
 	groupCreateHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing admin org url: %s", err)
+		return nil, fmt.Errorf("error parsing admin org url: %w", err)
 	}
 	groupCreateHREF.Path += "/groups"
 
@@ -129,7 +129,7 @@ func (group *OrgGroup) Update(ctx context.Context) error {
 
 	groupHREF, err := url.ParseRequestURI(group.Group.Href)
 	if err != nil {
-		return fmt.Errorf("error getting HREF for group %s : %s", group.Group.Href, err)
+		return fmt.Errorf("error getting HREF for group %s : %w", group.Group.Href, err)
 	}
 	util.Logger.Printf("[TRACE] Url for updating group : %s and name: %s", groupHREF.String(), group.Group.Name)
 
@@ -146,7 +146,7 @@ func (group *OrgGroup) Delete(ctx context.Context) error {
 
 	groupHREF, err := url.ParseRequestURI(group.Group.Href)
 	if err != nil {
-		return fmt.Errorf("error getting HREF for group %s : %s", group.Group.Name, err)
+		return fmt.Errorf("error getting HREF for group %s : %w", group.Group.Name, err)
 	}
 	util.Logger.Printf("[TRACE] Url for deleting group : %s and name: %s", groupHREF, group.Group.Name)
 
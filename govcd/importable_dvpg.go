@@ -55,7 +55,7 @@ func (vdc *Vdc) GetVcenterImportableDvpgByName(ctx context.Context, name string)
 
 	vcImportableDvpgs, err := vdc.GetAllVcenterImportableDvpgs(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not find Distributed Virtual Port Group with name '%s': %s", name, err)
+		return nil, fmt.Errorf("could not find Distributed Virtual Port Group with name '%s': %w", name, err)
 	}
 
 	filteredVcImportableDvpgs := filterVcImportableDvpgsByName(name, vcImportableDvpgs)
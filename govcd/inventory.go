@@ -0,0 +1,320 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// defaultInventoryConcurrency is the number of queries run at the same time by CollectInventory when
+// InventoryScope.MaxConcurrency is not set
+const defaultInventoryConcurrency = 4
+
+// InventoryScope defines which objects are collected by VCDClient.CollectInventory
+type InventoryScope struct {
+	// OrgName limits the inventory to a single Org. When empty, all the Orgs visible to the client are collected
+	OrgName string
+
+	SkipEdgeGateways bool
+	SkipNetworks     bool
+	SkipCatalogs     bool
+	SkipVApps        bool
+	SkipVms          bool
+	// SkipDisks skips independent disks
+	SkipDisks bool
+	// SkipStorageProfiles skips the storage profiles of the VDCs
+	SkipStorageProfiles bool
+	// SkipNetworkDetails skips the OpenAPI view of Org VDC networks, which includes the IP usage of each network
+	SkipNetworkDetails bool
+
+	// MaxConcurrency is the maximum number of queries running at the same time. Defaults to 4
+	MaxConcurrency int
+}
+
+// InventorySnapshot is a read-only view of the resources of a VCD, collected at a given time. It only contains
+// query records and can be serialized (e.g. to JSON) for audit or backup purposes.
+type InventorySnapshot struct {
+	CollectedAt time.Time `json:"collectedAt"`
+	Site        string    `json:"site"`
+	ApiVersion  string    `json:"apiVersion"`
+	Scope       string    `json:"scope"` // The Org name, or "system" when all Orgs were collected
+
+	Orgs         []*types.Org                                `json:"orgs"`
+	Vdcs         []*types.QueryResultOrgVdcRecordType        `json:"vdcs"`
+	EdgeGateways []*types.QueryResultEdgeGatewayRecordType   `json:"edgeGateways,omitempty"`
+	Networks     []*types.QueryResultOrgVdcNetworkRecordType `json:"networks,omitempty"`
+	Catalogs     []*types.CatalogRecord                      `json:"catalogs,omitempty"`
+	VApps        []*types.QueryResultVAppRecordType          `json:"vApps,omitempty"`
+	Vms          []*types.QueryResultVMRecordType            `json:"vms,omitempty"`
+
+	Disks           []*types.DiskRecordType                            `json:"disks,omitempty"`
+	StorageProfiles []*types.QueryResultOrgVdcStorageProfileRecordType `json:"storageProfiles,omitempty"`
+	NetworkDetails  []*types.OpenApiOrgVdcNetwork                      `json:"networkDetails,omitempty"`
+}
+
+// CollectInventory gathers Orgs, VDCs, Edge Gateways, Org VDC networks, catalogs, vApps, VMs, independent disks
+// and VDC storage profiles into a single InventorySnapshot. The objects are retrieved with paginated queries, running up to scope.MaxConcurrency of them
+// at the same time. Nothing is modified in VCD. VMs in vApp templates are not included.
+//
+// When scope.OrgName is set, only the objects of that Org are collected. Tenant users can only see their own Org.
+func (vcdClient *VCDClient) CollectInventory(ctx context.Context, scope InventoryScope) (*InventorySnapshot, error) {
+	client := &vcdClient.Client
+	snapshot := &InventorySnapshot{
+		CollectedAt: time.Now(),
+		Site:        client.VCDHREF.String(),
+		ApiVersion:  client.APIVersion,
+		Scope:       "system",
+	}
+
+	orgList, err := vcdClient.GetOrgList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error collecting inventory: %w", err)
+	}
+	orgFilter := map[string]string{}
+	networkQueryParameters := url.Values{}
+	if scope.OrgName != "" {
+		snapshot.Scope = scope.OrgName
+		for _, org := range orgList.Org {
+			if strings.EqualFold(org.Name, scope.OrgName) {
+				snapshot.Orgs = append(snapshot.Orgs, org)
+				orgFilter["org"] = org.HREF
+				networkQueryParameters.Set("filter", "orgRef.id=="+"urn:vcloud:org:"+extractUuid(org.HREF))
+			}
+		}
+		if len(snapshot.Orgs) == 0 {
+			return nil, fmt.Errorf("error collecting inventory: %s: Org '%s'", ErrorEntityNotFound, scope.OrgName)
+		}
+	} else {
+		snapshot.Orgs = orgList.Org
+	}
+
+	snapshot.Vdcs, err = queryOrgVdcList(ctx, client, orgFilter)
+	if err != nil {
+		return nil, fmt.Errorf("error collecting VDCs: %w", err)
+	}
+	vdcIds := make(map[string]bool, len(snapshot.Vdcs))
+	for _, vdc := range snapshot.Vdcs {
+		vdcIds[inventoryUuid(vdc.HREF)] = true
+	}
+	// Edge Gateways, networks, disks and storage profiles are not filtered by Org on the server side. When the scope
+	// is an Org, they are filtered by VDC
+	inScope := func(vdcReference string) bool {
+		return scope.OrgName == "" || vdcIds[inventoryUuid(vdcReference)]
+	}
+
+	var collectors []inventoryCollector
+	if !scope.SkipEdgeGateways {
+		collectors = append(collectors, inventoryCollector{"Edge Gateways", func(ctx context.Context) error {
+			records, err := queryInventoryRecords(ctx, client, types.QtEdgeGateway, nil)
+			for _, record := range records.EdgeGatewayRecord {
+				if inScope(record.Vdc) {
+					snapshot.EdgeGateways = append(snapshot.EdgeGateways, record)
+				}
+			}
+			return err
+		}})
+	}
+	if !scope.SkipNetworks {
+		collectors = append(collectors, inventoryCollector{"Org VDC networks", func(ctx context.Context) error {
+			records, err := queryInventoryRecords(ctx, client, types.QtOrgVdcNetwork, nil)
+			for _, record := range records.OrgVdcNetworkRecord {
+				if inScope(record.Vdc) {
+					snapshot.Networks = append(snapshot.Networks, record)
+				}
+			}
+			return err
+		}})
+	}
+	if !scope.SkipCatalogs {
+		collectors = append(collectors, inventoryCollector{"catalogs", func(ctx context.Context) error {
+			var err error
+			snapshot.Catalogs, err = queryCatalogList(ctx, client, orgFilter)
+			return err
+		}})
+	}
+	if !scope.SkipVApps {
+		collectors = append(collectors, inventoryCollector{"vApps", func(ctx context.Context) error {
+			records, err := queryInventoryRecords(ctx, client, types.QtVapp, orgFilter)
+			snapshot.VApps = append(records.VAppRecord, records.AdminVAppRecord...)
+			return err
+		}})
+	}
+	if !scope.SkipVms {
+		collectors = append(collectors, inventoryCollector{"VMs", func(ctx context.Context) error {
+			filter := map[string]string{"isVAppTemplate": "false"}
+			for key, value := range orgFilter {
+				filter[key] = value
+			}
+			records, err := queryInventoryRecords(ctx, client, types.QtVm, filter)
+			snapshot.Vms = append(records.VMRecord, records.AdminVMRecord...)
+			return err
+		}})
+	}
+
+	if !scope.SkipDisks {
+		collectors = append(collectors, inventoryCollector{"independent disks", func(ctx context.Context) error {
+			records, err := queryInventoryRecords(ctx, client, types.QtDisk, nil)
+			for _, record := range append(records.DiskRecord, records.AdminDiskRecord...) {
+				if inScope(record.Vdc) {
+					snapshot.Disks = append(snapshot.Disks, record)
+				}
+			}
+			return err
+		}})
+	}
+	if !scope.SkipStorageProfiles {
+		collectors = append(collectors, inventoryCollector{"storage profiles", func(ctx context.Context) error {
+			records, err := queryInventoryRecords(ctx, client, types.QtOrgVdcStorageProfile, nil)
+			for _, record := range records.OrgVdcStorageProfileRecord {
+				if inScope(record.Vdc) {
+					snapshot.StorageProfiles = append(snapshot.StorageProfiles, record)
+				}
+			}
+			for _, record := range records.AdminOrgVdcStorageProfileRecord {
+				if inScope(record.Vdc) {
+					snapshot.StorageProfiles = append(snapshot.StorageProfiles, adminStorageProfileRecordToTenant(record))
+				}
+			}
+			return err
+		}})
+	}
+	if !scope.SkipNetworkDetails {
+		collectors = append(collectors, inventoryCollector{"Org VDC network details", func(ctx context.Context) error {
+			networks, err := getAllOpenApiOrgVdcNetworks(ctx, client, copyOrNewUrlValues(networkQueryParameters))
+			for _, network := range networks {
+				snapshot.NetworkDetails = append(snapshot.NetworkDetails, network.OpenApiOrgVdcNetwork)
+			}
+			return err
+		}})
+	}
+
+	err = runInventoryCollectors(ctx, collectors, scope.MaxConcurrency)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// inventoryCollector retrieves one kind of objects for CollectInventory. Each collector writes to its own
+// fields of the snapshot, so that collectors can run concurrently.
+type inventoryCollector struct {
+	name    string
+	collect func(ctx context.Context) error
+}
+
+// runInventoryCollectors runs the collectors with at most maxConcurrency of them at the same time, starting them in
+// order. After the first error, the collectors which did not start yet are skipped. All the errors are returned
+// together.
+func runInventoryCollectors(ctx context.Context, collectors []inventoryCollector, maxConcurrency int) error {
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultInventoryConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	semaphore := make(chan struct{}, maxConcurrency)
+	var waitGroup sync.WaitGroup
+	var mutex sync.Mutex
+	var errorMessages []string
+
+	for _, collector := range collectors {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// A failed collector cancels the context before releasing its slot, so that the next one is not started
+		if ctx.Err() != nil {
+			break
+		}
+
+		collector := collector
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			defer func() { <-semaphore }()
+
+			util.Logger.Printf("[TRACE] CollectInventory: collecting %s", collector.name)
+			err := collector.collect(ctx)
+			if err != nil {
+				mutex.Lock()
+				errorMessages = append(errorMessages, fmt.Sprintf("error collecting %s: %s", collector.name, err))
+				mutex.Unlock()
+				cancel()
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("%s", strings.Join(errorMessages, "; "))
+	}
+	// The parent context may have been cancelled before all the collectors ran
+	return ctx.Err()
+}
+
+// queryInventoryRecords runs a paginated query of the given type (converted to the admin type for System
+// administrators) with optional equality filters
+func queryInventoryRecords(ctx context.Context, client *Client, queryType string, filterFields map[string]string) (*types.QueryResultRecordsType, error) {
+	queryType = client.GetQueryType(queryType)
+	params := map[string]string{
+		"type":          queryType,
+		"filterEncoded": "true",
+	}
+	var filters []string
+	for key, value := range filterFields {
+		filters = append(filters, fmt.Sprintf("%s==%s", key, url.QueryEscape(value)))
+	}
+	sort.Strings(filters)
+	if len(filters) > 0 {
+		params["filter"] = strings.Join(filters, ";")
+	}
+
+	results, err := client.cumulativeQuery(ctx, queryType, nil, params)
+	if err != nil {
+		return &types.QueryResultRecordsType{}, err
+	}
+	return results.Results, nil
+}
+
+// adminStorageProfileRecordToTenant converts an admin storage profile record to the tenant record type, so that
+// the inventory has a single list of storage profiles for all users
+func adminStorageProfileRecordToTenant(record *types.QueryResultAdminOrgVdcStorageProfileRecordType) *types.QueryResultOrgVdcStorageProfileRecordType {
+	return &types.QueryResultOrgVdcStorageProfileRecordType{
+		HREF:                    record.HREF,
+		ID:                      record.ID,
+		Type:                    record.Type,
+		Name:                    record.Name,
+		IsEnabled:               record.IsEnabled,
+		IsDefaultStorageProfile: record.IsDefaultStorageProfile,
+		StorageUsedMB:           record.StorageUsedMB,
+		StorageLimitMB:          record.StorageLimitMB,
+		IopsAllocated:           record.IopsAllocated,
+		IopsLimit:               record.IopsLimit,
+		NumberOfConditions:      record.NumberOfConditions,
+		Vdc:                     record.Vdc,
+		VdcName:                 record.VdcName,
+		Link:                    record.Link,
+		MetadataEntry:           record.MetadataEntry,
+	}
+}
+
+// inventoryUuid returns the UUID of a reference that can be either an HREF or a URN, or the reference itself if
+// it does not contain a UUID
+func inventoryUuid(reference string) string {
+	uuid := extractUuid(reference)
+	if uuid == "" {
+		return reference
+	}
+	return uuid
+}
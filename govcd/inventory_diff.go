@@ -0,0 +1,247 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Kinds of InventoryChange
+const (
+	InventoryChangeCreated  = "created"
+	InventoryChangeDeleted  = "deleted"
+	InventoryChangeModified = "modified"
+)
+
+// InventoryChangeSet is the difference between two InventorySnapshot, as returned by InventorySnapshot.Diff
+type InventoryChangeSet struct {
+	OldCollectedAt time.Time          `json:"oldCollectedAt"`
+	NewCollectedAt time.Time          `json:"newCollectedAt"`
+	Changes        []*InventoryChange `json:"changes"`
+}
+
+// InventoryChange describes an object that was created, deleted or modified between two snapshots
+type InventoryChange struct {
+	Kind       string `json:"kind"` // One of the InventoryChange* values
+	ObjectType string `json:"objectType"`
+	ObjectName string `json:"objectName"`
+	ObjectHref string `json:"objectHref"` // HREF or ID of the object
+	// Fields lists the key attributes that changed, only for modified objects
+	Fields []*InventoryFieldChange `json:"fields,omitempty"`
+}
+
+// InventoryFieldChange is the change of a single attribute of a modified object
+type InventoryFieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"oldValue"`
+	NewValue string `json:"newValue"`
+}
+
+// inventoryDiffEntry is the comparable view of an object of a snapshot: its identity and its key attributes
+type inventoryDiffEntry struct {
+	name   string
+	href   string
+	fields []inventoryDiffField
+}
+
+// inventoryDiffField is a key attribute of an inventoryDiffEntry. Fields are kept in a slice to report changes in a
+// stable order
+type inventoryDiffField struct {
+	name  string
+	value string
+}
+
+// Diff compares the snapshot with newSnapshot, taken later, and returns the objects created, deleted and modified
+// in between. Objects are matched by the UUID in their HREF and only their key attributes (e.g. status, size,
+// storage profile, network settings) are compared; counters and usage values are ignored. NetworkDetails are not
+// compared, as the same networks are already compared through Networks.
+//
+// Changes are sorted by object type (in the order of the snapshot fields) and by name. Both snapshots should be
+// collected with the same InventoryScope: objects skipped in only one of them are reported as created or deleted.
+func (snapshot *InventorySnapshot) Diff(newSnapshot *InventorySnapshot) *InventoryChangeSet {
+	changeSet := &InventoryChangeSet{
+		OldCollectedAt: snapshot.CollectedAt,
+		NewCollectedAt: newSnapshot.CollectedAt,
+	}
+	oldEntries := snapshot.diffEntries()
+	newEntries := newSnapshot.diffEntries()
+	for _, objectType := range inventoryDiffObjectTypes {
+		changeSet.Changes = append(changeSet.Changes,
+			diffInventoryEntries(objectType, oldEntries[objectType], newEntries[objectType])...)
+	}
+	return changeSet
+}
+
+// inventoryDiffObjectTypes are the object types compared by InventorySnapshot.Diff, in the order in which changes
+// are reported
+var inventoryDiffObjectTypes = []string{"Org", "VDC", "Edge Gateway", "network", "catalog", "vApp", "VM", "disk",
+	"storage profile"}
+
+// diffEntries returns the comparable entries of the snapshot by object type
+func (snapshot *InventorySnapshot) diffEntries() map[string][]inventoryDiffEntry {
+	entries := make(map[string][]inventoryDiffEntry)
+	add := func(objectType string, entry inventoryDiffEntry) {
+		entries[objectType] = append(entries[objectType], entry)
+	}
+	for _, org := range snapshot.Orgs {
+		add("Org", inventoryDiffEntry{name: org.Name, href: org.HREF, fields: []inventoryDiffField{
+			{"isEnabled", strconv.FormatBool(org.IsEnabled)},
+		}})
+	}
+	for _, vdc := range snapshot.Vdcs {
+		add("VDC", inventoryDiffEntry{name: vdc.Name, href: vdc.HREF, fields: []inventoryDiffField{
+			{"isEnabled", vdc.IsEnabled},
+			{"status", vdc.Status},
+			{"allocationModel", vdc.AllocationModel},
+			{"providerVdcName", vdc.ProviderVdcName},
+			{"cpuAllocationMhz", inventoryDiffInt(vdc.CpuAllocationMhz)},
+			{"cpuLimitMhz", inventoryDiffInt(vdc.CpuLimitMhz)},
+			{"memoryAllocationMB", inventoryDiffInt(vdc.MemoryAllocationMB)},
+			{"memoryLimitMB", inventoryDiffInt(vdc.MemoryLimitMB)},
+			{"storageLimitMB", inventoryDiffInt(vdc.StorageLimitMB)},
+		}})
+	}
+	for _, edge := range snapshot.EdgeGateways {
+		add("Edge Gateway", inventoryDiffEntry{name: edge.Name, href: edge.HREF, fields: []inventoryDiffField{
+			{"orgVdcName", edge.OrgVdcName},
+			{"gatewayStatus", edge.GatewayStatus},
+			{"haStatus", edge.HaStatus},
+		}})
+	}
+	for _, network := range snapshot.Networks {
+		add("network", inventoryDiffEntry{name: network.Name, href: network.HREF, fields: []inventoryDiffField{
+			{"vdcName", network.VdcName},
+			{"linkType", strconv.Itoa(network.LinkType)},
+			{"connectedTo", network.ConnectedTo},
+			{"defaultGateway", network.DefaultGateway},
+			{"netmask", network.Netmask},
+			{"isShared", strconv.FormatBool(network.IsShared)},
+		}})
+	}
+	for _, catalog := range snapshot.Catalogs {
+		add("catalog", inventoryDiffEntry{name: catalog.Name, href: catalog.HREF, fields: []inventoryDiffField{
+			{"description", catalog.Description},
+			{"ownerName", catalog.OwnerName},
+			{"isPublished", strconv.FormatBool(catalog.IsPublished)},
+			{"isShared", strconv.FormatBool(catalog.IsShared)},
+			{"publishSubscriptionType", catalog.PublishSubscriptionType},
+		}})
+	}
+	for _, vApp := range snapshot.VApps {
+		add("vApp", inventoryDiffEntry{name: vApp.Name, href: vApp.HREF, fields: []inventoryDiffField{
+			{"description", vApp.Description},
+			{"vdcName", vApp.VdcName},
+			{"ownerName", vApp.OwnerName},
+			{"status", vApp.Status},
+			{"isDeployed", strconv.FormatBool(vApp.Deployed)},
+			{"numberOfVMs", strconv.Itoa(vApp.NumberOfVMs)},
+		}})
+	}
+	for _, vm := range snapshot.Vms {
+		add("VM", inventoryDiffEntry{name: vm.Name, href: vm.HREF, fields: []inventoryDiffField{
+			{"containerName", vm.ContainerName},
+			{"ownerName", vm.OwnerName},
+			{"status", vm.Status},
+			{"guestOs", vm.GuestOS},
+			{"numberOfCpus", strconv.Itoa(vm.Cpus)},
+			{"memoryMB", strconv.Itoa(vm.MemoryMB)},
+			{"hardwareVersion", strconv.Itoa(vm.HardwareVersion)},
+			{"storageProfileName", vm.StorageProfileName},
+			{"networkName", vm.NetworkName},
+			{"ipAddress", vm.IpAddress},
+			{"vmSizingPolicyId", vm.VmSizingPolicyId},
+			{"vmPlacementPolicyId", vm.VmPlacementPolicyId},
+		}})
+	}
+	for _, disk := range snapshot.Disks {
+		add("disk", inventoryDiffEntry{name: disk.Name, href: disk.HREF, fields: []inventoryDiffField{
+			{"description", disk.Description},
+			{"vdcName", disk.VdcName},
+			{"ownerName", disk.OwnerName},
+			{"status", disk.Status},
+			{"sizeMb", strconv.FormatInt(disk.SizeMb, 10)},
+			{"iops", strconv.FormatInt(disk.Iops, 10)},
+			{"storageProfileName", disk.StorageProfileName},
+			{"attachedVmCount", strconv.Itoa(int(disk.AttachedVmCount))},
+		}})
+	}
+	for _, profile := range snapshot.StorageProfiles {
+		add("storage profile", inventoryDiffEntry{name: profile.Name, href: profile.HREF, fields: []inventoryDiffField{
+			{"vdcName", profile.VdcName},
+			{"isEnabled", strconv.FormatBool(profile.IsEnabled)},
+			{"isDefaultStorageProfile", strconv.FormatBool(profile.IsDefaultStorageProfile)},
+			{"storageLimitMB", strconv.FormatUint(profile.StorageLimitMB, 10)},
+			{"iopsLimit", strconv.FormatUint(profile.IopsLimit, 10)},
+		}})
+	}
+	return entries
+}
+
+// diffInventoryEntries compares the objects of one type of two snapshots. Objects are matched by UUID, so that the
+// same object matches in snapshots taken by users with different views (e.g. admin and tenant HREFs)
+func diffInventoryEntries(objectType string, oldEntries, newEntries []inventoryDiffEntry) []*InventoryChange {
+	oldByHref := make(map[string]inventoryDiffEntry, len(oldEntries))
+	for _, entry := range oldEntries {
+		oldByHref[inventoryUuid(entry.href)] = entry
+	}
+	newByHref := make(map[string]inventoryDiffEntry, len(newEntries))
+	for _, entry := range newEntries {
+		newByHref[inventoryUuid(entry.href)] = entry
+	}
+
+	var changes []*InventoryChange
+	for key, oldEntry := range oldByHref {
+		newEntry, ok := newByHref[key]
+		if !ok {
+			changes = append(changes, &InventoryChange{Kind: InventoryChangeDeleted, ObjectType: objectType,
+				ObjectName: oldEntry.name, ObjectHref: oldEntry.href})
+			continue
+		}
+		fieldChanges := diffInventoryFields(oldEntry, newEntry)
+		if len(fieldChanges) > 0 {
+			changes = append(changes, &InventoryChange{Kind: InventoryChangeModified, ObjectType: objectType,
+				ObjectName: newEntry.name, ObjectHref: newEntry.href, Fields: fieldChanges})
+		}
+	}
+	for key, newEntry := range newByHref {
+		if _, ok := oldByHref[key]; !ok {
+			changes = append(changes, &InventoryChange{Kind: InventoryChangeCreated, ObjectType: objectType,
+				ObjectName: newEntry.name, ObjectHref: newEntry.href})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].ObjectName != changes[j].ObjectName {
+			return changes[i].ObjectName < changes[j].ObjectName
+		}
+		return changes[i].ObjectHref < changes[j].ObjectHref
+	})
+	return changes
+}
+
+// diffInventoryFields returns the changes of the key attributes of an object, including its name
+func diffInventoryFields(oldEntry, newEntry inventoryDiffEntry) []*InventoryFieldChange {
+	var changes []*InventoryFieldChange
+	if oldEntry.name != newEntry.name {
+		changes = append(changes, &InventoryFieldChange{Field: "name", OldValue: oldEntry.name, NewValue: newEntry.name})
+	}
+	for index, field := range newEntry.fields {
+		oldValue := oldEntry.fields[index].value
+		if oldValue != field.value {
+			changes = append(changes, &InventoryFieldChange{Field: field.name, OldValue: oldValue, NewValue: field.value})
+		}
+	}
+	return changes
+}
+
+// inventoryDiffInt formats an optional integer attribute
+func inventoryDiffInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+)
+
+// Rules checked by InventorySnapshot.Lint
+const (
+	LintRuleVmOnDisabledStorageProfile = "vm-on-disabled-storage-profile"
+	LintRuleVAppLeaseExpired           = "vapp-lease-expired"
+	LintRuleUnattachedDisk             = "unattached-disk"
+	LintRuleNetworkIpPoolExhausted     = "network-ip-pool-exhausted"
+)
+
+// Severity of a LintFinding
+const (
+	LintSeverityWarning = "WARNING"
+	LintSeverityError   = "ERROR"
+)
+
+// LintFinding describes a problem found in an InventorySnapshot
+type LintFinding struct {
+	Rule       string `json:"rule"`     // One of the LintRule* values
+	Severity   string `json:"severity"` // One of the LintSeverity* values
+	ObjectType string `json:"objectType"`
+	ObjectName string `json:"objectName"`
+	ObjectHref string `json:"objectHref,omitempty"` // HREF or ID of the object
+	VdcName    string `json:"vdcName,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Lint collects an inventory with CollectInventory and returns the problems found in it by InventorySnapshot.Lint
+func (vcdClient *VCDClient) Lint(ctx context.Context, scope InventoryScope) ([]*LintFinding, error) {
+	snapshot, err := vcdClient.CollectInventory(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Lint(), nil
+}
+
+// Lint checks the snapshot for common placement and housekeeping problems:
+// * VMs on disabled storage profiles
+// * vApps with expired leases
+// * independent disks not attached to any VM
+// * Org VDC networks without free IP addresses
+//
+// A check is skipped when the snapshot does not contain the data it needs (see InventoryScope)
+func (snapshot *InventorySnapshot) Lint() []*LintFinding {
+	var findings []*LintFinding
+
+	disabledProfiles := make(map[string]bool)
+	for _, profile := range snapshot.StorageProfiles {
+		if !profile.IsEnabled {
+			disabledProfiles[inventoryUuid(profile.Vdc)+"/"+profile.Name] = true
+		}
+	}
+	for _, vm := range snapshot.Vms {
+		if disabledProfiles[inventoryUuid(vm.VdcHREF)+"/"+vm.StorageProfileName] {
+			findings = append(findings, &LintFinding{
+				Rule:       LintRuleVmOnDisabledStorageProfile,
+				Severity:   LintSeverityWarning,
+				ObjectType: "VM",
+				ObjectName: vm.Name,
+				ObjectHref: vm.HREF,
+				Message:    fmt.Sprintf("VM '%s' (vApp '%s') is on disabled storage profile '%s'", vm.Name, vm.ContainerName, vm.StorageProfileName),
+			})
+		}
+	}
+
+	for _, vApp := range snapshot.VApps {
+		if vApp.Expired {
+			findings = append(findings, &LintFinding{
+				Rule:       LintRuleVAppLeaseExpired,
+				Severity:   LintSeverityWarning,
+				ObjectType: "vApp",
+				ObjectName: vApp.Name,
+				ObjectHref: vApp.HREF,
+				VdcName:    vApp.VdcName,
+				Message:    fmt.Sprintf("the lease of vApp '%s' is expired", vApp.Name),
+			})
+		}
+	}
+
+	for _, disk := range snapshot.Disks {
+		if !disk.IsAttached && disk.AttachedVmCount == 0 {
+			findings = append(findings, &LintFinding{
+				Rule:       LintRuleUnattachedDisk,
+				Severity:   LintSeverityWarning,
+				ObjectType: "disk",
+				ObjectName: disk.Name,
+				ObjectHref: disk.HREF,
+				VdcName:    disk.VdcName,
+				Message:    fmt.Sprintf("independent disk '%s' (%d MB) is not attached to any VM", disk.Name, disk.SizeMb),
+			})
+		}
+	}
+
+	for _, network := range snapshot.NetworkDetails {
+		if network.TotalIpCount == nil || network.UsedIpCount == nil || *network.TotalIpCount == 0 {
+			continue
+		}
+		if *network.UsedIpCount >= *network.TotalIpCount {
+			finding := &LintFinding{
+				Rule:       LintRuleNetworkIpPoolExhausted,
+				Severity:   LintSeverityError,
+				ObjectType: "network",
+				ObjectName: network.Name,
+				ObjectHref: network.ID,
+				Message: fmt.Sprintf("all %d IP addresses of network '%s' are in use",
+					*network.TotalIpCount, network.Name),
+			}
+			if network.OwnerRef != nil {
+				finding.VdcName = network.OwnerRef.Name
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,55 @@
+//go:build functional || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_CollectInventory(check *C) {
+	snapshot, err := vcd.client.CollectInventory(ctx, InventoryScope{OrgName: vcd.config.VCD.Org, MaxConcurrency: 2})
+	check.Assert(err, IsNil)
+	check.Assert(snapshot.Scope, Equals, vcd.config.VCD.Org)
+	check.Assert(len(snapshot.Orgs), Equals, 1)
+
+	foundVdc := false
+	for _, vdc := range snapshot.Vdcs {
+		if vdc.Name == vcd.config.VCD.Vdc {
+			foundVdc = true
+		}
+	}
+	check.Assert(foundVdc, Equals, true)
+	for _, vm := range snapshot.Vms {
+		check.Assert(vm.VAppTemplate, Equals, false)
+	}
+
+	_, err = json.Marshal(snapshot)
+	check.Assert(err, IsNil)
+
+	snapshot, err = vcd.client.CollectInventory(ctx, InventoryScope{OrgName: vcd.config.VCD.Org, SkipVms: true, SkipVApps: true})
+	check.Assert(err, IsNil)
+	check.Assert(snapshot.Vms, IsNil)
+	check.Assert(snapshot.VApps, IsNil)
+
+	_, err = vcd.client.CollectInventory(ctx, InventoryScope{OrgName: "non-existing-org"})
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
+
+func (vcd *TestVCD) Test_LintInventory(check *C) {
+	findings, err := vcd.client.Lint(ctx, InventoryScope{OrgName: vcd.config.VCD.Org})
+	check.Assert(err, IsNil)
+	for _, finding := range findings {
+		check.Assert(finding.Rule, Not(Equals), "")
+		check.Assert(finding.Message, Not(Equals), "")
+		if testVerbose {
+			fmt.Printf("%s %s: %s\n", finding.Severity, finding.Rule, finding.Message)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_runInventoryCollectors(t *testing.T) {
+	var running, maxRunning, completed int32
+	newCollector := func(name string, fail bool) inventoryCollector {
+		return inventoryCollector{name: name, collect: func(ctx context.Context) error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				previous := atomic.LoadInt32(&maxRunning)
+				if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&completed, 1)
+			if fail {
+				return fmt.Errorf("failure")
+			}
+			return nil
+		}}
+	}
+
+	var collectors []inventoryCollector
+	for i := 0; i < 8; i++ {
+		collectors = append(collectors, newCollector(fmt.Sprintf("collector%d", i), false))
+	}
+	err := runInventoryCollectors(context.Background(), collectors, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 concurrent collectors, got %d", maxRunning)
+	}
+	if completed != 8 {
+		t.Errorf("expected 8 completed collectors, got %d", completed)
+	}
+
+	completed = 0
+	err = runInventoryCollectors(context.Background(), []inventoryCollector{
+		newCollector("VMs", true),
+		newCollector("vApps", false),
+		newCollector("catalogs", false),
+	}, 1)
+	if err == nil || !strings.Contains(err.Error(), "error collecting VMs: failure") {
+		t.Errorf("expected error for VMs collector, got %v", err)
+	}
+	if completed != 1 {
+		t.Errorf("expected collectors to be skipped after the first error, %d completed", completed)
+	}
+}
+
+func Test_InventorySnapshotJson(t *testing.T) {
+	snapshot := &InventorySnapshot{
+		Scope: "org1",
+		Orgs:  []*types.Org{{Name: "org1", HREF: "https://vcd/api/org/11111111-2222-3333-4444-555555555555"}},
+		Vms:   []*types.QueryResultVMRecordType{{Name: "vm1", Status: "POWERED_ON"}},
+	}
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("error serializing snapshot: %s", err)
+	}
+	restored := &InventorySnapshot{}
+	err = json.Unmarshal(payload, restored)
+	if err != nil {
+		t.Fatalf("error deserializing snapshot: %s", err)
+	}
+	if len(restored.Orgs) != 1 || restored.Orgs[0].Name != "org1" || len(restored.Vms) != 1 || restored.Vms[0].Name != "vm1" {
+		t.Errorf("snapshot not restored correctly: %s", payload)
+	}
+}
+
+func Test_inventoryUuid(t *testing.T) {
+	uuid := "11111111-2222-3333-4444-555555555555"
+	for _, reference := range []string{"https://vcd/api/vdc/" + uuid, "urn:vcloud:vdc:" + uuid, uuid} {
+		if got := inventoryUuid(reference); got != uuid {
+			t.Errorf("inventoryUuid(%s): expected %s, got %s", reference, uuid, got)
+		}
+	}
+	if got := inventoryUuid("vdc1"); got != "vdc1" {
+		t.Errorf("expected reference without UUID to be returned as is, got %s", got)
+	}
+}
+
+func Test_InventorySnapshotLint(t *testing.T) {
+	vdcUuid := "11111111-2222-3333-4444-555555555555"
+	total, used, free := 10, 10, 5
+	snapshot := &InventorySnapshot{
+		StorageProfiles: []*types.QueryResultOrgVdcStorageProfileRecordType{
+			{Name: "gold", Vdc: "urn:vcloud:vdc:" + vdcUuid, IsEnabled: false},
+			{Name: "silver", Vdc: "urn:vcloud:vdc:" + vdcUuid, IsEnabled: true},
+		},
+		Vms: []*types.QueryResultVMRecordType{
+			{Name: "vm-gold", VdcHREF: "https://vcd/api/vdc/" + vdcUuid, StorageProfileName: "gold"},
+			{Name: "vm-silver", VdcHREF: "https://vcd/api/vdc/" + vdcUuid, StorageProfileName: "silver"},
+		},
+		VApps: []*types.QueryResultVAppRecordType{
+			{Name: "expired", Expired: true},
+			{Name: "active"},
+		},
+		Disks: []*types.DiskRecordType{
+			{Name: "orphan", SizeMb: 1024},
+			{Name: "attached", IsAttached: true, AttachedVmCount: 1},
+		},
+		NetworkDetails: []*types.OpenApiOrgVdcNetwork{
+			{Name: "full", TotalIpCount: &total, UsedIpCount: &used, OwnerRef: &types.OpenApiReference{Name: "vdc1"}},
+			{Name: "available", TotalIpCount: &total, UsedIpCount: &free},
+			{Name: "unknown"},
+		},
+	}
+
+	findings := snapshot.Lint()
+	expected := map[string]string{
+		LintRuleVmOnDisabledStorageProfile: "vm-gold",
+		LintRuleVAppLeaseExpired:           "expired",
+		LintRuleUnattachedDisk:             "orphan",
+		LintRuleNetworkIpPoolExhausted:     "full",
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("expected %d findings, got %d: %v", len(expected), len(findings), findings)
+	}
+	for _, finding := range findings {
+		if expected[finding.Rule] != finding.ObjectName {
+			t.Errorf("unexpected finding for rule %s: %s", finding.Rule, finding.ObjectName)
+		}
+	}
+
+	if findings := (&InventorySnapshot{}).Lint(); len(findings) != 0 {
+		t.Errorf("expected no findings for empty snapshot, got %v", findings)
+	}
+}
+
+func TestInventorySnapshot_Diff(t *testing.T) {
+	vmHref := func(uuid string) string { return "https://vcd/api/vApp/vm-" + uuid }
+	oldSnapshot := &InventorySnapshot{
+		CollectedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Orgs:        []*types.Org{{Name: "org1", HREF: "https://vcd/api/org/11111111-1111-1111-1111-111111111111", IsEnabled: true}},
+		Vms: []*types.QueryResultVMRecordType{
+			{Name: "unchanged", HREF: vmHref("22222222-2222-2222-2222-222222222222"), MemoryMB: 1024},
+			{Name: "resized", HREF: vmHref("33333333-3333-3333-3333-333333333333"), MemoryMB: 1024, Cpus: 1, StorageProfileName: "gold"},
+			{Name: "removed", HREF: vmHref("44444444-4444-4444-4444-444444444444")},
+		},
+	}
+	newSnapshot := &InventorySnapshot{
+		CollectedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		// The same Org seen through its admin HREF
+		Orgs: []*types.Org{{Name: "org1", HREF: "https://vcd/api/admin/org/11111111-1111-1111-1111-111111111111", IsEnabled: true}},
+		Vms: []*types.QueryResultVMRecordType{
+			{Name: "unchanged", HREF: vmHref("22222222-2222-2222-2222-222222222222"), MemoryMB: 1024},
+			{Name: "resized-renamed", HREF: vmHref("33333333-3333-3333-3333-333333333333"), MemoryMB: 2048, Cpus: 1, StorageProfileName: "silver"},
+			{Name: "added", HREF: vmHref("55555555-5555-5555-5555-555555555555")},
+		},
+		Disks: []*types.DiskRecordType{{Name: "disk1", HREF: "https://vcd/api/disk/66666666-6666-6666-6666-666666666666"}},
+	}
+
+	changeSet := oldSnapshot.Diff(newSnapshot)
+	if !changeSet.OldCollectedAt.Equal(oldSnapshot.CollectedAt) || !changeSet.NewCollectedAt.Equal(newSnapshot.CollectedAt) {
+		t.Errorf("unexpected collection times in change set: %s, %s", changeSet.OldCollectedAt, changeSet.NewCollectedAt)
+	}
+	expected := []string{
+		"created VM added",
+		"deleted VM removed",
+		"modified VM resized-renamed",
+		"created disk disk1",
+	}
+	if len(changeSet.Changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %v", len(expected), len(changeSet.Changes), changeSet.Changes)
+	}
+	for index, change := range changeSet.Changes {
+		got := fmt.Sprintf("%s %s %s", change.Kind, change.ObjectType, change.ObjectName)
+		if got != expected[index] {
+			t.Errorf("change %d: expected %q, got %q", index, expected[index], got)
+		}
+	}
+
+	fields := changeSet.Changes[2].Fields
+	expectedFields := []InventoryFieldChange{
+		{Field: "name", OldValue: "resized", NewValue: "resized-renamed"},
+		{Field: "memoryMB", OldValue: "1024", NewValue: "2048"},
+		{Field: "storageProfileName", OldValue: "gold", NewValue: "silver"},
+	}
+	if len(fields) != len(expectedFields) {
+		t.Fatalf("expected %d field changes, got %d", len(expectedFields), len(fields))
+	}
+	for index, field := range fields {
+		if *field != expectedFields[index] {
+			t.Errorf("field change %d: expected %v, got %v", index, expectedFields[index], *field)
+		}
+	}
+
+	if changes := newSnapshot.Diff(newSnapshot).Changes; len(changes) != 0 {
+		t.Errorf("expected no changes between identical snapshots, got %v", changes)
+	}
+}
@@ -21,7 +21,7 @@ func (egw *EdgeGateway) CreateLbAppProfile(ctx context.Context, lbAppProfileConf
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppProfilePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPost, types.AnyXMLMime,
@@ -49,7 +49,7 @@ func (egw *EdgeGateway) CreateLbAppProfile(ctx context.Context, lbAppProfileConf
 func (egw *EdgeGateway) GetLbAppProfiles(ctx context.Context) ([]*types.LbAppProfile, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppProfilePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap response
@@ -122,12 +122,12 @@ func (egw *EdgeGateway) UpdateLbAppProfile(ctx context.Context, lbAppProfileConf
 
 	lbAppProfileConfig.ID, err = egw.getLbAppProfileIdByNameId(ctx, lbAppProfileConfig.Name, lbAppProfileConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer application profile: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer application profile: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppProfilePath + lbAppProfileConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -156,12 +156,12 @@ func (egw *EdgeGateway) DeleteLbAppProfile(ctx context.Context, lbAppProfileConf
 
 	lbAppProfileConfig.ID, err = egw.getLbAppProfileIdByNameId(ctx, lbAppProfileConfig.Name, lbAppProfileConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot delete load balancer application profile: %s", err)
+		return fmt.Errorf("cannot delete load balancer application profile: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppProfilePath + lbAppProfileConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -233,7 +233,7 @@ func (egw *EdgeGateway) getLbAppProfileIdByNameId(ctx context.Context, name, id
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readlbAppProfile, err := egw.GetLbAppProfileByName(ctx, name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer application profile by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer application profile by name: %w", err)
 	}
 	return readlbAppProfile.ID, nil
 }
@@ -21,7 +21,7 @@ func (egw *EdgeGateway) CreateLbAppRule(ctx context.Context, lbAppRuleConfig *ty
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppRulePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPost, types.AnyXMLMime,
@@ -50,7 +50,7 @@ func (egw *EdgeGateway) GetLbAppRules(ctx context.Context) ([]*types.LbAppRule,
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppRulePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap response
@@ -123,12 +123,12 @@ func (egw *EdgeGateway) UpdateLbAppRule(ctx context.Context, lbAppRuleConfig *ty
 
 	lbAppRuleConfig.ID, err = egw.getLbAppRuleIdByNameId(ctx, lbAppRuleConfig.Name, lbAppRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer application rule: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer application rule: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppRulePath + lbAppRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -157,12 +157,12 @@ func (egw *EdgeGateway) DeleteLbAppRule(ctx context.Context, lbAppRuleConfig *ty
 
 	lbAppRuleConfig.ID, err = egw.getLbAppRuleIdByNameId(ctx, lbAppRuleConfig.Name, lbAppRuleConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot update load balancer application rule: %s", err)
+		return fmt.Errorf("cannot update load balancer application rule: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbAppRulePath + lbAppRuleConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -234,7 +234,7 @@ func (egw *EdgeGateway) getLbAppRuleIdByNameId(ctx context.Context, name, id str
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readlbAppRule, err := egw.GetLbAppRuleByName(ctx, name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer application rule by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer application rule by name: %w", err)
 	}
 	return readlbAppRule.ID, nil
 }
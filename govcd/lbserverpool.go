@@ -22,7 +22,7 @@ func (egw *EdgeGateway) CreateLbServerPool(ctx context.Context, lbPoolConfig *ty
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbServerPoolPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPost, types.AnyXMLMime,
@@ -40,7 +40,7 @@ func (egw *EdgeGateway) CreateLbServerPool(ctx context.Context, lbPoolConfig *ty
 
 	readPool, err := egw.GetLbServerPoolById(ctx, lbPoolID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve lb server pool with ID (%s) after creation: %s", lbPoolID, err)
+		return nil, fmt.Errorf("unable to retrieve lb server pool with ID (%s) after creation: %w", lbPoolID, err)
 	}
 	return readPool, nil
 }
@@ -83,7 +83,7 @@ func (egw *EdgeGateway) getLbServerPool(ctx context.Context, lbPoolConfig *types
 func (egw *EdgeGateway) GetLbServerPools(ctx context.Context) ([]*types.LbPool, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbServerPoolPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap "server pool response"
@@ -123,12 +123,12 @@ func (egw *EdgeGateway) UpdateLbServerPool(ctx context.Context, lbPoolConfig *ty
 
 	lbPoolConfig.ID, err = egw.getLbServerPoolIdByNameId(ctx, lbPoolConfig.Name, lbPoolConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer server pool: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer server pool: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbServerPoolPath + lbPoolConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -140,7 +140,7 @@ func (egw *EdgeGateway) UpdateLbServerPool(ctx context.Context, lbPoolConfig *ty
 
 	readPool, err := egw.GetLbServerPoolById(ctx, lbPoolConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve server pool with ID (%s) after update: %s", lbPoolConfig.ID, err)
+		return nil, fmt.Errorf("unable to retrieve server pool with ID (%s) after update: %w", lbPoolConfig.ID, err)
 	}
 	return readPool, nil
 }
@@ -156,12 +156,12 @@ func (egw *EdgeGateway) DeleteLbServerPool(ctx context.Context, lbPoolConfig *ty
 
 	lbPoolConfig.ID, err = egw.getLbServerPoolIdByNameId(ctx, lbPoolConfig.Name, lbPoolConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot delete load balancer server pool: %s", err)
+		return fmt.Errorf("cannot delete load balancer server pool: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbServerPoolPath + lbPoolConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -242,7 +242,7 @@ func (egw *EdgeGateway) getLbServerPoolIdByNameId(ctx context.Context, name, id
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readlbServerPool, err := egw.GetLbServerPoolByName(ctx, name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer server pool by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer server pool by name: %w", err)
 	}
 	return readlbServerPool.ID, nil
 }
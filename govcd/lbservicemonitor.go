@@ -25,7 +25,7 @@ func (egw *EdgeGateway) CreateLbServiceMonitor(ctx context.Context, lbMonitorCon
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbMonitorPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPost, types.AnyXMLMime,
@@ -43,7 +43,7 @@ func (egw *EdgeGateway) CreateLbServiceMonitor(ctx context.Context, lbMonitorCon
 
 	readMonitor, err := egw.GetLbServiceMonitorById(ctx, lbMonitorID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve monitor with ID (%s) after creation: %s", lbMonitorID, err)
+		return nil, fmt.Errorf("unable to retrieve monitor with ID (%s) after creation: %w", lbMonitorID, err)
 	}
 	return readMonitor, nil
 }
@@ -86,7 +86,7 @@ func (egw *EdgeGateway) getLbServiceMonitor(ctx context.Context, lbMonitorConfig
 func (egw *EdgeGateway) GetLbServiceMonitors(ctx context.Context) ([]*types.LbMonitor, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbMonitorPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap "monitor response"
@@ -124,12 +124,12 @@ func (egw *EdgeGateway) UpdateLbServiceMonitor(ctx context.Context, lbMonitorCon
 
 	lbMonitorConfig.ID, err = egw.getLbServiceMonitorIdByNameId(ctx, lbMonitorConfig.Name, lbMonitorConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer service monitor: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer service monitor: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbMonitorPath + lbMonitorConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -141,7 +141,7 @@ func (egw *EdgeGateway) UpdateLbServiceMonitor(ctx context.Context, lbMonitorCon
 
 	readMonitor, err := egw.GetLbServiceMonitorById(ctx, lbMonitorConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve monitor with ID (%s) after update: %s", lbMonitorConfig.ID, err)
+		return nil, fmt.Errorf("unable to retrieve monitor with ID (%s) after update: %w", lbMonitorConfig.ID, err)
 	}
 	return readMonitor, nil
 }
@@ -157,12 +157,12 @@ func (egw *EdgeGateway) DeleteLbServiceMonitor(ctx context.Context, lbMonitorCon
 
 	lbMonitorConfig.ID, err = egw.getLbServiceMonitorIdByNameId(ctx, lbMonitorConfig.Name, lbMonitorConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot delete load balancer service monitor: %s", err)
+		return fmt.Errorf("cannot delete load balancer service monitor: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbMonitorPath + lbMonitorConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -249,7 +249,7 @@ func (egw *EdgeGateway) getLbServiceMonitorIdByNameId(ctx context.Context, name,
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readlbServiceMonitor, err := egw.GetLbServiceMonitorByName(ctx, name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer service monitor by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer service monitor by name: %w", err)
 	}
 	return readlbServiceMonitor.ID, nil
 }
@@ -23,7 +23,7 @@ func (egw *EdgeGateway) CreateLbVirtualServer(ctx context.Context, lbVirtualServ
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbVirtualServerPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPost, types.AnyXMLMime,
@@ -86,7 +86,7 @@ func (egw *EdgeGateway) getLbVirtualServer(ctx context.Context, lbVirtualServerC
 func (egw *EdgeGateway) GetLbVirtualServers(ctx context.Context) ([]*types.LbVirtualServer, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbVirtualServerPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Anonymous struct to unwrap "virtual server response"
@@ -126,12 +126,12 @@ func (egw *EdgeGateway) UpdateLbVirtualServer(ctx context.Context, lbVirtualServ
 
 	lbVirtualServerConfig.ID, err = egw.getLbVirtualServerIdByNameId(ctx, lbVirtualServerConfig.Name, lbVirtualServerConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update load balancer virtual server: %s", err)
+		return nil, fmt.Errorf("cannot update load balancer virtual server: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbVirtualServerPath + lbVirtualServerConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -160,12 +160,12 @@ func (egw *EdgeGateway) DeleteLbVirtualServer(ctx context.Context, lbVirtualServ
 
 	lbVirtualServerConfig.ID, err = egw.getLbVirtualServerIdByNameId(ctx, lbVirtualServerConfig.Name, lbVirtualServerConfig.ID)
 	if err != nil {
-		return fmt.Errorf("cannot delete load balancer virtual server: %s", err)
+		return fmt.Errorf("cannot delete load balancer virtual server: %w", err)
 	}
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.LbVirtualServerPath + lbVirtualServerConfig.ID)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodDelete, types.AnyXMLMime,
@@ -249,7 +249,7 @@ func (egw *EdgeGateway) getLbVirtualServerIdByNameId(ctx context.Context, name,
 	// if only name was specified, ID must be found, because only ID can be used in request path
 	readLbVirtualServer, err := egw.GetLbVirtualServerByName(ctx, name)
 	if err != nil {
-		return "", fmt.Errorf("unable to find load balancer virtual server by name: %s", err)
+		return "", fmt.Errorf("unable to find load balancer virtual server by name: %w", err)
 	}
 	return readLbVirtualServer.ID, nil
 }
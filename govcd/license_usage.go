@@ -0,0 +1,105 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// LicenseUsageMetrics are the compute figures that service provider licensing programs, such as VCPP, are based on
+type LicenseUsageMetrics struct {
+	VmCount          int   // VMs in vApps, excluding the ones in vApp templates
+	PoweredOnVmCount int   // VMs that are powered on
+	VCpuCount        int   // Virtual CPUs of the powered on VMs
+	VRamMb           int64 // Memory of the powered on VMs, which is the base of vRAM licensing
+	AllocatedVRamMb  int64 // Memory of all the VMs, regardless of their power state
+}
+
+// OrgLicenseUsage is the usage of an Org
+type OrgLicenseUsage struct {
+	OrgName string
+	LicenseUsageMetrics
+}
+
+// LicenseUsage is a point-in-time report of the compute usage of the whole VCD
+type LicenseUsage struct {
+	CollectedAt time.Time
+	Site        string // VCD URL
+	Total       LicenseUsageMetrics
+	Orgs        []*OrgLicenseUsage // Sorted by Org name
+}
+
+// GetLicenseUsage computes the VM count and vRAM figures of all the Orgs, as needed for service provider usage
+// reporting. It is built on the query service: VMs are counted from the current inventory, and the values are not
+// averaged over time as a metering tool would.
+//
+// Note. Requires System Administrator privileges
+func (vcdClient *VCDClient) GetLicenseUsage(ctx context.Context) (*LicenseUsage, error) {
+	client := &vcdClient.Client
+	if !client.IsSysAdmin {
+		return nil, fmt.Errorf("functionality requires System Administrator privileges")
+	}
+	collectedAt := time.Now()
+
+	vdcs, err := client.QueryAllVdcs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VDCs: %w", err)
+	}
+	vms, err := client.QueryVmList(ctx, types.VmQueryFilterOnlyDeployed)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VMs: %w", err)
+	}
+
+	usage := computeLicenseUsage(vms, vdcs)
+	usage.CollectedAt = collectedAt
+	usage.Site = client.VCDHREF.String()
+	return usage, nil
+}
+
+// computeLicenseUsage aggregates the VM records by Org, using the VDCs to find the Org of each VM
+func computeLicenseUsage(vms []*types.QueryResultVMRecordType, vdcs []*types.QueryResultOrgVdcRecordType) *LicenseUsage {
+	vdcOrgs := make(map[string]string, len(vdcs))
+	for _, vdc := range vdcs {
+		vdcOrgs[extractUuid(vdc.HREF)] = vdc.OrgName
+	}
+
+	usage := &LicenseUsage{}
+	orgs := make(map[string]*OrgLicenseUsage)
+	for _, vm := range vms {
+		if vm.VAppTemplate || vm.Deleted {
+			continue
+		}
+		orgName := vdcOrgs[extractUuid(vm.VdcHREF)]
+		orgUsage, found := orgs[orgName]
+		if !found {
+			orgUsage = &OrgLicenseUsage{OrgName: orgName}
+			orgs[orgName] = orgUsage
+			usage.Orgs = append(usage.Orgs, orgUsage)
+		}
+		for _, metrics := range []*LicenseUsageMetrics{&usage.Total, &orgUsage.LicenseUsageMetrics} {
+			metrics.add(vm)
+		}
+	}
+	sort.Slice(usage.Orgs, func(i, j int) bool {
+		return usage.Orgs[i].OrgName < usage.Orgs[j].OrgName
+	})
+	return usage
+}
+
+// add counts a VM in the metrics
+func (metrics *LicenseUsageMetrics) add(vm *types.QueryResultVMRecordType) {
+	metrics.VmCount++
+	metrics.AllocatedVRamMb += int64(vm.MemoryMB)
+	if vm.Status == "POWERED_ON" {
+		metrics.PoweredOnVmCount++
+		metrics.VCpuCount += vm.Cpus
+		metrics.VRamMb += int64(vm.MemoryMB)
+	}
+}
@@ -0,0 +1,40 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_computeLicenseUsage(t *testing.T) {
+	vdcs := []*types.QueryResultOrgVdcRecordType{
+		{HREF: "https://vcd/api/vdc/11111111-1111-1111-1111-111111111111", OrgName: "org1"},
+		{HREF: "https://vcd/api/vdc/22222222-2222-2222-2222-222222222222", OrgName: "org2"},
+		{HREF: "https://vcd/api/vdc/33333333-3333-3333-3333-333333333333", OrgName: "org1"},
+	}
+	vms := []*types.QueryResultVMRecordType{
+		{VdcHREF: vdcs[0].HREF, Status: "POWERED_ON", Cpus: 2, MemoryMB: 2048},
+		{VdcHREF: vdcs[2].HREF, Status: "POWERED_OFF", Cpus: 4, MemoryMB: 4096},
+		{VdcHREF: vdcs[1].HREF, Status: "POWERED_ON", Cpus: 1, MemoryMB: 1024},
+		{VdcHREF: vdcs[1].HREF, Status: "POWERED_ON", Cpus: 8, MemoryMB: 8192, VAppTemplate: true},
+	}
+	usage := computeLicenseUsage(vms, vdcs)
+
+	wantTotal := LicenseUsageMetrics{VmCount: 3, PoweredOnVmCount: 2, VCpuCount: 3, VRamMb: 3072, AllocatedVRamMb: 7168}
+	if usage.Total != wantTotal {
+		t.Errorf("got total %+v, want %+v", usage.Total, wantTotal)
+	}
+	if len(usage.Orgs) != 2 || usage.Orgs[0].OrgName != "org1" || usage.Orgs[1].OrgName != "org2" {
+		t.Fatalf("unexpected Orgs %+v", usage.Orgs)
+	}
+	wantOrg1 := LicenseUsageMetrics{VmCount: 2, PoweredOnVmCount: 1, VCpuCount: 2, VRamMb: 2048, AllocatedVRamMb: 6144}
+	if usage.Orgs[0].LicenseUsageMetrics != wantOrg1 {
+		t.Errorf("got org1 usage %+v, want %+v", usage.Orgs[0].LicenseUsageMetrics, wantOrg1)
+	}
+}
@@ -36,6 +36,8 @@ func NewMediaItem(vdc *Vdc) *MediaItem {
 	}
 }
 
+// Media is a media item (e.g. an ISO image) as returned by the entity API. It contains the full definition of the
+// media, including its links and tasks. Use Media.GetRecord to get the query view of the same media
 type Media struct {
 	Media  *types.Media
 	client *Client
@@ -48,6 +50,8 @@ func NewMedia(cli *Client) *Media {
 	}
 }
 
+// MediaRecord is a media item as returned by a query. It contains the names and references of the catalog, VDC
+// and catalog item of the media, which Media does not include. Use MediaRecord.ToMedia to get the full media
 type MediaRecord struct {
 	MediaRecord *types.MediaRecordType
 	client      *Client
@@ -85,12 +89,12 @@ func (vdc *Vdc) UploadMediaImage(ctx context.Context, mediaName, mediaDescriptio
 
 	isISOGood, err := verifyIso(mediaFilePath)
 	if err != nil || !isISOGood {
-		return UploadTask{}, fmt.Errorf("[ERROR] File %s isn't correct iso file: %s", mediaFilePath, err)
+		return UploadTask{}, fmt.Errorf("[ERROR] File %s isn't correct iso file: %w", mediaFilePath, err)
 	}
 
 	mediaList, err := getExistingMedia(ctx, vdc)
 	if err != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Checking existing media files failed: %s", err)
+		return UploadTask{}, fmt.Errorf("[ERROR] Checking existing media files failed: %w", err)
 	}
 
 	for _, media := range mediaList {
@@ -101,25 +105,25 @@ func (vdc *Vdc) UploadMediaImage(ctx context.Context, mediaName, mediaDescriptio
 
 	file, e := os.Stat(mediaFilePath)
 	if e != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Issue finding file: %#v", e)
+		return UploadTask{}, fmt.Errorf("[ERROR] Issue finding file: %w", e)
 	}
 	fileSize := file.Size()
 
 	media, err := createMedia(ctx, vdc.client, vdc.Vdc.HREF+"/media", mediaName, mediaDescription, fileSize)
 	if err != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Issue creating media: %s", err)
+		return UploadTask{}, fmt.Errorf("[ERROR] Issue creating media: %w", err)
 	}
 
-	return executeUpload(ctx, vdc.client, media, mediaFilePath, mediaName, fileSize, uploadPieceSize)
+	return executeUpload(ctx, vdc.client, media, mediaFilePath, mediaName, fileSize, UploadOptions{PieceSize: uploadPieceSize})
 }
 
-func executeUpload(ctx context.Context, client *Client, media *types.Media, mediaFilePath, mediaName string, fileSize, uploadPieceSize int64) (UploadTask, error) {
+func executeUpload(ctx context.Context, client *Client, media *types.Media, mediaFilePath, mediaName string, fileSize int64, options UploadOptions) (UploadTask, error) {
 	uploadLink, err := getUploadLink(media.Files)
 	if err != nil {
-		return UploadTask{}, fmt.Errorf("[ERROR] Issue getting upload link: %s", err)
+		return UploadTask{}, fmt.Errorf("[ERROR] Issue getting upload link: %w", err)
 	}
 
-	callBack, uploadProgress := getProgressCallBackFunction()
+	callBack, uploadProgress := getProgressCallBackFunction(options.ProgressFunc)
 
 	uploadError := *new(error)
 
@@ -127,18 +131,22 @@ func executeUpload(ctx context.Context, client *Client, media *types.Media, medi
 		uploadLink:               uploadLink.String(), // just take string
 		uploadedBytes:            0,
 		fileSizeToUpload:         fileSize,
-		uploadPieceSize:          uploadPieceSize,
+		uploadPieceSize:          options.PieceSize,
 		uploadedBytesForCallback: 0,
 		allFilesSize:             fileSize,
 		callBack:                 callBack,
 		uploadError:              &uploadError,
+		maxRetries:               options.MaxRetries,
+		retryDelay:               options.RetryDelay,
+		concurrency:              options.Concurrency,
 	}
 
 	// sending upload process to background, this allows not to lock and return task to client
 	// The error should be captured in details.uploadError, but just in case, we add a logging for the
 	// main error
 	go func() {
-		_, err = uploadFile(ctx, client, mediaFilePath, details)
+		_, err := uploadFile(ctx, client, mediaFilePath, details)
+		cancelImportTasksOnCancellation(ctx, client, media.Tasks, err)
 		if err != nil {
 			util.Logger.Println(strings.Repeat("*", 80))
 			util.Logger.Printf("*** [DEBUG - executeUpload] error calling uploadFile: %s\n", err)
@@ -170,7 +178,7 @@ func executeUpload(ctx context.Context, client *Client, media *types.Media, medi
 func createMedia(ctx context.Context, client *Client, link, mediaName, mediaDescription string, fileSize int64) (*types.Media, error) {
 	uploadUrl, err := url.ParseRequestURI(link)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vdc href: %s", err)
+		return nil, fmt.Errorf("error getting vdc href: %w", err)
 	}
 
 	reqBody := bytes.NewBufferString(
@@ -337,7 +345,7 @@ func queryMediaWithFilter(ctx context.Context, vdc *Vdc, filter string) ([]*type
 
 	results, err := vdc.QueryWithNotEncodedParams(ctx, nil, map[string]string{"type": typeMedia, "filter": filter, "filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -347,6 +355,26 @@ func queryMediaWithFilter(ctx context.Context, vdc *Vdc, filter string) ([]*type
 	return mediaResults, nil
 }
 
+// queryMediaListWithMetadata retrieves all the media items matching filter, an encoded query filter, and the
+// metadata filters of options, with the requested metadata fields populated in each record
+func queryMediaListWithMetadata(ctx context.Context, client *Client, filter string, options QueryMetadataOptions) ([]*types.MediaRecordType, error) {
+	typeMedia := types.QtMedia
+	if client.IsSysAdmin {
+		typeMedia = types.QtAdminMedia
+	}
+
+	results, err := client.queryWithMetadataOptions(ctx, typeMedia, map[string]string{"filter": filter, "filterEncoded": "true"}, options)
+	if err != nil {
+		return nil, fmt.Errorf("error querying medias: %w", err)
+	}
+
+	mediaResults := results.Results.MediaRecord
+	if client.IsSysAdmin {
+		mediaResults = results.Results.AdminMediaRecord
+	}
+	return mediaResults, nil
+}
+
 // Looks for media and, if found, will delete it.
 // Deprecated: Use catalog.RemoveMediaIfExist
 func RemoveMediaImageIfExists(ctx context.Context, vdc Vdc, mediaName string) error {
@@ -417,12 +445,12 @@ func FindMediaAsCatalogItem(ctx context.Context, org *Org, catalogName, mediaNam
 
 	catalog, err := org.FindCatalog(ctx, catalogName)
 	if err != nil || catalog == (Catalog{}) {
-		return CatalogItem{}, fmt.Errorf("catalog not found or error %s", err)
+		return CatalogItem{}, fmt.Errorf("catalog not found or error %w", err)
 	}
 
 	media, err := catalog.FindCatalogItem(ctx, mediaName)
 	if err != nil || media == (CatalogItem{}) {
-		return CatalogItem{}, fmt.Errorf("media not found or error %s", err)
+		return CatalogItem{}, fmt.Errorf("media not found or error %w", err)
 	}
 	return media, nil
 }
@@ -472,8 +500,8 @@ func (cat *Catalog) GetMediaByHref(ctx context.Context, mediaHref string) (*Medi
 	media := NewMedia(cat.client)
 
 	_, err := cat.client.ExecuteRequest(ctx, mediaHref, http.MethodGet,
-		"", "error retrieving media: %#v", nil, media.Media)
-	if err != nil && strings.Contains(err.Error(), "MajorErrorCode:403") {
+		"", "error retrieving media: %s", nil, media.Media)
+	if errors.Is(mapServerError(err, serverErrorScopeMedia), ErrorEntityNotFound) {
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
@@ -519,7 +547,7 @@ func (catalog *Catalog) GetMediaById(ctx context.Context, mediaId string) (*Medi
 		"filter":        fmt.Sprintf("catalogName==%s", url.QueryEscape(catalog.Catalog.Name)),
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -609,7 +637,7 @@ func (catalog *Catalog) QueryMedia(ctx context.Context, mediaName string) (*Medi
 			url.QueryEscape(catalog.Catalog.Name)),
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 	newMediaRecord := NewMediaRecord(catalog.client)
 
@@ -644,25 +672,31 @@ func (adminCatalog *AdminCatalog) QueryMedia(ctx context.Context, mediaName stri
 // QueryMediaById returns a MediaRecord associated to the given media item URN.
 // Returns ErrorEntityNotFound if it is not found, or an error if there's more than one result.
 func (vcdClient *VCDClient) QueryMediaById(ctx context.Context, mediaId string) (*MediaRecord, error) {
+	return queryMediaRecordById(ctx, &vcdClient.Client, mediaId)
+}
+
+// queryMediaRecordById returns a MediaRecord associated to the given media item URN.
+// Returns ErrorEntityNotFound if it is not found, or an error if there's more than one result.
+func queryMediaRecordById(ctx context.Context, client *Client, mediaId string) (*MediaRecord, error) {
 	if mediaId == "" {
 		return nil, fmt.Errorf("media ID is empty")
 	}
 
 	filterType := types.QtMedia
-	if vcdClient.Client.IsSysAdmin {
+	if client.IsSysAdmin {
 		filterType = types.QtAdminMedia
 	}
-	results, err := vcdClient.Client.QueryWithNotEncodedParams(ctx, nil, map[string]string{
+	results, err := client.QueryWithNotEncodedParams(ctx, nil, map[string]string{
 		"type":          filterType,
 		"filter":        fmt.Sprintf("id==%s", url.QueryEscape(mediaId)),
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
-	newMediaRecord := NewMediaRecord(&vcdClient.Client)
+	newMediaRecord := NewMediaRecord(client)
 
 	mediaResults := results.Results.MediaRecord
-	if vcdClient.Client.IsSysAdmin {
+	if client.IsSysAdmin {
 		mediaResults = results.Results.AdminMediaRecord
 	}
 
@@ -726,7 +760,7 @@ func (vdc *Vdc) QueryAllMedia(ctx context.Context, mediaName string) ([]*MediaRe
 	results, err := vdc.client.QueryWithNotEncodedParams(ctx, nil, map[string]string{"type": typeMedia,
 		"filter": fmt.Sprintf("name==%s", url.QueryEscape(mediaName))})
 	if err != nil {
-		return nil, fmt.Errorf("error querying medias %s", err)
+		return nil, fmt.Errorf("error querying medias %w", err)
 	}
 
 	mediaResults := results.Results.MediaRecord
@@ -748,3 +782,77 @@ func (vdc *Vdc) QueryAllMedia(ctx context.Context, mediaName string) ([]*MediaRe
 	util.Logger.Printf("[TRACE] Found media records by name: %#v \n", mediaResults)
 	return newMediaRecords, nil
 }
+
+// ToMedia retrieves the full definition of the media item of this record
+func (mediaRecord *MediaRecord) ToMedia(ctx context.Context) (*Media, error) {
+	if mediaRecord.MediaRecord == nil || mediaRecord.MediaRecord.HREF == "" {
+		return nil, fmt.Errorf("media record is empty")
+	}
+	media := NewMedia(mediaRecord.client)
+
+	_, err := mediaRecord.client.ExecuteRequest(ctx, mediaRecord.MediaRecord.HREF, http.MethodGet,
+		"", "error retrieving media: %s", nil, media.Media)
+	if errors.Is(mapServerError(err, serverErrorScopeMedia), ErrorEntityNotFound) {
+		return nil, ErrorEntityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// GetRecord retrieves the query record of this media item, which includes its catalog, VDC and catalog item
+func (media *Media) GetRecord(ctx context.Context) (*MediaRecord, error) {
+	if media.Media == nil || media.Media.ID == "" {
+		return nil, fmt.Errorf("media is empty")
+	}
+	return queryMediaRecordById(ctx, media.client, media.Media.ID)
+}
+
+// LaunchSync starts synchronisation of the media item, when it belongs to a subscribed catalog.
+// The synchronisation is performed on the catalog item that contains the media
+func (mediaRecord *MediaRecord) LaunchSync(ctx context.Context) (*Task, error) {
+	if mediaRecord.MediaRecord == nil || mediaRecord.MediaRecord.CatalogItem == "" {
+		return nil, fmt.Errorf("media record has no catalog item")
+	}
+	catalogItem := NewCatalogItem(mediaRecord.client)
+	catalogItem.CatalogItem.HREF = mediaRecord.MediaRecord.CatalogItem
+	err := catalogItem.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving catalog item of media '%s': %w", mediaRecord.MediaRecord.Name, err)
+	}
+	return catalogItem.LaunchSync(ctx)
+}
+
+// Sync synchronises the media item, when it belongs to a subscribed catalog, and waits for the task to complete
+func (mediaRecord *MediaRecord) Sync(ctx context.Context) error {
+	return waitForMediaSync(ctx, mediaRecord.LaunchSync)
+}
+
+// LaunchSync starts synchronisation of the media item, when it belongs to a subscribed catalog.
+// The synchronisation is performed on the catalog item that contains the media
+func (media *Media) LaunchSync(ctx context.Context) (*Task, error) {
+	mediaRecord, err := media.GetRecord(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mediaRecord.LaunchSync(ctx)
+}
+
+// Sync synchronises the media item, when it belongs to a subscribed catalog, and waits for the task to complete
+func (media *Media) Sync(ctx context.Context) error {
+	return waitForMediaSync(ctx, media.LaunchSync)
+}
+
+// waitForMediaSync starts a media synchronisation with launchSync and waits for its task, if any
+func waitForMediaSync(ctx context.Context, launchSync func(ctx context.Context) (*Task, error)) error {
+	task, err := launchSync(ctx)
+	if err != nil {
+		return err
+	}
+	// A nil task means that a synchronisation was already in progress
+	if task == nil {
+		return nil
+	}
+	return task.WaitTaskCompletion(ctx)
+}
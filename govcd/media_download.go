@@ -0,0 +1,87 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// MediaDownloadOptions are the optional settings of Media.Download. They are the same as the ones used to
+// download a vApp template
+type MediaDownloadOptions = VAppTemplateDownloadOptions
+
+// EnableDownload makes the media available for download and waits for VCD to prepare its file
+func (media *Media) EnableDownload(ctx context.Context) error {
+	task, err := media.client.ExecuteTaskRequest(ctx, media.Media.HREF+"/action/enableDownload",
+		http.MethodPost, "", "error enabling download of media: %s", nil)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return fmt.Errorf("error enabling download of media %s: %w", media.Media.Name, err)
+	}
+	return media.Refresh(ctx)
+}
+
+// DisableDownload makes the media no longer available for download
+func (media *Media) DisableDownload(ctx context.Context) error {
+	return media.client.ExecuteRequestWithoutResponse(ctx, media.Media.HREF+"/action/disableDownload",
+		http.MethodPost, "", "error disabling download of media: %s", nil)
+}
+
+// Download exports the image file of the media (ISO or floppy) into writer
+func (media *Media) Download(ctx context.Context, writer io.Writer, options MediaDownloadOptions) error {
+	if media.Media == nil || media.Media.HREF == "" {
+		return fmt.Errorf("media is empty")
+	}
+	err := media.EnableDownload(ctx)
+	if err != nil {
+		return err
+	}
+	if !options.KeepDownloadEnabled {
+		defer func() {
+			err := media.DisableDownload(ctx)
+			if err != nil {
+				util.Logger.Printf("[ERROR] error disabling download of media %s: %s", media.Media.Name, err)
+			}
+		}()
+	}
+
+	var downloadLink *types.Link
+	var fileSize int64
+	if media.Media.Files != nil {
+		for _, file := range media.Media.Files.File {
+			if file == nil {
+				continue
+			}
+			downloadLink = file.Link.Find(func(link *types.Link) bool {
+				return link != nil && link.Rel == types.RelDownloadDefault
+			})
+			if downloadLink != nil {
+				fileSize = file.Size
+				break
+			}
+		}
+	}
+	if downloadLink == nil {
+		return fmt.Errorf("download link not found for media %s", media.Media.Name)
+	}
+
+	_, err = downloadFile(ctx, media.client, writer, downloadDetails{
+		downloadLink:      downloadLink.HREF,
+		fileSize:          fileSize,
+		downloadPieceSize: options.DownloadPieceSize,
+		allFilesSize:      fileSize,
+		callBack:          options.ProgressCallback,
+	})
+	return err
+}
@@ -227,6 +227,45 @@ func (vcd *TestVCD) Test_RefreshMediaRecord(check *C) {
 	check.Assert(oldMediaRecord.MediaRecord.HREF, Equals, mediaRecord.MediaRecord.HREF)
 }
 
+// Tests the conversion between MediaRecord and Media
+func (vcd *TestVCD) Test_MediaRecordToMedia(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	skipWhenMediaPathMissing(vcd, check)
+	if vcd.config.VCD.Catalog.Name == "" {
+		check.Skip("Test_MediaRecordToMedia: Catalog name not given")
+		return
+	}
+	if vcd.config.Media.Media == "" {
+		check.Skip("Test_MediaRecordToMedia: Media name not given")
+		return
+	}
+
+	catalog, err := vcd.org.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+	check.Assert(catalog, NotNil)
+
+	mediaRecord, err := catalog.QueryMedia(ctx, vcd.config.Media.Media)
+	check.Assert(err, IsNil)
+	check.Assert(mediaRecord, NotNil)
+
+	media, err := mediaRecord.ToMedia(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(media, NotNil)
+	check.Assert(media.Media.Name, Equals, mediaRecord.MediaRecord.Name)
+	check.Assert(media.Media.HREF, Equals, mediaRecord.MediaRecord.HREF)
+
+	record, err := media.GetRecord(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(record, NotNil)
+	check.Assert(record.MediaRecord.HREF, Equals, media.Media.HREF)
+	check.Assert(record.MediaRecord.CatalogName, Equals, catalog.Catalog.Name)
+
+	// Synchronisation is only possible for media items of subscribed catalogs
+	_, err = media.LaunchSync(ctx)
+	check.Assert(err, NotNil)
+}
+
 func (vcd *TestVCD) Test_QueryAllMedia(check *C) {
 	fmt.Printf("Running: %s\n", check.TestName())
 
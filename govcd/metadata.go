@@ -789,7 +789,7 @@ func (adminOrg *AdminOrg) DeleteMetadataEntry(ctx context.Context, key string) e
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for organization task: %s", err)
+		return fmt.Errorf("error completing delete metadata for organization task: %w", err)
 	}
 
 	return nil
@@ -845,7 +845,7 @@ func (disk *Disk) DeleteMetadataEntry(ctx context.Context, key string) error {
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for independent disk task: %s", err)
+		return fmt.Errorf("error completing delete metadata for independent disk task: %w", err)
 	}
 
 	return nil
@@ -1148,12 +1148,12 @@ func (vAppTemplate *VAppTemplate) AddMetadata(ctx context.Context, key string, v
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error completing add metadata for vApp template task: %s", err)
+		return nil, fmt.Errorf("error completing add metadata for vApp template task: %w", err)
 	}
 
 	err = vAppTemplate.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing vApp template: %s", err)
+		return nil, fmt.Errorf("error refreshing vApp template: %w", err)
 	}
 
 	return vAppTemplate, nil
@@ -1172,7 +1172,7 @@ func (vAppTemplate *VAppTemplate) DeleteMetadata(ctx context.Context, key string
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for vApp template task: %s", err)
+		return fmt.Errorf("error completing delete metadata for vApp template task: %w", err)
 	}
 
 	return nil
@@ -1191,12 +1191,12 @@ func (media *Media) AddMetadata(ctx context.Context, key string, value string) (
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error completing add metadata for media item task: %s", err)
+		return nil, fmt.Errorf("error completing add metadata for media item task: %w", err)
 	}
 
 	err = media.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing media item: %s", err)
+		return nil, fmt.Errorf("error refreshing media item: %w", err)
 	}
 
 	return media, nil
@@ -1215,7 +1215,7 @@ func (media *Media) DeleteMetadata(ctx context.Context, key string) error {
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for media item task: %s", err)
+		return fmt.Errorf("error completing delete metadata for media item task: %w", err)
 	}
 
 	return nil
@@ -1241,12 +1241,12 @@ func (mediaItem *MediaItem) AddMetadata(ctx context.Context, key string, value s
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error completing add metadata for media item task: %s", err)
+		return nil, fmt.Errorf("error completing add metadata for media item task: %w", err)
 	}
 
 	err = mediaItem.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing media item: %s", err)
+		return nil, fmt.Errorf("error refreshing media item: %w", err)
 	}
 
 	return mediaItem, nil
@@ -1266,7 +1266,7 @@ func (mediaItem *MediaItem) DeleteMetadata(ctx context.Context, key string) erro
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for media item task: %s", err)
+		return fmt.Errorf("error completing delete metadata for media item task: %w", err)
 	}
 
 	return nil
@@ -1286,12 +1286,12 @@ func (mediaRecord *MediaRecord) AddMetadata(ctx context.Context, key string, val
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error completing add metadata for media item task: %s", err)
+		return nil, fmt.Errorf("error completing add metadata for media item task: %w", err)
 	}
 
 	err = mediaRecord.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing media item: %s", err)
+		return nil, fmt.Errorf("error refreshing media item: %w", err)
 	}
 
 	return mediaRecord, nil
@@ -1310,7 +1310,7 @@ func (mediaRecord *MediaRecord) DeleteMetadata(ctx context.Context, key string)
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error completing delete metadata for media item task: %s", err)
+		return fmt.Errorf("error completing delete metadata for media item task: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// defaultMetadataBatchConcurrency is the number of entities updated at the same time by BatchApplyMetadata when
+// maxConcurrency is not set
+const defaultMetadataBatchConcurrency = 8
+
+// MetadataBatchResult is the outcome of merging metadata into a single entity with BatchApplyMetadata
+type MetadataBatchResult struct {
+	Href  string // HREF of the entity, as given to BatchApplyMetadata
+	Error error  // nil when the metadata was merged successfully
+}
+
+// BatchApplyMetadata merges metadata into each of the entities referenced by hrefs, in the same way as
+// MergeMetadataWithMetadataValues, running up to maxConcurrency merges at the same time (8 when maxConcurrency is 0 or less).
+// A failure on one entity does not stop the others: the returned slice has one result per HREF, in the same order as
+// hrefs, and the error of each entity is reported in its result. When ctx is done, the entities not processed yet
+// report the context error.
+// Note: entities that require the admin HREF to manage metadata, such as Org VDC networks, must be given with it.
+func (vcdClient *VCDClient) BatchApplyMetadata(ctx context.Context, hrefs []string, metadata map[string]types.MetadataValue, maxConcurrency int) []MetadataBatchResult {
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMetadataBatchConcurrency
+	}
+
+	results := make([]MetadataBatchResult, len(hrefs))
+	semaphore := make(chan struct{}, maxConcurrency)
+	var waitGroup sync.WaitGroup
+
+	for index, href := range hrefs {
+		results[index].Href = href
+		if href == "" {
+			results[index].Error = fmt.Errorf("empty HREF")
+			continue
+		}
+		waitGroup.Add(1)
+		go func(result *MetadataBatchResult) {
+			defer waitGroup.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				result.Error = ctx.Err()
+				return
+			}
+			defer func() { <-semaphore }()
+			if ctx.Err() != nil {
+				result.Error = ctx.Err()
+				return
+			}
+
+			result.Error = mergeMetadataAndWait(ctx, &vcdClient.Client, result.Href, metadata)
+			if result.Error != nil {
+				util.Logger.Printf("[TRACE] BatchApplyMetadata: error merging metadata into %s: %s", result.Href, result.Error)
+			}
+		}(&results[index])
+	}
+	waitGroup.Wait()
+
+	return results
+}
@@ -0,0 +1,89 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_BatchApplyMetadata(t *testing.T) {
+	// Metadata POST requests return a task, which is always successful, except for entities named "fail*"
+	var running, maxRunning int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				previous := atomic.LoadInt32(&maxRunning)
+				if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if strings.Contains(r.URL.Path, "/fail") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `<Error xmlns="http://www.vmware.com/vcloud/v1.5" majorErrorCode="400" message="invalid metadata"/>`)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}
+		w.Header().Set("Content-Type", types.MimeTask)
+		_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="success" href="https://%s/api/task/1"></Task>`, r.Host)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+
+	var hrefs []string
+	for i := 0; i < 6; i++ {
+		hrefs = append(hrefs, fmt.Sprintf("%s/api/vApp/vm-%d", server.URL, i))
+	}
+	hrefs = append(hrefs, server.URL+"/api/vApp/fail-1", "")
+	metadata := map[string]types.MetadataValue{
+		"key": {TypedValue: &types.MetadataTypedValue{Value: "value", XsiType: types.MetadataStringValue}},
+	}
+
+	results := vcdClient.BatchApplyMetadata(context.Background(), hrefs, metadata, 2)
+	if len(results) != len(hrefs) {
+		t.Fatalf("expected %d results, got %d", len(hrefs), len(results))
+	}
+	for index, result := range results {
+		if result.Href != hrefs[index] {
+			t.Errorf("result %d: expected HREF %s, got %s", index, hrefs[index], result.Href)
+		}
+		expectError := index >= 6
+		if (result.Error != nil) != expectError {
+			t.Errorf("result %d (%s): expected error: %t, got %v", index, result.Href, expectError, result.Error)
+		}
+	}
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxRunning)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = vcdClient.BatchApplyMetadata(ctx, hrefs[:2], metadata, 0)
+	for _, result := range results {
+		if result.Error == nil {
+			t.Errorf("expected error for %s with a canceled context", result.Href)
+		}
+	}
+}
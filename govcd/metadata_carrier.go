@@ -0,0 +1,356 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// MetadataCarrier is implemented by the entities that support XML API metadata, so that metadata can be managed
+// without knowing the type of the entity:
+//
+//	func tagAll(ctx context.Context, carriers []MetadataCarrier) error {
+//		for _, carrier := range carriers {
+//			err := SetMetadataEntry(ctx, carrier, "owner", "team-a", types.MetadataStringValue, types.MetadataReadWriteVisibility, false)
+//			...
+//
+// Entities of tenant types such as Org, Vdc and Catalog can only be used to read metadata, the same as their methods.
+type MetadataCarrier interface {
+	MetadataEntity
+	// MetadataHref returns the HREF used to manage the metadata of the entity, which is the admin HREF for
+	// the entities that can only be modified through it
+	MetadataHref() string
+}
+
+// GetMetadata returns the metadata of the given entity
+func GetMetadata(ctx context.Context, carrier MetadataCarrier) (*types.Metadata, error) {
+	metadata, err := getMetadata(ctx, carrier.MetadataClient(), carrier.MetadataHref())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving metadata of '%s': %w", carrier.MetadataEntityName(), err)
+	}
+	return metadata, nil
+}
+
+// GetMetadataByKey returns the metadata entry of the given entity corresponding to the given key and domain
+func GetMetadataByKey(ctx context.Context, carrier MetadataCarrier, key string, isSystem bool) (*types.MetadataValue, error) {
+	metadata, err := getMetadataByKey(ctx, carrier.MetadataClient(), carrier.MetadataHref(), key, isSystem)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving metadata entry '%s' of '%s': %w", key, carrier.MetadataEntityName(), err)
+	}
+	return metadata, nil
+}
+
+// SetMetadataEntry adds or replaces the metadata entry of the given entity with the given key, value, type and
+// visibility, and waits for the task to complete
+func SetMetadataEntry(ctx context.Context, carrier MetadataCarrier, key, value, typedValue, visibility string, isSystem bool) error {
+	err := addMetadataAndWait(ctx, carrier.MetadataClient(), carrier.MetadataHref(), key, value, typedValue, visibility, isSystem)
+	if err != nil {
+		return fmt.Errorf("error setting metadata entry '%s' of '%s': %w", key, carrier.MetadataEntityName(), err)
+	}
+	return nil
+}
+
+// MergeMetadata updates the metadata entries of the given entity that are present in metadata and creates the ones
+// that are not present yet, then waits for the task to complete
+func MergeMetadata(ctx context.Context, carrier MetadataCarrier, metadata map[string]types.MetadataValue) error {
+	err := mergeMetadataAndWait(ctx, carrier.MetadataClient(), carrier.MetadataHref(), metadata)
+	if err != nil {
+		return fmt.Errorf("error merging metadata of '%s': %w", carrier.MetadataEntityName(), err)
+	}
+	return nil
+}
+
+// DeleteMetadataEntry deletes the metadata entry of the given entity corresponding to the given key and domain, and
+// waits for the task to complete
+func DeleteMetadataEntry(ctx context.Context, carrier MetadataCarrier, key string, isSystem bool) error {
+	err := deleteMetadataAndWait(ctx, carrier.MetadataClient(), carrier.MetadataHref(), key, isSystem)
+	if err != nil {
+		return fmt.Errorf("error deleting metadata entry '%s' of '%s': %w", key, carrier.MetadataEntityName(), err)
+	}
+	return nil
+}
+
+// UpdateMetadataEntryVisibility changes the visibility of the metadata entry of the given entity corresponding to
+// the given key, keeping its value. isSystem indicates the domain that the entry must have after the change
+func UpdateMetadataEntryVisibility(ctx context.Context, carrier MetadataCarrier, key, newVisibility string, isSystem bool) error {
+	err := updateMetadataEntryVisibility(ctx, carrier.MetadataClient(), carrier.MetadataHref(), key, newVisibility, isSystem)
+	if err != nil {
+		return fmt.Errorf("error updating visibility of metadata entry '%s' of '%s': %w", key, carrier.MetadataEntityName(), err)
+	}
+	return nil
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the VM
+func (vm *VM) MetadataHref() string {
+	return vm.VM.HREF
+}
+
+// MetadataClient returns the client of the VM
+func (vm *VM) MetadataClient() *Client {
+	return vm.client
+}
+
+// MetadataEntityName returns the name of the VM
+func (vm *VM) MetadataEntityName() string {
+	return vm.VM.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the vApp
+func (vapp *VApp) MetadataHref() string {
+	return vapp.VApp.HREF
+}
+
+// MetadataClient returns the client of the vApp
+func (vapp *VApp) MetadataClient() *Client {
+	return vapp.client
+}
+
+// MetadataEntityName returns the name of the vApp
+func (vapp *VApp) MetadataEntityName() string {
+	return vapp.VApp.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the vApp Template
+func (vAppTemplate *VAppTemplate) MetadataHref() string {
+	return vAppTemplate.VAppTemplate.HREF
+}
+
+// MetadataClient returns the client of the vApp Template
+func (vAppTemplate *VAppTemplate) MetadataClient() *Client {
+	return vAppTemplate.client
+}
+
+// MetadataEntityName returns the name of the vApp Template
+func (vAppTemplate *VAppTemplate) MetadataEntityName() string {
+	return vAppTemplate.VAppTemplate.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the VDC
+func (vdc *Vdc) MetadataHref() string {
+	return vdc.Vdc.HREF
+}
+
+// MetadataClient returns the client of the VDC
+func (vdc *Vdc) MetadataClient() *Client {
+	return vdc.client
+}
+
+// MetadataEntityName returns the name of the VDC
+func (vdc *Vdc) MetadataEntityName() string {
+	return vdc.Vdc.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Admin VDC
+func (adminVdc *AdminVdc) MetadataHref() string {
+	return adminVdc.AdminVdc.HREF
+}
+
+// MetadataClient returns the client of the Admin VDC
+func (adminVdc *AdminVdc) MetadataClient() *Client {
+	return adminVdc.client
+}
+
+// MetadataEntityName returns the name of the Admin VDC
+func (adminVdc *AdminVdc) MetadataEntityName() string {
+	return adminVdc.AdminVdc.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Provider VDC
+func (providerVdc *ProviderVdc) MetadataHref() string {
+	return providerVdc.ProviderVdc.HREF
+}
+
+// MetadataClient returns the client of the Provider VDC
+func (providerVdc *ProviderVdc) MetadataClient() *Client {
+	return providerVdc.client
+}
+
+// MetadataEntityName returns the name of the Provider VDC
+func (providerVdc *ProviderVdc) MetadataEntityName() string {
+	return providerVdc.ProviderVdc.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Catalog
+func (catalog *Catalog) MetadataHref() string {
+	return catalog.Catalog.HREF
+}
+
+// MetadataClient returns the client of the Catalog
+func (catalog *Catalog) MetadataClient() *Client {
+	return catalog.client
+}
+
+// MetadataEntityName returns the name of the Catalog
+func (catalog *Catalog) MetadataEntityName() string {
+	return catalog.Catalog.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Admin Catalog
+func (adminCatalog *AdminCatalog) MetadataHref() string {
+	return adminCatalog.AdminCatalog.HREF
+}
+
+// MetadataClient returns the client of the Admin Catalog
+func (adminCatalog *AdminCatalog) MetadataClient() *Client {
+	return adminCatalog.client
+}
+
+// MetadataEntityName returns the name of the Admin Catalog
+func (adminCatalog *AdminCatalog) MetadataEntityName() string {
+	return adminCatalog.AdminCatalog.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Catalog Item
+func (catalogItem *CatalogItem) MetadataHref() string {
+	return catalogItem.CatalogItem.HREF
+}
+
+// MetadataClient returns the client of the Catalog Item
+func (catalogItem *CatalogItem) MetadataClient() *Client {
+	return catalogItem.client
+}
+
+// MetadataEntityName returns the name of the Catalog Item
+func (catalogItem *CatalogItem) MetadataEntityName() string {
+	return catalogItem.CatalogItem.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Org
+func (org *Org) MetadataHref() string {
+	return org.Org.HREF
+}
+
+// MetadataClient returns the client of the Org
+func (org *Org) MetadataClient() *Client {
+	return org.client
+}
+
+// MetadataEntityName returns the name of the Org
+func (org *Org) MetadataEntityName() string {
+	return org.Org.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Admin Org
+func (adminOrg *AdminOrg) MetadataHref() string {
+	return adminOrg.AdminOrg.HREF
+}
+
+// MetadataClient returns the client of the Admin Org
+func (adminOrg *AdminOrg) MetadataClient() *Client {
+	return adminOrg.client
+}
+
+// MetadataEntityName returns the name of the Admin Org
+func (adminOrg *AdminOrg) MetadataEntityName() string {
+	return adminOrg.AdminOrg.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Media
+func (media *Media) MetadataHref() string {
+	return media.Media.HREF
+}
+
+// MetadataClient returns the client of the Media
+func (media *Media) MetadataClient() *Client {
+	return media.client
+}
+
+// MetadataEntityName returns the name of the Media
+func (media *Media) MetadataEntityName() string {
+	return media.Media.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Media Record
+func (mediaRecord *MediaRecord) MetadataHref() string {
+	return mediaRecord.MediaRecord.HREF
+}
+
+// MetadataClient returns the client of the Media Record
+func (mediaRecord *MediaRecord) MetadataClient() *Client {
+	return mediaRecord.client
+}
+
+// MetadataEntityName returns the name of the Media Record
+func (mediaRecord *MediaRecord) MetadataEntityName() string {
+	return mediaRecord.MediaRecord.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the independent Disk
+func (disk *Disk) MetadataHref() string {
+	return disk.Disk.HREF
+}
+
+// MetadataClient returns the client of the independent Disk
+func (disk *Disk) MetadataClient() *Client {
+	return disk.client
+}
+
+// MetadataEntityName returns the name of the independent Disk
+func (disk *Disk) MetadataEntityName() string {
+	return disk.Disk.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Org VDC Network
+func (orgVdcNetwork *OrgVDCNetwork) MetadataHref() string {
+	return getAdminURL(orgVdcNetwork.OrgVDCNetwork.HREF)
+}
+
+// MetadataClient returns the client of the Org VDC Network
+func (orgVdcNetwork *OrgVDCNetwork) MetadataClient() *Client {
+	return orgVdcNetwork.client
+}
+
+// MetadataEntityName returns the name of the Org VDC Network
+func (orgVdcNetwork *OrgVDCNetwork) MetadataEntityName() string {
+	return orgVdcNetwork.OrgVDCNetwork.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the OpenAPI Org VDC Network
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) MetadataHref() string {
+	return fmt.Sprintf("%s/admin/network/%s", openApiOrgVdcNetwork.client.VCDHREF.String(), extractUuid(openApiOrgVdcNetwork.OpenApiOrgVdcNetwork.ID))
+}
+
+// MetadataClient returns the client of the OpenAPI Org VDC Network
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) MetadataClient() *Client {
+	return openApiOrgVdcNetwork.client
+}
+
+// MetadataEntityName returns the name of the OpenAPI Org VDC Network
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) MetadataEntityName() string {
+	return openApiOrgVdcNetwork.OpenApiOrgVdcNetwork.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the VDC Storage Profile
+func (storageProfile *VdcStorageProfile) MetadataHref() string {
+	return getAdminURL(storageProfile.VdcStorageProfile.HREF)
+}
+
+// MetadataClient returns the client of the VDC Storage Profile
+func (storageProfile *VdcStorageProfile) MetadataClient() *Client {
+	return storageProfile.client
+}
+
+// MetadataEntityName returns the name of the VDC Storage Profile
+func (storageProfile *VdcStorageProfile) MetadataEntityName() string {
+	return storageProfile.VdcStorageProfile.Name
+}
+
+// MetadataHref returns the HREF used to manage the metadata of the Admin VDC Storage Profile
+func (adminStorageProfile *AdminVdcStorageProfile) MetadataHref() string {
+	return getAdminURL(adminStorageProfile.AdminVdcStorageProfile.HREF)
+}
+
+// MetadataClient returns the client of the Admin VDC Storage Profile
+func (adminStorageProfile *AdminVdcStorageProfile) MetadataClient() *Client {
+	return adminStorageProfile.client
+}
+
+// MetadataEntityName returns the name of the Admin VDC Storage Profile
+func (adminStorageProfile *AdminVdcStorageProfile) MetadataEntityName() string {
+	return adminStorageProfile.AdminVdcStorageProfile.Name
+}
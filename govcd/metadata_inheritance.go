@@ -0,0 +1,194 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Levels of the hierarchy used to compute the effective metadata of a VM
+const (
+	MetadataLevelOrg  = "Org"
+	MetadataLevelVdc  = "VDC"
+	MetadataLevelVApp = "vApp"
+	MetadataLevelVm   = "VM"
+)
+
+// DefaultMetadataPrecedence is the precedence used by VM.GetEffectiveMetadata when none is given: from the lowest to
+// the highest priority, so that the metadata of the most specific object wins
+var DefaultMetadataPrecedence = []string{MetadataLevelOrg, MetadataLevelVdc, MetadataLevelVApp, MetadataLevelVm}
+
+// EffectiveMetadataEntry is the value of a metadata key after applying the precedence, with its provenance
+type EffectiveMetadataEntry struct {
+	Key        string
+	Value      string
+	Type       string                   // One of types.MetadataStringValue, types.MetadataNumberValue, ...
+	Domain     *types.MetadataDomainTag // Domain and visibility of the winning entry
+	Level      string                   // Level of the object the value comes from, e.g. MetadataLevelVApp
+	SourceHref string                   // HREF of the object the value comes from
+	// OverriddenLevels are the levels that also define the key, with a lower priority
+	OverriddenLevels []string
+}
+
+// EffectiveMetadata is the metadata of a VM merged with the metadata of the objects containing it
+type EffectiveMetadata struct {
+	// Values is the merged map of metadata keys and values
+	Values map[string]string
+	// Entries has the provenance of each key of Values
+	Entries map[string]*EffectiveMetadataEntry
+}
+
+// metadataLevel is the metadata of one object of the hierarchy
+type metadataLevel struct {
+	level    string
+	href     string
+	metadata *types.Metadata
+}
+
+// GetEffectiveMetadata returns the metadata of the VM overlaid on the metadata of its vApp, VDC and Org.
+// The precedence lists the levels (MetadataLevelOrg, MetadataLevelVdc, MetadataLevelVApp, MetadataLevelVm) from the
+// lowest to the highest priority: a key defined at several levels takes the value of the last one. Levels not in the
+// precedence are ignored. When precedence is empty, DefaultMetadataPrecedence is used.
+//
+// Keys are merged regardless of their domain. When an object has the same key in the GENERAL and SYSTEM domains, the
+// SYSTEM one is used, as it is set by the provider. Entries that are hidden to the caller are not returned by VCD
+// and can't be taken into account.
+func (vm *VM) GetEffectiveMetadata(ctx context.Context, precedence []string) (*EffectiveMetadata, error) {
+	if len(precedence) == 0 {
+		precedence = DefaultMetadataPrecedence
+	}
+	err := validateMetadataPrecedence(precedence)
+	if err != nil {
+		return nil, err
+	}
+
+	hrefs, err := vm.getMetadataHierarchyHrefs(ctx, precedence)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]metadataLevel, 0, len(precedence))
+	for _, level := range precedence {
+		metadata, err := getMetadata(ctx, vm.client, hrefs[level])
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving %s metadata of VM '%s': %w", level, vm.VM.Name, err)
+		}
+		levels = append(levels, metadataLevel{level: level, href: hrefs[level], metadata: metadata})
+	}
+	return mergeEffectiveMetadata(levels), nil
+}
+
+// getMetadataHierarchyHrefs returns the HREFs of the VM and of the objects containing it, by level. Only the parents
+// needed by the precedence are retrieved
+func (vm *VM) getMetadataHierarchyHrefs(ctx context.Context, precedence []string) (map[string]string, error) {
+	hrefs := map[string]string{MetadataLevelVm: vm.VM.HREF}
+
+	needsLevel := func(levels ...string) bool {
+		for _, level := range levels {
+			if contains(level, precedence) {
+				return true
+			}
+		}
+		return false
+	}
+	if !needsLevel(MetadataLevelVApp, MetadataLevelVdc, MetadataLevelOrg) {
+		return hrefs, nil
+	}
+
+	vapp, err := vm.GetParentVApp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hrefs[MetadataLevelVApp] = vapp.VApp.HREF
+	if !needsLevel(MetadataLevelVdc, MetadataLevelOrg) {
+		return hrefs, nil
+	}
+
+	vdc, err := vapp.getParentVDC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hrefs[MetadataLevelVdc] = vdc.Vdc.HREF
+
+	switch org := vdc.parent.(type) {
+	case *Org:
+		hrefs[MetadataLevelOrg] = org.Org.HREF
+	case *AdminOrg:
+		hrefs[MetadataLevelOrg] = org.AdminOrg.HREF
+	default:
+		return nil, fmt.Errorf("could not find the parent Org of VDC '%s'", vdc.Vdc.Name)
+	}
+	return hrefs, nil
+}
+
+// validateMetadataPrecedence checks that the precedence contains only known levels, each at most once
+func validateMetadataPrecedence(precedence []string) error {
+	seen := make(map[string]bool)
+	for _, level := range precedence {
+		if !contains(level, DefaultMetadataPrecedence) {
+			return fmt.Errorf("unknown metadata level '%s': valid levels are %v", level, DefaultMetadataPrecedence)
+		}
+		if seen[level] {
+			return fmt.Errorf("metadata level '%s' is given more than once", level)
+		}
+		seen[level] = true
+	}
+	return nil
+}
+
+// mergeEffectiveMetadata overlays the metadata of the levels, from the lowest to the highest priority
+func mergeEffectiveMetadata(levels []metadataLevel) *EffectiveMetadata {
+	result := &EffectiveMetadata{
+		Values:  make(map[string]string),
+		Entries: make(map[string]*EffectiveMetadataEntry),
+	}
+	for _, level := range levels {
+		if level.metadata == nil {
+			continue
+		}
+		for _, entry := range effectiveMetadataEntries(level.metadata) {
+			effectiveEntry := &EffectiveMetadataEntry{
+				Key:        entry.Key,
+				Domain:     entry.Domain,
+				Level:      level.level,
+				SourceHref: level.href,
+			}
+			if entry.TypedValue != nil {
+				effectiveEntry.Value = entry.TypedValue.Value
+				effectiveEntry.Type = entry.TypedValue.XsiType
+			}
+			if previous, found := result.Entries[entry.Key]; found {
+				effectiveEntry.OverriddenLevels = append(previous.OverriddenLevels, previous.Level)
+			}
+			result.Entries[entry.Key] = effectiveEntry
+			result.Values[entry.Key] = effectiveEntry.Value
+		}
+	}
+	return result
+}
+
+// effectiveMetadataEntries returns the entries of an object with one entry per key, preferring the SYSTEM domain
+func effectiveMetadataEntries(metadata *types.Metadata) []*types.MetadataEntry {
+	var entries []*types.MetadataEntry
+	indexes := make(map[string]int)
+	for _, entry := range metadata.MetadataEntry {
+		if entry == nil {
+			continue
+		}
+		index, found := indexes[entry.Key]
+		if !found {
+			indexes[entry.Key] = len(entries)
+			entries = append(entries, entry)
+			continue
+		}
+		if entry.Domain != nil && entry.Domain.Domain == "SYSTEM" {
+			entries[index] = entry
+		}
+	}
+	return entries
+}
@@ -0,0 +1,84 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func makeTestMetadata(entries ...*types.MetadataEntry) *types.Metadata {
+	return &types.Metadata{MetadataEntry: entries}
+}
+
+func makeTestMetadataEntry(key, value, domain string) *types.MetadataEntry {
+	entry := &types.MetadataEntry{
+		Key:        key,
+		TypedValue: &types.MetadataTypedValue{Value: value, XsiType: types.MetadataStringValue},
+	}
+	if domain != "" {
+		entry.Domain = &types.MetadataDomainTag{Domain: domain, Visibility: types.MetadataReadOnlyVisibility}
+	}
+	return entry
+}
+
+func Test_mergeEffectiveMetadata(t *testing.T) {
+	levels := []metadataLevel{
+		{level: MetadataLevelOrg, href: "org", metadata: makeTestMetadata(
+			makeTestMetadataEntry("costCenter", "org", ""),
+			makeTestMetadataEntry("owner", "org", ""),
+		)},
+		{level: MetadataLevelVdc, href: "vdc", metadata: makeTestMetadata()},
+		{level: MetadataLevelVApp, href: "vapp", metadata: makeTestMetadata(
+			makeTestMetadataEntry("owner", "vapp", ""),
+			makeTestMetadataEntry("tier", "general", "GENERAL"),
+			makeTestMetadataEntry("tier", "system", "SYSTEM"),
+		)},
+		{level: MetadataLevelVm, href: "vm", metadata: makeTestMetadata(
+			makeTestMetadataEntry("owner", "vm", ""),
+		)},
+	}
+	result := mergeEffectiveMetadata(levels)
+
+	wantValues := map[string]string{"costCenter": "org", "owner": "vm", "tier": "system"}
+	if !reflect.DeepEqual(result.Values, wantValues) {
+		t.Errorf("got values %v, want %v", result.Values, wantValues)
+	}
+	owner := result.Entries["owner"]
+	if owner.Level != MetadataLevelVm || owner.SourceHref != "vm" || owner.Type != types.MetadataStringValue {
+		t.Errorf("unexpected provenance for owner: %+v", owner)
+	}
+	if !reflect.DeepEqual(owner.OverriddenLevels, []string{MetadataLevelOrg, MetadataLevelVApp}) {
+		t.Errorf("got overridden levels %v", owner.OverriddenLevels)
+	}
+	if result.Entries["costCenter"].Level != MetadataLevelOrg || len(result.Entries["costCenter"].OverriddenLevels) != 0 {
+		t.Errorf("unexpected provenance for costCenter: %+v", result.Entries["costCenter"])
+	}
+	if result.Entries["tier"].Domain.Domain != "SYSTEM" || len(result.Entries["tier"].OverriddenLevels) != 0 {
+		t.Errorf("unexpected provenance for tier: %+v", result.Entries["tier"])
+	}
+}
+
+func Test_validateMetadataPrecedence(t *testing.T) {
+	tests := []struct {
+		precedence []string
+		wantErr    bool
+	}{
+		{DefaultMetadataPrecedence, false},
+		{[]string{MetadataLevelVm, MetadataLevelOrg}, false},
+		{[]string{MetadataLevelVm, MetadataLevelVm}, true},
+		{[]string{"vm"}, true},
+	}
+	for _, tt := range tests {
+		err := validateMetadataPrecedence(tt.precedence)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateMetadataPrecedence(%v) error = %v, wantErr %t", tt.precedence, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,547 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// OpenApiMetadataEntry is a wrapper object for types.OpenApiMetadataEntry. It is used for entities that support
+// metadata through the OpenAPI endpoint '/cloudapi/1.0.0/entities/{id}/metadata' (VCD 10.4+), such as Runtime Defined
+// Entities, VDC Groups and Org VDC Networks.
+type OpenApiMetadataEntry struct {
+	MetadataEntry  *types.OpenApiMetadataEntry
+	Etag           string // Populated by GetMetadataById and GetMetadataByKey, needed to perform updates
+	client         *Client
+	parentEntityId string // The ID of the entity that owns this metadata entry
+}
+
+// MetadataEntity contains the methods shared by the entities that support XML API metadata (MetadataCarrier) and the
+// ones that support OpenAPI metadata (OpenApiMetadataEntity), so that the metadata of any of them can be read with
+// GetMetadataEntries, whichever API the entity uses.
+type MetadataEntity interface {
+	// MetadataClient returns the client used to manage the metadata of the entity
+	MetadataClient() *Client
+	// MetadataEntityName returns the name of the entity, used in error messages
+	MetadataEntityName() string
+}
+
+// OpenApiMetadataEntity is implemented by the entities that support metadata through the OpenAPI metadata endpoint.
+// It allows handling OpenAPI metadata regardless of the entity type with GetOpenApiMetadata, GetOpenApiMetadataByKey,
+// GetOpenApiMetadataById and AddOpenApiMetadata.
+type OpenApiMetadataEntity interface {
+	MetadataEntity
+	// OpenApiMetadataEntityId returns the URN that identifies the entity in the OpenAPI metadata endpoint
+	OpenApiMetadataEntityId() string
+}
+
+// GetMetadataEntries retrieves all the metadata entries of the given entity as XML metadata entries. Entities that
+// implement MetadataCarrier are read with the XML API, including OpenApiOrgVdcNetwork as its GetMetadata method does.
+// The entries of the other entities are read with the OpenAPI metadata endpoint and converted with
+// OpenApiMetadataEntry.ToMetadataValue, dropping their namespace.
+func GetMetadataEntries(ctx context.Context, entity MetadataEntity) ([]*types.MetadataEntry, error) {
+	switch typedEntity := entity.(type) {
+	case MetadataCarrier:
+		metadata, err := GetMetadata(ctx, typedEntity)
+		if err != nil {
+			return nil, err
+		}
+		return metadata.MetadataEntry, nil
+	case OpenApiMetadataEntity:
+		openApiEntries, err := GetOpenApiMetadata(ctx, typedEntity, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving metadata of '%s': %w", entity.MetadataEntityName(), err)
+		}
+		entries := make([]*types.MetadataEntry, len(openApiEntries))
+		for i, openApiEntry := range openApiEntries {
+			value, err := openApiEntry.ToMetadataValue()
+			if err != nil {
+				return nil, fmt.Errorf("error converting metadata entry '%s' of '%s': %w",
+					openApiEntry.MetadataEntry.KeyValue.Key, entity.MetadataEntityName(), err)
+			}
+			entries[i] = &types.MetadataEntry{
+				Xmlns:      value.Xmlns,
+				Xsi:        value.Xsi,
+				Domain:     value.Domain,
+				Key:        openApiEntry.MetadataEntry.KeyValue.Key,
+				TypedValue: value.TypedValue,
+			}
+		}
+		return entries, nil
+	default:
+		return nil, fmt.Errorf("entity '%s' of type %T supports neither XML nor OpenAPI metadata", entity.MetadataEntityName(), entity)
+	}
+}
+
+// ConvertMetadataEntryToOpenApi converts the given XML metadata entry into the equivalent OpenAPI metadata entry in
+// the given namespace, so that it can be added to an entity with OpenAPI metadata. It is the reverse of
+// OpenApiMetadataEntry.ToMetadataValue:
+//   - SYSTEM domain maps to PROVIDER domain, read-only if the visibility is READONLY.
+//   - GENERAL domain (or no domain) maps to TENANT domain.
+//   - MetadataStringValue, MetadataNumberValue and MetadataBooleanValue map to their OpenAPI counterparts.
+//     MetadataDateTimeValue is not supported by OpenAPI metadata.
+func ConvertMetadataEntryToOpenApi(entry *types.MetadataEntry, namespace string) (*types.OpenApiMetadataEntry, error) {
+	if entry == nil || entry.TypedValue == nil {
+		return nil, fmt.Errorf("the metadata entry has no value")
+	}
+
+	openApiEntry := &types.OpenApiMetadataEntry{
+		KeyValue: types.OpenApiMetadataKeyValue{
+			Domain:    types.OpenApiMetadataTenantDomain,
+			Key:       entry.Key,
+			Namespace: namespace,
+		},
+	}
+	if entry.Domain != nil && entry.Domain.Domain == "SYSTEM" {
+		openApiEntry.KeyValue.Domain = types.OpenApiMetadataProviderDomain
+		openApiEntry.IsReadOnly = entry.Domain.Visibility == types.MetadataReadOnlyVisibility
+	}
+
+	rawValue := entry.TypedValue.Value
+	switch entry.TypedValue.XsiType {
+	case types.MetadataStringValue:
+		openApiEntry.KeyValue.Value = types.OpenApiMetadataTypedValue{Value: rawValue, Type: types.OpenApiMetadataStringEntry}
+	case types.MetadataNumberValue:
+		number, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' in metadata entry '%s': %w", rawValue, entry.Key, err)
+		}
+		openApiEntry.KeyValue.Value = types.OpenApiMetadataTypedValue{Value: number, Type: types.OpenApiMetadataNumberEntry}
+	case types.MetadataBooleanValue:
+		boolean, err := strconv.ParseBool(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean '%s' in metadata entry '%s': %w", rawValue, entry.Key, err)
+		}
+		openApiEntry.KeyValue.Value = types.OpenApiMetadataTypedValue{Value: boolean, Type: types.OpenApiMetadataBoolEntry}
+	default:
+		return nil, fmt.Errorf("metadata type '%s' of entry '%s' is not supported by OpenAPI metadata", entry.TypedValue.XsiType, entry.Key)
+	}
+
+	return openApiEntry, nil
+}
+
+// GetOpenApiMetadata retrieves all the metadata entries of the given entity.
+func GetOpenApiMetadata(ctx context.Context, entity OpenApiMetadataEntity, queryParameters url.Values) ([]*OpenApiMetadataEntry, error) {
+	return getAllOpenApiMetadata(ctx, entity.MetadataClient(), entity.OpenApiMetadataEntityId(), queryParameters)
+}
+
+// GetOpenApiMetadataByKey retrieves the metadata entry of the given entity identified by the given namespace and key.
+func GetOpenApiMetadataByKey(ctx context.Context, entity OpenApiMetadataEntity, namespace, key string) (*OpenApiMetadataEntry, error) {
+	return getOpenApiMetadataByKey(ctx, entity.MetadataClient(), entity.OpenApiMetadataEntityId(), namespace, key)
+}
+
+// GetOpenApiMetadataById retrieves the metadata entry of the given entity identified by the given ID.
+func GetOpenApiMetadataById(ctx context.Context, entity OpenApiMetadataEntity, id string) (*OpenApiMetadataEntry, error) {
+	return getOpenApiMetadataById(ctx, entity.MetadataClient(), entity.OpenApiMetadataEntityId(), id)
+}
+
+// AddOpenApiMetadata adds the given metadata entry to the given entity.
+func AddOpenApiMetadata(ctx context.Context, entity OpenApiMetadataEntity, metadataEntry types.OpenApiMetadataEntry) (*OpenApiMetadataEntry, error) {
+	return addOpenApiMetadata(ctx, entity.MetadataClient(), entity.OpenApiMetadataEntityId(), metadataEntry)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Runtime Defined Entities
+// ------------------------------------------------------------------------------------------------
+
+// OpenApiMetadataEntityId returns the ID of the receiver Runtime Defined Entity. It implements OpenApiMetadataEntity.
+func (rde *DefinedEntity) OpenApiMetadataEntityId() string {
+	return rde.DefinedEntity.ID
+}
+
+// MetadataClient returns the client of the receiver Runtime Defined Entity. It implements OpenApiMetadataEntity.
+func (rde *DefinedEntity) MetadataClient() *Client {
+	return rde.client
+}
+
+// MetadataEntityName returns the name of the receiver Runtime Defined Entity. It implements OpenApiMetadataEntity.
+func (rde *DefinedEntity) MetadataEntityName() string {
+	return rde.DefinedEntity.Name
+}
+
+// GetMetadata retrieves all the metadata entries of the receiver Runtime Defined Entity.
+func (rde *DefinedEntity) GetMetadata(ctx context.Context, queryParameters url.Values) ([]*OpenApiMetadataEntry, error) {
+	return getAllOpenApiMetadata(ctx, rde.client, rde.DefinedEntity.ID, queryParameters)
+}
+
+// GetMetadataByKey retrieves the metadata entry of the receiver Runtime Defined Entity identified by the given
+// namespace and key.
+func (rde *DefinedEntity) GetMetadataByKey(ctx context.Context, namespace, key string) (*OpenApiMetadataEntry, error) {
+	return getOpenApiMetadataByKey(ctx, rde.client, rde.DefinedEntity.ID, namespace, key)
+}
+
+// GetMetadataById retrieves the metadata entry of the receiver Runtime Defined Entity identified by the given ID.
+func (rde *DefinedEntity) GetMetadataById(ctx context.Context, id string) (*OpenApiMetadataEntry, error) {
+	return getOpenApiMetadataById(ctx, rde.client, rde.DefinedEntity.ID, id)
+}
+
+// AddMetadata adds the given metadata entry to the receiver Runtime Defined Entity.
+func (rde *DefinedEntity) AddMetadata(ctx context.Context, metadataEntry types.OpenApiMetadataEntry) (*OpenApiMetadataEntry, error) {
+	return addOpenApiMetadata(ctx, rde.client, rde.DefinedEntity.ID, metadataEntry)
+}
+
+// ------------------------------------------------------------------------------------------------
+// VDC Groups
+// ------------------------------------------------------------------------------------------------
+
+// OpenApiMetadataEntityId returns the ID of the receiver VDC Group. It implements OpenApiMetadataEntity.
+func (vdcGroup *VdcGroup) OpenApiMetadataEntityId() string {
+	return vdcGroup.VdcGroup.Id
+}
+
+// MetadataClient returns the client of the receiver VDC Group. It implements OpenApiMetadataEntity.
+func (vdcGroup *VdcGroup) MetadataClient() *Client {
+	return vdcGroup.client
+}
+
+// MetadataEntityName returns the name of the receiver VDC Group. It implements OpenApiMetadataEntity.
+func (vdcGroup *VdcGroup) MetadataEntityName() string {
+	return vdcGroup.VdcGroup.Name
+}
+
+// GetMetadata retrieves all the metadata entries of the receiver VDC Group.
+func (vdcGroup *VdcGroup) GetMetadata(ctx context.Context, queryParameters url.Values) ([]*OpenApiMetadataEntry, error) {
+	return getAllOpenApiMetadata(ctx, vdcGroup.client, vdcGroup.VdcGroup.Id, queryParameters)
+}
+
+// GetMetadataByKey retrieves the metadata entry of the receiver VDC Group identified by the given namespace and key.
+func (vdcGroup *VdcGroup) GetMetadataByKey(ctx context.Context, namespace, key string) (*OpenApiMetadataEntry, error) {
+	return getOpenApiMetadataByKey(ctx, vdcGroup.client, vdcGroup.VdcGroup.Id, namespace, key)
+}
+
+// GetMetadataById retrieves the metadata entry of the receiver VDC Group identified by the given ID.
+func (vdcGroup *VdcGroup) GetMetadataById(ctx context.Context, id string) (*OpenApiMetadataEntry, error) {
+	return getOpenApiMetadataById(ctx, vdcGroup.client, vdcGroup.VdcGroup.Id, id)
+}
+
+// AddMetadata adds the given metadata entry to the receiver VDC Group.
+func (vdcGroup *VdcGroup) AddMetadata(ctx context.Context, metadataEntry types.OpenApiMetadataEntry) (*OpenApiMetadataEntry, error) {
+	return addOpenApiMetadata(ctx, vdcGroup.client, vdcGroup.VdcGroup.Id, metadataEntry)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Org VDC Networks
+// ------------------------------------------------------------------------------------------------
+
+// OpenApiMetadataEntityId returns the ID of the receiver Org VDC Network. Together with MetadataClient and
+// MetadataEntityName, it implements OpenApiMetadataEntity.
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) OpenApiMetadataEntityId() string {
+	return openApiOrgVdcNetwork.OpenApiOrgVdcNetwork.ID
+}
+
+// GetOpenApiMetadata retrieves all the OpenAPI metadata entries of the receiver Org VDC Network.
+// Unlike GetMetadata, it also works with networks that belong to a VDC Group.
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) GetOpenApiMetadata(ctx context.Context, queryParameters url.Values) ([]*OpenApiMetadataEntry, error) {
+	return GetOpenApiMetadata(ctx, openApiOrgVdcNetwork, queryParameters)
+}
+
+// GetOpenApiMetadataByKey retrieves the OpenAPI metadata entry of the receiver Org VDC Network identified by the given
+// namespace and key. Unlike GetMetadataByKey, it also works with networks that belong to a VDC Group.
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) GetOpenApiMetadataByKey(ctx context.Context, namespace, key string) (*OpenApiMetadataEntry, error) {
+	return GetOpenApiMetadataByKey(ctx, openApiOrgVdcNetwork, namespace, key)
+}
+
+// GetOpenApiMetadataById retrieves the OpenAPI metadata entry of the receiver Org VDC Network identified by the given ID.
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) GetOpenApiMetadataById(ctx context.Context, id string) (*OpenApiMetadataEntry, error) {
+	return GetOpenApiMetadataById(ctx, openApiOrgVdcNetwork, id)
+}
+
+// AddOpenApiMetadata adds the given OpenAPI metadata entry to the receiver Org VDC Network.
+// Unlike AddMetadataEntryWithVisibility, it also works with networks that belong to a VDC Group.
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) AddOpenApiMetadata(ctx context.Context, metadataEntry types.OpenApiMetadataEntry) (*OpenApiMetadataEntry, error) {
+	return AddOpenApiMetadata(ctx, openApiOrgVdcNetwork, metadataEntry)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Metadata entry operations
+// ------------------------------------------------------------------------------------------------
+
+// Update updates the value and persistence of the receiver metadata entry. Key, namespace, type and domain can't be
+// changed, the entry must be deleted and created again for that.
+func (entry *OpenApiMetadataEntry) Update(ctx context.Context, value interface{}, persistent bool) error {
+	if entry.MetadataEntry == nil || entry.MetadataEntry.ID == "" {
+		return fmt.Errorf("ID of the receiver metadata entry is empty")
+	}
+
+	if entry.Etag == "" {
+		// We need to get an Etag to perform the update
+		retrievedEntry, err := getOpenApiMetadataById(ctx, entry.client, entry.parentEntityId, entry.MetadataEntry.ID)
+		if err != nil {
+			return err
+		}
+		if retrievedEntry.Etag == "" {
+			return fmt.Errorf("could not retrieve a valid Etag to perform an update to metadata entry %s", entry.MetadataEntry.ID)
+		}
+		entry.Etag = retrievedEntry.Etag
+	}
+
+	payload := *entry.MetadataEntry
+	payload.KeyValue.Value.Value = value
+	payload.IsPersistent = persistent
+
+	client := entry.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEntityMetadata
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, entry.parentEntityId), entry.MetadataEntry.ID)
+	if err != nil {
+		return err
+	}
+
+	headers, err := client.OpenApiPutItemAndGetHeaders(ctx, apiVersion, urlRef, nil, payload, entry.MetadataEntry, map[string]string{"If-Match": entry.Etag})
+	if err != nil {
+		return fmt.Errorf("error updating metadata entry %s: %w", entry.MetadataEntry.ID, err)
+	}
+	entry.Etag = headers.Get("Etag")
+
+	return nil
+}
+
+// Delete deletes the receiver metadata entry.
+func (entry *OpenApiMetadataEntry) Delete(ctx context.Context) error {
+	if entry.MetadataEntry == nil || entry.MetadataEntry.ID == "" {
+		return fmt.Errorf("ID of the receiver metadata entry is empty")
+	}
+
+	client := entry.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEntityMetadata
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, entry.parentEntityId), entry.MetadataEntry.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting metadata entry %s: %w", entry.MetadataEntry.ID, err)
+	}
+
+	entry.MetadataEntry = &types.OpenApiMetadataEntry{}
+	entry.Etag = ""
+	return nil
+}
+
+// ToMetadataValue converts the receiver OpenAPI metadata entry into the equivalent XML metadata value, so that
+// both kinds of metadata can be processed uniformly:
+//   - PROVIDER domain maps to SYSTEM domain, with READONLY visibility if the entry is read-only, or PRIVATE otherwise.
+//   - TENANT domain maps to GENERAL domain, with READWRITE visibility.
+//   - StringEntry, NumberEntry and BoolEntry map to their XML counterparts. JsonEntry is converted to a string
+//     holding the JSON encoding of the value.
+func (entry *OpenApiMetadataEntry) ToMetadataValue() (*types.MetadataValue, error) {
+	if entry.MetadataEntry == nil {
+		return nil, fmt.Errorf("the receiver metadata entry is empty")
+	}
+	return convertOpenApiMetadataToMetadataValue(*entry.MetadataEntry)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Generic private functions
+// ------------------------------------------------------------------------------------------------
+
+// getAllOpenApiMetadata retrieves all the metadata entries of the entity identified by the given URN
+func getAllOpenApiMetadata(ctx context.Context, client *Client, entityId string, queryParameters url.Values) ([]*OpenApiMetadataEntry, error) {
+	if entityId == "" {
+		return nil, fmt.Errorf("entity ID is required to retrieve metadata")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEntityMetadata
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, entityId))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.OpenApiMetadataEntry{{}}
+	err = client.OpenApiGetAllItems(ctx, apiVersion, urlRef, queryParameters, &typeResponses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*OpenApiMetadataEntry, len(typeResponses))
+	for i, typeResponse := range typeResponses {
+		results[i] = &OpenApiMetadataEntry{
+			MetadataEntry:  typeResponse,
+			client:         client,
+			parentEntityId: entityId,
+		}
+	}
+
+	return results, nil
+}
+
+// getOpenApiMetadataByKey retrieves the metadata entry identified by namespace and key of the entity identified
+// by the given URN. The returned entry contains a valid Etag.
+func getOpenApiMetadataByKey(ctx context.Context, client *Client, entityId, namespace, key string) (*OpenApiMetadataEntry, error) {
+	if key == "" {
+		return nil, fmt.Errorf("metadata key is required")
+	}
+
+	queryParameters := url.Values{}
+	queryParameters = queryParameterFilterAnd(fmt.Sprintf("keyValue.key==%s", key), queryParameters)
+	allEntries, err := getAllOpenApiMetadata(ctx, client, entityId, queryParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	var foundEntries []*OpenApiMetadataEntry
+	for _, entry := range allEntries {
+		if entry.MetadataEntry.KeyValue.Key == key && entry.MetadataEntry.KeyValue.Namespace == namespace {
+			foundEntries = append(foundEntries, entry)
+		}
+	}
+
+	foundEntry, err := oneOrError("namespace/key", namespace+"/"+key, foundEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieving the entry by ID, to get the Etag
+	return getOpenApiMetadataById(ctx, client, entityId, foundEntry.MetadataEntry.ID)
+}
+
+// getOpenApiMetadataById retrieves the metadata entry identified by the given ID of the entity identified
+// by the given URN. The returned entry contains a valid Etag.
+func getOpenApiMetadataById(ctx context.Context, client *Client, entityId, metadataId string) (*OpenApiMetadataEntry, error) {
+	if entityId == "" || metadataId == "" {
+		return nil, fmt.Errorf("both entity ID and metadata ID are required to retrieve a metadata entry")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEntityMetadata
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, entityId), metadataId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OpenApiMetadataEntry{
+		MetadataEntry:  &types.OpenApiMetadataEntry{},
+		client:         client,
+		parentEntityId: entityId,
+	}
+
+	headers, err := client.OpenApiGetItemAndHeaders(ctx, apiVersion, urlRef, nil, result.MetadataEntry, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Etag = headers.Get("Etag")
+
+	return result, nil
+}
+
+// addOpenApiMetadata adds the given metadata entry to the entity identified by the given URN
+func addOpenApiMetadata(ctx context.Context, client *Client, entityId string, metadataEntry types.OpenApiMetadataEntry) (*OpenApiMetadataEntry, error) {
+	if entityId == "" {
+		return nil, fmt.Errorf("entity ID is required to add metadata")
+	}
+	if metadataEntry.KeyValue.Key == "" {
+		return nil, fmt.Errorf("metadata key is required")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEntityMetadata
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, entityId))
+	if err != nil {
+		return nil, err
+	}
+
+	createdEntry := &types.OpenApiMetadataEntry{}
+	err = client.OpenApiPostItem(ctx, apiVersion, urlRef, nil, metadataEntry, createdEntry, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error adding metadata entry with key '%s': %w", metadataEntry.KeyValue.Key, err)
+	}
+
+	return getOpenApiMetadataById(ctx, client, entityId, createdEntry.ID)
+}
+
+// convertOpenApiMetadataToMetadataValue converts an OpenAPI metadata entry to the XML metadata value equivalent
+func convertOpenApiMetadataToMetadataValue(entry types.OpenApiMetadataEntry) (*types.MetadataValue, error) {
+	var xsiType, value string
+	rawValue := entry.KeyValue.Value.Value
+	switch entry.KeyValue.Value.Type {
+	case types.OpenApiMetadataStringEntry:
+		xsiType = types.MetadataStringValue
+		stringValue, ok := rawValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of metadata entry '%s' is not a string: %v", entry.KeyValue.Key, rawValue)
+		}
+		value = stringValue
+	case types.OpenApiMetadataJsonEntry:
+		xsiType = types.MetadataStringValue
+		jsonValue, err := json.Marshal(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding JSON value of metadata entry '%s': %w", entry.KeyValue.Key, err)
+		}
+		value = string(jsonValue)
+	case types.OpenApiMetadataNumberEntry:
+		xsiType = types.MetadataNumberValue
+		switch numberValue := rawValue.(type) {
+		case float64:
+			// Numbers retrieved from VCD are decoded as float64
+			value = strconv.FormatFloat(numberValue, 'f', -1, 64)
+		case int:
+			value = strconv.Itoa(numberValue)
+		case int64:
+			value = strconv.FormatInt(numberValue, 10)
+		default:
+			return nil, fmt.Errorf("value of metadata entry '%s' is not a number: %v", entry.KeyValue.Key, rawValue)
+		}
+	case types.OpenApiMetadataBoolEntry:
+		xsiType = types.MetadataBooleanValue
+		boolValue, ok := rawValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("value of metadata entry '%s' is not a boolean: %v", entry.KeyValue.Key, rawValue)
+		}
+		value = strconv.FormatBool(boolValue)
+	default:
+		return nil, fmt.Errorf("unsupported OpenAPI metadata type '%s'", entry.KeyValue.Value.Type)
+	}
+
+	domain := &types.MetadataDomainTag{
+		Domain:     "GENERAL",
+		Visibility: types.MetadataReadWriteVisibility,
+	}
+	if entry.KeyValue.Domain == types.OpenApiMetadataProviderDomain {
+		domain.Domain = "SYSTEM"
+		domain.Visibility = types.MetadataHiddenVisibility
+		if entry.IsReadOnly {
+			domain.Visibility = types.MetadataReadOnlyVisibility
+		}
+	}
+
+	return &types.MetadataValue{
+		Xmlns:  types.XMLNamespaceVCloud,
+		Xsi:    types.XMLNamespaceXSI,
+		Domain: domain,
+		TypedValue: &types.MetadataTypedValue{
+			XsiType: xsiType,
+			Value:   value,
+		},
+	}, nil
+}
@@ -0,0 +1,163 @@
+//go:build functional || openapi || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+// Test_RdeOpenApiMetadata tests OpenAPI metadata of Runtime Defined Entities
+func (vcd *TestVCD) Test_RdeOpenApiMetadata(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+	for _, endpoint := range []string{
+		types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRdeEntityTypes,
+		types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRdeEntities,
+		types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEntityMetadata,
+	} {
+		skipOpenApiEndpointTest(ctx, vcd, check, endpoint)
+	}
+
+	schema, err := loadRdeTypeSchemaFromTestResources()
+	check.Assert(err, IsNil)
+
+	rdeType, err := vcd.client.CreateRdeType(ctx, &types.DefinedEntityType{
+		Name:    check.TestName(),
+		Nss:     strings.ReplaceAll(check.TestName(), ".", ""),
+		Version: "1.0.0",
+		Schema:  schema,
+		Vendor:  "vmware",
+	})
+	check.Assert(err, IsNil)
+	AddToCleanupListOpenApi(rdeType.DefinedEntityType.ID, check.TestName(), types.OpenApiPathVersion1_0_0+types.OpenApiEndpointRdeEntityTypes+rdeType.DefinedEntityType.ID)
+
+	rde, err := rdeType.CreateRde(ctx, types.DefinedEntity{
+		Name:   check.TestName(),
+		Entity: map[string]interface{}{"foo": map[string]interface{}{"key": "value"}, "bar": "value"},
+	}, nil)
+	check.Assert(err, IsNil)
+	err = rde.Resolve(ctx)
+	check.Assert(err, IsNil)
+	AddToCleanupListOpenApi(rde.DefinedEntity.ID, check.TestName(), types.OpenApiPathVersion1_0_0+types.OpenApiEndpointRdeEntities+rde.DefinedEntity.ID)
+
+	testOpenApiMetadataCrud(check, rde)
+
+	err = rde.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = rdeType.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+// Test_VdcGroupOpenApiMetadata tests OpenAPI metadata of VDC Groups
+func (vcd *TestVCD) Test_VdcGroupOpenApiMetadata(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+	if vcd.config.VCD.Nsxt.Vdc == "" {
+		check.Skip("Missing NSX-T config: No NSX-T VDC specified")
+	}
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointVdcGroups)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEntityMetadata)
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.org.Org.Name)
+	check.Assert(err, IsNil)
+
+	vdcGroup, err := adminOrg.CreateNsxtVdcGroup(ctx, check.TestName(), "", vcd.nsxtVdc.vdcId(), []string{vcd.nsxtVdc.vdcId()})
+	check.Assert(err, IsNil)
+	openApiEndpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointVdcGroups + vdcGroup.VdcGroup.Id
+	PrependToCleanupListOpenApi(vdcGroup.VdcGroup.Name, check.TestName(), openApiEndpoint)
+
+	testOpenApiMetadataCrud(check, vdcGroup)
+
+	err = vdcGroup.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+// testOpenApiMetadataCrud adds entries of every type to the given entity, checks them with the OpenAPI and the XML
+// representations, then updates and deletes them
+func testOpenApiMetadataCrud(check *C, entity OpenApiMetadataEntity) {
+	existingEntries, err := GetOpenApiMetadata(ctx, entity, nil)
+	check.Assert(err, IsNil)
+
+	testCases := []struct {
+		value       interface{}
+		valueType   string
+		xmlType     string
+		xmlValue    string
+		updateValue interface{}
+	}{
+		{"stringValue", types.OpenApiMetadataStringEntry, types.MetadataStringValue, "stringValue", "updatedValue"},
+		{float64(42), types.OpenApiMetadataNumberEntry, types.MetadataNumberValue, "42", float64(43)},
+		{true, types.OpenApiMetadataBoolEntry, types.MetadataBooleanValue, "true", false},
+		{map[string]interface{}{"a": float64(1), "b": "two"}, types.OpenApiMetadataJsonEntry, types.MetadataStringValue,
+			`{"a":1,"b":"two"}`, map[string]interface{}{"a": float64(2)}},
+	}
+
+	var createdEntries []*OpenApiMetadataEntry
+	for _, testCase := range testCases {
+		key := fmt.Sprintf("%s_%s", check.TestName(), testCase.valueType)
+		createdEntry, err := AddOpenApiMetadata(ctx, entity, types.OpenApiMetadataEntry{
+			KeyValue: types.OpenApiMetadataKeyValue{
+				Domain:    types.OpenApiMetadataTenantDomain,
+				Key:       key,
+				Namespace: "govcd",
+				Value: types.OpenApiMetadataTypedValue{
+					Value: testCase.value,
+					Type:  testCase.valueType,
+				},
+			},
+		})
+		check.Assert(err, IsNil)
+		check.Assert(createdEntry.MetadataEntry.ID, Not(Equals), "")
+		createdEntries = append(createdEntries, createdEntry)
+
+		entryByKey, err := GetOpenApiMetadataByKey(ctx, entity, "govcd", key)
+		check.Assert(err, IsNil)
+		check.Assert(entryByKey.MetadataEntry.ID, Equals, createdEntry.MetadataEntry.ID)
+		check.Assert(entryByKey.MetadataEntry.KeyValue.Value.Value, DeepEquals, testCase.value)
+
+		value, err := entryByKey.ToMetadataValue()
+		check.Assert(err, IsNil)
+		check.Assert(value.TypedValue.XsiType, Equals, testCase.xmlType)
+		check.Assert(value.TypedValue.Value, Equals, testCase.xmlValue)
+
+		err = entryByKey.Update(ctx, testCase.updateValue, false)
+		check.Assert(err, IsNil)
+		entryById, err := GetOpenApiMetadataById(ctx, entity, createdEntry.MetadataEntry.ID)
+		check.Assert(err, IsNil)
+		check.Assert(entryById.MetadataEntry.KeyValue.Value.Value, DeepEquals, testCase.updateValue)
+	}
+
+	// The entries can also be read as XML metadata
+	xmlEntries, err := GetMetadataEntries(ctx, entity)
+	check.Assert(err, IsNil)
+	check.Assert(len(xmlEntries), Equals, len(existingEntries)+len(testCases))
+	for _, xmlEntry := range xmlEntries {
+		if !strings.HasPrefix(xmlEntry.Key, check.TestName()) {
+			continue
+		}
+		check.Assert(xmlEntry.Domain.Domain, Equals, "GENERAL")
+		openApiEntry, err := ConvertMetadataEntryToOpenApi(xmlEntry, "govcd")
+		check.Assert(err, IsNil)
+		check.Assert(openApiEntry.KeyValue.Key, Equals, xmlEntry.Key)
+	}
+
+	for _, createdEntry := range createdEntries {
+		entryId := createdEntry.MetadataEntry.ID
+		err = createdEntry.Delete(ctx)
+		check.Assert(err, IsNil)
+		_, err = GetOpenApiMetadataById(ctx, entity, entryId)
+		check.Assert(ContainsNotFound(err), Equals, true)
+	}
+}
@@ -0,0 +1,208 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_convertOpenApiMetadataToMetadataValue(t *testing.T) {
+	newEntry := func(domain, valueType string, value interface{}, readOnly bool) types.OpenApiMetadataEntry {
+		return types.OpenApiMetadataEntry{
+			IsReadOnly: readOnly,
+			KeyValue: types.OpenApiMetadataKeyValue{
+				Domain: domain,
+				Key:    "key",
+				Value: types.OpenApiMetadataTypedValue{
+					Value: value,
+					Type:  valueType,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		entry          types.OpenApiMetadataEntry
+		wantType       string
+		wantValue      string
+		wantDomain     string
+		wantVisibility string
+		wantErr        bool
+	}{
+		{
+			name:           "TenantString",
+			entry:          newEntry(types.OpenApiMetadataTenantDomain, types.OpenApiMetadataStringEntry, "foo", false),
+			wantType:       types.MetadataStringValue,
+			wantValue:      "foo",
+			wantDomain:     "GENERAL",
+			wantVisibility: types.MetadataReadWriteVisibility,
+		},
+		{
+			name:           "ProviderReadOnlyNumber",
+			entry:          newEntry(types.OpenApiMetadataProviderDomain, types.OpenApiMetadataNumberEntry, float64(42), true),
+			wantType:       types.MetadataNumberValue,
+			wantValue:      "42",
+			wantDomain:     "SYSTEM",
+			wantVisibility: types.MetadataReadOnlyVisibility,
+		},
+		{
+			name:           "ProviderPrivateBool",
+			entry:          newEntry(types.OpenApiMetadataProviderDomain, types.OpenApiMetadataBoolEntry, true, false),
+			wantType:       types.MetadataBooleanValue,
+			wantValue:      "true",
+			wantDomain:     "SYSTEM",
+			wantVisibility: types.MetadataHiddenVisibility,
+		},
+		{
+			name:           "TenantDecimalNumber",
+			entry:          newEntry(types.OpenApiMetadataTenantDomain, types.OpenApiMetadataNumberEntry, 1234567.5, false),
+			wantType:       types.MetadataNumberValue,
+			wantValue:      "1234567.5",
+			wantDomain:     "GENERAL",
+			wantVisibility: types.MetadataReadWriteVisibility,
+		},
+		{
+			name:           "TenantIntNumber",
+			entry:          newEntry(types.OpenApiMetadataTenantDomain, types.OpenApiMetadataNumberEntry, 7, false),
+			wantType:       types.MetadataNumberValue,
+			wantValue:      "7",
+			wantDomain:     "GENERAL",
+			wantVisibility: types.MetadataReadWriteVisibility,
+		},
+		{
+			name: "TenantJson",
+			entry: newEntry(types.OpenApiMetadataTenantDomain, types.OpenApiMetadataJsonEntry,
+				map[string]interface{}{"b": []interface{}{"x", float64(2)}, "a": float64(1)}, false),
+			wantType:       types.MetadataStringValue,
+			wantValue:      `{"a":1,"b":["x",2]}`,
+			wantDomain:     "GENERAL",
+			wantVisibility: types.MetadataReadWriteVisibility,
+		},
+		{
+			name:    "StringWithWrongValue",
+			entry:   newEntry(types.OpenApiMetadataTenantDomain, types.OpenApiMetadataStringEntry, float64(1), false),
+			wantErr: true,
+		},
+		{
+			name:    "BoolWithWrongValue",
+			entry:   newEntry(types.OpenApiMetadataTenantDomain, types.OpenApiMetadataBoolEntry, "true", false),
+			wantErr: true,
+		},
+		{
+			name:    "UnknownType",
+			entry:   newEntry(types.OpenApiMetadataTenantDomain, "DateTimeEntry", "2023-01-01", false),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertOpenApiMetadataToMetadataValue(tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("convertOpenApiMetadataToMetadataValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.TypedValue.XsiType != tt.wantType || got.TypedValue.Value != tt.wantValue {
+				t.Errorf("got type %s and value %s, expected %s and %s", got.TypedValue.XsiType, got.TypedValue.Value, tt.wantType, tt.wantValue)
+			}
+			if got.Domain.Domain != tt.wantDomain || got.Domain.Visibility != tt.wantVisibility {
+				t.Errorf("got domain %s and visibility %s, expected %s and %s", got.Domain.Domain, got.Domain.Visibility, tt.wantDomain, tt.wantVisibility)
+			}
+		})
+	}
+}
+
+func Test_ConvertMetadataEntryToOpenApi(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    *types.MetadataEntry
+		want     types.OpenApiMetadataEntry
+		wantErr  bool
+		readBack string
+	}{
+		{
+			name: "GeneralString",
+			entry: &types.MetadataEntry{Key: "key", Domain: &types.MetadataDomainTag{Domain: "GENERAL", Visibility: types.MetadataReadWriteVisibility},
+				TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "foo"}},
+			want: types.OpenApiMetadataEntry{KeyValue: types.OpenApiMetadataKeyValue{Domain: types.OpenApiMetadataTenantDomain, Key: "key", Namespace: "ns",
+				Value: types.OpenApiMetadataTypedValue{Value: "foo", Type: types.OpenApiMetadataStringEntry}}},
+			readBack: "foo",
+		},
+		{
+			name: "SystemReadOnlyNumber",
+			entry: &types.MetadataEntry{Key: "key", Domain: &types.MetadataDomainTag{Domain: "SYSTEM", Visibility: types.MetadataReadOnlyVisibility},
+				TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataNumberValue, Value: "42"}},
+			want: types.OpenApiMetadataEntry{IsReadOnly: true, KeyValue: types.OpenApiMetadataKeyValue{Domain: types.OpenApiMetadataProviderDomain, Key: "key", Namespace: "ns",
+				Value: types.OpenApiMetadataTypedValue{Value: float64(42), Type: types.OpenApiMetadataNumberEntry}}},
+			readBack: "42",
+		},
+		{
+			name:  "NoDomainBool",
+			entry: &types.MetadataEntry{Key: "key", TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataBooleanValue, Value: "true"}},
+			want: types.OpenApiMetadataEntry{KeyValue: types.OpenApiMetadataKeyValue{Domain: types.OpenApiMetadataTenantDomain, Key: "key", Namespace: "ns",
+				Value: types.OpenApiMetadataTypedValue{Value: true, Type: types.OpenApiMetadataBoolEntry}}},
+			readBack: "true",
+		},
+		{
+			name:    "DateTime",
+			entry:   &types.MetadataEntry{Key: "key", TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataDateTimeValue, Value: "2023-01-01T00:00:00Z"}},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidNumber",
+			entry:   &types.MetadataEntry{Key: "key", TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataNumberValue, Value: "abc"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertMetadataEntryToOpenApi(tt.entry, "ns")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertMetadataEntryToOpenApi() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != tt.want {
+				t.Errorf("got %+v, expected %+v", *got, tt.want)
+			}
+			// Converting back gives the original value
+			value, err := convertOpenApiMetadataToMetadataValue(*got)
+			if err != nil {
+				t.Fatalf("unexpected error converting back: %s", err)
+			}
+			if value.TypedValue.Value != tt.readBack || value.TypedValue.XsiType != tt.entry.TypedValue.XsiType {
+				t.Errorf("got back type %s and value %s, expected %s and %s", value.TypedValue.XsiType, value.TypedValue.Value,
+					tt.entry.TypedValue.XsiType, tt.readBack)
+			}
+		})
+	}
+}
+
+func Test_OpenApiMetadataEntity(t *testing.T) {
+	client := &Client{}
+	entities := map[string]OpenApiMetadataEntity{
+		"urn:vcloud:entity:vmware:type:1": &DefinedEntity{DefinedEntity: &types.DefinedEntity{ID: "urn:vcloud:entity:vmware:type:1", Name: "rde"}, client: client},
+		"urn:vcloud:vdcGroup:1":           &VdcGroup{VdcGroup: &types.VdcGroup{Id: "urn:vcloud:vdcGroup:1", Name: "group"}, client: client},
+		"urn:vcloud:network:1":            &OpenApiOrgVdcNetwork{OpenApiOrgVdcNetwork: &types.OpenApiOrgVdcNetwork{ID: "urn:vcloud:network:1", Name: "net"}, client: client},
+	}
+	for expectedId, entity := range entities {
+		if entity.OpenApiMetadataEntityId() != expectedId {
+			t.Errorf("expected entity ID %s, got %s", expectedId, entity.OpenApiMetadataEntityId())
+		}
+		if entity.MetadataClient() != client {
+			t.Errorf("unexpected client for entity %s", expectedId)
+		}
+		if entity.MetadataEntityName() == "" {
+			t.Errorf("empty name for entity %s", expectedId)
+		}
+	}
+}
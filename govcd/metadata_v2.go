@@ -6,10 +6,10 @@ package govcd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"net/http"
-	"strings"
 )
 
 // NOTE: This "v2" is not v2 in terms of API versioning, it's just a way to separate the functions that handle
@@ -107,8 +107,18 @@ func (catalogItem *CatalogItem) GetMetadataByKey(ctx context.Context, key string
 	return getMetadataByKey(ctx, catalogItem.client, catalogItem.CatalogItem.HREF, key, isSystem)
 }
 
+// GetMetadataByKey returns VdcStorageProfile metadata corresponding to the given key and domain.
+func (storageProfile *VdcStorageProfile) GetMetadataByKey(ctx context.Context, key string, isSystem bool) (*types.MetadataValue, error) {
+	return getMetadataByKey(ctx, storageProfile.client, getAdminURL(storageProfile.VdcStorageProfile.HREF), key, isSystem)
+}
+
+// GetMetadataByKey returns AdminVdcStorageProfile metadata corresponding to the given key and domain.
+func (adminStorageProfile *AdminVdcStorageProfile) GetMetadataByKey(ctx context.Context, key string, isSystem bool) (*types.MetadataValue, error) {
+	return getMetadataByKey(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF, key, isSystem)
+}
+
 // GetMetadataByKey returns OpenApiOrgVdcNetwork metadata corresponding to the given key and domain.
-// NOTE: This function cannot retrieve metadata if the network belongs to a VDC Group.
+// NOTE: This function cannot retrieve metadata if the network belongs to a VDC Group. Use GetOpenApiMetadataByKey for those.
 // TODO: This function is currently using XML API underneath as OpenAPI metadata is still not supported.
 func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) GetMetadataByKey(ctx context.Context, key string, isSystem bool) (*types.MetadataValue, error) {
 	href := fmt.Sprintf("%s/network/%s", openApiOrgVdcNetwork.client.VCDHREF.String(), extractUuid(openApiOrgVdcNetwork.OpenApiOrgVdcNetwork.ID))
@@ -200,8 +210,18 @@ func (catalogItem *CatalogItem) GetMetadata(ctx context.Context) (*types.Metadat
 	return getMetadata(ctx, catalogItem.client, catalogItem.CatalogItem.HREF)
 }
 
+// GetMetadata returns VdcStorageProfile metadata.
+func (storageProfile *VdcStorageProfile) GetMetadata(ctx context.Context) (*types.Metadata, error) {
+	return getMetadata(ctx, storageProfile.client, getAdminURL(storageProfile.VdcStorageProfile.HREF))
+}
+
+// GetMetadata returns AdminVdcStorageProfile metadata.
+func (adminStorageProfile *AdminVdcStorageProfile) GetMetadata(ctx context.Context) (*types.Metadata, error) {
+	return getMetadata(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF)
+}
+
 // GetMetadata returns OpenApiOrgVdcNetwork metadata.
-// NOTE: This function cannot retrieve metadata if the network belongs to a VDC Group.
+// NOTE: This function cannot retrieve metadata if the network belongs to a VDC Group. Use GetOpenApiMetadata for those.
 // TODO: This function is currently using XML API underneath as OpenAPI metadata is still not supported.
 func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) GetMetadata(ctx context.Context) (*types.Metadata, error) {
 	href := fmt.Sprintf("%s/network/%s", openApiOrgVdcNetwork.client.VCDHREF.String(), extractUuid(openApiOrgVdcNetwork.OpenApiOrgVdcNetwork.ID))
@@ -292,6 +312,16 @@ func (catalogItem *CatalogItem) AddMetadataEntryWithVisibilityAsync(ctx context.
 	return addMetadata(ctx, catalogItem.client, catalogItem.CatalogItem.HREF, key, value, typedValue, visibility, isSystem)
 }
 
+// AddMetadataEntryWithVisibilityAsync adds metadata to the receiver VdcStorageProfile and returns the task.
+func (storageProfile *VdcStorageProfile) AddMetadataEntryWithVisibilityAsync(ctx context.Context, key, value, typedValue, visibility string, isSystem bool) (Task, error) {
+	return addMetadata(ctx, storageProfile.client, getAdminURL(storageProfile.VdcStorageProfile.HREF), key, value, typedValue, visibility, isSystem)
+}
+
+// AddMetadataEntryWithVisibilityAsync adds metadata to the receiver AdminVdcStorageProfile and returns the task.
+func (adminStorageProfile *AdminVdcStorageProfile) AddMetadataEntryWithVisibilityAsync(ctx context.Context, key, value, typedValue, visibility string, isSystem bool) (Task, error) {
+	return addMetadata(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF, key, value, typedValue, visibility, isSystem)
+}
+
 // ------------------------------------------------------------------------------------------------
 // ADD metadata
 // ------------------------------------------------------------------------------------------------
@@ -368,8 +398,18 @@ func (catalogItem *CatalogItem) AddMetadataEntryWithVisibility(ctx context.Conte
 	return addMetadataAndWait(ctx, catalogItem.client, catalogItem.CatalogItem.HREF, key, value, typedValue, visibility, isSystem)
 }
 
+// AddMetadataEntryWithVisibility adds metadata to the receiver VdcStorageProfile and waits for the task to finish.
+func (storageProfile *VdcStorageProfile) AddMetadataEntryWithVisibility(ctx context.Context, key, value, typedValue, visibility string, isSystem bool) error {
+	return addMetadataAndWait(ctx, storageProfile.client, getAdminURL(storageProfile.VdcStorageProfile.HREF), key, value, typedValue, visibility, isSystem)
+}
+
+// AddMetadataEntryWithVisibility adds metadata to the receiver AdminVdcStorageProfile and waits for the task to finish.
+func (adminStorageProfile *AdminVdcStorageProfile) AddMetadataEntryWithVisibility(ctx context.Context, key, value, typedValue, visibility string, isSystem bool) error {
+	return addMetadataAndWait(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF, key, value, typedValue, visibility, isSystem)
+}
+
 // AddMetadataEntryWithVisibility adds metadata to the receiver OpenApiOrgVdcNetwork and waits for the task to finish.
-// Note: It doesn't add metadata to networks that belong to a VDC Group.
+// Note: It doesn't add metadata to networks that belong to a VDC Group. Use AddOpenApiMetadata for those.
 // TODO: This function is currently using XML API underneath as OpenAPI metadata is still not supported.
 func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) AddMetadataEntryWithVisibility(ctx context.Context, key, value, typedValue, visibility string, isSystem bool) error {
 	href := fmt.Sprintf("%s/admin/network/%s", openApiOrgVdcNetwork.client.VCDHREF.String(), extractUuid(openApiOrgVdcNetwork.OpenApiOrgVdcNetwork.ID))
@@ -464,6 +504,18 @@ func (catalogItem *CatalogItem) MergeMetadataWithMetadataValuesAsync(ctx context
 	return mergeAllMetadata(ctx, catalogItem.client, catalogItem.CatalogItem.HREF, metadata)
 }
 
+// MergeMetadataWithMetadataValuesAsync merges VdcStorageProfile metadata provided as a key-value map of type `typedValue` with the already present in VCD,
+// then waits for the task to complete.
+func (storageProfile *VdcStorageProfile) MergeMetadataWithMetadataValuesAsync(ctx context.Context, metadata map[string]types.MetadataValue) (Task, error) {
+	return mergeAllMetadata(ctx, storageProfile.client, getAdminURL(storageProfile.VdcStorageProfile.HREF), metadata)
+}
+
+// MergeMetadataWithMetadataValuesAsync merges AdminVdcStorageProfile metadata provided as a key-value map of type `typedValue` with the already present in VCD,
+// then waits for the task to complete.
+func (adminStorageProfile *AdminVdcStorageProfile) MergeMetadataWithMetadataValuesAsync(ctx context.Context, metadata map[string]types.MetadataValue) (Task, error) {
+	return mergeAllMetadata(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF, metadata)
+}
+
 // ------------------------------------------------------------------------------------------------
 // MERGE metadata
 // ------------------------------------------------------------------------------------------------
@@ -554,6 +606,20 @@ func (catalogItem *CatalogItem) MergeMetadataWithMetadataValues(ctx context.Cont
 	return mergeMetadataAndWait(ctx, catalogItem.client, catalogItem.CatalogItem.HREF, metadata)
 }
 
+// MergeMetadataWithMetadataValues updates the metadata values that are already present in the receiver VdcStorageProfile and creates the ones not present.
+// The input metadata map has a "metadata key"->"metadata value" relation.
+// This function waits until merge finishes.
+func (storageProfile *VdcStorageProfile) MergeMetadataWithMetadataValues(ctx context.Context, metadata map[string]types.MetadataValue) error {
+	return mergeMetadataAndWait(ctx, storageProfile.client, getAdminURL(storageProfile.VdcStorageProfile.HREF), metadata)
+}
+
+// MergeMetadataWithMetadataValues updates the metadata values that are already present in the receiver AdminVdcStorageProfile and creates the ones not present.
+// The input metadata map has a "metadata key"->"metadata value" relation.
+// This function waits until merge finishes.
+func (adminStorageProfile *AdminVdcStorageProfile) MergeMetadataWithMetadataValues(ctx context.Context, metadata map[string]types.MetadataValue) error {
+	return mergeMetadataAndWait(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF, metadata)
+}
+
 // MergeMetadataWithMetadataValues updates the metadata values that are already present in the receiver OpenApiOrgVdcNetwork and creates the ones not present.
 // The input metadata map has a "metadata key"->"metadata value" relation.
 // This function waits until merge finishes.
@@ -640,6 +706,16 @@ func (catalogItem *CatalogItem) DeleteMetadataEntryWithDomainAsync(ctx context.C
 	return deleteMetadata(ctx, catalogItem.client, catalogItem.CatalogItem.HREF, key, isSystem)
 }
 
+// DeleteMetadataEntryWithDomainAsync deletes VdcStorageProfile metadata associated to the input key and returns the task.
+func (storageProfile *VdcStorageProfile) DeleteMetadataEntryWithDomainAsync(ctx context.Context, key string, isSystem bool) (Task, error) {
+	return deleteMetadata(ctx, storageProfile.client, getAdminURL(storageProfile.VdcStorageProfile.HREF), key, isSystem)
+}
+
+// DeleteMetadataEntryWithDomainAsync deletes AdminVdcStorageProfile metadata associated to the input key and returns the task.
+func (adminStorageProfile *AdminVdcStorageProfile) DeleteMetadataEntryWithDomainAsync(ctx context.Context, key string, isSystem bool) (Task, error) {
+	return deleteMetadata(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF, key, isSystem)
+}
+
 // ------------------------------------------------------------------------------------------------
 // DELETE metadata
 // ------------------------------------------------------------------------------------------------
@@ -717,6 +793,16 @@ func (catalogItem *CatalogItem) DeleteMetadataEntryWithDomain(ctx context.Contex
 	return deleteMetadataAndWait(ctx, catalogItem.client, catalogItem.CatalogItem.HREF, key, isSystem)
 }
 
+// DeleteMetadataEntryWithDomain deletes VdcStorageProfile metadata associated to the input key and waits for the task to finish.
+func (storageProfile *VdcStorageProfile) DeleteMetadataEntryWithDomain(ctx context.Context, key string, isSystem bool) error {
+	return deleteMetadataAndWait(ctx, storageProfile.client, getAdminURL(storageProfile.VdcStorageProfile.HREF), key, isSystem)
+}
+
+// DeleteMetadataEntryWithDomain deletes AdminVdcStorageProfile metadata associated to the input key and waits for the task to finish.
+func (adminStorageProfile *AdminVdcStorageProfile) DeleteMetadataEntryWithDomain(ctx context.Context, key string, isSystem bool) error {
+	return deleteMetadataAndWait(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF, key, isSystem)
+}
+
 // DeleteMetadataEntryWithDomain deletes OpenApiOrgVdcNetwork metadata associated to the input key and waits for the task to finish.
 // Note: It doesn't delete metadata from networks that belong to a VDC Group.
 // TODO: This function is currently using XML API underneath as OpenAPI metadata is still not supported.
@@ -729,6 +815,111 @@ func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) DeleteMetadataEntryWithDomain(
 	return task.WaitTaskCompletion(ctx)
 }
 
+// ------------------------------------------------------------------------------------------------
+// UPDATE metadata visibility
+// ------------------------------------------------------------------------------------------------
+
+// UpdateMetadataEntryVisibilityByHref changes the visibility and/or domain of the metadata entry identified by the given
+// key in the given resource reference, keeping its value. isSystem indicates the domain that the entry must have
+// after the change.
+func (vcdClient *VCDClient) UpdateMetadataEntryVisibilityByHref(ctx context.Context, href, key, newVisibility string, isSystem bool) error {
+	return updateMetadataEntryVisibility(ctx, &vcdClient.Client, href, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver VM metadata entry identified by
+// the given key, keeping its value.
+func (vm *VM) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, vm, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver AdminVdc metadata entry identified by
+// the given key, keeping its value.
+func (adminVdc *AdminVdc) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, adminVdc, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver ProviderVdc metadata entry identified by
+// the given key, keeping its value.
+// Note: Requires system administrator privileges.
+func (providerVdc *ProviderVdc) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, providerVdc, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver VApp metadata entry identified by
+// the given key, keeping its value.
+func (vapp *VApp) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, vapp, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver VAppTemplate metadata entry identified by
+// the given key, keeping its value.
+func (vAppTemplate *VAppTemplate) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, vAppTemplate, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver MediaRecord metadata entry identified by
+// the given key, keeping its value.
+func (mediaRecord *MediaRecord) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, mediaRecord, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver Media metadata entry identified by
+// the given key, keeping its value.
+func (media *Media) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, media, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver AdminCatalog metadata entry identified by
+// the given key, keeping its value.
+func (adminCatalog *AdminCatalog) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, adminCatalog, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver AdminOrg metadata entry identified by
+// the given key, keeping its value.
+func (adminOrg *AdminOrg) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, adminOrg, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver Disk metadata entry identified by
+// the given key, keeping its value.
+func (disk *Disk) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, disk, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver OrgVDCNetwork metadata entry identified by
+// the given key, keeping its value.
+// Note: Requires system administrator privileges.
+func (orgVdcNetwork *OrgVDCNetwork) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, orgVdcNetwork, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver CatalogItem metadata entry identified by
+// the given key, keeping its value.
+func (catalogItem *CatalogItem) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, catalogItem, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver VdcStorageProfile metadata entry identified by
+// the given key, keeping its value.
+func (storageProfile *VdcStorageProfile) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, storageProfile, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver AdminVdcStorageProfile metadata entry identified by
+// the given key, keeping its value.
+func (adminStorageProfile *AdminVdcStorageProfile) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, adminStorageProfile, key, newVisibility, isSystem)
+}
+
+// UpdateMetadataEntryVisibility changes the visibility and/or domain of the receiver OpenApiOrgVdcNetwork metadata entry
+// identified by the given key, keeping its value.
+// Note: It doesn't update metadata of networks that belong to a VDC Group.
+// TODO: This function is currently using XML API underneath as OpenAPI metadata is still not supported.
+func (openApiOrgVdcNetwork *OpenApiOrgVdcNetwork) UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error {
+	return UpdateMetadataEntryVisibility(ctx, openApiOrgVdcNetwork, key, newVisibility, isSystem)
+}
+
 // ------------------------------------------------------------------------------------------------
 // Generic private functions
 // ------------------------------------------------------------------------------------------------
@@ -743,6 +934,14 @@ func getMetadataByKey(ctx context.Context, client *Client, requestUri, key strin
 	}
 
 	_, err := client.ExecuteRequest(ctx, href+key, http.MethodGet, types.MimeMetaData, "error retrieving metadata by key "+key+": %s", nil, metadata)
+	var apiError *APIError
+	if HasAPIErrorStatus(err, http.StatusNotFound) && errors.As(err, &apiError) {
+		// A missing entry is reported with ErrorEntityNotFound, so that it can be told apart from other errors
+		return metadata, &notFoundAPIError{
+			message:  fmt.Sprintf("%s: %s", ErrorEntityNotFound, err),
+			apiError: apiError,
+		}
+	}
 	return metadata, err
 }
 
@@ -788,9 +987,10 @@ func addMetadata(ctx context.Context, client *Client, requestUri, key, value, ty
 	domain := newMetadata.Domain.Visibility
 	task, err := client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPut, types.MimeMetaDataValue, "error adding metadata: %s", newMetadata)
 
-	// Workaround for ugly error returned by VCD: "API Error: 500: [ <uuid> ] visibility"
-	if err != nil && strings.HasSuffix(err.Error(), "visibility") {
-		err = fmt.Errorf("error adding metadata with key %s: visibility cannot be %s when domain is %s: %s", key, visibility, domain, err)
+	// VCD returns an unfriendly error when the visibility does not match the domain
+	err = mapServerError(err, serverErrorScopeMetadata)
+	if errors.Is(err, ErrorMetadataVisibility) {
+		err = fmt.Errorf("error adding metadata with key %s: visibility cannot be %s when domain is %s: %w", key, visibility, domain, err)
 	}
 	return task, err
 }
@@ -869,3 +1069,76 @@ func deleteMetadataAndWait(ctx context.Context, client *Client, requestUri strin
 
 	return task.WaitTaskCompletion(ctx)
 }
+
+// validateMetadataVisibility checks that the given visibility is allowed in the given domain:
+// GENERAL domain only accepts types.MetadataReadWriteVisibility, while SYSTEM domain accepts
+// types.MetadataReadOnlyVisibility and types.MetadataHiddenVisibility.
+func validateMetadataVisibility(visibility string, isSystem bool) error {
+	switch visibility {
+	case types.MetadataReadWriteVisibility:
+		if isSystem {
+			return fmt.Errorf("visibility %s is not allowed in SYSTEM domain", visibility)
+		}
+	case types.MetadataReadOnlyVisibility, types.MetadataHiddenVisibility:
+		if !isSystem {
+			return fmt.Errorf("visibility %s is not allowed in GENERAL domain", visibility)
+		}
+	default:
+		return fmt.Errorf("unknown metadata visibility '%s'", visibility)
+	}
+	return nil
+}
+
+// updateMetadataEntryVisibility changes the visibility of the metadata entry identified by key, keeping its value.
+// isSystem is the domain that the entry must have after the change:
+//   - If the entry already exists in the target domain, it is re-written in place with the new visibility, which is a
+//     single operation.
+//   - If the entry only exists in the other domain, it is first created in the target domain and then removed from the
+//     original one. If the removal fails, the newly created entry is removed, so that the original entry is left untouched.
+//     If that also fails, the returned error reports that the entry exists in both domains.
+//
+// Errors other than ErrorEntityNotFound while retrieving the entry are returned without changing anything.
+func updateMetadataEntryVisibility(ctx context.Context, client *Client, requestUri, key, newVisibility string, isSystem bool) error {
+	err := validateMetadataVisibility(newVisibility, isSystem)
+	if err != nil {
+		return fmt.Errorf("error updating visibility of metadata entry with key %s: %w", key, err)
+	}
+
+	// The entry is already in the wanted domain: only visibility needs to change
+	current, err := getMetadataByKey(ctx, client, requestUri, key, isSystem)
+	if err != nil && !ContainsNotFound(err) {
+		return fmt.Errorf("error retrieving metadata entry with key %s: %w", key, err)
+	}
+	if err == nil && current.TypedValue != nil {
+		if current.Domain != nil && current.Domain.Visibility == newVisibility {
+			return nil
+		}
+		return addMetadataAndWait(ctx, client, requestUri, key, current.TypedValue.Value, current.TypedValue.XsiType, newVisibility, isSystem)
+	}
+
+	// The entry must be moved from the other domain
+	current, err = getMetadataByKey(ctx, client, requestUri, key, !isSystem)
+	if err != nil {
+		return fmt.Errorf("error retrieving metadata entry with key %s: %w", key, err)
+	}
+	if current.TypedValue == nil {
+		return fmt.Errorf("metadata entry with key %s has no value", key)
+	}
+
+	err = addMetadataAndWait(ctx, client, requestUri, key, current.TypedValue.Value, current.TypedValue.XsiType, newVisibility, isSystem)
+	if err != nil {
+		return fmt.Errorf("error creating metadata entry with key %s in new domain: %w", key, err)
+	}
+
+	err = deleteMetadataAndWait(ctx, client, requestUri, key, !isSystem)
+	if err != nil {
+		rollbackErr := deleteMetadataAndWait(ctx, client, requestUri, key, isSystem)
+		if rollbackErr != nil {
+			return fmt.Errorf("error removing metadata entry with key %s from previous domain: %w. Rollback failed, "+
+				"the entry now exists in both domains: %s", key, err, rollbackErr)
+		}
+		return fmt.Errorf("error removing metadata entry with key %s from previous domain (changes were rolled back): %w", key, err)
+	}
+
+	return nil
+}
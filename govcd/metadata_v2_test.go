@@ -277,6 +277,231 @@ func (vcd *TestVCD) TestCatalogItemMetadata(check *C) {
 	testMetadataCRUDActions(catalogItem, check, nil)
 }
 
+func (vcd *TestVCD) TestVdcStorageProfileMetadata(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	if vcd.config.VCD.StorageProfile.SP1 == "" {
+		check.Skip("skipping test because storage profile name is empty")
+	}
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.org.Org.Name)
+	check.Assert(err, IsNil)
+	adminVdc, err := adminOrg.GetAdminVDCByName(ctx, vcd.vdc.Vdc.Name, false)
+	check.Assert(err, IsNil)
+
+	storageProfile, err := adminVdc.GetStorageProfileByName(ctx, vcd.config.VCD.StorageProfile.SP1)
+	check.Assert(err, IsNil)
+	check.Assert(storageProfile.VdcStorageProfile.Name, Equals, vcd.config.VCD.StorageProfile.SP1)
+
+	storageProfileById, err := adminVdc.GetStorageProfileById(ctx, storageProfile.VdcStorageProfile.ID)
+	check.Assert(err, IsNil)
+	check.Assert(storageProfileById.VdcStorageProfile.HREF, Equals, storageProfile.VdcStorageProfile.HREF)
+
+	_, err = adminVdc.GetStorageProfileByName(ctx, "non-existing-storage-profile")
+	check.Assert(ContainsNotFound(err), Equals, true)
+
+	testMetadataCRUDActions(storageProfile, check, nil)
+}
+
+func (vcd *TestVCD) TestAdminVdcStorageProfileMetadata(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	if vcd.config.VCD.StorageProfile.SP1 == "" {
+		check.Skip("skipping test because storage profile name is empty")
+	}
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.org.Org.Name)
+	check.Assert(err, IsNil)
+	adminVdc, err := adminOrg.GetAdminVDCByName(ctx, vcd.vdc.Vdc.Name, false)
+	check.Assert(err, IsNil)
+
+	adminStorageProfile, err := adminVdc.GetAdminStorageProfileByName(ctx, vcd.config.VCD.StorageProfile.SP1)
+	check.Assert(err, IsNil)
+	check.Assert(adminStorageProfile.AdminVdcStorageProfile.Name, Equals, vcd.config.VCD.StorageProfile.SP1)
+
+	adminStorageProfileById, err := adminVdc.GetAdminStorageProfileById(ctx, adminStorageProfile.AdminVdcStorageProfile.ID)
+	check.Assert(err, IsNil)
+	check.Assert(adminStorageProfileById.AdminVdcStorageProfile.HREF, Equals, adminStorageProfile.AdminVdcStorageProfile.HREF)
+
+	testMetadataCRUDActions(adminStorageProfile, check, func(testCase metadataTest) {
+		storageProfile, err := vcd.vdc.GetStorageProfileByName(ctx, vcd.config.VCD.StorageProfile.SP1)
+		check.Assert(err, IsNil)
+		metadata, err := storageProfile.GetMetadata(ctx)
+		check.Assert(err, IsNil)
+		assertMetadata(check, metadata, testCase, 1)
+	})
+}
+
+func (vcd *TestVCD) TestOpenApiOrgVdcNetworkOpenApiMetadata(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEntityMetadata)
+
+	net, err := vcd.vdc.GetOpenApiOrgVdcNetworkByName(ctx, vcd.config.VCD.Network.Net1)
+	if err != nil {
+		check.Skip(fmt.Sprintf("network %s not found. Test can't proceed", vcd.config.VCD.Network.Net1))
+		return
+	}
+
+	existingEntries, err := net.GetOpenApiMetadata(ctx, nil)
+	check.Assert(err, IsNil)
+
+	entry, err := net.AddOpenApiMetadata(ctx, types.OpenApiMetadataEntry{
+		KeyValue: types.OpenApiMetadataKeyValue{
+			Domain:    types.OpenApiMetadataTenantDomain,
+			Key:       check.TestName(),
+			Namespace: "govcd",
+			Value: types.OpenApiMetadataTypedValue{
+				Value: "networkValue",
+				Type:  types.OpenApiMetadataStringEntry,
+			},
+		},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(entry.MetadataEntry.ID, Not(Equals), "")
+
+	// The generic functions reach the same entries as the receiver methods
+	var metadataEntity OpenApiMetadataEntity = net
+	entries, err := GetOpenApiMetadata(ctx, metadataEntity, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(entries), Equals, len(existingEntries)+1)
+
+	entry, err = GetOpenApiMetadataByKey(ctx, metadataEntity, "govcd", check.TestName())
+	check.Assert(err, IsNil)
+	check.Assert(entry.MetadataEntry.KeyValue.Value.Value, Equals, "networkValue")
+
+	err = entry.Delete(ctx)
+	check.Assert(err, IsNil)
+	_, err = net.GetOpenApiMetadataById(ctx, entry.MetadataEntry.ID)
+	check.Assert(err, NotNil)
+}
+
+func (vcd *TestVCD) TestBatchApplyMetadata(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vApp was not successfully created at setup")
+	}
+
+	vApp := vcd.findFirstVapp(ctx)
+	vmType, vmName := vcd.findFirstVm(vApp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+
+	key := check.TestName()
+	hrefs := []string{vApp.VApp.HREF, vmType.HREF}
+	results := vcd.client.BatchApplyMetadata(ctx, hrefs, map[string]types.MetadataValue{
+		key: {TypedValue: &types.MetadataTypedValue{Value: "batch", XsiType: types.MetadataStringValue}},
+	}, 0)
+	check.Assert(len(results), Equals, len(hrefs))
+	for _, result := range results {
+		check.Assert(result.Error, IsNil)
+		value, err := getMetadataByKey(ctx, &vcd.client.Client, result.Href, key, false)
+		check.Assert(err, IsNil)
+		check.Assert(value.TypedValue.Value, Equals, "batch")
+		err = vcd.client.DeleteMetadataEntryWithDomainByHref(ctx, result.Href, key, false)
+		check.Assert(err, IsNil)
+	}
+}
+
+func (vcd *TestVCD) TestMetadataCarrier(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vApp was not successfully created at setup")
+	}
+
+	vApp := vcd.findFirstVapp(ctx)
+	vmType, vmName := vcd.findFirstVm(vApp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm := NewVM(&vcd.client.Client)
+	vm.VM = &vmType
+
+	key := check.TestName()
+	for _, carrier := range []MetadataCarrier{&vApp, vm} {
+		err := SetMetadataEntry(ctx, carrier, key, "value", types.MetadataStringValue, types.MetadataReadWriteVisibility, false)
+		check.Assert(err, IsNil)
+
+		value, err := GetMetadataByKey(ctx, carrier, key, false)
+		check.Assert(err, IsNil)
+		check.Assert(value.TypedValue.Value, Equals, "value")
+
+		err = MergeMetadata(ctx, carrier, map[string]types.MetadataValue{
+			key: {TypedValue: &types.MetadataTypedValue{Value: "merged", XsiType: types.MetadataStringValue}},
+		})
+		check.Assert(err, IsNil)
+		metadata, err := GetMetadata(ctx, carrier)
+		check.Assert(err, IsNil)
+		found := false
+		for _, entry := range metadata.MetadataEntry {
+			if entry.Key == key {
+				found = true
+				check.Assert(entry.TypedValue.Value, Equals, "merged")
+			}
+		}
+		check.Assert(found, Equals, true)
+
+		err = DeleteMetadataEntry(ctx, carrier, key, false)
+		check.Assert(err, IsNil)
+		_, err = GetMetadataByKey(ctx, carrier, key, false)
+		check.Assert(err, NotNil)
+	}
+}
+
+func (vcd *TestVCD) TestVmEffectiveMetadata(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vApp was not successfully created at setup")
+	}
+
+	vApp := vcd.findFirstVapp(ctx)
+	vmType, vmName := vcd.findFirstVm(vApp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm := NewVM(&vcd.client.Client)
+	vm.VM = &vmType
+
+	inheritedKey := check.TestName() + "-inherited"
+	overriddenKey := check.TestName() + "-overridden"
+	for _, entry := range []struct {
+		carrier MetadataCarrier
+		key     string
+		value   string
+	}{
+		{&vApp, inheritedKey, "vApp"},
+		{&vApp, overriddenKey, "vApp"},
+		{vm, overriddenKey, "VM"},
+	} {
+		err := SetMetadataEntry(ctx, entry.carrier, entry.key, entry.value, types.MetadataStringValue,
+			types.MetadataReadWriteVisibility, false)
+		check.Assert(err, IsNil)
+		defer func(carrier MetadataCarrier, key string) {
+			err := DeleteMetadataEntry(ctx, carrier, key, false)
+			check.Assert(err, IsNil)
+		}(entry.carrier, entry.key)
+	}
+
+	effectiveMetadata, err := vm.GetEffectiveMetadata(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(effectiveMetadata.Values[inheritedKey], Equals, "vApp")
+	check.Assert(effectiveMetadata.Entries[inheritedKey].Level, Equals, MetadataLevelVApp)
+	check.Assert(effectiveMetadata.Entries[inheritedKey].SourceHref, Equals, vApp.VApp.HREF)
+	check.Assert(effectiveMetadata.Values[overriddenKey], Equals, "VM")
+	check.Assert(effectiveMetadata.Entries[overriddenKey].Level, Equals, MetadataLevelVm)
+	check.Assert(effectiveMetadata.Entries[overriddenKey].OverriddenLevels, DeepEquals, []string{MetadataLevelVApp})
+
+	// With the vApp taking precedence over the VM
+	effectiveMetadata, err = vm.GetEffectiveMetadata(ctx, []string{MetadataLevelVm, MetadataLevelVApp})
+	check.Assert(err, IsNil)
+	check.Assert(effectiveMetadata.Values[overriddenKey], Equals, "vApp")
+	check.Assert(effectiveMetadata.Entries[overriddenKey].Level, Equals, MetadataLevelVApp)
+
+	_, err = vm.GetEffectiveMetadata(ctx, []string{MetadataLevelVm, "Site"})
+	check.Assert(err, NotNil)
+}
+
 // metadataCompatible allows centralizing and generalizing the tests for metadata compatible resources.
 type metadataCompatible interface {
 	GetMetadata(context.Context) (*types.Metadata, error)
@@ -284,6 +509,7 @@ type metadataCompatible interface {
 	AddMetadataEntryWithVisibility(ctx context.Context, key, value, typedValue, visibility string, isSystem bool) error
 	MergeMetadataWithMetadataValues(ctx context.Context, metadata map[string]types.MetadataValue) error
 	DeleteMetadataEntryWithDomain(ctx context.Context, key string, isSystem bool) error
+	UpdateMetadataEntryVisibility(ctx context.Context, key, newVisibility string, isSystem bool) error
 }
 
 type metadataTest struct {
@@ -421,6 +647,23 @@ func testMetadataCRUDActions(resource metadataCompatible, check *C, extraReadSte
 			extraReadStep(testCase)
 		}
 
+		// Change visibility of SYSTEM entries back and forth, checking that the value is kept
+		if testCase.IsSystem {
+			newVisibility := types.MetadataReadOnlyVisibility
+			if testCase.Visibility == types.MetadataReadOnlyVisibility {
+				newVisibility = types.MetadataHiddenVisibility
+			}
+			err = resource.UpdateMetadataEntryVisibility(ctx, testCase.Key, newVisibility, true)
+			check.Assert(err, IsNil)
+			metadataValue, err = resource.GetMetadataByKey(ctx, testCase.Key, true)
+			check.Assert(err, IsNil)
+			check.Assert(metadataValue.Domain.Visibility, Equals, newVisibility)
+			check.Assert(metadataValue.TypedValue.Value, Equals, testCase.Value)
+
+			err = resource.UpdateMetadataEntryVisibility(ctx, testCase.Key, testCase.Visibility, true)
+			check.Assert(err, IsNil)
+		}
+
 		domain := "GENERAL"
 		if testCase.IsSystem {
 			domain = "SYSTEM"
@@ -0,0 +1,181 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_validateMetadataVisibility(t *testing.T) {
+	tests := []struct {
+		visibility string
+		isSystem   bool
+		wantErr    bool
+	}{
+		{types.MetadataReadWriteVisibility, false, false},
+		{types.MetadataReadWriteVisibility, true, true},
+		{types.MetadataReadOnlyVisibility, true, false},
+		{types.MetadataReadOnlyVisibility, false, true},
+		{types.MetadataHiddenVisibility, true, false},
+		{types.MetadataHiddenVisibility, false, true},
+		{"INVALID", true, true},
+		{"", false, true},
+	}
+	for _, tt := range tests {
+		err := validateMetadataVisibility(tt.visibility, tt.isSystem)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateMetadataVisibility(%q, %t) error = %v, wantErr %t", tt.visibility, tt.isSystem, err, tt.wantErr)
+		}
+	}
+}
+
+func Test_MetadataCarrierHref(t *testing.T) {
+	client := &Client{}
+	tests := []struct {
+		carrier  MetadataCarrier
+		wantHref string
+		wantName string
+	}{
+		{
+			carrier:  &VM{VM: &types.Vm{HREF: "https://vcd/api/vApp/vm-1", Name: "vm1"}, client: client},
+			wantHref: "https://vcd/api/vApp/vm-1",
+			wantName: "vm1",
+		},
+		{
+			carrier:  &OrgVDCNetwork{OrgVDCNetwork: &types.OrgVDCNetwork{HREF: "https://vcd/api/network/1", Name: "net1"}, client: client},
+			wantHref: "https://vcd/api/admin/network/1",
+			wantName: "net1",
+		},
+		{
+			carrier:  &VdcStorageProfile{VdcStorageProfile: &types.VdcStorageProfile{HREF: "https://vcd/api/vdcStorageProfile/1", Name: "*"}, client: client},
+			wantHref: "https://vcd/api/admin/vdcStorageProfile/1",
+			wantName: "*",
+		},
+	}
+	for _, tt := range tests {
+		if tt.carrier.MetadataHref() != tt.wantHref {
+			t.Errorf("expected HREF %s, got %s", tt.wantHref, tt.carrier.MetadataHref())
+		}
+		if tt.carrier.MetadataEntityName() != tt.wantName {
+			t.Errorf("expected name %s, got %s", tt.wantName, tt.carrier.MetadataEntityName())
+		}
+		if tt.carrier.MetadataClient() != client {
+			t.Errorf("unexpected client for %s", tt.wantName)
+		}
+	}
+}
+
+func Test_updateMetadataEntryVisibility(t *testing.T) {
+	var server *httptest.Server
+	var requests []string
+	// responses maps "method path" to the status of the response. Successful mutations return a completed task
+	responses := map[string]int{}
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.Path
+		requests = append(requests, request)
+		status, ok := responses[request]
+		switch {
+		case r.URL.Path == "/api/task/1":
+			w.Header().Set("Content-Type", types.MimeTask)
+			_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="success" href="` + server.URL + `/api/task/1"/>`))
+		case !ok:
+			w.WriteHeader(http.StatusNotImplemented)
+		case status >= http.StatusBadRequest:
+			w.Header().Set("Content-Type", types.MimeError)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(fmt.Sprintf(`<Error xmlns="http://www.vmware.com/vcloud/v1.5" majorErrorCode="%d" `+
+				`minorErrorCode="ERROR" message="%s"/>`, status, http.StatusText(status))))
+		case r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", types.MimeMetaDataValue)
+			_, _ = w.Write([]byte(`<MetadataValue xmlns="http://www.vmware.com/vcloud/v1.5" ` +
+				`xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><Domain visibility="READWRITE">GENERAL</Domain>` +
+				`<TypedValue xsi:type="MetadataStringValue"><Value>value</Value></TypedValue></MetadataValue>`))
+		default:
+			w.Header().Set("Content-Type", types.MimeTask)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="running" href="` + server.URL + `/api/task/1"/>`))
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.APIVersion = "37.0"
+	client := &vcdClient.Client
+	ctx := context.Background()
+	vmHref := server.URL + "/api/vApp/vm-1"
+
+	// Errors other than a missing entry are returned, without changing anything
+	responses["GET /api/vApp/vm-1/metadata/SYSTEM/key"] = http.StatusInternalServerError
+	err = updateMetadataEntryVisibility(ctx, client, vmHref, "key", types.MetadataReadOnlyVisibility, true)
+	if err == nil || ContainsNotFound(err) || !HasAPIErrorStatus(err, http.StatusInternalServerError) {
+		t.Errorf("expected error with status 500, got %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected a single request, got %v", requests)
+	}
+
+	// The entry is moved from the GENERAL domain, and the new entry is removed when the old one cannot be deleted
+	requests = nil
+	responses = map[string]int{
+		"GET /api/vApp/vm-1/metadata/SYSTEM/key":    http.StatusNotFound,
+		"GET /api/vApp/vm-1/metadata/key":           http.StatusOK,
+		"PUT /api/vApp/vm-1/metadata/SYSTEM/key":    http.StatusAccepted,
+		"DELETE /api/vApp/vm-1/metadata/key":        http.StatusBadRequest,
+		"DELETE /api/vApp/vm-1/metadata/SYSTEM/key": http.StatusAccepted,
+	}
+	err = updateMetadataEntryVisibility(ctx, client, vmHref, "key", types.MetadataReadOnlyVisibility, true)
+	if err == nil || !strings.Contains(err.Error(), "changes were rolled back") {
+		t.Errorf("expected error with rollback, got %v", err)
+	}
+	expectedRequests := []string{
+		"GET /api/vApp/vm-1/metadata/SYSTEM/key",
+		"GET /api/vApp/vm-1/metadata/key",
+		"PUT /api/vApp/vm-1/metadata/SYSTEM/key",
+		"GET /api/task/1",
+		"DELETE /api/vApp/vm-1/metadata/key",
+		"DELETE /api/vApp/vm-1/metadata/SYSTEM/key",
+		"GET /api/task/1",
+	}
+	if strings.Join(requests, ",") != strings.Join(expectedRequests, ",") {
+		t.Errorf("expected requests %v, got %v", expectedRequests, requests)
+	}
+
+	// When the rollback fails too, the error reports that the entry exists in both domains
+	responses["DELETE /api/vApp/vm-1/metadata/SYSTEM/key"] = http.StatusBadRequest
+	err = updateMetadataEntryVisibility(ctx, client, vmHref, "key", types.MetadataReadOnlyVisibility, true)
+	if err == nil || !strings.Contains(err.Error(), "exists in both domains") {
+		t.Errorf("expected error reporting the partial change, got %v", err)
+	}
+
+	// A missing entry is reported as not found
+	responses["GET /api/vApp/vm-1/metadata/key"] = http.StatusNotFound
+	err = updateMetadataEntryVisibility(ctx, client, vmHref, "key", types.MetadataReadOnlyVisibility, true)
+	if !ContainsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	// The entity receivers report the name of the entity, keeping the original error
+	vm := NewVM(client)
+	vm.VM.HREF = vmHref
+	vm.VM.Name = "vm-1"
+	err = vm.UpdateMetadataEntryVisibility(ctx, "key", types.MetadataReadOnlyVisibility, true)
+	if !ContainsNotFound(err) || !strings.Contains(err.Error(), "of 'vm-1'") {
+		t.Errorf("expected not found error naming the VM, got %v", err)
+	}
+}
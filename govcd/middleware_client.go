@@ -0,0 +1,103 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Middleware wraps the transport of a client to intercept its requests, e.g. to trace them, collect metrics or
+// redact the payloads that are logged. It receives the next transport of the chain, and returns a transport that
+// must call it to send the request.
+//
+// An OpenTelemetry instrumented transport can be used as a Middleware:
+//
+//	func(next http.RoundTripper) http.RoundTripper { return otelhttp.NewTransport(next) }
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithMiddleware intercepts all the requests of the client, including the ones sent by ExecuteRequest and the
+// OpenAPI helpers, with the given middlewares. The first middleware is the outermost one: it receives the requests
+// first and the responses last.
+//
+// The middlewares wrap the transport configured by the options given before this one. When WithRetryPolicy or
+// WithFailoverEndpoints are given before WithMiddleware, the middlewares see a single request for all the attempts
+// of a call. When they are given after it, the middlewares see every attempt.
+func WithMiddleware(middlewares ...Middleware) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		if len(middlewares) == 0 {
+			return fmt.Errorf("no middleware given")
+		}
+		nextTransport := vcdClient.Client.Http.Transport
+		if nextTransport == nil {
+			nextTransport = http.DefaultTransport
+		}
+		handler := nextTransport
+		for index := len(middlewares) - 1; index >= 0; index-- {
+			if middlewares[index] == nil {
+				return fmt.Errorf("middleware %d is nil", index)
+			}
+			handler = middlewares[index](handler)
+			if handler == nil {
+				return fmt.Errorf("middleware %d returned a nil transport", index)
+			}
+		}
+		vcdClient.Client.Http.Transport = &middlewareRoundTripper{
+			next:    nextTransport,
+			handler: handler,
+		}
+		return nil
+	}
+}
+
+// RequestHook returns a Middleware calling hook before sending each request, e.g. to add tracing headers. The hook
+// receives a copy of the request that it can change. When the hook returns an error, the request is not sent and
+// the error is returned to the caller. A hook reading the body must replace it with one returning the same content.
+func RequestHook(hook func(req *http.Request) error) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			hookedReq := req.Clone(req.Context())
+			if err := hook(hookedReq); err != nil {
+				if req.Body != nil {
+					_ = req.Body.Close()
+				}
+				return nil, fmt.Errorf("request hook rejected %s %s: %w", req.Method, req.URL, err)
+			}
+			return next.RoundTrip(hookedReq)
+		})
+	}
+}
+
+// ResponseHook returns a Middleware calling hook with the outcome of each request: either the response, whose body
+// must not be consumed by the hook, or the transport error
+func ResponseHook(hook func(req *http.Request, resp *http.Response, err error)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			hook(req, resp, err)
+			return resp, err
+		})
+	}
+}
+
+// roundTripperFunc is a function implementing http.RoundTripper
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// middlewareRoundTripper is an http.RoundTripper sending the requests through the middlewares given to
+// WithMiddleware. It keeps the transport wrapped by the middlewares, so that the other options can still find it
+type middlewareRoundTripper struct {
+	next    http.RoundTripper
+	handler http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (mrt *middlewareRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return mrt.handler.RoundTrip(req)
+}
@@ -0,0 +1,153 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_WithMiddleware(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.MimeOrgList)
+		w.Header().Set("X-Seen-Trace-Id", r.Header.Get("X-Trace-Id"))
+		_, _ = w.Write([]byte(`<OrgList xmlns="http://www.vmware.com/vcloud/v1.5"></OrgList>`))
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var calls []string
+	tracing := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next.RoundTrip(req)
+				calls = append(calls, name+" after")
+				return resp, err
+			})
+		}
+	}
+	var seenTraceIds []string
+	vcdClient := NewVCDClient(*serverUrl, true,
+		WithMiddleware(tracing("outer"), tracing("inner")),
+		WithMiddleware(RequestHook(func(req *http.Request) error {
+			if strings.Contains(req.URL.Path, "forbidden") {
+				return fmt.Errorf("path not allowed")
+			}
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return nil
+		}), ResponseHook(func(req *http.Request, resp *http.Response, err error) {
+			if resp != nil {
+				seenTraceIds = append(seenTraceIds, resp.Header.Get("X-Seen-Trace-Id"))
+			}
+		})),
+	)
+	// The options changing the HTTP transport keep working behind the middlewares
+	if _, ok := findRoundTripper[*http.Transport](vcdClient.Client.Http.Transport); !ok {
+		t.Fatalf("HTTP transport not found behind the middlewares")
+	}
+
+	ctx := context.Background()
+	_, err = vcdClient.Client.ExecuteRequest(ctx, server.URL+"/api/org", http.MethodGet, types.MimeOrgList,
+		"error: %s", nil, &types.OrgList{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedCalls := []string{"outer before", "inner before", "inner after", "outer after"}
+	if strings.Join(calls, ",") != strings.Join(expectedCalls, ",") {
+		t.Errorf("got middleware calls %v, expected %v", calls, expectedCalls)
+	}
+	if len(seenTraceIds) != 1 || seenTraceIds[0] != "trace-1" {
+		t.Errorf("got trace IDs %v, expected [trace-1]", seenTraceIds)
+	}
+
+	// A request rejected by a hook is not sent
+	_, err = vcdClient.Client.ExecuteRequest(ctx, server.URL+"/api/forbidden", http.MethodGet, types.MimeOrgList,
+		"error: %s", nil, &types.OrgList{})
+	if err == nil || !strings.Contains(err.Error(), "path not allowed") {
+		t.Fatalf("got error %v, expected request to be rejected by hook", err)
+	}
+	if len(seenTraceIds) != 1 {
+		t.Errorf("rejected request reached the server")
+	}
+}
+
+func Test_WithMiddlewareAndRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryFirst    bool
+		expectedCalls int
+	}{
+		{name: "middlewareSeesCalls", retryFirst: true, expectedCalls: 1},
+		{name: "middlewareSeesAttempts", retryFirst: false, expectedCalls: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &scriptedRoundTripper{outcomes: []scriptedOutcome{{status: 503}, {status: 503}, {status: 200}}}
+			vcdClient := NewVCDClient(url.URL{Scheme: "https", Host: "vcd.example.com", Path: "/api"}, true)
+			vcdClient.Client.Http.Transport = next
+
+			calls := 0
+			counter := ResponseHook(func(req *http.Request, resp *http.Response, err error) {
+				calls++
+			})
+			options := []VCDClientOption{WithMiddleware(counter), WithRetryPolicy(RetryPolicy{InitialInterval: time.Millisecond})}
+			if tt.retryFirst {
+				options[0], options[1] = options[1], options[0]
+			}
+			for _, option := range options {
+				if err := option(vcdClient); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "https://vcd.example.com/api/org", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp, err := vcdClient.Client.Http.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("got status %d, expected 200", resp.StatusCode)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("got %d middleware calls, expected %d", calls, tt.expectedCalls)
+			}
+		})
+	}
+}
+
+func Test_WithMiddlewareErrors(t *testing.T) {
+	vcdClient := NewVCDClient(url.URL{Scheme: "https", Host: "vcd.example.com", Path: "/api"}, true)
+	err := WithMiddleware()(vcdClient)
+	if err == nil {
+		t.Errorf("expected error without middlewares")
+	}
+	err = WithMiddleware(nil)(vcdClient)
+	if err == nil {
+		t.Errorf("expected error with nil middleware")
+	}
+	err = WithMiddleware(func(next http.RoundTripper) http.RoundTripper { return nil })(vcdClient)
+	if err == nil {
+		t.Errorf("expected error with middleware returning nil transport")
+	}
+}
@@ -0,0 +1,330 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Types of the nodes of a NetworkTopology
+const (
+	NetworkTopologyNodeExternalNetwork = "externalNetwork"
+	NetworkTopologyNodeProviderGateway = "providerGateway" // External network backed by an NSX-T Tier-0 router or VRF
+	NetworkTopologyNodeEdgeGateway     = "edgeGateway"
+	NetworkTopologyNodeOrgNetwork      = "orgNetwork"
+	NetworkTopologyNodeVApp            = "vApp"
+)
+
+// Types of the links of a NetworkTopology
+const (
+	NetworkTopologyLinkUplink     = "uplink"     // From an edge gateway to its external networks or provider gateway
+	NetworkTopologyLinkAttachment = "attachment" // From an Org VDC network or a vApp to the network it is connected to
+)
+
+// NetworkTopologyNode is an element of the network topology
+type NetworkTopologyNode struct {
+	Id         string            `json:"id"`
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// NetworkTopologyLink is a connection between two nodes of the network topology. It goes from the node closest to
+// the workloads (From) to the one closest to the provider networks (To)
+type NetworkTopologyLink struct {
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Type       string            `json:"type"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// NetworkTopology is a graph of the networking elements used by an Org or by a VDC Group, and of the vApps
+// connected to them
+type NetworkTopology struct {
+	Name  string                 `json:"name"`
+	Nodes []*NetworkTopologyNode `json:"nodes"`
+	Links []*NetworkTopologyLink `json:"links"`
+}
+
+// GetNetworkTopology builds the network topology of the Org: its edge gateways with their uplinks, its Org VDC
+// networks (including the ones shared through VDC Groups) and the vApps of its VDCs.
+//
+// Note. The external networks and provider gateways are identified from the edge gateway uplinks and the direct Org
+// VDC networks, which doesn't require System Administrator privileges. The first uplink of an NSX-T edge gateway is
+// reported as provider gateway, as VCD requires it to be backed by a Tier-0 router or VRF.
+func (org *Org) GetNetworkTopology(ctx context.Context) (*NetworkTopology, error) {
+	edgeGateways, err := getAllOpenApiEdgeGateways(ctx, org.client, queryParameterFilterAnd("orgRef.id=="+org.Org.ID, nil))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving edge gateways of Org '%s': %w", org.Org.Name, err)
+	}
+	orgNetworks, err := org.GetAllOpenApiOrgVdcNetworks(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving Org VDC networks of Org '%s': %w", org.Org.Name, err)
+	}
+	vdcs, err := org.QueryOrgVdcList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VDCs of Org '%s': %w", org.Org.Name, err)
+	}
+	var vdcIds []string
+	for _, vdc := range vdcs {
+		vdcIds = append(vdcIds, vdc.HREF)
+	}
+
+	return buildNetworkTopology(ctx, org.client, org.Org.Name, edgeGateways, orgNetworks, vdcIds)
+}
+
+// GetNetworkTopology builds the network topology of the VDC Group: its edge gateways with their uplinks, its Org VDC
+// networks and the vApps of its local participating VDCs. Networks of the participating VDCs that are used by the vApps
+// are also included, even if they don't belong to the VDC Group.
+//
+// Note. The external networks and provider gateways are identified as described for Org.GetNetworkTopology
+func (vdcGroup *VdcGroup) GetNetworkTopology(ctx context.Context) (*NetworkTopology, error) {
+	edgeGateways, err := getAllOpenApiEdgeGateways(ctx, vdcGroup.client,
+		queryParameterFilterAnd("ownerRef.id=="+vdcGroup.VdcGroup.Id, nil))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving edge gateways of VDC Group '%s': %w", vdcGroup.VdcGroup.Name, err)
+	}
+	orgNetworks, err := vdcGroup.GetAllOpenApiOrgVdcNetworks(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving Org VDC networks of VDC Group '%s': %w", vdcGroup.VdcGroup.Name, err)
+	}
+	var vdcIds []string
+	for _, participatingVdc := range vdcGroup.VdcGroup.ParticipatingOrgVdcs {
+		if !participatingVdc.RemoteOrg {
+			vdcIds = append(vdcIds, participatingVdc.VdcRef.ID)
+		}
+	}
+
+	return buildNetworkTopology(ctx, vdcGroup.client, vdcGroup.VdcGroup.Name, edgeGateways, orgNetworks, vdcIds)
+}
+
+// ToJson returns the network topology as indented JSON
+func (topology *NetworkTopology) ToJson() ([]byte, error) {
+	return json.MarshalIndent(topology, "", "  ")
+}
+
+// ToDot returns the network topology in the DOT language of Graphviz, with one shape per node type
+func (topology *NetworkTopology) ToDot() string {
+	var builder strings.Builder
+	builder.WriteString("digraph " + dotQuote(topology.Name) + " {\n")
+	builder.WriteString("  rankdir=BT;\n")
+	for _, node := range topology.Nodes {
+		builder.WriteString(fmt.Sprintf("  %s [label=%s shape=%s];\n", dotQuote(node.Id),
+			dotQuote(node.Name+"\n("+node.Type+")"), networkTopologyDotShapes[node.Type]))
+	}
+	for _, link := range topology.Links {
+		builder.WriteString(fmt.Sprintf("  %s -> %s [label=%s];\n", dotQuote(link.From), dotQuote(link.To),
+			dotQuote(link.Type)))
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// networkTopologyDotShapes are the DOT shapes used for each node type
+var networkTopologyDotShapes = map[string]string{
+	NetworkTopologyNodeExternalNetwork: "ellipse",
+	NetworkTopologyNodeProviderGateway: "doubleoctagon",
+	NetworkTopologyNodeEdgeGateway:     "octagon",
+	NetworkTopologyNodeOrgNetwork:      "ellipse",
+	NetworkTopologyNodeVApp:            "box",
+}
+
+// networkTopologyNodeOrder sorts the nodes from the provider networks to the workloads
+var networkTopologyNodeOrder = map[string]int{
+	NetworkTopologyNodeProviderGateway: 0,
+	NetworkTopologyNodeExternalNetwork: 1,
+	NetworkTopologyNodeEdgeGateway:     2,
+	NetworkTopologyNodeOrgNetwork:      3,
+	NetworkTopologyNodeVApp:            4,
+}
+
+// dotQuote returns a quoted DOT identifier
+func dotQuote(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(text) + `"`
+}
+
+// buildNetworkTopology adds the vApps of the given VDCs to the topology made of edge gateways and Org VDC networks
+func buildNetworkTopology(ctx context.Context, client *Client, name string, edgeGateways []*types.OpenAPIEdgeGateway,
+	orgNetworks []*OpenApiOrgVdcNetwork, vdcIds []string) (*NetworkTopology, error) {
+	builder := newNetworkTopologyBuilder(name)
+	builder.addEdgeGateways(edgeGateways)
+	for _, orgNetwork := range orgNetworks {
+		builder.addOrgNetwork(orgNetwork.OpenApiOrgVdcNetwork)
+	}
+
+	vapps, err := client.QueryVappList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, vappRecord := range vapps {
+		if !containsEqualId(vdcIds, vappRecord.VdcHREF) {
+			continue
+		}
+		vapp := NewVApp(client)
+		vapp.VApp.HREF = vappRecord.HREF
+		networkConfig, err := vapp.GetNetworkConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving networks of vApp '%s': %w", vappRecord.Name, err)
+		}
+		builder.addVapp(vappRecord.HREF, vappRecord.Name, networkConfig)
+	}
+
+	return builder.build(), nil
+}
+
+// containsEqualId checks whether one of the IDs or HREFs refers to the same entity as the given one
+func containsEqualId(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if extractUuid(candidate) == extractUuid(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkTopologyBuilder collects the nodes and links of a NetworkTopology, merging the nodes found more than once
+type networkTopologyBuilder struct {
+	topology *NetworkTopology
+	nodes    map[string]*NetworkTopologyNode // Nodes by UUID, as the same entity is referred to by URN or by HREF
+}
+
+func newNetworkTopologyBuilder(name string) *networkTopologyBuilder {
+	return &networkTopologyBuilder{
+		topology: &NetworkTopology{Name: name},
+		nodes:    make(map[string]*NetworkTopologyNode),
+	}
+}
+
+// addNode adds a node, or returns the existing one with the same UUID. An existing node is completed with the name
+// and attributes that were unknown when it was added
+func (builder *networkTopologyBuilder) addNode(id, name, nodeType string, attributes map[string]string) *NetworkTopologyNode {
+	node, exists := builder.nodes[extractUuid(id)]
+	if !exists {
+		node = &NetworkTopologyNode{Id: id, Type: nodeType}
+		builder.nodes[extractUuid(id)] = node
+		builder.topology.Nodes = append(builder.topology.Nodes, node)
+	}
+	if node.Name == "" {
+		node.Name = name
+	}
+	for key, value := range attributes {
+		if node.Attributes == nil {
+			node.Attributes = make(map[string]string)
+		}
+		if value != "" {
+			node.Attributes[key] = value
+		}
+	}
+	return node
+}
+
+func (builder *networkTopologyBuilder) addLink(from, to *NetworkTopologyNode, linkType string, attributes map[string]string) {
+	builder.topology.Links = append(builder.topology.Links, &NetworkTopologyLink{
+		From:       from.Id,
+		To:         to.Id,
+		Type:       linkType,
+		Attributes: attributes,
+	})
+}
+
+// addEdgeGateways adds the edge gateways and the external networks or provider gateways of their uplinks
+func (builder *networkTopologyBuilder) addEdgeGateways(edgeGateways []*types.OpenAPIEdgeGateway) {
+	for _, edgeGateway := range edgeGateways {
+		attributes := map[string]string{}
+		if edgeGateway.OwnerRef != nil {
+			attributes["ownerId"] = edgeGateway.OwnerRef.ID
+			attributes["ownerName"] = edgeGateway.OwnerRef.Name
+		}
+		isNsxt := false
+		if edgeGateway.GatewayBacking != nil {
+			attributes["backingType"] = edgeGateway.GatewayBacking.GatewayType
+			isNsxt = edgeGateway.GatewayBacking.GatewayType == "NSXT_BACKED"
+		}
+		edgeNode := builder.addNode(edgeGateway.ID, edgeGateway.Name, NetworkTopologyNodeEdgeGateway, attributes)
+
+		for index, uplink := range edgeGateway.EdgeGatewayUplinks {
+			uplinkType := NetworkTopologyNodeExternalNetwork
+			if isNsxt && index == 0 {
+				uplinkType = NetworkTopologyNodeProviderGateway
+			}
+			uplinkNode := builder.addNode(uplink.UplinkID, uplink.UplinkName, uplinkType, nil)
+			builder.addLink(edgeNode, uplinkNode, NetworkTopologyLinkUplink, nil)
+		}
+	}
+}
+
+// addOrgNetwork adds an Org VDC network and its connection to an edge gateway or to an external network
+func (builder *networkTopologyBuilder) addOrgNetwork(orgNetwork *types.OpenApiOrgVdcNetwork) {
+	attributes := map[string]string{"networkType": orgNetwork.NetworkType}
+	if orgNetwork.OwnerRef != nil {
+		attributes["ownerId"] = orgNetwork.OwnerRef.ID
+		attributes["ownerName"] = orgNetwork.OwnerRef.Name
+	}
+	networkNode := builder.addNode(orgNetwork.ID, orgNetwork.Name, NetworkTopologyNodeOrgNetwork, attributes)
+
+	if orgNetwork.Connection != nil && orgNetwork.Connection.RouterRef.ID != "" {
+		edgeNode := builder.addNode(orgNetwork.Connection.RouterRef.ID, orgNetwork.Connection.RouterRef.Name,
+			NetworkTopologyNodeEdgeGateway, nil)
+		builder.addLink(networkNode, edgeNode, NetworkTopologyLinkAttachment,
+			map[string]string{"connectionType": orgNetwork.Connection.ConnectionType})
+	}
+	if orgNetwork.ParentNetwork != nil && orgNetwork.ParentNetwork.ID != "" {
+		externalNode := builder.addNode(orgNetwork.ParentNetwork.ID, orgNetwork.ParentNetwork.Name,
+			NetworkTopologyNodeExternalNetwork, nil)
+		builder.addLink(networkNode, externalNode, NetworkTopologyLinkAttachment, nil)
+	}
+}
+
+// addVapp adds a vApp and its attachments to the Org VDC networks. Isolated vApp networks, which have no parent
+// network, are not part of the topology
+func (builder *networkTopologyBuilder) addVapp(href, name string, networkConfig *types.NetworkConfigSection) {
+	vappNode := builder.addNode("urn:vcloud:vapp:"+extractUuid(href), name, NetworkTopologyNodeVApp, nil)
+	if networkConfig == nil {
+		return
+	}
+	for _, vappNetwork := range networkConfig.NetworkConfig {
+		if vappNetwork.Configuration == nil || vappNetwork.Configuration.ParentNetwork == nil {
+			continue
+		}
+		parentNetwork := vappNetwork.Configuration.ParentNetwork
+		networkNode := builder.addNode("urn:vcloud:network:"+extractUuid(parentNetwork.HREF), parentNetwork.Name,
+			NetworkTopologyNodeOrgNetwork, nil)
+		builder.addLink(vappNode, networkNode, NetworkTopologyLinkAttachment, map[string]string{
+			"networkName": vappNetwork.NetworkName,
+			"fenceMode":   vappNetwork.Configuration.FenceMode,
+		})
+	}
+}
+
+// build returns the topology with nodes sorted from the provider networks to the workloads, and links sorted by
+// their ends, so that the output is stable
+func (builder *networkTopologyBuilder) build() *NetworkTopology {
+	topology := builder.topology
+	sort.SliceStable(topology.Nodes, func(i, j int) bool {
+		first, second := topology.Nodes[i], topology.Nodes[j]
+		if first.Type != second.Type {
+			return networkTopologyNodeOrder[first.Type] < networkTopologyNodeOrder[second.Type]
+		}
+		if first.Name != second.Name {
+			return first.Name < second.Name
+		}
+		return first.Id < second.Id
+	})
+	sort.SliceStable(topology.Links, func(i, j int) bool {
+		first, second := topology.Links[i], topology.Links[j]
+		if first.From != second.From {
+			return first.From < second.From
+		}
+		return first.To < second.To
+	})
+	return topology
+}
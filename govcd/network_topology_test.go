@@ -0,0 +1,62 @@
+//go:build network || nsxt || functional || openapi || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+// Test_OrgNetworkTopology checks that the topology of the Org contains the NSX-T edge gateway of the configuration and
+// its uplink, and that it can be exported
+func (vcd *TestVCD) Test_OrgNetworkTopology(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	edge, err := vcd.nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	topology, err := vcd.org.GetNetworkTopology(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(topology.Name, Equals, vcd.org.Org.Name)
+
+	nodes := make(map[string]*NetworkTopologyNode)
+	for _, node := range topology.Nodes {
+		nodes[node.Id] = node
+	}
+	edgeNode := nodes[edge.EdgeGateway.ID]
+	check.Assert(edgeNode, NotNil)
+	check.Assert(edgeNode.Type, Equals, NetworkTopologyNodeEdgeGateway)
+	check.Assert(edgeNode.Name, Equals, edge.EdgeGateway.Name)
+
+	uplinkId := edge.EdgeGateway.EdgeGatewayUplinks[0].UplinkID
+	check.Assert(nodes[uplinkId], NotNil)
+	check.Assert(nodes[uplinkId].Type, Equals, NetworkTopologyNodeProviderGateway)
+	foundUplink := false
+	for _, link := range topology.Links {
+		check.Assert(nodes[link.From], NotNil)
+		check.Assert(nodes[link.To], NotNil)
+		if link.From == edgeNode.Id && link.To == uplinkId {
+			foundUplink = true
+			check.Assert(link.Type, Equals, NetworkTopologyLinkUplink)
+		}
+	}
+	check.Assert(foundUplink, Equals, true)
+
+	jsonTopology, err := topology.ToJson()
+	check.Assert(err, IsNil)
+	var decodedTopology NetworkTopology
+	err = json.Unmarshal(jsonTopology, &decodedTopology)
+	check.Assert(err, IsNil)
+	check.Assert(len(decodedTopology.Nodes), Equals, len(topology.Nodes))
+	check.Assert(len(decodedTopology.Links), Equals, len(topology.Links))
+
+	check.Assert(strings.Contains(topology.ToDot(), `"`+edge.EdgeGateway.ID+`"`), Equals, true)
+}
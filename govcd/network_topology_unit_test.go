@@ -0,0 +1,89 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_networkTopologyBuilder(t *testing.T) {
+	builder := newNetworkTopologyBuilder("org1")
+	builder.addEdgeGateways([]*types.OpenAPIEdgeGateway{
+		{
+			ID:             "urn:vcloud:gateway:11111111-1111-1111-1111-111111111111",
+			Name:           "edge1",
+			GatewayBacking: &types.OpenAPIEdgeGatewayBacking{GatewayType: "NSXT_BACKED"},
+			EdgeGatewayUplinks: []types.EdgeGatewayUplinks{
+				{UplinkID: "urn:vcloud:network:22222222-2222-2222-2222-222222222222", UplinkName: "t0"},
+				{UplinkID: "urn:vcloud:network:33333333-3333-3333-3333-333333333333", UplinkName: "segment"},
+			},
+		},
+	})
+	builder.addOrgNetwork(&types.OpenApiOrgVdcNetwork{
+		ID:          "urn:vcloud:network:44444444-4444-4444-4444-444444444444",
+		Name:        "routed",
+		NetworkType: "NAT_ROUTED",
+		Connection: &types.Connection{
+			RouterRef: types.OpenApiReference{ID: "urn:vcloud:gateway:11111111-1111-1111-1111-111111111111"},
+		},
+	})
+	builder.addVapp("https://vcd.example.com/api/vApp/vapp-55555555-5555-5555-5555-555555555555", "vapp1",
+		&types.NetworkConfigSection{
+			NetworkConfig: []types.VAppNetworkConfiguration{
+				{
+					NetworkName: "routed",
+					Configuration: &types.NetworkConfiguration{
+						FenceMode: types.FenceModeBridged,
+						ParentNetwork: &types.Reference{
+							HREF: "https://vcd.example.com/api/network/44444444-4444-4444-4444-444444444444",
+							Name: "routed",
+						},
+					},
+				},
+				{
+					NetworkName:   "isolated",
+					Configuration: &types.NetworkConfiguration{FenceMode: types.FenceModeIsolated},
+				},
+			},
+		})
+	topology := builder.build()
+
+	var nodes []string
+	for _, node := range topology.Nodes {
+		nodes = append(nodes, node.Type+":"+node.Name)
+	}
+	wantNodes := "providerGateway:t0,externalNetwork:segment,edgeGateway:edge1,orgNetwork:routed,vApp:vapp1"
+	if strings.Join(nodes, ",") != wantNodes {
+		t.Errorf("got nodes %v, want %s", nodes, wantNodes)
+	}
+	if len(topology.Links) != 4 {
+		t.Fatalf("got %d links, want 4", len(topology.Links))
+	}
+	vappLink := topology.Links[len(topology.Links)-1]
+	if vappLink.From != "urn:vcloud:vapp:55555555-5555-5555-5555-555555555555" ||
+		vappLink.To != "urn:vcloud:network:44444444-4444-4444-4444-444444444444" ||
+		vappLink.Attributes["networkName"] != "routed" {
+		t.Errorf("unexpected vApp link %+v", vappLink)
+	}
+
+	dot := topology.ToDot()
+	if !strings.HasPrefix(dot, `digraph "org1" {`) ||
+		!strings.Contains(dot, `"urn:vcloud:network:44444444-4444-4444-4444-444444444444" -> "urn:vcloud:gateway:11111111-1111-1111-1111-111111111111" [label="attachment"];`) {
+		t.Errorf("unexpected DOT output:\n%s", dot)
+	}
+}
+
+func Test_dotQuote(t *testing.T) {
+	got := dotQuote("a \"b\"\\c\nd")
+	want := `"a \"b\"\\c\nd"`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -63,7 +63,7 @@ func (vcdClient *VCDClient) GetAlbCloudByName(ctx context.Context, name string)
 
 	albClouds, err := vcdClient.GetAllAlbClouds(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with Name '%s': %w", name, err)
 	}
 
 	if len(albClouds) == 0 {
@@ -88,7 +88,7 @@ func (vcdClient *VCDClient) GetAlbCloudById(ctx context.Context, id string) (*Ns
 
 	albCloud, err := vcdClient.GetAllAlbClouds(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with ID '%s': %s", id, err)
+		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with ID '%s': %w", id, err)
 	}
 
 	if len(albCloud) == 0 {
@@ -98,6 +98,18 @@ func (vcdClient *VCDClient) GetAlbCloudById(ctx context.Context, id string) (*Ns
 	return albCloud[0], nil
 }
 
+// GetAlbCloudByNameOrId retrieves an ALB Cloud by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vcdClient *VCDClient) GetAlbCloudByNameOrId(ctx context.Context, identifier string) (*NsxtAlbCloud, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) { return vcdClient.GetAlbCloudByName(ctx, name) }
+	getById := func(id string, refresh bool) (interface{}, error) { return vcdClient.GetAlbCloudById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtAlbCloud), err
+}
+
 // CreateAlbCloud creates NSX-T ALB Cloud
 func (vcdClient *VCDClient) CreateAlbCloud(ctx context.Context, albCloudConfig *types.NsxtAlbCloud) (*NsxtAlbCloud, error) {
 	client := vcdClient.Client
@@ -123,7 +135,7 @@ func (vcdClient *VCDClient) CreateAlbCloud(ctx context.Context, albCloudConfig *
 
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, albCloudConfig, returnObject.NsxtAlbCloud, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Cloud: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Cloud: %w", err)
 	}
 
 	return returnObject, nil
@@ -156,7 +168,7 @@ func (vcdClient *VCDClient) CreateAlbCloud(ctx context.Context, albCloudConfig *
 //
 //	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, albCloudConfig, responseAlbCloud.NsxtAlbCloud, nil)
 //	if err != nil {
-//		return nil, fmt.Errorf("error updating NSX-T ALB Cloud: %s", err)
+//		return nil, fmt.Errorf("error updating NSX-T ALB Cloud: %w", err)
 //	}
 //
 //	return responseAlbCloud, nil
@@ -182,7 +194,7 @@ func (nsxtAlbCloud *NsxtAlbCloud) Delete(ctx context.Context) error {
 
 	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Cloud: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Cloud: %w", err)
 	}
 
 	return nil
@@ -65,7 +65,7 @@ func (vcdClient *VCDClient) GetAlbControllerByName(ctx context.Context, name str
 
 	controllers, err := vcdClient.GetAllAlbControllers(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error reading ALB Controller with Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error reading ALB Controller with Name '%s': %w", name, err)
 	}
 
 	if len(controllers) == 0 {
@@ -115,6 +115,20 @@ func (vcdClient *VCDClient) GetAlbControllerById(ctx context.Context, id string)
 	return wrappedResponse, nil
 }
 
+// GetAlbControllerByNameOrId retrieves an ALB Controller by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vcdClient *VCDClient) GetAlbControllerByNameOrId(ctx context.Context, identifier string) (*NsxtAlbController, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return vcdClient.GetAlbControllerByName(ctx, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) { return vcdClient.GetAlbControllerById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtAlbController), err
+}
+
 // GetAlbControllerByUrl returns configured ALB Controller by URL
 //
 // Note. Filtering is performed on client side.
@@ -122,7 +136,7 @@ func (vcdClient *VCDClient) GetAlbControllerByUrl(ctx context.Context, url strin
 	// Ideally this function could filter on VCD side, but API does not support filtering on URL
 	controllers, err := vcdClient.GetAllAlbControllers(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading ALB Controller with Url '%s': %s", url, err)
+		return nil, fmt.Errorf("error reading ALB Controller with Url '%s': %w", url, err)
 	}
 
 	// Search for controllers
@@ -169,8 +183,9 @@ func (vcdClient *VCDClient) CreateNsxtAlbController(ctx context.Context, albCont
 
 	err = client.OpenApiPostItem(ctx, apiVersion, urlRef, nil, albControllerConfig, returnObject.NsxtAlbController, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Controller: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Controller: %w", err)
 	}
+	vcdClient.Client.invalidateCapability(FeatureAlb)
 
 	return returnObject, nil
 }
@@ -200,7 +215,7 @@ func (nsxtAlbController *NsxtAlbController) Update(ctx context.Context, albContr
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, albControllerConfig, responseAlbController.NsxtAlbController, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Controller: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Controller: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -226,8 +241,9 @@ func (nsxtAlbController *NsxtAlbController) Delete(ctx context.Context) error {
 
 	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Controller: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Controller: %w", err)
 	}
+	nsxtAlbController.vcdClient.Client.invalidateCapability(FeatureAlb)
 
 	return nil
 }
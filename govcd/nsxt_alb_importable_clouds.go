@@ -65,7 +65,7 @@ func (vcdClient *VCDClient) GetAllAlbImportableClouds(ctx context.Context, paren
 func (vcdClient *VCDClient) GetAlbImportableCloudByName(ctx context.Context, parentAlbControllerUrn, name string) (*NsxtAlbImportableCloud, error) {
 	albImportableClouds, err := vcdClient.GetAllAlbImportableClouds(ctx, parentAlbControllerUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by Name '%s': %w", name, err)
 	}
 
 	// Filtering by Name is not supported by API therefore it must be filtered on client side
@@ -91,7 +91,7 @@ func (vcdClient *VCDClient) GetAlbImportableCloudByName(ctx context.Context, par
 func (vcdClient *VCDClient) GetAlbImportableCloudById(ctx context.Context, parentAlbControllerUrn, id string) (*NsxtAlbImportableCloud, error) {
 	albImportableClouds, err := vcdClient.GetAllAlbImportableClouds(ctx, parentAlbControllerUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by ID '%s': %s", id, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by ID '%s': %w", id, err)
 	}
 
 	// Filtering by ID is not supported by API therefore it must be filtered on client side
@@ -66,7 +66,7 @@ func (vcdClient *VCDClient) GetAllAlbImportableServiceEngineGroups(ctx context.C
 func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupByName(ctx context.Context, parentAlbCloudUrn, name string) (*NsxtAlbImportableServiceEngineGroups, error) {
 	albClouds, err := vcdClient.GetAllAlbImportableServiceEngineGroups(ctx, parentAlbCloudUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %w", name, err)
 	}
 
 	// Filtering by Name is not supported by API therefore it must be filtered on client side
@@ -92,7 +92,7 @@ func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupByName(ctx context
 func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupById(ctx context.Context, parentAlbCloudUrn, id string) (*NsxtAlbImportableServiceEngineGroups, error) {
 	albClouds, err := vcdClient.GetAllAlbImportableServiceEngineGroups(ctx, parentAlbCloudUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by ID '%s': %s", id, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by ID '%s': %w", id, err)
 	}
 
 	// Filtering by ID is not supported by API therefore it must be filtered on client side
@@ -156,7 +156,7 @@ func (nsxtAlbCloud *NsxtAlbCloud) GetAllAlbImportableServiceEngineGroups(ctx con
 func (nsxtAlbCloud *NsxtAlbCloud) GetAlbImportableServiceEngineGroupByName(ctx context.Context, parentAlbCloudUrn, name string) (*NsxtAlbImportableServiceEngineGroups, error) {
 	albClouds, err := nsxtAlbCloud.vcdClient.GetAllAlbImportableServiceEngineGroups(ctx, parentAlbCloudUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %w", name, err)
 	}
 
 	// Filtering by ID is not supported by API therefore it must be filtered on client side
@@ -182,7 +182,7 @@ func (nsxtAlbCloud *NsxtAlbCloud) GetAlbImportableServiceEngineGroupByName(ctx c
 func (nsxtAlbCloud *NsxtAlbCloud) GetAlbImportableServiceEngineGroupById(ctx context.Context, parentAlbCloudUrn, id string) (*NsxtAlbImportableServiceEngineGroups, error) {
 	albClouds, err := nsxtAlbCloud.vcdClient.GetAllAlbImportableServiceEngineGroups(ctx, parentAlbCloudUrn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by ID '%s': %s", id, err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by ID '%s': %w", id, err)
 	}
 
 	// Filtering by ID is not supported by API therefore it must be filtered on client side
@@ -21,7 +21,7 @@ type NsxtAlbPool struct {
 }
 
 // GetAllAlbPoolSummaries retrieves partial information for type `NsxtAlbPool`, but it is the only way to retrieve all ALB
-// pools for Edge Gateway
+// pools for Edge Gateway. When NSX-T ALB is not available, the error is a FeatureNotAvailableError
 func (vcdClient *VCDClient) GetAllAlbPoolSummaries(ctx context.Context, edgeGatewayId string, queryParameters url.Values) ([]*NsxtAlbPool, error) {
 	client := vcdClient.Client
 
@@ -39,7 +39,7 @@ func (vcdClient *VCDClient) GetAllAlbPoolSummaries(ctx context.Context, edgeGate
 	typeResponses := []*types.NsxtAlbPool{{}}
 	err = client.OpenApiGetAllItems(ctx, apiVersion, urlRef, queryParameters, &typeResponses, nil)
 	if err != nil {
-		return nil, err
+		return nil, client.featureError(ctx, FeatureAlb, err)
 	}
 
 	// Wrap all typeResponses into NsxtAlbPool types with client
@@ -59,7 +59,7 @@ func (vcdClient *VCDClient) GetAllAlbPoolSummaries(ctx context.Context, edgeGate
 func (vcdClient *VCDClient) GetAllAlbPools(ctx context.Context, edgeGatewayId string, queryParameters url.Values) ([]*NsxtAlbPool, error) {
 	allAlbPoolSummaries, err := vcdClient.GetAllAlbPoolSummaries(ctx, edgeGatewayId, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all ALB Pool summaries: %s", err)
+		return nil, fmt.Errorf("error retrieving all ALB Pool summaries: %w", err)
 	}
 
 	// Loop over all Summaries and retrieve complete information
@@ -68,7 +68,7 @@ func (vcdClient *VCDClient) GetAllAlbPools(ctx context.Context, edgeGatewayId st
 
 		allAlbPools[index], err = vcdClient.GetAlbPoolById(ctx, allAlbPoolSummaries[index].NsxtAlbPool.ID)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving complete ALB Pool: %s", err)
+			return nil, fmt.Errorf("error retrieving complete ALB Pool: %w", err)
 		}
 
 	}
@@ -83,7 +83,7 @@ func (vcdClient *VCDClient) GetAlbPoolByName(ctx context.Context, edgeGatewayId
 
 	allAlbPools, err := vcdClient.GetAllAlbPools(ctx, edgeGatewayId, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving ALB Pool with Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error retrieving ALB Pool with Name '%s': %w", name, err)
 	}
 
 	if len(allAlbPools) == 0 {
@@ -130,6 +130,20 @@ func (vcdClient *VCDClient) GetAlbPoolById(ctx context.Context, id string) (*Nsx
 	return wrappedResponse, nil
 }
 
+// GetAlbPoolByNameOrId retrieves an ALB Pool in a given Edge Gateway by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vcdClient *VCDClient) GetAlbPoolByNameOrId(ctx context.Context, edgeGatewayId, identifier string) (*NsxtAlbPool, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return vcdClient.GetAlbPoolByName(ctx, edgeGatewayId, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) { return vcdClient.GetAlbPoolById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtAlbPool), err
+}
+
 // CreateNsxtAlbPool creates NSX-T ALB Pool based on supplied configuration
 func (vcdClient *VCDClient) CreateNsxtAlbPool(ctx context.Context, albPoolConfig *types.NsxtAlbPool) (*NsxtAlbPool, error) {
 	client := vcdClient.Client
@@ -152,7 +166,7 @@ func (vcdClient *VCDClient) CreateNsxtAlbPool(ctx context.Context, albPoolConfig
 
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, albPoolConfig, returnObject.NsxtAlbPool, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Pool: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Pool: %w", err)
 	}
 
 	return returnObject, nil
@@ -183,7 +197,7 @@ func (nsxtAlbPool *NsxtAlbPool) Update(ctx context.Context, albPoolConfig *types
 
 	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, albPoolConfig, responseAlbController.NsxtAlbPool, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Pool: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Pool: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -209,7 +223,7 @@ func (nsxtAlbPool *NsxtAlbPool) Delete(ctx context.Context) error {
 
 	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Pool: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Pool: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,189 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NsxtAlbSystemDefinedProfiles lists the types of the system-defined profiles that can be used by NSX-T ALB Pools
+// and Virtual Services placed on a Service Engine Group
+type NsxtAlbSystemDefinedProfiles struct {
+	// HealthMonitorTypes can be used in NsxtAlbPool.SetHealthMonitors
+	HealthMonitorTypes []string
+	// PersistenceProfileTypes can be used in NsxtAlbPool.SetPersistenceProfile
+	PersistenceProfileTypes []string
+	// ApplicationProfileTypes can be used in types.NsxtAlbVirtualServiceApplicationProfile
+	ApplicationProfileTypes []string
+	// TcpUdpProfileTypes can be used in types.NsxtAlbVirtualServicePortTcpUdpProfile
+	TcpUdpProfileTypes []string
+}
+
+// nsxtAlbHealthMonitorTypes are the health monitor types supported by VCD
+var nsxtAlbHealthMonitorTypes = []string{
+	types.NsxtAlbHealthMonitorHttp,
+	types.NsxtAlbHealthMonitorHttps,
+	types.NsxtAlbHealthMonitorTcp,
+	types.NsxtAlbHealthMonitorUdp,
+	types.NsxtAlbHealthMonitorPing,
+}
+
+// nsxtAlbPersistenceProfileTypes are the persistence profile types supported by VCD
+var nsxtAlbPersistenceProfileTypes = []string{
+	types.NsxtAlbPersistenceClientIp,
+	types.NsxtAlbPersistenceHttpCookie,
+	types.NsxtAlbPersistenceCustomHttpHeader,
+	types.NsxtAlbPersistenceAppCookie,
+	types.NsxtAlbPersistenceTls,
+}
+
+// GetSystemDefinedProfiles returns the types of the system-defined profiles available to Pools and Virtual Services
+// using the Service Engine Group. The L4_TLS application profile is only available with the PREMIUM feature set.
+//
+// Note. VCD does not expose the profiles of a Service Engine Group: the list is based on the feature set of the
+// Service Engine Group (SupportedFeatureSet, VCD 10.4+). When the feature set is not reported, the STANDARD feature
+// set is assumed.
+func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) GetSystemDefinedProfiles() *NsxtAlbSystemDefinedProfiles {
+	profiles := &NsxtAlbSystemDefinedProfiles{
+		HealthMonitorTypes:      append([]string{}, nsxtAlbHealthMonitorTypes...),
+		PersistenceProfileTypes: append([]string{}, nsxtAlbPersistenceProfileTypes...),
+		ApplicationProfileTypes: []string{
+			types.NsxtAlbApplicationProfileHttp,
+			types.NsxtAlbApplicationProfileHttps,
+			types.NsxtAlbApplicationProfileL4,
+		},
+		TcpUdpProfileTypes: []string{
+			types.NsxtAlbTcpUdpProfileTcpProxy,
+			types.NsxtAlbTcpUdpProfileTcpFastPath,
+			types.NsxtAlbTcpUdpProfileUdpFastPath,
+		},
+	}
+	if nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.SupportedFeatureSet == types.NsxtAlbFeatureSetPremium {
+		profiles.ApplicationProfileTypes = append(profiles.ApplicationProfileTypes, types.NsxtAlbApplicationProfileL4Tls)
+	}
+	return profiles
+}
+
+// NewNsxtAlbPoolHealthMonitors returns the system-defined health monitors of the given types (see
+// types.NsxtAlbHealthMonitor* constants)
+func NewNsxtAlbPoolHealthMonitors(monitorTypes ...string) ([]types.NsxtAlbPoolHealthMonitor, error) {
+	healthMonitors := make([]types.NsxtAlbPoolHealthMonitor, 0, len(monitorTypes))
+	for _, monitorType := range monitorTypes {
+		if !contains(monitorType, nsxtAlbHealthMonitorTypes) {
+			return nil, fmt.Errorf("invalid NSX-T ALB health monitor type '%s' (supported: %s)",
+				monitorType, strings.Join(nsxtAlbHealthMonitorTypes, ", "))
+		}
+		healthMonitors = append(healthMonitors, types.NsxtAlbPoolHealthMonitor{Type: monitorType})
+	}
+	return healthMonitors, nil
+}
+
+// NewNsxtAlbPoolPersistenceProfile returns a persistence profile of the given type (see types.NsxtAlbPersistence*
+// constants). value is the cookie name for HTTP_COOKIE and APP_COOKIE, and the header name for CUSTOM_HTTP_HEADER. It
+// must be empty for the other types.
+func NewNsxtAlbPoolPersistenceProfile(profileType, value string) (*types.NsxtAlbPoolPersistenceProfile, error) {
+	switch profileType {
+	case types.NsxtAlbPersistenceHttpCookie, types.NsxtAlbPersistenceCustomHttpHeader, types.NsxtAlbPersistenceAppCookie:
+		if value == "" {
+			return nil, fmt.Errorf("NSX-T ALB persistence profile '%s' requires a value", profileType)
+		}
+	case types.NsxtAlbPersistenceClientIp, types.NsxtAlbPersistenceTls:
+		if value != "" {
+			return nil, fmt.Errorf("NSX-T ALB persistence profile '%s' does not accept a value", profileType)
+		}
+	default:
+		return nil, fmt.Errorf("invalid NSX-T ALB persistence profile type '%s' (supported: %s)",
+			profileType, strings.Join(nsxtAlbPersistenceProfileTypes, ", "))
+	}
+	return &types.NsxtAlbPoolPersistenceProfile{Type: profileType, Value: value}, nil
+}
+
+// SetHealthMonitors replaces the health monitors of the NSX-T ALB Pool with the system-defined monitors of the given
+// types (see types.NsxtAlbHealthMonitor* constants). No types remove all the health monitors.
+func (nsxtAlbPool *NsxtAlbPool) SetHealthMonitors(ctx context.Context, monitorTypes ...string) (*NsxtAlbPool, error) {
+	healthMonitors, err := NewNsxtAlbPoolHealthMonitors(monitorTypes...)
+	if err != nil {
+		return nil, err
+	}
+	albPoolConfig := *nsxtAlbPool.NsxtAlbPool
+	albPoolConfig.HealthMonitors = healthMonitors
+	return nsxtAlbPool.Update(ctx, &albPoolConfig)
+}
+
+// SetPersistenceProfile sets the persistence profile of the NSX-T ALB Pool (see NewNsxtAlbPoolPersistenceProfile). An
+// empty profileType removes the persistence profile.
+func (nsxtAlbPool *NsxtAlbPool) SetPersistenceProfile(ctx context.Context, profileType, value string) (*NsxtAlbPool, error) {
+	albPoolConfig := *nsxtAlbPool.NsxtAlbPool
+	albPoolConfig.PersistenceProfile = nil
+	if profileType != "" {
+		persistenceProfile, err := NewNsxtAlbPoolPersistenceProfile(profileType, value)
+		if err != nil {
+			return nil, err
+		}
+		albPoolConfig.PersistenceProfile = persistenceProfile
+	}
+	return nsxtAlbPool.Update(ctx, &albPoolConfig)
+}
+
+// SetSslSettings sets the certificates used to validate the pool members of the NSX-T ALB Pool. caCertificateIds are
+// the IDs of CA certificates in the certificate library. The common name check requires at least one CA certificate
+// and uses domainNames, if any, to verify the certificates of the members. No CA certificates disable the validation.
+func (nsxtAlbPool *NsxtAlbPool) SetSslSettings(ctx context.Context, caCertificateIds []string, commonNameCheck bool, domainNames []string) (*NsxtAlbPool, error) {
+	if commonNameCheck && len(caCertificateIds) == 0 {
+		return nil, fmt.Errorf("NSX-T ALB Pool common name check requires at least one CA certificate")
+	}
+	if !commonNameCheck && len(domainNames) > 0 {
+		return nil, fmt.Errorf("NSX-T ALB Pool domain names are only used with common name check")
+	}
+
+	albPoolConfig := *nsxtAlbPool.NsxtAlbPool
+	albPoolConfig.CaCertificateRefs = nil
+	for _, id := range caCertificateIds {
+		albPoolConfig.CaCertificateRefs = append(albPoolConfig.CaCertificateRefs, types.OpenApiReference{ID: id})
+	}
+	albPoolConfig.CommonNameCheckEnabled = &commonNameCheck
+	albPoolConfig.DomainNames = domainNames
+	return nsxtAlbPool.Update(ctx, &albPoolConfig)
+}
+
+// SetSslCertificate sets the certificate served by the NSX-T ALB Virtual Service and enables SSL on all its service
+// ports. certificateId is the ID of a certificate with private key in the certificate library. An empty
+// certificateId removes the certificate and disables SSL, which is not possible with HTTPS and L4_TLS application
+// profiles.
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) SetSslCertificate(ctx context.Context, certificateId string) (*NsxtAlbVirtualService, error) {
+	virtualServiceConfig := *nsxtAlbVirtualService.NsxtAlbVirtualService
+	err := setNsxtAlbVirtualServiceCertificate(&virtualServiceConfig, certificateId)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.Update(ctx, &virtualServiceConfig)
+}
+
+// setNsxtAlbVirtualServiceCertificate sets the certificate of virtualServiceConfig and the SSL flag of its ports.
+// The ports are copied, so that the ones of the original configuration are not changed
+func setNsxtAlbVirtualServiceCertificate(virtualServiceConfig *types.NsxtAlbVirtualService, certificateId string) error {
+	sslEnabled := certificateId != ""
+	applicationProfile := virtualServiceConfig.ApplicationProfile.Type
+	if !sslEnabled && (applicationProfile == types.NsxtAlbApplicationProfileHttps ||
+		applicationProfile == types.NsxtAlbApplicationProfileL4Tls) {
+		return fmt.Errorf("NSX-T ALB Virtual Service with application profile '%s' requires a certificate", applicationProfile)
+	}
+
+	virtualServiceConfig.CertificateRef = nil
+	if sslEnabled {
+		virtualServiceConfig.CertificateRef = &types.OpenApiReference{ID: certificateId}
+	}
+	servicePorts := make([]types.NsxtAlbVirtualServicePort, len(virtualServiceConfig.ServicePorts))
+	for index, servicePort := range virtualServiceConfig.ServicePorts {
+		servicePort.SslEnabled = &sslEnabled
+		servicePorts[index] = servicePort
+	}
+	virtualServiceConfig.ServicePorts = servicePorts
+	return nil
+}
@@ -0,0 +1,118 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestNsxtAlbServiceEngineGroup_GetSystemDefinedProfiles(t *testing.T) {
+	standard := &NsxtAlbServiceEngineGroup{NsxtAlbServiceEngineGroup: &types.NsxtAlbServiceEngineGroup{}}
+	if contains(types.NsxtAlbApplicationProfileL4Tls, standard.GetSystemDefinedProfiles().ApplicationProfileTypes) {
+		t.Errorf("L4_TLS application profile must not be available with the STANDARD feature set")
+	}
+	premium := &NsxtAlbServiceEngineGroup{NsxtAlbServiceEngineGroup: &types.NsxtAlbServiceEngineGroup{
+		SupportedFeatureSet: types.NsxtAlbFeatureSetPremium,
+	}}
+	profiles := premium.GetSystemDefinedProfiles()
+	if !contains(types.NsxtAlbApplicationProfileL4Tls, profiles.ApplicationProfileTypes) {
+		t.Errorf("L4_TLS application profile must be available with the PREMIUM feature set")
+	}
+	if len(profiles.HealthMonitorTypes) != 5 || len(profiles.PersistenceProfileTypes) != 5 {
+		t.Errorf("unexpected profiles: %+v", profiles)
+	}
+}
+
+func TestNewNsxtAlbPoolHealthMonitors(t *testing.T) {
+	monitors, err := NewNsxtAlbPoolHealthMonitors(types.NsxtAlbHealthMonitorHttp, types.NsxtAlbHealthMonitorPing)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []types.NsxtAlbPoolHealthMonitor{{Type: "HTTP"}, {Type: "PING"}}
+	if !reflect.DeepEqual(monitors, expected) {
+		t.Errorf("expected %v, got %v", expected, monitors)
+	}
+	_, err = NewNsxtAlbPoolHealthMonitors("ICMP")
+	if err == nil || !strings.Contains(err.Error(), "supported: HTTP, HTTPS, TCP, UDP, PING") {
+		t.Errorf("expected error for invalid health monitor type, got %v", err)
+	}
+}
+
+func TestNewNsxtAlbPoolPersistenceProfile(t *testing.T) {
+	tests := []struct {
+		profileType string
+		value       string
+		wantErr     string
+	}{
+		{profileType: types.NsxtAlbPersistenceClientIp},
+		{profileType: types.NsxtAlbPersistenceTls},
+		{profileType: types.NsxtAlbPersistenceHttpCookie, value: "session"},
+		{profileType: types.NsxtAlbPersistenceCustomHttpHeader, value: "X-User"},
+		{profileType: types.NsxtAlbPersistenceAppCookie, wantErr: "requires a value"},
+		{profileType: types.NsxtAlbPersistenceClientIp, value: "ip", wantErr: "does not accept a value"},
+		{profileType: "SOURCE_IP", wantErr: "invalid NSX-T ALB persistence profile type"},
+	}
+	for _, tt := range tests {
+		profile, err := NewNsxtAlbPoolPersistenceProfile(tt.profileType, tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.profileType, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.profileType, err)
+			continue
+		}
+		if profile.Type != tt.profileType || profile.Value != tt.value {
+			t.Errorf("%s: unexpected profile %+v", tt.profileType, profile)
+		}
+	}
+}
+
+func Test_setNsxtAlbVirtualServiceCertificate(t *testing.T) {
+	port := 443
+	original := types.NsxtAlbVirtualService{
+		ApplicationProfile: types.NsxtAlbVirtualServiceApplicationProfile{Type: types.NsxtAlbApplicationProfileHttp},
+		ServicePorts:       []types.NsxtAlbVirtualServicePort{{PortStart: &port}},
+	}
+
+	withCertificate := original
+	err := setNsxtAlbVirtualServiceCertificate(&withCertificate, "urn:vcloud:certificateLibraryItem:1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if withCertificate.CertificateRef == nil || withCertificate.CertificateRef.ID != "urn:vcloud:certificateLibraryItem:1" {
+		t.Errorf("certificate not set: %+v", withCertificate.CertificateRef)
+	}
+	if sslEnabled := withCertificate.ServicePorts[0].SslEnabled; sslEnabled == nil || !*sslEnabled {
+		t.Errorf("SSL not enabled on service port")
+	}
+	if original.ServicePorts[0].SslEnabled != nil {
+		t.Errorf("service ports of the original configuration changed")
+	}
+
+	withoutCertificate := withCertificate
+	err = setNsxtAlbVirtualServiceCertificate(&withoutCertificate, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if withoutCertificate.CertificateRef != nil || *withoutCertificate.ServicePorts[0].SslEnabled {
+		t.Errorf("certificate and SSL not removed: %+v", withoutCertificate)
+	}
+
+	https := original
+	https.ApplicationProfile.Type = types.NsxtAlbApplicationProfileHttps
+	err = setNsxtAlbVirtualServiceCertificate(&https, "")
+	if err == nil || !strings.Contains(err.Error(), "requires a certificate") {
+		t.Errorf("expected error removing the certificate of an HTTPS virtual service, got %v", err)
+	}
+}
@@ -150,7 +150,7 @@ func (vcdClient *VCDClient) CreateAlbServiceEngineGroupAssignment(ctx context.Co
 
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, assignmentConfig, returnObject.NsxtAlbServiceEngineGroupAssignment, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group Assignment: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group Assignment: %w", err)
 	}
 
 	return returnObject, nil
@@ -181,7 +181,7 @@ func (nsxtEdgeAlbServiceEngineGroup *NsxtAlbServiceEngineGroupAssignment) Update
 
 	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, assignmentConfig, responseAlbController.NsxtAlbServiceEngineGroupAssignment, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group Assignment: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group Assignment: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -207,7 +207,7 @@ func (nsxtEdgeAlbServiceEngineGroup *NsxtAlbServiceEngineGroupAssignment) Delete
 
 	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group Assignment: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group Assignment: %w", err)
 	}
 
 	return nil
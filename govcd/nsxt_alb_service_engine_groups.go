@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
@@ -88,7 +89,7 @@ func (vcdClient *VCDClient) GetAlbServiceEngineGroupByName(ctx context.Context,
 
 	albSeGroups, err := vcdClient.GetAllAlbServiceEngineGroups(ctx, "", queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T ALB Service Engine Group By Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error retrieving NSX-T ALB Service Engine Group By Name '%s': %w", name, err)
 	}
 
 	if len(albSeGroups) == 0 {
@@ -136,6 +137,22 @@ func (vcdClient *VCDClient) GetAlbServiceEngineGroupById(ctx context.Context, id
 	return wrappedResponse, nil
 }
 
+// GetAlbServiceEngineGroupByNameOrId retrieves an ALB Service Engine Group by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vcdClient *VCDClient) GetAlbServiceEngineGroupByNameOrId(ctx context.Context, optionalContext, identifier string) (*NsxtAlbServiceEngineGroup, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return vcdClient.GetAlbServiceEngineGroupByName(ctx, optionalContext, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) {
+		return vcdClient.GetAlbServiceEngineGroupById(ctx, id)
+	}
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtAlbServiceEngineGroup), err
+}
+
 func (vcdClient *VCDClient) CreateNsxtAlbServiceEngineGroup(ctx context.Context, albServiceEngineGroup *types.NsxtAlbServiceEngineGroup) (*NsxtAlbServiceEngineGroup, error) {
 	client := vcdClient.Client
 	if !client.IsSysAdmin {
@@ -160,7 +177,7 @@ func (vcdClient *VCDClient) CreateNsxtAlbServiceEngineGroup(ctx context.Context,
 
 	err = client.OpenApiPostItem(ctx, apiVersion, urlRef, nil, albServiceEngineGroup, returnObject.NsxtAlbServiceEngineGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group: %w", err)
 	}
 
 	return returnObject, nil
@@ -191,7 +208,7 @@ func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Update(ctx context.C
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, albSEGroupConfig, responseAlbController.NsxtAlbServiceEngineGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -217,7 +234,7 @@ func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Delete(ctx context.C
 
 	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group: %w", err)
 	}
 
 	return nil
@@ -245,13 +262,228 @@ func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Sync(ctx context.Con
 
 	task, err := client.OpenApiPostItemAsync(ctx, apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error syncing NSX-T ALB Service Engine Group: %s", err)
+		return fmt.Errorf("error syncing NSX-T ALB Service Engine Group: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("sync task for NSX-T ALB Service Engine Group failed: %s", err)
+		return fmt.Errorf("sync task for NSX-T ALB Service Engine Group failed: %w", err)
+	}
+
+	return nil
+}
+
+// NsxtAlbServiceEngineGroupDrain defines where ALB Virtual Services are moved when a Service Engine Group change
+// (ResizeWithDrain, ChangeHaMode) cannot be applied while they are deployed in it
+type NsxtAlbServiceEngineGroupDrain struct {
+	// TargetServiceEngineGroupId is the ID of the Service Engine Group that receives the Virtual Services. It must be
+	// assigned to all the Edge Gateways of the Virtual Services that are moved
+	TargetServiceEngineGroupId string
+}
+
+// albServiceEngineGroupHaModes lists the values accepted by ChangeHaMode
+var albServiceEngineGroupHaModes = []string{"ELASTIC_N_PLUS_M_BUFFER", "ELASTIC_ACTIVE_ACTIVE", "LEGACY_ACTIVE_STANDBY"}
+
+// Resize sets the maximum number of Virtual Services supported by the Service Engine Group and the number of
+// Virtual Services reserved for Edge Gateways. Current Edge Gateway assignments are checked before applying the
+// change, and an error is returned without changing anything when they do not fit the new sizing.
+// Use ResizeWithDrain to move the Virtual Services that exceed maxVs to another Service Engine Group.
+func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Resize(ctx context.Context, maxVs, reservedVs int) (*NsxtAlbServiceEngineGroup, error) {
+	return nsxtAlbServiceEngineGroup.ResizeWithDrain(ctx, maxVs, reservedVs, nil)
+}
+
+// ResizeWithDrain works like Resize, but when more than maxVs Virtual Services are deployed in the Service Engine
+// Group, the ones in excess are moved to drain.TargetServiceEngineGroupId before applying the new sizing.
+// A nil drain makes it behave like Resize.
+func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) ResizeWithDrain(ctx context.Context, maxVs, reservedVs int, drain *NsxtAlbServiceEngineGroupDrain) (*NsxtAlbServiceEngineGroup, error) {
+	assignments, err := nsxtAlbServiceEngineGroup.getAssignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	excess, err := validateAlbServiceEngineGroupResize(nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup, assignments, maxVs, reservedVs)
+	if err != nil {
+		return nil, err
+	}
+	if excess > 0 {
+		if drain == nil {
+			return nil, fmt.Errorf("cannot resize NSX-T ALB Service Engine Group '%s': %d Virtual Services exceed the new maximum of %d and no drain target was given",
+				nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.Name, excess, maxVs)
+		}
+		err = nsxtAlbServiceEngineGroup.drainVirtualServices(ctx, assignments, excess, drain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	albSEGroupConfig := *nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup
+	albSEGroupConfig.MaxVirtualServices = &maxVs
+	albSEGroupConfig.ReservedVirtualServices = &reservedVs
+	return nsxtAlbServiceEngineGroup.Update(ctx, &albSEGroupConfig)
+}
+
+// ChangeHaMode changes the High Availability mode of the Service Engine Group to one of "ELASTIC_N_PLUS_M_BUFFER",
+// "ELASTIC_ACTIVE_ACTIVE" or "LEGACY_ACTIVE_STANDBY". The HA mode cannot be changed while Virtual Services are
+// deployed in the Service Engine Group: they are moved to drain.TargetServiceEngineGroupId first or, when drain is
+// nil, an error is returned without changing anything.
+func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) ChangeHaMode(ctx context.Context, haMode string, drain *NsxtAlbServiceEngineGroupDrain) (*NsxtAlbServiceEngineGroup, error) {
+	if !contains(haMode, albServiceEngineGroupHaModes) {
+		return nil, fmt.Errorf("invalid HA mode '%s'. Supported values are %s", haMode, strings.Join(albServiceEngineGroupHaModes, ", "))
+	}
+	if nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.HaMode == haMode {
+		return nsxtAlbServiceEngineGroup, nil
+	}
+
+	assignments, err := nsxtAlbServiceEngineGroup.getAssignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deployed := countAlbDeployedVirtualServices(nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup, assignments)
+	if deployed > 0 {
+		if drain == nil {
+			return nil, fmt.Errorf("cannot change HA mode of NSX-T ALB Service Engine Group '%s': %d Virtual Services are deployed and no drain target was given",
+				nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.Name, deployed)
+		}
+		err = nsxtAlbServiceEngineGroup.drainVirtualServices(ctx, assignments, deployed, drain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	albSEGroupConfig := *nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup
+	albSEGroupConfig.HaMode = haMode
+	return nsxtAlbServiceEngineGroup.Update(ctx, &albSEGroupConfig)
+}
+
+// getAssignments retrieves the Edge Gateway assignments of the Service Engine Group
+func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) getAssignments(ctx context.Context) ([]*NsxtAlbServiceEngineGroupAssignment, error) {
+	if nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.ID == "" {
+		return nil, fmt.Errorf("cannot retrieve assignments of NSX-T ALB Service Engine Group without ID")
+	}
+	queryParams := queryParameterFilterAnd("serviceEngineGroupRef.id=="+nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.ID, nil)
+	assignments, err := nsxtAlbServiceEngineGroup.vcdClient.GetAllAlbServiceEngineGroupAssignments(ctx, queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving assignments of NSX-T ALB Service Engine Group '%s': %s",
+			nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.Name, err)
+	}
+	return assignments, nil
+}
+
+// drainVirtualServices moves count Virtual Services to the Service Engine Group defined in drain. All the Virtual
+// Services to move are checked against the assignments of the target before moving any of them
+func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) drainVirtualServices(ctx context.Context, assignments []*NsxtAlbServiceEngineGroupAssignment, count int, drain *NsxtAlbServiceEngineGroupDrain) error {
+	sourceId := nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.ID
+	if drain.TargetServiceEngineGroupId == "" {
+		return fmt.Errorf("drain target Service Engine Group ID is empty")
+	}
+	if drain.TargetServiceEngineGroupId == sourceId {
+		return fmt.Errorf("drain target Service Engine Group must be different from '%s'", nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.Name)
+	}
+	target, err := nsxtAlbServiceEngineGroup.vcdClient.GetAlbServiceEngineGroupById(ctx, drain.TargetServiceEngineGroupId)
+	if err != nil {
+		return fmt.Errorf("error retrieving drain target Service Engine Group: %w", err)
+	}
+	targetAssignments, err := target.getAssignments(ctx)
+	if err != nil {
+		return err
+	}
+	targetGateways := make(map[string]bool)
+	for _, assignment := range targetAssignments {
+		targetGateways[assignment.NsxtAlbServiceEngineGroupAssignment.GatewayRef.ID] = true
+	}
+
+	var toMove []*NsxtAlbVirtualService
+	for _, assignment := range assignments {
+		if len(toMove) == count {
+			break
+		}
+		gatewayRef := assignment.NsxtAlbServiceEngineGroupAssignment.GatewayRef
+		if assignment.NsxtAlbServiceEngineGroupAssignment.NumDeployedVirtualServices == 0 || gatewayRef == nil {
+			continue
+		}
+		virtualServices, err := nsxtAlbServiceEngineGroup.vcdClient.GetAllAlbVirtualServiceSummaries(ctx, gatewayRef.ID, nil)
+		if err != nil {
+			return fmt.Errorf("error retrieving Virtual Services of Edge Gateway '%s': %w", gatewayRef.Name, err)
+		}
+		for _, virtualService := range virtualServices {
+			if len(toMove) == count {
+				break
+			}
+			if virtualService.NsxtAlbVirtualService.ServiceEngineGroupRef.ID != sourceId {
+				continue
+			}
+			if !targetGateways[gatewayRef.ID] {
+				return fmt.Errorf("cannot move Virtual Service '%s': Service Engine Group '%s' is not assigned to Edge Gateway '%s'",
+					virtualService.NsxtAlbVirtualService.Name, target.NsxtAlbServiceEngineGroup.Name, gatewayRef.Name)
+			}
+			toMove = append(toMove, virtualService)
+		}
+	}
+	if len(toMove) < count {
+		return fmt.Errorf("found only %d of the %d Virtual Services to move out of Service Engine Group '%s'",
+			len(toMove), count, nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.Name)
 	}
 
+	for moved, summary := range toMove {
+		virtualService, err := nsxtAlbServiceEngineGroup.vcdClient.GetAlbVirtualServiceById(ctx, summary.NsxtAlbVirtualService.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving Virtual Service '%s' (%d of %d moved): %w", summary.NsxtAlbVirtualService.Name, moved, count, err)
+		}
+		virtualService.NsxtAlbVirtualService.ServiceEngineGroupRef = types.OpenApiReference{ID: target.NsxtAlbServiceEngineGroup.ID}
+		_, err = virtualService.Update(ctx, virtualService.NsxtAlbVirtualService)
+		if err != nil {
+			return fmt.Errorf("error moving Virtual Service '%s' to Service Engine Group '%s' (%d of %d moved): %s",
+				summary.NsxtAlbVirtualService.Name, target.NsxtAlbServiceEngineGroup.Name, moved, count, err)
+		}
+	}
 	return nil
 }
+
+// validateAlbServiceEngineGroupResize checks that the Edge Gateway assignments of a Service Engine Group fit the
+// new sizing. It returns the number of deployed Virtual Services that exceed maxVs
+func validateAlbServiceEngineGroupResize(albSEGroup *types.NsxtAlbServiceEngineGroup, assignments []*NsxtAlbServiceEngineGroupAssignment, maxVs, reservedVs int) (int, error) {
+	if maxVs < 1 {
+		return 0, fmt.Errorf("maximum number of Virtual Services must be at least 1, got %d", maxVs)
+	}
+	if reservedVs < 0 || reservedVs > maxVs {
+		return 0, fmt.Errorf("reserved Virtual Services must be between 0 and the maximum of %d, got %d", maxVs, reservedVs)
+	}
+
+	guaranteed := 0
+	for _, assignment := range assignments {
+		config := assignment.NsxtAlbServiceEngineGroupAssignment
+		gatewayName := ""
+		if config.GatewayRef != nil {
+			gatewayName = config.GatewayRef.Name
+		}
+		if config.MinVirtualServices != nil {
+			guaranteed += *config.MinVirtualServices
+		}
+		if config.MaxVirtualServices != nil && *config.MaxVirtualServices > maxVs {
+			return 0, fmt.Errorf("the assignment of Edge Gateway '%s' allows up to %d Virtual Services, more than the new maximum of %d",
+				gatewayName, *config.MaxVirtualServices, maxVs)
+		}
+	}
+	if guaranteed > reservedVs {
+		return 0, fmt.Errorf("the Edge Gateway assignments of Service Engine Group '%s' guarantee %d Virtual Services, more than the %d reserved",
+			albSEGroup.Name, guaranteed, reservedVs)
+	}
+
+	deployed := countAlbDeployedVirtualServices(albSEGroup, assignments)
+	if deployed > maxVs {
+		return deployed - maxVs, nil
+	}
+	return 0, nil
+}
+
+// countAlbDeployedVirtualServices returns the number of Virtual Services deployed in a Service Engine Group
+func countAlbDeployedVirtualServices(albSEGroup *types.NsxtAlbServiceEngineGroup, assignments []*NsxtAlbServiceEngineGroupAssignment) int {
+	deployed := 0
+	for _, assignment := range assignments {
+		deployed += assignment.NsxtAlbServiceEngineGroupAssignment.NumDeployedVirtualServices
+	}
+	if albSEGroup.NumDeployedVirtualServices != nil && *albSEGroup.NumDeployedVirtualServices > deployed {
+		deployed = *albSEGroup.NumDeployedVirtualServices
+	}
+	return deployed
+}
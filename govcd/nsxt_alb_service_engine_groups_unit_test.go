@@ -0,0 +1,65 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_validateAlbServiceEngineGroupResize(t *testing.T) {
+	newAssignment := func(gateway string, minVs, maxVs, deployed int) *NsxtAlbServiceEngineGroupAssignment {
+		return &NsxtAlbServiceEngineGroupAssignment{
+			NsxtAlbServiceEngineGroupAssignment: &types.NsxtAlbServiceEngineGroupAssignment{
+				GatewayRef:                 &types.OpenApiReference{Name: gateway},
+				MinVirtualServices:         &minVs,
+				MaxVirtualServices:         &maxVs,
+				NumDeployedVirtualServices: deployed,
+			},
+		}
+	}
+	albSEGroup := &types.NsxtAlbServiceEngineGroup{Name: "seg1"}
+	assignments := []*NsxtAlbServiceEngineGroupAssignment{
+		newAssignment("edge1", 2, 5, 4),
+		newAssignment("edge2", 3, 6, 2),
+	}
+
+	tests := []struct {
+		name           string
+		maxVs          int
+		reservedVs     int
+		expectedExcess int
+		wantErr        bool
+	}{
+		{name: "Fits", maxVs: 10, reservedVs: 5, expectedExcess: 0},
+		{name: "EqualsDeployed", maxVs: 6, reservedVs: 5, expectedExcess: 0},
+		{name: "BelowAssignmentMaximum", maxVs: 5, reservedVs: 5, wantErr: true},
+		{name: "BelowGuaranteed", maxVs: 10, reservedVs: 4, wantErr: true},
+		{name: "ReservedAboveMaximum", maxVs: 10, reservedVs: 11, wantErr: true},
+		{name: "ZeroMaximum", maxVs: 0, reservedVs: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			excess, err := validateAlbServiceEngineGroupResize(albSEGroup, assignments, tt.maxVs, tt.reservedVs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %t, got %v", tt.wantErr, err)
+			}
+			if excess != tt.expectedExcess {
+				t.Errorf("expected %d Virtual Services in excess, got %d", tt.expectedExcess, excess)
+			}
+		})
+	}
+
+	// Without per-gateway maximums, Virtual Services above the new maximum need to be drained
+	excess, err := validateAlbServiceEngineGroupResize(albSEGroup, []*NsxtAlbServiceEngineGroupAssignment{
+		{NsxtAlbServiceEngineGroupAssignment: &types.NsxtAlbServiceEngineGroupAssignment{NumDeployedVirtualServices: 7}},
+	}, 4, 0)
+	if err != nil || excess != 3 {
+		t.Errorf("expected 3 Virtual Services in excess, got %d (%v)", excess, err)
+	}
+}
@@ -7,7 +7,8 @@ import (
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
 
-// GetAlbSettings retrieves NSX-T ALB settings for a particular Edge Gateway
+// GetAlbSettings retrieves NSX-T ALB settings for a particular Edge Gateway.
+// When NSX-T ALB is not available, the error is a FeatureNotAvailableError
 func (egw *NsxtEdgeGateway) GetAlbSettings(ctx context.Context) (*types.NsxtAlbConfig, error) {
 	client := egw.client
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbEdgeGateway
@@ -24,7 +25,7 @@ func (egw *NsxtEdgeGateway) GetAlbSettings(ctx context.Context) (*types.NsxtAlbC
 	typeResponse := &types.NsxtAlbConfig{}
 	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, &typeResponse, nil)
 	if err != nil {
-		return nil, err
+		return nil, client.featureError(ctx, FeatureAlb, err)
 	}
 
 	return typeResponse, nil
@@ -60,8 +61,64 @@ func (egw *NsxtEdgeGateway) DisableAlb(ctx context.Context) error {
 	}
 	_, err := egw.UpdateAlbSettings(ctx, config)
 	if err != nil {
-		return fmt.Errorf("error disabling NSX-T ALB: %s", err)
+		return fmt.Errorf("error disabling NSX-T ALB: %w", err)
 	}
 
 	return nil
 }
+
+// SetAlbAnalytics is a shortcut wrapping UpdateAlbSettings which toggles ALB analytics for the Edge Gateway
+// while keeping all other settings intact. ALB must already be enabled on the Edge Gateway.
+//
+// Note. Requires VCD 10.5.0+ (API v38.0+)
+func (egw *NsxtEdgeGateway) SetAlbAnalytics(ctx context.Context, enabled bool) (*types.NsxtAlbConfig, error) {
+	return egw.updateAlbFeatureToggle(ctx, "analytics", func(config *types.NsxtAlbConfig) error {
+		config.AnalyticsEnabled = &enabled
+		return nil
+	})
+}
+
+// SetAlbWaf is a shortcut wrapping UpdateAlbSettings which toggles Web Application Firewall (WAF) policies for
+// the Edge Gateway while keeping all other settings intact. Enabling WAF requires the "PREMIUM" feature set.
+//
+// Note. Requires VCD 10.5.0+ (API v38.0+)
+func (egw *NsxtEdgeGateway) SetAlbWaf(ctx context.Context, enabled bool) (*types.NsxtAlbConfig, error) {
+	return egw.updateAlbFeatureToggle(ctx, "WAF", func(config *types.NsxtAlbConfig) error {
+		if enabled && config.SupportedFeatureSet != "PREMIUM" {
+			return fmt.Errorf("WAF requires 'PREMIUM' feature set, but Edge Gateway '%s' uses '%s'",
+				egw.EdgeGateway.Name, config.SupportedFeatureSet)
+		}
+		config.WafEnabled = &enabled
+		return nil
+	})
+}
+
+// updateAlbFeatureToggle retrieves current ALB settings, applies the change performed by setToggle and sends
+// the update
+func (egw *NsxtEdgeGateway) updateAlbFeatureToggle(ctx context.Context, featureName string, setToggle func(*types.NsxtAlbConfig) error) (*types.NsxtAlbConfig, error) {
+	if egw.client.APIVCDMaxVersionIs(ctx, "< 38.0") {
+		return nil, fmt.Errorf("ALB %s toggle requires VCD 10.5.0+ (API v38.0+)", featureName)
+	}
+
+	albConfig, err := egw.GetAlbSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T ALB settings: %w", err)
+	}
+
+	if !albConfig.Enabled {
+		return nil, fmt.Errorf("cannot change ALB %s setting: ALB is not enabled on Edge Gateway '%s'",
+			featureName, egw.EdgeGateway.Name)
+	}
+
+	err = setToggle(albConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedConfig, err := egw.UpdateAlbSettings(ctx, albConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T ALB %s setting: %w", featureName, err)
+	}
+
+	return updatedConfig, nil
+}
@@ -94,6 +94,28 @@ func (vcd *TestVCD) Test_UpdateAlbSettings(check *C) {
 		check.Assert(err, IsNil)
 	}
 
+	// Toggle analytics (VCD 10.5.0+)
+	if vcd.client.Client.APIVCDMaxVersionIs(ctx, ">= 38.0") {
+		printVerbose("Toggling analytics for VCD 10.5.0+\n")
+		albSettingsConfig.TransparentModeEnabled = nil
+		_, err = edge.UpdateAlbSettings(ctx, albSettingsConfig)
+		check.Assert(err, IsNil)
+
+		analyticsSettings, err := edge.SetAlbAnalytics(ctx, true)
+		check.Assert(err, IsNil)
+		check.Assert(*analyticsSettings.AnalyticsEnabled, Equals, true)
+		analyticsSettings, err = edge.SetAlbAnalytics(ctx, false)
+		check.Assert(err, IsNil)
+		check.Assert(*analyticsSettings.AnalyticsEnabled, Equals, false)
+
+		// WAF is not available with STANDARD feature set
+		_, err = edge.SetAlbWaf(ctx, true)
+		check.Assert(err, NotNil)
+
+		err = edge.DisableAlb(ctx)
+		check.Assert(err, IsNil)
+	}
+
 	albSettings, err := edge.GetAlbSettings(ctx)
 	check.Assert(err, IsNil)
 	check.Assert(albSettings, NotNil)
@@ -22,6 +22,7 @@ type NsxtAlbVirtualService struct {
 // GetAllAlbVirtualServiceSummaries returns a limited subset of NsxtAlbVirtualService values, but does it in single
 // query. To fetch complete information for ALB Virtual Services one can use GetAllAlbVirtualServices(), but it is slower
 // as it has to retrieve Virtual Services one by one.
+// When NSX-T ALB is not available, the error is a FeatureNotAvailableError
 func (vcdClient *VCDClient) GetAllAlbVirtualServiceSummaries(ctx context.Context, edgeGatewayId string, queryParameters url.Values) ([]*NsxtAlbVirtualService, error) {
 	client := vcdClient.Client
 
@@ -39,7 +40,7 @@ func (vcdClient *VCDClient) GetAllAlbVirtualServiceSummaries(ctx context.Context
 	typeResponses := []*types.NsxtAlbVirtualService{{}}
 	err = client.OpenApiGetAllItems(ctx, apiVersion, urlRef, queryParameters, &typeResponses, nil)
 	if err != nil {
-		return nil, err
+		return nil, client.featureError(ctx, FeatureAlb, err)
 	}
 
 	// Wrap all typeResponses into NsxtAlbPool types with client
@@ -59,7 +60,7 @@ func (vcdClient *VCDClient) GetAllAlbVirtualServiceSummaries(ctx context.Context
 func (vcdClient *VCDClient) GetAllAlbVirtualServices(ctx context.Context, edgeGatewayId string, queryParameters url.Values) ([]*NsxtAlbVirtualService, error) {
 	allAlbVirtualServiceSummaries, err := vcdClient.GetAllAlbVirtualServiceSummaries(ctx, edgeGatewayId, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all ALB Virtual Service summaries: %s", err)
+		return nil, fmt.Errorf("error retrieving all ALB Virtual Service summaries: %w", err)
 	}
 
 	// Loop over all Summaries and retrieve complete information
@@ -67,7 +68,7 @@ func (vcdClient *VCDClient) GetAllAlbVirtualServices(ctx context.Context, edgeGa
 	for index := range allAlbVirtualServiceSummaries {
 		allAlbVirtualServices[index], err = vcdClient.GetAlbVirtualServiceById(ctx, allAlbVirtualServiceSummaries[index].NsxtAlbVirtualService.ID)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving complete ALB Virtual Service: %s", err)
+			return nil, fmt.Errorf("error retrieving complete ALB Virtual Service: %w", err)
 		}
 
 	}
@@ -82,7 +83,7 @@ func (vcdClient *VCDClient) GetAlbVirtualServiceByName(ctx context.Context, edge
 
 	allAlbVirtualServices, err := vcdClient.GetAllAlbVirtualServices(ctx, edgeGatewayId, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("error reading ALB Virtual Service with Name '%s': %s", name, err)
+		return nil, fmt.Errorf("error reading ALB Virtual Service with Name '%s': %w", name, err)
 	}
 
 	if len(allAlbVirtualServices) == 0 {
@@ -129,6 +130,20 @@ func (vcdClient *VCDClient) GetAlbVirtualServiceById(ctx context.Context, id str
 	return wrappedResponse, nil
 }
 
+// GetAlbVirtualServiceByNameOrId retrieves an ALB Virtual Service in a given Edge Gateway by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vcdClient *VCDClient) GetAlbVirtualServiceByNameOrId(ctx context.Context, edgeGatewayId, identifier string) (*NsxtAlbVirtualService, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return vcdClient.GetAlbVirtualServiceByName(ctx, edgeGatewayId, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) { return vcdClient.GetAlbVirtualServiceById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtAlbVirtualService), err
+}
+
 // CreateNsxtAlbVirtualService creates NSX-T ALB Virtual Service based on supplied configuration
 func (vcdClient *VCDClient) CreateNsxtAlbVirtualService(ctx context.Context, albVirtualServiceConfig *types.NsxtAlbVirtualService) (*NsxtAlbVirtualService, error) {
 	client := vcdClient.Client
@@ -151,7 +166,7 @@ func (vcdClient *VCDClient) CreateNsxtAlbVirtualService(ctx context.Context, alb
 
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, albVirtualServiceConfig, returnObject.NsxtAlbVirtualService, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T ALB Virtual Service: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T ALB Virtual Service: %w", err)
 	}
 
 	return returnObject, nil
@@ -182,7 +197,7 @@ func (nsxtAlbVirtualService *NsxtAlbVirtualService) Update(ctx context.Context,
 
 	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, albVirtualServiceConfig, responseAlbController.NsxtAlbVirtualService, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T ALB Virtual Service: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T ALB Virtual Service: %w", err)
 	}
 
 	return responseAlbController, nil
@@ -208,7 +223,7 @@ func (nsxtAlbVirtualService *NsxtAlbVirtualService) Delete(ctx context.Context)
 
 	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T ALB Virtual Service: %s", err)
+		return fmt.Errorf("error deleting NSX-T ALB Virtual Service: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,299 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NSX-T ALB Virtual Service HTTP policies (VCD 10.5+) are ordered lists of rules, which are always retrieved and
+// updated as a whole. The rule level methods (Add*, Delete*, Move*) retrieve the current list, change it and update
+// it, so that the order of the other rules is preserved.
+
+// GetHttpRequestRules retrieves the HTTP request rules of the NSX-T ALB Virtual Service
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) GetHttpRequestRules(ctx context.Context) (*types.AlbVsHttpRequestRules, error) {
+	return getAlbVsHttpPolicy[types.AlbVsHttpRequestRules](ctx, nsxtAlbVirtualService, types.OpenApiEndpointAlbVsHttpRequestRules, "request")
+}
+
+// UpdateHttpRequestRules replaces the HTTP request rules of the NSX-T ALB Virtual Service. An empty list removes all
+// the rules
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) UpdateHttpRequestRules(ctx context.Context, rules *types.AlbVsHttpRequestRules) (*types.AlbVsHttpRequestRules, error) {
+	return updateAlbVsHttpPolicy(ctx, nsxtAlbVirtualService, types.OpenApiEndpointAlbVsHttpRequestRules, "request", rules)
+}
+
+// AddHttpRequestRule inserts an HTTP request rule at the given position (0 being the first rule). A negative position
+// or one past the last rule appends the rule
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) AddHttpRequestRule(ctx context.Context, rule types.AlbVsHttpRequestRule, position int) (*types.AlbVsHttpRequestRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpRequestRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = addAlbVsHttpRule(rules.Values, rule, position, httpRequestRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpRequestRules(ctx, rules)
+}
+
+// DeleteHttpRequestRule removes the HTTP request rule with the given name
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) DeleteHttpRequestRule(ctx context.Context, name string) (*types.AlbVsHttpRequestRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpRequestRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = deleteAlbVsHttpRule(rules.Values, name, httpRequestRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpRequestRules(ctx, rules)
+}
+
+// MoveHttpRequestRule moves the HTTP request rule with the given name to the given position (0 being the first rule).
+// A negative position moves the rule to the end of the list
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) MoveHttpRequestRule(ctx context.Context, name string, position int) (*types.AlbVsHttpRequestRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpRequestRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = moveAlbVsHttpRule(rules.Values, name, position, httpRequestRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpRequestRules(ctx, rules)
+}
+
+// GetHttpResponseRules retrieves the HTTP response rules of the NSX-T ALB Virtual Service
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) GetHttpResponseRules(ctx context.Context) (*types.AlbVsHttpResponseRules, error) {
+	return getAlbVsHttpPolicy[types.AlbVsHttpResponseRules](ctx, nsxtAlbVirtualService, types.OpenApiEndpointAlbVsHttpResponseRules, "response")
+}
+
+// UpdateHttpResponseRules replaces the HTTP response rules of the NSX-T ALB Virtual Service. An empty list removes
+// all the rules
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) UpdateHttpResponseRules(ctx context.Context, rules *types.AlbVsHttpResponseRules) (*types.AlbVsHttpResponseRules, error) {
+	return updateAlbVsHttpPolicy(ctx, nsxtAlbVirtualService, types.OpenApiEndpointAlbVsHttpResponseRules, "response", rules)
+}
+
+// AddHttpResponseRule inserts an HTTP response rule at the given position (0 being the first rule). A negative
+// position or one past the last rule appends the rule
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) AddHttpResponseRule(ctx context.Context, rule types.AlbVsHttpResponseRule, position int) (*types.AlbVsHttpResponseRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpResponseRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = addAlbVsHttpRule(rules.Values, rule, position, httpResponseRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpResponseRules(ctx, rules)
+}
+
+// DeleteHttpResponseRule removes the HTTP response rule with the given name
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) DeleteHttpResponseRule(ctx context.Context, name string) (*types.AlbVsHttpResponseRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpResponseRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = deleteAlbVsHttpRule(rules.Values, name, httpResponseRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpResponseRules(ctx, rules)
+}
+
+// MoveHttpResponseRule moves the HTTP response rule with the given name to the given position (0 being the first
+// rule). A negative position moves the rule to the end of the list
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) MoveHttpResponseRule(ctx context.Context, name string, position int) (*types.AlbVsHttpResponseRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpResponseRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = moveAlbVsHttpRule(rules.Values, name, position, httpResponseRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpResponseRules(ctx, rules)
+}
+
+// GetHttpSecurityRules retrieves the HTTP security rules of the NSX-T ALB Virtual Service
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) GetHttpSecurityRules(ctx context.Context) (*types.AlbVsHttpSecurityRules, error) {
+	return getAlbVsHttpPolicy[types.AlbVsHttpSecurityRules](ctx, nsxtAlbVirtualService, types.OpenApiEndpointAlbVsHttpSecurityRules, "security")
+}
+
+// UpdateHttpSecurityRules replaces the HTTP security rules of the NSX-T ALB Virtual Service. An empty list removes
+// all the rules
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) UpdateHttpSecurityRules(ctx context.Context, rules *types.AlbVsHttpSecurityRules) (*types.AlbVsHttpSecurityRules, error) {
+	return updateAlbVsHttpPolicy(ctx, nsxtAlbVirtualService, types.OpenApiEndpointAlbVsHttpSecurityRules, "security", rules)
+}
+
+// AddHttpSecurityRule inserts an HTTP security rule at the given position (0 being the first rule). A negative
+// position or one past the last rule appends the rule
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) AddHttpSecurityRule(ctx context.Context, rule types.AlbVsHttpSecurityRule, position int) (*types.AlbVsHttpSecurityRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpSecurityRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = addAlbVsHttpRule(rules.Values, rule, position, httpSecurityRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpSecurityRules(ctx, rules)
+}
+
+// DeleteHttpSecurityRule removes the HTTP security rule with the given name
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) DeleteHttpSecurityRule(ctx context.Context, name string) (*types.AlbVsHttpSecurityRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpSecurityRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = deleteAlbVsHttpRule(rules.Values, name, httpSecurityRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpSecurityRules(ctx, rules)
+}
+
+// MoveHttpSecurityRule moves the HTTP security rule with the given name to the given position (0 being the first
+// rule). A negative position moves the rule to the end of the list
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) MoveHttpSecurityRule(ctx context.Context, name string, position int) (*types.AlbVsHttpSecurityRules, error) {
+	rules, err := nsxtAlbVirtualService.GetHttpSecurityRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules.Values, err = moveAlbVsHttpRule(rules.Values, name, position, httpSecurityRuleName)
+	if err != nil {
+		return nil, err
+	}
+	return nsxtAlbVirtualService.UpdateHttpSecurityRules(ctx, rules)
+}
+
+func httpRequestRuleName(rule types.AlbVsHttpRequestRule) string   { return rule.Name }
+func httpResponseRuleName(rule types.AlbVsHttpResponseRule) string { return rule.Name }
+func httpSecurityRuleName(rule types.AlbVsHttpSecurityRule) string { return rule.Name }
+
+// getAlbVsHttpPolicy retrieves one of the HTTP policies of an NSX-T ALB Virtual Service. policyName is only used in
+// error messages
+func getAlbVsHttpPolicy[T any](ctx context.Context, nsxtAlbVirtualService *NsxtAlbVirtualService, endpointPath, policyName string) (*T, error) {
+	if nsxtAlbVirtualService.NsxtAlbVirtualService.ID == "" {
+		return nil, fmt.Errorf("cannot retrieve NSX-T ALB Virtual Service HTTP %s rules without ID", policyName)
+	}
+
+	client := nsxtAlbVirtualService.vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + endpointPath
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, nsxtAlbVirtualService.NsxtAlbVirtualService.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	policy := new(T)
+	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, policy, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T ALB Virtual Service HTTP %s rules: %w", policyName, err)
+	}
+
+	return policy, nil
+}
+
+// updateAlbVsHttpPolicy replaces one of the HTTP policies of an NSX-T ALB Virtual Service and returns the updated
+// policy
+func updateAlbVsHttpPolicy[T any](ctx context.Context, nsxtAlbVirtualService *NsxtAlbVirtualService, endpointPath, policyName string, policy *T) (*T, error) {
+	if nsxtAlbVirtualService.NsxtAlbVirtualService.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T ALB Virtual Service HTTP %s rules without ID", policyName)
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("cannot update NSX-T ALB Virtual Service HTTP %s rules from empty configuration", policyName)
+	}
+
+	client := nsxtAlbVirtualService.vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + endpointPath
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, nsxtAlbVirtualService.NsxtAlbVirtualService.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPolicy := new(T)
+	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, policy, updatedPolicy, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T ALB Virtual Service HTTP %s rules: %w", policyName, err)
+	}
+
+	return updatedPolicy, nil
+}
+
+// addAlbVsHttpRule returns a copy of rules with rule inserted at position. Rule names must be unique
+func addAlbVsHttpRule[T any](rules []T, rule T, position int, nameOf func(T) string) ([]T, error) {
+	name := nameOf(rule)
+	if name == "" {
+		return nil, fmt.Errorf("cannot add NSX-T ALB Virtual Service HTTP rule without name")
+	}
+	if albVsHttpRuleIndex(rules, name, nameOf) != -1 {
+		return nil, fmt.Errorf("NSX-T ALB Virtual Service HTTP rule '%s' already exists", name)
+	}
+	if position > len(rules) {
+		return nil, fmt.Errorf("invalid position %d for NSX-T ALB Virtual Service HTTP rule '%s' (%d rules)",
+			position, name, len(rules))
+	}
+	if position < 0 {
+		position = len(rules)
+	}
+
+	result := make([]T, 0, len(rules)+1)
+	result = append(result, rules[:position]...)
+	result = append(result, rule)
+	return append(result, rules[position:]...), nil
+}
+
+// deleteAlbVsHttpRule returns a copy of rules without the rule with the given name
+func deleteAlbVsHttpRule[T any](rules []T, name string, nameOf func(T) string) ([]T, error) {
+	index := albVsHttpRuleIndex(rules, name, nameOf)
+	if index == -1 {
+		return nil, fmt.Errorf("%s: NSX-T ALB Virtual Service HTTP rule '%s'", ErrorEntityNotFound, name)
+	}
+
+	result := make([]T, 0, len(rules)-1)
+	result = append(result, rules[:index]...)
+	return append(result, rules[index+1:]...), nil
+}
+
+// moveAlbVsHttpRule returns a copy of rules with the rule with the given name moved to position
+func moveAlbVsHttpRule[T any](rules []T, name string, position int, nameOf func(T) string) ([]T, error) {
+	index := albVsHttpRuleIndex(rules, name, nameOf)
+	if index == -1 {
+		return nil, fmt.Errorf("%s: NSX-T ALB Virtual Service HTTP rule '%s'", ErrorEntityNotFound, name)
+	}
+	if position >= len(rules) {
+		return nil, fmt.Errorf("invalid position %d for NSX-T ALB Virtual Service HTTP rule '%s' (%d rules)",
+			position, name, len(rules))
+	}
+
+	rule := rules[index]
+	result, err := deleteAlbVsHttpRule(rules, name, nameOf)
+	if err != nil {
+		return nil, err
+	}
+	return addAlbVsHttpRule(result, rule, position, nameOf)
+}
+
+// albVsHttpRuleIndex returns the index of the rule with the given name, or -1 when it is not found
+func albVsHttpRuleIndex[T any](rules []T, name string, nameOf func(T) string) int {
+	for index, rule := range rules {
+		if nameOf(rule) == name {
+			return index
+		}
+	}
+	return -1
+}
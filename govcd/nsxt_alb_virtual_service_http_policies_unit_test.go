@@ -0,0 +1,88 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func albVsHttpRuleNames(rules []types.AlbVsHttpRequestRule) []string {
+	names := make([]string, len(rules))
+	for index, rule := range rules {
+		names[index] = rule.Name
+	}
+	return names
+}
+
+func TestAlbVsHttpRuleOrdering(t *testing.T) {
+	rules := []types.AlbVsHttpRequestRule{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	type testCase struct {
+		name      string
+		operation func() ([]types.AlbVsHttpRequestRule, error)
+		expected  []string
+		wantErr   bool
+	}
+	testCases := []testCase{
+		{"add-first", func() ([]types.AlbVsHttpRequestRule, error) {
+			return addAlbVsHttpRule(rules, types.AlbVsHttpRequestRule{Name: "new"}, 0, httpRequestRuleName)
+		}, []string{"new", "a", "b", "c"}, false},
+		{"add-middle", func() ([]types.AlbVsHttpRequestRule, error) {
+			return addAlbVsHttpRule(rules, types.AlbVsHttpRequestRule{Name: "new"}, 2, httpRequestRuleName)
+		}, []string{"a", "b", "new", "c"}, false},
+		{"add-append", func() ([]types.AlbVsHttpRequestRule, error) {
+			return addAlbVsHttpRule(rules, types.AlbVsHttpRequestRule{Name: "new"}, -1, httpRequestRuleName)
+		}, []string{"a", "b", "c", "new"}, false},
+		{"add-duplicate", func() ([]types.AlbVsHttpRequestRule, error) {
+			return addAlbVsHttpRule(rules, types.AlbVsHttpRequestRule{Name: "b"}, 0, httpRequestRuleName)
+		}, nil, true},
+		{"add-out-of-range", func() ([]types.AlbVsHttpRequestRule, error) {
+			return addAlbVsHttpRule(rules, types.AlbVsHttpRequestRule{Name: "new"}, 4, httpRequestRuleName)
+		}, nil, true},
+		{"delete", func() ([]types.AlbVsHttpRequestRule, error) {
+			return deleteAlbVsHttpRule(rules, "b", httpRequestRuleName)
+		}, []string{"a", "c"}, false},
+		{"delete-missing", func() ([]types.AlbVsHttpRequestRule, error) {
+			return deleteAlbVsHttpRule(rules, "x", httpRequestRuleName)
+		}, nil, true},
+		{"move-first", func() ([]types.AlbVsHttpRequestRule, error) {
+			return moveAlbVsHttpRule(rules, "c", 0, httpRequestRuleName)
+		}, []string{"c", "a", "b"}, false},
+		{"move-last", func() ([]types.AlbVsHttpRequestRule, error) {
+			return moveAlbVsHttpRule(rules, "a", -1, httpRequestRuleName)
+		}, []string{"b", "c", "a"}, false},
+		{"move-out-of-range", func() ([]types.AlbVsHttpRequestRule, error) {
+			return moveAlbVsHttpRule(rules, "a", 3, httpRequestRuleName)
+		}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.operation()
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got rules %v", albVsHttpRuleNames(result))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if names := albVsHttpRuleNames(result); !reflect.DeepEqual(names, tc.expected) {
+				t.Errorf("expected rules %v, got %v", tc.expected, names)
+			}
+		})
+	}
+
+	// The original rules must never be changed
+	if names := albVsHttpRuleNames(rules); !reflect.DeepEqual(names, []string{"a", "b", "c"}) {
+		t.Errorf("original rules were changed: %v", names)
+	}
+}
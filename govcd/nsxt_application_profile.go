@@ -31,28 +31,7 @@ type NsxtAppPortProfile struct {
 // * TENANT (Create by tenant at Org level)
 // More details about scope in documentation for types.NsxtAppPortProfile
 func (org *Org) CreateNsxtAppPortProfile(ctx context.Context, appPortProfileConfig *types.NsxtAppPortProfile) (*NsxtAppPortProfile, error) {
-	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAppPortProfiles
-	minimumApiVersion, err := org.client.checkOpenApiEndpointCompatibility(ctx, endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	urlRef, err := org.client.OpenApiBuildEndpoint(endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	returnObject := &NsxtAppPortProfile{
-		NsxtAppPortProfile: &types.NsxtAppPortProfile{},
-		client:             org.client,
-	}
-
-	err = org.client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, appPortProfileConfig, returnObject.NsxtAppPortProfile, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Application Port Profile: %s", err)
-	}
-
-	return returnObject, nil
+	return createNsxtAppPortProfile(ctx, org.client, appPortProfileConfig)
 }
 
 // GetAllNsxtAppPortProfiles returns all NSX-T Application Port Profiles for specific scope
@@ -137,7 +116,7 @@ func (appPortProfile *NsxtAppPortProfile) Update(ctx context.Context, appPortPro
 
 	err = appPortProfile.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, appPortProfileConfig, returnObject.NsxtAppPortProfile, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T Application Port Profile : %s", err)
+		return nil, fmt.Errorf("error updating NSX-T Application Port Profile : %w", err)
 	}
 
 	return returnObject, nil
@@ -163,19 +142,44 @@ func (appPortProfile *NsxtAppPortProfile) Delete(ctx context.Context) error {
 	err = appPortProfile.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Application Port Profile: %s", err)
+		return fmt.Errorf("error deleting NSX-T Application Port Profile: %w", err)
 	}
 
 	return nil
 }
 
+func createNsxtAppPortProfile(ctx context.Context, client *Client, appPortProfileConfig *types.NsxtAppPortProfile) (*NsxtAppPortProfile, error) {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAppPortProfiles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtAppPortProfile{
+		NsxtAppPortProfile: &types.NsxtAppPortProfile{},
+		client:             client,
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, appPortProfileConfig, returnObject.NsxtAppPortProfile, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Application Port Profile: %w", err)
+	}
+
+	return returnObject, nil
+}
+
 func getNsxtAppPortProfileByName(ctx context.Context, client *Client, name string, queryParameters url.Values) (*NsxtAppPortProfile, error) {
 	queryParams := copyOrNewUrlValues(queryParameters)
 	queryParams = queryParameterFilterAnd("name=="+name, queryParams)
 
 	allAppPortProfiles, err := getAllNsxtAppPortProfiles(ctx, client, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("could not find NSX-T Application Port Profile with name '%s': %s", name, err)
+		return nil, fmt.Errorf("could not find NSX-T Application Port Profile with name '%s': %w", name, err)
 	}
 
 	if len(allAppPortProfiles) == 0 {
@@ -0,0 +1,226 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// Types of the objects deleted by NsxtEdgeGateway.BulkDeleteNsxtObjects
+const (
+	NsxtObjectFirewallRule   = "firewall rule"
+	NsxtObjectNatRule        = "NAT rule"
+	NsxtObjectFirewallGroup  = "firewall group"
+	NsxtObjectAppPortProfile = "application port profile"
+)
+
+// NsxtBulkDelete lists the objects to delete with NsxtEdgeGateway.BulkDeleteNsxtObjects
+type NsxtBulkDelete struct {
+	FirewallRuleIds   []string // IDs of user defined firewall rules of the Edge Gateway
+	NatRuleIds        []string // IDs of NAT rules of the Edge Gateway
+	FirewallGroupIds  []string // IDs of IP Sets and Security Groups
+	AppPortProfileIds []string // IDs of custom Application Port Profiles
+	// ContinueOnError keeps deleting the remaining objects after a failure, instead of stopping at the first one
+	ContinueOnError bool
+	// Progress, when set, is called after each object is processed, with the number of processed objects and the total
+	Progress func(result NsxtBulkDeleteResult, processed, total int)
+}
+
+// NsxtBulkDeleteResult is the outcome of deleting a single object with NsxtEdgeGateway.BulkDeleteNsxtObjects
+type NsxtBulkDeleteResult struct {
+	ObjectType string // One of the NsxtObject* constants
+	Id         string
+	Error      error // nil when the object was deleted
+}
+
+// BulkDeleteNsxtObjects deletes firewall rules, NAT rules, firewall groups and Application Port Profiles in an order
+// that respects their references: rules are deleted first, then the firewall groups and the Application Port
+// Profiles that they use.
+// Firewall groups and Application Port Profiles that are still used by rules of the Edge Gateway that are not
+// deleted (or whose deletion failed) are not deleted, and their result contains an error.
+//
+// It returns the result of each processed object, in the order in which they were processed. The error is not nil
+// when at least one object could not be deleted. Unless request.ContinueOnError is set, the process stops at the
+// first failure and the remaining objects are not included in the results.
+func (egw *NsxtEdgeGateway) BulkDeleteNsxtObjects(ctx context.Context, request NsxtBulkDelete) ([]NsxtBulkDeleteResult, error) {
+	if egw.EdgeGateway == nil || egw.EdgeGateway.ID == "" {
+		return nil, fmt.Errorf("edge gateway is not initialized")
+	}
+
+	firewallRules := []*types.NsxtFirewallRule{}
+	var firewall *NsxtFirewall
+	var err error
+	if len(request.FirewallRuleIds) > 0 || len(request.FirewallGroupIds) > 0 || len(request.AppPortProfileIds) > 0 {
+		firewall, err = egw.GetNsxtFirewall(ctx)
+		if err != nil {
+			return nil, err
+		}
+		firewallRules = firewall.NsxtFirewallRuleContainer.UserDefinedRules
+	}
+
+	natRules := []*NsxtNatRule{}
+	if len(request.NatRuleIds) > 0 || len(request.AppPortProfileIds) > 0 {
+		natRules, err = egw.GetAllNatRules(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deleter := nsxtBulkDeleter{
+		request: request,
+		total:   len(request.FirewallRuleIds) + len(request.NatRuleIds) + len(request.FirewallGroupIds) + len(request.AppPortProfileIds),
+		deleted: make(map[string]bool),
+	}
+
+	for _, id := range request.FirewallRuleIds {
+		if !deleter.run(NsxtObjectFirewallRule, id, func() error {
+			if findNsxtFirewallRule(firewallRules, id) == nil {
+				return fmt.Errorf("%s: firewall rule '%s' not found in Edge Gateway '%s'", ErrorEntityNotFound, id, egw.EdgeGateway.Name)
+			}
+			return firewall.DeleteRuleById(ctx, id)
+		}) {
+			return deleter.results, deleter.err()
+		}
+	}
+
+	for _, id := range request.NatRuleIds {
+		if !deleter.run(NsxtObjectNatRule, id, func() error {
+			natRule := findNsxtNatRule(natRules, id)
+			if natRule == nil {
+				return fmt.Errorf("%s: NAT rule '%s' not found in Edge Gateway '%s'", ErrorEntityNotFound, id, egw.EdgeGateway.Name)
+			}
+			return natRule.Delete(ctx)
+		}) {
+			return deleter.results, deleter.err()
+		}
+	}
+
+	// References are computed after deleting the rules, so that the rules that could not be deleted still block
+	// the objects they use
+	remainingNatRules := make([]*types.NsxtNatRule, 0, len(natRules))
+	for _, natRule := range natRules {
+		remainingNatRules = append(remainingNatRules, natRule.NsxtNatRule)
+	}
+	groupReferences, profileReferences := nsxtRuleReferences(firewallRules, remainingNatRules, deleter.deleted)
+
+	for _, id := range request.FirewallGroupIds {
+		if !deleter.run(NsxtObjectFirewallGroup, id, func() error {
+			if ruleName, ok := groupReferences[id]; ok {
+				return fmt.Errorf("firewall group '%s' is still used by rule '%s'", id, ruleName)
+			}
+			firewallGroup, err := getNsxtFirewallGroupById(ctx, egw.client, id)
+			if err != nil {
+				return err
+			}
+			return firewallGroup.Delete(ctx)
+		}) {
+			return deleter.results, deleter.err()
+		}
+	}
+
+	for _, id := range request.AppPortProfileIds {
+		if !deleter.run(NsxtObjectAppPortProfile, id, func() error {
+			if ruleName, ok := profileReferences[id]; ok {
+				return fmt.Errorf("application port profile '%s' is still used by rule '%s'", id, ruleName)
+			}
+			appPortProfile, err := getNsxtAppPortProfileById(ctx, egw.client, id)
+			if err != nil {
+				return err
+			}
+			return appPortProfile.Delete(ctx)
+		}) {
+			return deleter.results, deleter.err()
+		}
+	}
+
+	return deleter.results, deleter.err()
+}
+
+// nsxtBulkDeleter keeps track of the objects processed by NsxtEdgeGateway.BulkDeleteNsxtObjects
+type nsxtBulkDeleter struct {
+	request  NsxtBulkDelete
+	total    int
+	results  []NsxtBulkDeleteResult
+	failures int
+	deleted  map[string]bool // IDs of the objects deleted successfully
+}
+
+// run deletes a single object with deleteFunc and records the result. It returns false when the process must stop
+func (deleter *nsxtBulkDeleter) run(objectType, id string, deleteFunc func() error) bool {
+	err := deleteFunc()
+	result := NsxtBulkDeleteResult{ObjectType: objectType, Id: id, Error: err}
+	deleter.results = append(deleter.results, result)
+	if err != nil {
+		deleter.failures++
+		util.Logger.Printf("[ERROR] error deleting %s '%s': %s", objectType, id, err)
+	} else {
+		deleter.deleted[id] = true
+	}
+	if deleter.request.Progress != nil {
+		deleter.request.Progress(result, len(deleter.results), deleter.total)
+	}
+	return err == nil || deleter.request.ContinueOnError
+}
+
+// err returns an error summarising the failures, if any
+func (deleter *nsxtBulkDeleter) err() error {
+	if deleter.failures == 0 {
+		return nil
+	}
+	if !deleter.request.ContinueOnError {
+		last := deleter.results[len(deleter.results)-1]
+		return fmt.Errorf("error deleting %s '%s': %s", last.ObjectType, last.Id, last.Error)
+	}
+	return fmt.Errorf("%d of %d objects could not be deleted", deleter.failures, deleter.total)
+}
+
+// nsxtRuleReferences returns the firewall groups and the Application Port Profiles used by the rules that are
+// not in deleted, each one with the name of a rule that uses it
+func nsxtRuleReferences(firewallRules []*types.NsxtFirewallRule, natRules []*types.NsxtNatRule, deleted map[string]bool) (map[string]string, map[string]string) {
+	groupReferences := make(map[string]string)
+	profileReferences := make(map[string]string)
+	for _, rule := range firewallRules {
+		if deleted[rule.ID] {
+			continue
+		}
+		for _, group := range append(append([]types.OpenApiReference{}, rule.SourceFirewallGroups...), rule.DestinationFirewallGroups...) {
+			groupReferences[group.ID] = rule.Name
+		}
+		for _, profile := range rule.ApplicationPortProfiles {
+			profileReferences[profile.ID] = rule.Name
+		}
+	}
+	for _, rule := range natRules {
+		if deleted[rule.ID] || rule.ApplicationPortProfile == nil {
+			continue
+		}
+		profileReferences[rule.ApplicationPortProfile.ID] = rule.Name
+	}
+	return groupReferences, profileReferences
+}
+
+// findNsxtFirewallRule returns the firewall rule with the given ID, or nil if not found
+func findNsxtFirewallRule(firewallRules []*types.NsxtFirewallRule, id string) *types.NsxtFirewallRule {
+	for _, rule := range firewallRules {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+// findNsxtNatRule returns the NAT rule with the given ID, or nil if not found
+func findNsxtNatRule(natRules []*NsxtNatRule, id string) *NsxtNatRule {
+	for _, rule := range natRules {
+		if rule.NsxtNatRule.ID == id {
+			return rule
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_nsxtRuleReferences(t *testing.T) {
+	firewallRules := []*types.NsxtFirewallRule{
+		{
+			ID:                        "fw-1",
+			Name:                      "rule1",
+			SourceFirewallGroups:      []types.OpenApiReference{{ID: "group-1"}},
+			DestinationFirewallGroups: []types.OpenApiReference{{ID: "group-2"}},
+			ApplicationPortProfiles:   []types.OpenApiReference{{ID: "profile-1"}},
+		},
+		{
+			ID:                   "fw-2",
+			Name:                 "rule2",
+			SourceFirewallGroups: []types.OpenApiReference{{ID: "group-3"}},
+		},
+	}
+	natRules := []*types.NsxtNatRule{
+		{ID: "nat-1", Name: "nat1", ApplicationPortProfile: &types.OpenApiReference{ID: "profile-2"}},
+		{ID: "nat-2", Name: "nat2"},
+	}
+
+	groups, profiles := nsxtRuleReferences(firewallRules, natRules, map[string]bool{"fw-1": true, "nat-2": true})
+	if len(groups) != 1 || groups["group-3"] != "rule2" {
+		t.Errorf("unexpected group references: %v", groups)
+	}
+	if len(profiles) != 1 || profiles["profile-2"] != "nat1" {
+		t.Errorf("unexpected profile references: %v", profiles)
+	}
+
+	groups, profiles = nsxtRuleReferences(firewallRules, natRules, map[string]bool{})
+	if len(groups) != 3 || groups["group-2"] != "rule1" {
+		t.Errorf("unexpected group references: %v", groups)
+	}
+	if len(profiles) != 2 || profiles["profile-1"] != "rule1" {
+		t.Errorf("unexpected profile references: %v", profiles)
+	}
+}
+
+func Test_nsxtBulkDeleter(t *testing.T) {
+	for _, continueOnError := range []bool{false, true} {
+		var progress []int
+		deleter := nsxtBulkDeleter{
+			request: NsxtBulkDelete{
+				ContinueOnError: continueOnError,
+				Progress: func(result NsxtBulkDeleteResult, processed, total int) {
+					progress = append(progress, processed)
+					if total != 3 {
+						t.Errorf("expected total 3, got %d", total)
+					}
+				},
+			},
+			total:   3,
+			deleted: make(map[string]bool),
+		}
+
+		ids := []string{"ok-1", "fail", "ok-2"}
+		for _, id := range ids {
+			id := id
+			if !deleter.run(NsxtObjectNatRule, id, func() error {
+				if id == "fail" {
+					return fmt.Errorf("failure")
+				}
+				return nil
+			}) {
+				break
+			}
+		}
+
+		expectedResults := 2
+		if continueOnError {
+			expectedResults = 3
+		}
+		if len(deleter.results) != expectedResults || len(progress) != expectedResults {
+			t.Errorf("continueOnError %t: expected %d results, got %d (progress %v)", continueOnError, expectedResults,
+				len(deleter.results), progress)
+		}
+		if !deleter.deleted["ok-1"] || deleter.deleted["fail"] || deleter.deleted["ok-2"] != continueOnError {
+			t.Errorf("continueOnError %t: unexpected deleted objects %v", continueOnError, deleter.deleted)
+		}
+		if deleter.err() == nil {
+			t.Errorf("continueOnError %t: expected error", continueOnError)
+		}
+	}
+}
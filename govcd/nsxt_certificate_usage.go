@@ -0,0 +1,204 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// Types of objects that can consume a certificate from the certificate library
+const (
+	CertificateConsumerAlbVirtualService = "ALB Virtual Service"
+	CertificateConsumerAlbPool           = "ALB Pool"
+	CertificateConsumerIpSecVpnTunnel    = "IPsec VPN Tunnel"
+)
+
+// CertificateConsumer describes an NSX-T Edge Gateway object which references a certificate library item
+type CertificateConsumer struct {
+	EdgeGatewayId   string
+	EdgeGatewayName string
+	ConsumerType    string // One of the CertificateConsumer* values
+	ConsumerId      string
+	ConsumerName    string
+	// Field is the JSON field of the consumer holding the reference (e.g. "certificateRef", "caCertificateRefs")
+	Field string
+
+	// replace updates the consumer so that it references the certificate with the given ID instead
+	replace func(ctx context.Context, newCertificateId string) error
+}
+
+// GetCertificateConsumers lists the objects of all NSX-T Edge Gateways visible to the client which use the
+// certificate library item with the given ID. It is meant to be run before rotating or deleting a certificate.
+// The following consumers are checked:
+// * ALB Virtual Services (service certificate)
+// * ALB Pools (CA certificates)
+// * IPsec VPN Tunnels (local endpoint and CA certificates)
+func (vcdClient *VCDClient) GetCertificateConsumers(ctx context.Context, certificateId string) ([]*CertificateConsumer, error) {
+	if certificateId == "" {
+		return nil, fmt.Errorf("empty certificate ID")
+	}
+
+	edgeGateways, err := vcdClient.GetAllNsxtEdgeGateways(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateways: %w", err)
+	}
+
+	var consumers []*CertificateConsumer
+	for _, egw := range edgeGateways {
+		egwConsumers, err := vcdClient.getEdgeGatewayCertificateConsumers(ctx, egw, certificateId)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving certificate consumers of Edge Gateway '%s': %w", egw.EdgeGateway.Name, err)
+		}
+		consumers = append(consumers, egwConsumers...)
+	}
+	return consumers, nil
+}
+
+// RotateCertificate replaces the certificate library item oldId with newId in all the objects that use it, as
+// listed by GetCertificateConsumers. It returns the consumers that were updated. If an update fails, the process
+// stops and the consumers updated so far are returned together with the error. The old certificate is not deleted.
+func (vcdClient *VCDClient) RotateCertificate(ctx context.Context, oldId, newId string) ([]*CertificateConsumer, error) {
+	if oldId == "" || newId == "" {
+		return nil, fmt.Errorf("both old and new certificate IDs are required for rotation")
+	}
+	if oldId == newId {
+		return nil, fmt.Errorf("old and new certificate IDs are the same: %s", oldId)
+	}
+
+	// Make sure the new certificate exists before touching any consumer
+	_, err := vcdClient.Client.GetCertificateFromLibraryById(ctx, newId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving new certificate '%s': %w", newId, err)
+	}
+
+	consumers, err := vcdClient.GetCertificateConsumers(ctx, oldId)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []*CertificateConsumer
+	for _, consumer := range consumers {
+		util.Logger.Printf("[TRACE] RotateCertificate: updating %s '%s' (%s) on Edge Gateway '%s'",
+			consumer.ConsumerType, consumer.ConsumerName, consumer.Field, consumer.EdgeGatewayName)
+		err = consumer.replace(ctx, newId)
+		if err != nil {
+			return updated, fmt.Errorf("error replacing certificate in %s '%s': %w", consumer.ConsumerType, consumer.ConsumerName, err)
+		}
+		updated = append(updated, consumer)
+	}
+	return updated, nil
+}
+
+// getEdgeGatewayCertificateConsumers lists the objects of a single NSX-T Edge Gateway using certificateId
+func (vcdClient *VCDClient) getEdgeGatewayCertificateConsumers(ctx context.Context, egw *NsxtEdgeGateway, certificateId string) ([]*CertificateConsumer, error) {
+	var consumers []*CertificateConsumer
+	newConsumer := func(consumerType, id, name, field string) *CertificateConsumer {
+		return &CertificateConsumer{
+			EdgeGatewayId:   egw.EdgeGateway.ID,
+			EdgeGatewayName: egw.EdgeGateway.Name,
+			ConsumerType:    consumerType,
+			ConsumerId:      id,
+			ConsumerName:    name,
+			Field:           field,
+		}
+	}
+
+	tunnels, err := egw.GetAllIpSecVpnTunnels(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving IPsec VPN Tunnels: %w", err)
+	}
+	for _, tunnel := range tunnels {
+		tunnel := tunnel
+		config := tunnel.NsxtIpSecVpn
+		if config.CertificateRef != nil && config.CertificateRef.ID == certificateId {
+			consumer := newConsumer(CertificateConsumerIpSecVpnTunnel, config.ID, config.Name, "certificateRef")
+			consumer.replace = func(ctx context.Context, newCertificateId string) error {
+				config.CertificateRef = &types.OpenApiReference{ID: newCertificateId}
+				_, err := tunnel.Update(ctx, config)
+				return err
+			}
+			consumers = append(consumers, consumer)
+		}
+		if config.CaCertificateRef != nil && config.CaCertificateRef.ID == certificateId {
+			consumer := newConsumer(CertificateConsumerIpSecVpnTunnel, config.ID, config.Name, "caCertificateRef")
+			consumer.replace = func(ctx context.Context, newCertificateId string) error {
+				config.CaCertificateRef = &types.OpenApiReference{ID: newCertificateId}
+				_, err := tunnel.Update(ctx, config)
+				return err
+			}
+			consumers = append(consumers, consumer)
+		}
+	}
+
+	virtualServices, err := vcdClient.GetAllAlbVirtualServices(ctx, egw.EdgeGateway.ID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ALB Virtual Services: %w", err)
+	}
+	for _, virtualService := range virtualServices {
+		virtualService := virtualService
+		config := virtualService.NsxtAlbVirtualService
+		if config.CertificateRef != nil && config.CertificateRef.ID == certificateId {
+			consumer := newConsumer(CertificateConsumerAlbVirtualService, config.ID, config.Name, "certificateRef")
+			consumer.replace = func(ctx context.Context, newCertificateId string) error {
+				config.CertificateRef = &types.OpenApiReference{ID: newCertificateId}
+				_, err := virtualService.Update(ctx, config)
+				return err
+			}
+			consumers = append(consumers, consumer)
+		}
+	}
+
+	pools, err := vcdClient.GetAllAlbPools(ctx, egw.EdgeGateway.ID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ALB Pools: %w", err)
+	}
+	for _, pool := range pools {
+		pool := pool
+		config := pool.NsxtAlbPool
+		if !containsCertificateRef(config.CaCertificateRefs, certificateId) {
+			continue
+		}
+		consumer := newConsumer(CertificateConsumerAlbPool, config.ID, config.Name, "caCertificateRefs")
+		consumer.replace = func(ctx context.Context, newCertificateId string) error {
+			config.CaCertificateRefs = replaceCertificateRef(config.CaCertificateRefs, certificateId, newCertificateId)
+			_, err := pool.Update(ctx, config)
+			return err
+		}
+		consumers = append(consumers, consumer)
+	}
+
+	return consumers, nil
+}
+
+// containsCertificateRef returns true if certificateId is one of refs
+func containsCertificateRef(refs []types.OpenApiReference, certificateId string) bool {
+	for _, ref := range refs {
+		if ref.ID == certificateId {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceCertificateRef returns refs with oldId replaced by newId. If newId is already present, oldId is
+// removed instead, so that the result does not contain duplicates.
+func replaceCertificateRef(refs []types.OpenApiReference, oldId, newId string) []types.OpenApiReference {
+	hasNew := containsCertificateRef(refs, newId)
+	var result []types.OpenApiReference
+	for _, ref := range refs {
+		if ref.ID != oldId {
+			result = append(result, ref)
+			continue
+		}
+		if !hasNew {
+			result = append(result, types.OpenApiReference{ID: newId})
+		}
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_replaceCertificateRef(t *testing.T) {
+	tests := []struct {
+		name string
+		refs []types.OpenApiReference
+		want []types.OpenApiReference
+	}{
+		{
+			name: "single",
+			refs: []types.OpenApiReference{{ID: "old"}},
+			want: []types.OpenApiReference{{ID: "new"}},
+		},
+		{
+			name: "keepOthers",
+			refs: []types.OpenApiReference{{ID: "a"}, {ID: "old"}, {ID: "b"}},
+			want: []types.OpenApiReference{{ID: "a"}, {ID: "new"}, {ID: "b"}},
+		},
+		{
+			name: "newAlreadyPresent",
+			refs: []types.OpenApiReference{{ID: "new"}, {ID: "old"}},
+			want: []types.OpenApiReference{{ID: "new"}},
+		},
+		{
+			name: "oldMissing",
+			refs: []types.OpenApiReference{{ID: "a"}},
+			want: []types.OpenApiReference{{ID: "a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := replaceCertificateRef(tt.refs, "old", "new")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replaceCertificateRef() = %v, want %v", got, tt.want)
+			}
+			if containsCertificateRef(got, "old") {
+				t.Errorf("replaceCertificateRef() result still contains the old certificate: %v", got)
+			}
+		})
+	}
+}
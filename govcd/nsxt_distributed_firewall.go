@@ -42,7 +42,7 @@ func (vdcGroup *VdcGroup) GetDistributedFirewall(ctx context.Context) (*Distribu
 
 	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, returnObject.DistributedFirewallRuleContainer, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving Distributed Firewall rules: %s", err)
+		return nil, fmt.Errorf("error retrieving Distributed Firewall rules: %w", err)
 	}
 
 	return returnObject, nil
@@ -74,7 +74,7 @@ func (vdcGroup *VdcGroup) UpdateDistributedFirewall(ctx context.Context, dfwRule
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, dfwRules, returnObject.DistributedFirewallRuleContainer, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Distributed Firewall rules: %s", err)
+		return nil, fmt.Errorf("error updating Distributed Firewall rules: %w", err)
 	}
 
 	return returnObject, nil
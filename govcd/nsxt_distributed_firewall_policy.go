@@ -0,0 +1,453 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// DistributedFirewallPolicy is a portable representation of the Distributed Firewall rules of a VDC Group, meant to
+// be stored as code and applied to the same or to another VDC Group. Rules keep their order, and refer to firewall
+// groups and profiles by name instead of ID
+type DistributedFirewallPolicy struct {
+	Rules []*DistributedFirewallPolicyRule `json:"rules"`
+}
+
+// DistributedFirewallPolicyRule is a Distributed Firewall rule of a DistributedFirewallPolicy. Empty lists of sources,
+// destinations and profiles mean 'Any'
+type DistributedFirewallPolicyRule struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Comments    string `json:"comments,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Action      string `json:"action"`     // ALLOW, DROP or REJECT
+	Direction   string `json:"direction"`  // IN, OUT or IN_OUT
+	IpProtocol  string `json:"ipProtocol"` // IPV4, IPV6 or IPV4_IPV6
+	Logging     bool   `json:"logging"`
+
+	Sources              []string `json:"sources,omitempty"` // Names of firewall groups of the VDC Group
+	SourcesExcluded      bool     `json:"sourcesExcluded,omitempty"`
+	Destinations         []string `json:"destinations,omitempty"` // Names of firewall groups of the VDC Group
+	DestinationsExcluded bool     `json:"destinationsExcluded,omitempty"`
+
+	ApplicationPortProfiles []string `json:"applicationPortProfiles,omitempty"`
+	NetworkContextProfiles  []string `json:"networkContextProfiles,omitempty"`
+}
+
+// DistributedFirewallPolicyDiff is the difference between the rules of a VDC Group and a DistributedFirewallPolicy,
+// with rules identified by name
+type DistributedFirewallPolicyDiff struct {
+	Added     []string // Rules of the policy that don't exist in the VDC Group
+	Removed   []string // Rules of the VDC Group that are not in the policy
+	Changed   []string // Rules whose settings are different
+	Reordered bool     // Whether the rules existing on both sides are in a different order
+}
+
+// HasChanges returns true when applying the policy would change the rules of the VDC Group
+func (diff *DistributedFirewallPolicyDiff) HasChanges() bool {
+	return len(diff.Added) > 0 || len(diff.Removed) > 0 || len(diff.Changed) > 0 || diff.Reordered
+}
+
+// String returns a human-readable summary of the differences
+func (diff *DistributedFirewallPolicyDiff) String() string {
+	if !diff.HasChanges() {
+		return "no changes"
+	}
+	var lines []string
+	for _, name := range diff.Added {
+		lines = append(lines, "+ "+name)
+	}
+	for _, name := range diff.Removed {
+		lines = append(lines, "- "+name)
+	}
+	for _, name := range diff.Changed {
+		lines = append(lines, "~ "+name)
+	}
+	if diff.Reordered {
+		lines = append(lines, "rules are reordered")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ExportDistributedFirewallPolicy returns the Distributed Firewall rules of the VDC Group as a
+// DistributedFirewallPolicy
+func (vdcGroup *VdcGroup) ExportDistributedFirewallPolicy(ctx context.Context) (*DistributedFirewallPolicy, error) {
+	firewall, err := vdcGroup.GetDistributedFirewall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newDistributedFirewallPolicy(firewall.DistributedFirewallRuleContainer.Values), nil
+}
+
+// PreviewDistributedFirewallPolicy validates the policy and returns the changes that
+// ImportDistributedFirewallPolicy would make to the VDC Group, without applying them
+func (vdcGroup *VdcGroup) PreviewDistributedFirewallPolicy(ctx context.Context, policy *DistributedFirewallPolicy) (*DistributedFirewallPolicyDiff, error) {
+	err := policy.Validate()
+	if err != nil {
+		return nil, err
+	}
+	current, err := vdcGroup.ExportDistributedFirewallPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Names are resolved to detect references to missing objects before the import
+	_, err = vdcGroup.resolveDistributedFirewallPolicy(ctx, policy, nil)
+	if err != nil {
+		return nil, err
+	}
+	return diffDistributedFirewallPolicies(current, policy), nil
+}
+
+// ImportDistributedFirewallPolicy replaces the Distributed Firewall rules of the VDC Group with the ones of the policy,
+// after validating it and resolving the names of firewall groups and profiles in the VDC Group. Existing rules with
+// the same name as a rule of the policy are updated in place, keeping their ID.
+//
+// Application Port Profiles and Network Context Profiles are searched in the context of the VDC Group. When a name
+// exists in several scopes, the TENANT profile is preferred to the PROVIDER one, which is preferred to the SYSTEM one
+func (vdcGroup *VdcGroup) ImportDistributedFirewallPolicy(ctx context.Context, policy *DistributedFirewallPolicy) (*DistributedFirewall, error) {
+	err := policy.Validate()
+	if err != nil {
+		return nil, err
+	}
+	firewall, err := vdcGroup.GetDistributedFirewall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := vdcGroup.resolveDistributedFirewallPolicy(ctx, policy, firewall.DistributedFirewallRuleContainer.Values)
+	if err != nil {
+		return nil, err
+	}
+	return vdcGroup.UpdateDistributedFirewall(ctx, &types.DistributedFirewallRules{Values: rules})
+}
+
+// ToJson returns the policy as indented JSON
+func (policy *DistributedFirewallPolicy) ToJson() ([]byte, error) {
+	return json.MarshalIndent(policy, "", "  ")
+}
+
+// ParseDistributedFirewallPolicy reads a policy from JSON, rejecting unknown fields, and validates it
+func ParseDistributedFirewallPolicy(data []byte) (*DistributedFirewallPolicy, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	policy := &DistributedFirewallPolicy{}
+	err := decoder.Decode(policy)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Distributed Firewall policy: %w", err)
+	}
+	err = policy.Validate()
+	if err != nil {
+		return nil, err
+	}
+	policy.normalize()
+	return policy, nil
+}
+
+// Validate checks that the rules have unique names and valid settings
+func (policy *DistributedFirewallPolicy) Validate() error {
+	if policy == nil {
+		return fmt.Errorf("the Distributed Firewall policy cannot be nil")
+	}
+	var errs []string
+	names := make(map[string]bool)
+	for index, rule := range policy.Rules {
+		if rule == nil {
+			errs = append(errs, fmt.Sprintf("rule %d is empty", index+1))
+			continue
+		}
+		if rule.Name == "" {
+			errs = append(errs, fmt.Sprintf("rule %d has no name", index+1))
+		} else if names[rule.Name] {
+			errs = append(errs, fmt.Sprintf("rule name '%s' is used more than once", rule.Name))
+		}
+		names[rule.Name] = true
+
+		for _, setting := range []struct {
+			field, value string
+			valid        []string
+		}{
+			{"action", rule.Action, []string{"ALLOW", "DROP", "REJECT"}},
+			{"direction", rule.Direction, []string{"IN", "OUT", "IN_OUT"}},
+			{"ipProtocol", rule.IpProtocol, []string{"IPV4", "IPV6", "IPV4_IPV6"}},
+		} {
+			if !contains(setting.value, setting.valid) {
+				errs = append(errs, fmt.Sprintf("rule '%s' has invalid %s '%s' (valid values: %s)", rule.Name,
+					setting.field, setting.value, strings.Join(setting.valid, ", ")))
+			}
+		}
+		if rule.SourcesExcluded && len(rule.Sources) == 0 {
+			errs = append(errs, fmt.Sprintf("rule '%s' excludes sources without listing any", rule.Name))
+		}
+		if rule.DestinationsExcluded && len(rule.Destinations) == 0 {
+			errs = append(errs, fmt.Sprintf("rule '%s' excludes destinations without listing any", rule.Name))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid Distributed Firewall policy:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// normalize sorts the names in the lists of each rule, as their order is not significant
+func (policy *DistributedFirewallPolicy) normalize() {
+	for _, rule := range policy.Rules {
+		for _, names := range [][]string{rule.Sources, rule.Destinations, rule.ApplicationPortProfiles, rule.NetworkContextProfiles} {
+			sort.Strings(names)
+		}
+	}
+}
+
+// newDistributedFirewallPolicy converts Distributed Firewall rules into a normalized policy
+func newDistributedFirewallPolicy(rules []*types.DistributedFirewallRule) *DistributedFirewallPolicy {
+	policy := &DistributedFirewallPolicy{Rules: make([]*DistributedFirewallPolicyRule, 0, len(rules))}
+	for _, rule := range rules {
+		action := rule.ActionValue
+		if action == "" {
+			action = rule.Action
+		}
+		policy.Rules = append(policy.Rules, &DistributedFirewallPolicyRule{
+			Name:                    rule.Name,
+			Description:             rule.Description,
+			Comments:                rule.Comments,
+			Enabled:                 rule.Enabled,
+			Action:                  action,
+			Direction:               rule.Direction,
+			IpProtocol:              rule.IpProtocol,
+			Logging:                 rule.Logging,
+			Sources:                 openApiReferenceNames(rule.SourceFirewallGroups),
+			SourcesExcluded:         rule.SourceGroupsExcluded != nil && *rule.SourceGroupsExcluded,
+			Destinations:            openApiReferenceNames(rule.DestinationFirewallGroups),
+			DestinationsExcluded:    rule.DestinationGroupsExcluded != nil && *rule.DestinationGroupsExcluded,
+			ApplicationPortProfiles: openApiReferenceNames(rule.ApplicationPortProfiles),
+			NetworkContextProfiles:  openApiReferenceNames(rule.NetworkContextProfiles),
+		})
+	}
+	policy.normalize()
+	return policy
+}
+
+// openApiReferenceNames returns the names of the references, or nil when there are none
+func openApiReferenceNames(references []types.OpenApiReference) []string {
+	var names []string
+	for _, reference := range references {
+		names = append(names, reference.Name)
+	}
+	return names
+}
+
+// diffDistributedFirewallPolicies compares two policies, identifying rules by name
+func diffDistributedFirewallPolicies(current, desired *DistributedFirewallPolicy) *DistributedFirewallPolicyDiff {
+	diff := &DistributedFirewallPolicyDiff{}
+	currentRules := make(map[string]*DistributedFirewallPolicyRule)
+	for _, rule := range current.Rules {
+		currentRules[rule.Name] = rule
+	}
+	desiredNames := make(map[string]bool)
+	var currentOrder, desiredOrder []string
+
+	normalizedDesired := newDistributedFirewallPolicyCopy(desired)
+	for _, rule := range normalizedDesired.Rules {
+		desiredNames[rule.Name] = true
+		currentRule, found := currentRules[rule.Name]
+		if !found {
+			diff.Added = append(diff.Added, rule.Name)
+			continue
+		}
+		desiredOrder = append(desiredOrder, rule.Name)
+		currentJson, _ := json.Marshal(currentRule)
+		desiredJson, _ := json.Marshal(rule)
+		if !bytes.Equal(currentJson, desiredJson) {
+			diff.Changed = append(diff.Changed, rule.Name)
+		}
+	}
+	for _, rule := range current.Rules {
+		if !desiredNames[rule.Name] {
+			diff.Removed = append(diff.Removed, rule.Name)
+			continue
+		}
+		currentOrder = append(currentOrder, rule.Name)
+	}
+	diff.Reordered = strings.Join(currentOrder, "\n") != strings.Join(desiredOrder, "\n")
+	return diff
+}
+
+// newDistributedFirewallPolicyCopy returns a normalized copy of the policy, leaving the original untouched
+func newDistributedFirewallPolicyCopy(policy *DistributedFirewallPolicy) *DistributedFirewallPolicy {
+	policyCopy := &DistributedFirewallPolicy{}
+	for _, rule := range policy.Rules {
+		ruleCopy := *rule
+		for _, names := range []*[]string{&ruleCopy.Sources, &ruleCopy.Destinations, &ruleCopy.ApplicationPortProfiles, &ruleCopy.NetworkContextProfiles} {
+			if len(*names) == 0 {
+				*names = nil
+				continue
+			}
+			*names = append([]string{}, *names...)
+		}
+		policyCopy.Rules = append(policyCopy.Rules, &ruleCopy)
+	}
+	policyCopy.normalize()
+	return policyCopy
+}
+
+// dfwPolicyNameIndex maps names to the IDs of the objects with that name, ranked by preference (lowest first)
+type dfwPolicyNameIndex struct {
+	kind    string
+	entries map[string][]dfwPolicyIndexEntry
+}
+
+type dfwPolicyIndexEntry struct {
+	id   string
+	rank int
+}
+
+func newDfwPolicyNameIndex(kind string) *dfwPolicyNameIndex {
+	return &dfwPolicyNameIndex{kind: kind, entries: make(map[string][]dfwPolicyIndexEntry)}
+}
+
+func (index *dfwPolicyNameIndex) add(name, id string, rank int) {
+	index.entries[name] = append(index.entries[name], dfwPolicyIndexEntry{id: id, rank: rank})
+}
+
+// resolve returns the references for the given names, failing for missing or ambiguous names
+func (index *dfwPolicyNameIndex) resolve(names []string) ([]types.OpenApiReference, error) {
+	var references []types.OpenApiReference
+	for _, name := range names {
+		candidates := index.entries[name]
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("%s: %s '%s'", ErrorEntityNotFound, index.kind, name)
+		}
+		best := candidates[0]
+		ambiguous := false
+		for _, candidate := range candidates[1:] {
+			switch {
+			case candidate.rank < best.rank:
+				best = candidate
+				ambiguous = false
+			case candidate.rank == best.rank:
+				ambiguous = true
+			}
+		}
+		if ambiguous {
+			return nil, fmt.Errorf("%s name '%s' is ambiguous", index.kind, name)
+		}
+		references = append(references, types.OpenApiReference{ID: best.id, Name: name})
+	}
+	return references, nil
+}
+
+// dfwPolicyProfileRanks sets the preference of profiles with the same name in different scopes
+var dfwPolicyProfileRanks = map[string]int{
+	types.ApplicationPortProfileScopeTenant:   0,
+	types.ApplicationPortProfileScopeProvider: 1,
+	types.ApplicationPortProfileScopeSystem:   2,
+}
+
+// resolveDistributedFirewallPolicy converts the policy rules into Distributed Firewall rules of the VDC Group. The
+// names of firewall groups and profiles are resolved only when the policy uses them. Existing rules with the same
+// name give their ID and version to the new rules
+func (vdcGroup *VdcGroup) resolveDistributedFirewallPolicy(ctx context.Context, policy *DistributedFirewallPolicy, existingRules []*types.DistributedFirewallRule) ([]*types.DistributedFirewallRule, error) {
+	usesFirewallGroups, usesAppPortProfiles, usesNetworkContextProfiles := false, false, false
+	for _, rule := range policy.Rules {
+		usesFirewallGroups = usesFirewallGroups || len(rule.Sources) > 0 || len(rule.Destinations) > 0
+		usesAppPortProfiles = usesAppPortProfiles || len(rule.ApplicationPortProfiles) > 0
+		usesNetworkContextProfiles = usesNetworkContextProfiles || len(rule.NetworkContextProfiles) > 0
+	}
+
+	firewallGroups := newDfwPolicyNameIndex("firewall group")
+	if usesFirewallGroups {
+		groups, err := getAllNsxtFirewallGroups(ctx, vdcGroup.client,
+			queryParameterFilterAnd("ownerRef.id=="+vdcGroup.VdcGroup.Id, nil))
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving firewall groups of VDC Group '%s': %w", vdcGroup.VdcGroup.Name, err)
+		}
+		for _, group := range groups {
+			firewallGroups.add(group.NsxtFirewallGroup.Name, group.NsxtFirewallGroup.ID, 0)
+		}
+	}
+	appPortProfiles := newDfwPolicyNameIndex("Application Port Profile")
+	if usesAppPortProfiles {
+		profiles, err := getAllNsxtAppPortProfiles(ctx, vdcGroup.client,
+			queryParameterFilterAnd("_context=="+vdcGroup.VdcGroup.Id, nil))
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Application Port Profiles of VDC Group '%s': %w", vdcGroup.VdcGroup.Name, err)
+		}
+		for _, profile := range profiles {
+			appPortProfiles.add(profile.NsxtAppPortProfile.Name, profile.NsxtAppPortProfile.ID,
+				dfwPolicyProfileRanks[profile.NsxtAppPortProfile.Scope])
+		}
+	}
+	networkContextProfiles := newDfwPolicyNameIndex("Network Context Profile")
+	if usesNetworkContextProfiles {
+		profiles, err := GetAllNetworkContextProfiles(ctx, vdcGroup.client,
+			queryParameterFilterAnd("_context=="+vdcGroup.VdcGroup.Id, nil))
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Network Context Profiles of VDC Group '%s': %w", vdcGroup.VdcGroup.Name, err)
+		}
+		for _, profile := range profiles {
+			networkContextProfiles.add(profile.Name, profile.ID, dfwPolicyProfileRanks[profile.Scope])
+		}
+	}
+
+	return buildDistributedFirewallRules(policy, existingRules, firewallGroups, appPortProfiles, networkContextProfiles)
+}
+
+// buildDistributedFirewallRules converts the policy rules, in order, using the given indexes to resolve names
+func buildDistributedFirewallRules(policy *DistributedFirewallPolicy, existingRules []*types.DistributedFirewallRule,
+	firewallGroups, appPortProfiles, networkContextProfiles *dfwPolicyNameIndex) ([]*types.DistributedFirewallRule, error) {
+	existingByName := make(map[string]*types.DistributedFirewallRule)
+	for _, rule := range existingRules {
+		existingByName[rule.Name] = rule
+	}
+
+	rules := make([]*types.DistributedFirewallRule, 0, len(policy.Rules))
+	for _, policyRule := range policy.Rules {
+		rule := &types.DistributedFirewallRule{
+			Name:        policyRule.Name,
+			Description: policyRule.Description,
+			Comments:    policyRule.Comments,
+			Enabled:     policyRule.Enabled,
+			ActionValue: policyRule.Action,
+			Direction:   policyRule.Direction,
+			IpProtocol:  policyRule.IpProtocol,
+			Logging:     policyRule.Logging,
+		}
+		// Exclusion flags are only sent when set, as they are not supported before API 36.2
+		if policyRule.SourcesExcluded {
+			rule.SourceGroupsExcluded = addrOf(true)
+		}
+		if policyRule.DestinationsExcluded {
+			rule.DestinationGroupsExcluded = addrOf(true)
+		}
+		if existing, found := existingByName[policyRule.Name]; found {
+			rule.ID = existing.ID
+			rule.Version = existing.Version
+		}
+
+		var err error
+		for _, field := range []struct {
+			names  []string
+			index  *dfwPolicyNameIndex
+			target *[]types.OpenApiReference
+		}{
+			{policyRule.Sources, firewallGroups, &rule.SourceFirewallGroups},
+			{policyRule.Destinations, firewallGroups, &rule.DestinationFirewallGroups},
+			{policyRule.ApplicationPortProfiles, appPortProfiles, &rule.ApplicationPortProfiles},
+			{policyRule.NetworkContextProfiles, networkContextProfiles, &rule.NetworkContextProfiles},
+		} {
+			*field.target, err = field.index.resolve(field.names)
+			if err != nil {
+				return nil, fmt.Errorf("error in rule '%s': %w", policyRule.Name, err)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
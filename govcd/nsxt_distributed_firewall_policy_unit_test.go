@@ -0,0 +1,157 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func makeTestDfwPolicyRule(name string) *DistributedFirewallPolicyRule {
+	return &DistributedFirewallPolicyRule{
+		Name:       name,
+		Enabled:    true,
+		Action:     "ALLOW",
+		Direction:  "IN_OUT",
+		IpProtocol: "IPV4_IPV6",
+	}
+}
+
+func TestDistributedFirewallPolicy_Validate(t *testing.T) {
+	valid := &DistributedFirewallPolicy{Rules: []*DistributedFirewallPolicyRule{makeTestDfwPolicyRule("a")}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	invalidAction := makeTestDfwPolicyRule("b")
+	invalidAction.Action = "allow"
+	excluded := makeTestDfwPolicyRule("c")
+	excluded.SourcesExcluded = true
+	invalid := &DistributedFirewallPolicy{Rules: []*DistributedFirewallPolicyRule{
+		makeTestDfwPolicyRule("a"), makeTestDfwPolicyRule("a"), invalidAction, excluded, {Name: ""},
+	}}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	for _, want := range []string{"'a' is used more than once", "invalid action 'allow'", "excludes sources", "rule 5 has no name"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestParseDistributedFirewallPolicy(t *testing.T) {
+	policy, err := ParseDistributedFirewallPolicy([]byte(`{"rules": [{"name": "a", "enabled": true, "action": "DROP",
+		"direction": "IN", "ipProtocol": "IPV4", "logging": false, "sources": ["z", "b"]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(policy.Rules[0].Sources, []string{"b", "z"}) {
+		t.Errorf("sources were not normalized: %v", policy.Rules[0].Sources)
+	}
+
+	_, err = ParseDistributedFirewallPolicy([]byte(`{"rules": [{"name": "a", "unknown": 1}]}`))
+	if err == nil {
+		t.Errorf("expected error for unknown field")
+	}
+}
+
+func Test_newDistributedFirewallPolicy(t *testing.T) {
+	policy := newDistributedFirewallPolicy([]*types.DistributedFirewallRule{
+		{
+			Name:                      "rule1",
+			Action:                    "ALLOW",
+			ActionValue:               "REJECT",
+			Direction:                 "IN",
+			IpProtocol:                "IPV4",
+			SourceFirewallGroups:      []types.OpenApiReference{{ID: "2", Name: "web"}, {ID: "1", Name: "app"}},
+			DestinationGroupsExcluded: addrOf(true),
+			DestinationFirewallGroups: []types.OpenApiReference{{ID: "3", Name: "db"}},
+		},
+	})
+	rule := policy.Rules[0]
+	if rule.Action != "REJECT" {
+		t.Errorf("got action %s, want REJECT", rule.Action)
+	}
+	if !reflect.DeepEqual(rule.Sources, []string{"app", "web"}) || !rule.DestinationsExcluded || rule.SourcesExcluded {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if rule.ApplicationPortProfiles != nil {
+		t.Errorf("expected no Application Port Profiles, got %v", rule.ApplicationPortProfiles)
+	}
+}
+
+func Test_diffDistributedFirewallPolicies(t *testing.T) {
+	current := &DistributedFirewallPolicy{Rules: []*DistributedFirewallPolicyRule{
+		makeTestDfwPolicyRule("a"), makeTestDfwPolicyRule("b"), makeTestDfwPolicyRule("c"),
+	}}
+	same := newDistributedFirewallPolicyCopy(current)
+	same.Rules[0].Sources = []string{}
+	if diff := diffDistributedFirewallPolicies(current, same); diff.HasChanges() {
+		t.Errorf("unexpected changes: %s", diff)
+	}
+
+	changedB := makeTestDfwPolicyRule("b")
+	changedB.Logging = true
+	desired := &DistributedFirewallPolicy{Rules: []*DistributedFirewallPolicyRule{
+		changedB, makeTestDfwPolicyRule("a"), makeTestDfwPolicyRule("d"),
+	}}
+	diff := diffDistributedFirewallPolicies(current, desired)
+	if !reflect.DeepEqual(diff.Added, []string{"d"}) || !reflect.DeepEqual(diff.Removed, []string{"c"}) ||
+		!reflect.DeepEqual(diff.Changed, []string{"b"}) || !diff.Reordered {
+		t.Errorf("unexpected diff %+v", diff)
+	}
+}
+
+func Test_buildDistributedFirewallRules(t *testing.T) {
+	firewallGroups := newDfwPolicyNameIndex("firewall group")
+	firewallGroups.add("web", "urn:fg:web", 0)
+	firewallGroups.add("dup", "urn:fg:dup1", 0)
+	firewallGroups.add("dup", "urn:fg:dup2", 0)
+	appPortProfiles := newDfwPolicyNameIndex("Application Port Profile")
+	appPortProfiles.add("SSH", "urn:app:system", dfwPolicyProfileRanks[types.ApplicationPortProfileScopeSystem])
+	appPortProfiles.add("SSH", "urn:app:tenant", dfwPolicyProfileRanks[types.ApplicationPortProfileScopeTenant])
+	networkContextProfiles := newDfwPolicyNameIndex("Network Context Profile")
+
+	rule := makeTestDfwPolicyRule("a")
+	rule.Sources = []string{"web"}
+	rule.SourcesExcluded = true
+	rule.ApplicationPortProfiles = []string{"SSH"}
+	policy := &DistributedFirewallPolicy{Rules: []*DistributedFirewallPolicyRule{rule, makeTestDfwPolicyRule("b")}}
+	existing := []*types.DistributedFirewallRule{
+		{ID: "urn:rule:a", Name: "a", Version: &types.DistributedFirewallRuleVersion{Version: 3}},
+	}
+
+	rules, err := buildDistributedFirewallRules(policy, existing, firewallGroups, appPortProfiles, networkContextProfiles)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rules) != 2 || rules[0].ID != "urn:rule:a" || rules[0].Version.Version != 3 || rules[1].ID != "" {
+		t.Errorf("existing rule IDs were not kept: %+v", rules)
+	}
+	if rules[0].SourceFirewallGroups[0].ID != "urn:fg:web" || rules[0].SourceGroupsExcluded == nil {
+		t.Errorf("unexpected sources %+v", rules[0].SourceFirewallGroups)
+	}
+	if rules[0].ApplicationPortProfiles[0].ID != "urn:app:tenant" {
+		t.Errorf("got Application Port Profile %s, want the TENANT one", rules[0].ApplicationPortProfiles[0].ID)
+	}
+	if rules[0].DestinationGroupsExcluded != nil || rules[0].ActionValue != "ALLOW" {
+		t.Errorf("unexpected rule %+v", rules[0])
+	}
+
+	for _, names := range [][]string{{"dup"}, {"missing"}} {
+		rule.Destinations = names
+		_, err = buildDistributedFirewallRules(policy, nil, firewallGroups, appPortProfiles, networkContextProfiles)
+		if err == nil {
+			t.Errorf("expected error for destinations %v", names)
+		}
+	}
+}
@@ -57,6 +57,89 @@ func (vcd *TestVCD) Test_NsxtDistributedFirewallRules(check *C) {
 	check.Assert(err, IsNil)
 }
 
+// Test_NsxtDistributedFirewallPolicy imports a Distributed Firewall policy into a VDC Group, exports it back and
+// previews changes
+func (vcd *TestVCD) Test_NsxtDistributedFirewallPolicy(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeGateways)
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	vdc, vdcGroup := test_CreateVdcGroup(check, adminOrg, vcd)
+	defer func() {
+		err = vdcGroup.Delete(ctx)
+		check.Assert(err, IsNil)
+		err = vdc.DeleteWait(ctx, true, true)
+		check.Assert(err, IsNil)
+	}()
+
+	_, err = vdcGroup.ActivateDfw(ctx)
+	check.Assert(err, IsNil)
+	ipSet := preCreateVdcGroupIpSet(check, vcd, vdcGroup.VdcGroup.Id, vdc)
+
+	policy := &DistributedFirewallPolicy{Rules: []*DistributedFirewallPolicyRule{
+		{
+			Name:                    check.TestName() + "-ssh",
+			Enabled:                 true,
+			Action:                  "ALLOW",
+			Direction:               "IN",
+			IpProtocol:              "IPV4",
+			Sources:                 []string{ipSet.NsxtFirewallGroup.Name},
+			ApplicationPortProfiles: []string{"SSH"},
+		},
+		{
+			Name:       check.TestName() + "-drop",
+			Enabled:    true,
+			Action:     "DROP",
+			Direction:  "IN_OUT",
+			IpProtocol: "IPV4_IPV6",
+			Logging:    true,
+		},
+	}}
+
+	diff, err := vdcGroup.PreviewDistributedFirewallPolicy(ctx, policy)
+	check.Assert(err, IsNil)
+	check.Assert(len(diff.Added), Equals, 2)
+
+	_, err = vdcGroup.ImportDistributedFirewallPolicy(ctx, policy)
+	check.Assert(err, IsNil)
+
+	exported, err := vdcGroup.ExportDistributedFirewallPolicy(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(exported.Rules, DeepEquals, policy.Rules)
+
+	jsonPolicy, err := exported.ToJson()
+	check.Assert(err, IsNil)
+	parsed, err := ParseDistributedFirewallPolicy(jsonPolicy)
+	check.Assert(err, IsNil)
+	diff, err = vdcGroup.PreviewDistributedFirewallPolicy(ctx, parsed)
+	check.Assert(err, IsNil)
+	check.Assert(diff.HasChanges(), Equals, false)
+
+	// Swapping the rules and changing one of them
+	parsed.Rules[0], parsed.Rules[1] = parsed.Rules[1], parsed.Rules[0]
+	parsed.Rules[0].Logging = false
+	diff, err = vdcGroup.PreviewDistributedFirewallPolicy(ctx, parsed)
+	check.Assert(err, IsNil)
+	check.Assert(diff.Changed, DeepEquals, []string{check.TestName() + "-drop"})
+	check.Assert(diff.Reordered, Equals, true)
+
+	// Unknown names are rejected before any change
+	parsed.Rules[1].Destinations = []string{"missing-" + check.TestName()}
+	_, err = vdcGroup.ImportDistributedFirewallPolicy(ctx, parsed)
+	check.Assert(err, NotNil)
+
+	err = vdcGroup.DeleteAllDistributedFirewallRules(ctx)
+	check.Assert(err, IsNil)
+	_, err = vdcGroup.DisableDefaultPolicy(ctx)
+	check.Assert(err, IsNil)
+	_, err = vdcGroup.DeactivateDfw(ctx)
+	check.Assert(err, IsNil)
+	err = ipSet.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
 func test_NsxtDistributedFirewallRules(vcd *TestVCD, check *C, vdcGroupId string, vcdClient *VCDClient, vdc *Vdc) {
 	adminOrg, err := vcdClient.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
 	check.Assert(adminOrg, NotNil)
@@ -18,6 +18,7 @@ import (
 type NsxtEdgeGateway struct {
 	EdgeGateway *types.OpenAPIEdgeGateway
 	client      *Client
+	etag        string // ETag of the last retrieval with RefreshIfModified
 }
 
 // GetNsxtEdgeGatewayById allows retrieving NSX-T edge gateway by ID for Org admins
@@ -47,6 +48,23 @@ func (vdc *Vdc) GetNsxtEdgeGatewayById(ctx context.Context, id string) (*NsxtEdg
 	return egw, nil
 }
 
+// GetNsxtEdgeGatewayById allows retrieving NSX-T edge gateway by ID for specific VDC Group
+func (vdcGroup *VdcGroup) GetNsxtEdgeGatewayById(ctx context.Context, id string) (*NsxtEdgeGateway, error) {
+	params := url.Values{}
+	filterParams := queryParameterFilterAnd("ownerRef.id=="+vdcGroup.VdcGroup.Id, params)
+	egw, err := getNsxtEdgeGatewayById(ctx, vdcGroup.client, id, filterParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if egw.EdgeGateway.OwnerRef.ID != vdcGroup.VdcGroup.Id {
+		return nil, fmt.Errorf("%s: no NSX-T Edge Gateway with ID '%s' found in VDC Group '%s'",
+			ErrorEntityNotFound, id, vdcGroup.VdcGroup.Id)
+	}
+
+	return egw, nil
+}
+
 // GetNsxtEdgeGatewayByName allows retrieving NSX-T edge gateway by Name for Org admins
 func (adminOrg *AdminOrg) GetNsxtEdgeGatewayByName(ctx context.Context, name string) (*NsxtEdgeGateway, error) {
 	queryParameters := url.Values{}
@@ -54,7 +72,7 @@ func (adminOrg *AdminOrg) GetNsxtEdgeGatewayByName(ctx context.Context, name str
 
 	allEdges, err := adminOrg.GetAllNsxtEdgeGateways(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", name, err)
 	}
 
 	onlyNsxtEdges := filterOnlyNsxtEdges(allEdges)
@@ -69,7 +87,7 @@ func (org *Org) GetNsxtEdgeGatewayByName(ctx context.Context, name string) (*Nsx
 
 	allEdges, err := org.GetAllNsxtEdgeGateways(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", name, err)
 	}
 
 	onlyNsxtEdges := filterOnlyNsxtEdges(allEdges)
@@ -89,7 +107,7 @@ func (org *Org) GetNsxtEdgeGatewayByNameAndOwnerId(ctx context.Context, edgeGate
 
 	allEdges, err := org.GetAllNsxtEdgeGateways(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", edgeGatewayName, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", edgeGatewayName, err)
 	}
 
 	onlyNsxtEdges := filterOnlyNsxtEdges(allEdges)
@@ -104,7 +122,7 @@ func (vdc *Vdc) GetNsxtEdgeGatewayByName(ctx context.Context, name string) (*Nsx
 
 	allEdges, err := vdc.GetAllNsxtEdgeGateways(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", name, err)
 	}
 
 	return returnSingleNsxtEdgeGateway(name, allEdges)
@@ -121,12 +139,64 @@ func (vdcGroup *VdcGroup) GetNsxtEdgeGatewayByName(ctx context.Context, name str
 
 	allEdges, err := vdcGroup.GetAllNsxtEdgeGateways(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Edge Gateway by name '%s': %w", name, err)
 	}
 
 	return returnSingleNsxtEdgeGateway(name, allEdges)
 }
 
+// GetNsxtEdgeGatewayByNameOrId retrieves an NSX-T Edge Gateway by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (adminOrg *AdminOrg) GetNsxtEdgeGatewayByNameOrId(ctx context.Context, identifier string) (*NsxtEdgeGateway, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return adminOrg.GetNsxtEdgeGatewayByName(ctx, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) { return adminOrg.GetNsxtEdgeGatewayById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtEdgeGateway), err
+}
+
+// GetNsxtEdgeGatewayByNameOrId retrieves an NSX-T Edge Gateway by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (org *Org) GetNsxtEdgeGatewayByNameOrId(ctx context.Context, identifier string) (*NsxtEdgeGateway, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) { return org.GetNsxtEdgeGatewayByName(ctx, name) }
+	getById := func(id string, refresh bool) (interface{}, error) { return org.GetNsxtEdgeGatewayById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtEdgeGateway), err
+}
+
+// GetNsxtEdgeGatewayByNameOrId retrieves an NSX-T Edge Gateway by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vdc *Vdc) GetNsxtEdgeGatewayByNameOrId(ctx context.Context, identifier string) (*NsxtEdgeGateway, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) { return vdc.GetNsxtEdgeGatewayByName(ctx, name) }
+	getById := func(id string, refresh bool) (interface{}, error) { return vdc.GetNsxtEdgeGatewayById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtEdgeGateway), err
+}
+
+// GetNsxtEdgeGatewayByNameOrId retrieves an NSX-T Edge Gateway by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vdcGroup *VdcGroup) GetNsxtEdgeGatewayByNameOrId(ctx context.Context, identifier string) (*NsxtEdgeGateway, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return vdcGroup.GetNsxtEdgeGatewayByName(ctx, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) { return vdcGroup.GetNsxtEdgeGatewayById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtEdgeGateway), err
+}
+
 // GetAllNsxtEdgeGateways allows to retrieve all NSX-T Edge Gateways
 func (vcdClient *VCDClient) GetAllNsxtEdgeGateways(ctx context.Context, queryParameters url.Values) ([]*NsxtEdgeGateway, error) {
 	if vcdClient == nil {
@@ -181,7 +251,7 @@ func (adminOrg *AdminOrg) CreateNsxtEdgeGateway(ctx context.Context, edgeGateway
 
 	err = adminOrg.client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, edgeGatewayConfig, returnEgw.EdgeGateway, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error creating Edge Gateway: %w", err)
 	}
 
 	return returnEgw, nil
@@ -195,7 +265,7 @@ func (egw *NsxtEdgeGateway) Refresh(ctx context.Context) error {
 
 	refreshedEdge, err := getNsxtEdgeGatewayById(ctx, egw.client, egw.EdgeGateway.ID, nil)
 	if err != nil {
-		return fmt.Errorf("error refreshing NSX-T Edge Gateway: %s", err)
+		return fmt.Errorf("error refreshing NSX-T Edge Gateway: %w", err)
 	}
 	egw.EdgeGateway = refreshedEdge.EdgeGateway
 	return nil
@@ -229,7 +299,7 @@ func (egw *NsxtEdgeGateway) Update(ctx context.Context, edgeGatewayConfig *types
 
 	err = egw.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, edgeGatewayConfig, returnEgw.EdgeGateway, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error updating Edge Gateway: %w", err)
 	}
 
 	return returnEgw, nil
@@ -259,12 +329,21 @@ func (egw *NsxtEdgeGateway) Delete(ctx context.Context) error {
 	err = egw.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting Edge Gateway: %s", err)
+		return fmt.Errorf("error deleting Edge Gateway: %w", err)
 	}
 
 	return nil
 }
 
+// DeleteWithOptions deletes the NSX-T Edge Gateway. No option is supported
+func (egw *NsxtEdgeGateway) DeleteWithOptions(ctx context.Context, options DeleteOptions) error {
+	err := options.validate("NSX-T Edge Gateway", false, false)
+	if err != nil {
+		return err
+	}
+	return egw.Delete(ctx)
+}
+
 // MoveToVdcOrVdcGroup moves NSX-T Edge Gateway to another VDC. This can cover such scenarios:
 // * Move from VDC to VDC Group
 // * Move from VDC Group to VDC (which is part of that VDC Group)
@@ -337,7 +416,30 @@ func returnSingleNsxtEdgeGateway(name string, allEdges []*NsxtEdgeGateway) (*Nsx
 // func (adminOrg *AdminOrg) GetAllNsxtEdgeGateways(queryParameters url.Values) ([]*NsxtEdgeGateway, error)
 // func (org *Org) GetAllNsxtEdgeGateways(queryParameters url.Values) ([]*NsxtEdgeGateway, error)
 // func (vdc *Vdc) GetAllNsxtEdgeGateways(queryParameters url.Values) ([]*NsxtEdgeGateway, error)
+// When NSX-T is not available, the error is a FeatureNotAvailableError
 func getAllNsxtEdgeGateways(ctx context.Context, client *Client, queryParameters url.Values) ([]*NsxtEdgeGateway, error) {
+	typeResponses, err := getAllOpenApiEdgeGateways(ctx, client, queryParameters)
+	if err != nil {
+		return nil, client.featureError(ctx, FeatureNsxt, err)
+	}
+
+	// Wrap all typeResponses into NsxtEdgeGateway types with client
+	wrappedResponses := make([]*NsxtEdgeGateway, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtEdgeGateway{
+			EdgeGateway: typeResponses[sliceIndex],
+			client:      client,
+		}
+	}
+
+	onlyNsxtEdges := filterOnlyNsxtEdges(wrappedResponses)
+
+	return onlyNsxtEdges, nil
+}
+
+// getAllOpenApiEdgeGateways retrieves all edge gateways from the OpenAPI endpoint, which returns both NSX-V and NSX-T
+// backed ones
+func getAllOpenApiEdgeGateways(ctx context.Context, client *Client, queryParameters url.Values) ([]*types.OpenAPIEdgeGateway, error) {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGateways
 	minimumApiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
 	if err != nil {
@@ -354,19 +456,7 @@ func getAllNsxtEdgeGateways(ctx context.Context, client *Client, queryParameters
 	if err != nil {
 		return nil, err
 	}
-
-	// Wrap all typeResponses into NsxtEdgeGateway types with client
-	wrappedResponses := make([]*NsxtEdgeGateway, len(typeResponses))
-	for sliceIndex := range typeResponses {
-		wrappedResponses[sliceIndex] = &NsxtEdgeGateway{
-			EdgeGateway: typeResponses[sliceIndex],
-			client:      client,
-		}
-	}
-
-	onlyNsxtEdges := filterOnlyNsxtEdges(wrappedResponses)
-
-	return onlyNsxtEdges, nil
+	return typeResponses, nil
 }
 
 // filterOnlyNsxtEdges filters our list of edge gateways only for NSXT_BACKED ones because original endpoint can
@@ -446,12 +536,12 @@ func (egw *NsxtEdgeGateway) GetUnusedExternalIPAddresses(ctx context.Context, re
 	if refresh {
 		err := egw.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 	usedIpAddresses, err := egw.GetUsedIpAddresses(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %w", err)
 	}
 
 	return getUnusedExternalIPAddress(egw.EdgeGateway.EdgeGatewayUplinks, usedIpAddresses, requiredIpCount, optionalSubnet)
@@ -463,12 +553,12 @@ func (egw *NsxtEdgeGateway) GetAllUnusedExternalIPAddresses(ctx context.Context,
 	if refresh {
 		err := egw.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 	usedIpAddresses, err := egw.GetUsedIpAddresses(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %w", err)
 	}
 
 	return getAllUnusedExternalIPAddresses(egw.EdgeGateway.EdgeGatewayUplinks, usedIpAddresses, netip.Prefix{})
@@ -480,7 +570,7 @@ func (egw *NsxtEdgeGateway) GetAllocatedIpCount(ctx context.Context, refresh boo
 	if refresh {
 		err := egw.Refresh(ctx)
 		if err != nil {
-			return 0, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return 0, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 
@@ -503,12 +593,12 @@ func (egw *NsxtEdgeGateway) GetUsedIpAddressSlice(ctx context.Context, refresh b
 	if refresh {
 		err := egw.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+			return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 		}
 	}
 	usedIpAddresses, err := egw.GetUsedIpAddresses(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error getting used IP addresses for Edge Gateway: %w", err)
 	}
 
 	return flattenGatewayUsedIpAddressesToIpSlice(usedIpAddresses)
@@ -529,12 +619,12 @@ func (egw *NsxtEdgeGateway) QuickDeallocateIpCount(ctx context.Context, ipCount
 
 	err := egw.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
 	}
 
 	err = egw.DeallocateIpCount(ipCount)
 	if err != nil {
-		return nil, fmt.Errorf("error deallocating IP count: %s", err)
+		return nil, fmt.Errorf("error deallocating IP count: %w", err)
 	}
 
 	return egw.Update(ctx, egw.EdgeGateway)
@@ -604,6 +694,114 @@ func (egw *NsxtEdgeGateway) DeallocateIpCount(deallocateIpCount int) error {
 	return nil
 }
 
+// AttachExternalNetwork adds an uplink to an additional External Network (e.g. an NSX-T Segment backed
+// External Network) to an existing NSX-T Edge Gateway.
+// Only System Administrator can perform this operation.
+func (egw *NsxtEdgeGateway) AttachExternalNetwork(ctx context.Context, uplink types.EdgeGatewayUplinks) (*NsxtEdgeGateway, error) {
+	if egw.EdgeGateway == nil {
+		return nil, fmt.Errorf("edge gateway is not initialized")
+	}
+
+	err := egw.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = attachEdgeGatewayUplink(egw.EdgeGateway, uplink)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.Update(ctx, egw.EdgeGateway)
+}
+
+// DetachExternalNetwork removes the uplink to External Network with ID externalNetworkId from an NSX-T
+// Edge Gateway. The primary uplink (the first one, pointing to the Provider Gateway) cannot be detached.
+// Only System Administrator can perform this operation.
+func (egw *NsxtEdgeGateway) DetachExternalNetwork(ctx context.Context, externalNetworkId string) (*NsxtEdgeGateway, error) {
+	if egw.EdgeGateway == nil {
+		return nil, fmt.Errorf("edge gateway is not initialized")
+	}
+
+	err := egw.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = detachEdgeGatewayUplink(egw.EdgeGateway, externalNetworkId)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.Update(ctx, egw.EdgeGateway)
+}
+
+// UpdateExternalNetworkSubnets replaces subnet allocations of uplink to External Network with ID
+// externalNetworkId in an NSX-T Edge Gateway. All other uplinks are left unchanged.
+// Only System Administrator can perform this operation.
+func (egw *NsxtEdgeGateway) UpdateExternalNetworkSubnets(ctx context.Context, externalNetworkId string, subnets types.OpenAPIEdgeGatewaySubnets) (*NsxtEdgeGateway, error) {
+	if egw.EdgeGateway == nil {
+		return nil, fmt.Errorf("edge gateway is not initialized")
+	}
+
+	err := egw.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uplinkIndex, err := getEdgeGatewayUplinkIndex(egw.EdgeGateway, externalNetworkId)
+	if err != nil {
+		return nil, err
+	}
+	egw.EdgeGateway.EdgeGatewayUplinks[uplinkIndex].Subnets = subnets
+
+	return egw.Update(ctx, egw.EdgeGateway)
+}
+
+// attachEdgeGatewayUplink adds a new uplink to Edge Gateway structure, making sure that the same External
+// Network is not attached twice
+func attachEdgeGatewayUplink(edgeGateway *types.OpenAPIEdgeGateway, uplink types.EdgeGatewayUplinks) error {
+	if uplink.UplinkID == "" {
+		return fmt.Errorf("uplink must have External Network ID set")
+	}
+	if _, err := getEdgeGatewayUplinkIndex(edgeGateway, uplink.UplinkID); err == nil {
+		return fmt.Errorf("external network '%s' is already attached to Edge Gateway '%s'", uplink.UplinkID, edgeGateway.Name)
+	}
+
+	edgeGateway.EdgeGatewayUplinks = append(edgeGateway.EdgeGatewayUplinks, uplink)
+	return nil
+}
+
+// detachEdgeGatewayUplink removes uplink with External Network ID externalNetworkId from Edge Gateway
+// structure. The first uplink is the primary one and cannot be removed
+func detachEdgeGatewayUplink(edgeGateway *types.OpenAPIEdgeGateway, externalNetworkId string) error {
+	uplinkIndex, err := getEdgeGatewayUplinkIndex(edgeGateway, externalNetworkId)
+	if err != nil {
+		return err
+	}
+	if uplinkIndex == 0 {
+		return fmt.Errorf("cannot detach primary uplink '%s' from Edge Gateway '%s'", externalNetworkId, edgeGateway.Name)
+	}
+
+	edgeGateway.EdgeGatewayUplinks = append(edgeGateway.EdgeGatewayUplinks[:uplinkIndex], edgeGateway.EdgeGatewayUplinks[uplinkIndex+1:]...)
+	return nil
+}
+
+// getEdgeGatewayUplinkIndex returns the index of uplink with External Network ID externalNetworkId in Edge
+// Gateway structure
+func getEdgeGatewayUplinkIndex(edgeGateway *types.OpenAPIEdgeGateway, externalNetworkId string) (int, error) {
+	if edgeGateway == nil {
+		return -1, fmt.Errorf("edge gateway structure cannot be nil")
+	}
+	for index, uplink := range edgeGateway.EdgeGatewayUplinks {
+		if uplink.UplinkID == externalNetworkId {
+			return index, nil
+		}
+	}
+	return -1, fmt.Errorf("%s: external network '%s' is not attached to Edge Gateway '%s'",
+		ErrorEntityNotFound, externalNetworkId, edgeGateway.Name)
+}
+
 // GetQoS retrieves QoS (rate limiting) configuration for an NSX-T Edge Gateway
 func (egw *NsxtEdgeGateway) GetQoS(ctx context.Context) (*types.NsxtEdgeGatewayQos, error) {
 	if egw.EdgeGateway == nil || egw.client == nil || egw.EdgeGateway.ID == "" {
@@ -665,7 +863,7 @@ func getAllUnusedExternalIPAddresses(uplinks []types.EdgeGatewayUplinks, usedIpA
 	// operator)
 	assignedIpSlice, err := flattenEdgeGatewayUplinkToIpSlice(uplinks)
 	if err != nil {
-		return nil, fmt.Errorf("error listing all IPs in Edge Gateway: %s", err)
+		return nil, fmt.Errorf("error listing all IPs in Edge Gateway: %w", err)
 	}
 
 	if len(assignedIpSlice) == 0 {
@@ -676,14 +874,14 @@ func getAllUnusedExternalIPAddresses(uplinks []types.EdgeGatewayUplinks, usedIpA
 	if optionalSubnet != (netip.Prefix{}) {
 		assignedIpSlice, err = filterIpSlicesBySubnet(assignedIpSlice, optionalSubnet)
 		if err != nil {
-			return nil, fmt.Errorf("error filtering ranges for given subnet '%s': %s", optionalSubnet, err)
+			return nil, fmt.Errorf("error filtering ranges for given subnet '%s': %w", optionalSubnet, err)
 		}
 	}
 
 	// 3. Get Used IP addresses in Edge Gateway in the same slice format
 	usedIpSlice, err := flattenGatewayUsedIpAddressesToIpSlice(usedIpAddresses)
 	if err != nil {
-		return nil, fmt.Errorf("could not flatten Edge Gateway used IP addresses: %s", err)
+		return nil, fmt.Errorf("could not flatten Edge Gateway used IP addresses: %w", err)
 	}
 
 	// 4. Get all unused IPs
@@ -696,7 +894,7 @@ func getAllUnusedExternalIPAddresses(uplinks []types.EdgeGatewayUplinks, usedIpA
 func getUnusedExternalIPAddress(uplinks []types.EdgeGatewayUplinks, usedIpAddresses []*types.GatewayUsedIpAddress, requiredIpCount int, optionalSubnet netip.Prefix) ([]netip.Addr, error) {
 	unusedIps, err := getAllUnusedExternalIPAddresses(uplinks, usedIpAddresses, optionalSubnet)
 	if err != nil {
-		return nil, fmt.Errorf("error getting all unused IPs: %s", err)
+		return nil, fmt.Errorf("error getting all unused IPs: %w", err)
 	}
 
 	// 5. Check if 'requiredIpCount' criteria is met
@@ -719,7 +917,7 @@ func flattenEdgeGatewayUplinkToIpSlice(uplinks []types.EdgeGatewayUplinks) ([]ne
 				// Convert IPs to netip.Addr
 				startIp, err := netip.ParseAddr(r.StartAddress)
 				if err != nil {
-					return nil, fmt.Errorf("error parsing start IP address in range '%s': %s", r.StartAddress, err)
+					return nil, fmt.Errorf("error parsing start IP address in range '%s': %w", r.StartAddress, err)
 				}
 
 				// if we have end address specified - a range of IPs must be expanded into slice
@@ -727,7 +925,7 @@ func flattenEdgeGatewayUplinkToIpSlice(uplinks []types.EdgeGatewayUplinks) ([]ne
 				if r.EndAddress != "" {
 					endIp, err := netip.ParseAddr(r.EndAddress)
 					if err != nil {
-						return nil, fmt.Errorf("error parsing end IP address in range '%s': %s", r.EndAddress, err)
+						return nil, fmt.Errorf("error parsing end IP address in range '%s': %w", r.EndAddress, err)
 					}
 
 					// Check if EndAddress is lower than StartAddress ant report an error if so
@@ -847,7 +1045,7 @@ func flattenGatewayUsedIpAddressesToIpSlice(usedIpAddresses []*types.GatewayUsed
 	for usedIpIndex := range usedIpAddresses {
 		ip, err := netip.ParseAddr(usedIpAddresses[usedIpIndex].IPAddress)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing IP '%s' in Edge Gateway used IP address list: %s", usedIpAddresses[usedIpIndex].IPAddress, err)
+			return nil, fmt.Errorf("error parsing IP '%s' in Edge Gateway used IP address list: %w", usedIpAddresses[usedIpIndex].IPAddress, err)
 		}
 		usedIpSlice[usedIpIndex] = ip
 	}
@@ -30,7 +30,7 @@ func (egw *NsxtEdgeGateway) GetBgpConfiguration(ctx context.Context) (*types.Edg
 
 	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, returnObject, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Configuration: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Configuration: %w", err)
 	}
 
 	return returnObject, nil
@@ -57,7 +57,7 @@ func (egw *NsxtEdgeGateway) UpdateBgpConfiguration(ctx context.Context, bgpConfi
 	// Update of BGP config requires version to be specified. This function automatically handles it.
 	existingBgpConfig, err := egw.GetBgpConfiguration(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting NSX-T Edge Gateway BGP Configuration: %s", err)
+		return nil, fmt.Errorf("error getting NSX-T Edge Gateway BGP Configuration: %w", err)
 	}
 	bgpConfig.Version = existingBgpConfig.Version
 
@@ -65,7 +65,7 @@ func (egw *NsxtEdgeGateway) UpdateBgpConfiguration(ctx context.Context, bgpConfi
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, bgpConfig, returnObject, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP Configuration: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP Configuration: %w", err)
 	}
 
 	return returnObject, nil
@@ -77,7 +77,7 @@ func (egw *NsxtEdgeGateway) DisableBgpConfiguration(ctx context.Context) error {
 	// Get existing BGP configuration so that when disabling it - other settings remain as they are
 	bgpConfig, err := egw.GetBgpConfiguration(ctx)
 	if err != nil {
-		return fmt.Errorf("error retrieving BGP configuration: %s", err)
+		return fmt.Errorf("error retrieving BGP configuration: %w", err)
 	}
 	bgpConfig.Enabled = false
 
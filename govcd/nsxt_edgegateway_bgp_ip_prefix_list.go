@@ -47,12 +47,12 @@ func (egw *NsxtEdgeGateway) CreateBgpIpPrefixList(ctx context.Context, bgpIpPref
 
 	task, err := client.OpenApiPostItemAsync(ctx, apiVersion, urlRef, nil, bgpIpPrefixList)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	// API is not consistent across different versions therefore explicit manual handling is
@@ -75,14 +75,14 @@ func (egw *NsxtEdgeGateway) CreateBgpIpPrefixList(ctx context.Context, bgpIpPref
 		}
 		err = client.OpenApiGetItem(ctx, apiVersion, getUrlRef, nil, returnObject.EdgeBgpIpPrefixList, nil)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List after creation: %w", err)
 		}
 	} else {
 		// ID after object creation was not returned therefore retrieving the entity by Name to lookup ID
 		// This has a risk of duplicate items, but is the only way to find the object when ID is not returned
 		bgpIpPrefixList, err := egw.GetBgpIpPrefixListByName(ctx, bgpIpPrefixList.Name)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List after creation: %w", err)
 		}
 		returnObject = bgpIpPrefixList
 	}
@@ -140,7 +140,7 @@ func (egw *NsxtEdgeGateway) GetBgpIpPrefixListByName(ctx context.Context, name s
 
 	allBgpIpPrefixLists, err := egw.GetAllBgpIpPrefixLists(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	var filteredBgpIpPrefixLists []*EdgeBgpIpPrefixList
@@ -188,12 +188,24 @@ func (egw *NsxtEdgeGateway) GetBgpIpPrefixListById(ctx context.Context, id strin
 
 	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, returnObject.EdgeBgpIpPrefixList, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	return returnObject, nil
 }
 
+// GetBgpIpPrefixListByNameOrId retrieves a BGP IP Prefix List by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (egw *NsxtEdgeGateway) GetBgpIpPrefixListByNameOrId(ctx context.Context, identifier string) (*EdgeBgpIpPrefixList, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) { return egw.GetBgpIpPrefixListByName(ctx, name) }
+	getById := func(id string, refresh bool) (interface{}, error) { return egw.GetBgpIpPrefixListById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*EdgeBgpIpPrefixList), err
+}
+
 // Update updates existing BGP IP Prefix List with new configuration and returns it
 func (bgpIpPrefixListCfg *EdgeBgpIpPrefixList) Update(ctx context.Context, bgpIpPrefixList *types.EdgeBgpIpPrefixList) (*EdgeBgpIpPrefixList, error) {
 	client := bgpIpPrefixListCfg.client
@@ -217,7 +229,7 @@ func (bgpIpPrefixListCfg *EdgeBgpIpPrefixList) Update(ctx context.Context, bgpIp
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, bgpIpPrefixList, returnObject.EdgeBgpIpPrefixList, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	return returnObject, nil
@@ -240,7 +252,7 @@ func (bgpIpPrefixListCfg *EdgeBgpIpPrefixList) Delete(ctx context.Context) error
 
 	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP IP Prefix List: %w", err)
 	}
 
 	return nil
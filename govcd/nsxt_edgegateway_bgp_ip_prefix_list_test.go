@@ -63,6 +63,14 @@ func (vcd *TestVCD) Test_NsxEdgeBgpIpPrefixList(check *C) {
 	check.Assert(err, IsNil)
 	check.Assert(bgpPrefixListById, NotNil)
 
+	// Get By Name or Id
+	bgpPrefixListByNameOrId, err := edge.GetBgpIpPrefixListByNameOrId(ctx, bgpIpPrefix.EdgeBgpIpPrefixList.ID)
+	check.Assert(err, IsNil)
+	check.Assert(bgpPrefixListByNameOrId.EdgeBgpIpPrefixList.ID, Equals, bgpIpPrefix.EdgeBgpIpPrefixList.ID)
+	bgpPrefixListByNameOrId, err = edge.GetBgpIpPrefixListByNameOrId(ctx, bgpIpPrefixList.Name)
+	check.Assert(err, IsNil)
+	check.Assert(bgpPrefixListByNameOrId.EdgeBgpIpPrefixList.ID, Equals, bgpIpPrefix.EdgeBgpIpPrefixList.ID)
+
 	// Update
 	bgpIpPrefixList.Name = check.TestName() + "-updated"
 	bgpIpPrefixList.Description = "test-description-updated"
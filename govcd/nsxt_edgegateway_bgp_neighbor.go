@@ -43,12 +43,12 @@ func (egw *NsxtEdgeGateway) CreateBgpNeighbor(ctx context.Context, bgpNeighborCo
 
 	task, err := client.OpenApiPostItemAsync(ctx, apiVersion, urlRef, nil, bgpNeighborConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	// API has problems therefore explicit manual handling is required to lookup newly created object
@@ -68,14 +68,14 @@ func (egw *NsxtEdgeGateway) CreateBgpNeighbor(ctx context.Context, bgpNeighborCo
 		}
 		err = client.OpenApiGetItem(ctx, apiVersion, getUrlRef, nil, returnObject.EdgeBgpNeighbor, nil)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor after creation: %w", err)
 		}
 	} else {
 		// ID after object creation was not returned therefore retrieving the entity by Name to lookup ID
 		// This has a risk of duplicate items, but is the only way to find the object when ID is not returned
 		bgpNeighbor, err := egw.GetBgpNeighborByIp(ctx, bgpNeighborConfig.NeighborAddress)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor after creation: %w", err)
 		}
 		returnObject = bgpNeighbor
 	}
@@ -133,7 +133,7 @@ func (egw *NsxtEdgeGateway) GetBgpNeighborByIp(ctx context.Context, neighborIpAd
 
 	allBgpNeighbors, err := egw.GetAllBgpNeighbors(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	var filteredBgpNeighbors []*EdgeBgpNeighbor
@@ -181,7 +181,7 @@ func (egw *NsxtEdgeGateway) GetBgpNeighborById(ctx context.Context, id string) (
 
 	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, returnObject.EdgeBgpNeighbor, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	return returnObject, nil
@@ -210,7 +210,7 @@ func (bgpNeighbor *EdgeBgpNeighbor) Update(ctx context.Context, bgpNeighborConfi
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, bgpNeighborConfig, returnObject.EdgeBgpNeighbor, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	return returnObject, nil
@@ -233,7 +233,7 @@ func (bgpNeighbor *EdgeBgpNeighbor) Delete(ctx context.Context) error {
 
 	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP Neighbor: %s", err)
+		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP Neighbor: %w", err)
 	}
 
 	return nil
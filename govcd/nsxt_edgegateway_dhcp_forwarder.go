@@ -0,0 +1,86 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetDhcpForwarder retrieves the DHCP forwarding configuration of NSX-T Edge Gateway
+func (egw *NsxtEdgeGateway) GetDhcpForwarder(ctx context.Context) (*types.NsxtEdgeGatewayDhcpForwarder, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeDhcpForwarder
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert Edge Gateway ID into endpoint path "edgeGateways/%s/dhcpForwarder"
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &types.NsxtEdgeGatewayDhcpForwarder{}
+
+	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, returnObject, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway DHCP forwarder: %w", err)
+	}
+
+	return returnObject, nil
+}
+
+// UpdateDhcpForwarder updates the DHCP forwarding configuration of NSX-T Edge Gateway. The DHCP servers are
+// validated before sending the configuration to VCD
+func (egw *NsxtEdgeGateway) UpdateDhcpForwarder(ctx context.Context, dhcpForwarder *types.NsxtEdgeGatewayDhcpForwarder) (*types.NsxtEdgeGatewayDhcpForwarder, error) {
+	err := validateDhcpForwarder(dhcpForwarder)
+	if err != nil {
+		return nil, err
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeDhcpForwarder
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert Edge Gateway ID into endpoint path
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &types.NsxtEdgeGatewayDhcpForwarder{}
+
+	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, dhcpForwarder, returnObject, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway DHCP forwarder: %w", err)
+	}
+
+	return returnObject, nil
+}
+
+// validateDhcpForwarder checks that the DHCP servers are IP addresses and that at least one is given when
+// forwarding is enabled
+func validateDhcpForwarder(dhcpForwarder *types.NsxtEdgeGatewayDhcpForwarder) error {
+	if dhcpForwarder == nil {
+		return fmt.Errorf("DHCP forwarder cannot be nil")
+	}
+	if dhcpForwarder.Enabled && len(dhcpForwarder.DhcpServers) == 0 {
+		return fmt.Errorf("at least one DHCP server is required to enable DHCP forwarding")
+	}
+	for _, address := range dhcpForwarder.DhcpServers {
+		if net.ParseIP(address) == nil {
+			return fmt.Errorf("DHCP server '%s' of DHCP forwarder is not an IP address", address)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build network || nsxt || functional || openapi || ALL
+
+package govcd
+
+import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_NsxEdgeDhcpForwarder(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeDhcpForwarder)
+
+	org, err := vcd.client.GetOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	nsxtVdc, err := org.GetVDCByName(ctx, vcd.config.VCD.Nsxt.Vdc, false)
+	check.Assert(err, IsNil)
+	edge, err := nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	// Get and store existing DHCP forwarder configuration
+	existingForwarder, err := edge.GetDhcpForwarder(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(existingForwarder, NotNil)
+
+	newForwarder := &types.NsxtEdgeGatewayDhcpForwarder{
+		Enabled:     true,
+		DhcpServers: []string{"1.1.1.1", "192.168.1.254", "fe80::aaaa"},
+		Version:     existingForwarder.Version,
+	}
+	updatedForwarder, err := edge.UpdateDhcpForwarder(ctx, newForwarder)
+	check.Assert(err, IsNil)
+	check.Assert(updatedForwarder.Enabled, Equals, true)
+	check.Assert(updatedForwarder.DhcpServers, DeepEquals, newForwarder.DhcpServers)
+
+	// Invalid DHCP servers are rejected before reaching VCD
+	newForwarder.DhcpServers = []string{"not-an-ip"}
+	_, err = edge.UpdateDhcpForwarder(ctx, newForwarder)
+	check.Assert(err, NotNil)
+
+	// Restore original configuration
+	existingForwarder.Version = updatedForwarder.Version
+	_, err = edge.UpdateDhcpForwarder(ctx, existingForwarder)
+	check.Assert(err, IsNil)
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// QuickAllocateIpAddresses refreshes the Edge Gateway and reserves the given external IP addresses for it, so that
+// they belong to the tenant before any NAT rule or service uses them.
+// externalNetworkId is the ID of the External Network of the uplink. When empty, the primary uplink is used.
+//
+// Note. This function modifies Edge Gateway structure and calls update. To only modify structure,
+// please use `NsxtEdgeGateway.AllocateIpAddresses` function
+func (egw *NsxtEdgeGateway) QuickAllocateIpAddresses(ctx context.Context, externalNetworkId string, ipAddresses []netip.Addr) (*NsxtEdgeGateway, error) {
+	if egw.EdgeGateway == nil {
+		return nil, fmt.Errorf("edge gateway is not initialized")
+	}
+
+	err := egw.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing Edge Gateway: %w", err)
+	}
+
+	err = egw.AllocateIpAddresses(externalNetworkId, ipAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("error allocating IP addresses: %w", err)
+	}
+
+	return egw.Update(ctx, egw.EdgeGateway)
+}
+
+// QuickDeallocateIpAddresses refreshes the Edge Gateway and releases the given external IP addresses, which are
+// returned to the External Network. IP addresses that are in use (e.g. by NAT rules) cannot be released.
+// externalNetworkId is the ID of the External Network of the uplink. When empty, the primary uplink is used.
+//
+// Note. This function modifies Edge Gateway structure and calls update. To only modify structure,
+// please use `NsxtEdgeGateway.DeallocateIpAddresses` function
+func (egw *NsxtEdgeGateway) QuickDeallocateIpAddresses(ctx context.Context, externalNetworkId string, ipAddresses []netip.Addr) (*NsxtEdgeGateway, error) {
+	if egw.EdgeGateway == nil {
+		return nil, fmt.Errorf("edge gateway is not initialized")
+	}
+
+	usedIpAddresses, err := egw.GetUsedIpAddressSlice(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, ipAddress := range ipAddresses {
+		for _, usedIpAddress := range usedIpAddresses {
+			if ipAddress == usedIpAddress {
+				return nil, fmt.Errorf("IP address '%s' is in use and cannot be deallocated", ipAddress)
+			}
+		}
+	}
+
+	err = egw.DeallocateIpAddresses(externalNetworkId, ipAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("error deallocating IP addresses: %w", err)
+	}
+
+	return egw.Update(ctx, egw.EdgeGateway)
+}
+
+// AllocateIpAddresses modifies the structure to add the given IP addresses to the IP ranges allocated to the
+// Edge Gateway in the uplink to External Network externalNetworkId (the primary uplink when empty). Each IP
+// address must belong to one of the subnets of the uplink, and must not be allocated already.
+//
+// Note. This function does not call Update() on the Edge Gateway and it is up to the caller to perform
+// this operation (or use NsxtEdgeGateway.QuickAllocateIpAddresses which wraps this function and
+// performs API call)
+func (egw *NsxtEdgeGateway) AllocateIpAddresses(externalNetworkId string, ipAddresses []netip.Addr) error {
+	return egw.changeAllocatedIpAddresses(externalNetworkId, ipAddresses, true)
+}
+
+// DeallocateIpAddresses modifies the structure to remove the given IP addresses from the IP ranges allocated to
+// the Edge Gateway in the uplink to External Network externalNetworkId (the primary uplink when empty).
+//
+// Note. This function does not check whether the IP addresses are in use and does not call Update() on the Edge
+// Gateway. Use NsxtEdgeGateway.QuickDeallocateIpAddresses to perform both
+func (egw *NsxtEdgeGateway) DeallocateIpAddresses(externalNetworkId string, ipAddresses []netip.Addr) error {
+	return egw.changeAllocatedIpAddresses(externalNetworkId, ipAddresses, false)
+}
+
+// changeAllocatedIpAddresses adds (allocate=true) or removes IP addresses from the allocated IP ranges of an uplink
+func (egw *NsxtEdgeGateway) changeAllocatedIpAddresses(externalNetworkId string, ipAddresses []netip.Addr, allocate bool) error {
+	if egw == nil || egw.EdgeGateway == nil {
+		return fmt.Errorf("edge gateway structure cannot be nil")
+	}
+	if len(ipAddresses) == 0 {
+		return fmt.Errorf("no IP addresses specified")
+	}
+
+	uplinkIndex := 0
+	if externalNetworkId != "" {
+		var err error
+		uplinkIndex, err = getEdgeGatewayUplinkIndex(egw.EdgeGateway, externalNetworkId)
+		if err != nil {
+			return err
+		}
+	} else if len(egw.EdgeGateway.EdgeGatewayUplinks) == 0 {
+		return fmt.Errorf("edge gateway '%s' has no uplinks", egw.EdgeGateway.Name)
+	}
+
+	return changeUplinkAllocatedIpAddresses(&egw.EdgeGateway.EdgeGatewayUplinks[uplinkIndex], ipAddresses, allocate)
+}
+
+// changeUplinkAllocatedIpAddresses adds (allocate=true) or removes IP addresses from the allocated IP ranges of the
+// subnets of uplink. The IP ranges of each modified subnet are rebuilt from the resulting addresses, merging
+// consecutive addresses, and its TotalIPCount is updated accordingly
+func changeUplinkAllocatedIpAddresses(uplink *types.EdgeGatewayUplinks, ipAddresses []netip.Addr, allocate bool) error {
+	// Allocated IP addresses of each subnet, indexed by subnet position
+	allocated := make(map[int][]netip.Addr)
+	for _, ipAddress := range ipAddresses {
+		subnetIndex, err := findUplinkSubnetIndex(uplink, ipAddress)
+		if err != nil {
+			return err
+		}
+		if _, ok := allocated[subnetIndex]; !ok {
+			subnet := uplink.Subnets.Values[subnetIndex]
+			allocated[subnetIndex] = []netip.Addr{}
+			// A subnet without allocated IP ranges has no IPRanges at all
+			if subnet.IPRanges != nil {
+				subnetIps, err := flattenEdgeGatewayUplinkToIpSlice([]types.EdgeGatewayUplinks{{
+					Subnets: types.OpenAPIEdgeGatewaySubnets{Values: []types.OpenAPIEdgeGatewaySubnetValue{subnet}},
+				}})
+				if err != nil {
+					return err
+				}
+				allocated[subnetIndex] = subnetIps
+			}
+		}
+
+		position := -1
+		for index, allocatedIp := range allocated[subnetIndex] {
+			if allocatedIp == ipAddress {
+				position = index
+				break
+			}
+		}
+		switch {
+		case allocate && position >= 0:
+			return fmt.Errorf("IP address '%s' is already allocated to the Edge Gateway", ipAddress)
+		case allocate:
+			allocated[subnetIndex] = append(allocated[subnetIndex], ipAddress)
+		case position < 0:
+			return fmt.Errorf("IP address '%s' is not allocated to the Edge Gateway", ipAddress)
+		default:
+			allocated[subnetIndex] = append(allocated[subnetIndex][:position], allocated[subnetIndex][position+1:]...)
+		}
+	}
+
+	for subnetIndex, subnetIps := range allocated {
+		subnet := &uplink.Subnets.Values[subnetIndex]
+		totalIpCount := len(subnetIps)
+		subnet.IPRanges = &types.OpenApiIPRanges{Values: ipAddressesToRanges(subnetIps)}
+		subnet.TotalIPCount = &totalIpCount
+		// Explicit IP ranges are only taken into account when automatic allocation is disabled
+		subnet.AutoAllocateIPRanges = false
+		util.Logger.Printf("[DEBUG] Edge Gateway subnet '%s' allocated IP count %d", subnet.Gateway, totalIpCount)
+	}
+	return nil
+}
+
+// findUplinkSubnetIndex returns the index of the subnet of uplink that contains ipAddress
+func findUplinkSubnetIndex(uplink *types.EdgeGatewayUplinks, ipAddress netip.Addr) (int, error) {
+	for index, subnet := range uplink.Subnets.Values {
+		gateway, err := netip.ParseAddr(subnet.Gateway)
+		if err != nil {
+			return -1, fmt.Errorf("error parsing gateway '%s' of subnet: %w", subnet.Gateway, err)
+		}
+		prefix, err := gateway.Prefix(subnet.PrefixLength)
+		if err != nil {
+			return -1, fmt.Errorf("error parsing subnet '%s/%d': %w", subnet.Gateway, subnet.PrefixLength, err)
+		}
+		if prefix.Contains(ipAddress) {
+			return index, nil
+		}
+	}
+	return -1, fmt.Errorf("IP address '%s' does not belong to any subnet of uplink '%s'", ipAddress, uplink.UplinkName)
+}
+
+// ipAddressesToRanges sorts the given IP addresses and merges consecutive ones into IP ranges. Single addresses
+// are returned as ranges with the same start and end address
+func ipAddressesToRanges(ipAddresses []netip.Addr) []types.OpenApiIPRangeValues {
+	sorted := make([]netip.Addr, len(ipAddresses))
+	copy(sorted, ipAddresses)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Less(sorted[j]) })
+
+	ranges := make([]types.OpenApiIPRangeValues, 0)
+	for index := 0; index < len(sorted); {
+		start := sorted[index]
+		end := start
+		for index+1 < len(sorted) && sorted[index+1] == end.Next() {
+			index++
+			end = sorted[index]
+		}
+		ranges = append(ranges, types.OpenApiIPRangeValues{StartAddress: start.String(), EndAddress: end.String()})
+		index++
+	}
+	return ranges
+}
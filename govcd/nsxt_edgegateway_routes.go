@@ -0,0 +1,161 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Sources of the routes returned by NsxtEdgeGateway.GetEffectiveRoutes
+const (
+	NsxtRouteSourceUplink    = "UPLINK"    // Subnet of an external network used by the Edge Gateway
+	NsxtRouteSourceConnected = "CONNECTED" // Subnet of a routed Org VDC network connected to the Edge Gateway
+	NsxtRouteSourceDefault   = "DEFAULT"   // Default route through the gateway of the primary uplink subnet
+)
+
+// NsxtEdgeGatewayRoute is a single route of the Edge Gateway returned by NsxtEdgeGateway.GetEffectiveRoutes
+type NsxtEdgeGatewayRoute struct {
+	// Network is the destination in CIDR format (e.g. 10.10.10.0/24)
+	Network string
+	// NextHop is the gateway of the route. It is empty for the networks directly connected to the Edge Gateway
+	NextHop string
+	// Source is one of the NsxtRouteSource* constants
+	Source string
+	// SourceName is the name of the external network or Org VDC network that defines the route
+	SourceName string
+	// Advertised is true when the network is included in the subnets advertised by the Edge Gateway
+	Advertised bool
+}
+
+// GetAdvertisedRoutes returns the subnets that the Edge Gateway advertises to its external network. The returned
+// slice is empty when route advertisement is disabled.
+func (egw *NsxtEdgeGateway) GetAdvertisedRoutes(ctx context.Context) ([]string, error) {
+	routeAdvertisement, err := egw.GetNsxtRouteAdvertisement(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving route advertisement of Edge Gateway '%s': %w", egw.EdgeGateway.Name, err)
+	}
+	if !routeAdvertisement.Enable {
+		return []string{}, nil
+	}
+	return routeAdvertisement.Subnets, nil
+}
+
+// GetEffectiveRoutes returns a snapshot of the routes of the Edge Gateway, as defined in VCD: the subnets of its
+// uplinks, the subnets of the routed Org VDC networks connected to it and the default route through the primary
+// uplink, each one marked when it is advertised.
+//
+// Note. VCD does not expose the routing table realized in NSX-T, so the routes are derived from the VCD
+// configuration. Routes learned through BGP and static routes defined directly in NSX-T are not included.
+func (egw *NsxtEdgeGateway) GetEffectiveRoutes(ctx context.Context) ([]NsxtEdgeGatewayRoute, error) {
+	if egw.EdgeGateway == nil || egw.EdgeGateway.ID == "" {
+		return nil, fmt.Errorf("edge gateway is not initialized")
+	}
+
+	queryParameters := queryParameterFilterAnd("connection.routerRef.id=="+egw.EdgeGateway.ID, url.Values{})
+	orgVdcNetworks, err := getAllOpenApiOrgVdcNetworks(ctx, egw.client, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving Org VDC networks connected to Edge Gateway '%s': %w", egw.EdgeGateway.Name, err)
+	}
+	networks := make([]*types.OpenApiOrgVdcNetwork, len(orgVdcNetworks))
+	for index, orgVdcNetwork := range orgVdcNetworks {
+		networks[index] = orgVdcNetwork.OpenApiOrgVdcNetwork
+	}
+
+	advertisedRoutes, err := egw.GetAdvertisedRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeNsxtEdgeGatewayRoutes(egw.EdgeGateway.EdgeGatewayUplinks, networks, advertisedRoutes)
+}
+
+// computeNsxtEdgeGatewayRoutes builds the routes of an Edge Gateway from its uplinks, the Org VDC networks connected
+// to it and its advertised subnets
+func computeNsxtEdgeGatewayRoutes(uplinks []types.EdgeGatewayUplinks, networks []*types.OpenApiOrgVdcNetwork, advertisedRoutes []string) ([]NsxtEdgeGatewayRoute, error) {
+	advertisedPrefixes := make([]netip.Prefix, len(advertisedRoutes))
+	for index, advertisedRoute := range advertisedRoutes {
+		prefix, err := netip.ParsePrefix(advertisedRoute)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing advertised subnet '%s': %w", advertisedRoute, err)
+		}
+		advertisedPrefixes[index] = prefix.Masked()
+	}
+
+	var routes []NsxtEdgeGatewayRoute
+	var defaultRoutes []NsxtEdgeGatewayRoute
+	addRoute := func(gateway string, prefixLength int, source, sourceName string) error {
+		prefix, err := subnetPrefix(gateway, prefixLength)
+		if err != nil {
+			return fmt.Errorf("error parsing subnet of network '%s': %w", sourceName, err)
+		}
+		routes = append(routes, NsxtEdgeGatewayRoute{
+			Network:    prefix.String(),
+			Source:     source,
+			SourceName: sourceName,
+			Advertised: isPrefixAdvertised(prefix, advertisedPrefixes),
+		})
+		return nil
+	}
+
+	for _, uplink := range uplinks {
+		for _, subnet := range uplink.Subnets.Values {
+			err := addRoute(subnet.Gateway, subnet.PrefixLength, NsxtRouteSourceUplink, uplink.UplinkName)
+			if err != nil {
+				return nil, err
+			}
+			if subnet.PrimaryIP == "" {
+				continue
+			}
+			defaultNetwork := "0.0.0.0/0"
+			if address, err := netip.ParseAddr(subnet.Gateway); err == nil && address.Is6() {
+				defaultNetwork = "::/0"
+			}
+			defaultRoutes = append(defaultRoutes, NsxtEdgeGatewayRoute{
+				Network:    defaultNetwork,
+				NextHop:    subnet.Gateway,
+				Source:     NsxtRouteSourceDefault,
+				SourceName: uplink.UplinkName,
+			})
+		}
+	}
+
+	for _, network := range networks {
+		if network == nil || network.Connection == nil {
+			continue
+		}
+		for _, subnet := range network.Subnets.Values {
+			err := addRoute(subnet.Gateway, subnet.PrefixLength, NsxtRouteSourceConnected, network.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return append(routes, defaultRoutes...), nil
+}
+
+// subnetPrefix returns the network prefix of a subnet defined by its gateway and prefix length
+func subnetPrefix(gateway string, prefixLength int) (netip.Prefix, error) {
+	address, err := netip.ParseAddr(gateway)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return address.Prefix(prefixLength)
+}
+
+// isPrefixAdvertised returns true when prefix is contained in one of the advertised prefixes
+func isPrefixAdvertised(prefix netip.Prefix, advertisedPrefixes []netip.Prefix) bool {
+	for _, advertisedPrefix := range advertisedPrefixes {
+		if advertisedPrefix.Bits() <= prefix.Bits() && advertisedPrefix.Contains(prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
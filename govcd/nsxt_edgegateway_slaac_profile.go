@@ -0,0 +1,87 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetSlaacProfile retrieves the IPv6 (SLAAC/DHCPv6) profile of NSX-T Edge Gateway
+func (egw *NsxtEdgeGateway) GetSlaacProfile(ctx context.Context) (*types.NsxtEdgeGatewaySlaacProfile, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeSlaacProfile
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert Edge Gateway ID into endpoint path "edgeGateways/%s/slaacProfile"
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &types.NsxtEdgeGatewaySlaacProfile{}
+
+	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, returnObject, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway SLAAC Profile: %w", err)
+	}
+
+	return returnObject, nil
+}
+
+// UpdateSlaacProfile updates the IPv6 (SLAAC/DHCPv6) profile of NSX-T Edge Gateway. The profile is validated before
+// sending it to VCD
+func (egw *NsxtEdgeGateway) UpdateSlaacProfile(ctx context.Context, slaacProfile *types.NsxtEdgeGatewaySlaacProfile) (*types.NsxtEdgeGatewaySlaacProfile, error) {
+	err := validateSlaacProfile(slaacProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeSlaacProfile
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert Edge Gateway ID into endpoint path
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &types.NsxtEdgeGatewaySlaacProfile{}
+
+	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, slaacProfile, returnObject, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway SLAAC Profile: %w", err)
+	}
+
+	return returnObject, nil
+}
+
+// validateSlaacProfile checks the mode of the profile and that DNS servers are IPv6 addresses
+func validateSlaacProfile(slaacProfile *types.NsxtEdgeGatewaySlaacProfile) error {
+	if slaacProfile == nil {
+		return fmt.Errorf("SLAAC profile cannot be nil")
+	}
+	if slaacProfile.Mode != types.NsxtEdgeSlaacProfileModeSlaac && slaacProfile.Mode != types.NsxtEdgeSlaacProfileModeDhcpv6 {
+		return fmt.Errorf("invalid SLAAC profile mode '%s'. Supported values are '%s' and '%s'",
+			slaacProfile.Mode, types.NsxtEdgeSlaacProfileModeSlaac, types.NsxtEdgeSlaacProfileModeDhcpv6)
+	}
+	for _, address := range slaacProfile.DNSConfig.DNSServerIpv6Addresses {
+		ip := net.ParseIP(address)
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("DNS server '%s' of SLAAC profile is not an IPv6 address", address)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+//go:build network || nsxt || functional || openapi || ALL
+
+package govcd
+
+import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_NsxEdgeSlaacProfile(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeSlaacProfile)
+
+	org, err := vcd.client.GetOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	nsxtVdc, err := org.GetVDCByName(ctx, vcd.config.VCD.Nsxt.Vdc, false)
+	check.Assert(err, IsNil)
+	edge, err := nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	// Get and store existing SLAAC profile
+	existingProfile, err := edge.GetSlaacProfile(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(existingProfile, NotNil)
+
+	newProfile := &types.NsxtEdgeGatewaySlaacProfile{
+		Enabled: true,
+		Mode:    types.NsxtEdgeSlaacProfileModeSlaac,
+		DNSConfig: types.NsxtEdgeGatewaySlaacProfileDNSConfig{
+			DNSServerIpv6Addresses: []string{"2001:4860:4860::8888", "2001:4860:4860::8844"},
+			DomainNames:            []string{"non-existing.org.tld"},
+		},
+	}
+	updatedProfile, err := edge.UpdateSlaacProfile(ctx, newProfile)
+	check.Assert(err, IsNil)
+	check.Assert(updatedProfile, DeepEquals, newProfile)
+
+	newProfile.Mode = types.NsxtEdgeSlaacProfileModeDhcpv6
+	newProfile.DNSConfig = types.NsxtEdgeGatewaySlaacProfileDNSConfig{}
+	updatedProfile, err = edge.UpdateSlaacProfile(ctx, newProfile)
+	check.Assert(err, IsNil)
+	check.Assert(updatedProfile.Mode, Equals, types.NsxtEdgeSlaacProfileModeDhcpv6)
+
+	// IPv4 DNS servers are rejected before reaching VCD
+	newProfile.Mode = types.NsxtEdgeSlaacProfileModeSlaac
+	newProfile.DNSConfig.DNSServerIpv6Addresses = []string{"8.8.8.8"}
+	_, err = edge.UpdateSlaacProfile(ctx, newProfile)
+	check.Assert(err, NotNil)
+
+	// Restore original profile
+	_, err = edge.UpdateSlaacProfile(ctx, existingProfile)
+	check.Assert(err, IsNil)
+}
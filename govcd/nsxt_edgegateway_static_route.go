@@ -0,0 +1,252 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NsxtEdgeGatewayStaticRoute helps to configure static routes in NSX-T Edge Gateways
+type NsxtEdgeGatewayStaticRoute struct {
+	NsxtEdgeGatewayStaticRoute *types.NsxtEdgeGatewayStaticRoute
+	client                     *Client
+	// edgeGatewayId is stored for usage in NsxtEdgeGatewayStaticRoute receiver functions
+	edgeGatewayId string
+}
+
+// CreateStaticRoute creates a static route with supplied configuration
+//
+// Note. When the task does not return the ID of the created static route, it is retrieved by Name, which fails if
+// duplicate static routes exist.
+func (egw *NsxtEdgeGateway) CreateStaticRoute(ctx context.Context, staticRouteConfig *types.NsxtEdgeGatewayStaticRoute) (*NsxtEdgeGatewayStaticRoute, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeStaticRoutes
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert Edge Gateway ID into endpoint path
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := client.OpenApiPostItemAsync(ctx, apiVersion, urlRef, nil, staticRouteConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway Static Route: %w", err)
+	}
+
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway Static Route: %w", err)
+	}
+
+	staticRouteId := task.Task.Details
+	if staticRouteId != "" {
+		staticRoute, err := egw.GetStaticRouteById(ctx, staticRouteId)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route after creation: %w", err)
+		}
+		return staticRoute, nil
+	}
+
+	// ID after object creation was not returned therefore retrieving the entity by Name to lookup ID
+	staticRoute, err := egw.GetStaticRouteByName(ctx, staticRouteConfig.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route after creation: %w", err)
+	}
+	return staticRoute, nil
+}
+
+// GetAllStaticRoutes retrieves all static routes in a given NSX-T Edge Gateway with optional queryParameters
+func (egw *NsxtEdgeGateway) GetAllStaticRoutes(ctx context.Context, queryParameters url.Values) ([]*NsxtEdgeGatewayStaticRoute, error) {
+	queryParams := copyOrNewUrlValues(queryParameters)
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeStaticRoutes
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert Edge Gateway ID into endpoint path
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.NsxtEdgeGatewayStaticRoute{{}}
+	err = client.OpenApiGetAllItems(ctx, apiVersion, urlRef, queryParams, &typeResponses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtEdgeGatewayStaticRoute, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtEdgeGatewayStaticRoute{
+			NsxtEdgeGatewayStaticRoute: typeResponses[sliceIndex],
+			client:                     client,
+			edgeGatewayId:              egw.EdgeGateway.ID,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetStaticRouteByName retrieves a static route by Name
+// It is meant to retrieve exactly one entry:
+// * Will fail if more than one entry with the same name found
+// * Will return an error containing `ErrorEntityNotFound` if no entries are found
+//
+// Note. API does not support filtering by 'name' field therefore filtering is performed on client side
+func (egw *NsxtEdgeGateway) GetStaticRouteByName(ctx context.Context, name string) (*NsxtEdgeGatewayStaticRoute, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+
+	allStaticRoutes, err := egw.GetAllStaticRoutes(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Routes: %w", err)
+	}
+
+	var filteredStaticRoutes []*NsxtEdgeGatewayStaticRoute
+	for _, staticRoute := range allStaticRoutes {
+		if staticRoute.NsxtEdgeGatewayStaticRoute.Name == name {
+			filteredStaticRoutes = append(filteredStaticRoutes, staticRoute)
+		}
+	}
+
+	return oneOrError("name", name, filteredStaticRoutes)
+}
+
+// GetStaticRouteByNetworkCidr retrieves a static route by its destination network in CIDR format
+// It is meant to retrieve exactly one entry:
+// * Will fail if more than one entry with the same network CIDR found
+// * Will return an error containing `ErrorEntityNotFound` if no entries are found
+func (egw *NsxtEdgeGateway) GetStaticRouteByNetworkCidr(ctx context.Context, networkCidr string) (*NsxtEdgeGatewayStaticRoute, error) {
+	if networkCidr == "" {
+		return nil, fmt.Errorf("network CIDR cannot be empty")
+	}
+
+	allStaticRoutes, err := egw.GetAllStaticRoutes(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Routes: %w", err)
+	}
+
+	var filteredStaticRoutes []*NsxtEdgeGatewayStaticRoute
+	for _, staticRoute := range allStaticRoutes {
+		if staticRoute.NsxtEdgeGatewayStaticRoute.NetworkCidr == networkCidr {
+			filteredStaticRoutes = append(filteredStaticRoutes, staticRoute)
+		}
+	}
+
+	return oneOrError("network CIDR", networkCidr, filteredStaticRoutes)
+}
+
+// GetStaticRouteById retrieves a static route by ID
+func (egw *NsxtEdgeGateway) GetStaticRouteById(ctx context.Context, id string) (*NsxtEdgeGatewayStaticRoute, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeStaticRoutes
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert Edge Gateway ID into endpoint path
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID), id)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtEdgeGatewayStaticRoute{
+		client:                     egw.client,
+		edgeGatewayId:              egw.EdgeGateway.ID,
+		NsxtEdgeGatewayStaticRoute: &types.NsxtEdgeGatewayStaticRoute{},
+	}
+
+	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, returnObject.NsxtEdgeGatewayStaticRoute, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway Static Route: %w", err)
+	}
+
+	return returnObject, nil
+}
+
+// GetStaticRouteByNameOrId retrieves a static route by Name or ID. ID lookup is only attempted if the identifier
+// looks like a UUID or URN.
+func (egw *NsxtEdgeGateway) GetStaticRouteByNameOrId(ctx context.Context, identifier string) (*NsxtEdgeGatewayStaticRoute, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) { return egw.GetStaticRouteByName(ctx, name) }
+	getById := func(id string, refresh bool) (interface{}, error) { return egw.GetStaticRouteById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtEdgeGatewayStaticRoute), err
+}
+
+// Update updates existing static route with new configuration and returns it
+func (staticRoute *NsxtEdgeGatewayStaticRoute) Update(ctx context.Context, staticRouteConfig *types.NsxtEdgeGatewayStaticRoute) (*NsxtEdgeGatewayStaticRoute, error) {
+	client := staticRoute.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeStaticRoutes
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if staticRouteConfig.ID == "" {
+		staticRouteConfig.ID = staticRoute.NsxtEdgeGatewayStaticRoute.ID
+	}
+
+	// Insert Edge Gateway ID into endpoint path
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, staticRoute.edgeGatewayId), staticRouteConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtEdgeGatewayStaticRoute{
+		client:                     staticRoute.client,
+		edgeGatewayId:              staticRoute.edgeGatewayId,
+		NsxtEdgeGatewayStaticRoute: &types.NsxtEdgeGatewayStaticRoute{},
+	}
+
+	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, staticRouteConfig, returnObject.NsxtEdgeGatewayStaticRoute, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error setting NSX-T Edge Gateway Static Route: %w", err)
+	}
+
+	return returnObject, nil
+}
+
+// Delete deletes existing static route
+func (staticRoute *NsxtEdgeGatewayStaticRoute) Delete(ctx context.Context) error {
+	client := staticRoute.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeStaticRoutes
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Insert Edge Gateway ID into endpoint path
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, staticRoute.edgeGatewayId), staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T Edge Gateway Static Route: %w", err)
+	}
+
+	return nil
+}
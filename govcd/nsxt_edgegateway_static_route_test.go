@@ -0,0 +1,83 @@
+//go:build network || nsxt || functional || openapi || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+// Test_NsxEdgeStaticRoute tests CRUD operations and lookups for NSX-T Edge Gateway Static Routes
+func (vcd *TestVCD) Test_NsxEdgeStaticRoute(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeStaticRoutes)
+
+	org, err := vcd.client.GetOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	nsxtVdc, err := org.GetVDCByName(ctx, vcd.config.VCD.Nsxt.Vdc, false)
+	check.Assert(err, IsNil)
+	edge, err := nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	staticRouteConfig := &types.NsxtEdgeGatewayStaticRoute{
+		Name:        check.TestName(),
+		Description: "test-description",
+		NetworkCidr: "10.254.254.0/24",
+		NextHops: []types.NsxtEdgeGatewayStaticRouteNextHops{
+			{
+				IPAddress:     edge.EdgeGateway.EdgeGatewayUplinks[0].Subnets.Values[0].Gateway,
+				AdminDistance: 4,
+			},
+		},
+	}
+
+	staticRoute, err := edge.CreateStaticRoute(ctx, staticRouteConfig)
+	check.Assert(err, IsNil)
+	check.Assert(staticRoute.NsxtEdgeGatewayStaticRoute.ID, Not(Equals), "")
+	openApiEndpoint := types.OpenApiPathVersion1_0_0 + fmt.Sprintf(types.OpenApiEndpointEdgeStaticRoutes, edge.EdgeGateway.ID) +
+		staticRoute.NsxtEdgeGatewayStaticRoute.ID
+	PrependToCleanupListOpenApi(staticRoute.NsxtEdgeGatewayStaticRoute.ID, check.TestName(), openApiEndpoint)
+
+	allStaticRoutes, err := edge.GetAllStaticRoutes(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(allStaticRoutes) > 0, Equals, true)
+
+	byName, err := edge.GetStaticRouteByName(ctx, staticRouteConfig.Name)
+	check.Assert(err, IsNil)
+	check.Assert(byName.NsxtEdgeGatewayStaticRoute.ID, Equals, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+
+	byId, err := edge.GetStaticRouteById(ctx, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+	check.Assert(err, IsNil)
+	check.Assert(byId.NsxtEdgeGatewayStaticRoute.NetworkCidr, Equals, staticRouteConfig.NetworkCidr)
+
+	byCidr, err := edge.GetStaticRouteByNetworkCidr(ctx, staticRouteConfig.NetworkCidr)
+	check.Assert(err, IsNil)
+	check.Assert(byCidr.NsxtEdgeGatewayStaticRoute.ID, Equals, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+
+	byNameOrId, err := edge.GetStaticRouteByNameOrId(ctx, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+	check.Assert(err, IsNil)
+	check.Assert(byNameOrId.NsxtEdgeGatewayStaticRoute.ID, Equals, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+	byNameOrId, err = edge.GetStaticRouteByNameOrId(ctx, staticRouteConfig.Name)
+	check.Assert(err, IsNil)
+	check.Assert(byNameOrId.NsxtEdgeGatewayStaticRoute.ID, Equals, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+
+	staticRouteConfig.Name = check.TestName() + "-updated"
+	staticRouteConfig.Version = staticRoute.NsxtEdgeGatewayStaticRoute.Version
+	updatedStaticRoute, err := staticRoute.Update(ctx, staticRouteConfig)
+	check.Assert(err, IsNil)
+	check.Assert(updatedStaticRoute.NsxtEdgeGatewayStaticRoute.Name, Equals, staticRouteConfig.Name)
+
+	err = updatedStaticRoute.Delete(ctx)
+	check.Assert(err, IsNil)
+
+	_, err = edge.GetStaticRouteByNameOrId(ctx, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+	check.Assert(ContainsNotFound(err), Equals, true)
+	_, err = edge.GetStaticRouteByName(ctx, staticRouteConfig.Name)
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
@@ -61,6 +61,12 @@ func (vcd *TestVCD) Test_NsxtEdgeCreate(check *C) {
 	openApiEndpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGateways + createdEdge.EdgeGateway.ID
 	AddToCleanupListOpenApi(createdEdge.EdgeGateway.Name, check.TestName(), openApiEndpoint)
 
+	err = createdEdge.WaitForRealization(ctx)
+	check.Assert(err, IsNil)
+	realizationStatus, err := createdEdge.GetRealizationStatus(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(realizationStatus, Equals, types.RealizationStatusRealized)
+
 	createdEdge.EdgeGateway.Name = "renamed-edge"
 	updatedEdge, err := createdEdge.Update(ctx, createdEdge.EdgeGateway)
 	check.Assert(err, IsNil)
@@ -1640,3 +1640,167 @@ func TestOpenAPIEdgeGateway_DeallocateIpCount(t *testing.T) {
 		})
 	}
 }
+
+func Test_attachDetachEdgeGatewayUplink(t *testing.T) {
+	edgeGateway := &types.OpenAPIEdgeGateway{
+		Name: "egw",
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{
+			{UplinkID: "urn:vcloud:network:primary"},
+		},
+	}
+
+	err := attachEdgeGatewayUplink(edgeGateway, types.EdgeGatewayUplinks{})
+	if err == nil {
+		t.Fatalf("expected error when attaching uplink without ID")
+	}
+
+	err = attachEdgeGatewayUplink(edgeGateway, types.EdgeGatewayUplinks{UplinkID: "urn:vcloud:network:segment"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(edgeGateway.EdgeGatewayUplinks) != 2 {
+		t.Fatalf("expected 2 uplinks, got %d", len(edgeGateway.EdgeGatewayUplinks))
+	}
+
+	err = attachEdgeGatewayUplink(edgeGateway, types.EdgeGatewayUplinks{UplinkID: "urn:vcloud:network:segment"})
+	if err == nil {
+		t.Fatalf("expected error when attaching the same uplink twice")
+	}
+
+	err = detachEdgeGatewayUplink(edgeGateway, "urn:vcloud:network:primary")
+	if err == nil {
+		t.Fatalf("expected error when detaching primary uplink")
+	}
+
+	err = detachEdgeGatewayUplink(edgeGateway, "urn:vcloud:network:unknown")
+	if !ContainsNotFound(err) {
+		t.Fatalf("expected not found error, got: %s", err)
+	}
+
+	err = detachEdgeGatewayUplink(edgeGateway, "urn:vcloud:network:segment")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(edgeGateway.EdgeGatewayUplinks) != 1 || edgeGateway.EdgeGatewayUplinks[0].UplinkID != "urn:vcloud:network:primary" {
+		t.Fatalf("unexpected uplinks after detach: %v", edgeGateway.EdgeGatewayUplinks)
+	}
+}
+
+func TestNsxtEdgeGateway_AllocateDeallocateIpAddresses(t *testing.T) {
+	totalIpCount := 2
+	egw := &NsxtEdgeGateway{EdgeGateway: &types.OpenAPIEdgeGateway{
+		Name: "egw",
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{
+			UplinkID:   "urn:vcloud:network:primary",
+			UplinkName: "primary",
+			Subnets: types.OpenAPIEdgeGatewaySubnets{Values: []types.OpenAPIEdgeGatewaySubnetValue{
+				{
+					Gateway:      "10.10.10.1",
+					PrefixLength: 24,
+					IPRanges: &types.OpenApiIPRanges{Values: []types.OpenApiIPRangeValues{
+						{StartAddress: "10.10.10.10", EndAddress: "10.10.10.11"},
+					}},
+					TotalIPCount: &totalIpCount,
+				},
+				{Gateway: "20.20.20.1", PrefixLength: 24},
+			}},
+		}},
+	}}
+
+	ips := []netip.Addr{netip.MustParseAddr("10.10.10.12"), netip.MustParseAddr("10.10.10.20"), netip.MustParseAddr("20.20.20.5")}
+	err := egw.AllocateIpAddresses("", ips)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	subnets := egw.EdgeGateway.EdgeGatewayUplinks[0].Subnets.Values
+	expectedRanges := []types.OpenApiIPRangeValues{
+		{StartAddress: "10.10.10.10", EndAddress: "10.10.10.12"},
+		{StartAddress: "10.10.10.20", EndAddress: "10.10.10.20"},
+	}
+	if !reflect.DeepEqual(subnets[0].IPRanges.Values, expectedRanges) || *subnets[0].TotalIPCount != 4 {
+		t.Fatalf("unexpected first subnet after allocation: %v, count %d", subnets[0].IPRanges.Values, *subnets[0].TotalIPCount)
+	}
+	if len(subnets[1].IPRanges.Values) != 1 || subnets[1].IPRanges.Values[0].StartAddress != "20.20.20.5" || *subnets[1].TotalIPCount != 1 {
+		t.Fatalf("unexpected second subnet after allocation: %v", subnets[1].IPRanges)
+	}
+
+	err = egw.AllocateIpAddresses("urn:vcloud:network:primary", []netip.Addr{netip.MustParseAddr("10.10.10.11")})
+	if err == nil {
+		t.Fatalf("expected error when allocating an IP address twice")
+	}
+	err = egw.AllocateIpAddresses("", []netip.Addr{netip.MustParseAddr("30.30.30.1")})
+	if err == nil {
+		t.Fatalf("expected error when allocating an IP address outside of the uplink subnets")
+	}
+	err = egw.AllocateIpAddresses("urn:vcloud:network:unknown", ips)
+	if !ContainsNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+
+	err = egw.DeallocateIpAddresses("", []netip.Addr{netip.MustParseAddr("10.10.10.11")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	subnets = egw.EdgeGateway.EdgeGatewayUplinks[0].Subnets.Values
+	expectedRanges = []types.OpenApiIPRangeValues{
+		{StartAddress: "10.10.10.10", EndAddress: "10.10.10.10"},
+		{StartAddress: "10.10.10.12", EndAddress: "10.10.10.12"},
+		{StartAddress: "10.10.10.20", EndAddress: "10.10.10.20"},
+	}
+	if !reflect.DeepEqual(subnets[0].IPRanges.Values, expectedRanges) || *subnets[0].TotalIPCount != 3 {
+		t.Fatalf("unexpected first subnet after deallocation: %v, count %d", subnets[0].IPRanges.Values, *subnets[0].TotalIPCount)
+	}
+
+	err = egw.DeallocateIpAddresses("", []netip.Addr{netip.MustParseAddr("10.10.10.11")})
+	if err == nil {
+		t.Fatalf("expected error when deallocating an IP address that is not allocated")
+	}
+}
+
+func Test_computeNsxtEdgeGatewayRoutes(t *testing.T) {
+	uplinks := []types.EdgeGatewayUplinks{
+		{
+			UplinkName: "ext-net",
+			Subnets: types.OpenAPIEdgeGatewaySubnets{Values: []types.OpenAPIEdgeGatewaySubnetValue{
+				{Gateway: "10.10.10.1", PrefixLength: 24, PrimaryIP: "10.10.10.10"},
+				{Gateway: "20.20.20.1", PrefixLength: 28},
+			}},
+		},
+	}
+	networks := []*types.OpenApiOrgVdcNetwork{
+		{
+			Name:       "routed-1",
+			Connection: &types.Connection{RouterRef: types.OpenApiReference{ID: "urn:vcloud:gateway:1"}},
+			Subnets:    types.OrgVdcNetworkSubnets{Values: []types.OrgVdcNetworkSubnetValues{{Gateway: "192.168.1.1", PrefixLength: 24}}},
+		},
+		{
+			Name:       "routed-2",
+			Connection: &types.Connection{RouterRef: types.OpenApiReference{ID: "urn:vcloud:gateway:1"}},
+			Subnets:    types.OrgVdcNetworkSubnets{Values: []types.OrgVdcNetworkSubnetValues{{Gateway: "172.16.0.1", PrefixLength: 16}}},
+		},
+		{
+			Name:    "isolated",
+			Subnets: types.OrgVdcNetworkSubnets{Values: []types.OrgVdcNetworkSubnetValues{{Gateway: "192.168.2.1", PrefixLength: 24}}},
+		},
+	}
+
+	routes, err := computeNsxtEdgeGatewayRoutes(uplinks, networks, []string{"192.168.0.0/16"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []NsxtEdgeGatewayRoute{
+		{Network: "10.10.10.0/24", Source: NsxtRouteSourceUplink, SourceName: "ext-net"},
+		{Network: "20.20.20.0/28", Source: NsxtRouteSourceUplink, SourceName: "ext-net"},
+		{Network: "192.168.1.0/24", Source: NsxtRouteSourceConnected, SourceName: "routed-1", Advertised: true},
+		{Network: "172.16.0.0/16", Source: NsxtRouteSourceConnected, SourceName: "routed-2"},
+		{Network: "0.0.0.0/0", NextHop: "10.10.10.1", Source: NsxtRouteSourceDefault, SourceName: "ext-net"},
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Fatalf("expected routes %v, got %v", expected, routes)
+	}
+
+	_, err = computeNsxtEdgeGatewayRoutes(uplinks, networks, []string{"not-a-subnet"})
+	if err == nil {
+		t.Fatalf("expected error with an invalid advertised subnet")
+	}
+}
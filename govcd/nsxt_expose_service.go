@@ -0,0 +1,254 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// NsxtExposeServiceSpec defines a service running on an internal address that must be reachable from an
+// external (sub-allocated) address of an NSX-T Edge Gateway
+type NsxtExposeServiceSpec struct {
+	// Name is used for all the objects created by NsxtEdgeGateway.ExposeService
+	Name        string
+	Description string
+
+	// ExternalAddress must be an IP address sub-allocated to the Edge Gateway
+	ExternalAddress string
+	// InternalAddress is the address of the workload providing the service
+	InternalAddress string
+	// ExternalPort is optional. When set, traffic to ExternalPort is translated to the port of the application
+	ExternalPort string
+
+	// ApplicationPortProfileId references an existing Application Port Profile describing the service ports.
+	// When empty, a new Application Port Profile is created using Protocol and Ports
+	ApplicationPortProfileId string
+	// Protocol is one of "TCP" or "UDP". Required when ApplicationPortProfileId is empty
+	Protocol string
+	// Ports is a list of ports ("443") or port ranges ("8000-8080"). Required when ApplicationPortProfileId is empty
+	Ports []string
+
+	// SourceFirewallGroupIds limits the sources allowed by the firewall rule. Empty means 'Any'
+	SourceFirewallGroupIds []string
+	Logging                bool
+}
+
+// NsxtExposedService contains the objects created by NsxtEdgeGateway.ExposeService
+type NsxtExposedService struct {
+	NatRule *NsxtNatRule
+	// IpSet contains the internal address and is used as destination of the firewall rule
+	IpSet          *NsxtFirewallGroup
+	FirewallRuleId string
+	// AppPortProfile is only set when the Application Port Profile was created by ExposeService
+	AppPortProfile *NsxtAppPortProfile
+
+	edgeGateway *NsxtEdgeGateway
+}
+
+// ExposeService makes a service running on an internal address reachable from an external address, performing
+// in one call the steps that are usually needed for it:
+// * creates an Application Port Profile for the service ports (unless spec.ApplicationPortProfileId is given)
+// * creates an IP Set with the internal address
+// * creates a DNAT rule from the external to the internal address
+// * adds a firewall rule allowing the service traffic to the IP Set, as first user defined rule
+//
+// The firewall rule matches the internal address, which is the default firewall match of DNAT rules.
+// If any step fails, the objects created so far are removed.
+func (egw *NsxtEdgeGateway) ExposeService(ctx context.Context, spec NsxtExposeServiceSpec) (*NsxtExposedService, error) {
+	err := validateExposeServiceSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	exposed := &NsxtExposedService{edgeGateway: egw}
+	rollback := func(cause error) error {
+		cleanupErr := exposed.Delete(ctx)
+		if cleanupErr != nil {
+			return fmt.Errorf("%s (removing objects created so far also failed: %s)", cause, cleanupErr)
+		}
+		return cause
+	}
+
+	appPortProfileId := spec.ApplicationPortProfileId
+	if appPortProfileId == "" {
+		if egw.EdgeGateway.Org == nil || egw.EdgeGateway.OwnerRef == nil {
+			return nil, fmt.Errorf("cannot create Application Port Profile: Org or owner of Edge Gateway '%s' unknown", egw.EdgeGateway.Name)
+		}
+		exposed.AppPortProfile, err = createNsxtAppPortProfile(ctx, egw.client, &types.NsxtAppPortProfile{
+			Name:             spec.Name,
+			Description:      spec.Description,
+			ApplicationPorts: []types.NsxtAppPortProfilePort{{Protocol: spec.Protocol, DestinationPorts: spec.Ports}},
+			OrgRef:           &types.OpenApiReference{ID: egw.EdgeGateway.Org.ID},
+			ContextEntityId:  egw.EdgeGateway.OwnerRef.ID,
+			Scope:            types.ApplicationPortProfileScopeTenant,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error exposing service '%s': %w", spec.Name, err)
+		}
+		appPortProfileId = exposed.AppPortProfile.NsxtAppPortProfile.ID
+	}
+	appPortProfileRef := &types.OpenApiReference{ID: appPortProfileId}
+
+	ipSetConfig := &types.NsxtFirewallGroup{
+		Name:        spec.Name,
+		Description: spec.Description,
+		IpAddresses: []string{spec.InternalAddress},
+		OwnerRef:    &types.OpenApiReference{ID: egw.EdgeGateway.ID},
+	}
+	if egw.client.APIVCDMaxVersionIs(ctx, ">= 36.0") {
+		ipSetConfig.TypeValue = types.FirewallGroupTypeIpSet
+	} else {
+		ipSetConfig.Type = types.FirewallGroupTypeIpSet
+	}
+	exposed.IpSet, err = egw.CreateNsxtFirewallGroup(ctx, ipSetConfig)
+	if err != nil {
+		return nil, rollback(fmt.Errorf("error exposing service '%s': %w", spec.Name, err))
+	}
+
+	exposed.NatRule, err = egw.CreateNatRule(ctx, &types.NsxtNatRule{
+		Name:                   spec.Name,
+		Description:            spec.Description,
+		Enabled:                true,
+		RuleType:               types.NsxtNatRuleTypeDnat,
+		ExternalAddresses:      spec.ExternalAddress,
+		InternalAddresses:      spec.InternalAddress,
+		ApplicationPortProfile: appPortProfileRef,
+		DnatExternalPort:       spec.ExternalPort,
+		Logging:                spec.Logging,
+	})
+	if err != nil {
+		return nil, rollback(fmt.Errorf("error exposing service '%s': %w", spec.Name, err))
+	}
+
+	sourceFirewallGroups := make([]types.OpenApiReference, len(spec.SourceFirewallGroupIds))
+	for index, id := range spec.SourceFirewallGroupIds {
+		sourceFirewallGroups[index] = types.OpenApiReference{ID: id}
+	}
+	exposed.FirewallRuleId, err = egw.addFirstFirewallRule(ctx, &types.NsxtFirewallRule{
+		Name:                      spec.Name,
+		Action:                    "ALLOW",
+		Enabled:                   true,
+		SourceFirewallGroups:      sourceFirewallGroups,
+		DestinationFirewallGroups: []types.OpenApiReference{{ID: exposed.IpSet.NsxtFirewallGroup.ID}},
+		ApplicationPortProfiles:   []types.OpenApiReference{*appPortProfileRef},
+		IpProtocol:                "IPV4_IPV6",
+		Logging:                   spec.Logging,
+		Direction:                 "IN_OUT",
+	})
+	if err != nil {
+		return nil, rollback(fmt.Errorf("error exposing service '%s': %w", spec.Name, err))
+	}
+
+	return exposed, nil
+}
+
+// Delete removes all the objects created by NsxtEdgeGateway.ExposeService, in reverse order of creation.
+// An Application Port Profile which was passed to ExposeService by ID is not removed.
+func (exposed *NsxtExposedService) Delete(ctx context.Context) error {
+	var errorMessages []string
+
+	if exposed.FirewallRuleId != "" {
+		firewall, err := exposed.edgeGateway.GetNsxtFirewall(ctx)
+		if err == nil {
+			err = firewall.DeleteRuleById(ctx, exposed.FirewallRuleId)
+		}
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		} else {
+			exposed.FirewallRuleId = ""
+		}
+	}
+	if exposed.NatRule != nil {
+		err := exposed.NatRule.Delete(ctx)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		} else {
+			exposed.NatRule = nil
+		}
+	}
+	if exposed.IpSet != nil {
+		err := exposed.IpSet.Delete(ctx)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		} else {
+			exposed.IpSet = nil
+		}
+	}
+	if exposed.AppPortProfile != nil {
+		err := exposed.AppPortProfile.Delete(ctx)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		} else {
+			exposed.AppPortProfile = nil
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("error removing exposed service objects: %s", strings.Join(errorMessages, "; "))
+	}
+	return nil
+}
+
+// addFirstFirewallRule inserts rule as first user defined firewall rule of the Edge Gateway and returns its ID
+func (egw *NsxtEdgeGateway) addFirstFirewallRule(ctx context.Context, rule *types.NsxtFirewallRule) (string, error) {
+	firewall, err := egw.GetNsxtFirewall(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	existingIds := make(map[string]bool)
+	for _, existingRule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		existingIds[existingRule.ID] = true
+	}
+
+	container := firewall.NsxtFirewallRuleContainer
+	container.UserDefinedRules = append([]*types.NsxtFirewallRule{rule}, container.UserDefinedRules...)
+	_, err = egw.UpdateNsxtFirewall(ctx, container)
+	if err != nil {
+		return "", err
+	}
+
+	// The firewall update does not return the ID of the new rule, which is looked up among the rules that did
+	// not exist before the update
+	firewall, err = egw.GetNsxtFirewall(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, newRule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		if !existingIds[newRule.ID] && newRule.Name == rule.Name {
+			util.Logger.Printf("[TRACE] created firewall rule '%s' with ID %s", rule.Name, newRule.ID)
+			return newRule.ID, nil
+		}
+	}
+	return "", fmt.Errorf("firewall rule '%s' not found after creation", rule.Name)
+}
+
+// validateExposeServiceSpec checks that the fields required by NsxtEdgeGateway.ExposeService are set
+func validateExposeServiceSpec(spec NsxtExposeServiceSpec) error {
+	if spec.Name == "" {
+		return fmt.Errorf("service name is required")
+	}
+	if spec.ExternalAddress == "" || spec.InternalAddress == "" {
+		return fmt.Errorf("both external and internal addresses are required for service '%s'", spec.Name)
+	}
+	if spec.ApplicationPortProfileId != "" {
+		if spec.Protocol != "" || len(spec.Ports) > 0 {
+			return fmt.Errorf("protocol and ports cannot be set together with an Application Port Profile ID for service '%s'", spec.Name)
+		}
+		return nil
+	}
+	if spec.Protocol != "TCP" && spec.Protocol != "UDP" {
+		return fmt.Errorf("protocol must be one of 'TCP' or 'UDP' for service '%s', got '%s'", spec.Name, spec.Protocol)
+	}
+	if len(spec.Ports) == 0 {
+		return fmt.Errorf("at least one port is required for service '%s'", spec.Name)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+//go:build network || nsxt || functional || openapi || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Test_NsxtExposeService checks that ExposeService creates an Application Port Profile, an IP Set, a DNAT rule
+// and a firewall rule, and that NsxtExposedService.Delete removes all of them
+func (vcd *TestVCD) Test_NsxtExposeService(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointFirewallGroups)
+
+	org, edge, externalAddress := getExposeServiceTestEdgeGateway(vcd, check)
+
+	exposed, err := edge.ExposeService(ctx, NsxtExposeServiceSpec{
+		Name:            check.TestName(),
+		Description:     "Exposed service created by test",
+		ExternalAddress: externalAddress,
+		InternalAddress: "11.11.11.10",
+		ExternalPort:    "8443",
+		Protocol:        "TCP",
+		Ports:           []string{"443"},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(exposed.AppPortProfile, NotNil)
+	check.Assert(exposed.IpSet, NotNil)
+	check.Assert(exposed.NatRule, NotNil)
+	check.Assert(exposed.FirewallRuleId, Not(Equals), "")
+
+	// The objects are prepended in creation order, so that they are removed in reverse order
+	PrependToCleanupListOpenApi(exposed.AppPortProfile.NsxtAppPortProfile.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAppPortProfiles+exposed.AppPortProfile.NsxtAppPortProfile.ID)
+	PrependToCleanupListOpenApi(exposed.IpSet.NsxtFirewallGroup.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointFirewallGroups+exposed.IpSet.NsxtFirewallGroup.ID)
+	PrependToCleanupListOpenApi(exposed.NatRule.NsxtNatRule.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+fmt.Sprintf(types.OpenApiEndpointNsxtNatRules, edge.EdgeGateway.ID)+exposed.NatRule.NsxtNatRule.ID)
+	PrependToCleanupListOpenApi(check.TestName(), check.TestName(),
+		types.OpenApiPathVersion1_0_0+fmt.Sprintf(types.OpenApiEndpointNsxtFirewallRules, edge.EdgeGateway.ID)+"/"+exposed.FirewallRuleId)
+
+	appPortProfileId := exposed.AppPortProfile.NsxtAppPortProfile.ID
+	ipSetId := exposed.IpSet.NsxtFirewallGroup.ID
+	natRuleId := exposed.NatRule.NsxtNatRule.ID
+	firewallRuleId := exposed.FirewallRuleId
+
+	// All the objects exist, and the firewall rule is the first user defined rule
+	_, err = org.GetNsxtAppPortProfileById(ctx, appPortProfileId)
+	check.Assert(err, IsNil)
+	ipSet, err := edge.GetNsxtFirewallGroupById(ctx, ipSetId)
+	check.Assert(err, IsNil)
+	check.Assert(ipSet.NsxtFirewallGroup.IpAddresses, DeepEquals, []string{"11.11.11.10"})
+	natRule, err := edge.GetNatRuleById(ctx, natRuleId)
+	check.Assert(err, IsNil)
+	check.Assert(natRule.NsxtNatRule.RuleType, Equals, types.NsxtNatRuleTypeDnat)
+	check.Assert(natRule.NsxtNatRule.ExternalAddresses, Equals, externalAddress)
+	check.Assert(natRule.NsxtNatRule.DnatExternalPort, Equals, "8443")
+	firewall, err := edge.GetNsxtFirewall(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(len(firewall.NsxtFirewallRuleContainer.UserDefinedRules) > 0, Equals, true)
+	firstRule := firewall.NsxtFirewallRuleContainer.UserDefinedRules[0]
+	check.Assert(firstRule.ID, Equals, firewallRuleId)
+	check.Assert(len(firstRule.DestinationFirewallGroups), Equals, 1)
+	check.Assert(firstRule.DestinationFirewallGroups[0].ID, Equals, ipSetId)
+
+	err = exposed.Delete(ctx)
+	check.Assert(err, IsNil)
+	checkExposedServiceRemoved(check, org, edge, appPortProfileId, ipSetId, natRuleId, firewallRuleId)
+}
+
+// Test_NsxtExposeServiceRollback checks that the objects created by ExposeService are removed when a later step
+// fails. The DNAT rule is rejected because its external address is not valid, after the Application Port Profile
+// and the IP Set were created
+func (vcd *TestVCD) Test_NsxtExposeServiceRollback(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointFirewallGroups)
+
+	org, edge, _ := getExposeServiceTestEdgeGateway(vcd, check)
+
+	exposed, err := edge.ExposeService(ctx, NsxtExposeServiceSpec{
+		Name:            check.TestName(),
+		ExternalAddress: "not-an-ip-address",
+		InternalAddress: "11.11.11.11",
+		Protocol:        "TCP",
+		Ports:           []string{"443"},
+	})
+	check.Assert(err, NotNil)
+	check.Assert(exposed, IsNil)
+
+	// Neither the objects created before the failure nor the ones after it exist
+	_, err = org.GetNsxtAppPortProfileByName(ctx, check.TestName(), types.ApplicationPortProfileScopeTenant)
+	check.Assert(ContainsNotFound(err), Equals, true)
+	_, err = edge.GetNsxtFirewallGroupByName(ctx, check.TestName(), types.FirewallGroupTypeIpSet)
+	check.Assert(ContainsNotFound(err), Equals, true)
+	_, err = edge.GetNatRuleByName(ctx, check.TestName())
+	check.Assert(ContainsNotFound(err), Equals, true)
+	firewall, err := edge.GetNsxtFirewall(ctx)
+	check.Assert(err, IsNil)
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		check.Assert(rule.Name, Not(Equals), check.TestName())
+	}
+}
+
+// getExposeServiceTestEdgeGateway returns the Org and the NSX-T Edge Gateway of the test configuration, and its
+// primary IP address, which is used as external address of the exposed services
+func getExposeServiceTestEdgeGateway(vcd *TestVCD, check *C) (*Org, *NsxtEdgeGateway, string) {
+	org, err := vcd.client.GetOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	nsxtVdc, err := org.GetVDCByName(ctx, vcd.config.VCD.Nsxt.Vdc, false)
+	check.Assert(err, IsNil)
+	edge, err := nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	edgeGatewayPrimaryIp := ""
+	if edge.EdgeGateway != nil && len(edge.EdgeGateway.EdgeGatewayUplinks) > 0 && len(edge.EdgeGateway.EdgeGatewayUplinks[0].Subnets.Values) > 0 {
+		edgeGatewayPrimaryIp = edge.EdgeGateway.EdgeGatewayUplinks[0].Subnets.Values[0].PrimaryIP
+	}
+	check.Assert(edgeGatewayPrimaryIp, Not(Equals), "")
+	return org, edge, edgeGatewayPrimaryIp
+}
+
+// checkExposedServiceRemoved checks that none of the objects of an exposed service exist
+func checkExposedServiceRemoved(check *C, org *Org, edge *NsxtEdgeGateway, appPortProfileId, ipSetId, natRuleId, firewallRuleId string) {
+	_, err := org.GetNsxtAppPortProfileById(ctx, appPortProfileId)
+	check.Assert(ContainsNotFound(err), Equals, true)
+	_, err = edge.GetNsxtFirewallGroupById(ctx, ipSetId)
+	check.Assert(ContainsNotFound(err), Equals, true)
+	_, err = edge.GetNatRuleById(ctx, natRuleId)
+	check.Assert(ContainsNotFound(err), Equals, true)
+	firewall, err := edge.GetNsxtFirewall(ctx)
+	check.Assert(err, IsNil)
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		check.Assert(rule.ID, Not(Equals), firewallRuleId)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+)
+
+func Test_validateExposeServiceSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    NsxtExposeServiceSpec
+		wantErr bool
+	}{
+		{name: "Empty", spec: NsxtExposeServiceSpec{}, wantErr: true},
+		{
+			name:    "MissingInternalAddress",
+			spec:    NsxtExposeServiceSpec{Name: "web", ExternalAddress: "1.1.1.1", Protocol: "TCP", Ports: []string{"443"}},
+			wantErr: true,
+		},
+		{
+			name:    "NewProfile",
+			spec:    NsxtExposeServiceSpec{Name: "web", ExternalAddress: "1.1.1.1", InternalAddress: "10.0.0.10", Protocol: "TCP", Ports: []string{"443"}},
+			wantErr: false,
+		},
+		{
+			name:    "NewProfileInvalidProtocol",
+			spec:    NsxtExposeServiceSpec{Name: "web", ExternalAddress: "1.1.1.1", InternalAddress: "10.0.0.10", Protocol: "ICMPv4", Ports: []string{"443"}},
+			wantErr: true,
+		},
+		{
+			name:    "NewProfileWithoutPorts",
+			spec:    NsxtExposeServiceSpec{Name: "web", ExternalAddress: "1.1.1.1", InternalAddress: "10.0.0.10", Protocol: "UDP"},
+			wantErr: true,
+		},
+		{
+			name:    "ExistingProfile",
+			spec:    NsxtExposeServiceSpec{Name: "web", ExternalAddress: "1.1.1.1", InternalAddress: "10.0.0.10", ApplicationPortProfileId: "urn:vcloud:applicationPortProfile:1"},
+			wantErr: false,
+		},
+		{
+			name:    "ExistingProfileAndPorts",
+			spec:    NsxtExposeServiceSpec{Name: "web", ExternalAddress: "1.1.1.1", InternalAddress: "10.0.0.10", ApplicationPortProfileId: "urn:vcloud:applicationPortProfile:1", Ports: []string{"443"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExposeServiceSpec(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateExposeServiceSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
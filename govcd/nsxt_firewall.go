@@ -7,6 +7,8 @@ package govcd
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
@@ -23,6 +25,9 @@ type NsxtFirewall struct {
 
 // UpdateNsxtFirewall allows user to set new firewall rules or update existing ones. The API does not have POST endpoint
 // and always uses PUT endpoint for creating and updating.
+//
+// Rules can be checked with ValidateNsxtFirewallIpFamilies before calling this function, to get a clearer error than
+// the one returned by VCD when they use firewall groups of the wrong IP family.
 func (egw *NsxtEdgeGateway) UpdateNsxtFirewall(ctx context.Context, firewallRules *types.NsxtFirewallRuleContainer) (*NsxtFirewall, error) {
 	client := egw.client
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointNsxtFirewallRules
@@ -45,7 +50,7 @@ func (egw *NsxtEdgeGateway) UpdateNsxtFirewall(ctx context.Context, firewallRule
 
 	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, firewallRules, returnObject.NsxtFirewallRuleContainer, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error setting NSX-T Firewall: %s", err)
+		return nil, fmt.Errorf("error setting NSX-T Firewall: %w", err)
 	}
 
 	return returnObject, nil
@@ -74,7 +79,7 @@ func (egw *NsxtEdgeGateway) GetNsxtFirewall(ctx context.Context) (*NsxtFirewall,
 
 	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, returnObject.NsxtFirewallRuleContainer, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T Firewall rules: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T Firewall rules: %w", err)
 	}
 
 	// Store Edge Gateway ID for later operations
@@ -104,7 +109,7 @@ func (firewall *NsxtFirewall) DeleteAllRules(ctx context.Context) error {
 	err = firewall.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting all NSX-T Firewall Rules: %s", err)
+		return fmt.Errorf("error deleting all NSX-T Firewall Rules: %w", err)
 	}
 
 	return nil
@@ -130,8 +135,89 @@ func (firewall *NsxtFirewall) DeleteRuleById(ctx context.Context, id string) err
 	err = firewall.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Firewall Rule with ID '%s': %s", id, err)
+		return fmt.Errorf("error deleting NSX-T Firewall Rule with ID '%s': %w", id, err)
 	}
 
 	return nil
 }
+
+// ValidateNsxtFirewallIpFamilies checks that the user defined rules for a single IP protocol (IPV4 or IPV6) don't use
+// IP Set firewall groups that only contain addresses of the other family. It retrieves each firewall group used by
+// those rules, so it needs permission to read them. It is meant to be called before UpdateNsxtFirewall, which
+// leaves the validation to VCD.
+func (egw *NsxtEdgeGateway) ValidateNsxtFirewallIpFamilies(ctx context.Context, firewallRules *types.NsxtFirewallRuleContainer) error {
+	if firewallRules == nil {
+		return nil
+	}
+	rules := firewallRules.UserDefinedRules
+	groups := make(map[string]*types.NsxtFirewallGroup)
+	for _, rule := range rules {
+		if rule == nil || (rule.IpProtocol != types.NsxtFirewallRuleIpProtocolIpv4 && rule.IpProtocol != types.NsxtFirewallRuleIpProtocolIpv6) {
+			continue
+		}
+		for _, reference := range append(append([]types.OpenApiReference{}, rule.SourceFirewallGroups...), rule.DestinationFirewallGroups...) {
+			if _, found := groups[reference.ID]; found || reference.ID == "" {
+				continue
+			}
+			group, err := egw.GetNsxtFirewallGroupById(ctx, reference.ID)
+			if err != nil {
+				return fmt.Errorf("error retrieving firewall group '%s' used in firewall rule '%s': %w", reference.ID, rule.Name, err)
+			}
+			groups[reference.ID] = group.NsxtFirewallGroup
+		}
+		err := validateNsxtFirewallRuleIpFamily(rule, groups)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNsxtFirewallRuleIpFamily returns an error when an IPV4 or IPV6 rule uses a firewall group whose IP addresses
+// all belong to the other family. Groups without IP addresses (e.g. Security Groups) are not checked
+func validateNsxtFirewallRuleIpFamily(rule *types.NsxtFirewallRule, groups map[string]*types.NsxtFirewallGroup) error {
+	check := func(direction string, references []types.OpenApiReference) error {
+		for _, reference := range references {
+			group := groups[reference.ID]
+			if group == nil {
+				continue
+			}
+			hasIpv4, hasIpv6 := nsxtFirewallGroupIpFamilies(group.IpAddresses)
+			if rule.IpProtocol == types.NsxtFirewallRuleIpProtocolIpv6 && hasIpv4 && !hasIpv6 {
+				return fmt.Errorf("firewall rule '%s' is IPv6 only, but its %s firewall group '%s' only contains IPv4 addresses",
+					rule.Name, direction, group.Name)
+			}
+			if rule.IpProtocol == types.NsxtFirewallRuleIpProtocolIpv4 && hasIpv6 && !hasIpv4 {
+				return fmt.Errorf("firewall rule '%s' is IPv4 only, but its %s firewall group '%s' only contains IPv6 addresses",
+					rule.Name, direction, group.Name)
+			}
+		}
+		return nil
+	}
+	err := check("source", rule.SourceFirewallGroups)
+	if err != nil {
+		return err
+	}
+	return check("destination", rule.DestinationFirewallGroups)
+}
+
+// nsxtFirewallGroupIpFamilies reports which IP families are present in a list of addresses, CIDRs and ranges such as
+// "10.0.0.1-10.0.0.10"
+func nsxtFirewallGroupIpFamilies(ipAddresses []string) (hasIpv4, hasIpv6 bool) {
+	for _, address := range ipAddresses {
+		address = strings.TrimSpace(address)
+		if index := strings.IndexAny(address, "/-"); index > 0 {
+			address = address[:index]
+		}
+		ip := net.ParseIP(address)
+		switch {
+		case ip == nil:
+			continue
+		case ip.To4() != nil:
+			hasIpv4 = true
+		default:
+			hasIpv6 = true
+		}
+	}
+	return hasIpv4, hasIpv6
+}
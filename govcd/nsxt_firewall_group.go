@@ -217,7 +217,7 @@ func (firewallGroup *NsxtFirewallGroup) Update(ctx context.Context, firewallGrou
 
 	err = firewallGroup.client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, firewallGroupConfig, returnObject.NsxtFirewallGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T firewall group: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T firewall group: %w", err)
 	}
 
 	return returnObject, nil
@@ -243,7 +243,7 @@ func (firewallGroup *NsxtFirewallGroup) Delete(ctx context.Context) error {
 	err = firewallGroup.client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T Firewall Group: %s", err)
+		return fmt.Errorf("error deleting NSX-T Firewall Group: %w", err)
 	}
 
 	return nil
@@ -279,7 +279,7 @@ func (firewallGroup *NsxtFirewallGroup) GetAssociatedVms(ctx context.Context) ([
 	err = firewallGroup.client.OpenApiGetAllItems(ctx, apiVersion, urlRef, nil, &associatedVms, nil)
 
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving associated VMs: %s", err)
+		return nil, fmt.Errorf("error retrieving associated VMs: %w", err)
 	}
 
 	return associatedVms, nil
@@ -306,7 +306,7 @@ func getNsxtFirewallGroupByName(ctx context.Context, client *Client, name string
 
 	allGroups, err := getAllNsxtFirewallGroups(ctx, client, queryParams)
 	if err != nil {
-		return nil, fmt.Errorf("could not find NSX-T Firewall Group with name '%s': %s", name, err)
+		return nil, fmt.Errorf("could not find NSX-T Firewall Group with name '%s': %w", name, err)
 	}
 
 	if len(allGroups) == 0 {
@@ -407,7 +407,7 @@ func createNsxtFirewallGroup(ctx context.Context, client *Client, firewallGroupC
 
 	err = client.OpenApiPostItem(ctx, apiVersion, urlRef, nil, firewallGroupConfig, returnObject.NsxtFirewallGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T Firewall Group: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T Firewall Group: %w", err)
 	}
 
 	return returnObject, nil
@@ -0,0 +1,74 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_validateNsxtFirewallRuleIpFamily(t *testing.T) {
+	groups := map[string]*types.NsxtFirewallGroup{
+		"ipv4":     {Name: "ipv4", IpAddresses: []string{"10.0.0.1", "10.0.1.0/24", "10.0.2.1-10.0.2.10"}},
+		"ipv6":     {Name: "ipv6", IpAddresses: []string{"2001:db8::1", "2001:db8:1::/64"}},
+		"dual":     {Name: "dual", IpAddresses: []string{"10.0.0.1", "2001:db8::1-2001:db8::10"}},
+		"security": {Name: "security"},
+	}
+	references := func(ids ...string) []types.OpenApiReference {
+		var result []types.OpenApiReference
+		for _, id := range ids {
+			result = append(result, types.OpenApiReference{ID: id})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		rule    *types.NsxtFirewallRule
+		wantErr bool
+	}{
+		{name: "Ipv6RuleIpv4Source", rule: &types.NsxtFirewallRule{IpProtocol: types.NsxtFirewallRuleIpProtocolIpv6, SourceFirewallGroups: references("ipv4")}, wantErr: true},
+		{name: "Ipv4RuleIpv6Destination", rule: &types.NsxtFirewallRule{IpProtocol: types.NsxtFirewallRuleIpProtocolIpv4, DestinationFirewallGroups: references("dual", "ipv6")}, wantErr: true},
+		{name: "Ipv6RuleIpv6Groups", rule: &types.NsxtFirewallRule{IpProtocol: types.NsxtFirewallRuleIpProtocolIpv6, SourceFirewallGroups: references("ipv6", "dual", "security")}},
+		{name: "Ipv4RuleIpv4Groups", rule: &types.NsxtFirewallRule{IpProtocol: types.NsxtFirewallRuleIpProtocolIpv4, SourceFirewallGroups: references("ipv4"), DestinationFirewallGroups: references("dual")}},
+		{name: "DualStackRule", rule: &types.NsxtFirewallRule{IpProtocol: types.NsxtFirewallRuleIpProtocolIpv4Ipv6, SourceFirewallGroups: references("ipv4", "ipv6")}},
+		{name: "UnknownGroup", rule: &types.NsxtFirewallRule{IpProtocol: types.NsxtFirewallRuleIpProtocolIpv6, SourceFirewallGroups: references("unknown")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNsxtFirewallRuleIpFamily(tt.rule, groups)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_validateSlaacProfile(t *testing.T) {
+	valid := &types.NsxtEdgeGatewaySlaacProfile{
+		Enabled: true,
+		Mode:    types.NsxtEdgeSlaacProfileModeSlaac,
+		DNSConfig: types.NsxtEdgeGatewaySlaacProfileDNSConfig{
+			DNSServerIpv6Addresses: []string{"2001:db8::53"},
+		},
+	}
+	if err := validateSlaacProfile(valid); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	invalidMode := &types.NsxtEdgeGatewaySlaacProfile{Mode: "DHCP"}
+	ipv4Dns := &types.NsxtEdgeGatewaySlaacProfile{
+		Mode:      types.NsxtEdgeSlaacProfileModeDhcpv6,
+		DNSConfig: types.NsxtEdgeGatewaySlaacProfileDNSConfig{DNSServerIpv6Addresses: []string{"8.8.8.8"}},
+	}
+	for _, profile := range []*types.NsxtEdgeGatewaySlaacProfile{nil, invalidMode, ipv4Dns} {
+		if err := validateSlaacProfile(profile); err == nil {
+			t.Errorf("expected error for profile %v", profile)
+		}
+	}
+}
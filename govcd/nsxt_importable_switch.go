@@ -34,7 +34,7 @@ func (vdc *Vdc) GetNsxtImportableSwitchByName(ctx context.Context, name string)
 
 	allNsxtImportableSwitches, err := vdc.GetAllNsxtImportableSwitches(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting all NSX-T Importable Switches for VDC '%s': %s", vdc.Vdc.Name, err)
+		return nil, fmt.Errorf("error getting all NSX-T Importable Switches for VDC '%s': %w", vdc.Vdc.Name, err)
 	}
 
 	var filteredNsxtImportableSwitches []*NsxtImportableSwitch
@@ -70,7 +70,7 @@ func (vdcGroup *VdcGroup) GetNsxtImportableSwitchByName(ctx context.Context, nam
 
 	allNsxtImportableSwitches, err := vdcGroup.GetAllNsxtImportableSwitches(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting all NSX-T Importable Switches for VDC Group '%s': %s", vdcGroup.VdcGroup.Name, err)
+		return nil, fmt.Errorf("error getting all NSX-T Importable Switches for VDC Group '%s': %w", vdcGroup.VdcGroup.Name, err)
 	}
 
 	var filteredNsxtImportableSwitches []*NsxtImportableSwitch
@@ -107,7 +107,7 @@ func (vdcGroup *VdcGroup) GetAllNsxtImportableSwitches(ctx context.Context) ([]*
 	// request requires Org VDC Group ID to be specified as UUID, not as URN
 	orgVdcGroupId, err := getBareEntityUuid(vdcGroup.VdcGroup.Id)
 	if err != nil {
-		return nil, fmt.Errorf("could not get UUID from URN '%s': %s", vdcGroup.VdcGroup.Id, err)
+		return nil, fmt.Errorf("could not get UUID from URN '%s': %w", vdcGroup.VdcGroup.Id, err)
 	}
 	filter := map[string]string{"vdcGroup": orgVdcGroupId}
 
@@ -127,7 +127,7 @@ func (vdc *Vdc) GetAllNsxtImportableSwitches(ctx context.Context) ([]*NsxtImport
 	// request requires Org VDC ID to be specified as UUID, not as URN
 	orgVdcId, err := getBareEntityUuid(vdc.Vdc.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get UUID from URN '%s': %s", vdc.Vdc.ID, err)
+		return nil, fmt.Errorf("could not get UUID from URN '%s': %w", vdc.Vdc.ID, err)
 	}
 	filter := map[string]string{"orgVdc": orgVdcId}
 
@@ -151,7 +151,7 @@ func (vcdClient *VCDClient) GetFilteredNsxtImportableSwitches(ctx context.Contex
 func (vcdClient *VCDClient) GetFilteredNsxtImportableSwitchesByName(ctx context.Context, filter map[string]string, name string) (*NsxtImportableSwitch, error) {
 	importableSwitches, err := getFilteredNsxtImportableSwitches(ctx, filter, &vcdClient.Client)
 	if err != nil {
-		return nil, fmt.Errorf("error getting list of filtered Importable Switches: %s", err)
+		return nil, fmt.Errorf("error getting list of filtered Importable Switches: %w", err)
 	}
 
 	var foundImportableSwitch bool
@@ -103,7 +103,7 @@ func (egw *NsxtEdgeGateway) GetIpSecVpnTunnelByName(ctx context.Context, name st
 
 	allVpns, err := egw.GetAllIpSecVpnTunnels(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configurations: %s", err)
+		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configurations: %w", err)
 	}
 
 	var allResults []*NsxtIpSecVpnTunnel
@@ -127,6 +127,18 @@ func (egw *NsxtEdgeGateway) GetIpSecVpnTunnelByName(ctx context.Context, name st
 	return egw.GetIpSecVpnTunnelById(ctx, allResults[0].NsxtIpSecVpn.ID)
 }
 
+// GetIpSecVpnTunnelByNameOrId retrieves single IPsec VPN Tunnel by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (egw *NsxtEdgeGateway) GetIpSecVpnTunnelByNameOrId(ctx context.Context, identifier string) (*NsxtIpSecVpnTunnel, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) { return egw.GetIpSecVpnTunnelByName(ctx, name) }
+	getById := func(id string, refresh bool) (interface{}, error) { return egw.GetIpSecVpnTunnelById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtIpSecVpnTunnel), err
+}
+
 // CreateIpSecVpnTunnel creates IPsec VPN Tunnel and returns it
 func (egw *NsxtEdgeGateway) CreateIpSecVpnTunnel(ctx context.Context, ipSecVpnConfig *types.NsxtIpSecVpnTunnel) (*NsxtIpSecVpnTunnel, error) {
 	client := egw.client
@@ -143,18 +155,18 @@ func (egw *NsxtEdgeGateway) CreateIpSecVpnTunnel(ctx context.Context, ipSecVpnCo
 
 	task, err := client.OpenApiPostItemAsync(ctx, minimumApiVersion, urlRef, nil, ipSecVpnConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T IPsec VPN Tunnel configuration: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("task failed while creating NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return nil, fmt.Errorf("task failed while creating NSX-T IPsec VPN Tunnel configuration: %w", err)
 	}
 
 	// filtering even by Name is not supported on VCD side
 	allVpns, err := egw.GetAllIpSecVpnTunnels(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configuration after creation: %s", err)
+		return nil, fmt.Errorf("error retrieving all NSX-T IPsec VPN Tunnel configuration after creation: %w", err)
 	}
 
 	for index, singleConfig := range allVpns {
@@ -162,7 +174,7 @@ func (egw *NsxtEdgeGateway) CreateIpSecVpnTunnel(ctx context.Context, ipSecVpnCo
 			// retrieve exact value by ID, because only this endpoint includes private key
 			ipSecVpn, err := egw.GetIpSecVpnTunnelById(ctx, allVpns[index].NsxtIpSecVpn.ID)
 			if err != nil {
-				return nil, fmt.Errorf("error retrieving NSX-T IPsec VPN Tunnel configuration: %s", err)
+				return nil, fmt.Errorf("error retrieving NSX-T IPsec VPN Tunnel configuration: %w", err)
 			}
 
 			return ipSecVpn, nil
@@ -198,7 +210,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) Update(ctx context.Context, ipSecVpnConfig *
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, ipSecVpnConfig, returnObject.NsxtIpSecVpn, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T IPsec VPN Tunnel configuration: %w", err)
 	}
 
 	return returnObject, nil
@@ -224,7 +236,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) Delete(ctx context.Context) error {
 
 	err = ipSecVpn.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T IPsec VPN Tunnel configuration: %s", err)
+		return fmt.Errorf("error deleting NSX-T IPsec VPN Tunnel configuration: %w", err)
 	}
 
 	return nil
@@ -255,7 +267,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) GetStatus(ctx context.Context) (*types.NsxtI
 
 	err = ipSecVpn.client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, ipSecVpnTunnelStatus, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting NSX-T IPsec VPN Tunnel status: %s", err)
+		return nil, fmt.Errorf("error getting NSX-T IPsec VPN Tunnel status: %w", err)
 	}
 
 	return ipSecVpnTunnelStatus, nil
@@ -283,7 +295,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) UpdateTunnelConnectionProperties(ctx context
 	ipSecVpnTunnelProfile := &types.NsxtIpSecVpnTunnelSecurityProfile{}
 	err = ipSecVpn.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, ipSecVpnTunnelConnectionProperties, ipSecVpnTunnelProfile, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T IPsec VPN Connection Properties: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T IPsec VPN Connection Properties: %w", err)
 	}
 
 	return ipSecVpnTunnelProfile, nil
@@ -310,7 +322,7 @@ func (ipSecVpn *NsxtIpSecVpnTunnel) GetTunnelConnectionProperties(ctx context.Co
 	ipSecVpnTunnelProfile := &types.NsxtIpSecVpnTunnelSecurityProfile{}
 	err = ipSecVpn.client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, ipSecVpnTunnelProfile, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving NSX-T IPsec VPN Connection Properties: %s", err)
+		return nil, fmt.Errorf("error retrieving NSX-T IPsec VPN Connection Properties: %w", err)
 	}
 
 	return ipSecVpnTunnelProfile, nil
@@ -213,6 +213,13 @@ func runIpSecVpnTests(check *C, edge *NsxtEdgeGateway, ipSecDef *types.NsxtIpSec
 	check.Assert(foundIpSecVpnByName.NsxtIpSecVpn, DeepEquals, createdIpSecVpn.NsxtIpSecVpn)
 	check.Assert(foundIpSecVpnByName.NsxtIpSecVpn, DeepEquals, foundIpSecVpnById.NsxtIpSecVpn)
 
+	foundIpSecVpnByNameOrId, err := edge.GetIpSecVpnTunnelByNameOrId(ctx, createdIpSecVpn.NsxtIpSecVpn.ID)
+	check.Assert(err, IsNil)
+	check.Assert(foundIpSecVpnByNameOrId.NsxtIpSecVpn, DeepEquals, createdIpSecVpn.NsxtIpSecVpn)
+	foundIpSecVpnByNameOrId, err = edge.GetIpSecVpnTunnelByNameOrId(ctx, createdIpSecVpn.NsxtIpSecVpn.Name)
+	check.Assert(err, IsNil)
+	check.Assert(foundIpSecVpnByNameOrId.NsxtIpSecVpn, DeepEquals, createdIpSecVpn.NsxtIpSecVpn)
+
 	check.Assert(createdIpSecVpn.NsxtIpSecVpn.ID, Not(Equals), "")
 
 	ipSecDef.Name = check.TestName() + "-updated"
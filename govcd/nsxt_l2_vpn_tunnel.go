@@ -0,0 +1,251 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NsxtL2VpnTunnel extends Org VDC networks of an NSX-T Edge Gateway to a remote site over an L2 VPN tunnel. The Edge
+// Gateway acts either as SERVER, generating the peer code of the tunnel, or as CLIENT, configured with the peer code
+// of the remote SERVER.
+type NsxtL2VpnTunnel struct {
+	NsxtL2VpnTunnel *types.NsxtL2VpnTunnel
+	client          *Client
+	// edgeGatewayId is stored here so that pointer receiver functions can embed edge gateway ID into path
+	edgeGatewayId string
+}
+
+// GetAllL2VpnTunnels returns all L2 VPN Tunnel configurations of the NSX-T Edge Gateway
+func (egw *NsxtEdgeGateway) GetAllL2VpnTunnels(ctx context.Context, queryParameters url.Values) ([]*NsxtL2VpnTunnel, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointL2VpnTunnel
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.NsxtL2VpnTunnel{{}}
+	err = client.OpenApiGetAllItems(ctx, apiVersion, urlRef, queryParameters, &typeResponses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrap all typeResponses into NsxtL2VpnTunnel types with client
+	wrappedResponses := make([]*NsxtL2VpnTunnel, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtL2VpnTunnel{
+			NsxtL2VpnTunnel: typeResponses[sliceIndex],
+			client:          client,
+			edgeGatewayId:   egw.EdgeGateway.ID,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetL2VpnTunnelById retrieves single L2 VPN Tunnel by ID. Only this method returns the peer code and the pre-shared
+// key of the tunnel
+func (egw *NsxtEdgeGateway) GetL2VpnTunnelById(ctx context.Context, id string) (*NsxtL2VpnTunnel, error) {
+	if id == "" {
+		return nil, fmt.Errorf("cannot find NSX-T L2 VPN Tunnel configuration without ID")
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointL2VpnTunnel
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID), id)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtL2VpnTunnel{
+		NsxtL2VpnTunnel: &types.NsxtL2VpnTunnel{},
+		client:          client,
+		edgeGatewayId:   egw.EdgeGateway.ID,
+	}
+
+	err = client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, returnObject.NsxtL2VpnTunnel, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return returnObject, nil
+}
+
+// GetL2VpnTunnelByName retrieves single L2 VPN Tunnel by Name.
+//
+// Note. Name uniqueness is not enforced therefore it might exist a few L2 VPN Tunnels with the same name.
+// An error will be returned in that case.
+func (egw *NsxtEdgeGateway) GetL2VpnTunnelByName(ctx context.Context, name string) (*NsxtL2VpnTunnel, error) {
+	if name == "" {
+		return nil, fmt.Errorf("cannot find NSX-T L2 VPN Tunnel configuration without Name")
+	}
+
+	allVpns, err := egw.GetAllL2VpnTunnels(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving all NSX-T L2 VPN Tunnel configurations: %w", err)
+	}
+
+	var allResults []*NsxtL2VpnTunnel
+	for _, vpnConfig := range allVpns {
+		if vpnConfig.NsxtL2VpnTunnel.Name == name {
+			allResults = append(allResults, vpnConfig)
+		}
+	}
+
+	singleResult, err := oneOrError("name", name, allResults)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieving again the object by ID, because only it includes peer code and Pre-shared Key
+	return egw.GetL2VpnTunnelById(ctx, singleResult.NsxtL2VpnTunnel.ID)
+}
+
+// CreateL2VpnTunnel creates L2 VPN Tunnel and returns it. A CLIENT tunnel requires the PeerCode of the remote SERVER
+// tunnel (see NsxtL2VpnTunnel.GetPeerCode)
+func (egw *NsxtEdgeGateway) CreateL2VpnTunnel(ctx context.Context, l2VpnConfig *types.NsxtL2VpnTunnel) (*NsxtL2VpnTunnel, error) {
+	if l2VpnConfig == nil {
+		return nil, fmt.Errorf("cannot create NSX-T L2 VPN Tunnel from empty configuration")
+	}
+	if l2VpnConfig.SessionMode == types.NsxtL2VpnSessionModeClient && l2VpnConfig.PeerCode == "" {
+		return nil, fmt.Errorf("peer code is required to create NSX-T L2 VPN Tunnel in %s session mode", l2VpnConfig.SessionMode)
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointL2VpnTunnel
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := client.OpenApiPostItemAsync(ctx, apiVersion, urlRef, nil, l2VpnConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T L2 VPN Tunnel configuration: %w", err)
+	}
+
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("task failed while creating NSX-T L2 VPN Tunnel configuration: %w", err)
+	}
+
+	// The task does not return the ID of the new tunnel, which is found by name and endpoints
+	allVpns, err := egw.GetAllL2VpnTunnels(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving all NSX-T L2 VPN Tunnel configurations after creation: %w", err)
+	}
+
+	for _, singleConfig := range allVpns {
+		if singleConfig.NsxtL2VpnTunnel.Name == l2VpnConfig.Name &&
+			singleConfig.NsxtL2VpnTunnel.LocalEndpointIp == l2VpnConfig.LocalEndpointIp &&
+			singleConfig.NsxtL2VpnTunnel.RemoteEndpointIp == l2VpnConfig.RemoteEndpointIp {
+			return egw.GetL2VpnTunnelById(ctx, singleConfig.NsxtL2VpnTunnel.ID)
+		}
+	}
+
+	return nil, fmt.Errorf("error finding NSX-T L2 VPN Tunnel configuration after creation: %s", ErrorEntityNotFound)
+}
+
+// Update updates NSX-T L2 VPN Tunnel configuration with newly supplied data. When l2VpnConfig has no Version, the
+// version of the current configuration is used
+func (l2Vpn *NsxtL2VpnTunnel) Update(ctx context.Context, l2VpnConfig *types.NsxtL2VpnTunnel) (*NsxtL2VpnTunnel, error) {
+	if l2Vpn.NsxtL2VpnTunnel.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T L2 VPN Tunnel configuration without ID")
+	}
+
+	client := l2Vpn.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointL2VpnTunnel
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, l2Vpn.edgeGatewayId), l2Vpn.NsxtL2VpnTunnel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if l2VpnConfig.Version == nil {
+		l2VpnConfig.Version = l2Vpn.NsxtL2VpnTunnel.Version
+	}
+
+	returnObject := &NsxtL2VpnTunnel{
+		NsxtL2VpnTunnel: &types.NsxtL2VpnTunnel{},
+		client:          client,
+		edgeGatewayId:   l2Vpn.edgeGatewayId,
+	}
+
+	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, l2VpnConfig, returnObject.NsxtL2VpnTunnel, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T L2 VPN Tunnel configuration: %w", err)
+	}
+
+	return returnObject, nil
+}
+
+// Delete allows users to delete NSX-T L2 VPN Tunnel
+func (l2Vpn *NsxtL2VpnTunnel) Delete(ctx context.Context) error {
+	if l2Vpn.NsxtL2VpnTunnel.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T L2 VPN Tunnel configuration without ID")
+	}
+
+	client := l2Vpn.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointL2VpnTunnel
+	apiVersion, err := client.getOpenApiHighestElevatedVersion(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, l2Vpn.edgeGatewayId), l2Vpn.NsxtL2VpnTunnel.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T L2 VPN Tunnel configuration: %w", err)
+	}
+
+	return nil
+}
+
+// GetPeerCode retrieves the peer code of a SERVER L2 VPN Tunnel, which is needed to create the CLIENT tunnel on the
+// remote site
+func (l2Vpn *NsxtL2VpnTunnel) GetPeerCode(ctx context.Context) (string, error) {
+	if l2Vpn.NsxtL2VpnTunnel.SessionMode != types.NsxtL2VpnSessionModeServer {
+		return "", fmt.Errorf("peer code is only available for NSX-T L2 VPN Tunnels in %s session mode",
+			types.NsxtL2VpnSessionModeServer)
+	}
+
+	egw := &NsxtEdgeGateway{EdgeGateway: &types.OpenAPIEdgeGateway{ID: l2Vpn.edgeGatewayId}, client: l2Vpn.client}
+	tunnel, err := egw.GetL2VpnTunnelById(ctx, l2Vpn.NsxtL2VpnTunnel.ID)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving NSX-T L2 VPN Tunnel configuration: %w", err)
+	}
+	if tunnel.NsxtL2VpnTunnel.PeerCode == "" {
+		return "", fmt.Errorf("NSX-T L2 VPN Tunnel '%s' has no peer code yet", tunnel.NsxtL2VpnTunnel.Name)
+	}
+
+	return tunnel.NsxtL2VpnTunnel.PeerCode, nil
+}
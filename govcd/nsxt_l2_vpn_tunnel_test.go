@@ -0,0 +1,91 @@
+//go:build network || nsxt || functional || openapi || ALL
+
+package govcd
+
+import (
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_NsxtL2VpnTunnel(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointL2VpnTunnel)
+
+	org, err := vcd.client.GetOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	nsxtVdc, err := org.GetVDCByName(ctx, vcd.config.VCD.Nsxt.Vdc, false)
+	check.Assert(err, IsNil)
+
+	edge, err := nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	routedNet := createNsxtRoutedNetwork(check, vcd, nsxtVdc, edge.EdgeGateway.ID)
+	openApiEndpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointOrgVdcNetworks + routedNet.OpenApiOrgVdcNetwork.ID
+	AddToCleanupListOpenApi(routedNet.OpenApiOrgVdcNetwork.Name, check.TestName(), openApiEndpoint)
+
+	l2VpnDef := &types.NsxtL2VpnTunnel{
+		Name:                    check.TestName(),
+		Description:             check.TestName() + "-description",
+		SessionMode:             types.NsxtL2VpnSessionModeServer,
+		Enabled:                 true,
+		ConnectorInitiationMode: "INITIATOR",
+		LocalEndpointIp:         edge.EdgeGateway.EdgeGatewayUplinks[0].Subnets.Values[0].PrimaryIP,
+		RemoteEndpointIp:        "192.168.140.1",
+		TunnelInterface:         "192.168.10.1/24",
+		PreSharedKey:            "PSK-Sec",
+		StretchedNetworks: []types.NsxtL2VpnStretchedNetwork{
+			{NetworkRef: types.OpenApiReference{ID: routedNet.OpenApiOrgVdcNetwork.ID}},
+		},
+	}
+
+	// A CLIENT tunnel cannot be created without peer code
+	_, err = edge.CreateL2VpnTunnel(ctx, &types.NsxtL2VpnTunnel{Name: check.TestName(), SessionMode: types.NsxtL2VpnSessionModeClient})
+	check.Assert(err, NotNil)
+
+	createdL2Vpn, err := edge.CreateL2VpnTunnel(ctx, l2VpnDef)
+	check.Assert(err, IsNil)
+	openApiEndpoint = types.OpenApiPathVersion1_0_0 + fmt.Sprintf(types.OpenApiEndpointL2VpnTunnel+createdL2Vpn.NsxtL2VpnTunnel.ID, edge.EdgeGateway.ID)
+	PrependToCleanupListOpenApi(createdL2Vpn.NsxtL2VpnTunnel.Name, check.TestName(), openApiEndpoint)
+
+	check.Assert(createdL2Vpn.NsxtL2VpnTunnel.ID, Not(Equals), "")
+	check.Assert(createdL2Vpn.NsxtL2VpnTunnel.Name, Equals, l2VpnDef.Name)
+	check.Assert(createdL2Vpn.NsxtL2VpnTunnel.SessionMode, Equals, types.NsxtL2VpnSessionModeServer)
+	check.Assert(len(createdL2Vpn.NsxtL2VpnTunnel.StretchedNetworks), Equals, 1)
+	check.Assert(createdL2Vpn.NsxtL2VpnTunnel.StretchedNetworks[0].NetworkRef.ID, Equals, routedNet.OpenApiOrgVdcNetwork.ID)
+
+	peerCode, err := createdL2Vpn.GetPeerCode(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(peerCode, Not(Equals), "")
+
+	// Get by ID and Name
+	byId, err := edge.GetL2VpnTunnelById(ctx, createdL2Vpn.NsxtL2VpnTunnel.ID)
+	check.Assert(err, IsNil)
+	check.Assert(byId.NsxtL2VpnTunnel, DeepEquals, createdL2Vpn.NsxtL2VpnTunnel)
+
+	byName, err := edge.GetL2VpnTunnelByName(ctx, createdL2Vpn.NsxtL2VpnTunnel.Name)
+	check.Assert(err, IsNil)
+	check.Assert(byName.NsxtL2VpnTunnel.ID, Equals, createdL2Vpn.NsxtL2VpnTunnel.ID)
+
+	allL2Vpns, err := edge.GetAllL2VpnTunnels(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(allL2Vpns) > 0, Equals, true)
+
+	// Update
+	updateDef := *createdL2Vpn.NsxtL2VpnTunnel
+	updateDef.Description = check.TestName() + "-updated"
+	updateDef.Enabled = false
+	updatedL2Vpn, err := createdL2Vpn.Update(ctx, &updateDef)
+	check.Assert(err, IsNil)
+	check.Assert(updatedL2Vpn.NsxtL2VpnTunnel.Description, Equals, updateDef.Description)
+	check.Assert(updatedL2Vpn.NsxtL2VpnTunnel.Enabled, Equals, false)
+
+	// Delete
+	err = updatedL2Vpn.Delete(ctx)
+	check.Assert(err, IsNil)
+
+	_, err = edge.GetL2VpnTunnelById(ctx, createdL2Vpn.NsxtL2VpnTunnel.ID)
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
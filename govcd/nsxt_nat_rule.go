@@ -72,7 +72,7 @@ func (egw *NsxtEdgeGateway) GetNatRuleByName(ctx context.Context, name string) (
 	// support any filters - even ID. Therefore one must retrieve all items and look if there is an item with the same ID
 	allNatRules, err := egw.GetAllNatRules(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retriving all NSX-T NAT rules: %s", err)
+		return nil, fmt.Errorf("error retriving all NSX-T NAT rules: %w", err)
 	}
 
 	var allResults []*NsxtNatRule
@@ -100,7 +100,7 @@ func (egw *NsxtEdgeGateway) GetNatRuleById(ctx context.Context, id string) (*Nsx
 	// support any filters - even ID. Therefore one must retrieve all items and look if there is an item with the same ID
 	allNatRules, err := egw.GetAllNatRules(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retriving all NSX-T NAT rules: %s", err)
+		return nil, fmt.Errorf("error retriving all NSX-T NAT rules: %w", err)
 	}
 
 	for _, natRule := range allNatRules {
@@ -112,6 +112,18 @@ func (egw *NsxtEdgeGateway) GetNatRuleById(ctx context.Context, id string) (*Nsx
 	return nil, ErrorEntityNotFound
 }
 
+// GetNatRuleByNameOrId retrieves a NAT rule by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (egw *NsxtEdgeGateway) GetNatRuleByNameOrId(ctx context.Context, identifier string) (*NsxtNatRule, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) { return egw.GetNatRuleByName(ctx, name) }
+	getById := func(id string, refresh bool) (interface{}, error) { return egw.GetNatRuleById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*NsxtNatRule), err
+}
+
 // CreateNatRule creates a NAT rule and returns it.
 //
 // Note. API has a limitation, that it does not return ID for created rule. To work around it this function creates
@@ -136,19 +148,19 @@ func (egw *NsxtEdgeGateway) CreateNatRule(ctx context.Context, natRuleConfig *ty
 	// items, and manually match it based on rule name, etc.
 	task, err := client.OpenApiPostItemAsync(ctx, apiVersion, urlRef, nil, natRuleConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating NSX-T NAT rule: %s", err)
+		return nil, fmt.Errorf("error creating NSX-T NAT rule: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("task failed while creating NSX-T NAT rule: %s", err)
+		return nil, fmt.Errorf("task failed while creating NSX-T NAT rule: %w", err)
 	}
 
 	// queryParameters (API side filtering) are not used because pretty much nothing is accepted as filter (such fields as
 	// name, description, ruleType and even ID are not allowed
 	allNatRules, err := egw.GetAllNatRules(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching all NAT rules: %s", err)
+		return nil, fmt.Errorf("error fetching all NAT rules: %w", err)
 	}
 
 	for index, singleRule := range allNatRules {
@@ -187,7 +199,7 @@ func (nsxtNat *NsxtNatRule) Update(ctx context.Context, natRuleConfig *types.Nsx
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, natRuleConfig, returnObject.NsxtNatRule, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating NSX-T NAT Rule: %s", err)
+		return nil, fmt.Errorf("error updating NSX-T NAT Rule: %w", err)
 	}
 
 	return returnObject, nil
@@ -214,7 +226,7 @@ func (nsxtNat *NsxtNatRule) Delete(ctx context.Context) error {
 	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting NSX-T NAT Rule: %s", err)
+		return fmt.Errorf("error deleting NSX-T NAT Rule: %w", err)
 	}
 
 	return nil
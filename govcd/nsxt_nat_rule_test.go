@@ -321,6 +321,13 @@ func nsxtNatRuleChecks(natRuleDefinition *types.NsxtNatRule, edge *NsxtEdgeGatew
 	check.Assert(natRuleById.NsxtNatRule, DeepEquals, natRuleDefinition)
 	check.Assert(natRuleByName.NsxtNatRule, DeepEquals, natRuleDefinition)
 
+	natRuleByNameOrId, err := edge.GetNatRuleByNameOrId(ctx, createdNatRule.NsxtNatRule.ID)
+	check.Assert(err, IsNil)
+	check.Assert(natRuleByNameOrId.NsxtNatRule, DeepEquals, natRuleDefinition)
+	natRuleByNameOrId, err = edge.GetNatRuleByNameOrId(ctx, createdNatRule.NsxtNatRule.Name)
+	check.Assert(err, IsNil)
+	check.Assert(natRuleByNameOrId.NsxtNatRule, DeepEquals, natRuleDefinition)
+
 	// Try to update value
 	createdNatRule.NsxtNatRule.Name = check.TestName() + "updated"
 	updatedNatRule, err := createdNatRule.Update(ctx, createdNatRule.NsxtNatRule)
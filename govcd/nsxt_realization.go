@@ -0,0 +1,99 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// ErrorRealizationFailed is returned by WaitForRealization when NSX-T reports that the entity could not be realized
+var ErrorRealizationFailed = errors.New("realization failed")
+
+// realizationWaitOptions is the polling used by WaitForRealization. Realization usually completes within seconds
+// of the creation task, so the interval stays short
+var realizationWaitOptions = WaitOptions{
+	InitialInterval: time.Second,
+	MaxInterval:     10 * time.Second,
+}
+
+// GetRealizationStatus refreshes the NSX-T Edge Gateway and returns its realization status, which is one of the
+// types.RealizationStatus* values
+func (egw *NsxtEdgeGateway) GetRealizationStatus(ctx context.Context) (string, error) {
+	err := egw.Refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+	return egw.EdgeGateway.Status, nil
+}
+
+// WaitForRealization waits until NSX-T has realized the Edge Gateway. The creation task of an Edge Gateway can
+// complete while its realization is still pending, in which case operations on its services fail. It returns an
+// error wrapping ErrorRealizationFailed when the realization fails. The wait is bounded by the deadline of ctx
+func (egw *NsxtEdgeGateway) WaitForRealization(ctx context.Context) error {
+	if egw.EdgeGateway == nil {
+		return fmt.Errorf("cannot wait for realization of empty Edge Gateway")
+	}
+	return waitForRealization(ctx, "NSX-T Edge Gateway", egw.EdgeGateway.Name, func() (string, error) {
+		return egw.GetRealizationStatus(ctx)
+	})
+}
+
+// GetRealizationStatus refreshes the Org VDC network and returns its realization status, which is one of the
+// types.RealizationStatus* values
+func (orgVdcNet *OpenApiOrgVdcNetwork) GetRealizationStatus(ctx context.Context) (string, error) {
+	err := orgVdcNet.Refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+	return orgVdcNet.OpenApiOrgVdcNetwork.Status, nil
+}
+
+// WaitForRealization waits until the Org VDC network is realized. The creation task of an NSX-T Org VDC network can
+// complete while its segment is still being realized, in which case attaching VMs or configuring DHCP fails. It
+// returns an error wrapping ErrorRealizationFailed when the realization fails. The wait is bounded by the deadline
+// of ctx
+func (orgVdcNet *OpenApiOrgVdcNetwork) WaitForRealization(ctx context.Context) error {
+	if orgVdcNet.OpenApiOrgVdcNetwork == nil {
+		return fmt.Errorf("cannot wait for realization of empty Org VDC network")
+	}
+	return waitForRealization(ctx, "Org VDC network", orgVdcNet.OpenApiOrgVdcNetwork.Name, func() (string, error) {
+		return orgVdcNet.GetRealizationStatus(ctx)
+	})
+}
+
+// waitForRealization polls getStatus until it reports types.RealizationStatusRealized. Any status other than
+// types.RealizationStatusFailed, such as PENDING, CONFIGURING or UNKNOWN, is considered transient
+func waitForRealization(ctx context.Context, entityType, name string, getStatus func() (string, error)) error {
+	var status string
+	err := WaitForState(ctx, func() (bool, error) {
+		var err error
+		status, err = getStatus()
+		if err != nil {
+			if ctx.Err() != nil {
+				// The request was interrupted by the context, whose error is more relevant
+				return false, ctx.Err()
+			}
+			return false, err
+		}
+		util.Logger.Printf("[TRACE] %s '%s' realization status: %s", entityType, name, status)
+		switch status {
+		case types.RealizationStatusRealized:
+			return true, nil
+		case types.RealizationStatusFailed:
+			return false, fmt.Errorf("%s '%s': %w", entityType, name, ErrorRealizationFailed)
+		}
+		return false, nil
+	}, realizationWaitOptions)
+	if err != nil && !errors.Is(err, ErrorRealizationFailed) && status != "" {
+		return fmt.Errorf("error waiting for realization of %s '%s' (last status %s): %w", entityType, name, status, err)
+	}
+	return err
+}
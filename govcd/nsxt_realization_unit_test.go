@@ -0,0 +1,135 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// newRealizationTestServer returns a client of a mock server where the entity at path reports the given statuses,
+// one per request, repeating the last one
+func newRealizationTestServer(t *testing.T, path, body string, statuses []string) (*Client, func()) {
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		status := statuses[len(statuses)-1]
+		if requests < len(statuses) {
+			status = statuses[requests]
+		}
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, body, status)
+	}))
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"35.0", "36.0"})
+	vcdClient.Client.APIVersion = "36.0"
+	return &vcdClient.Client, server.Close
+}
+
+func Test_OrgVdcNetworkWaitForRealization(t *testing.T) {
+	defaultOptions := realizationWaitOptions
+	realizationWaitOptions = WaitOptions{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	defer func() { realizationWaitOptions = defaultOptions }()
+
+	networkId := "urn:vcloud:network:11111111-1111-1111-1111-111111111111"
+	path := "/cloudapi/1.0.0/orgVdcNetworks/" + networkId
+	body := `{"id":"` + networkId + `","name":"net1","status":"%s"}`
+
+	tests := []struct {
+		name       string
+		statuses   []string
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			name:       "realized",
+			statuses:   []string{types.RealizationStatusPending, types.RealizationStatusConfiguring, types.RealizationStatusRealized},
+			wantStatus: types.RealizationStatusRealized,
+		},
+		{
+			name:       "unknownThenRealized",
+			statuses:   []string{types.RealizationStatusUnknown, types.RealizationStatusRealized},
+			wantStatus: types.RealizationStatusRealized,
+		},
+		{
+			name:       "failed",
+			statuses:   []string{types.RealizationStatusPending, types.RealizationStatusFailed},
+			wantErr:    ErrorRealizationFailed,
+			wantStatus: types.RealizationStatusFailed,
+		},
+		{
+			name:       "timeout",
+			statuses:   []string{types.RealizationStatusPending},
+			wantErr:    context.DeadlineExceeded,
+			wantStatus: types.RealizationStatusPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, closeServer := newRealizationTestServer(t, path, body, tt.statuses)
+			defer closeServer()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			orgVdcNet := &OpenApiOrgVdcNetwork{
+				OpenApiOrgVdcNetwork: &types.OpenApiOrgVdcNetwork{ID: networkId, Name: "net1"},
+				client:               client,
+			}
+			err := orgVdcNet.WaitForRealization(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, expected %v", err, tt.wantErr)
+			}
+			if orgVdcNet.OpenApiOrgVdcNetwork.Status != tt.wantStatus {
+				t.Errorf("got status %s, expected %s", orgVdcNet.OpenApiOrgVdcNetwork.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_NsxtEdgeGatewayGetRealizationStatus(t *testing.T) {
+	edgeId := "urn:vcloud:gateway:11111111-1111-1111-1111-111111111111"
+	path := "/cloudapi/1.0.0/edgeGateways/" + edgeId
+	body := `{"id":"` + edgeId + `","name":"edge1","status":"%s","gatewayBacking":{"gatewayType":"NSXT_BACKED"}}`
+	client, closeServer := newRealizationTestServer(t, path, body,
+		[]string{types.RealizationStatusPending, types.RealizationStatusRealized})
+	defer closeServer()
+
+	ctx := context.Background()
+	egw := &NsxtEdgeGateway{EdgeGateway: &types.OpenAPIEdgeGateway{ID: edgeId, Name: "edge1"}, client: client}
+	status, err := egw.GetRealizationStatus(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if status != types.RealizationStatusPending {
+		t.Errorf("got status %s, expected %s", status, types.RealizationStatusPending)
+	}
+
+	err = egw.WaitForRealization(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error waiting for realization: %s", err)
+	}
+	if egw.EdgeGateway.Status != types.RealizationStatusRealized {
+		t.Errorf("got status %s, expected %s", egw.EdgeGateway.Status, types.RealizationStatusRealized)
+	}
+}
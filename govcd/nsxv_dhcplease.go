@@ -49,7 +49,7 @@ func (egw *EdgeGateway) GetAllNsxvDhcpLeases(ctx context.Context) ([]*types.Edge
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpLeasePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	dhcpLeases := &responseEdgeDhcpLeases{}
@@ -24,7 +24,7 @@ func (egw *EdgeGateway) UpdateDhcpRelay(ctx context.Context, dhcpRelayConfig *ty
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpRelayPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusNoContent or if not an error of type types.NSXError
 	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPut, types.AnyXMLMime,
@@ -46,7 +46,7 @@ func (egw *EdgeGateway) GetDhcpRelay(ctx context.Context) (*types.EdgeDhcpRelay,
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpRelayPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// This query Edge gateway DHCP relay using proxied NSX-V API
@@ -68,7 +68,7 @@ func (egw *EdgeGateway) ResetDhcpRelay(ctx context.Context) error {
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpRelayPath)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Send a DELETE request to DHCP relay configuration endpoint
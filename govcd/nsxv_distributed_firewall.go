@@ -149,7 +149,7 @@ func (dfw *NsxvDistributedFirewall) Disable(ctx context.Context) error {
 				return nil
 			}
 		}
-		return fmt.Errorf("error deleting Distributed firewall: %s", err)
+		return fmt.Errorf("error deleting Distributed firewall: %w", err)
 
 	}
 	if resp != nil && resp.StatusCode != http.StatusNoContent {
@@ -372,7 +372,7 @@ func (dfw *NsxvDistributedFirewall) GetServicesByRegex(ctx context.Context, expr
 	}
 	searchRegex, err := regexp.Compile(expression)
 	if err != nil {
-		return nil, fmt.Errorf("[GetServicesByRegex] error validating regular expression '%s': %s", expression, err)
+		return nil, fmt.Errorf("[GetServicesByRegex] error validating regular expression '%s': %w", expression, err)
 	}
 	var found []types.Application
 	for _, app := range services {
@@ -431,7 +431,7 @@ func (dfw *NsxvDistributedFirewall) GetServiceGroupsByRegex(ctx context.Context,
 	}
 	searchRegex, err := regexp.Compile(expression)
 	if err != nil {
-		return nil, fmt.Errorf("[GetServiceGroupsByRegex] error validating regular expression '%s': %s", expression, err)
+		return nil, fmt.Errorf("[GetServiceGroupsByRegex] error validating regular expression '%s': %w", expression, err)
 	}
 	var found []types.ApplicationGroup
 	for _, appGroup := range serviceGroups {
@@ -48,7 +48,7 @@ func (egw *EdgeGateway) CreateNsxvFirewallRule(ctx context.Context, firewallRule
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateFirewallPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	// The query must be wrapped differently, depending if it mus specify the "aboveRuleId" parameter
@@ -90,7 +90,7 @@ func (egw *EdgeGateway) UpdateNsxvFirewallRule(ctx context.Context, firewallRule
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateFirewallPath + "/" + firewallRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result is either 204 for success, or an error of type types.NSXError
@@ -141,7 +141,7 @@ func (egw *EdgeGateway) GetAllNsxvFirewallRules(ctx context.Context) ([]*types.E
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeFirewallPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	firewallRuleResponse := &responseEdgeFirewallRules{}
@@ -171,7 +171,7 @@ func (egw *EdgeGateway) DeleteNsxvFirewallRuleById(ctx context.Context, id strin
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateFirewallPath + "/" + id)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// check if the rule exists and pass back the error at it may be 'ErrorEntityNotFound'
@@ -23,7 +23,7 @@ func (vdc *Vdc) CreateNsxvIpSet(ctx context.Context, ipSetConfig *types.EdgeIpSe
 
 	vdcId, err := GetUuidFromHref(vdc.Vdc.HREF, true)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get vdc ID from HREF: %s", err)
+		return nil, fmt.Errorf("unable to get vdc ID from HREF: %w", err)
 	}
 
 	// build a path for IP set creation. The endpoint should look like:
@@ -31,7 +31,7 @@ func (vdc *Vdc) CreateNsxvIpSet(ctx context.Context, ipSetConfig *types.EdgeIpSe
 	// trailing UUID is vDC ID
 	httpPath, err := vdc.buildNsxvNetworkServiceEndpointURL(types.NsxvIpSetServicePath + "/" + vdcId)
 	if err != nil {
-		return nil, fmt.Errorf("could not get network services API endpoint for IP set: %s", err)
+		return nil, fmt.Errorf("could not get network services API endpoint for IP set: %w", err)
 	}
 
 	// Success or an error of type types.NSXError is expected
@@ -43,7 +43,7 @@ func (vdc *Vdc) CreateNsxvIpSet(ctx context.Context, ipSetConfig *types.EdgeIpSe
 
 	createdIpSet, err := vdc.GetNsxvIpSetByName(ctx, ipSetConfig.Name)
 	if err != nil {
-		return nil, fmt.Errorf("could not lookup newly created IP set with name %s: %s", ipSetConfig.Name, err)
+		return nil, fmt.Errorf("could not lookup newly created IP set with name %s: %w", ipSetConfig.Name, err)
 	}
 
 	return createdIpSet, nil
@@ -62,13 +62,13 @@ func (vdc *Vdc) UpdateNsxvIpSet(ctx context.Context, ipSetConfig *types.EdgeIpSe
 	// Inject latest Revision for this IP set so that API accepts change
 	currentIpSet, err := vdc.GetNsxvIpSetById(ctx, ipSetConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not fetch current IP set: %s", err)
+		return nil, fmt.Errorf("could not fetch current IP set: %w", err)
 	}
 	ipSetConfig.Revision = currentIpSet.Revision
 
 	httpPath, err := vdc.buildNsxvNetworkServiceEndpointURL(types.NsxvIpSetServicePath + "/" + ipSetConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get network services API endpoint for IP set: %s", err)
+		return nil, fmt.Errorf("could not get network services API endpoint for IP set: %w", err)
 	}
 
 	// Result is either 204 for success, or an error of type types.NSXError
@@ -81,7 +81,7 @@ func (vdc *Vdc) UpdateNsxvIpSet(ctx context.Context, ipSetConfig *types.EdgeIpSe
 
 	updatedIpSet, err := vdc.GetNsxvIpSetById(ctx, ipSetConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not lookup updated IP set with ID %s: %s", ipSetConfig.ID, err)
+		return nil, fmt.Errorf("could not lookup updated IP set with ID %s: %w", ipSetConfig.ID, err)
 	}
 
 	return updatedIpSet, nil
@@ -149,7 +149,7 @@ func (vdc *Vdc) GetNsxvIpSetByNameOrId(ctx context.Context, identifier string) (
 func (vdc *Vdc) GetAllNsxvIpSets(ctx context.Context) ([]*types.EdgeIpSet, error) {
 	vdcId, err := GetUuidFromHref(vdc.Vdc.HREF, true)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get vdc ID from HREF: %s", err)
+		return nil, fmt.Errorf("unable to get vdc ID from HREF: %w", err)
 	}
 
 	// build a path for to read all IP sets in a scope. A scope is defined by vDC ID. The endpoint
@@ -158,7 +158,7 @@ func (vdc *Vdc) GetAllNsxvIpSets(ctx context.Context) ([]*types.EdgeIpSet, error
 	// the trailing UUID is vDC ID
 	httpPath, err := vdc.buildNsxvNetworkServiceEndpointURL(types.NsxvIpSetServicePath + "/scope/" + vdcId)
 	if err != nil {
-		return nil, fmt.Errorf("could not get network services API endpoint for IP set: %s", err)
+		return nil, fmt.Errorf("could not get network services API endpoint for IP set: %w", err)
 	}
 
 	// Anonymous struct to unwrap list of IP sets <list><ipset></ipset><ipset></ipset></list>
@@ -193,7 +193,7 @@ func (vdc *Vdc) DeleteNsxvIpSetById(ctx context.Context, id string) error {
 	// https://192.168.1.109/network/services/ipset/f9daf2da-b4f9-4921-a2f4-d77a943a381c:ipset-9
 	httpPath, err := vdc.buildNsxvNetworkServiceEndpointURL(types.NsxvIpSetServicePath + "/" + id)
 	if err != nil {
-		return fmt.Errorf("could not get network services API endpoint for IP set: %s", err)
+		return fmt.Errorf("could not get network services API endpoint for IP set: %w", err)
 	}
 
 	errString := fmt.Sprintf("unable to delete IP set with ID %s: %%s", id)
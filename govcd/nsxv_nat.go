@@ -40,7 +40,7 @@ func (egw *EdgeGateway) CreateNsxvNatRule(ctx context.Context, natRuleConfig *ty
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateNatPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 	// We expect to get http.StatusCreated or if not an error of type types.NSXError
 	resp, err := egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPost, types.AnyXMLMime,
@@ -74,7 +74,7 @@ func (egw *EdgeGateway) UpdateNsxvNatRule(ctx context.Context, natRuleConfig *ty
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateNatPath + "/" + natRuleConfig.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// Result should be 204, if not we expect an error of type types.NSXError
@@ -96,7 +96,7 @@ func (egw *EdgeGateway) UpdateNsxvNatRule(ctx context.Context, natRuleConfig *ty
 func (egw *EdgeGateway) GetNsxvNatRules(ctx context.Context) ([]*types.EdgeNatRule, error) {
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeNatPath)
 	if err != nil {
-		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	natRuleResponse := &responseEdgeNatRules{}
@@ -143,7 +143,7 @@ func (egw *EdgeGateway) DeleteNsxvNatRuleById(ctx context.Context, id string) er
 
 	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeCreateNatPath + "/" + id)
 	if err != nil {
-		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %w", err)
 	}
 
 	// check if the rule exists and pass back the error at it may be 'ErrorEntityNotFound'
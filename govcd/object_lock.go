@@ -0,0 +1,158 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// KeyedMutex provides one mutual exclusion lock for each key. Locks are created on demand and removed when
+// no longer used, so that the number of keys is unbounded.
+// The zero value is ready to use.
+type KeyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+// keyedMutexEntry is a single lock, together with the number of callers holding or waiting for it
+type keyedMutexEntry struct {
+	semaphore chan struct{}
+	refs      int
+}
+
+// objectLocks is the lock manager shared by all clients in the program. VCD rejects concurrent operations
+// on the same object regardless of the client performing them, therefore locks are process wide.
+var objectLocks KeyedMutex
+
+// Lock acquires the lock for key, waiting for it to be released if needed. It returns a function that must be
+// called to release the lock. An error is returned if ctx is cancelled before the lock is acquired.
+func (km *KeyedMutex) Lock(ctx context.Context, key string) (func(), error) {
+	km.mu.Lock()
+	if km.locks == nil {
+		km.locks = make(map[string]*keyedMutexEntry)
+	}
+	entry, ok := km.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{semaphore: make(chan struct{}, 1)}
+		km.locks[key] = entry
+	}
+	entry.refs++
+	km.mu.Unlock()
+
+	select {
+	case entry.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		km.release(key, entry)
+		return nil, fmt.Errorf("error waiting for lock on '%s': %s", key, ctx.Err())
+	}
+
+	once := sync.Once{}
+	unlock := func() {
+		once.Do(func() {
+			<-entry.semaphore
+			km.release(key, entry)
+		})
+	}
+	return unlock, nil
+}
+
+// release decreases the reference count of a lock entry, removing it when no longer used
+func (km *KeyedMutex) release(key string, entry *keyedMutexEntry) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	entry.refs--
+	if entry.refs == 0 {
+		delete(km.locks, key)
+	}
+}
+
+// LockObject acquires the process wide lock for the object identified by href (an XML API HREF, an OpenAPI
+// URL or an ID), so that callers can serialize their own operations with the ones performed by the SDK when
+// Client.SerializeObjectOperations is enabled. It returns a function that must be called to release the lock, and
+// a context recording that the lock is held: the SDK operations run with this context don't wait for the same lock
+// again. The context must not be shared with other goroutines while the lock is held.
+//
+//	lockedCtx, unlock, err := LockObject(ctx, vapp.VApp.HREF)
+//	if err != nil {
+//		return err
+//	}
+//	defer unlock()
+//	task, err := vapp.PowerOn(lockedCtx)
+//	...
+//	err = task.WaitTaskCompletion(lockedCtx)
+func LockObject(ctx context.Context, href string) (context.Context, func(), error) {
+	key := objectLockKey(href)
+	if key == "" {
+		return nil, nil, fmt.Errorf("cannot determine object identifier from '%s'", href)
+	}
+	if objectLockHeld(ctx, key) {
+		return ctx, func() {}, nil
+	}
+	unlock, err := objectLocks.Lock(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return withObjectLockHeld(ctx, key), unlock, nil
+}
+
+// heldObjectLocksKey is the context key of the set of object locks held by the caller of LockObject
+type heldObjectLocksKey struct{}
+
+// objectLockHeld returns true if the lock of the object identified by key was acquired with LockObject for ctx
+func objectLockHeld(ctx context.Context, key string) bool {
+	held, _ := ctx.Value(heldObjectLocksKey{}).(map[string]bool)
+	return held[key]
+}
+
+// withObjectLockHeld returns a copy of ctx recording that the lock of the object identified by key is held
+func withObjectLockHeld(ctx context.Context, key string) context.Context {
+	previous, _ := ctx.Value(heldObjectLocksKey{}).(map[string]bool)
+	held := make(map[string]bool, len(previous)+1)
+	for heldKey := range previous {
+		held[heldKey] = true
+	}
+	held[key] = true
+	return context.WithValue(ctx, heldObjectLocksKey{}, held)
+}
+
+// objectLockKeyRegex matches the UUID part of an HREF or URN
+var objectLockKeyRegex = regexp.MustCompile(`[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}`)
+
+// objectLockKey returns the identifier of the object targeted by href, which is the first UUID found in it.
+// For example, both '/cloudapi/1.0.0/edgeGateways/urn:vcloud:gateway:{uuid}/nat/rules' and
+// '/api/admin/edgeGateway/{uuid}' are keyed by the Edge Gateway UUID. Returns an empty string if no UUID is found.
+func objectLockKey(href string) string {
+	return strings.ToLower(objectLockKeyRegex.FindString(href))
+}
+
+// lockObjectIfEnabled acquires the lock of the object targeted by href when Client.SerializeObjectOperations is
+// enabled, unless the caller already holds it through LockObject. The returned function must always be called and
+// is a no-op when no lock was acquired.
+func (client *Client) lockObjectIfEnabled(ctx context.Context, href string) (func(), error) {
+	key := objectLockKey(href)
+	if !client.SerializeObjectOperations || key == "" || objectLockHeld(ctx, key) {
+		return func() {}, nil
+	}
+	util.Logger.Printf("[TRACE] acquiring lock for object '%s'", key)
+	return objectLocks.Lock(ctx, key)
+}
+
+// lockObjectForRequestIfEnabled acquires the lock of the object targeted by href for requests that change it
+// (POST, PUT, PATCH and DELETE) when Client.SerializeObjectOperations is enabled. The returned function must always
+// be called and is a no-op when no lock was acquired.
+func (client *Client) lockObjectForRequestIfEnabled(ctx context.Context, method, href string) (func(), error) {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return client.lockObjectIfEnabled(ctx, href)
+	}
+	return func() {}, nil
+}
@@ -0,0 +1,189 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_objectLockKey(t *testing.T) {
+	tests := []struct {
+		href string
+		want string
+	}{
+		{"/cloudapi/1.0.0/edgeGateways/urn:vcloud:gateway:8c1ee5a9-9a30-4b67-b3ec-6a5d1c2b4a11/nat/rules", "8c1ee5a9-9a30-4b67-b3ec-6a5d1c2b4a11"},
+		{"https://vcd.example.com/api/admin/edgeGateway/8C1EE5A9-9A30-4B67-B3EC-6A5D1C2B4A11", "8c1ee5a9-9a30-4b67-b3ec-6a5d1c2b4a11"},
+		{"/api/vApp/vapp-0f1a3c5e-1234-4b67-b3ec-6a5d1c2b4a11/leaseSettingsSection/", "0f1a3c5e-1234-4b67-b3ec-6a5d1c2b4a11"},
+		{"/cloudapi/1.0.0/sessions/current", ""},
+	}
+	for _, tt := range tests {
+		if got := objectLockKey(tt.href); got != tt.want {
+			t.Errorf("objectLockKey(%s) = '%s', want '%s'", tt.href, got, tt.want)
+		}
+	}
+}
+
+func TestKeyedMutex_Lock(t *testing.T) {
+	var km KeyedMutex
+	ctx := context.Background()
+
+	// Operations on the same key never overlap
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := km.Lock(ctx, "key")
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			unlock()
+		}()
+	}
+	wg.Wait()
+	if maxRunning != 1 {
+		t.Errorf("expected at most 1 concurrent holder, got %d", maxRunning)
+	}
+	if len(km.locks) != 0 {
+		t.Errorf("expected all locks to be released, %d left", len(km.locks))
+	}
+
+	// Different keys do not block each other
+	unlockA, err := km.Lock(ctx, "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	unlockB, err := km.Lock(ctx, "b")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	unlockB()
+
+	// Waiting for a busy key can be cancelled
+	cancelledCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = km.Lock(cancelledCtx, "a")
+	if err == nil {
+		t.Errorf("expected error when context expires while waiting for lock")
+	}
+	unlockA()
+	unlockA() // calling unlock twice is harmless
+	if len(km.locks) != 0 {
+		t.Errorf("expected all locks to be released, %d left", len(km.locks))
+	}
+}
+
+func Test_lockObjectForRequest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.MimeTask)
+		w.Header().Set("Location", "https://"+r.Host+"/api/task/1")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="running" name="task"/>`))
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true, WithObjectOperationsSerialized())
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"36.0", "37.0"})
+	vcdClient.Client.APIVersion = "37.0"
+	client := &vcdClient.Client
+
+	objectId := "8c1ee5a9-9a30-4b67-b3ec-6a5d1c2b4a11"
+	lockedCtx, unlock, err := LockObject(context.Background(), objectId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Requests run with the context of the lock holder don't wait for the lock again. The timeout only stops the
+	// test if they do
+	holderCtx, cancelHolder := context.WithTimeout(lockedCtx, 5*time.Second)
+	defer cancelHolder()
+	_, err = client.ExecuteTaskRequest(holderCtx, server.URL+"/api/vApp/vapp-"+objectId+"/action/powerOn",
+		http.MethodPost, "", "error powering on: %s", nil)
+	if err != nil {
+		t.Errorf("unexpected error for XML POST request within LockObject: %s", err)
+	}
+	holderEndpoint, err := client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways,
+		"urn:vcloud:gateway:"+objectId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = client.OpenApiPutItemAsync(holderCtx, "37.0", holderEndpoint, nil, &types.OpenAPIEdgeGateway{}, nil)
+	if err != nil {
+		t.Errorf("unexpected error for OpenAPI asynchronous PUT request within LockObject: %s", err)
+	}
+	nestedCtx, nestedUnlock, err := LockObject(lockedCtx, "urn:vcloud:vapp:"+objectId)
+	if err != nil {
+		t.Fatalf("unexpected error locking the object again: %s", err)
+	}
+	nestedUnlock()
+	if !objectLockHeld(nestedCtx, objectId) {
+		t.Errorf("expected the object lock to be recorded in the context")
+	}
+
+	// Requests changing the locked object wait for the lock
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.ExecuteTaskRequest(ctx, server.URL+"/api/vApp/vapp-"+objectId+"/action/powerOn",
+		http.MethodPost, "", "error powering on: %s", nil)
+	if err == nil || !strings.Contains(err.Error(), "error waiting for lock") {
+		t.Errorf("expected XML POST request to wait for the lock of the object, got %v", err)
+	}
+	endpoint, err := client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointEdgeGateways,
+		"urn:vcloud:gateway:"+objectId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = client.OpenApiPutItemAsync(ctx, "37.0", endpoint, nil, &types.OpenAPIEdgeGateway{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "error waiting for lock") {
+		t.Errorf("expected OpenAPI asynchronous PUT request to wait for the lock of the object, got %v", err)
+	}
+
+	// Reading the object does not need the lock
+	_, err = client.ExecuteRequest(context.Background(), server.URL+"/api/vApp/vapp-"+objectId, http.MethodGet, "",
+		"error getting vApp: %s", nil, &types.Task{})
+	if err != nil {
+		t.Errorf("unexpected error for GET request: %s", err)
+	}
+
+	// Once released, the lock is only held while the request is sent
+	unlock()
+	for i := 0; i < 2; i++ {
+		_, err = client.OpenApiPostItemAsync(context.Background(), "37.0", endpoint, nil, &types.OpenAPIEdgeGateway{})
+		if err != nil {
+			t.Errorf("unexpected error for OpenAPI asynchronous POST request: %s", err)
+		}
+	}
+	if len(objectLocks.locks) != 0 {
+		t.Errorf("expected all locks to be released, %d left", len(objectLocks.locks))
+	}
+}
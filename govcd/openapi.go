@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -62,7 +63,7 @@ func (client *Client) OpenApiBuildEndpoint(endpoint ...string) (*url.URL, error)
 	endpointString := client.VCDHREF.Scheme + "://" + client.VCDHREF.Host + "/cloudapi/" + strings.Join(endpoint, "")
 	urlRef, err := url.ParseRequestURI(endpointString)
 	if err != nil {
-		return nil, fmt.Errorf("error formatting OpenAPI endpoint: %s", err)
+		return nil, fmt.Errorf("error formatting OpenAPI endpoint: %w", err)
 	}
 	return urlRef, nil
 }
@@ -93,7 +94,7 @@ func (client *Client) OpenApiGetAllItems(ctx context.Context, apiVersion string,
 	// until it crawls all results
 	responses, err := client.openApiGetAllPages(ctx, apiVersion, urlRefCopy, newQueryParams, outType, nil, additionalHeader)
 	if err != nil {
-		return fmt.Errorf("error getting all pages for endpoint %s: %s", urlRefCopy.String(), err)
+		return fmt.Errorf("error getting all pages for endpoint %s: %w", urlRefCopy.String(), err)
 	}
 
 	// Create a slice of raw JSON messages in text so that they can be unmarshalled to specified `outType` after multiple
@@ -109,7 +110,12 @@ func (client *Client) OpenApiGetAllItems(ctx context.Context, apiVersion string,
 
 	// Unmarshal all accumulated responses into `outType`
 	if err = json.Unmarshal([]byte(allResponses), &outType); err != nil {
-		return fmt.Errorf("error decoding values into type: %s", err)
+		return fmt.Errorf("error decoding values into type: %w", err)
+	}
+
+	// Client-side sorting is only applied when the caller did not ask for server-side sorting
+	if client.SortResultsBy != "" && newQueryParams.Get("sortAsc") == "" && newQueryParams.Get("sortDesc") == "" {
+		sortOpenApiResults(outType, client.SortResultsBy)
 	}
 
 	return nil
@@ -143,15 +149,19 @@ func (client *Client) OpenApiGetItemAndHeaders(ctx context.Context, apiVersion s
 	req := client.newOpenApiRequest(ctx, apiVersion, params, http.MethodGet, urlRefCopy, nil, additionalHeader)
 	resp, err := client.Http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error performing GET request to %s: %s", urlRefCopy.String(), err)
+		return nil, fmt.Errorf("error performing GET request to %s: %w", urlRefCopy.String(), err)
 	}
 
 	// Bypassing the regular path using function checkRespWithErrType and returning parsed error directly
 	// HTTP 403: Forbidden - is returned if the user is not authorized or the entity does not exist.
 	if resp.StatusCode == http.StatusForbidden {
-		err := ParseErr(types.BodyTypeJSON, resp, &types.OpenApiError{})
+		collectResponseHeaders(resp)
+		apiError := newResponseAPIError(resp, ParseErr(types.BodyTypeJSON, resp, &types.OpenApiError{}))
 		closeErr := resp.Body.Close()
-		return nil, fmt.Errorf("%s: %s [body close error: %s]", ErrorEntityNotFound, err, closeErr)
+		return nil, &notFoundAPIError{
+			message:  fmt.Sprintf("%s: %s [body close error: %s]", ErrorEntityNotFound, apiError, closeErr),
+			apiError: apiError,
+		}
 	}
 
 	// resp is ignored below because it is the same as above
@@ -159,16 +169,16 @@ func (client *Client) OpenApiGetItemAndHeaders(ctx context.Context, apiVersion s
 
 	// Any other error occurred
 	if err != nil {
-		return nil, fmt.Errorf("error in HTTP GET request: %s", err)
+		return nil, fmt.Errorf("error in HTTP GET request: %w", err)
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return nil, fmt.Errorf("error decoding JSON response after GET: %s", err)
+		return nil, fmt.Errorf("error decoding JSON response after GET: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing response body: %s", err)
+		return nil, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return resp.Header, nil
@@ -192,6 +202,12 @@ func (client *Client) OpenApiPostItemSync(ctx context.Context, apiVersion string
 		return fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
+	unlock, err := client.lockObjectIfEnabled(ctx, urlRefCopy.Path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	resp, err := client.openApiPerformPostPut(ctx, http.MethodPost, apiVersion, urlRefCopy, params, payload, nil)
 	if err != nil {
 		return err
@@ -204,12 +220,12 @@ func (client *Client) OpenApiPostItemSync(ctx context.Context, apiVersion string
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return fmt.Errorf("error decoding JSON response after POST: %s", err)
+		return fmt.Errorf("error decoding JSON response after POST: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return nil
@@ -242,7 +258,13 @@ func (client *Client) OpenApiPostItemAsyncWithHeaders(ctx context.Context, apiVe
 		return Task{}, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
+	// The lock is only held while the request is sent, as the task is not tracked here
+	unlock, err := client.lockObjectIfEnabled(ctx, urlRefCopy.Path)
+	if err != nil {
+		return Task{}, err
+	}
 	resp, err := client.openApiPerformPostPut(ctx, http.MethodPost, apiVersion, urlRefCopy, params, payload, additionalHeader)
+	unlock()
 	if err != nil {
 		return Task{}, err
 	}
@@ -253,7 +275,7 @@ func (client *Client) OpenApiPostItemAsyncWithHeaders(ctx context.Context, apiVe
 
 	err = resp.Body.Close()
 	if err != nil {
-		return Task{}, fmt.Errorf("error closing response body: %s", err)
+		return Task{}, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// Asynchronous case returns "Location" header pointing to XML task
@@ -289,6 +311,12 @@ func (client *Client) OpenApiPostItemAndGetHeaders(ctx context.Context, apiVersi
 		return nil, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
+	unlock, err := client.lockObjectIfEnabled(ctx, urlRefCopy.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	resp, err := client.openApiPerformPostPut(ctx, http.MethodPost, apiVersion, urlRefCopy, params, payload, additionalHeader)
 	if err != nil {
 		return nil, err
@@ -305,7 +333,7 @@ func (client *Client) OpenApiPostItemAndGetHeaders(ctx context.Context, apiVersi
 		task.Task.HREF = taskUrl
 		err = task.WaitTaskCompletion(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error waiting completion of task (%s): %s", taskUrl, err)
+			return nil, fmt.Errorf("error waiting completion of task (%s): %w", taskUrl, err)
 		}
 
 		// Here we have to find the resource once more to return it populated.
@@ -314,20 +342,20 @@ func (client *Client) OpenApiPostItemAndGetHeaders(ctx context.Context, apiVersi
 		newObjectUrl := urlParseRequestURI(urlRefCopy.String() + task.Task.Owner.ID)
 		err = client.OpenApiGetItem(ctx, apiVersion, newObjectUrl, nil, outType, additionalHeader)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving item after creation: %s", err)
+			return nil, fmt.Errorf("error retrieving item after creation: %w", err)
 		}
 
 		// Synchronous task - new item body is returned in response of HTTP POST request
 	case http.StatusCreated, http.StatusOK:
 		util.Logger.Printf("[TRACE] Synchronous task detected (HTTP Status %d), marshalling outType '%s'", resp.StatusCode, reflect.TypeOf(outType))
 		if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-			return nil, fmt.Errorf("error decoding JSON response after POST: %s", err)
+			return nil, fmt.Errorf("error decoding JSON response after POST: %w", err)
 		}
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing response body: %s", err)
+		return nil, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return resp.Header, nil
@@ -351,6 +379,12 @@ func (client *Client) OpenApiPutItemSync(ctx context.Context, apiVersion string,
 		return fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
+	unlock, err := client.lockObjectIfEnabled(ctx, urlRefCopy.Path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	resp, err := client.openApiPerformPostPut(ctx, http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
 	if err != nil {
 		return err
@@ -362,12 +396,12 @@ func (client *Client) OpenApiPutItemSync(ctx context.Context, apiVersion string,
 	}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-		return fmt.Errorf("error decoding JSON response after PUT: %s", err)
+		return fmt.Errorf("error decoding JSON response after PUT: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	return nil
@@ -389,7 +423,14 @@ func (client *Client) OpenApiPutItemAsync(ctx context.Context, apiVersion string
 	if !client.OpenApiIsSupported(ctx) {
 		return Task{}, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
+
+	// The lock is only held while the request is sent, as the task is not tracked here
+	unlock, err := client.lockObjectIfEnabled(ctx, urlRefCopy.Path)
+	if err != nil {
+		return Task{}, err
+	}
 	resp, err := client.openApiPerformPostPut(ctx, http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
+	unlock()
 	if err != nil {
 		return Task{}, err
 	}
@@ -400,7 +441,7 @@ func (client *Client) OpenApiPutItemAsync(ctx context.Context, apiVersion string
 
 	err = resp.Body.Close()
 	if err != nil {
-		return Task{}, fmt.Errorf("error closing response body: %s", err)
+		return Task{}, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// Asynchronous case returns "Location" header pointing to XML task
@@ -435,6 +476,12 @@ func (client *Client) OpenApiPutItemAndGetHeaders(ctx context.Context, apiVersio
 	if !client.OpenApiIsSupported(ctx) {
 		return nil, fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
+
+	unlock, err := client.lockObjectIfEnabled(ctx, urlRefCopy.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 	resp, err := client.openApiPerformPostPut(ctx, http.MethodPut, apiVersion, urlRefCopy, params, payload, additionalHeader)
 	if err != nil {
 		return nil, err
@@ -451,26 +498,26 @@ func (client *Client) OpenApiPutItemAndGetHeaders(ctx context.Context, apiVersio
 		task.Task.HREF = taskUrl
 		err = task.WaitTaskCompletion(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error waiting completion of task (%s): %s", taskUrl, err)
+			return nil, fmt.Errorf("error waiting completion of task (%s): %w", taskUrl, err)
 		}
 
 		// Here we have to find the resource once more to return it populated. Provided params ir ignored for retrieval.
 		err = client.OpenApiGetItem(ctx, apiVersion, urlRefCopy, nil, outType, additionalHeader)
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving item after updating: %s", err)
+			return nil, fmt.Errorf("error retrieving item after updating: %w", err)
 		}
 
 		// Synchronous task - new item body is returned in response of HTTP PUT request
 	case http.StatusOK:
 		util.Logger.Printf("[TRACE] Synchronous task detected, marshalling outType '%s'", reflect.TypeOf(outType))
 		if err = decodeBody(types.BodyTypeJSON, resp, outType); err != nil {
-			return nil, fmt.Errorf("error decoding JSON response after PUT: %s", err)
+			return nil, fmt.Errorf("error decoding JSON response after PUT: %w", err)
 		}
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing HTTP PUT response body: %s", err)
+		return nil, fmt.Errorf("error closing HTTP PUT response body: %w", err)
 	}
 
 	return resp.Header, nil
@@ -489,6 +536,12 @@ func (client *Client) OpenApiDeleteItem(ctx context.Context, apiVersion string,
 		return fmt.Errorf("OpenAPI is not supported on this VCD version")
 	}
 
+	unlock, err := client.lockObjectIfEnabled(ctx, urlRefCopy.Path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Perform request
 	req := client.newOpenApiRequest(ctx, apiVersion, params, http.MethodDelete, urlRefCopy, nil, additionalHeader)
 
@@ -500,12 +553,12 @@ func (client *Client) OpenApiDeleteItem(ctx context.Context, apiVersion string,
 	// resp is ignored below because it would be the same as above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
 	if err != nil {
-		return fmt.Errorf("error in HTTP DELETE request: %s", err)
+		return fmt.Errorf("error in HTTP DELETE request: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error closing response body: %s", err)
+		return fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// OpenAPI may work synchronously or asynchronously. When working asynchronously - it will return HTTP 202 and
@@ -517,7 +570,7 @@ func (client *Client) OpenApiDeleteItem(ctx context.Context, apiVersion string,
 		task.Task.HREF = taskUrl
 		err = task.WaitTaskCompletion(ctx)
 		if err != nil {
-			return fmt.Errorf("error waiting completion of task (%s): %s", taskUrl, err)
+			return fmt.Errorf("error waiting completion of task (%s): %w", taskUrl, err)
 		}
 	}
 
@@ -526,18 +579,32 @@ func (client *Client) OpenApiDeleteItem(ctx context.Context, apiVersion string,
 
 // openApiPerformPostPut is a shared function for all public PUT and POST function parts - OpenApiPostItemSync,
 // OpenApiPostItemAsync, OpenApiPostItem, OpenApiPutItemSync, OpenApiPutItemAsync, OpenApiPutItem
+// A payload of type *OpenApiStreamBody is streamed instead of being marshalled
 func (client *Client) openApiPerformPostPut(ctx context.Context, httpMethod string, apiVersion string, urlRef *url.URL, params url.Values, payload interface{}, additionalHeader map[string]string) (*http.Response, error) {
-	// Marshal payload if we have one
-	body := new(bytes.Buffer)
-	if payload != nil {
-		marshaledJson, err := json.MarshalIndent(payload, "", "  ")
+	var req *http.Request
+	if streamBody, ok := payload.(*OpenApiStreamBody); ok {
+		var err error
+		req, err = client.newOpenApiStreamRequest(ctx, apiVersion, params, httpMethod, urlRef, streamBody, additionalHeader)
 		if err != nil {
-			return nil, fmt.Errorf("error marshalling JSON data for %s request %s", httpMethod, err)
+			return nil, err
 		}
-		body = bytes.NewBuffer(marshaledJson)
+	} else {
+		// Marshal payload if we have one
+		body := new(bytes.Buffer)
+		if payload != nil {
+			marshaledJson, err := json.MarshalIndent(payload, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("error marshalling JSON data for %s request %w", httpMethod, err)
+			}
+			err = client.checkRequestBodySize(int64(len(marshaledJson)))
+			if err != nil {
+				return nil, err
+			}
+			body = bytes.NewBuffer(marshaledJson)
+		}
+		req = client.newOpenApiRequest(ctx, apiVersion, params, httpMethod, urlRef, body, additionalHeader)
 	}
 
-	req := client.newOpenApiRequest(ctx, apiVersion, params, httpMethod, urlRef, body, additionalHeader)
 	resp, err := client.Http.Do(req)
 	if err != nil {
 		return nil, err
@@ -546,7 +613,7 @@ func (client *Client) openApiPerformPostPut(ctx context.Context, httpMethod stri
 	// resp is ignored below because it is the same the one above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
 	if err != nil {
-		return nil, fmt.Errorf("error in HTTP %s request: %s", httpMethod, err)
+		return nil, fmt.Errorf("error in HTTP %s request: %w", httpMethod, err)
 	}
 	return resp, nil
 }
@@ -586,19 +653,19 @@ func (client *Client) openApiGetAllPages(ctx context.Context, apiVersion string,
 	// resp is ignored below because it is the same as above
 	_, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
 	if err != nil {
-		return nil, fmt.Errorf("error in HTTP GET request: %s", err)
+		return nil, fmt.Errorf("error in HTTP GET request: %w", err)
 	}
 
 	// Pages will unwrap pagination and keep a slice of raw json message to marshal to specific types
 	pages := &types.OpenApiPages{}
 
 	if err = decodeBody(types.BodyTypeJSON, resp, pages); err != nil {
-		return nil, fmt.Errorf("error decoding JSON page response: %s", err)
+		return nil, fmt.Errorf("error decoding JSON page response: %w", err)
 	}
 
 	err = resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing response body: %s", err)
+		return nil, fmt.Errorf("error closing response body: %w", err)
 	}
 
 	// Accumulate all responses in a single page as JSON text using json.RawMessage
@@ -606,20 +673,20 @@ func (client *Client) openApiGetAllPages(ctx context.Context, apiVersion string,
 	// singleQueryResponses := &json.RawMessage{}
 	var singleQueryResponses []json.RawMessage
 	if err = json.Unmarshal(pages.Values, &singleQueryResponses); err != nil {
-		return nil, fmt.Errorf("error decoding values into accumulation type: %s", err)
+		return nil, fmt.Errorf("error decoding values into accumulation type: %w", err)
 	}
 	responses = append(responses, singleQueryResponses...)
 
 	// Check if there is still 'nextPage' linked and continue accumulating responses if so
 	nextPageUrlRef, err := findRelLink("nextPage", resp.Header)
 	if err != nil && !IsNotFound(err) {
-		return nil, fmt.Errorf("error looking for 'nextPage' in 'Link' header: %s", err)
+		return nil, fmt.Errorf("error looking for 'nextPage' in 'Link' header: %w", err)
 	}
 
 	if nextPageUrlRef != nil {
 		responses, err = client.openApiGetAllPages(ctx, apiVersion, nextPageUrlRef, url.Values{}, outType, responses, additionalHeader)
 		if err != nil {
-			return nil, fmt.Errorf("got error on page %d: %s", pages.Page, err)
+			return nil, fmt.Errorf("got error on page %d: %w", pages.Page, err)
 		}
 	}
 
@@ -639,7 +706,7 @@ func (client *Client) openApiGetAllPages(ctx context.Context, apiVersion string,
 			urlQueryString := queryParams.Encode()
 			urlQuery, err := url.ParseQuery(urlQueryString)
 			if err != nil {
-				return nil, fmt.Errorf("error cloning queryParams: %s", err)
+				return nil, fmt.Errorf("error cloning queryParams: %w", err)
 			}
 
 			// Increase page query by one to fetch "next" page
@@ -647,7 +714,7 @@ func (client *Client) openApiGetAllPages(ctx context.Context, apiVersion string,
 
 			responses, err = client.openApiGetAllPages(ctx, apiVersion, urlRefCopy, urlQuery, outType, responses, additionalHeader)
 			if err != nil {
-				return nil, fmt.Errorf("got error on page %d: %s", pages.Page, err)
+				return nil, fmt.Errorf("got error on page %d: %w", pages.Page, err)
 			}
 		}
 
@@ -681,7 +748,23 @@ func (client *Client) newOpenApiRequest(ctx context.Context, apiVersion string,
 	if err != nil {
 		util.Logger.Printf("[DEBUG - newOpenApiRequest] error getting new request: %s", err)
 	}
+	client.setOpenApiRequestHeaders(req, apiVersion, additionalHeader)
 
+	// Avoids passing data if the logging of requests is disabled
+	if util.LogHttpRequest {
+		payload := ""
+		if req.ContentLength > 0 {
+			payload = string(readBody)
+		}
+		util.ProcessRequestOutput(util.FuncNameCallStack(), method, reqUrlCopy.String(), payload, req)
+		debugShowRequest(req, payload)
+	}
+
+	return req
+}
+
+// setOpenApiRequestHeaders sets the authentication, content type and custom headers of an OpenAPI request
+func (client *Client) setOpenApiRequestHeaders(req *http.Request, apiVersion string, additionalHeader map[string]string) {
 	if client.VCDAuthHeader != "" && client.VCDToken != "" {
 		// Add the authorization header
 		req.Header.Add(client.VCDAuthHeader, client.VCDToken)
@@ -704,23 +787,12 @@ func (client *Client) newOpenApiRequest(ctx context.Context, apiVersion string,
 	for k, v := range additionalHeader {
 		req.Header.Add(k, v)
 	}
+	setContextHeaders(req)
 
 	// Inject JSON mime type
 	req.Header.Add("Content-Type", types.JSONMime)
 
 	setHttpUserAgent(client.UserAgent, req)
-
-	// Avoids passing data if the logging of requests is disabled
-	if util.LogHttpRequest {
-		payload := ""
-		if req.ContentLength > 0 {
-			payload = string(readBody)
-		}
-		util.ProcessRequestOutput(util.FuncNameCallStack(), method, reqUrlCopy.String(), payload, req)
-		debugShowRequest(req, payload)
-	}
-
-	return req
 }
 
 // findRelLink looks for link to "nextPage" in "Link" header. It will return when first occurrence is found.
@@ -796,6 +868,74 @@ func queryParameterFilterAnd(filter string, parameters url.Values) url.Values {
 	return newParameters
 }
 
+// QueryParameterSortAsc returns a copy of parameters with 'sortAsc' set to field, so that an OpenAPI endpoint
+// returns results in a stable order (e.g. QueryParameterSortAsc("name", nil))
+func QueryParameterSortAsc(field string, parameters url.Values) url.Values {
+	newParameters := copyOrNewUrlValues(parameters)
+	newParameters.Del("sortDesc")
+	newParameters.Set("sortAsc", field)
+	return newParameters
+}
+
+// QueryParameterSortDesc returns a copy of parameters with 'sortDesc' set to field
+func QueryParameterSortDesc(field string, parameters url.Values) url.Values {
+	newParameters := copyOrNewUrlValues(parameters)
+	newParameters.Del("sortAsc")
+	newParameters.Set("sortDesc", field)
+	return newParameters
+}
+
+// sortOpenApiResults sorts in place a pointer to a slice of structures (or pointers to structures) using the
+// field matching sortBy (case insensitive). The slice is left untouched if it has no such string field.
+func sortOpenApiResults(outType interface{}, sortBy string) {
+	sliceValue := reflect.ValueOf(outType)
+	for sliceValue.Kind() == reflect.Ptr || sliceValue.Kind() == reflect.Interface {
+		if sliceValue.IsNil() {
+			return
+		}
+		sliceValue = sliceValue.Elem()
+	}
+	if sliceValue.Kind() != reflect.Slice || sliceValue.Len() < 2 {
+		return
+	}
+
+	keys := make([]string, sliceValue.Len())
+	for index := 0; index < sliceValue.Len(); index++ {
+		element := sliceValue.Index(index)
+		for element.Kind() == reflect.Ptr || element.Kind() == reflect.Interface {
+			if element.IsNil() {
+				break
+			}
+			element = element.Elem()
+		}
+		if element.Kind() != reflect.Struct {
+			return
+		}
+		field := element.FieldByNameFunc(func(name string) bool { return strings.EqualFold(name, sortBy) })
+		if !field.IsValid() || field.Kind() != reflect.String {
+			return
+		}
+		keys[index] = field.String()
+	}
+
+	// Keys and slice elements are swapped together, so that they stay aligned during sorting
+	swap := reflect.Swapper(sliceValue.Interface())
+	sort.Stable(&sortableByKeys{keys: keys, swap: swap})
+}
+
+// sortableByKeys implements sort.Interface for a slice whose elements are compared using precomputed keys
+type sortableByKeys struct {
+	keys []string
+	swap func(i, j int)
+}
+
+func (s *sortableByKeys) Len() int           { return len(s.keys) }
+func (s *sortableByKeys) Less(i, j int) bool { return s.keys[i] < s.keys[j] }
+func (s *sortableByKeys) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.swap(i, j)
+}
+
 // defaultPageSize allows to set 'pageSize' query parameter to defaultPageSize if one is not already specified in
 // url.Values while preserving all other supplied url.Values
 func defaultPageSize(queryParams url.Values, defaultPageSize string) url.Values {
@@ -35,6 +35,9 @@ var endpointMinApiVersions = map[string]string{
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointVdcComputePolicies:         "32.0",
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointVdcAssignedComputePolicies: "33.0",
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointSessionCurrent:             "34.0",
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointTokens:                     "36.1", // VCD 10.3.1+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointServiceAccounts:            "37.0", // VCD 10.4+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointServiceAccountGrant:        "37.0", // VCD 10.4+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeClusters:               "34.0", // VCD 10.1+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointQosProfiles:                "36.2", // VCD 10.3.2+ (NSX-T only)
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGatewayQos:             "36.2", // VCD 10.3.2+ (NSX-T only)
@@ -53,6 +56,7 @@ var endpointMinApiVersions = map[string]string{
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointIpSecVpnTunnel:                     "34.0", // VCD 10.1+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointIpSecVpnTunnelConnectionProperties: "34.0", // VCD 10.1+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointIpSecVpnTunnelStatus:               "34.0", // VCD 10.1+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointL2VpnTunnel:                        "37.0", // VCD 10.4+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointVdcGroups:                          "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointVdcGroupsCandidateVdcs:             "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointVdcGroupsDfwPolicies:               "35.0", // VCD 10.2+
@@ -65,6 +69,8 @@ var endpointMinApiVersions = map[string]string{
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRdeEntities:                        "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRdeEntitiesTypes:                   "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRdeEntitiesResolve:                 "35.0", // VCD 10.2+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEntityMetadata:                     "37.0", // VCD 10.4+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointFeatureFlags:                       "34.0", // VCD 10.1+
 
 	// NSX-T ALB (Advanced/AVI Load Balancer) support was introduced in 10.2
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbController:                    "35.0", // VCD 10.2+
@@ -77,6 +83,9 @@ var endpointMinApiVersions = map[string]string{
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPools:                         "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPoolSummaries:                 "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServices:               "35.0", // VCD 10.2+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVsHttpRequestRules:            "38.0", // VCD 10.5+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVsHttpResponseRules:           "38.0", // VCD 10.5+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVsHttpSecurityRules:           "38.0", // VCD 10.5+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServiceSummaries:       "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointSSLCertificateLibrary:            "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointSSLCertificateLibraryOld:         "35.0", // VCD 10.2+ and deprecated from 10.3
@@ -86,6 +95,9 @@ var endpointMinApiVersions = map[string]string{
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpNeighbor:          "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfigPrefixLists: "35.0", // VCD 10.2+
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfig:            "35.0", // VCD 10.2+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeSlaacProfile:         "37.0", // VCD 10.4+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeDhcpForwarder:        "37.1", // VCD 10.4.1+
+	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeStaticRoutes:         "37.0", // VCD 10.4+
 
 	types.OpenApiPathVersion2_0_0 + types.OpenApiEndpointVdcAssignedComputePolicies: "35.0",
 	types.OpenApiPathVersion2_0_0 + types.OpenApiEndpointVdcComputePolicies:         "35.0",
@@ -129,6 +141,7 @@ var endpointElevatedApiVersions = map[string][]string{
 		//"35.0", // Basic minimum required version
 		"37.0", // Deprecates LicenseType in favor of SupportedFeatureSet. Adds IPv6 service network definition support
 		"37.1", // Adds support for Transparent Mode
+		"38.0", // Adds support for Analytics and WAF toggles
 	},
 	types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointVdcNetworkProfile: {
 		//"36.0", // Introduced support
@@ -148,6 +161,7 @@ var endpointElevatedApiVersions = map[string][]string{
 // specified OpenAPI endpoint and returns either an error or the Api version to use for calling that endpoint. This Api
 // version can then be supplied to low level OpenAPI client functions.
 // If the system default API version is higher than endpoint introduction version - default system one is used.
+// When the VCD version is not sufficient, the error is a FeatureNotAvailableError.
 func (client *Client) checkOpenApiEndpointCompatibility(ctx context.Context, endpoint string) (string, error) {
 	minimumApiVersion, ok := endpointMinApiVersions[endpoint]
 	if !ok {
@@ -157,10 +171,13 @@ func (client *Client) checkOpenApiEndpointCompatibility(ctx context.Context, end
 	if client.APIVCDMaxVersionIs(ctx, "< "+minimumApiVersion) {
 		maxSupportedVersion, err := client.MaxSupportedVersion()
 		if err != nil {
-			return "", fmt.Errorf("error reading maximum supported API version: %s", err)
+			return "", fmt.Errorf("error reading maximum supported API version: %w", err)
+		}
+		return "", &FeatureNotAvailableError{
+			Feature: fmt.Sprintf("endpoint '%s'", endpoint),
+			Reason: fmt.Sprintf("it requires API version to support at least '%s'. Maximum supported version in this instance: '%s'",
+				minimumApiVersion, maxSupportedVersion),
 		}
-		return "", fmt.Errorf("endpoint '%s' requires API version to support at least '%s'. Maximum supported version in this instance: '%s'",
-			endpoint, minimumApiVersion, maxSupportedVersion)
 	}
 
 	// If default API version is higher than minimum required API version for endpoint - use the system default one.
@@ -184,7 +201,7 @@ func (client *Client) getOpenApiHighestElevatedVersion(ctx context.Context, endp
 	// At first, get the minimum API version and check if it can be supported
 	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
 	if err != nil {
-		return "", fmt.Errorf("error getting minimum required API version: %s", err)
+		return "", fmt.Errorf("error getting minimum required API version: %w", err)
 	}
 
 	// If no elevated versions are defined - return minimumApiVersion
@@ -204,7 +221,7 @@ func (client *Client) getOpenApiHighestElevatedVersion(ctx context.Context, endp
 	for i, raw := range versionsRaw {
 		v, err := version.NewVersion(raw)
 		if err != nil {
-			return "", fmt.Errorf("error evaluating version %s: %s", raw, err)
+			return "", fmt.Errorf("error evaluating version %s: %w", raw, err)
 		}
 		versions[i] = v
 	}
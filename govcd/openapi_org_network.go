@@ -36,7 +36,7 @@ func (org *Org) GetOpenApiOrgVdcNetworkByNameAndOwnerId(ctx context.Context, nam
 
 	allEdges, err := getAllOpenApiOrgVdcNetworks(ctx, org.client, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s' in Owner '%s': %s", name, ownerId, err)
+		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s' in Owner '%s': %w", name, ownerId, err)
 	}
 
 	return returnSingleOpenApiOrgVdcNetwork(name, allEdges)
@@ -72,7 +72,7 @@ func (vdc *Vdc) GetOpenApiOrgVdcNetworkByName(ctx context.Context, name string)
 
 	allEdges, err := vdc.GetAllOpenApiOrgVdcNetworks(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %w", name, err)
 	}
 
 	return returnSingleOpenApiOrgVdcNetwork(name, allEdges)
@@ -85,12 +85,42 @@ func (vdcGroup *VdcGroup) GetOpenApiOrgVdcNetworkByName(ctx context.Context, nam
 
 	allEdges, err := vdcGroup.GetAllOpenApiOrgVdcNetworks(ctx, queryParameters)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %w", name, err)
 	}
 
 	return returnSingleOpenApiOrgVdcNetwork(name, allEdges)
 }
 
+// GetOpenApiOrgVdcNetworkByNameOrId retrieves an Org VDC Network by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vdc *Vdc) GetOpenApiOrgVdcNetworkByNameOrId(ctx context.Context, identifier string) (*OpenApiOrgVdcNetwork, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return vdc.GetOpenApiOrgVdcNetworkByName(ctx, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) { return vdc.GetOpenApiOrgVdcNetworkById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*OpenApiOrgVdcNetwork), err
+}
+
+// GetOpenApiOrgVdcNetworkByNameOrId retrieves an Org VDC Network by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (vdcGroup *VdcGroup) GetOpenApiOrgVdcNetworkByNameOrId(ctx context.Context, identifier string) (*OpenApiOrgVdcNetwork, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return vdcGroup.GetOpenApiOrgVdcNetworkByName(ctx, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) {
+		return vdcGroup.GetOpenApiOrgVdcNetworkById(ctx, id)
+	}
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*OpenApiOrgVdcNetwork), err
+}
+
 // GetAllOpenApiOrgVdcNetworks allows to retrieve all NSX-T or NSX-V Org VDC networks in Org
 //
 // Note. If pageSize > 32 it will be limited to maximum of 32 in this function because API validation does not allow for
@@ -141,6 +171,20 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) UpdateDhcp(ctx context.Context, orgVdcNet
 	return updateOrgNetworkDhcp(ctx, orgVdcNet.client, orgVdcNet.OpenApiOrgVdcNetwork.ID, orgVdcNetworkDhcpConfig)
 }
 
+// Refresh reloads Org VDC network contents
+func (orgVdcNet *OpenApiOrgVdcNetwork) Refresh(ctx context.Context) error {
+	if orgVdcNet.client == nil || orgVdcNet.OpenApiOrgVdcNetwork == nil || orgVdcNet.OpenApiOrgVdcNetwork.ID == "" {
+		return fmt.Errorf("cannot refresh Org VDC network without ID")
+	}
+
+	refreshedNetwork, err := getOpenApiOrgVdcNetworkById(ctx, orgVdcNet.client, orgVdcNet.OpenApiOrgVdcNetwork.ID, nil)
+	if err != nil {
+		return fmt.Errorf("error refreshing Org VDC network: %w", err)
+	}
+	orgVdcNet.OpenApiOrgVdcNetwork = refreshedNetwork.OpenApiOrgVdcNetwork
+	return nil
+}
+
 // Update allows to update Org VDC network
 func (orgVdcNet *OpenApiOrgVdcNetwork) Update(ctx context.Context, OrgVdcNetworkConfig *types.OpenApiOrgVdcNetwork) (*OpenApiOrgVdcNetwork, error) {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointOrgVdcNetworks
@@ -165,7 +209,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) Update(ctx context.Context, OrgVdcNetwork
 
 	err = orgVdcNet.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, OrgVdcNetworkConfig, returnEgw.OpenApiOrgVdcNetwork, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Org VDC network: %s", err)
+		return nil, fmt.Errorf("error updating Org VDC network: %w", err)
 	}
 
 	return returnEgw, nil
@@ -173,6 +217,30 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) Update(ctx context.Context, OrgVdcNetwork
 
 // Delete allows to delete Org VDC network
 func (orgVdcNet *OpenApiOrgVdcNetwork) Delete(ctx context.Context) error {
+	return orgVdcNet.delete(ctx, nil)
+}
+
+// DeleteWithOptions deletes the Org VDC network. options.Force removes the network even when it is in use, and
+// implies Recursive, which is only accepted together with Force
+func (orgVdcNet *OpenApiOrgVdcNetwork) DeleteWithOptions(ctx context.Context, options DeleteOptions) error {
+	err := options.validate("Org VDC network", true, true)
+	if err != nil {
+		return err
+	}
+	if options.Recursive && !options.Force {
+		return fmt.Errorf("%w: the deletion of Org VDC network only supports Recursive together with Force",
+			ErrorUnsupportedDeleteOption)
+	}
+
+	var queryParams url.Values
+	if options.Force {
+		queryParams = url.Values{"force": []string{"true"}}
+	}
+	return orgVdcNet.delete(ctx, queryParams)
+}
+
+// delete deletes the Org VDC network with the given query parameters
+func (orgVdcNet *OpenApiOrgVdcNetwork) delete(ctx context.Context, queryParams url.Values) error {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointOrgVdcNetworks
 	minimumApiVersion, err := orgVdcNet.client.checkOpenApiEndpointCompatibility(ctx, endpoint)
 	if err != nil {
@@ -188,10 +256,10 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) Delete(ctx context.Context) error {
 		return err
 	}
 
-	err = orgVdcNet.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
+	err = orgVdcNet.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, queryParams, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting Org VDC network: %s", err)
+		return fmt.Errorf("error deleting Org VDC network: %w", err)
 	}
 
 	return nil
@@ -315,7 +383,7 @@ func getAllOpenApiOrgVdcNetworks(ctx context.Context, client *Client, queryParam
 	default:
 		pageSizeValue, err := strconv.Atoi(pageSizeString)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing pageSize value: %s", err)
+			return nil, fmt.Errorf("error parsing pageSize value: %w", err)
 		}
 		if pageSizeString != "" && pageSizeValue > 32 {
 			queryParameters.Set("pageSize", "32")
@@ -371,7 +439,7 @@ func createOpenApiOrgVdcNetwork(ctx context.Context, client *Client, OrgVdcNetwo
 
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, OrgVdcNetworkConfig, returnEgw.OpenApiOrgVdcNetwork, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating Org VDC network: %s", err)
+		return nil, fmt.Errorf("error creating Org VDC network: %w", err)
 	}
 
 	return returnEgw, nil
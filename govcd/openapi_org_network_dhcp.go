@@ -116,7 +116,7 @@ func (vdc *Vdc) DeleteOpenApiOrgVdcNetworkDhcp(ctx context.Context, orgNetworkId
 
 	err = vdc.client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting Org VDC network DHCP configuration: %s", err)
+		return fmt.Errorf("error deleting Org VDC network DHCP configuration: %w", err)
 	}
 
 	return nil
@@ -142,7 +142,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) DeletNetworkDhcp(ctx context.Context) err
 	err = orgVdcNet.client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting Org VDC network DHCP configuration: %s", err)
+		return fmt.Errorf("error deleting Org VDC network DHCP configuration: %w", err)
 	}
 
 	return nil
@@ -179,7 +179,7 @@ func updateOrgNetworkDhcp(ctx context.Context, client *Client, orgNetworkId stri
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, orgVdcNetworkDhcpConfig, orgNetDhcpResponse.OpenApiOrgVdcNetworkDhcp, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Org VDC network DHCP configuration: %s", err)
+		return nil, fmt.Errorf("error updating Org VDC network DHCP configuration: %w", err)
 	}
 
 	return orgNetDhcpResponse, nil
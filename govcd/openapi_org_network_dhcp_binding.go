@@ -41,12 +41,12 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) CreateOpenApiOrgVdcNetworkDhcpBinding(ctx
 	// which is not standard, therefore it must be explicitly handled in the code here
 	task, err := client.OpenApiPostItemAsync(ctx, apiVersion, urlRef, nil, dhcpBindingConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating Org VDC Network DHCP Binding: %s", err)
+		return nil, fmt.Errorf("error creating Org VDC Network DHCP Binding: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for Org VDC Network DHCP Binding to be created: %s", err)
+		return nil, fmt.Errorf("error waiting for Org VDC Network DHCP Binding to be created: %w", err)
 	}
 
 	dhcpBindingId := task.Task.Details
@@ -58,7 +58,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) CreateOpenApiOrgVdcNetworkDhcpBinding(ctx
 	// Get the DHCP binding by ID and return it
 	createdBinding, err := orgVdcNet.GetOpenApiOrgVdcNetworkDhcpBindingById(ctx, dhcpBindingId)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving DHCP binding for Org VDC network after creation: %s", err)
+		return nil, fmt.Errorf("error retrieving DHCP binding for Org VDC network after creation: %w", err)
 	}
 
 	return createdBinding, nil
@@ -158,7 +158,7 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) GetOpenApiOrgVdcNetworkDhcpBindingByName(
 
 	allDhcpBindings, err := orgVdcNet.GetAllOpenApiOrgVdcNetworkDhcpBindings(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %s", name, err)
+		return nil, fmt.Errorf("unable to retrieve Org VDC network by name '%s': %w", name, err)
 	}
 
 	// Bindings do not support name filtering, so we need to filter them manually
@@ -177,6 +177,22 @@ func (orgVdcNet *OpenApiOrgVdcNetwork) GetOpenApiOrgVdcNetworkDhcpBindingByName(
 	return foundBinding, nil
 }
 
+// GetOpenApiOrgVdcNetworkDhcpBindingByNameOrId retrieves a DHCP binding by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (orgVdcNet *OpenApiOrgVdcNetwork) GetOpenApiOrgVdcNetworkDhcpBindingByNameOrId(ctx context.Context, identifier string) (*OpenApiOrgVdcNetworkDhcpBinding, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) {
+		return orgVdcNet.GetOpenApiOrgVdcNetworkDhcpBindingByName(ctx, name)
+	}
+	getById := func(id string, refresh bool) (interface{}, error) {
+		return orgVdcNet.GetOpenApiOrgVdcNetworkDhcpBindingById(ctx, id)
+	}
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*OpenApiOrgVdcNetworkDhcpBinding), err
+}
+
 // Update allows to update DHCP configuration
 //
 // Note. This API requires `Version` field to be sent in the request and this function does it
@@ -207,13 +223,13 @@ func (dhcpBinding *OpenApiOrgVdcNetworkDhcpBinding) Update(ctx context.Context,
 	// load latest binding information to fetch Version value which is required for updates
 	err = result.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %s", orgVdcNetworkDhcpConfig.ID, err)
+		return nil, fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %w", orgVdcNetworkDhcpConfig.ID, err)
 	}
 	orgVdcNetworkDhcpConfig.Version = result.OpenApiOrgVdcNetworkDhcpBinding.Version
 
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, orgVdcNetworkDhcpConfig, result.OpenApiOrgVdcNetworkDhcpBinding, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating Org VDC network DHCP configuration with ID '%s': %s", orgVdcNetworkDhcpConfig.ID, err)
+		return nil, fmt.Errorf("error updating Org VDC network DHCP configuration with ID '%s': %w", orgVdcNetworkDhcpConfig.ID, err)
 	}
 
 	return result, nil
@@ -233,12 +249,12 @@ func (dhcpBinding *OpenApiOrgVdcNetworkDhcpBinding) Refresh(ctx context.Context)
 	client := dhcpBinding.client
 	orgVdcNet, err := getOpenApiOrgVdcNetworkById(ctx, client, dhcpBinding.ParentOrgVdcNetworkId, nil)
 	if err != nil {
-		return fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %s", dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID, err)
+		return fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %w", dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID, err)
 	}
 
 	newDhcpBinding, err := orgVdcNet.GetOpenApiOrgVdcNetworkDhcpBindingById(ctx, dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID)
 	if err != nil {
-		return fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %s", dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID, err)
+		return fmt.Errorf("error refreshing Org VDC network DHCP binding configuration with ID '%s': %w", dhcpBinding.OpenApiOrgVdcNetworkDhcpBinding.ID, err)
 	}
 
 	// Explicitly reassign the body
@@ -271,7 +287,7 @@ func (dhcpBinding *OpenApiOrgVdcNetworkDhcpBinding) Delete(ctx context.Context)
 	err = dhcpBinding.client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting Org VDC network DHCP configuration: %s", err)
+		return fmt.Errorf("error deleting Org VDC network DHCP configuration: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,322 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// defaultNetworkPlanConcurrency is the number of networks created at the same time from an OrgVdcNetworkPlan when
+// MaxConcurrency is not set
+const defaultNetworkPlanConcurrency = 4
+
+// OrgVdcNetworkPlanEntry describes one of the networks of an OrgVdcNetworkPlan
+type OrgVdcNetworkPlanEntry struct {
+	Name        string
+	Description string
+	Cidr        string   // Subnet of the network, such as 192.168.1.0/24
+	Gateway     string   // Address of the gateway. Default: first address of the subnet
+	IpPools     []string // Static IP pools, as ranges ("192.168.1.10-192.168.1.100") or single addresses
+	DnsServers  []string // Up to 2 DNS servers
+	DnsSuffix   string
+}
+
+// OrgVdcNetworkPlan is a set of Org VDC networks to be created at once, for example when onboarding a tenant, with
+// NsxtEdgeGateway.CreateRoutedNetworksFromPlan or VdcGroup.CreateIsolatedNetworksFromPlan
+type OrgVdcNetworkPlan struct {
+	Networks []OrgVdcNetworkPlanEntry
+	// MaxConcurrency is the number of networks created at the same time. Default: 4
+	MaxConcurrency int
+	// WaitForRealization makes the creation of each network wait until it is realized by NSX-T (see
+	// OpenApiOrgVdcNetwork.WaitForRealization), so that the networks can be used as soon as they are returned
+	WaitForRealization bool
+}
+
+// Validate checks the plan before creating any network: names must be unique, subnets must not overlap, and the
+// gateway, IP pools and DNS servers of each network must be valid addresses, with the gateway and pools inside its
+// subnet
+func (plan *OrgVdcNetworkPlan) Validate() error {
+	if plan == nil || len(plan.Networks) == 0 {
+		return fmt.Errorf("network plan is empty")
+	}
+	if plan.MaxConcurrency < 0 {
+		return fmt.Errorf("network plan concurrency can't be negative: %d", plan.MaxConcurrency)
+	}
+
+	var subnets []netip.Prefix
+	for index, entry := range plan.Networks {
+		if entry.Name == "" {
+			return fmt.Errorf("network %d of the plan has no name", index)
+		}
+		subnet, err := entry.validate()
+		if err != nil {
+			return fmt.Errorf("network '%s' of the plan is invalid: %w", entry.Name, err)
+		}
+		for previous := 0; previous < index; previous++ {
+			if plan.Networks[previous].Name == entry.Name {
+				return fmt.Errorf("network name '%s' is used more than once in the plan", entry.Name)
+			}
+			if subnets[previous].Overlaps(subnet) {
+				return fmt.Errorf("subnet %s of network '%s' overlaps subnet %s of network '%s'", subnet,
+					entry.Name, subnets[previous], plan.Networks[previous].Name)
+			}
+		}
+		subnets = append(subnets, subnet)
+	}
+	return nil
+}
+
+// CreateRoutedNetworksFromPlan creates the networks of the plan as routed networks connected to the NSX-T Edge
+// Gateway, in the VDC or VDC Group of the Edge Gateway. The networks are returned in the order of the plan.
+// When the creation of a network fails, no other creation is started, the networks already created are deleted,
+// and an error describing all the failures is returned
+func (egw *NsxtEdgeGateway) CreateRoutedNetworksFromPlan(ctx context.Context, plan *OrgVdcNetworkPlan) ([]*OpenApiOrgVdcNetwork, error) {
+	ownerRef := egw.EdgeGateway.OwnerRef
+	if ownerRef == nil || ownerRef.ID == "" {
+		ownerRef = egw.EdgeGateway.OrgVdc
+	}
+	if ownerRef == nil || ownerRef.ID == "" {
+		return nil, fmt.Errorf("cannot find the VDC or VDC Group of Edge Gateway '%s'", egw.EdgeGateway.Name)
+	}
+
+	return createOrgVdcNetworksFromPlan(ctx, egw.client, plan, func(network *types.OpenApiOrgVdcNetwork) {
+		network.NetworkType = types.OrgVdcNetworkTypeRouted
+		network.OwnerRef = &types.OpenApiReference{ID: ownerRef.ID}
+		network.Connection = &types.Connection{
+			RouterRef:      types.OpenApiReference{ID: egw.EdgeGateway.ID},
+			ConnectionType: "INTERNAL",
+		}
+	})
+}
+
+// CreateIsolatedNetworksFromPlan creates the networks of the plan as isolated networks of the VDC Group. The
+// networks are returned in the order of the plan.
+// When the creation of a network fails, no other creation is started, the networks already created are deleted,
+// and an error describing all the failures is returned
+func (vdcGroup *VdcGroup) CreateIsolatedNetworksFromPlan(ctx context.Context, plan *OrgVdcNetworkPlan) ([]*OpenApiOrgVdcNetwork, error) {
+	return createOrgVdcNetworksFromPlan(ctx, vdcGroup.client, plan, func(network *types.OpenApiOrgVdcNetwork) {
+		network.NetworkType = types.OrgVdcNetworkTypeIsolated
+		network.OwnerRef = &types.OpenApiReference{ID: vdcGroup.VdcGroup.Id}
+	})
+}
+
+// createOrgVdcNetworksFromPlan creates the networks of the plan, with the type and owner set by setOwner, running up
+// to plan.MaxConcurrency creations at the same time, and deletes the created networks when a creation fails.
+// Creations that were started are never interrupted, so that all the networks to delete are known
+func createOrgVdcNetworksFromPlan(ctx context.Context, client *Client, plan *OrgVdcNetworkPlan, setOwner func(network *types.OpenApiOrgVdcNetwork)) ([]*OpenApiOrgVdcNetwork, error) {
+	err := plan.Validate()
+	if err != nil {
+		return nil, err
+	}
+	maxConcurrency := plan.MaxConcurrency
+	if maxConcurrency == 0 {
+		maxConcurrency = defaultNetworkPlanConcurrency
+	}
+
+	// stop is closed at the first failure, so that the creations waiting for their turn are not started
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	networks := make([]*OpenApiOrgVdcNetwork, len(plan.Networks))
+	errs := make([]error, len(plan.Networks))
+	semaphore := make(chan struct{}, maxConcurrency)
+	var waitGroup sync.WaitGroup
+
+	for index := range plan.Networks {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-stop:
+				return
+			}
+			defer func() { <-semaphore }()
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			networks[index], errs[index] = createPlannedOrgVdcNetwork(ctx, client, plan, &plan.Networks[index], setOwner)
+			if errs[index] != nil {
+				util.Logger.Printf("[TRACE] %s", errs[index])
+				stopOnce.Do(func() { close(stop) })
+			}
+		}(index)
+	}
+	waitGroup.Wait()
+
+	var failures []string
+	for _, err := range errs {
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) == 0 {
+		return networks, nil
+	}
+
+	for _, network := range networks {
+		if network == nil {
+			continue
+		}
+		err = network.Delete(ctx)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("error removing network '%s' during rollback: %s",
+				network.OpenApiOrgVdcNetwork.Name, err))
+		}
+	}
+	return nil, fmt.Errorf("error creating networks from plan: %s", strings.Join(failures, "; "))
+}
+
+// createPlannedOrgVdcNetwork creates the network described by entry and, if the plan requires it, waits for its
+// realization. When the realization fails, the network is returned together with the error, to be deleted
+func createPlannedOrgVdcNetwork(ctx context.Context, client *Client, plan *OrgVdcNetworkPlan, entry *OrgVdcNetworkPlanEntry, setOwner func(network *types.OpenApiOrgVdcNetwork)) (*OpenApiOrgVdcNetwork, error) {
+	networkConfig, err := entry.toOrgVdcNetwork()
+	if err != nil {
+		return nil, fmt.Errorf("error creating network '%s': %w", entry.Name, err)
+	}
+	setOwner(networkConfig)
+
+	network, err := createOpenApiOrgVdcNetwork(ctx, client, networkConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network '%s': %w", entry.Name, err)
+	}
+	if plan.WaitForRealization {
+		err = network.WaitForRealization(ctx)
+		if err != nil {
+			return network, fmt.Errorf("error creating network '%s': %w", entry.Name, err)
+		}
+	}
+	return network, nil
+}
+
+// validate checks the addresses of the network and returns its subnet
+func (entry *OrgVdcNetworkPlanEntry) validate() (netip.Prefix, error) {
+	subnet, gateway, err := entry.subnetAndGateway()
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if gateway == subnet.Addr() {
+		return netip.Prefix{}, fmt.Errorf("gateway %s is the network address of subnet %s", gateway, subnet)
+	}
+
+	pools, err := entry.ipPools()
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	for index, pool := range pools {
+		if !subnet.Contains(pool[0]) || !subnet.Contains(pool[1]) {
+			return netip.Prefix{}, fmt.Errorf("IP pool %s is outside subnet %s", entry.IpPools[index], subnet)
+		}
+		if pool[0].Compare(gateway) <= 0 && gateway.Compare(pool[1]) <= 0 {
+			return netip.Prefix{}, fmt.Errorf("IP pool %s contains gateway %s", entry.IpPools[index], gateway)
+		}
+		for previous := 0; previous < index; previous++ {
+			if pool[0].Compare(pools[previous][1]) <= 0 && pools[previous][0].Compare(pool[1]) <= 0 {
+				return netip.Prefix{}, fmt.Errorf("IP pool %s overlaps IP pool %s", entry.IpPools[index],
+					entry.IpPools[previous])
+			}
+		}
+	}
+
+	if len(entry.DnsServers) > 2 {
+		return netip.Prefix{}, fmt.Errorf("at most 2 DNS servers can be set, got %d", len(entry.DnsServers))
+	}
+	for _, dnsServer := range entry.DnsServers {
+		_, err = netip.ParseAddr(dnsServer)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid DNS server: %w", err)
+		}
+	}
+	return subnet, nil
+}
+
+// subnetAndGateway returns the subnet of the network, without host bits, and its gateway
+func (entry *OrgVdcNetworkPlanEntry) subnetAndGateway() (netip.Prefix, netip.Addr, error) {
+	prefix, err := netip.ParsePrefix(entry.Cidr)
+	if err != nil {
+		return netip.Prefix{}, netip.Addr{}, fmt.Errorf("invalid CIDR: %w", err)
+	}
+	subnet := prefix.Masked()
+
+	gateway := subnet.Addr().Next()
+	if entry.Gateway != "" {
+		gateway, err = netip.ParseAddr(entry.Gateway)
+		if err != nil {
+			return netip.Prefix{}, netip.Addr{}, fmt.Errorf("invalid gateway: %w", err)
+		}
+	}
+	if !subnet.Contains(gateway) {
+		return netip.Prefix{}, netip.Addr{}, fmt.Errorf("gateway %s is outside subnet %s", gateway, subnet)
+	}
+	return subnet, gateway, nil
+}
+
+// ipPools parses the IP pools of the network into their first and last addresses
+func (entry *OrgVdcNetworkPlanEntry) ipPools() ([][2]netip.Addr, error) {
+	pools := make([][2]netip.Addr, len(entry.IpPools))
+	for index, ipPool := range entry.IpPools {
+		start, end, isRange := strings.Cut(ipPool, "-")
+		if !isRange {
+			end = start
+		}
+		var err error
+		pools[index][0], err = netip.ParseAddr(strings.TrimSpace(start))
+		if err == nil {
+			pools[index][1], err = netip.ParseAddr(strings.TrimSpace(end))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP pool '%s': %w", ipPool, err)
+		}
+		if pools[index][1].Less(pools[index][0]) {
+			return nil, fmt.Errorf("invalid IP pool '%s': the last address is lower than the first one", ipPool)
+		}
+	}
+	return pools, nil
+}
+
+// toOrgVdcNetwork returns the definition of the network, without its type and owner
+func (entry *OrgVdcNetworkPlanEntry) toOrgVdcNetwork() (*types.OpenApiOrgVdcNetwork, error) {
+	subnet, gateway, err := entry.subnetAndGateway()
+	if err != nil {
+		return nil, err
+	}
+	pools, err := entry.ipPools()
+	if err != nil {
+		return nil, err
+	}
+
+	subnetValues := types.OrgVdcNetworkSubnetValues{
+		Gateway:      gateway.String(),
+		PrefixLength: subnet.Bits(),
+		DNSSuffix:    entry.DnsSuffix,
+	}
+	if len(entry.DnsServers) > 0 {
+		subnetValues.DNSServer1 = entry.DnsServers[0]
+	}
+	if len(entry.DnsServers) > 1 {
+		subnetValues.DNSServer2 = entry.DnsServers[1]
+	}
+	for _, pool := range pools {
+		subnetValues.IPRanges.Values = append(subnetValues.IPRanges.Values, types.OrgVdcNetworkSubnetIPRangeValues{
+			StartAddress: pool[0].String(),
+			EndAddress:   pool[1].String(),
+		})
+	}
+
+	return &types.OpenApiOrgVdcNetwork{
+		Name:        entry.Name,
+		Description: entry.Description,
+		Subnets:     types.OrgVdcNetworkSubnets{Values: []types.OrgVdcNetworkSubnetValues{subnetValues}},
+	}, nil
+}
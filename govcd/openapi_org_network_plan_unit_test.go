@@ -0,0 +1,208 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_OrgVdcNetworkPlanValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		networks []OrgVdcNetworkPlanEntry
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			networks: []OrgVdcNetworkPlanEntry{
+				{Name: "net1", Cidr: "10.0.1.0/24", IpPools: []string{"10.0.1.10-10.0.1.20", "10.0.1.30"},
+					DnsServers: []string{"8.8.8.8", "8.8.4.4"}},
+				{Name: "net2", Cidr: "10.0.2.0/24", Gateway: "10.0.2.254"},
+			},
+		},
+		{
+			name:     "empty",
+			networks: nil,
+			wantErr:  "plan is empty",
+		},
+		{
+			name:     "duplicateName",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0/24"}, {Name: "net1", Cidr: "10.0.2.0/24"}},
+			wantErr:  "used more than once",
+		},
+		{
+			name:     "overlappingSubnets",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.0.0/16"}, {Name: "net2", Cidr: "10.0.2.0/24"}},
+			wantErr:  "overlaps subnet",
+		},
+		{
+			name:     "invalidCidr",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0"}},
+			wantErr:  "invalid CIDR",
+		},
+		{
+			name:     "gatewayOutsideSubnet",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0/24", Gateway: "10.0.2.1"}},
+			wantErr:  "outside subnet",
+		},
+		{
+			name:     "gatewayIsNetworkAddress",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0/24", Gateway: "10.0.1.0"}},
+			wantErr:  "network address",
+		},
+		{
+			name:     "poolOutsideSubnet",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0/24", IpPools: []string{"10.0.1.10-10.0.2.10"}}},
+			wantErr:  "outside subnet",
+		},
+		{
+			name:     "poolContainsGateway",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0/24", IpPools: []string{"10.0.1.1-10.0.1.10"}}},
+			wantErr:  "contains gateway",
+		},
+		{
+			name: "overlappingPools",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0/24",
+				IpPools: []string{"10.0.1.10-10.0.1.20", "10.0.1.20-10.0.1.30"}}},
+			wantErr: "overlaps IP pool",
+		},
+		{
+			name:     "reversedPool",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0/24", IpPools: []string{"10.0.1.20-10.0.1.10"}}},
+			wantErr:  "lower than the first one",
+		},
+		{
+			name:     "tooManyDnsServers",
+			networks: []OrgVdcNetworkPlanEntry{{Name: "net1", Cidr: "10.0.1.0/24", DnsServers: []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"}}},
+			wantErr:  "at most 2 DNS servers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &OrgVdcNetworkPlan{Networks: tt.networks}
+			err := plan.Validate()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("got error %v, expected error containing '%s'", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_CreateRoutedNetworksFromPlan(t *testing.T) {
+	var lock sync.Mutex
+	created := map[string]*types.OpenApiOrgVdcNetwork{}
+	var deleted []string
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/cloudapi/1.0.0/orgVdcNetworks/":
+			network := &types.OpenApiOrgVdcNetwork{}
+			_ = json.NewDecoder(r.Body).Decode(network)
+			if network.Name == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"minorErrorCode":"BAD_REQUEST","message":"invalid network"}`))
+				return
+			}
+			network.ID = "urn:vcloud:network:" + network.Name
+			created[network.ID] = network
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(network)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/cloudapi/1.0.0/orgVdcNetworks/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/cloudapi/1.0.0/orgVdcNetworks/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"35.0", "36.0"})
+	vcdClient.Client.APIVersion = "36.0"
+	ctx := context.Background()
+	egw := &NsxtEdgeGateway{
+		EdgeGateway: &types.OpenAPIEdgeGateway{
+			ID:       "urn:vcloud:gateway:edge",
+			Name:     "edge",
+			OwnerRef: &types.OpenApiReference{ID: "urn:vcloud:vdc:vdc"},
+		},
+		client: &vcdClient.Client,
+	}
+
+	plan := &OrgVdcNetworkPlan{
+		Networks: []OrgVdcNetworkPlanEntry{
+			{Name: "net1", Cidr: "10.0.1.0/24", IpPools: []string{"10.0.1.10-10.0.1.20"}, DnsServers: []string{"8.8.8.8"}},
+			{Name: "net2", Cidr: "10.0.2.0/24", Gateway: "10.0.2.254"},
+			{Name: "net3", Cidr: "10.0.3.0/24"},
+		},
+		MaxConcurrency: 2,
+	}
+	networks, err := egw.CreateRoutedNetworksFromPlan(ctx, plan)
+	if err != nil {
+		t.Fatalf("unexpected error creating networks: %s", err)
+	}
+	if len(networks) != len(plan.Networks) {
+		t.Fatalf("got %d networks, expected %d", len(networks), len(plan.Networks))
+	}
+	for index, network := range networks {
+		if network.OpenApiOrgVdcNetwork.Name != plan.Networks[index].Name {
+			t.Errorf("got network '%s' at position %d, expected '%s'", network.OpenApiOrgVdcNetwork.Name, index,
+				plan.Networks[index].Name)
+		}
+	}
+	net1 := created["urn:vcloud:network:net1"]
+	subnet := net1.Subnets.Values[0]
+	if net1.NetworkType != types.OrgVdcNetworkTypeRouted || net1.OwnerRef.ID != "urn:vcloud:vdc:vdc" ||
+		net1.Connection.RouterRef.ID != "urn:vcloud:gateway:edge" {
+		t.Errorf("unexpected type, owner or connection of network: %+v", net1)
+	}
+	if subnet.Gateway != "10.0.1.1" || subnet.PrefixLength != 24 || subnet.DNSServer1 != "8.8.8.8" ||
+		len(subnet.IPRanges.Values) != 1 || subnet.IPRanges.Values[0].EndAddress != "10.0.1.20" {
+		t.Errorf("unexpected subnet of network: %+v", subnet)
+	}
+	if created["urn:vcloud:network:net2"].Subnets.Values[0].Gateway != "10.0.2.254" {
+		t.Errorf("gateway of network net2 was not used")
+	}
+
+	// A failure removes the networks that were created
+	created = map[string]*types.OpenApiOrgVdcNetwork{}
+	plan.Networks = append(plan.Networks, OrgVdcNetworkPlanEntry{Name: "bad", Cidr: "10.0.4.0/24"})
+	networks, err = egw.CreateRoutedNetworksFromPlan(ctx, plan)
+	if err == nil || !strings.Contains(err.Error(), "error creating network 'bad'") {
+		t.Fatalf("got error %v, expected error creating network 'bad'", err)
+	}
+	if networks != nil {
+		t.Errorf("got networks %v after failure, expected none", networks)
+	}
+	if len(deleted) != len(created) {
+		t.Errorf("deleted networks %v, expected all created networks to be deleted (%d)", deleted, len(created))
+	}
+	for _, id := range deleted {
+		if created[id] == nil {
+			t.Errorf("deleted network '%s' was not created", id)
+		}
+	}
+}
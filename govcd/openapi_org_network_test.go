@@ -112,6 +112,51 @@ func (vcd *TestVCD) Test_NsxtOrgVdcNetworkRouted(check *C) {
 	runOpenApiOrgVdcNetworkWithVdcGroupTest(check, vcd, orgVdcNetworkConfig, types.OrgVdcNetworkTypeRouted, []dhcpConfigFunc{nsxtRoutedDhcpConfigEdgeMode, nsxtDhcpConfigNetworkMode})
 }
 
+func (vcd *TestVCD) Test_NsxtOrgVdcNetworkRoutedFromPlan(check *C) {
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointOrgVdcNetworks)
+	skipNoNsxtConfiguration(vcd, check)
+
+	egw, err := vcd.org.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	plan := &OrgVdcNetworkPlan{
+		Networks: []OrgVdcNetworkPlanEntry{
+			{Name: check.TestName() + "-1", Cidr: "2.1.1.0/24", IpPools: []string{"2.1.1.20-2.1.1.30"}},
+			{Name: check.TestName() + "-2", Cidr: "2.1.2.0/24", DnsServers: []string{"8.8.8.8"}},
+			{Name: check.TestName() + "-3", Cidr: "2.1.3.0/24", Gateway: "2.1.3.254"},
+		},
+		WaitForRealization: true,
+	}
+	networks, err := egw.CreateRoutedNetworksFromPlan(ctx, plan)
+	check.Assert(err, IsNil)
+	check.Assert(len(networks), Equals, len(plan.Networks))
+	for index, network := range networks {
+		openApiEndpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointOrgVdcNetworks + network.OpenApiOrgVdcNetwork.ID
+		PrependToCleanupListOpenApi(network.OpenApiOrgVdcNetwork.Name, check.TestName(), openApiEndpoint)
+		check.Assert(network.OpenApiOrgVdcNetwork.Name, Equals, plan.Networks[index].Name)
+		check.Assert(network.OpenApiOrgVdcNetwork.Status, Equals, types.RealizationStatusRealized)
+	}
+	check.Assert(networks[2].OpenApiOrgVdcNetwork.Subnets.Values[0].Gateway, Equals, "2.1.3.254")
+
+	// An invalid network makes the whole plan fail, removing the networks that were created
+	failingPlan := &OrgVdcNetworkPlan{
+		Networks: []OrgVdcNetworkPlanEntry{
+			{Name: check.TestName() + "-4", Cidr: "2.1.4.0/24"},
+			{Name: networks[0].OpenApiOrgVdcNetwork.Name, Cidr: "2.1.5.0/24"},
+		},
+		MaxConcurrency: 1,
+	}
+	_, err = egw.CreateRoutedNetworksFromPlan(ctx, failingPlan)
+	check.Assert(err, NotNil)
+	_, err = vcd.nsxtVdc.GetOpenApiOrgVdcNetworkByName(ctx, failingPlan.Networks[0].Name)
+	check.Assert(ContainsNotFound(err), Equals, true)
+
+	for _, network := range networks {
+		err = network.Delete(ctx)
+		check.Assert(err, IsNil)
+	}
+}
+
 func (vcd *TestVCD) Test_NsxtOrgVdcNetworkImportedNsxtLogicalSwitch(check *C) {
 	if vcd.skipAdminTests {
 		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
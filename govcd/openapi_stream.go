@@ -0,0 +1,100 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// ErrorRequestBodyTooLarge is returned when a request body exceeds Client.MaxRequestBodySize
+var ErrorRequestBodyTooLarge = errors.New("request body too large")
+
+// OpenApiStreamBody is a request body read from an io.Reader, so that large payloads (e.g. RDE contents) are sent
+// without being built in memory. It can be passed as payload to the low level OpenAPI POST and PUT functions, such as
+// OpenApiPostItem, in place of a structure to marshal. The Reader must provide valid JSON.
+type OpenApiStreamBody struct {
+	Reader io.Reader
+	// Size is the length of the body in bytes, sent as Content-Length. When it is negative (unknown), the body is sent
+	// with chunked transfer encoding
+	Size int64
+	// Chunked forces chunked transfer encoding even when Size is known
+	Chunked bool
+}
+
+// checkRequestBodySize returns an error when a body of the given size exceeds Client.MaxRequestBodySize
+func (client *Client) checkRequestBodySize(size int64) error {
+	if client.MaxRequestBodySize > 0 && size > client.MaxRequestBodySize {
+		return fmt.Errorf("%w: %d bytes exceed the maximum of %d bytes", ErrorRequestBodyTooLarge, size,
+			client.MaxRequestBodySize)
+	}
+	return nil
+}
+
+// bodySizeGuard fails the reading of a request body of unknown size when it exceeds the limit, so that the request
+// is aborted
+type bodySizeGuard struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+func (guard *bodySizeGuard) Read(buffer []byte) (int, error) {
+	count, err := guard.reader.Read(buffer)
+	guard.read += int64(count)
+	if guard.read > guard.limit {
+		return 0, fmt.Errorf("%w: more than %d bytes were read", ErrorRequestBodyTooLarge, guard.limit)
+	}
+	return count, err
+}
+
+// newOpenApiStreamRequest is the equivalent of newOpenApiRequest for streamed bodies: the body is not read in
+// advance, so it is not logged
+func (client *Client) newOpenApiStreamRequest(ctx context.Context, apiVersion string, params url.Values, method string, reqUrl *url.URL, body *OpenApiStreamBody, additionalHeader map[string]string) (*http.Request, error) {
+	if body == nil || body.Reader == nil {
+		return nil, fmt.Errorf("the request body has no reader")
+	}
+
+	reader := body.Reader
+	if body.Size >= 0 {
+		err := client.checkRequestBodySize(body.Size)
+		if err != nil {
+			return nil, err
+		}
+	} else if client.MaxRequestBodySize > 0 {
+		reader = &bodySizeGuard{reader: reader, limit: client.MaxRequestBodySize}
+	}
+
+	// copy passed in URL ref so that it is not mutated
+	reqUrlCopy := copyUrlRef(reqUrl)
+	reqUrlCopy.RawQuery += params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, reqUrlCopy.String(), io.NopCloser(reader))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", method, err)
+	}
+	// A ContentLength of -1 makes the transport use chunked transfer encoding
+	req.ContentLength = body.Size
+	if body.Chunked || body.Size < 0 {
+		req.ContentLength = -1
+	}
+	client.setOpenApiRequestHeaders(req, apiVersion, additionalHeader)
+
+	if util.LogHttpRequest {
+		payload := fmt.Sprintf("[streamed body of %d bytes]", body.Size)
+		if req.ContentLength < 0 {
+			payload = "[streamed body of unknown size]"
+		}
+		util.ProcessRequestOutput(util.FuncNameCallStack(), method, reqUrlCopy.String(), payload, req)
+		debugShowRequest(req, payload)
+	}
+	return req, nil
+}
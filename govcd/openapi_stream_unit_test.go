@@ -0,0 +1,95 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_openApiPerformPostPutStream(t *testing.T) {
+	type receivedRequest struct {
+		body             string
+		contentLength    int64
+		transferEncoding []string
+	}
+	var received []receivedRequest
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, receivedRequest{
+			body:             string(body),
+			contentLength:    r.ContentLength,
+			transferEncoding: r.TransferEncoding,
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true, WithMaxRequestBodySize(20)).Client
+	endpoint, err := url.Parse(server.URL + "/cloudapi/1.0.0/entities")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx := context.Background()
+	post := func(payload interface{}) error {
+		resp, err := client.openApiPerformPostPut(ctx, http.MethodPost, "37.0", endpoint, nil, payload, nil)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	// Known size: sent with Content-Length
+	err = post(&OpenApiStreamBody{Reader: strings.NewReader(`{"a":1}`), Size: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if received[0].body != `{"a":1}` || received[0].contentLength != 7 || len(received[0].transferEncoding) != 0 {
+		t.Errorf("unexpected request with known size: %+v", received[0])
+	}
+
+	// Unknown size or forced chunked transfer
+	for _, body := range []*OpenApiStreamBody{
+		{Reader: strings.NewReader(`{"b":2}`), Size: -1},
+		{Reader: strings.NewReader(`{"b":2}`), Size: 7, Chunked: true},
+	} {
+		received = nil
+		err = post(body)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if received[0].body != `{"b":2}` || len(received[0].transferEncoding) == 0 || received[0].transferEncoding[0] != "chunked" {
+			t.Errorf("expected chunked request, got %+v", received[0])
+		}
+	}
+
+	// Bodies larger than the maximum size are rejected, before sending them when the size is known
+	received = nil
+	err = post(&OpenApiStreamBody{Reader: strings.NewReader(strings.Repeat("x", 30)), Size: 30})
+	if !errors.Is(err, ErrorRequestBodyTooLarge) || len(received) != 0 {
+		t.Errorf("expected request body too large error without request, got %v (%d requests)", err, len(received))
+	}
+	err = post(&OpenApiStreamBody{Reader: strings.NewReader(`"` + strings.Repeat("x", 30) + `"`), Size: -1})
+	if !errors.Is(err, ErrorRequestBodyTooLarge) {
+		t.Errorf("expected request body too large error for unknown size, got %v", err)
+	}
+	err = post(map[string]string{"name": strings.Repeat("x", 30)})
+	if !errors.Is(err, ErrorRequestBodyTooLarge) {
+		t.Errorf("expected request body too large error for marshalled payload, got %v", err)
+	}
+}
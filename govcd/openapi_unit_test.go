@@ -47,3 +47,49 @@ func Test_defaultPageSize(t *testing.T) {
 		})
 	}
 }
+
+func Test_sortOpenApiResults(t *testing.T) {
+	type item struct {
+		ID   string
+		Name string
+	}
+	items := []*item{{ID: "3", Name: "b"}, {ID: "1", Name: "c"}, {ID: "2", Name: "a"}}
+
+	sortOpenApiResults(&items, SortResultsByName)
+	if items[0].Name != "a" || items[1].Name != "b" || items[2].Name != "c" {
+		t.Errorf("unexpected order by name: %s %s %s", items[0].Name, items[1].Name, items[2].Name)
+	}
+
+	sortOpenApiResults(&items, SortResultsById)
+	if items[0].ID != "1" || items[1].ID != "2" || items[2].ID != "3" {
+		t.Errorf("unexpected order by ID: %s %s %s", items[0].ID, items[1].ID, items[2].ID)
+	}
+
+	// Unknown fields leave the slice untouched
+	sortOpenApiResults(&items, "unknown")
+	if items[0].ID != "1" || items[1].ID != "2" || items[2].ID != "3" {
+		t.Errorf("slice was modified when sorting by unknown field")
+	}
+
+	// Works with interface wrapped pointers, as received by OpenApiGetAllItems
+	var outType interface{} = &items
+	sortOpenApiResults(outType, SortResultsByName)
+	if items[0].Name != "a" {
+		t.Errorf("unexpected order by name through interface: %s", items[0].Name)
+	}
+}
+
+func TestQueryParameterSort(t *testing.T) {
+	params := url.Values{"filter": []string{"name==a"}, "sortDesc": []string{"id"}}
+	sorted := QueryParameterSortAsc("name", params)
+	if sorted.Get("sortAsc") != "name" || sorted.Get("sortDesc") != "" || sorted.Get("filter") != "name==a" {
+		t.Errorf("unexpected parameters: %v", sorted)
+	}
+	if params.Get("sortDesc") != "id" {
+		t.Errorf("original parameters were modified")
+	}
+	sorted = QueryParameterSortDesc("name", sorted)
+	if sorted.Get("sortDesc") != "name" || sorted.Get("sortAsc") != "" {
+		t.Errorf("unexpected parameters: %v", sorted)
+	}
+}
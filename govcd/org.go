@@ -193,6 +193,10 @@ func validateVdcConfiguration(vdcDefinition *types.VdcConfiguration) error {
 	if vdcDefinition.VdcStorageProfile[0].Units == "" {
 		return errors.New("VdcConfiguration missing required field: VdcStorageProfile.Units")
 	}
+	err := validateVdcStorageProfiles(vdcDefinition.VdcStorageProfile)
+	if err != nil {
+		return err
+	}
 	if vdcDefinition.ProviderVdcReference == nil {
 		return errors.New("VdcConfiguration missing required field: ProviderVdcReference")
 	}
@@ -202,6 +206,46 @@ func validateVdcConfiguration(vdcDefinition *types.VdcConfiguration) error {
 	return nil
 }
 
+// validateVdcStorageProfiles checks the storage profiles of a VDC creation payload, including their optional
+// IOPS settings, so that a VDC is not created with an invalid storage configuration
+func validateVdcStorageProfiles(storageProfiles []*types.VdcStorageProfileConfiguration) error {
+	defaultCount := 0
+	for index, storageProfile := range storageProfiles {
+		if storageProfile == nil {
+			return fmt.Errorf("VdcConfiguration invalid field: VdcStorageProfile[%d] is empty", index)
+		}
+		if storageProfile.ProviderVdcStorageProfile == nil || storageProfile.ProviderVdcStorageProfile.HREF == "" {
+			return fmt.Errorf("VdcConfiguration missing required field: VdcStorageProfile[%d].ProviderVdcStorageProfile.HREF", index)
+		}
+		if storageProfile.Default {
+			defaultCount++
+			if storageProfile.Enabled != nil && !*storageProfile.Enabled {
+				return fmt.Errorf("VdcConfiguration invalid field: VdcStorageProfile[%d] is default and cannot be disabled", index)
+			}
+		}
+
+		iops := storageProfile.IopsSettings
+		if iops == nil {
+			continue
+		}
+		if iops.DiskIopsMax < 0 || iops.DiskIopsDefault < 0 || iops.StorageProfileIopsLimit < 0 || iops.DiskIopsPerGbMax < 0 {
+			return fmt.Errorf("VdcConfiguration invalid field: VdcStorageProfile[%d].IopsSettings values cannot be negative", index)
+		}
+		if iops.DiskIopsMax > 0 && iops.DiskIopsDefault > iops.DiskIopsMax {
+			return fmt.Errorf("VdcConfiguration invalid field: VdcStorageProfile[%d].IopsSettings.DiskIopsDefault (%d) cannot exceed DiskIopsMax (%d)",
+				index, iops.DiskIopsDefault, iops.DiskIopsMax)
+		}
+		if iops.StorageProfileIopsLimit > 0 && iops.DiskIopsMax > iops.StorageProfileIopsLimit {
+			return fmt.Errorf("VdcConfiguration invalid field: VdcStorageProfile[%d].IopsSettings.DiskIopsMax (%d) cannot exceed StorageProfileIopsLimit (%d)",
+				index, iops.DiskIopsMax, iops.StorageProfileIopsLimit)
+		}
+	}
+	if defaultCount > 1 {
+		return fmt.Errorf("VdcConfiguration invalid field: only one VdcStorageProfile can be default, found %d", defaultCount)
+	}
+	return nil
+}
+
 // GetCatalogByHref  finds a Catalog by HREF
 // On success, returns a pointer to the Catalog structure and a nil error
 // On failure, returns a nil pointer and an error
@@ -228,7 +272,7 @@ func (org *Org) GetCatalogByName(ctx context.Context, catalogName string, refres
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error querying Catalog: %s", err)
+		return nil, fmt.Errorf("error querying Catalog: %w", err)
 	}
 	// This is not an AdminOrg and admin HREF must be removed if it exists
 	href := strings.Replace(vdcQuery.HREF, "/api/admin", "/api", 1)
@@ -244,7 +288,7 @@ func (org *Org) GetCatalogById(ctx context.Context, catalogId string, refresh bo
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error querying Catalog: %s", err)
+		return nil, fmt.Errorf("error querying Catalog: %w", err)
 	}
 
 	// This is not an AdminOrg and admin HREF must be removed if it exists
@@ -294,7 +338,7 @@ func (org *Org) GetVDCByName(ctx context.Context, vdcName string, refresh bool)
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error querying VDC: %s", err)
+		return nil, fmt.Errorf("error querying VDC: %w", err)
 	}
 	// This is not an AdminOrg and admin HREF must be removed if it exists
 	href := strings.Replace(vdcQuery.HREF, "/api/admin", "/api", 1)
@@ -311,7 +355,7 @@ func (org *Org) GetVDCById(ctx context.Context, vdcId string, refresh bool) (*Vd
 		return nil, ErrorEntityNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error querying VDC: %s", err)
+		return nil, fmt.Errorf("error querying VDC: %w", err)
 	}
 
 	// This is not an AdminOrg and admin HREF must be removed if it exists
@@ -525,7 +569,7 @@ func queryOrgVdcList(ctx context.Context, client *Client, filterFields map[strin
 
 	results, err := client.cumulativeQuery(ctx, queryType, nil, filter)
 	if err != nil {
-		return nil, fmt.Errorf("error querying Org VDCs %s", err)
+		return nil, fmt.Errorf("error querying Org VDCs %w", err)
 	}
 
 	if client.IsSysAdmin {
@@ -58,7 +58,7 @@ func (orgVdcNet *OrgVDCNetwork) Refresh(ctx context.Context) error {
 func (orgVdcNet *OrgVDCNetwork) Delete(ctx context.Context) (Task, error) {
 	err := orgVdcNet.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing network: %s", err)
+		return Task{}, fmt.Errorf("error refreshing network: %w", err)
 	}
 	pathArr := strings.Split(orgVdcNet.OrgVDCNetwork.HREF, "/")
 	apiEndpoint := urlParseRequestURI(orgVdcNet.OrgVDCNetwork.HREF)
@@ -73,7 +73,7 @@ func (orgVdcNet *OrgVDCNetwork) Delete(ctx context.Context) (Task, error) {
 				time.Sleep(3 * time.Second)
 				continue
 			}
-			return Task{}, fmt.Errorf("error deleting Network: %s", err)
+			return Task{}, fmt.Errorf("error deleting Network: %w", err)
 		}
 		break
 	}
@@ -81,13 +81,26 @@ func (orgVdcNet *OrgVDCNetwork) Delete(ctx context.Context) (Task, error) {
 	task := NewTask(orgVdcNet.client)
 
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	// The request was successful
 	return *task, nil
 }
 
+// DeleteWithOptions deletes the network and waits for the deletion to complete. No option is supported
+func (orgVdcNet *OrgVDCNetwork) DeleteWithOptions(ctx context.Context, options DeleteOptions) error {
+	err := options.validate("Org VDC network", false, false)
+	if err != nil {
+		return err
+	}
+	task, err := orgVdcNet.Delete(ctx)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
+
 // RemoveOrgVdcNetworkIfExists looks for an Org Vdc network and, if found, will delete it.
 func RemoveOrgVdcNetworkIfExists(ctx context.Context, vdc Vdc, networkName string) error {
 	network, err := vdc.GetOrgVdcNetworkByName(ctx, networkName, true)
@@ -103,11 +116,11 @@ func RemoveOrgVdcNetworkIfExists(ctx context.Context, vdc Vdc, networkName strin
 	// The network was found. We attempt deletion
 	task, err := network.Delete(ctx)
 	if err != nil {
-		return fmt.Errorf("error deleting network '%s' [phase 1]: %s", networkName, err)
+		return fmt.Errorf("error deleting network '%s' [phase 1]: %w", networkName, err)
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error deleting network '%s' [task]: %s", networkName, err)
+		return fmt.Errorf("error deleting network '%s' [task]: %w", networkName, err)
 	}
 	return nil
 }
@@ -118,7 +131,7 @@ func (vdc *Vdc) CreateOrgVDCNetworkWait(ctx context.Context, networkConfig *type
 
 	task, err := vdc.CreateOrgVDCNetwork(ctx, networkConfig)
 	if err != nil {
-		return fmt.Errorf("error creating the network: %s", err)
+		return fmt.Errorf("error creating the network: %w", err)
 	}
 	if task == (Task{}) {
 		return fmt.Errorf("NULL task retrieved after network creation")
@@ -127,7 +140,7 @@ func (vdc *Vdc) CreateOrgVDCNetworkWait(ctx context.Context, networkConfig *type
 	err = task.WaitTaskCompletion(ctx)
 	// err = task.WaitInspectTaskCompletion(InspectTask, 10)
 	if err != nil {
-		return fmt.Errorf("error performing task: %s", err)
+		return fmt.Errorf("error performing task: %w", err)
 	}
 	return nil
 }
@@ -143,14 +156,14 @@ func (vdc *Vdc) CreateOrgVDCNetwork(ctx context.Context, networkConfig *types.Or
 			createUrl, err := url.ParseRequestURI(av.HREF)
 
 			if err != nil {
-				return Task{}, fmt.Errorf("error decoding vdc response: %s", err)
+				return Task{}, fmt.Errorf("error decoding vdc response: %w", err)
 			}
 
 			networkConfig.Xmlns = types.XMLNamespaceVCloud
 
 			output, err := xml.MarshalIndent(networkConfig, "  ", "    ")
 			if err != nil {
-				return Task{}, fmt.Errorf("error marshaling OrgVDCNetwork compose: %s", err)
+				return Task{}, fmt.Errorf("error marshaling OrgVDCNetwork compose: %w", err)
 			}
 
 			var resp *http.Response
@@ -165,13 +178,13 @@ func (vdc *Vdc) CreateOrgVDCNetwork(ctx context.Context, networkConfig *types.Or
 						time.Sleep(3 * time.Second)
 						continue
 					}
-					return Task{}, fmt.Errorf("error instantiating a new OrgVDCNetwork: %s", err)
+					return Task{}, fmt.Errorf("error instantiating a new OrgVDCNetwork: %w", err)
 				}
 				break
 			}
 			orgVDCNetwork := NewOrgVDCNetwork(vdc.client)
 			if err = decodeBody(types.BodyTypeXML, resp, orgVDCNetwork.OrgVDCNetwork); err != nil {
-				return Task{}, fmt.Errorf("error decoding orgvdcnetwork response: %s", err)
+				return Task{}, fmt.Errorf("error decoding orgvdcnetwork response: %w", err)
 			}
 			activeTasks := 0
 			// Makes sure that there is only one active task for this network.
@@ -189,7 +202,7 @@ func (vdc *Vdc) CreateOrgVDCNetwork(ctx context.Context, networkConfig *types.Or
 				return Task{}, fmt.Errorf("found %d active tasks instead of one", activeTasks)
 			}
 			for _, taskItem := range orgVDCNetwork.OrgVDCNetwork.Tasks.Task {
-				return Task{taskItem, vdc.client}, nil
+				return Task{Task: taskItem, client: vdc.client}, nil
 			}
 			return Task{}, fmt.Errorf("[%s] no suitable task found", util.CurrentFuncName())
 		}
@@ -206,7 +219,7 @@ func (vdc *Vdc) GetNetworkList(ctx context.Context) ([]*types.QueryResultOrgVdcN
 		"filterEncoded": "true",
 	})
 	if err != nil {
-		return nil, fmt.Errorf("[findEdgeGatewayConnection] error returning the list of networks for VDC: %s", err)
+		return nil, fmt.Errorf("[findEdgeGatewayConnection] error returning the list of networks for VDC: %w", err)
 	}
 	return result.Results.OrgVdcNetworkRecord, nil
 }
@@ -222,7 +235,7 @@ func (vdc *Vdc) FindEdgeGatewayNameByNetwork(ctx context.Context, networkName st
 		"filterEncoded": "true",
 	})
 	if err != nil {
-		return "", fmt.Errorf("[findEdgeGatewayConnection] error returning the list of networks for VDC: %s", err)
+		return "", fmt.Errorf("[findEdgeGatewayConnection] error returning the list of networks for VDC: %w", err)
 	}
 	netList := result.Results.OrgVdcNetworkRecord
 
@@ -312,7 +325,7 @@ func (orgVdcNet *OrgVDCNetwork) UpdateAsync(ctx context.Context) (Task, error) {
 	if orgVdcNet.OrgVDCNetwork.Configuration.FenceMode == types.FenceModeNAT {
 		edgeGateway, err := orgVdcNet.getEdgeGateway(ctx)
 		if err != nil {
-			return Task{}, fmt.Errorf("error retrieving edge gateway for Org VDC network %s : %s", orgVdcNet.OrgVDCNetwork.Name, err)
+			return Task{}, fmt.Errorf("error retrieving edge gateway for Org VDC network %s : %w", orgVdcNet.OrgVDCNetwork.Name, err)
 		}
 		orgVdcNet.OrgVDCNetwork.EdgeGateway = &types.Reference{
 			HREF: edgeGateway.EdgeGateway.HREF,
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// defaultPowerOperationConcurrency is the number of entities processed at the same time by PowerOperate when
+// maxConcurrency is not set
+const defaultPowerOperationConcurrency = 8
+
+// PowerOperation is a power operation run by VCDClient.PowerOperate
+type PowerOperation string
+
+// Power operations supported by VCDClient.PowerOperate
+const (
+	PowerOperationOn       PowerOperation = "powerOn"
+	PowerOperationOff      PowerOperation = "powerOff"
+	PowerOperationShutdown PowerOperation = "shutdown" // Shut down the guest OS. Requires the guest tools
+	PowerOperationSuspend  PowerOperation = "suspend"
+	PowerOperationReset    PowerOperation = "reset"
+	PowerOperationReboot   PowerOperation = "reboot" // Reboot the guest OS. Requires the guest tools
+)
+
+// PowerOperationResult is the outcome of a power operation on a single entity with PowerOperate
+type PowerOperationResult struct {
+	Reference types.Reference // The entity, as given to PowerOperate
+	Error     error           // nil when the operation completed successfully
+}
+
+// PowerOperate runs the power operation on each of the vApps and VMs in references, running up to maxConcurrency
+// operations at the same time (8 when maxConcurrency is 0 or less), and waits for their tasks to complete.
+// The kind of entity is taken from the Type of each reference or, when empty, from its HREF.
+//
+// A failure on one entity does not stop the others: the returned slice has one result per reference, in the same
+// order as references, and the error of each entity is reported in its result. When ctx is done, the entities not
+// processed yet report the context error.
+func (vcdClient *VCDClient) PowerOperate(ctx context.Context, references []types.Reference, operation PowerOperation, maxConcurrency int) []PowerOperationResult {
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultPowerOperationConcurrency
+	}
+
+	results := make([]PowerOperationResult, len(references))
+	semaphore := make(chan struct{}, maxConcurrency)
+	var waitGroup sync.WaitGroup
+
+	for index, reference := range references {
+		results[index].Reference = reference
+		waitGroup.Add(1)
+		go func(result *PowerOperationResult) {
+			defer waitGroup.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				result.Error = ctx.Err()
+				return
+			}
+			defer func() { <-semaphore }()
+			if ctx.Err() != nil {
+				result.Error = ctx.Err()
+				return
+			}
+
+			result.Error = runPowerOperation(ctx, &vcdClient.Client, result.Reference, operation)
+			if result.Error != nil {
+				util.Logger.Printf("[TRACE] PowerOperate: error running %s on %s: %s", operation, result.Reference.HREF, result.Error)
+			}
+		}(&results[index])
+	}
+	waitGroup.Wait()
+
+	return results
+}
+
+// runPowerOperation starts the power operation on the vApp or VM in reference and waits for its task
+func runPowerOperation(ctx context.Context, client *Client, reference types.Reference, operation PowerOperation) error {
+	if reference.HREF == "" {
+		return fmt.Errorf("empty HREF")
+	}
+
+	var task Task
+	var err error
+	switch powerOperationEntityType(reference) {
+	case types.MimeVApp:
+		vapp := NewVApp(client)
+		vapp.VApp.HREF = reference.HREF
+		task, err = vAppPowerOperation(ctx, vapp, operation)
+	case types.MimeVM:
+		vm := NewVM(client)
+		vm.VM.HREF = reference.HREF
+		task, err = vmPowerOperation(ctx, vm, operation)
+	default:
+		return fmt.Errorf("power operations are only supported for vApps and VMs, not for '%s'", reference.HREF)
+	}
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
+
+// vAppPowerOperation starts the power operation on a vApp
+func vAppPowerOperation(ctx context.Context, vapp *VApp, operation PowerOperation) (Task, error) {
+	switch operation {
+	case PowerOperationOn:
+		return vapp.PowerOn(ctx)
+	case PowerOperationOff:
+		return vapp.PowerOff(ctx)
+	case PowerOperationShutdown:
+		return vapp.Shutdown(ctx)
+	case PowerOperationSuspend:
+		return vapp.Suspend(ctx)
+	case PowerOperationReset:
+		return vapp.Reset(ctx)
+	case PowerOperationReboot:
+		return vapp.Reboot(ctx)
+	}
+	return Task{}, fmt.Errorf("unsupported power operation '%s'", operation)
+}
+
+// vmPowerOperation starts the power operation on a VM
+func vmPowerOperation(ctx context.Context, vm *VM, operation PowerOperation) (Task, error) {
+	switch operation {
+	case PowerOperationOn:
+		return vm.PowerOn(ctx)
+	case PowerOperationOff:
+		return vm.PowerOff(ctx)
+	case PowerOperationShutdown:
+		return vm.Shutdown(ctx)
+	case PowerOperationSuspend, PowerOperationReset, PowerOperationReboot:
+		apiEndpoint := urlParseRequestURI(vm.VM.HREF)
+		apiEndpoint.Path += "/power/action/" + string(operation)
+		return vm.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost,
+			"", "error running power operation on VM: %s", nil)
+	}
+	return Task{}, fmt.Errorf("unsupported power operation '%s'", operation)
+}
+
+// powerOperationEntityType returns the MIME type of the entity in reference, using its HREF when the type is not set
+func powerOperationEntityType(reference types.Reference) string {
+	if reference.Type != "" {
+		return reference.Type
+	}
+	switch {
+	case strings.Contains(reference.HREF, "/vApp/vapp-"):
+		return types.MimeVApp
+	case strings.Contains(reference.HREF, "/vApp/vm-"):
+		return types.MimeVM
+	}
+	return ""
+}
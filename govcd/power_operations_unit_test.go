@@ -0,0 +1,89 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_PowerOperate(t *testing.T) {
+	var mutex sync.Mutex
+	var actions []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if strings.Contains(r.URL.Path, "-fail") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `<Error xmlns="http://www.vmware.com/vcloud/v1.5" majorErrorCode="400" message="invalid state"/>`)
+				return
+			}
+			mutex.Lock()
+			actions = append(actions, strings.TrimPrefix(r.URL.Path, "/api/vApp/"))
+			mutex.Unlock()
+			w.WriteHeader(http.StatusAccepted)
+		}
+		w.Header().Set("Content-Type", types.MimeTask)
+		_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="success" href="https://%s/api/task/1"></Task>`, r.Host)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+
+	references := []types.Reference{
+		{HREF: server.URL + "/api/vApp/vapp-1"},
+		{HREF: server.URL + "/api/vApp/vm-1", Type: types.MimeVM},
+		{HREF: server.URL + "/api/vApp/vm-2"},
+		{HREF: server.URL + "/api/vApp/vm-fail"},
+		{HREF: server.URL + "/api/media/1"},
+		{},
+	}
+
+	for _, operation := range []PowerOperation{PowerOperationShutdown, PowerOperationSuspend} {
+		actions = nil
+		results := vcdClient.PowerOperate(context.Background(), references, operation, 2)
+		if len(results) != len(references) {
+			t.Fatalf("expected %d results, got %d", len(references), len(results))
+		}
+		for index, result := range results {
+			if result.Reference != references[index] {
+				t.Errorf("%s: result %d has reference %v", operation, index, result.Reference)
+			}
+			expectError := index >= 3
+			if (result.Error != nil) != expectError {
+				t.Errorf("%s: result %d (%s): expected error: %t, got %v", operation, index, result.Reference.HREF, expectError, result.Error)
+			}
+		}
+
+		// A VM is shut down by an undeploy action
+		expected := []string{"vapp-1/power/action/shutdown", "vm-1/action/undeploy", "vm-2/action/undeploy"}
+		if operation == PowerOperationSuspend {
+			expected = []string{"vapp-1/power/action/suspend", "vm-1/power/action/suspend", "vm-2/power/action/suspend"}
+		}
+		sort.Strings(actions)
+		if strings.Join(actions, ",") != strings.Join(expected, ",") {
+			t.Errorf("%s: expected actions %v, got %v", operation, expected, actions)
+		}
+	}
+
+	results := vcdClient.PowerOperate(context.Background(), references[:1], PowerOperation("hibernate"), 0)
+	if results[0].Error == nil {
+		t.Errorf("expected error for an unsupported power operation")
+	}
+}
@@ -25,12 +25,12 @@ func setProductSectionList(ctx context.Context, client *Client, href string, pro
 		types.MimeProductSection, "error setting product section: %s", productSection)
 
 	if err != nil {
-		return fmt.Errorf("unable to set product section: %s", err)
+		return fmt.Errorf("unable to set product section: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("task for setting product section failed: %s", err)
+		return fmt.Errorf("task for setting product section failed: %w", err)
 	}
 
 	return nil
@@ -47,7 +47,7 @@ func getProductSectionList(ctx context.Context, client *Client, href string) (*t
 		types.MimeProductSection, "error retrieving product section : %s", nil, productSection)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve product section: %s", err)
+		return nil, fmt.Errorf("unable to retrieve product section: %w", err)
 	}
 
 	return productSection, nil
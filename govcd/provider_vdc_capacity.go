@@ -0,0 +1,107 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// ProviderVdcResourceCapacity is the capacity of a resource (CPU or memory) of a Provider VDC
+type ProviderVdcResourceCapacity struct {
+	Units     string // MHz for CPU, MB for memory
+	Total     int64  // Capacity of the resource pools backing the Provider VDC
+	Allocated int64  // Capacity allocated to the Org VDCs
+	Reserved  int64  // Capacity reserved by the Org VDCs
+	Used      int64  // Capacity used by the VMs
+	Overhead  int64  // Capacity used by the virtualization overhead of the VMs
+}
+
+// ProviderVdcComputeCapacity is a sample of the compute capacity of a Provider VDC
+type ProviderVdcComputeCapacity struct {
+	ProviderVdcName string
+	ProviderVdcId   string
+	Time            time.Time // When the sample was taken
+	Cpu             ProviderVdcResourceCapacity
+	Memory          ProviderVdcResourceCapacity
+	IsElastic       bool
+	IsHA            bool
+}
+
+// ComputeCapacitySink receives the samples of ProviderVdc.PollComputeCapacity. When a sample can't be retrieved,
+// it receives a nil sample and the error. Returning an error stops the polling
+type ComputeCapacitySink func(sample *ProviderVdcComputeCapacity, err error) error
+
+// GetComputeCapacity retrieves the current CPU and memory capacity of the Provider VDC, refreshing it
+func (providerVdc *ProviderVdc) GetComputeCapacity(ctx context.Context) (*ProviderVdcComputeCapacity, error) {
+	err := providerVdc.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newProviderVdcComputeCapacity(providerVdc.ProviderVdc, time.Now())
+}
+
+// PollComputeCapacity samples the compute capacity of the Provider VDC every interval, starting immediately, and
+// passes the samples to sink. It blocks until the context is done, returning its error, or until sink returns an
+// error, which is returned. Errors retrieving a sample don't stop the polling, unless sink returns them
+func (providerVdc *ProviderVdc) PollComputeCapacity(ctx context.Context, interval time.Duration, sink ComputeCapacitySink) error {
+	if interval <= 0 {
+		return fmt.Errorf("polling interval must be positive, got %s", interval)
+	}
+	if sink == nil {
+		return fmt.Errorf("compute capacity sink is nil")
+	}
+
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		sample, err := providerVdc.GetComputeCapacity(ctx)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err = sink(sample, err)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick.C:
+		}
+	}
+}
+
+// newProviderVdcComputeCapacity converts the compute capacity of a Provider VDC into a sample
+func newProviderVdcComputeCapacity(providerVdc *types.ProviderVdc, sampleTime time.Time) (*ProviderVdcComputeCapacity, error) {
+	computeCapacity := providerVdc.ComputeCapacity
+	if computeCapacity == nil || computeCapacity.Cpu == nil || computeCapacity.Memory == nil {
+		return nil, fmt.Errorf("compute capacity of Provider VDC '%s' is not available", providerVdc.Name)
+	}
+	return &ProviderVdcComputeCapacity{
+		ProviderVdcName: providerVdc.Name,
+		ProviderVdcId:   providerVdc.ID,
+		Time:            sampleTime,
+		Cpu:             newProviderVdcResourceCapacity(computeCapacity.Cpu),
+		Memory:          newProviderVdcResourceCapacity(computeCapacity.Memory),
+		IsElastic:       computeCapacity.IsElastic,
+		IsHA:            computeCapacity.IsHA,
+	}, nil
+}
+
+// newProviderVdcResourceCapacity converts the capacity of a resource of a Provider VDC
+func newProviderVdcResourceCapacity(capacity *types.ProviderVdcCapacity) ProviderVdcResourceCapacity {
+	return ProviderVdcResourceCapacity{
+		Units:     capacity.Units,
+		Total:     capacity.Total,
+		Allocated: capacity.Allocation,
+		Reserved:  capacity.Reserved,
+		Used:      capacity.Used,
+		Overhead:  capacity.Overhead,
+	}
+}
@@ -0,0 +1,100 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_PollComputeCapacity(t *testing.T) {
+	// Used memory grows by 1024 MB at each request. The third request fails
+	var requests int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&requests, 1)
+		if count == 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.vmware.admin.providervdc+xml")
+		_, _ = fmt.Fprintf(w, `<ProviderVdc xmlns="http://www.vmware.com/vcloud/v1.5" name="pvdc1" id="urn:vcloud:providervdc:1" href="https://%s%s">
+  <ComputeCapacity>
+    <Cpu><Units>MHz</Units><Allocation>10000</Allocation><Overhead>100</Overhead><Reserved>5000</Reserved><Total>40000</Total><Used>2000</Used></Cpu>
+    <Memory><Units>MB</Units><Allocation>65536</Allocation><Overhead>512</Overhead><Reserved>16384</Reserved><Total>131072</Total><Used>%d</Used></Memory>
+    <IsElastic>false</IsElastic>
+    <IsHA>true</IsHA>
+  </ComputeCapacity>
+</ProviderVdc>`, r.Host, r.URL.Path, int(count)*1024)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	providerVdc := newProviderVdc(&NewVCDClient(*serverUrl, true).Client)
+	providerVdc.ProviderVdc.HREF = server.URL + "/api/admin/providervdc/1"
+
+	capacity, err := providerVdc.GetComputeCapacity(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedCpu := ProviderVdcResourceCapacity{Units: "MHz", Total: 40000, Allocated: 10000, Reserved: 5000, Used: 2000, Overhead: 100}
+	if capacity.Cpu != expectedCpu || capacity.Memory.Used != 1024 || !capacity.IsHA || capacity.ProviderVdcName != "pvdc1" {
+		t.Errorf("unexpected compute capacity %+v", *capacity)
+	}
+
+	// The sink stops the polling after 3 more samples, one of which is an error
+	var samples []*ProviderVdcComputeCapacity
+	var sampleErrors int
+	errStop := errors.New("stop")
+	err = providerVdc.PollComputeCapacity(context.Background(), time.Millisecond,
+		func(sample *ProviderVdcComputeCapacity, err error) error {
+			if err != nil {
+				sampleErrors++
+			} else {
+				samples = append(samples, sample)
+			}
+			if len(samples)+sampleErrors == 3 {
+				return errStop
+			}
+			return nil
+		})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected the error of the sink, got %v", err)
+	}
+	if sampleErrors != 1 || len(samples) != 2 || samples[0].Memory.Used != 2048 || samples[1].Memory.Used != 4096 {
+		t.Errorf("unexpected samples: %d errors, %d samples", sampleErrors, len(samples))
+	}
+	if !samples[0].Time.Before(samples[1].Time) {
+		t.Errorf("expected increasing sample times")
+	}
+
+	// The context stops the polling
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = providerVdc.PollComputeCapacity(ctx, time.Millisecond, func(*ProviderVdcComputeCapacity, error) error {
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	_, err = newProviderVdcComputeCapacity(&types.ProviderVdc{Name: "empty"}, time.Now())
+	if err == nil {
+		t.Errorf("expected an error for a Provider VDC without compute capacity")
+	}
+}
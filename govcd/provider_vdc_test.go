@@ -3,9 +3,11 @@
 package govcd
 
 import (
+	"context"
 	"fmt"
 	. "gopkg.in/check.v1"
 	"strings"
+	"time"
 )
 
 func init() {
@@ -157,3 +159,32 @@ func (vcd *TestVCD) Test_GetProviderVdcConvertFromExtendedToNormal(check *C) {
 	check.Assert(providerVdc.ProviderVdc.NetworkPoolReferences.NetworkPoolReference[0].Name, Equals, vcd.config.VCD.NsxtProviderVdc.NetworkPool)
 	check.Assert(providerVdc.ProviderVdc.Link, NotNil)
 }
+
+func (vcd *TestVCD) Test_ProviderVdcComputeCapacity(check *C) {
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+
+	providerVdc, err := vcd.client.GetProviderVdcByName(ctx, vcd.config.VCD.NsxtProviderVdc.Name)
+	check.Assert(err, IsNil)
+
+	capacity, err := providerVdc.GetComputeCapacity(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(capacity.ProviderVdcName, Equals, vcd.config.VCD.NsxtProviderVdc.Name)
+	check.Assert(capacity.Cpu.Units, Not(Equals), "")
+	check.Assert(capacity.Memory.Units, Not(Equals), "")
+	check.Assert(capacity.Memory.Total >= capacity.Memory.Used, Equals, true)
+
+	pollCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var samples []*ProviderVdcComputeCapacity
+	err = providerVdc.PollComputeCapacity(pollCtx, time.Second, func(sample *ProviderVdcComputeCapacity, err error) error {
+		if err != nil {
+			return err
+		}
+		samples = append(samples, sample)
+		return nil
+	})
+	check.Assert(err, Equals, context.DeadlineExceeded)
+	check.Assert(len(samples) > 1, Equals, true)
+}
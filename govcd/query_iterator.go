@@ -0,0 +1,201 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// defaultQueryIteratorPageSize is the page size used by QueryPaged when the caller does not set 'pageSize'
+const defaultQueryIteratorPageSize = "128"
+
+// QueryIterator reads the records of a query one page at a time, so that only one page is kept in memory and the
+// iteration can stop at any point without retrieving the remaining pages.
+//
+// Usage:
+//
+//	iterator, err := QueryPaged[types.QueryResultVMRecordType](ctx, client, types.QtVm, nil)
+//	...
+//	for iterator.Next() {
+//		vm := iterator.Record()
+//		...
+//	}
+//	if iterator.Err() != nil { ... }
+type QueryIterator[T any] struct {
+	ctx       context.Context
+	client    *Client
+	queryType string
+	params    map[string]string
+	page      int
+	records   []*T
+	index     int
+	total     int
+	retrieved int
+	done      bool
+	err       error
+}
+
+// QueryPaged returns an iterator over the records of a query service query type. T is the record type of the query
+// type (e.g. types.QueryResultVMRecordType for types.QtVm) and a mismatch is reported as an error.
+// filter holds additional query parameters, which are not encoded, such as
+// {"filter": "name==vm1", "filterEncoded": "true", "sortAsc": "name"}. The page size can be set with 'pageSize', and
+// defaults to 128.
+//
+// Each page is retrieved when the records of the previous one have been consumed, so records created or deleted
+// during the iteration may be missed or seen twice, unless a sort order is given.
+// QueryPaged is a function rather than a method of Client, because Go methods can't have type parameters.
+// The context is used for all the page requests of the iterator.
+func QueryPaged[T any](ctx context.Context, client *Client, queryType string, filter map[string]string) (*QueryIterator[T], error) {
+	_, err := queryRecordsOf[T](queryType, &types.QueryResultRecordsType{})
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{
+		"pageSize": defaultQueryIteratorPageSize,
+	}
+	for key, value := range filter {
+		params[key] = value
+	}
+	params["type"] = queryType
+
+	return &QueryIterator[T]{
+		ctx:       ctx,
+		client:    client,
+		queryType: queryType,
+		params:    params,
+		index:     -1,
+	}, nil
+}
+
+// Next moves to the next record, retrieving the next page when needed. It returns false when there are no more
+// records or an error occurred, which is returned by Err
+func (iterator *QueryIterator[T]) Next() bool {
+	if iterator.err != nil {
+		return false
+	}
+	iterator.index++
+	if iterator.index < len(iterator.records) {
+		return true
+	}
+	if iterator.done {
+		return false
+	}
+
+	iterator.err = iterator.nextPage()
+	if iterator.err != nil {
+		return false
+	}
+	iterator.index = 0
+	return len(iterator.records) > 0
+}
+
+// Record returns the current record. It must be called after Next returned true
+func (iterator *QueryIterator[T]) Record() *T {
+	if iterator.index < 0 || iterator.index >= len(iterator.records) {
+		return nil
+	}
+	return iterator.records[iterator.index]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (iterator *QueryIterator[T]) Err() error {
+	return iterator.err
+}
+
+// Total returns the total number of records reported by VCD, which is known after the first call to Next
+func (iterator *QueryIterator[T]) Total() int {
+	return iterator.total
+}
+
+// nextPage replaces the records with the ones of the next page
+func (iterator *QueryIterator[T]) nextPage() error {
+	err := iterator.ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	iterator.page++
+	iterator.params["page"] = strconv.Itoa(iterator.page)
+	results, err := iterator.client.QueryWithNotEncodedParams(iterator.ctx, nil, iterator.params)
+	if err != nil {
+		return fmt.Errorf("error retrieving page %d of query '%s': %w", iterator.page, iterator.queryType, err)
+	}
+	records, err := queryRecordsOf[T](iterator.queryType, results.Results)
+	if err != nil {
+		return err
+	}
+
+	iterator.records = records
+	iterator.total = int(results.Results.Total)
+	iterator.retrieved += len(records)
+	if len(records) == 0 || iterator.retrieved >= iterator.total {
+		iterator.done = true
+	}
+	return nil
+}
+
+// queryRecordsOf returns the records of the query type from the results, checking that they are of type T
+func queryRecordsOf[T any](queryType string, results *types.QueryResultRecordsType) ([]*T, error) {
+	var records interface{}
+	switch queryType {
+	case types.QtVappTemplate:
+		records = results.VappTemplateRecord
+	case types.QtAdminVappTemplate:
+		records = results.AdminVappTemplateRecord
+	case types.QtEdgeGateway:
+		records = results.EdgeGatewayRecord
+	case types.QtOrgVdcNetwork:
+		records = results.OrgVdcNetworkRecord
+	case types.QtCatalog:
+		records = results.CatalogRecord
+	case types.QtAdminCatalog:
+		records = results.AdminCatalogRecord
+	case types.QtMedia:
+		records = results.MediaRecord
+	case types.QtAdminMedia:
+		records = results.AdminMediaRecord
+	case types.QtCatalogItem:
+		records = results.CatalogItemRecord
+	case types.QtAdminCatalogItem:
+		records = results.AdminCatalogItemRecord
+	case types.QtVm:
+		records = results.VMRecord
+	case types.QtAdminVm:
+		records = results.AdminVMRecord
+	case types.QtVapp:
+		records = results.VAppRecord
+	case types.QtAdminVapp:
+		records = results.AdminVAppRecord
+	case types.QtOrgVdc:
+		records = results.OrgVdcRecord
+	case types.QtAdminOrgVdc:
+		records = results.OrgVdcAdminRecord
+	case types.QtTask:
+		records = results.TaskRecord
+	case types.QtAdminTask:
+		records = results.AdminTaskRecord
+	case types.QtDisk:
+		records = results.DiskRecord
+	case types.QtAdminDisk:
+		records = results.AdminDiskRecord
+	case types.QtOrgVdcStorageProfile:
+		records = results.OrgVdcStorageProfileRecord
+	case types.QtAdminOrgVdcStorageProfile:
+		records = results.AdminOrgVdcStorageProfileRecord
+	default:
+		return nil, fmt.Errorf("query type %s not supported", queryType)
+	}
+
+	typedRecords, ok := records.([]*T)
+	if !ok {
+		return nil, fmt.Errorf("query type %s returns records of type %T, not %T", queryType, records, typedRecords)
+	}
+	return typedRecords, nil
+}
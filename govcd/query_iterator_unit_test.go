@@ -0,0 +1,98 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_QueryPaged(t *testing.T) {
+	const total = 5
+	var requestedPages []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requestedPages = append(requestedPages, query.Get("page"))
+		page, _ := strconv.Atoi(query.Get("page"))
+		pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+
+		w.Header().Set("Content-Type", types.MimeQueryRecords)
+		_, _ = fmt.Fprintf(w, `<QueryResultRecords xmlns="http://www.vmware.com/vcloud/v1.5" total="%d" pageSize="%d" page="%d">`,
+			total, pageSize, page)
+		for index := (page - 1) * pageSize; index < page*pageSize && index < total; index++ {
+			_, _ = fmt.Fprintf(w, `<VMRecord name="vm%d" href="https://%s/api/vApp/vm-%d"/>`, index, r.Host, index)
+		}
+		_, _ = fmt.Fprint(w, `</QueryResultRecords>`)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+	ctx := context.Background()
+
+	iterator, err := QueryPaged[types.QueryResultVMRecordType](ctx, client, types.QtVm, map[string]string{"pageSize": "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var names []string
+	for iterator.Next() {
+		names = append(names, iterator.Record().Name)
+	}
+	if iterator.Err() != nil {
+		t.Fatalf("unexpected error: %s", iterator.Err())
+	}
+	if fmt.Sprint(names) != "[vm0 vm1 vm2 vm3 vm4]" || iterator.Total() != total {
+		t.Errorf("unexpected records %v of %d", names, iterator.Total())
+	}
+	if fmt.Sprint(requestedPages) != "[1 2 3]" {
+		t.Errorf("expected pages 1 to 3 to be requested, got %v", requestedPages)
+	}
+
+	// Stopping early does not retrieve the remaining pages
+	requestedPages = nil
+	iterator, err = QueryPaged[types.QueryResultVMRecordType](ctx, client, types.QtVm, map[string]string{"pageSize": "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !iterator.Next() || iterator.Record().Name != "vm0" {
+		t.Fatalf("expected the first record to be vm0")
+	}
+	if fmt.Sprint(requestedPages) != "[1]" {
+		t.Errorf("expected only page 1 to be requested, got %v", requestedPages)
+	}
+
+	// A cancelled context stops the iteration before the next page
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	iterator, err = QueryPaged[types.QueryResultVMRecordType](cancelledCtx, client, types.QtVm, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cancel()
+	if iterator.Next() || iterator.Err() == nil {
+		t.Errorf("expected the iteration to stop with an error")
+	}
+
+	// The record type must match the query type
+	_, err = QueryPaged[types.QueryResultVAppRecordType](ctx, client, types.QtVm, nil)
+	if err == nil {
+		t.Errorf("expected an error for a mismatched record type")
+	}
+	_, err = QueryPaged[types.QueryResultVMRecordType](ctx, client, "unknown", nil)
+	if err == nil {
+		t.Errorf("expected an error for an unsupported query type")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -167,6 +168,18 @@ func addResults(queryType string, cumulativeResults, newResults Results) (Result
 	case types.QtAdminTask:
 		cumulativeResults.Results.AdminTaskRecord = append(cumulativeResults.Results.AdminTaskRecord, newResults.Results.AdminTaskRecord...)
 		size = len(newResults.Results.AdminTaskRecord)
+	case types.QtDisk:
+		cumulativeResults.Results.DiskRecord = append(cumulativeResults.Results.DiskRecord, newResults.Results.DiskRecord...)
+		size = len(newResults.Results.DiskRecord)
+	case types.QtAdminDisk:
+		cumulativeResults.Results.AdminDiskRecord = append(cumulativeResults.Results.AdminDiskRecord, newResults.Results.AdminDiskRecord...)
+		size = len(newResults.Results.AdminDiskRecord)
+	case types.QtOrgVdcStorageProfile:
+		cumulativeResults.Results.OrgVdcStorageProfileRecord = append(cumulativeResults.Results.OrgVdcStorageProfileRecord, newResults.Results.OrgVdcStorageProfileRecord...)
+		size = len(newResults.Results.OrgVdcStorageProfileRecord)
+	case types.QtAdminOrgVdcStorageProfile:
+		cumulativeResults.Results.AdminOrgVdcStorageProfileRecord = append(cumulativeResults.Results.AdminOrgVdcStorageProfileRecord, newResults.Results.AdminOrgVdcStorageProfileRecord...)
+		size = len(newResults.Results.AdminOrgVdcStorageProfileRecord)
 
 	default:
 		return Results{}, 0, fmt.Errorf("query type %s not supported", queryType)
@@ -201,6 +214,10 @@ func (client *Client) cumulativeQueryWithHeaders(ctx context.Context, queryType
 		types.QtAdminOrgVdc,
 		types.QtTask,
 		types.QtAdminTask,
+		types.QtDisk,
+		types.QtAdminDisk,
+		types.QtOrgVdcStorageProfile,
+		types.QtAdminOrgVdcStorageProfile,
 	}
 	// Make sure the query type is supported
 	// We need to check early, as queries that would return less than 25 items (default page size) would succeed,
@@ -273,25 +290,13 @@ func (client *Client) queryWithMetadataFields(ctx context.Context, queryType str
 
 	fields, err := queryFieldsOnDemand(queryType)
 	if err != nil {
-		return Results{}, fmt.Errorf("[queryWithMetadataFields] %s", err)
+		return Results{}, fmt.Errorf("[queryWithMetadataFields] %w", err)
 	}
 
 	if len(fields) == 0 {
 		return Results{}, fmt.Errorf("[queryWithMetadataFields] no fields found for type '%s'", queryType)
 	}
-	metadataFieldText := ""
-	prefix := "metadata"
-	if isSystem {
-		prefix = "metadata@SYSTEM"
-	}
-	for i, field := range metadataFields {
-		metadataFieldText += fmt.Sprintf("%s:%s", prefix, field)
-		if i != len(metadataFields) {
-			metadataFieldText += ","
-		}
-	}
-
-	notEncodedParams["fields"] = strings.Join(fields, ",") + "," + metadataFieldText
+	notEncodedParams["fields"] = strings.Join(fields, ",") + "," + metadataFieldsText(metadataFields, isSystem)
 
 	return client.cumulativeQuery(ctx, queryType, params, notEncodedParams)
 }
@@ -315,27 +320,91 @@ func (client *Client) queryByMetadataFilter(ctx context.Context, queryType strin
 	}
 	notEncodedParams["type"] = queryType
 
-	metadataFilterText := ""
-	prefix := "metadata"
-	if isSystem {
-		prefix = "metadata@SYSTEM"
+	addMetadataFilterText(notEncodedParams, metadataFilters, isSystem)
+
+	return client.cumulativeQuery(ctx, queryType, params, notEncodedParams)
+}
+
+// QueryMetadataOptions defines the metadata that a query returns and filters on, server side
+type QueryMetadataOptions struct {
+	// Fields are the metadata keys whose values are returned in the Metadata of each record
+	Fields []string
+	// Filters are the conditions on metadata values that each record must satisfy, indexed by metadata key
+	Filters map[string]MetadataFilter
+	// IsSystem selects the SYSTEM domain, i.e. keys are requested as 'metadata@SYSTEM:key'
+	IsSystem bool
+}
+
+// queryWithMetadataOptions runs a cumulative query that returns the metadata fields and applies the metadata
+// filters defined in options, in a single request per page.
+// Unlike using both queryWithMetadataFields and queryByMetadataFilter, the records are returned already filtered
+// and with their metadata, without further calls for each entity.
+//
+// * queryType is the type of the query. Metadata fields are only supported for the ones listed in queryFieldsOnDemand
+// * notEncodedParams are the same ones passed to QueryWithNotEncodedParams. An existing filter is combined with
+// the metadata filters
+func (client *Client) queryWithMetadataOptions(ctx context.Context, queryType string, notEncodedParams map[string]string,
+	options QueryMetadataOptions) (Results, error) {
+	if notEncodedParams == nil {
+		notEncodedParams = make(map[string]string)
 	}
-	count := 0
-	for key, value := range metadataFilters {
-		metadataFilterText += fmt.Sprintf("%s:%s==%s:%s", prefix, key, value.Type, url.QueryEscape(value.Value))
-		if count < len(metadataFilters)-1 {
-			metadataFilterText += ";"
+	notEncodedParams["type"] = queryType
+
+	if len(options.Fields) > 0 {
+		fields, err := queryFieldsOnDemand(queryType)
+		if err != nil {
+			return Results{}, fmt.Errorf("[queryWithMetadataOptions] %w", err)
 		}
-		count++
+		notEncodedParams["fields"] = strings.Join(fields, ",") + "," + metadataFieldsText(options.Fields, options.IsSystem)
+	}
+	if len(options.Filters) > 0 {
+		addMetadataFilterText(notEncodedParams, options.Filters, options.IsSystem)
+	}
+
+	return client.cumulativeQuery(ctx, queryType, nil, notEncodedParams)
+}
+
+// metadataQueryPrefix returns the prefix of metadata keys used in query fields and filters
+func metadataQueryPrefix(isSystem bool) string {
+	if isSystem {
+		return "metadata@SYSTEM"
+	}
+	return "metadata"
+}
+
+// metadataFieldsText returns the metadata keys as a list of query fields, such as 'metadata:key1,metadata:key2'
+func metadataFieldsText(metadataFields []string, isSystem bool) string {
+	prefix := metadataQueryPrefix(isSystem)
+	fields := make([]string, len(metadataFields))
+	for i, field := range metadataFields {
+		fields[i] = fmt.Sprintf("%s:%s", prefix, field)
+	}
+	return strings.Join(fields, ",")
+}
+
+// addMetadataFilterText adds the metadata filters to the 'filter' parameter of notEncodedParams, such as
+// 'metadata:key1==STRING:value1;metadata:key2==NUMBER:2'. An existing filter is combined with the metadata ones.
+// The conditions are sorted by key, so that the same filters always produce the same query
+func addMetadataFilterText(notEncodedParams map[string]string, metadataFilters map[string]MetadataFilter, isSystem bool) {
+	prefix := metadataQueryPrefix(isSystem)
+	keys := make([]string, 0, len(metadataFilters))
+	for key := range metadataFilters {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+
+	conditions := make([]string, len(keys))
+	for i, key := range keys {
+		value := metadataFilters[key]
+		conditions[i] = fmt.Sprintf("%s:%s==%s:%s", prefix, key, value.Type, url.QueryEscape(value.Value))
+	}
+	metadataFilterText := strings.Join(conditions, ";")
 
 	filter, ok := notEncodedParams["filter"]
-	if ok {
+	if ok && filter != "" {
 		filter = "(" + filter + ";" + metadataFilterText + ")"
 	} else {
 		filter = metadataFilterText
 	}
 	notEncodedParams["filter"] = filter
-
-	return client.cumulativeQuery(ctx, queryType, params, notEncodedParams)
 }
@@ -0,0 +1,121 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_addMetadataFilterText(t *testing.T) {
+	type testCase struct {
+		name     string
+		filter   string
+		filters  map[string]MetadataFilter
+		isSystem bool
+		expected string
+	}
+	testCases := []testCase{
+		{
+			name:     "single",
+			filters:  map[string]MetadataFilter{"env": {Type: "STRING", Value: "prod"}},
+			expected: "metadata:env==STRING:prod",
+		},
+		{
+			name: "sorted",
+			filters: map[string]MetadataFilter{
+				"tier": {Type: "NUMBER", Value: "2"},
+				"env":  {Type: "STRING", Value: "my prod"},
+			},
+			expected: "metadata:env==STRING:my+prod;metadata:tier==NUMBER:2",
+		},
+		{
+			name:     "system",
+			filters:  map[string]MetadataFilter{"env": {Type: "STRING", Value: "prod"}},
+			isSystem: true,
+			expected: "metadata@SYSTEM:env==STRING:prod",
+		},
+		{
+			name:     "combined",
+			filter:   "name==test",
+			filters:  map[string]MetadataFilter{"env": {Type: "STRING", Value: "prod"}},
+			expected: "(name==test;metadata:env==STRING:prod)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := map[string]string{}
+			if tc.filter != "" {
+				params["filter"] = tc.filter
+			}
+			addMetadataFilterText(params, tc.filters, tc.isSystem)
+			if params["filter"] != tc.expected {
+				t.Errorf("expected filter '%s', got '%s'", tc.expected, params["filter"])
+			}
+		})
+	}
+
+	fields := metadataFieldsText([]string{"env", "tier"}, true)
+	if fields != "metadata@SYSTEM:env,metadata@SYSTEM:tier" {
+		t.Errorf("unexpected metadata fields '%s'", fields)
+	}
+}
+
+func Test_queryMediaListWithMetadata(t *testing.T) {
+	var requestedQuery string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The filter is not encoded and contains ';', which url.Query() would discard
+		requestedQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", types.MimeQueryRecords)
+		_, _ = fmt.Fprintf(w, `<QueryResultRecords xmlns="http://www.vmware.com/vcloud/v1.5" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" total="1" pageSize="25" page="1">
+	<MediaRecord name="media1" href="https://%s/api/media/media-1">
+		<Metadata>
+			<MetadataEntry>
+				<Key>env</Key>
+				<TypedValue xsi:type="MetadataStringValue"><Value>prod</Value></TypedValue>
+			</MetadataEntry>
+		</Metadata>
+	</MediaRecord>
+</QueryResultRecords>`, r.Host)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+
+	mediaList, err := queryMediaListWithMetadata(context.Background(), client, "vdc==https://vdc", QueryMetadataOptions{
+		Fields:  []string{"env"},
+		Filters: map[string]MetadataFilter{"env": {Type: "STRING", Value: "prod"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(requestedQuery, "filter=(vdc==https://vdc;metadata:env==STRING:prod)") {
+		t.Errorf("unexpected filter in '%s'", requestedQuery)
+	}
+	if !strings.Contains(requestedQuery, ",metadata:env") || strings.Contains(requestedQuery, "metadata:env,&") {
+		t.Errorf("unexpected fields in '%s'", requestedQuery)
+	}
+	if len(mediaList) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(mediaList))
+	}
+	metadata := mediaList[0].Metadata
+	if metadata == nil || len(metadata.MetadataEntry) != 1 || metadata.MetadataEntry[0].Key != "env" ||
+		metadata.MetadataEntry[0].TypedValue.Value != "prod" {
+		t.Errorf("expected metadata to be populated, got %+v", metadata)
+	}
+}
@@ -8,6 +8,7 @@
 package govcd
 
 import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	. "gopkg.in/check.v1"
 )
 
@@ -17,3 +18,48 @@ func (vcd *TestVCD) Test_Query(check *C) {
 	_, err := vcd.client.Query(ctx, map[string]string{"type": "vm"})
 	check.Assert(err, IsNil)
 }
+
+func (vcd *TestVCD) Test_Search(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vApp was not successfully created at setup")
+	}
+	vappName := vcd.vapp.VApp.Name
+
+	results, err := vcd.client.Search(ctx, vappName, []EntityKind{EntityKindVapp})
+	check.Assert(err, IsNil)
+	check.Assert(len(results) > 0, Equals, true)
+	check.Assert(results[0].Kind, Equals, EntityKindVapp)
+	check.Assert(results[0].Name, Equals, vappName)
+	check.Assert(results[0].Score, Equals, SearchScoreExact)
+
+	// A partial term finds the same vApp, with a lower score
+	results, err = vcd.client.Search(ctx, vappName[1:], []EntityKind{EntityKindVapp})
+	check.Assert(err, IsNil)
+	found := false
+	for _, result := range results {
+		if result.Href == vcd.vapp.VApp.HREF {
+			found = true
+			check.Assert(result.Score < SearchScoreExact, Equals, true)
+		}
+	}
+	check.Assert(found, Equals, true)
+}
+
+func (vcd *TestVCD) Test_QueryPaged(check *C) {
+	queryType := vcd.client.Client.GetQueryType(types.QtVm)
+	allVms, err := vcd.client.Client.QueryVmList(ctx, types.VmQueryFilterAll)
+	check.Assert(err, IsNil)
+
+	iterator, err := QueryPaged[types.QueryResultVMRecordType](ctx, &vcd.client.Client, queryType,
+		map[string]string{"pageSize": "5", "sortAsc": "name"})
+	check.Assert(err, IsNil)
+	seen := make(map[string]bool)
+	for iterator.Next() {
+		vm := iterator.Record()
+		check.Assert(vm, NotNil)
+		seen[vm.HREF] = true
+	}
+	check.Assert(iterator.Err(), IsNil)
+	check.Assert(iterator.Total(), Equals, len(allVms))
+	check.Assert(len(seen), Equals, len(allVms))
+}
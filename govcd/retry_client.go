@@ -0,0 +1,246 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// Defaults of RetryPolicy
+const (
+	defaultRetryMaxAttempts     = 4
+	defaultRetryInitialInterval = time.Second
+	defaultRetryMaxInterval     = 30 * time.Second
+	defaultRetryMultiplier      = 2.0
+	defaultRetryMaxRetryAfter   = 2 * time.Minute
+)
+
+// RetryPolicy configures the automatic retries of the requests of a client, set with WithRetryPolicy. Zero values
+// select the defaults
+type RetryPolicy struct {
+	MaxAttempts     int           // Maximum number of attempts of a request, including the first one. Default: 4
+	InitialInterval time.Duration // Delay before the first retry. Default: 1 second
+	MaxInterval     time.Duration // Maximum delay between two attempts. Default: 30 seconds
+	Multiplier      float64       // Growth of the delay after each retry (1 for a constant delay). Default: 2
+	// MaxRetryAfter is the longest delay requested by a Retry-After header that is honored. A request asking to
+	// wait longer is not retried. Default: 2 minutes
+	MaxRetryAfter time.Duration
+	// RetryAllMethods also retries POST and PATCH requests on transient errors. As these requests are not
+	// idempotent, VCD may run the operation more than once. Requests rejected with HTTP 429 are always retried
+	RetryAllMethods bool
+	// ShouldRetry decides whether the outcome of an attempt is a transient error. It receives either the response or
+	// the transport error of the attempt. Default: transport errors other than certificate errors, and HTTP 429, 502,
+	// 503 and 504 responses
+	ShouldRetry func(resp *http.Response, err error) bool
+	// OnRetry is called before waiting for each retry, e.g. to log it
+	OnRetry func(event RetryEvent)
+}
+
+// RetryEvent describes a failed attempt of a request that is going to be retried
+type RetryEvent struct {
+	Request  *http.Request
+	Attempt  int            // Number of the failed attempt, starting from 1
+	Response *http.Response // Response of the failed attempt, nil when Error is set. Its body is already closed
+	Error    error          // Transport error of the failed attempt
+	Delay    time.Duration  // Time to wait before the next attempt
+}
+
+// WithRetryPolicy retries the requests of the client that fail with a transient error, such as a connection reset
+// or an HTTP 503 response, waiting with an exponential backoff between the attempts. When VCD answers HTTP 429 or
+// 503 with a Retry-After header, the requested delay is used instead.
+//
+// Only idempotent requests (GET, HEAD, OPTIONS, PUT and DELETE) are retried on transient errors, unless
+// policy.RetryAllMethods is set. Requests with a body that cannot be sent again are never retried.
+// Waiting stops when the context of the request is done.
+func WithRetryPolicy(policy RetryPolicy) VCDClientOption {
+	return func(vcdClient *VCDClient) error {
+		if _, ok := findRoundTripper[*retryRoundTripper](vcdClient.Client.Http.Transport); ok {
+			return fmt.Errorf("retry policy is already configured")
+		}
+		if policy.MaxAttempts < 0 || policy.InitialInterval < 0 || policy.MaxInterval < 0 || policy.MaxRetryAfter < 0 {
+			return fmt.Errorf("retry policy values can't be negative: %+v", policy)
+		}
+		if policy.Multiplier != 0 && policy.Multiplier < 1 {
+			return fmt.Errorf("retry multiplier must be at least 1, got %g", policy.Multiplier)
+		}
+		if policy.MaxAttempts == 0 {
+			policy.MaxAttempts = defaultRetryMaxAttempts
+		}
+		if policy.InitialInterval == 0 {
+			policy.InitialInterval = defaultRetryInitialInterval
+		}
+		if policy.MaxInterval == 0 {
+			policy.MaxInterval = defaultRetryMaxInterval
+		}
+		if policy.MaxInterval < policy.InitialInterval {
+			policy.MaxInterval = policy.InitialInterval
+		}
+		if policy.Multiplier == 0 {
+			policy.Multiplier = defaultRetryMultiplier
+		}
+		if policy.MaxRetryAfter == 0 {
+			policy.MaxRetryAfter = defaultRetryMaxRetryAfter
+		}
+		if policy.ShouldRetry == nil {
+			policy.ShouldRetry = isTransientResponse
+		}
+
+		nextTransport := vcdClient.Client.Http.Transport
+		if nextTransport == nil {
+			nextTransport = http.DefaultTransport
+		}
+		vcdClient.Client.Http.Transport = &retryRoundTripper{
+			next:   nextTransport,
+			policy: policy,
+		}
+		return nil
+	}
+}
+
+// retryRoundTripper is an http.RoundTripper that sends again the requests failing with a transient error
+type retryRoundTripper struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+}
+
+// RoundTrip implements http.RoundTripper
+func (rrt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	interval := rrt.policy.InitialInterval
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := rrt.next.RoundTrip(attemptReq)
+		if attempt >= rrt.policy.MaxAttempts || req.Context().Err() != nil || !rrt.canRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := interval
+		if resp != nil {
+			retryAfter, ok := parseRetryAfter(resp)
+			if ok && retryAfter > rrt.policy.MaxRetryAfter {
+				util.Logger.Printf("[DEBUG] not retrying %s %s: Retry-After %s exceeds %s", req.Method, req.URL,
+					retryAfter, rrt.policy.MaxRetryAfter)
+				return resp, err
+			}
+			if ok {
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		util.Logger.Printf("[WARN] attempt %d of %s %s failed: %s. Retrying in %s", attempt, req.Method, req.URL,
+			failoverReason(resp, err), delay)
+		if rrt.policy.OnRetry != nil {
+			rrt.policy.OnRetry(RetryEvent{Request: req, Attempt: attempt, Response: resp, Error: err, Delay: delay})
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * rrt.policy.Multiplier)
+		if interval > rrt.policy.MaxInterval {
+			interval = rrt.policy.MaxInterval
+		}
+	}
+}
+
+// canRetry returns true when the outcome of an attempt of req is a transient error, and req can be sent again
+func (rrt *retryRoundTripper) canRetry(req *http.Request, resp *http.Response, err error) bool {
+	if !canRetryRequest(req) || !rrt.policy.ShouldRetry(resp, err) {
+		return false
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		// The request was rejected before being processed
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return rrt.policy.RetryAllMethods
+}
+
+// isTransientResponse is the default RetryPolicy.ShouldRetry: transport errors, except certificate errors, and
+// HTTP responses showing that VCD or a proxy in front of it is temporarily unavailable
+func isTransientResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		var unknownAuthorityError x509.UnknownAuthorityError
+		var hostnameError x509.HostnameError
+		var certificateInvalidError x509.CertificateInvalidError
+		return !errors.As(err, &unknownAuthorityError) && !errors.As(err, &hostnameError) &&
+			!errors.As(err, &certificateInvalidError)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter returns the delay requested by the Retry-After header of a HTTP 429 or 503 response, given either
+// as seconds or as a date
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// findRoundTripper looks for the transport of type T in the chain of failover, retry and middleware transports
+// starting at transport
+func findRoundTripper[T http.RoundTripper](transport http.RoundTripper) (T, bool) {
+	for {
+		if found, ok := transport.(T); ok {
+			return found, true
+		}
+		switch wrapper := transport.(type) {
+		case *failoverRoundTripper:
+			transport = wrapper.next
+		case *retryRoundTripper:
+			transport = wrapper.next
+		case *middlewareRoundTripper:
+			transport = wrapper.next
+		default:
+			var notFound T
+			return notFound, false
+		}
+	}
+}
@@ -0,0 +1,266 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedOutcome is the outcome of a request sent to a scriptedRoundTripper: a transport error when err is set,
+// otherwise a response with the given status and headers
+type scriptedOutcome struct {
+	status int
+	header http.Header
+	err    error
+}
+
+// scriptedRoundTripper answers the requests with the scripted outcomes in order, repeating the last one
+type scriptedRoundTripper struct {
+	outcomes []scriptedOutcome
+	bodies   []string // body of each request
+}
+
+func (srt *scriptedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		body = string(data)
+	}
+	srt.bodies = append(srt.bodies, body)
+	outcome := srt.outcomes[len(srt.outcomes)-1]
+	if len(srt.bodies) <= len(srt.outcomes) {
+		outcome = srt.outcomes[len(srt.bodies)-1]
+	}
+	if outcome.err != nil {
+		return nil, outcome.err
+	}
+	header := outcome.header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: outcome.status, Status: http.StatusText(outcome.status), Header: header,
+		Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+}
+
+func Test_WithRetryPolicy(t *testing.T) {
+	retryAfterZero := http.Header{"Retry-After": []string{"0"}}
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		policy       RetryPolicy
+		outcomes     []scriptedOutcome
+		wantStatus   int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "getRetriedUntilSuccess",
+			method:       http.MethodGet,
+			outcomes:     []scriptedOutcome{{status: 503}, {err: fmt.Errorf("connection reset")}, {status: 200}},
+			wantStatus:   200,
+			wantAttempts: 3,
+		},
+		{
+			name:         "getAttemptsExhausted",
+			method:       http.MethodGet,
+			policy:       RetryPolicy{MaxAttempts: 2},
+			outcomes:     []scriptedOutcome{{status: 502}},
+			wantStatus:   502,
+			wantAttempts: 2,
+		},
+		{
+			name:         "clientErrorNotRetried",
+			method:       http.MethodGet,
+			outcomes:     []scriptedOutcome{{status: 404}},
+			wantStatus:   404,
+			wantAttempts: 1,
+		},
+		{
+			name:         "postNotRetried",
+			method:       http.MethodPost,
+			body:         "payload",
+			outcomes:     []scriptedOutcome{{status: 503}, {status: 200}},
+			wantStatus:   503,
+			wantAttempts: 1,
+		},
+		{
+			name:         "postRetriedOnTooManyRequests",
+			method:       http.MethodPost,
+			body:         "payload",
+			outcomes:     []scriptedOutcome{{status: 429, header: retryAfterZero}, {status: 201}},
+			wantStatus:   201,
+			wantAttempts: 2,
+		},
+		{
+			name:         "postRetriedWithAllMethods",
+			method:       http.MethodPost,
+			body:         "payload",
+			policy:       RetryPolicy{RetryAllMethods: true},
+			outcomes:     []scriptedOutcome{{err: fmt.Errorf("connection reset")}, {status: 201}},
+			wantStatus:   201,
+			wantAttempts: 2,
+		},
+		{
+			name:         "retryAfterTooLong",
+			method:       http.MethodGet,
+			outcomes:     []scriptedOutcome{{status: 503, header: http.Header{"Retry-After": []string{"3600"}}}, {status: 200}},
+			wantStatus:   503,
+			wantAttempts: 1,
+		},
+		{
+			name:         "customShouldRetry",
+			method:       http.MethodGet,
+			policy:       RetryPolicy{ShouldRetry: func(resp *http.Response, err error) bool { return resp.StatusCode == 409 }},
+			outcomes:     []scriptedOutcome{{status: 409}, {status: 200}},
+			wantStatus:   200,
+			wantAttempts: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &scriptedRoundTripper{outcomes: tt.outcomes}
+			vcdClient := NewVCDClient(url.URL{Scheme: "https", Host: "vcd.example.com", Path: "/api"}, true)
+			vcdClient.Client.Http.Transport = next
+			policy := tt.policy
+			policy.InitialInterval = time.Millisecond
+			var events []RetryEvent
+			policy.OnRetry = func(event RetryEvent) {
+				events = append(events, event)
+			}
+			err := WithRetryPolicy(policy)(vcdClient)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(tt.method, "https://vcd.example.com/api/org", body)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp, err := vcdClient.Client.Http.Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, expected error: %t", err, tt.wantErr)
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("got status %d, expected %d", resp.StatusCode, tt.wantStatus)
+				}
+			}
+			if len(next.bodies) != tt.wantAttempts {
+				t.Errorf("got %d attempts, expected %d", len(next.bodies), tt.wantAttempts)
+			}
+			if len(events) != tt.wantAttempts-1 {
+				t.Errorf("got %d retry events, expected %d", len(events), tt.wantAttempts-1)
+			}
+			for index, event := range events {
+				if event.Attempt != index+1 {
+					t.Errorf("got retry event for attempt %d, expected %d", event.Attempt, index+1)
+				}
+			}
+			for _, sentBody := range next.bodies {
+				if sentBody != tt.body {
+					t.Errorf("got body '%s', expected '%s'", sentBody, tt.body)
+				}
+			}
+		})
+	}
+}
+
+func Test_WithRetryPolicyContextCancelled(t *testing.T) {
+	next := &scriptedRoundTripper{outcomes: []scriptedOutcome{{status: 503}}}
+	vcdClient := NewVCDClient(url.URL{Scheme: "https", Host: "vcd.example.com", Path: "/api"}, true)
+	vcdClient.Client.Http.Transport = next
+	err := WithRetryPolicy(RetryPolicy{InitialInterval: time.Hour})(vcdClient)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://vcd.example.com/api/org", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = vcdClient.Client.Http.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, expected %s", err, context.DeadlineExceeded)
+	}
+	if len(next.bodies) != 1 {
+		t.Errorf("got %d attempts, expected 1", len(next.bodies))
+	}
+}
+
+func Test_WithRetryPolicyOptions(t *testing.T) {
+	vcdClient := NewVCDClient(url.URL{Scheme: "https", Host: "cell1.example.com", Path: "/api"}, true)
+	err := WithRetryPolicy(RetryPolicy{Multiplier: 0.5})(vcdClient)
+	if err == nil {
+		t.Fatalf("expected error with multiplier lower than 1")
+	}
+	err = WithRetryPolicy(RetryPolicy{})(vcdClient)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = WithRetryPolicy(RetryPolicy{})(vcdClient)
+	if err == nil {
+		t.Fatalf("expected error configuring retry policy twice")
+	}
+
+	// The other transport options keep working with the retry transport
+	err = WithFailoverEndpoints([]url.URL{{Scheme: "https", Host: "cell2.example.com", Path: "/api"}}, FailoverOptions{})(vcdClient)
+	if err != nil {
+		t.Fatalf("unexpected error adding failover endpoints: %s", err)
+	}
+	err = WithStrictTLS()(vcdClient)
+	if err != nil {
+		t.Fatalf("unexpected error setting strict TLS: %s", err)
+	}
+	activeEndpoint := vcdClient.GetActiveEndpoint()
+	if activeEndpoint.Host != "cell1.example.com" {
+		t.Errorf("got active endpoint %s, expected cell1.example.com", activeEndpoint.Host)
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		wantDelay  time.Duration
+		wantOk     bool
+	}{
+		{name: "seconds", status: 429, retryAfter: "120", wantDelay: 2 * time.Minute, wantOk: true},
+		{name: "pastDate", status: 503, retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT", wantDelay: 0, wantOk: true},
+		{name: "empty", status: 429, retryAfter: "", wantOk: false},
+		{name: "invalid", status: 429, retryAfter: "soon", wantOk: false},
+		{name: "otherStatus", status: 502, retryAfter: "10", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			delay, ok := parseRetryAfter(resp)
+			if ok != tt.wantOk || delay != tt.wantDelay {
+				t.Errorf("got (%s, %t), expected (%s, %t)", delay, ok, tt.wantDelay, tt.wantOk)
+			}
+		})
+	}
+}
@@ -249,3 +249,40 @@ func (adminOrg *AdminOrg) GetRightsCategoryById(ctx context.Context, id string)
 func (client *Client) GetRightsCategoryById(ctx context.Context, id string) (*types.RightsCategory, error) {
 	return getRightCategoryById(ctx, client, id, nil)
 }
+
+// getRightsSetByName retrieves the rights with the given names, ignoring duplicates. When withImpliedRights is true,
+// the rights implied by them that are not in the list are added to it
+func getRightsSetByName(ctx context.Context, client *Client, rightNames []string, withImpliedRights bool, additionalHeader map[string]string) ([]types.OpenApiReference, error) {
+	var rightList []types.OpenApiReference
+	var uniqueNames = make(map[string]bool)
+
+	for _, name := range rightNames {
+		if uniqueNames[name] {
+			continue
+		}
+		right, err := getRightByName(ctx, client, name, additionalHeader)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving right '%s': %w", name, err)
+		}
+		rightList = append(rightList, types.OpenApiReference{
+			Name: right.Name,
+			ID:   right.ID,
+		})
+		uniqueNames[name] = true
+	}
+	if !withImpliedRights {
+		return rightList, nil
+	}
+
+	implied, err := FindMissingImpliedRights(ctx, client, rightList)
+	if err != nil {
+		return nil, err
+	}
+	return append(rightList, implied...), nil
+}
+
+// GetRightsSetByName retrieves the rights with the given names, together with the rights they imply, ready to be
+// used when creating a Role, Global Role or Rights Bundle, or when adding rights to it
+func (client *Client) GetRightsSetByName(ctx context.Context, rightNames []string) ([]types.OpenApiReference, error) {
+	return getRightsSetByName(ctx, client, rightNames, true, nil)
+}
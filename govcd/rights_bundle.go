@@ -45,7 +45,7 @@ func (client *Client) CreateRightsBundle(ctx context.Context, newRightsBundle *t
 
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, newRightsBundle, returnBundle.RightsBundle, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating rights bundle: %s", err)
+		return nil, fmt.Errorf("error creating rights bundle: %w", err)
 	}
 
 	return returnBundle, nil
@@ -75,12 +75,27 @@ func (rb *RightsBundle) Update(ctx context.Context) (*RightsBundle, error) {
 
 	err = rb.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, rb.RightsBundle, returnRightsBundle.RightsBundle, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating rights bundle: %s", err)
+		return nil, fmt.Errorf("error updating rights bundle: %w", err)
 	}
 
 	return returnRightsBundle, nil
 }
 
+// Refresh reloads the rights bundle from VCD
+func (rb *RightsBundle) Refresh(ctx context.Context) error {
+	if rb.RightsBundle.Id == "" {
+		return fmt.Errorf("cannot refresh rights bundle without id")
+	}
+
+	refreshedRightsBundle, err := rb.client.GetRightsBundleById(ctx, rb.RightsBundle.Id)
+	if err != nil {
+		return fmt.Errorf("error refreshing rights bundle: %w", err)
+	}
+	rb.RightsBundle = refreshedRightsBundle.RightsBundle
+
+	return nil
+}
+
 // getAllRightsBundles retrieves all rights bundles. Query parameters can be supplied to perform additional
 // filtering
 func getAllRightsBundles(ctx context.Context, client *Client, queryParameters url.Values, additionalHeader map[string]string) ([]*RightsBundle, error) {
@@ -159,6 +174,24 @@ func (rb *RightsBundle) RemoveAllRights(ctx context.Context) error {
 	return removeAllRightsFromRole(ctx, rb.client, "RightsBundle", rb.RightsBundle.Name, rb.RightsBundle.Id, endpoint, nil)
 }
 
+// AddRightsByName adds the rights with the given names to a rights bundle, together with the rights they imply
+func (rb *RightsBundle) AddRightsByName(ctx context.Context, rightNames ...string) error {
+	newRights, err := getRightsSetByName(ctx, rb.client, rightNames, true, nil)
+	if err != nil {
+		return err
+	}
+	return rb.AddRights(ctx, newRights)
+}
+
+// RemoveRightsByName removes the rights with the given names from a rights bundle. The rights they imply are kept
+func (rb *RightsBundle) RemoveRightsByName(ctx context.Context, rightNames ...string) error {
+	removeRights, err := getRightsSetByName(ctx, rb.client, rightNames, false, nil)
+	if err != nil {
+		return err
+	}
+	return rb.RemoveRights(ctx, removeRights)
+}
+
 // PublishTenants publishes a rights bundle to one or more tenants
 func (rb *RightsBundle) PublishTenants(ctx context.Context, tenants []types.OpenApiReference) error {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRightsBundles
@@ -256,7 +289,7 @@ func (rb *RightsBundle) Delete(ctx context.Context) error {
 	err = rb.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting rights bundle: %s", err)
+		return fmt.Errorf("error deleting rights bundle: %w", err)
 	}
 
 	return nil
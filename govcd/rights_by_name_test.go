@@ -0,0 +1,148 @@
+//go:build functional || openapi || role || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"net/url"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// rightsByNameContainer is implemented by Role, GlobalRole and RightsBundle
+type rightsByNameContainer interface {
+	AddRightsByName(ctx context.Context, rightNames ...string) error
+	RemoveRightsByName(ctx context.Context, rightNames ...string) error
+	GetRights(ctx context.Context, queryParameters url.Values) ([]*types.Right, error)
+}
+
+func (vcd *TestVCD) Test_RoleRightsByName(check *C) {
+	vcd.checkSkipWhenApiToken(check)
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	role, err := adminOrg.CreateRole(ctx, &types.Role{
+		Name:        check.TestName(),
+		Description: "Role created by test",
+		BundleKey:   types.VcloudUndefinedKey,
+	})
+	check.Assert(err, IsNil)
+	AddToCleanupListOpenApi(role.Role.Name, check.TestName(), types.OpenApiPathVersion1_0_0+types.OpenApiEndpointRoles+role.Role.ID)
+
+	// Refresh retrieves the changes made through another copy of the role
+	roleCopy, err := adminOrg.GetRoleById(ctx, role.Role.ID)
+	check.Assert(err, IsNil)
+	roleCopy.Role.Description = "Updated description"
+	_, err = roleCopy.Update(ctx)
+	check.Assert(err, IsNil)
+	err = role.Refresh(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(role.Role.Description, Equals, "Updated description")
+
+	testRightsByName(check, adminOrg.client, role)
+
+	err = role.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+func (vcd *TestVCD) Test_GlobalRoleRightsByName(check *C) {
+	client := vcd.client.Client
+	if !client.IsSysAdmin {
+		check.Skip("test Test_GlobalRoleRightsByName requires system administrator privileges")
+	}
+	vcd.checkSkipWhenApiToken(check)
+
+	globalRole, err := client.CreateGlobalRole(ctx, &types.GlobalRole{
+		Name:        check.TestName(),
+		Description: "Global Role created by test",
+		BundleKey:   types.VcloudUndefinedKey,
+	})
+	check.Assert(err, IsNil)
+	AddToCleanupListOpenApi(globalRole.GlobalRole.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointGlobalRoles+globalRole.GlobalRole.Id)
+
+	// Refresh retrieves the changes made through another copy of the global role
+	globalRoleCopy, err := client.GetGlobalRoleById(ctx, globalRole.GlobalRole.Id)
+	check.Assert(err, IsNil)
+	globalRoleCopy.GlobalRole.Description = "Updated description"
+	_, err = globalRoleCopy.Update(ctx)
+	check.Assert(err, IsNil)
+	err = globalRole.Refresh(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(globalRole.GlobalRole.Description, Equals, "Updated description")
+
+	testRightsByName(check, &client, globalRole)
+
+	err = globalRole.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+func (vcd *TestVCD) Test_RightsBundleRightsByName(check *C) {
+	client := vcd.client.Client
+	if !client.IsSysAdmin {
+		check.Skip("test Test_RightsBundleRightsByName requires system administrator privileges")
+	}
+	vcd.checkSkipWhenApiToken(check)
+
+	rightsBundle, err := client.CreateRightsBundle(ctx, &types.RightsBundle{
+		Name:        check.TestName(),
+		Description: "Rights Bundle created by test",
+		BundleKey:   types.VcloudUndefinedKey,
+	})
+	check.Assert(err, IsNil)
+	AddToCleanupListOpenApi(rightsBundle.RightsBundle.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointRightsBundles+rightsBundle.RightsBundle.Id)
+
+	// Refresh retrieves the changes made through another copy of the rights bundle
+	rightsBundleCopy, err := client.GetRightsBundleById(ctx, rightsBundle.RightsBundle.Id)
+	check.Assert(err, IsNil)
+	rightsBundleCopy.RightsBundle.Description = "Updated description"
+	_, err = rightsBundleCopy.Update(ctx)
+	check.Assert(err, IsNil)
+	err = rightsBundle.Refresh(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(rightsBundle.RightsBundle.Description, Equals, "Updated description")
+
+	testRightsByName(check, &client, rightsBundle)
+
+	err = rightsBundle.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+// testRightsByName adds rights by name to the given container, with their implied rights, and removes one of them
+func testRightsByName(check *C, client *Client, container rightsByNameContainer) {
+	// These rights include 5 implied rights
+	rightNames := []string{
+		"Catalog: Add vApp from My Cloud",
+		"Catalog: Edit Properties",
+	}
+	// Add an intentional duplicate, which is ignored
+	rightSet, err := client.GetRightsSetByName(ctx, append(rightNames, rightNames[1]))
+	check.Assert(err, IsNil)
+	check.Assert(len(rightSet) > len(rightNames), Equals, true)
+
+	err = container.AddRightsByName(ctx, rightNames...)
+	check.Assert(err, IsNil)
+	rights, err := container.GetRights(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(rights), Equals, len(rightSet))
+
+	// Removing a right keeps the rights it implies
+	err = container.RemoveRightsByName(ctx, rightNames[0])
+	check.Assert(err, IsNil)
+	rights, err = container.GetRights(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(rights), Equals, len(rightSet)-1)
+	for _, right := range rights {
+		check.Assert(right.Name, Not(Equals), rightNames[0])
+	}
+
+	_, err = client.GetRightsSetByName(ctx, []string{"not existing right"})
+	check.Assert(err, NotNil)
+}
@@ -149,7 +149,7 @@ func (adminOrg *AdminOrg) CreateRole(ctx context.Context, newRole *types.Role) (
 
 	err = adminOrg.client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, newRole, returnRole.Role, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error creating role: %s", err)
+		return nil, fmt.Errorf("error creating role: %w", err)
 	}
 
 	return returnRole, nil
@@ -180,12 +180,39 @@ func (role *Role) Update(ctx context.Context) (*Role, error) {
 
 	err = role.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, role.Role, returnRole.Role, getTenantContextHeader(role.TenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating role: %s", err)
+		return nil, fmt.Errorf("error updating role: %w", err)
 	}
 
 	return returnRole, nil
 }
 
+// Refresh reloads the role from VCD
+func (role *Role) Refresh(ctx context.Context) error {
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRoles
+	minimumApiVersion, err := role.client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if role.Role.ID == "" {
+		return fmt.Errorf("cannot refresh role without id")
+	}
+
+	urlRef, err := role.client.OpenApiBuildEndpoint(endpoint, role.Role.ID)
+	if err != nil {
+		return err
+	}
+
+	refreshedRole := &types.Role{}
+	err = role.client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, refreshedRole, getTenantContextHeader(role.TenantContext))
+	if err != nil {
+		return fmt.Errorf("error refreshing role: %w", err)
+	}
+	role.Role = refreshedRole
+
+	return nil
+}
+
 // Delete deletes OpenAPI role
 func (role *Role) Delete(ctx context.Context) error {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRoles
@@ -206,7 +233,7 @@ func (role *Role) Delete(ctx context.Context) error {
 	err = role.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, getTenantContextHeader(role.TenantContext))
 
 	if err != nil {
-		return fmt.Errorf("error deleting role: %s", err)
+		return fmt.Errorf("error deleting role: %w", err)
 	}
 
 	return nil
@@ -236,6 +263,24 @@ func (role *Role) RemoveAllRights(ctx context.Context) error {
 	return removeAllRightsFromRole(ctx, role.client, "Role", role.Role.Name, role.Role.ID, endpoint, getTenantContextHeader(role.TenantContext))
 }
 
+// AddRightsByName adds the rights with the given names to a role, together with the rights they imply
+func (role *Role) AddRightsByName(ctx context.Context, rightNames ...string) error {
+	newRights, err := getRightsSetByName(ctx, role.client, rightNames, true, getTenantContextHeader(role.TenantContext))
+	if err != nil {
+		return err
+	}
+	return role.AddRights(ctx, newRights)
+}
+
+// RemoveRightsByName removes the rights with the given names from a role. The rights they imply are kept
+func (role *Role) RemoveRightsByName(ctx context.Context, rightNames ...string) error {
+	removeRights, err := getRightsSetByName(ctx, role.client, rightNames, false, getTenantContextHeader(role.TenantContext))
+	if err != nil {
+		return err
+	}
+	return role.RemoveRights(ctx, removeRights)
+}
+
 // addRightsToRole is a generic function that can add rights to a rights collection (Role, Global Role, or Rights bundle)
 // roleType is an informative string (one of "Role", "GlobalRole", or "RightsBundle")
 // name and id are the name and ID of the collection
@@ -274,7 +319,7 @@ func addRightsToRole(ctx context.Context, client *Client, roleType, name, id, en
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, &input, &pages, additionalHeader)
 
 	if err != nil {
-		return fmt.Errorf("error adding rights to %s %s: %s", roleType, name, err)
+		return fmt.Errorf("error adding rights to %s %s: %w", roleType, name, err)
 	}
 
 	return nil
@@ -318,7 +363,7 @@ func updateRightsInRole(ctx context.Context, client *Client, roleType, name, id,
 	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, &input, &pages, additionalHeader)
 
 	if err != nil {
-		return fmt.Errorf("error updating rights in %s %s: %s", roleType, name, err)
+		return fmt.Errorf("error updating rights in %s %s: %w", roleType, name, err)
 	}
 
 	return nil
@@ -398,7 +443,7 @@ func removeRightsFromRole(ctx context.Context, client *Client, roleType, name, i
 	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, &input, &pages, additionalHeader)
 
 	if err != nil {
-		return fmt.Errorf("error updating rights in %s %s: %s", roleType, name, err)
+		return fmt.Errorf("error updating rights in %s %s: %w", roleType, name, err)
 	}
 
 	return nil
@@ -72,7 +72,7 @@ func (vcdClient *VCDClient) authorizeSamlAdfs(ctx context.Context, user, pass, o
 	if overrideRptId == "" {
 		samlEntityId, err = getSamlEntityId(ctx, vcdClient, org)
 		if err != nil {
-			return fmt.Errorf("SAML - error getting vCD SAML Entity ID: %s", err)
+			return fmt.Errorf("SAML - error getting vCD SAML Entity ID: %w", err)
 		}
 	}
 
@@ -81,7 +81,7 @@ func (vcdClient *VCDClient) authorizeSamlAdfs(ctx context.Context, user, pass, o
 	// url.Scheme + "://" + url.Host + "/login/my-org/saml/login/alias/vcd?service=tenant:" + org
 	adfsAuthEndPoint, err := getSamlAdfsServer(ctx, vcdClient, org)
 	if err != nil {
-		return fmt.Errorf("SAML - error getting IdP (ADFS): %s", err)
+		return fmt.Errorf("SAML - error getting IdP (ADFS): %w", err)
 	}
 
 	// Step 3 - authenticate to ADFS to receive SIGN token which can be used for vCD authentication
@@ -94,7 +94,7 @@ func (vcdClient *VCDClient) authorizeSamlAdfs(ctx context.Context, user, pass, o
 	// Step 4 - gzip and base64 encode SIGN token so that vCD can understand it
 	base64GzippedSignToken, err := gzipAndBase64Encode(signToken)
 	if err != nil {
-		return fmt.Errorf("SAML - error encoding SIGN token: %s", err)
+		return fmt.Errorf("SAML - error encoding SIGN token: %w", err)
 	}
 	util.Logger.Printf("[DEBUG] SAML got SIGN token from IdP '%s' for entity with ID '%s'",
 		adfsAuthEndPoint, samlEntityId)
@@ -102,13 +102,13 @@ func (vcdClient *VCDClient) authorizeSamlAdfs(ctx context.Context, user, pass, o
 	// Step 5 - authenticate to vCD with SIGN token and receive vCD regular token in exchange
 	accessToken, err := authorizeSignToken(ctx, vcdClient, base64GzippedSignToken, org)
 	if err != nil {
-		return fmt.Errorf("SAML - error submitting SIGN token to vCD: %s", err)
+		return fmt.Errorf("SAML - error submitting SIGN token to vCD: %w", err)
 	}
 
 	// Step 6 - set regular vCD auth token X-Vcloud-Authorization
 	err = vcdClient.SetToken(ctx, org, AuthorizationHeader, accessToken)
 	if err != nil {
-		return fmt.Errorf("error during token-based authentication: %s", err)
+		return fmt.Errorf("error during token-based authentication: %w", err)
 	}
 
 	return nil
@@ -146,7 +146,7 @@ func getSamlAdfsServer(ctx context.Context, vcdCli *VCDClient, org string) (stri
 	loginURLString := url.Scheme + "://" + url.Host + "/login/" + org + "/saml/login/alias/vcd"
 	loginURL, err := url.Parse(loginURLString)
 	if err != nil {
-		return "", fmt.Errorf("unable to parse login URL '%s': %s", loginURLString, err)
+		return "", fmt.Errorf("unable to parse login URL '%s': %w", loginURLString, err)
 	}
 	util.Logger.Printf("[DEBUG] SAML looking up IdP (ADFS) host redirect in: %s", loginURL.String())
 
@@ -156,12 +156,12 @@ func getSamlAdfsServer(ctx context.Context, vcdCli *VCDClient, org string) (stri
 		nil, map[string]string{"service": "tenant:" + org}, http.MethodGet, *loginURL, nil)
 	httpResponse, err := checkResp(vcdCli.Client.Http.Do(req))
 	if err != nil {
-		return "", fmt.Errorf("SAML - ADFS server query failed: %s", err)
+		return "", fmt.Errorf("SAML - ADFS server query failed: %w", err)
 	}
 
 	err = decodeBody(types.BodyTypeXML, httpResponse, nil)
 	if err != nil {
-		return "", fmt.Errorf("SAML - error decoding body: %s", err)
+		return "", fmt.Errorf("SAML - error decoding body: %w", err)
 	}
 
 	// httpResponse.Location() returns an error if no 'Location' header is present
@@ -214,7 +214,7 @@ func getSamlAuthToken(ctx context.Context, vcdCli *VCDClient, user, pass, samlEn
 	// Post to ADFS endpoint "/adfs/services/trust/13/usernamemixed"
 	authEndpointUrl, err := url.Parse(authEndpoint)
 	if err != nil {
-		return "", fmt.Errorf("SAML - error parsing authentication endpoint %s: %s", authEndpoint, err)
+		return "", fmt.Errorf("SAML - error parsing authentication endpoint %s: %w", authEndpoint, err)
 	}
 	req := vcdCli.Client.NewRequest(ctx, nil, http.MethodPost, *authEndpointUrl, samlTokenRequestBody)
 	req.Header.Add("Content-Type", types.SoapXML)
@@ -227,7 +227,7 @@ func getSamlAuthToken(ctx context.Context, vcdCli *VCDClient, user, pass, samlEn
 
 	err = decodeBody(types.BodyTypeXML, resp, &tokenRequestResponse)
 	if err != nil {
-		return "", fmt.Errorf("SAML - error decoding ADFS token request response: %s", err)
+		return "", fmt.Errorf("SAML - error decoding ADFS token request response: %w", err)
 	}
 
 	tokenString := tokenRequestResponse.Body.RequestSecurityTokenResponseCollection.RequestSecurityTokenResponse.RequestedSecurityTokenTxt.Text
@@ -241,7 +241,7 @@ func getSamlAuthToken(ctx context.Context, vcdCli *VCDClient, user, pass, samlEn
 func authorizeSignToken(ctx context.Context, vcdCli *VCDClient, base64GzippedSignToken, org string) (string, error) {
 	url, err := url.Parse(vcdCli.Client.VCDHREF.Scheme + "://" + vcdCli.Client.VCDHREF.Host + "/api/sessions")
 	if err != nil {
-		return "", fmt.Errorf("SAML error - could not parse URL for posting SIGN token: %s", err)
+		return "", fmt.Errorf("SAML error - could not parse URL for posting SIGN token: %w", err)
 	}
 
 	signHeader := http.Header{}
@@ -250,11 +250,11 @@ func authorizeSignToken(ctx context.Context, vcdCli *VCDClient, base64GzippedSig
 	req := vcdCli.Client.newRequest(ctx, nil, nil, http.MethodPost, *url, nil, vcdCli.Client.APIVersion, signHeader)
 	resp, err := checkResp(vcdCli.Client.Http.Do(req))
 	if err != nil {
-		return "", fmt.Errorf("SAML - error submitting SIGN token for authentication to %s: %s", req.URL.String(), err)
+		return "", fmt.Errorf("SAML - error submitting SIGN token for authentication to %s: %w", req.URL.String(), err)
 	}
 	err = decodeBody(types.BodyTypeXML, resp, nil)
 	if err != nil {
-		return "", fmt.Errorf("SAML - error decoding body SIGN token auth response: %s", err)
+		return "", fmt.Errorf("SAML - error decoding body SIGN token auth response: %w", err)
 	}
 
 	accessToken := resp.Header.Get("X-Vcloud-Authorization")
@@ -311,10 +311,10 @@ func gzipAndBase64Encode(text string) (string, error) {
 	var gzipBuffer bytes.Buffer
 	gz := gzip.NewWriter(&gzipBuffer)
 	if _, err := gz.Write([]byte(text)); err != nil {
-		return "", fmt.Errorf("error writing to gzip buffer: %s", err)
+		return "", fmt.Errorf("error writing to gzip buffer: %w", err)
 	}
 	if err := gz.Close(); err != nil {
-		return "", fmt.Errorf("error closing gzip buffer: %s", err)
+		return "", fmt.Errorf("error closing gzip buffer: %w", err)
 	}
 	base64GzippedToken := base64.StdEncoding.EncodeToString(gzipBuffer.Bytes())
 
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ClientScope defines the boundaries of a scoped client created with VCDClient.NewScopedClient.
+// OrgId is mandatory, while VdcId is optional. Both accept either a URN or a bare UUID.
+type ClientScope struct {
+	OrgId string
+	VdcId string
+}
+
+// NewScopedClient returns a copy of the VCDClient that shares the session (token) of the original one, but
+// refuses to send requests that refer to an Org or a VDC other than the ones specified in scope.
+//
+// The restriction is enforced client-side by inspecting the path and query of each request before it
+// is sent:
+// * XML API paths such as '/api/org/{id}', '/api/admin/org/{id}', '/api/vdc/{id}', '/api/admin/vdc/{id}'
+// * OpenAPI URNs such as 'urn:vcloud:org:{id}' and 'urn:vcloud:vdc:{id}' in path or filters
+// * Provider only endpoints ('/api/admin/extension' and '/cloudapi/.../provider')
+//
+// Note. This is a guard rail to reduce the blast radius when passing a client to less trusted code, not
+// a replacement for VCD role based access control. Objects that do not carry their parent Org or VDC ID in
+// their URL (e.g. a vApp retrieved by HREF) are not blocked.
+func (vcdClient *VCDClient) NewScopedClient(scope ClientScope) (*VCDClient, error) {
+	// UUIDs are matched case-insensitively, as VCD accepts them in any case
+	orgUuid := extractUuid(strings.ToLower(scope.OrgId))
+	if orgUuid == "" {
+		return nil, fmt.Errorf("a valid Org ID is required to create a scoped client, got '%s'", scope.OrgId)
+	}
+	vdcUuid := ""
+	if scope.VdcId != "" {
+		vdcUuid = extractUuid(strings.ToLower(scope.VdcId))
+		if vdcUuid == "" {
+			return nil, fmt.Errorf("invalid VDC ID '%s' for scoped client", scope.VdcId)
+		}
+	}
+
+	scopedClient := *vcdClient
+	if vcdClient.Client.customHeader != nil {
+		scopedClient.Client.customHeader = vcdClient.Client.customHeader.Clone()
+	}
+
+	nextTransport := vcdClient.Client.Http.Transport
+	if nextTransport == nil {
+		nextTransport = http.DefaultTransport
+	}
+	scopedClient.Client.Http.Transport = &scopedRoundTripper{
+		next:    nextTransport,
+		orgUuid: orgUuid,
+		vdcUuid: vdcUuid,
+	}
+	// A scoped client must never act as a provider
+	scopedClient.Client.IsSysAdmin = false
+
+	return &scopedClient, nil
+}
+
+// IsScoped returns true if the client was created with NewScopedClient
+func (vcdClient *VCDClient) IsScoped() bool {
+	_, ok := vcdClient.Client.Http.Transport.(*scopedRoundTripper)
+	return ok
+}
+
+// scopedRoundTripper is an http.RoundTripper that validates the target of each request against the allowed
+// Org and VDC before passing it to the next transport
+type scopedRoundTripper struct {
+	next    http.RoundTripper
+	orgUuid string
+	vdcUuid string
+}
+
+// The expressions are case-insensitive, so that UUIDs in upper case cannot bypass the scope
+var (
+	scopedXmlPathRegex = regexp.MustCompile(`(?i)/(org|vdc)/([a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12})`)
+	scopedUrnRegex     = regexp.MustCompile(`(?i)urn:vcloud:(org|vdc):([a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12})`)
+)
+
+// RoundTrip implements http.RoundTripper
+func (srt *scopedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := srt.validateUrl(req.URL); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	return srt.next.RoundTrip(req)
+}
+
+// validateUrl returns an error if the URL refers to an Org or a VDC outside of the allowed scope
+func (srt *scopedRoundTripper) validateUrl(requestUrl *url.URL) error {
+	if requestUrl == nil {
+		return nil
+	}
+	path := requestUrl.EscapedPath()
+	if strings.Contains(path, "/api/admin/extension") || strings.HasSuffix(path, "/provider") {
+		return fmt.Errorf("scoped client: provider endpoint '%s' is not allowed", path)
+	}
+
+	query, err := url.QueryUnescape(requestUrl.RawQuery)
+	if err != nil {
+		query = requestUrl.RawQuery
+	}
+
+	var found [][]string
+	found = append(found, scopedXmlPathRegex.FindAllStringSubmatch(path, -1)...)
+	found = append(found, scopedUrnRegex.FindAllStringSubmatch(path, -1)...)
+	found = append(found, scopedUrnRegex.FindAllStringSubmatch(query, -1)...)
+
+	for _, match := range found {
+		entityType, id := strings.ToLower(match[1]), strings.ToLower(match[2])
+		switch entityType {
+		case "org":
+			if id != srt.orgUuid {
+				return fmt.Errorf("scoped client: access to Org '%s' denied, client is restricted to Org '%s'",
+					id, srt.orgUuid)
+			}
+		case "vdc":
+			if srt.vdcUuid != "" && id != srt.vdcUuid {
+				return fmt.Errorf("scoped client: access to VDC '%s' denied, client is restricted to VDC '%s'",
+					id, srt.vdcUuid)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type countingRoundTripper struct {
+	calls int
+}
+
+func (crt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	crt.calls++
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func Test_NewScopedClient(t *testing.T) {
+	const orgId = "urn:vcloud:org:1111abcd-1111-1111-1111-111111111111"
+	const vdcId = "urn:vcloud:vdc:22222222-2222-2222-2222-222222222222"
+	const otherUuid = "3333abcd-3333-3333-3333-333333333333"
+
+	vcdClient := NewVCDClient(url.URL{Scheme: "https", Host: "vcd.example.com", Path: "/api"}, true)
+	next := &countingRoundTripper{}
+	vcdClient.Client.Http.Transport = next
+
+	_, err := vcdClient.NewScopedClient(ClientScope{})
+	if err == nil {
+		t.Fatalf("expected error when Org ID is missing")
+	}
+
+	// The scope accepts IDs in upper case
+	scopedClient, err := vcdClient.NewScopedClient(ClientScope{OrgId: strings.ToUpper(orgId), VdcId: vdcId})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !scopedClient.IsScoped() || vcdClient.IsScoped() {
+		t.Fatalf("only the derived client must be scoped")
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		allowed bool
+	}{
+		{"OwnOrg", "https://vcd.example.com/api/org/1111abcd-1111-1111-1111-111111111111", true},
+		{"OwnAdminVdc", "https://vcd.example.com/api/admin/vdc/22222222-2222-2222-2222-222222222222", true},
+		{"OtherOrg", "https://vcd.example.com/api/admin/org/" + otherUuid, false},
+		{"OtherVdc", "https://vcd.example.com/api/vdc/" + otherUuid, false},
+		{"OtherVdcUrnInFilter", "https://vcd.example.com/cloudapi/1.0.0/edgeGateways?filter=ownerRef.id%3D%3Durn%3Avcloud%3Avdc%3A" + otherUuid, false},
+		{"OwnVdcUrnInFilter", "https://vcd.example.com/cloudapi/1.0.0/edgeGateways?filter=ownerRef.id%3D%3D" + url.QueryEscape(vdcId), true},
+		{"ProviderExtension", "https://vcd.example.com/api/admin/extension/providerVdcReferences", false},
+		{"ProviderSession", "https://vcd.example.com/cloudapi/1.0.0/sessions/provider", false},
+		{"OtherOrgUpperCase", "https://vcd.example.com/api/org/" + strings.ToUpper(otherUuid), false},
+		{"OtherVdcUrnUpperCase", "https://vcd.example.com/cloudapi/1.0.0/vdcs/URN:VCLOUD:VDC:" + strings.ToUpper(otherUuid), false},
+		{"OwnOrgUpperCase", "https://vcd.example.com/api/org/1111ABCD-1111-1111-1111-111111111111", true},
+		{"NotScopedObject", "https://vcd.example.com/api/vApp/vapp-" + otherUuid, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callsBefore := next.calls
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatalf("error creating request: %s", err)
+			}
+			resp, err := scopedClient.Client.Http.Do(req)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("expected request to be allowed, got: %s", err)
+				}
+				_ = resp.Body.Close()
+				if next.calls != callsBefore+1 {
+					t.Fatalf("expected request to reach the next transport")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "scoped client") {
+				t.Fatalf("expected scoped client error, got: %v", err)
+			}
+			if next.calls != callsBefore {
+				t.Fatalf("denied request must not reach the next transport")
+			}
+		})
+	}
+}
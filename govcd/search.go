@@ -0,0 +1,193 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// EntityKind is a kind of entity that can be searched with VCDClient.Search. Its value is the tenant query type of
+// the entity
+type EntityKind string
+
+// Entity kinds supported by VCDClient.Search
+const (
+	EntityKindVapp          EntityKind = types.QtVapp
+	EntityKindVm            EntityKind = types.QtVm
+	EntityKindVappTemplate  EntityKind = types.QtVappTemplate
+	EntityKindCatalog       EntityKind = types.QtCatalog
+	EntityKindCatalogItem   EntityKind = types.QtCatalogItem
+	EntityKindMedia         EntityKind = types.QtMedia
+	EntityKindOrgVdc        EntityKind = types.QtOrgVdc
+	EntityKindOrgVdcNetwork EntityKind = types.QtOrgVdcNetwork
+	EntityKindEdgeGateway   EntityKind = types.QtEdgeGateway
+)
+
+// searchEntityKinds are the kinds searched when none is given, in the order used to rank results with the same score
+var searchEntityKinds = []EntityKind{
+	EntityKindVapp,
+	EntityKindVm,
+	EntityKindVappTemplate,
+	EntityKindCatalog,
+	EntityKindCatalogItem,
+	EntityKindMedia,
+	EntityKindOrgVdc,
+	EntityKindOrgVdcNetwork,
+	EntityKindEdgeGateway,
+}
+
+// Scores of a SearchResult, from the best to the worst match
+const (
+	SearchScoreExact    = 3 // The name is the search term
+	SearchScorePrefix   = 2 // The name starts with the search term
+	SearchScoreContains = 1 // The name contains the search term
+)
+
+// SearchResult is an entity found by VCDClient.Search
+type SearchResult struct {
+	Kind       EntityKind
+	Name       string
+	Href       string
+	ParentName string // Name of the VDC, catalog or vApp containing the entity, when available
+	Score      int    // One of the SearchScore* values
+	// Item is the query record of the entity, which can be cast to its Query* type (e.g. QueryVapp)
+	Item QueryItem
+}
+
+// Search finds the entities of the given kinds whose name contains term, and returns them sorted by relevance: exact
+// matches first, then names starting with term, then the other ones. Results with the same score are sorted by name
+// and kind. When no kinds are given, all the EntityKind* kinds are searched.
+//
+// The queries of the different kinds run concurrently, with the admin query types when the client is connected as
+// system administrator. The comparison is case-insensitive. VMs inside vApp templates are not included.
+func (vcdClient *VCDClient) Search(ctx context.Context, term string, kinds []EntityKind) ([]*SearchResult, error) {
+	if strings.TrimSpace(term) == "" {
+		return nil, fmt.Errorf("cannot search without a search term")
+	}
+	if len(kinds) == 0 {
+		kinds = searchEntityKinds
+	}
+	for _, kind := range kinds {
+		if searchKindRank(kind) == -1 {
+			return nil, fmt.Errorf("entity kind '%s' is not supported by search", kind)
+		}
+	}
+
+	resultsByKind := make([][]*SearchResult, len(kinds))
+	errorsByKind := make([]error, len(kinds))
+	var waitGroup sync.WaitGroup
+	for index, kind := range kinds {
+		waitGroup.Add(1)
+		go func(index int, kind EntityKind) {
+			defer waitGroup.Done()
+			resultsByKind[index], errorsByKind[index] = vcdClient.Client.searchKind(ctx, term, kind)
+		}(index, kind)
+	}
+	waitGroup.Wait()
+
+	var errorMessages []string
+	var results []*SearchResult
+	for index, kind := range kinds {
+		if errorsByKind[index] != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("error searching %s: %s", kind, errorsByKind[index]))
+			continue
+		}
+		results = append(results, resultsByKind[index]...)
+	}
+	if len(errorMessages) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errorMessages, "; "))
+	}
+
+	sortSearchResults(results)
+	return results, nil
+}
+
+// searchKind runs the query of a single kind with a name filter and scores its results
+func (client *Client) searchKind(ctx context.Context, term string, kind EntityKind) ([]*SearchResult, error) {
+	queryType := client.GetQueryType(string(kind))
+	filter := fmt.Sprintf("name==*%s*", url.QueryEscape(term))
+	if kind == EntityKindVm {
+		filter += ";" + types.VmQueryFilterOnlyDeployed.String()
+	}
+
+	util.Logger.Printf("[TRACE] Search: querying %s with filter %s", queryType, filter)
+	queryResult, err := client.cumulativeQuery(ctx, queryType, nil, map[string]string{
+		"type":          queryType,
+		"filter":        filter,
+		"filterEncoded": "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+	items, err := resultToQueryItems(queryType, queryResult)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*SearchResult
+	for _, item := range items {
+		score := searchScore(item.GetName(), term)
+		// The filter of some query types is case-sensitive or matches other fields: the name is always checked again
+		if score == 0 {
+			continue
+		}
+		results = append(results, &SearchResult{
+			Kind:       kind,
+			Name:       item.GetName(),
+			Href:       item.GetHref(),
+			ParentName: item.GetParentName(),
+			Score:      score,
+			Item:       item,
+		})
+	}
+	return results, nil
+}
+
+// searchScore returns how well name matches term (one of the SearchScore* values), or 0 when it does not match
+func searchScore(name, term string) int {
+	name = strings.ToLower(name)
+	term = strings.ToLower(term)
+	switch {
+	case name == term:
+		return SearchScoreExact
+	case strings.HasPrefix(name, term):
+		return SearchScorePrefix
+	case strings.Contains(name, term):
+		return SearchScoreContains
+	}
+	return 0
+}
+
+// searchKindRank returns the position of kind in searchEntityKinds, or -1 when the kind is not supported
+func searchKindRank(kind EntityKind) int {
+	for index, supportedKind := range searchEntityKinds {
+		if kind == supportedKind {
+			return index
+		}
+	}
+	return -1
+}
+
+// sortSearchResults sorts results by score (best first), name and kind
+func sortSearchResults(results []*SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		nameI, nameJ := strings.ToLower(results[i].Name), strings.ToLower(results[j].Name)
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return searchKindRank(results[i].Kind) < searchKindRank(results[j].Kind)
+	})
+}
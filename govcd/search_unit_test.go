@@ -0,0 +1,51 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		term     string
+		expected int
+	}{
+		{"web-server", "web-server", SearchScoreExact},
+		{"Web-Server", "web-server", SearchScoreExact},
+		{"web-server-01", "web", SearchScorePrefix},
+		{"my-web-server", "WEB", SearchScoreContains},
+		{"database", "web", 0},
+	}
+	for _, tt := range tests {
+		if score := searchScore(tt.name, tt.term); score != tt.expected {
+			t.Errorf("searchScore(%q, %q): expected %d, got %d", tt.name, tt.term, tt.expected, score)
+		}
+	}
+}
+
+func TestSortSearchResults(t *testing.T) {
+	results := []*SearchResult{
+		{Kind: EntityKindVm, Name: "my-web", Score: SearchScoreContains},
+		{Kind: EntityKindVm, Name: "web", Score: SearchScoreExact},
+		{Kind: EntityKindVapp, Name: "web", Score: SearchScoreExact},
+		{Kind: EntityKindCatalog, Name: "Web-b", Score: SearchScorePrefix},
+		{Kind: EntityKindVapp, Name: "web-a", Score: SearchScorePrefix},
+	}
+	sortSearchResults(results)
+
+	var got []string
+	for _, result := range results {
+		got = append(got, string(result.Kind)+"/"+result.Name)
+	}
+	expected := []string{"vApp/web", "vm/web", "vApp/web-a", "catalog/Web-b", "vm/my-web"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -54,6 +54,39 @@ func (org *Org) GetAllSecurityTaggedEntitiesByName(ctx context.Context, security
 	return securityTagEntities, nil
 }
 
+// GetVmsBySecurityTag retrieves all the VMs of the Org that have the security tag securityTagName assigned.
+// Tagged entities are retrieved page by page (the page size can be set with 'pageSize' in queryParameters), then
+// each VM is retrieved by its HREF. It returns an empty slice if no VM has the tag.
+// This function works from API v36.0 (VCD 10.3.0+)
+func (org *Org) GetVmsBySecurityTag(ctx context.Context, securityTagName string, queryParameters url.Values) ([]*VM, error) {
+	if securityTagName == "" {
+		return nil, fmt.Errorf("security tag name is required")
+	}
+
+	queryParameters = queryParameterFilterAnd(fmt.Sprintf("tag==%s;entityType==vm", securityTagName), queryParameters)
+	securityTaggedEntities, err := org.GetAllSecurityTaggedEntities(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving entities with security tag '%s': %w", securityTagName, err)
+	}
+
+	vms := make([]*VM, 0, len(securityTaggedEntities))
+	for _, entity := range securityTaggedEntities {
+		vmUuid := extractUuid(entity.ID)
+		if vmUuid == "" {
+			return nil, fmt.Errorf("invalid ID '%s' for VM '%s'", entity.ID, entity.Name)
+		}
+		vmHref := org.client.VCDHREF
+		vmHref.Path += "/vApp/vm-" + vmUuid
+		vm, err := org.client.GetVMByHref(ctx, vmHref.String())
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving VM '%s' with security tag '%s': %w", entity.Name, securityTagName, err)
+		}
+		vms = append(vms, vm)
+	}
+
+	return vms, nil
+}
+
 // GetAllSecurityTagValues Retrieves the list of security tags that are in the organization and can be reused to tag an entity.
 // The list of tags include tags assigned to entities within the organization.
 // This function works from API v36.0 (VCD 10.3.0+)
@@ -82,6 +82,16 @@ func (vcd *TestVCD) Test_SecurityTags(check *C) {
 
 	check.Assert(securityTaggedEntity, NotNil)
 
+	// Check that the VM is retrieved by its security tag
+	taggedVms, err := testingOrg.GetVmsBySecurityTag(ctx, securityTagName2, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(taggedVms), Equals, 1)
+	check.Assert(taggedVms[0].VM.ID, Equals, testingVM.ID)
+
+	taggedVms, err = testingOrg.GetVmsBySecurityTag(ctx, nonExistingSecurityTag, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(taggedVms), Equals, 0)
+
 	// Check that security tags added before exist (As sysadm)
 	securityTagValues, err := testingOrg.GetAllSecurityTagValues(ctx, nil)
 	check.Assert(err, IsNil)
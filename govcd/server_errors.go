@@ -0,0 +1,110 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Typed errors for known VCD error responses that are otherwise hard to tell apart. They can be checked with
+// errors.Is on the errors returned by the functions that apply the server error mapping
+var (
+	// ErrorMetadataVisibility is returned when the metadata visibility is not allowed for the metadata domain
+	ErrorMetadataVisibility = errors.New("metadata visibility not allowed for the domain")
+	// ErrorEntityAlreadyExists is returned when an entity with the same name already exists
+	ErrorEntityAlreadyExists = errors.New("entity already exists")
+	// errorLibraryItemSyncInProgress is returned when a catalog synchronisation is already running
+	errorLibraryItemSyncInProgress = errors.New("library item synchronisation in progress")
+)
+
+// Scopes of the server error mappings, as a mapping only makes sense for the operations it was written for
+const (
+	serverErrorScopeMetadata         = "metadata"
+	serverErrorScopeMedia            = "media"
+	serverErrorScopeDisk             = "disk"
+	serverErrorScopeComputePolicy    = "computePolicy"
+	serverErrorScopeDefinedInterface = "definedInterface"
+	serverErrorScopeCatalogSync      = "catalogSync"
+)
+
+// ServerError is an error returned by VCD that matched a known error response. It unwraps to the typed error, so
+// that errors.Is(err, ErrorMetadataVisibility) works, while Error() keeps the original message
+type ServerError struct {
+	Typed error // One of the typed errors, such as ErrorMetadataVisibility or ErrorEntityNotFound
+	Cause error // The error returned by VCD
+}
+
+func (serverError *ServerError) Error() string {
+	return fmt.Sprintf("%s: %s", serverError.Typed, serverError.Cause)
+}
+
+// Unwrap returns the typed error
+func (serverError *ServerError) Unwrap() error {
+	return serverError.Typed
+}
+
+// serverErrorMapping maps the errors of a scope whose text matches all the patterns to a typed error.
+// The text contains the major error code, and sometimes the minor error code, followed by the message
+type serverErrorMapping struct {
+	scope    string
+	patterns []*regexp.Regexp
+	typed    error
+}
+
+// serverErrorMappings is the table of known VCD error responses. Workarounds for unfriendly or inaccurate errors
+// are added here rather than as string checks in the functions that receive them
+var serverErrorMappings = []serverErrorMapping{
+	// VCD returns "API Error: 500: [ <uuid> ] visibility" when the visibility is not allowed for the domain
+	{scope: serverErrorScopeMetadata, patterns: serverErrorPatterns(`\bvisibility$`), typed: ErrorMetadataVisibility},
+	// Media and disks that are not accessible are reported as forbidden
+	{scope: serverErrorScopeMedia, patterns: serverErrorPatterns(`MajorErrorCode:403`), typed: ErrorEntityNotFound},
+	{scope: serverErrorScopeDisk, patterns: serverErrorPatterns(`MajorErrorCode:403|does not exist`), typed: ErrorEntityNotFound},
+	// VCD 10.4.0 discloses a database error when creating a VM Placement Policy with a duplicate name
+	{scope: serverErrorScopeComputePolicy, patterns: serverErrorPatterns(`already exists`, `duplicate key`), typed: ErrorEntityAlreadyExists},
+	// VCD API <= 36.0 does not return a 404 for defined interfaces that do not exist
+	{scope: serverErrorScopeDefinedInterface, patterns: serverErrorPatterns(`does not exist`), typed: ErrorEntityNotFound},
+	// A catalog synchronisation started in background in the meantime
+	{scope: serverErrorScopeCatalogSync, patterns: serverErrorPatterns(`LIBRARY_ITEM_SYNC`), typed: errorLibraryItemSyncInProgress},
+}
+
+// serverErrorPatterns compiles the patterns of a server error mapping
+func serverErrorPatterns(patterns ...string) []*regexp.Regexp {
+	result := make([]*regexp.Regexp, len(patterns))
+	for index, pattern := range patterns {
+		result[index] = regexp.MustCompile(pattern)
+	}
+	return result
+}
+
+// mapServerError returns a *ServerError wrapping err when it matches a mapping of the given scope, or err itself
+func mapServerError(err error, scope string) error {
+	if err == nil {
+		return nil
+	}
+	var serverError *ServerError
+	if errors.As(err, &serverError) {
+		return err
+	}
+	text := err.Error()
+	for _, mapping := range serverErrorMappings {
+		if mapping.scope != scope || !matchesAllPatterns(text, mapping.patterns) {
+			continue
+		}
+		return &ServerError{Typed: mapping.typed, Cause: err}
+	}
+	return err
+}
+
+// matchesAllPatterns returns true when text matches every pattern
+func matchesAllPatterns(text string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if !pattern.MatchString(text) {
+			return false
+		}
+	}
+	return len(patterns) > 0
+}
@@ -0,0 +1,100 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_mapServerError(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    string
+		err      error
+		expected error
+	}{
+		{
+			name:     "MetadataVisibility",
+			scope:    serverErrorScopeMetadata,
+			err:      fmt.Errorf("error adding metadata: %s", types.Error{MajorErrorCode: 500, Message: "[ 1a2b3c ] visibility"}),
+			expected: ErrorMetadataVisibility,
+		},
+		{
+			name:  "MetadataOtherError",
+			scope: serverErrorScopeMetadata,
+			err:   fmt.Errorf("error adding metadata: %s", types.Error{MajorErrorCode: 400, Message: "invalid visibility value"}),
+		},
+		{
+			name:  "VisibilityOutsideScope",
+			scope: serverErrorScopeDisk,
+			err:   fmt.Errorf("API Error: 500: [ 1a2b3c ] visibility"),
+		},
+		{
+			name:     "MediaForbidden",
+			scope:    serverErrorScopeMedia,
+			err:      fmt.Errorf("error retrieving media: %#v", types.Error{MajorErrorCode: 403}),
+			expected: ErrorEntityNotFound,
+		},
+		{
+			name:     "DiskDoesNotExist",
+			scope:    serverErrorScopeDisk,
+			err:      fmt.Errorf("error retrieving Disk: API Error: 400: disk 1a2b3c does not exist"),
+			expected: ErrorEntityNotFound,
+		},
+		{
+			name:     "DuplicateComputePolicy",
+			scope:    serverErrorScopeComputePolicy,
+			err:      fmt.Errorf("policy already exists: ERROR: duplicate key value violates unique constraint"),
+			expected: ErrorEntityAlreadyExists,
+		},
+		{
+			name:  "ComputePolicyPartialMatch",
+			scope: serverErrorScopeComputePolicy,
+			err:   fmt.Errorf("policy already exists"),
+		},
+		{
+			name:     "CatalogSync",
+			scope:    serverErrorScopeCatalogSync,
+			err:      fmt.Errorf("error: task LIBRARY_ITEM_SYNC is running"),
+			expected: errorLibraryItemSyncInProgress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapped := mapServerError(tt.err, tt.scope)
+			if tt.expected == nil {
+				if mapped != tt.err {
+					t.Errorf("expected error to be returned unchanged, got %s", mapped)
+				}
+				return
+			}
+			if !errors.Is(mapped, tt.expected) {
+				t.Fatalf("expected error to match %q, got %s", tt.expected, mapped)
+			}
+			var serverError *ServerError
+			if !errors.As(mapped, &serverError) || serverError.Cause != tt.err {
+				t.Errorf("expected ServerError wrapping the original error, got %#v", mapped)
+			}
+			if mapServerError(mapped, tt.scope) != mapped {
+				t.Errorf("expected an already mapped error to be returned unchanged")
+			}
+		})
+	}
+
+	if mapServerError(nil, serverErrorScopeMetadata) != nil {
+		t.Errorf("expected nil for nil error")
+	}
+	// The original message is kept, so that checks like ContainsNotFound keep working
+	mapped := mapServerError(fmt.Errorf("interface does not exist"), serverErrorScopeDefinedInterface)
+	if !ContainsNotFound(mapped) {
+		t.Errorf("expected not found error, got %s", mapped)
+	}
+}
@@ -0,0 +1,371 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// serviceAccountUrnPrefix prefixes the OAuth client ID of a Service Account to build its ID
+const serviceAccountUrnPrefix = "urn:vcloud:serviceAccount:"
+
+// defaultDeviceCodeInterval is the interval between token requests of the device authorization flow, when VCD
+// doesn't specify it
+const defaultDeviceCodeInterval = 5 * time.Second
+
+// ServiceAccount is a VCD Service Account, which allows applications to authenticate with an API token obtained
+// through the OAuth device authorization flow:
+//  1. CreateServiceAccount registers the Service Account
+//  2. ServiceAccount.Authorize requests access, returning a user code
+//  3. An Org administrator grants access to the user code, in the UI or with ServiceAccount.Grant
+//  4. ServiceAccount.GetInitialApiToken retrieves the first API token, to be stored with SaveServiceAccountApiToken
+//
+// The API token is rotated at each authentication, with VCDClient.SetServiceAccountApiToken or with a client
+// created with WithServiceAccountTokenFile
+type ServiceAccount struct {
+	ServiceAccount *types.ServiceAccount
+	// DeviceAuthorization is the pending device authorization request, set by Authorize
+	DeviceAuthorization *types.ServiceAccountDeviceAuthorization
+	vcdClient           *VCDClient
+}
+
+// CreateServiceAccount creates a Service Account named name in the given Org ("System" for provider Service
+// Accounts), with the role roleName. softwareId is a UUID identifying the application using the Service Account,
+// while softwareVersion and uri, which describe the application, are optional
+func (vcdClient *VCDClient) CreateServiceAccount(ctx context.Context, org, name, roleName, softwareId, softwareVersion, uri string) (*ServiceAccount, error) {
+	err := vcdClient.checkServiceAccountSupport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" || roleName == "" {
+		return nil, fmt.Errorf("Service Account name and role are required")
+	}
+	if !IsUuid(softwareId) {
+		return nil, fmt.Errorf("Service Account software ID must be a UUID, got '%s'", softwareId)
+	}
+
+	serviceAccountClient, err := vcdClient.registerOAuthClient(ctx, org, name, &types.ServiceAccountParams{
+		ClientName:      name,
+		ClientURI:       uri,
+		SoftwareID:      softwareId,
+		SoftwareVersion: softwareVersion,
+		Scope:           "urn:vcloud:role:" + url.PathEscape(roleName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating Service Account '%s': %w", name, err)
+	}
+
+	return vcdClient.GetServiceAccountById(ctx, serviceAccountUrnPrefix+serviceAccountClient.ClientID)
+}
+
+// GetAllServiceAccounts retrieves the Service Accounts visible to the user authenticated with the client, which are
+// the ones of all Orgs for System administrators.
+// Query parameters can be supplied to perform additional filtering
+func (vcdClient *VCDClient) GetAllServiceAccounts(ctx context.Context, queryParameters url.Values) ([]*ServiceAccount, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointServiceAccounts
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.ServiceAccount{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccounts := make([]*ServiceAccount, len(typeResponses))
+	for index := range typeResponses {
+		serviceAccounts[index] = &ServiceAccount{
+			ServiceAccount: typeResponses[index],
+			vcdClient:      vcdClient,
+		}
+	}
+	return serviceAccounts, nil
+}
+
+// GetServiceAccountByName retrieves the Service Account with the given name. System administrators, who can see the
+// Service Accounts of all Orgs, get an error when several Orgs have a Service Account with that name
+func (vcdClient *VCDClient) GetServiceAccountByName(ctx context.Context, name string) (*ServiceAccount, error) {
+	queryParams := url.Values{}
+	queryParams.Add("filter", "name=="+name)
+	serviceAccounts, err := vcdClient.GetAllServiceAccounts(ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(serviceAccounts) == 0 {
+		return nil, ErrorEntityNotFound
+	}
+	if len(serviceAccounts) > 1 {
+		return nil, fmt.Errorf("more than one Service Account found with name '%s'", name)
+	}
+	return serviceAccounts[0], nil
+}
+
+// GetServiceAccountById retrieves a Service Account by its ID
+func (vcdClient *VCDClient) GetServiceAccountById(ctx context.Context, id string) (*ServiceAccount, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointServiceAccounts
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("empty Service Account id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount := &ServiceAccount{
+		ServiceAccount: &types.ServiceAccount{},
+		vcdClient:      vcdClient,
+	}
+
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, serviceAccount.ServiceAccount, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return serviceAccount, nil
+}
+
+// Refresh reloads the Service Account, keeping its pending device authorization request
+func (sa *ServiceAccount) Refresh(ctx context.Context) error {
+	if sa.ServiceAccount == nil || sa.vcdClient == nil || sa.ServiceAccount.ID == "" {
+		return fmt.Errorf("cannot refresh Service Account without ID")
+	}
+
+	refreshedServiceAccount, err := sa.vcdClient.GetServiceAccountById(ctx, sa.ServiceAccount.ID)
+	if err != nil {
+		return fmt.Errorf("error refreshing Service Account: %w", err)
+	}
+	sa.ServiceAccount = refreshedServiceAccount.ServiceAccount
+	return nil
+}
+
+// Update updates the name, role, software version and URI of the Service Account
+func (sa *ServiceAccount) Update(ctx context.Context, serviceAccountConfig *types.ServiceAccount) (*ServiceAccount, error) {
+	client := &sa.vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointServiceAccounts
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if serviceAccountConfig.ID == "" {
+		return nil, fmt.Errorf("cannot update Service Account without id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, serviceAccountConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ServiceAccount{
+		ServiceAccount:      &types.ServiceAccount{},
+		DeviceAuthorization: sa.DeviceAuthorization,
+		vcdClient:           sa.vcdClient,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, serviceAccountConfig, result.ServiceAccount, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating Service Account '%s': %w", serviceAccountConfig.Name, err)
+	}
+
+	return result, nil
+}
+
+// Delete deletes the Service Account, which revokes its API token
+func (sa *ServiceAccount) Delete(ctx context.Context) error {
+	client := &sa.vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointServiceAccounts
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if sa.ServiceAccount.ID == "" {
+		return fmt.Errorf("cannot delete Service Account without id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, sa.ServiceAccount.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting Service Account '%s': %w", sa.ServiceAccount.Name, err)
+	}
+
+	return nil
+}
+
+// Revoke revokes the API token of the Service Account, which goes back to the CREATED status and must be
+// authorized again
+func (sa *ServiceAccount) Revoke(ctx context.Context) error {
+	client := &sa.vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointServiceAccounts
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if sa.ServiceAccount.ID == "" {
+		return fmt.Errorf("cannot revoke Service Account without id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, sa.ServiceAccount.ID, "/revoke")
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error revoking Service Account '%s': %w", sa.ServiceAccount.Name, err)
+	}
+
+	sa.DeviceAuthorization = nil
+	return sa.Refresh(ctx)
+}
+
+// Authorize requests access for the Service Account, starting the OAuth device authorization flow. Access must then
+// be granted to the returned user code, in the UI or with Grant, before the device code expires.
+// The request is kept in the DeviceAuthorization field, for Grant and GetInitialApiToken
+func (sa *ServiceAccount) Authorize(ctx context.Context) (*types.ServiceAccountDeviceAuthorization, error) {
+	if sa.ServiceAccount == nil || sa.ServiceAccount.Org == nil {
+		return nil, fmt.Errorf("cannot authorize Service Account without Org")
+	}
+	reqHref, err := sa.vcdClient.oauthUrl(sa.ServiceAccount.Org.Name, "device_authorization")
+	if err != nil {
+		return nil, err
+	}
+
+	data := bytes.NewBufferString(url.Values{"client_id": {sa.clientId()}}.Encode())
+	req := sa.vcdClient.Client.NewRequest(ctx, nil, http.MethodPost, *reqHref, data)
+	req.Header.Set("Accept", "application/json;version=37.0")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := sa.vcdClient.Client.Http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = checkRespWithErrType(types.BodyTypeJSON, resp, err, &types.OpenApiError{})
+	if err != nil {
+		return nil, fmt.Errorf("error authorizing Service Account '%s': %w", sa.ServiceAccount.Name, err)
+	}
+
+	deviceAuthorization := &types.ServiceAccountDeviceAuthorization{}
+	err = decodeBody(types.BodyTypeJSON, resp, deviceAuthorization)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding authorization of Service Account '%s': %w", sa.ServiceAccount.Name, err)
+	}
+	err = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error closing response body: %w", err)
+	}
+	if deviceAuthorization.DeviceCode == "" || deviceAuthorization.UserCode == "" {
+		return nil, fmt.Errorf("error authorizing Service Account '%s': no device code was returned",
+			sa.ServiceAccount.Name)
+	}
+
+	sa.DeviceAuthorization = deviceAuthorization
+	return deviceAuthorization, sa.Refresh(ctx)
+}
+
+// Grant grants access to the pending device authorization request of the Service Account. It requires a client
+// authenticated with the right to manage Service Accounts, such as an Org administrator
+func (sa *ServiceAccount) Grant(ctx context.Context) error {
+	if sa.DeviceAuthorization == nil || sa.DeviceAuthorization.UserCode == "" {
+		return fmt.Errorf("Service Account '%s' has no pending authorization request", sa.ServiceAccount.Name)
+	}
+
+	client := &sa.vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointServiceAccountGrant
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+
+	grant := &types.ServiceAccountGrant{UserCode: sa.DeviceAuthorization.UserCode}
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, grant, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error granting access to Service Account '%s': %w", sa.ServiceAccount.Name, err)
+	}
+
+	return sa.Refresh(ctx)
+}
+
+// GetInitialApiToken completes the device authorization flow of the Service Account, waiting until access is
+// granted to its pending request and returning the first API token, which should be stored with
+// SaveServiceAccountApiToken. The status of the Service Account is set to ACTIVE without refreshing it. The wait is bounded by the expiration of the device code and by the deadline of ctx
+func (sa *ServiceAccount) GetInitialApiToken(ctx context.Context) (*types.ApiTokenRefresh, error) {
+	if sa.DeviceAuthorization == nil || sa.DeviceAuthorization.DeviceCode == "" {
+		return nil, fmt.Errorf("Service Account '%s' has no pending authorization request", sa.ServiceAccount.Name)
+	}
+
+	waitOptions := WaitOptions{
+		InitialInterval: defaultDeviceCodeInterval,
+		Timeout:         time.Duration(sa.DeviceAuthorization.ExpiresIn) * time.Second,
+	}
+	if sa.DeviceAuthorization.Interval > 0 {
+		waitOptions.InitialInterval = time.Duration(sa.DeviceAuthorization.Interval) * time.Second
+	}
+
+	var apiToken *types.ApiTokenRefresh
+	err := WaitForState(ctx, func() (bool, error) {
+		data := bytes.NewBufferString(url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"client_id":   {sa.clientId()},
+			"device_code": {sa.DeviceAuthorization.DeviceCode},
+		}.Encode())
+		var err error
+		apiToken, err = sa.vcdClient.getOAuthToken(ctx, sa.ServiceAccount.Org.Name, data, "GetInitialApiToken")
+		if errors.Is(err, errorOAuthAuthorizationPending) {
+			return false, nil
+		}
+		return err == nil, err
+	}, waitOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving API token of Service Account '%s': %w", sa.ServiceAccount.Name, err)
+	}
+	if apiToken.RefreshToken == "" {
+		return nil, fmt.Errorf("error retrieving API token of Service Account '%s': no refresh token was returned",
+			sa.ServiceAccount.Name)
+	}
+
+	// The Service Account is not refreshed, as a failure would hide the API token, which can't be retrieved again
+	sa.DeviceAuthorization = nil
+	sa.ServiceAccount.Status = types.ServiceAccountStatusActive
+	return apiToken, nil
+}
+
+// clientId returns the OAuth client ID of the Service Account, which is the last part of its ID
+func (sa *ServiceAccount) clientId() string {
+	return extractUuid(sa.ServiceAccount.ID)
+}
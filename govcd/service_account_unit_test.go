@@ -0,0 +1,177 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_ServiceAccountLifecycle(t *testing.T) {
+	clientId := "11111111-1111-1111-1111-111111111111"
+	softwareId := "22222222-2222-2222-2222-222222222222"
+	serviceAccountPath := "/cloudapi/1.0.0/serviceAccounts/" + serviceAccountUrnPrefix + clientId
+	status := types.ServiceAccountStatusCreated
+	var registered types.ServiceAccountParams
+	pendingReturned := false
+	refreshTokens := 0
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/oauth/tenant/my-org/register":
+			_ = json.NewDecoder(r.Body).Decode(&registered)
+			_, _ = fmt.Fprintf(w, `{"client_id":"%s","client_name":"%s"}`, clientId, registered.ClientName)
+		case r.Method == http.MethodGet && r.URL.Path == serviceAccountPath:
+			_, _ = fmt.Fprintf(w, `{"id":"%s%s","name":"%s","softwareId":"%s","org":{"name":"my-org"},"status":"%s"}`,
+				serviceAccountUrnPrefix, clientId, registered.ClientName, softwareId, status)
+		case r.Method == http.MethodPost && r.URL.Path == "/oauth/tenant/my-org/device_authorization":
+			_ = r.ParseForm()
+			if r.PostForm.Get("client_id") != clientId {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			status = types.ServiceAccountStatusRequested
+			_, _ = w.Write([]byte(`{"device_code":"device","user_code":"USER-CODE","verification_uri":"https://vcd/device",` +
+				`"expires_in":60,"interval":1}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/cloudapi/1.0.0/deviceLookup/grant":
+			grant := types.ServiceAccountGrant{}
+			_ = json.NewDecoder(r.Body).Decode(&grant)
+			if grant.UserCode != "USER-CODE" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			status = types.ServiceAccountStatusGranted
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/oauth/tenant/my-org/token":
+			_ = r.ParseForm()
+			switch r.PostForm.Get("grant_type") {
+			case "urn:ietf:params:oauth:grant-type:device_code":
+				if !pendingReturned || status != types.ServiceAccountStatusGranted {
+					pendingReturned = true
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"error":"authorization_pending","error_description":"not granted"}`))
+					return
+				}
+				status = types.ServiceAccountStatusActive
+			case "refresh_token":
+				if r.PostForm.Get("refresh_token") != fmt.Sprintf("refresh-%d", refreshTokens) {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+					return
+				}
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			refreshTokens++
+			_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","token_type":"Bearer","expires_in":3600,"refresh_token":"refresh-%d"}`,
+				refreshTokens, refreshTokens)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/org":
+			w.Header().Set("Content-Type", types.MimeOrgList)
+			_, _ = w.Write([]byte(`<OrgList xmlns="http://www.vmware.com/vcloud/v1.5"></OrgList>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"36.1", "37.0"})
+	vcdClient.Client.APIVersion = "37.0"
+	ctx := context.Background()
+
+	_, err = vcdClient.CreateServiceAccount(ctx, "my-org", "automation", "Org Admin", "not-a-uuid", "", "")
+	if err == nil {
+		t.Fatalf("expected error creating Service Account with invalid software ID")
+	}
+	serviceAccount, err := vcdClient.CreateServiceAccount(ctx, "my-org", "automation", "Org Admin", softwareId, "1.0", "")
+	if err != nil {
+		t.Fatalf("unexpected error creating Service Account: %s", err)
+	}
+	if registered.Scope != "urn:vcloud:role:Org%20Admin" || registered.SoftwareID != softwareId {
+		t.Errorf("unexpected Service Account registration: %+v", registered)
+	}
+	if serviceAccount.ServiceAccount.Status != types.ServiceAccountStatusCreated {
+		t.Errorf("got status %s, expected %s", serviceAccount.ServiceAccount.Status, types.ServiceAccountStatusCreated)
+	}
+
+	_, err = serviceAccount.GetInitialApiToken(ctx)
+	if err == nil {
+		t.Fatalf("expected error retrieving API token without authorization request")
+	}
+	deviceAuthorization, err := serviceAccount.Authorize(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error authorizing Service Account: %s", err)
+	}
+	if deviceAuthorization.UserCode != "USER-CODE" || serviceAccount.ServiceAccount.Status != types.ServiceAccountStatusRequested {
+		t.Errorf("unexpected authorization %+v with status %s", deviceAuthorization, serviceAccount.ServiceAccount.Status)
+	}
+	err = serviceAccount.Grant(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error granting access to Service Account: %s", err)
+	}
+	apiToken, err := serviceAccount.GetInitialApiToken(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error retrieving API token: %s", err)
+	}
+	if !pendingReturned || apiToken.RefreshToken != "refresh-1" {
+		t.Errorf("got API token '%s' (pending returned: %t), expected 'refresh-1'", apiToken.RefreshToken, pendingReturned)
+	}
+	if serviceAccount.ServiceAccount.Status != types.ServiceAccountStatusActive {
+		t.Errorf("got status %s, expected %s", serviceAccount.ServiceAccount.Status, types.ServiceAccountStatusActive)
+	}
+
+	// Each authentication rotates the API token stored in the file
+	tokenFile := filepath.Join(t.TempDir(), "token.json")
+	err = SaveServiceAccountApiToken(tokenFile, apiToken, "test")
+	if err != nil {
+		t.Fatalf("unexpected error saving API token: %s", err)
+	}
+	saClient := NewVCDClient(*serverUrl, true, WithServiceAccountTokenFile(tokenFile))
+	saClient.Client.supportedVersions = renderSupportedVersions([]string{"36.1", "37.0"})
+	saClient.Client.APIVersion = "37.0"
+	for _, expected := range []string{"refresh-2", "refresh-3"} {
+		err = saClient.Authenticate(ctx, "", "", "my-org")
+		if err != nil {
+			t.Fatalf("unexpected error authenticating with Service Account: %s", err)
+		}
+		storedToken := &types.ApiTokenRefresh{}
+		err = readFileAndUnmarshalJSON(tokenFile, storedToken)
+		if err != nil {
+			t.Fatalf("unexpected error reading API token: %s", err)
+		}
+		if storedToken.RefreshToken != expected || storedToken.AccessToken != "" {
+			t.Errorf("got stored API token %+v, expected only refresh token '%s'", storedToken, expected)
+		}
+		if saClient.Client.VCDToken == "" || saClient.Client.VCDAuthHeader != BearerTokenHeader {
+			t.Errorf("client is not authenticated with a bearer token")
+		}
+	}
+
+	// A token already rotated can't be used again, and the stored one is left unchanged
+	err = SaveServiceAccountApiToken(tokenFile, apiToken, "test")
+	if err != nil {
+		t.Fatalf("unexpected error saving API token: %s", err)
+	}
+	err = saClient.Authenticate(ctx, "", "", "my-org")
+	if err == nil {
+		t.Fatalf("expected error authenticating with a rotated API token")
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
@@ -24,6 +26,55 @@ type ExtendedSessionInfo struct {
 	ConnectionType string
 }
 
+// PingResult contains the outcome of a successful Client.Ping
+type PingResult struct {
+	VcdVersion string        // VCD product version. Empty when the user is not allowed to retrieve it
+	ApiVersion string        // API version used by the client
+	User       string        // Name of the user owning the session
+	Org        string        // Name of the Org of the user owning the session
+	Roles      []string      // Roles of the user owning the session
+	Elapsed    time.Duration // Total time spent performing the checks
+}
+
+// Ping performs a minimal check of connectivity and authentication, suitable for readiness probes.
+// It retrieves the list of supported API versions (connectivity) and the current session
+// (authentication). Retrieving the product version is attempted, but failing to do so is not an error,
+// as it requires administrative rights.
+func (client *Client) Ping(ctx context.Context) (*PingResult, error) {
+	startTime := time.Now()
+
+	versionsEndpoint := client.VCDHREF
+	versionsEndpoint.Path += "/versions"
+	var supportedVersions SupportedVersions
+	_, err := client.ExecuteRequest(ctx, versionsEndpoint.String(), http.MethodGet,
+		"", "error connecting to VCD: %s", nil, &supportedVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionInfo, err := client.GetSessionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error validating session: %w", err)
+	}
+
+	result := &PingResult{
+		ApiVersion: client.APIVersion,
+		User:       sessionInfo.User.Name,
+		Org:        sessionInfo.Org.Name,
+		Roles:      sessionInfo.Roles,
+	}
+
+	vcdVersion, _, err := client.GetVcdVersion(ctx)
+	if err != nil {
+		util.Logger.Printf("[DEBUG] Ping: could not retrieve VCD version: %s", err)
+	} else {
+		result.VcdVersion = vcdVersion
+	}
+
+	result.Elapsed = time.Since(startTime)
+	return result, nil
+}
+
 // GetSessionInfo collects the basic session information for a VCD connection
 func (client *Client) GetSessionInfo(ctx context.Context) (*types.CurrentSessionInfo, error) {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointSessionCurrent
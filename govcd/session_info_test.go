@@ -55,3 +55,16 @@ func (vcd *TestVCD) Test_GetExtendedSessionInfo(check *C) {
 		fmt.Printf("%s\n", text)
 	}
 }
+
+func (vcd *TestVCD) Test_Ping(check *C) {
+	result, err := vcd.client.Client.Ping(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(result, NotNil)
+	check.Assert(result.ApiVersion, Equals, vcd.client.Client.APIVersion)
+	check.Assert(result.User, Not(Equals), "")
+	check.Assert(result.Org, Not(Equals), "")
+	check.Assert(len(result.Roles), Not(Equals), 0)
+	if vcd.client.Client.IsSysAdmin {
+		check.Assert(result.VcdVersion, Not(Equals), "")
+	}
+}
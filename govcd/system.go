@@ -206,7 +206,7 @@ func CreateAndConfigureEdgeGatewayAsync(ctx context.Context, vcdClient *VCDClien
 
 	vdcId, err := getBareEntityUuid(vdc.Vdc.ID)
 	if err != nil {
-		return Task{}, fmt.Errorf("error retrieving ID from Vdc %s: %s", vdcName, err)
+		return Task{}, fmt.Errorf("error retrieving ID from Vdc %s: %w", vdcName, err)
 	}
 	if vdcId == "" {
 		return Task{}, fmt.Errorf("error retrieving ID from Vdc %s - empty ID returned", vdcName)
@@ -331,7 +331,7 @@ func getAdminOrgByHref(ctx context.Context, vcdClient *Client, href string) (*Ad
 func GetOrgByName(ctx context.Context, vcdClient *VCDClient, orgName string) (Org, error) {
 	orgUrl, err := getOrgHREF(ctx, vcdClient, orgName)
 	if err != nil {
-		return Org{}, fmt.Errorf("organization '%s' fetch failed: %s", orgName, err)
+		return Org{}, fmt.Errorf("organization '%s' fetch failed: %w", orgName, err)
 	}
 	org := NewOrg(&vcdClient.Client)
 
@@ -742,3 +742,28 @@ func (vcd *TestVCD) TestQueryAllVdcs(check *C) {
 		check.Assert(contains(knownVdcName, foundVdcNames), Equals, true)
 	}
 }
+
+func (vcd *TestVCD) Test_GetLicenseUsage(check *C) {
+	if !vcd.client.Client.IsSysAdmin {
+		check.Skip("Test_GetLicenseUsage requires System Administrator privileges")
+	}
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	usage, err := vcd.client.GetLicenseUsage(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(usage.Total.PoweredOnVmCount <= usage.Total.VmCount, Equals, true)
+	check.Assert(usage.Total.VRamMb <= usage.Total.AllocatedVRamMb, Equals, true)
+
+	orgTotal := LicenseUsageMetrics{}
+	for _, orgUsage := range usage.Orgs {
+		orgTotal.VmCount += orgUsage.VmCount
+		orgTotal.PoweredOnVmCount += orgUsage.PoweredOnVmCount
+		orgTotal.VCpuCount += orgUsage.VCpuCount
+		orgTotal.VRamMb += orgUsage.VRamMb
+		orgTotal.AllocatedVRamMb += orgUsage.AllocatedVRamMb
+	}
+	check.Assert(orgTotal, Equals, usage.Total)
+	if testVerbose {
+		fmt.Printf("%+v\n", usage.Total)
+	}
+}
@@ -21,6 +21,10 @@ import (
 type Task struct {
 	Task   *types.Task
 	client *Client
+	etag   string // ETag of the last retrieval with RefreshIfModified
+	// onSuccess is called when waiting for the task, or its TaskFuture, finds that it completed successfully. It is a
+	// pointer to keep Task comparable
+	onSuccess *func()
 }
 
 func NewTask(cli *Client) *Task {
@@ -65,7 +69,7 @@ func (task *Task) Refresh(ctx context.Context) error {
 
 	resp, err := checkResp(task.client.Http.Do(req))
 	if err != nil {
-		return fmt.Errorf("%s: %s", errorRetrievingTask, err)
+		return fmt.Errorf("%s: %w", errorRetrievingTask, err)
 	}
 
 	// Empty struct before a new unmarshal, otherwise we end up with duplicate
@@ -92,9 +96,28 @@ type InspectionFunc func(task *types.Task, howManyTimes int, elapsed time.Durati
 // TaskMonitoringFunc can run monitoring operations on a task
 type TaskMonitoringFunc func(*types.Task)
 
+// TaskWaitError is returned when waiting for a task stops before the task finishes, e.g. because the context
+// deadline expired. It holds the last retrieved state of the task and unwraps to the cause, so that
+// errors.Is(err, context.DeadlineExceeded) works
+type TaskWaitError struct {
+	Task *types.Task // Last retrieved state of the task
+	Err  error
+}
+
+func (waitError *TaskWaitError) Error() string {
+	return fmt.Sprintf("stopped waiting for task '%s' (%s) with status '%s' and progress %d%%: %s",
+		waitError.Task.Name, waitError.Task.Operation, waitError.Task.Status, waitError.Task.Progress, waitError.Err)
+}
+
+// Unwrap returns the cause
+func (waitError *TaskWaitError) Unwrap() error {
+	return waitError.Err
+}
+
 // WaitInspectTaskCompletion is a customizable version of WaitTaskCompletion.
 // Users can define the sleeping duration and an optional callback function for
 // extra monitoring.
+// When ctx is done before the task finishes, it returns a TaskWaitError.
 func (task *Task) WaitInspectTaskCompletion(ctx context.Context, inspectionFunc InspectionFunc, delay time.Duration) error {
 
 	if task.Task == nil {
@@ -109,7 +132,10 @@ func (task *Task) WaitInspectTaskCompletion(ctx context.Context, inspectionFunc
 		elapsed := time.Since(startTime)
 		err := task.Refresh(ctx)
 		if err != nil {
-			return fmt.Errorf("%s : %s", errorRetrievingTask, err)
+			if ctx.Err() != nil {
+				return &TaskWaitError{Task: task.Task, Err: ctx.Err()}
+			}
+			return fmt.Errorf("%s : %w", errorRetrievingTask, err)
 		}
 
 		// If an inspection function is provided, we pass information about the task processing:
@@ -131,7 +157,10 @@ func (task *Task) WaitInspectTaskCompletion(ctx context.Context, inspectionFunc
 				)
 			}
 			if task.Task.Status == "error" {
-				return fmt.Errorf("task did not complete successfully: %s", task.getErrorMessage(err))
+				return newTaskAPIError(task.Task, fmt.Sprintf("task did not complete successfully: %s", task.getErrorMessage(err)))
+			}
+			if task.onSuccess != nil && task.Task.Status == "success" {
+				(*task.onSuccess)()
 			}
 			return nil
 		}
@@ -163,15 +192,72 @@ func (task *Task) WaitInspectTaskCompletion(ctx context.Context, inspectionFunc
 			)
 		}
 
-		// Sleep for a given period and try again.
-		time.Sleep(delay)
+		// Sleep for a given period and try again, unless the context is done in the meantime.
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &TaskWaitError{Task: task.Task, Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+// defaultTaskPollingInterval is the interval between task refreshes used by WaitTaskCompletion
+const defaultTaskPollingInterval = 3 * time.Second
+
+// TaskWaitOption customizes how WaitTaskCompletion waits for a task
+type TaskWaitOption func(*taskWaitSettings) error
+
+// taskWaitSettings are the settings of WaitTaskCompletion, set with TaskWaitOption
+type taskWaitSettings struct {
+	pollingInterval  time.Duration
+	progressCallback TaskMonitoringFunc
+}
+
+// WithTaskPollingInterval sets the interval between task refreshes. The default is 3 seconds
+func WithTaskPollingInterval(interval time.Duration) TaskWaitOption {
+	return func(settings *taskWaitSettings) error {
+		if interval <= 0 {
+			return fmt.Errorf("task polling interval must be positive, got %s", interval)
+		}
+		settings.pollingInterval = interval
+		return nil
+	}
+}
+
+// WithTaskProgressCallback sets a function that receives the task after each refresh, including the last one, so
+// that its Progress and Status can be reported while waiting
+func WithTaskProgressCallback(callback TaskMonitoringFunc) TaskWaitOption {
+	return func(settings *taskWaitSettings) error {
+		settings.progressCallback = callback
+		return nil
 	}
 }
 
 // WaitTaskCompletion checks the status of the task every 3 seconds and returns when the
-// task is either completed or failed
-func (task *Task) WaitTaskCompletion(ctx context.Context) error {
-	return task.WaitInspectTaskCompletion(ctx, nil, 3*time.Second)
+// task is either completed or failed.
+// The polling interval and a progress callback can be set with options (see WithTaskPollingInterval and
+// WithTaskProgressCallback). When ctx is done before the task finishes, such as when its deadline expires, it returns
+// a TaskWaitError with the last state of the task.
+func (task *Task) WaitTaskCompletion(ctx context.Context, options ...TaskWaitOption) error {
+	settings := &taskWaitSettings{
+		pollingInterval: defaultTaskPollingInterval,
+	}
+	for _, option := range options {
+		err := option(settings)
+		if err != nil {
+			return err
+		}
+	}
+
+	var inspectionFunc InspectionFunc
+	if settings.progressCallback != nil {
+		inspectionFunc = func(task *types.Task, _ int, _ time.Duration, _, _ bool) {
+			settings.progressCallback(task)
+		}
+	}
+	return task.WaitInspectTaskCompletion(ctx, inspectionFunc, settings.pollingInterval)
 }
 
 // GetTaskProgress retrieves the task progress as a string
@@ -182,11 +268,11 @@ func (task *Task) GetTaskProgress(ctx context.Context) (string, error) {
 
 	err := task.Refresh(ctx)
 	if err != nil {
-		return "", fmt.Errorf("error retrieving task: %s", err)
+		return "", fmt.Errorf("error retrieving task: %w", err)
 	}
 
 	if task.Task.Status == "error" {
-		return "", fmt.Errorf("task did not complete successfully: %s", task.getErrorMessage(err))
+		return "", newTaskAPIError(task.Task, fmt.Sprintf("task did not complete successfully: %s", task.getErrorMessage(err)))
 	}
 
 	return strconv.Itoa(task.Task.Progress), nil
@@ -345,7 +431,7 @@ func (client *Client) GetTaskByHREF(ctx context.Context, taskHref string) (*Task
 	_, err := client.ExecuteRequest(ctx, taskHref, http.MethodGet,
 		"", "error retrieving task: %s", nil, task.Task)
 	if err != nil {
-		return nil, fmt.Errorf("%s : %s", ErrorEntityNotFound, err)
+		return nil, fmt.Errorf("%s : %w", ErrorEntityNotFound, err)
 	}
 
 	return task, nil
@@ -429,7 +515,7 @@ func (client *Client) QueryTaskList(ctx context.Context, filter map[string]strin
 	}
 	results, err := client.cumulativeQuery(ctx, taskType, nil, notEncodedParams)
 	if err != nil {
-		return nil, fmt.Errorf("error querying task %s", err)
+		return nil, fmt.Errorf("error querying task %w", err)
 	}
 
 	if client.IsSysAdmin {
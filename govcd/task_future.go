@@ -0,0 +1,124 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// taskFutureDelay is the interval between task refreshes in a TaskFuture, the default of WaitTaskCompletion
+const taskFutureDelay = defaultTaskPollingInterval
+
+// TaskFuture tracks a VCD task in the background, so that several tasks can be waited for in a single select{}
+// instead of blocking a goroutine per WaitTaskCompletion:
+//
+//	task, err := vm.PowerOnAsync(ctx)
+//	future := NewTaskFuture(ctx, task, err)
+//	select {
+//	case <-future.Done():
+//	    err = future.Err()
+//	case <-otherFuture.Done():
+//	    ...
+//	}
+//
+// The task is polled until it finishes or the context passed at creation is done.
+type TaskFuture struct {
+	done chan struct{}
+	task Task
+	err  error
+}
+
+// NewTaskFuture starts tracking task, as returned together with err by the *Async methods. When err is not nil, the
+// future is already done and Err returns it
+func NewTaskFuture(ctx context.Context, task Task, err error) *TaskFuture {
+	return newTaskFuture(ctx, task, err, taskFutureDelay)
+}
+
+// Future starts tracking the task in the background. See TaskFuture
+func (task Task) Future(ctx context.Context) *TaskFuture {
+	return newTaskFuture(ctx, task, nil, taskFutureDelay)
+}
+
+func newTaskFuture(ctx context.Context, task Task, err error, delay time.Duration) *TaskFuture {
+	future := &TaskFuture{
+		done: make(chan struct{}),
+		// Refresh replaces the inner types.Task, so the copy can be refreshed without touching the caller's Task
+		task: Task{Task: task.Task, client: task.client, onSuccess: task.onSuccess},
+		err:  err,
+	}
+	if err != nil {
+		close(future.done)
+		return future
+	}
+	if task.Task == nil {
+		future.err = fmt.Errorf("cannot track task, Object is empty")
+		close(future.done)
+		return future
+	}
+	go future.poll(ctx, delay)
+	return future
+}
+
+// poll refreshes the task until it is no longer running, then closes the done channel
+func (future *TaskFuture) poll(ctx context.Context, delay time.Duration) {
+	defer close(future.done)
+	for {
+		err := future.task.Refresh(ctx)
+		if err != nil {
+			future.err = fmt.Errorf("%s : %w", errorRetrievingTask, err)
+			return
+		}
+		if !isTaskRunning(future.task.Task.Status) {
+			if future.task.Task.Status == "error" {
+				future.err = fmt.Errorf("task did not complete successfully: %s", future.task.getErrorMessage(nil))
+			}
+			if future.task.onSuccess != nil && future.task.Task.Status == "success" {
+				(*future.task.onSuccess)()
+			}
+			return
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			future.err = &TaskWaitError{Task: future.task.Task, Err: ctx.Err()}
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Done returns a channel that is closed when the task finishes, or when tracking stops because of an error or
+// because the context passed at creation is done
+func (future *TaskFuture) Done() <-chan struct{} {
+	return future.done
+}
+
+// Err returns nil while the task is running. After Done is closed, it returns the reason why the task did not
+// complete successfully, or nil if it did
+func (future *TaskFuture) Err() error {
+	select {
+	case <-future.done:
+		return future.err
+	default:
+		return nil
+	}
+}
+
+// Result waits until the task finishes or ctx is done, and returns the last retrieved state of the task together
+// with the same error returned by Err. Canceling ctx only stops this call: the future keeps tracking the task
+func (future *TaskFuture) Result(ctx context.Context) (*types.Task, error) {
+	select {
+	case <-future.done:
+		return future.task.Task, future.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
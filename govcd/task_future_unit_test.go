@@ -0,0 +1,92 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_TaskFuture(t *testing.T) {
+	// Each task reports 'running' twice, then the status given in its path
+	var refreshes int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&refreshes, 1)
+		status := "running"
+		if count%3 == 0 && !strings.HasSuffix(r.URL.Path, "/forever") {
+			status = r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		}
+		w.Header().Set("Content-Type", types.MimeTask)
+		_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" name="task" status="%s" href="https://%s%s"></Task>`,
+			status, r.Host, r.URL.Path)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+	newTask := func(status string) Task {
+		return Task{Task: &types.Task{HREF: server.URL + "/api/task/" + status}, client: client}
+	}
+
+	atomic.StoreInt32(&refreshes, 0)
+	future := newTaskFuture(context.Background(), newTask("success"), nil, time.Millisecond)
+	if future.Err() != nil {
+		t.Errorf("expected no error before the task is done, got %s", future.Err())
+	}
+	result, err := future.Result(context.Background())
+	if err != nil || result.Status != "success" {
+		t.Errorf("expected successful task, got %v (%v)", result, err)
+	}
+
+	atomic.StoreInt32(&refreshes, 0)
+	future = newTaskFuture(context.Background(), newTask("error"), nil, time.Millisecond)
+	<-future.Done()
+	if future.Err() == nil {
+		t.Errorf("expected error for failed task")
+	}
+
+	future = NewTaskFuture(context.Background(), Task{}, fmt.Errorf("async call failed"))
+	select {
+	case <-future.Done():
+	default:
+		t.Fatalf("expected future to be done when created with an error")
+	}
+	if future.Err() == nil || future.Err().Error() != "async call failed" {
+		t.Errorf("expected error from async call, got %v", future.Err())
+	}
+
+	// Result stops waiting with its own context, while the future stops tracking with the creation context
+	ctx, cancel := context.WithCancel(context.Background())
+	future = newTaskFuture(ctx, newTask("forever"), nil, time.Millisecond)
+	resultCtx, resultCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer resultCancel()
+	_, err = future.Result(resultCtx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded from Result, got %v", err)
+	}
+	cancel()
+	select {
+	case <-future.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("expected future to be done after canceling its context")
+	}
+	if future.Err() == nil {
+		t.Errorf("expected error after canceling the context")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// GetAllTasks retrieves the tasks matching the filter, which has the same format as the one of Client.QueryTaskList:
+// keys are task query fields and values can contain several values separated by commas, which are combined with a
+// logical OR (e.g. {"status": "running,queued", "objectType": "vApp"}).
+// System administrators get the tasks of all Orgs, while other users get the tasks of their Org.
+// The returned tasks only contain the fields provided by the query: use Task.Refresh to retrieve the full task
+func (vcdClient *VCDClient) GetAllTasks(ctx context.Context, filter map[string]string) ([]*Task, error) {
+	taskRecords, err := vcdClient.Client.QueryTaskList(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*Task, len(taskRecords))
+	for index, taskRecord := range taskRecords {
+		tasks[index] = newTaskFromRecord(&vcdClient.Client, taskRecord)
+	}
+	return tasks, nil
+}
+
+// GetRunningTasks retrieves the tasks of the Org that are running, preRunning or queued
+func (adminOrg *AdminOrg) GetRunningTasks(ctx context.Context) ([]*Task, error) {
+	taskRecords, err := adminOrg.client.QueryTaskList(ctx, map[string]string{
+		"status": strings.Join(defaultStuckTaskStatuses, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// System administrators get the tasks of all Orgs
+	orgId := extractUuid(adminOrg.AdminOrg.ID)
+	var tasks []*Task
+	for _, taskRecord := range taskRecords {
+		if adminOrg.client.IsSysAdmin && extractUuid(taskRecord.Org) != orgId {
+			continue
+		}
+		tasks = append(tasks, newTaskFromRecord(adminOrg.client, taskRecord))
+	}
+	return tasks, nil
+}
+
+// Cancel requests the cancellation of a task in progress, and refreshes it. The cancellation is asynchronous, so the
+// task may still be running when Cancel returns: use WaitTaskCompletion to wait for it to be aborted.
+// Unlike CancelTask, it fails when the task is known to be no longer in progress
+func (task *Task) Cancel(ctx context.Context) error {
+	if task.Task.HREF == "" {
+		return fmt.Errorf("cannot cancel task, HREF is empty")
+	}
+	if task.Task.Status != "" && !isTaskRunning(task.Task.Status) {
+		return fmt.Errorf("cannot cancel task '%s' with status '%s'", task.Task.Name, task.Task.Status)
+	}
+
+	err := task.CancelTask(ctx)
+	if err != nil {
+		return fmt.Errorf("error cancelling task '%s': %w", task.Task.Name, err)
+	}
+	return task.Refresh(ctx)
+}
+
+// newTaskFromRecord creates a Task with the fields of a task query record
+func newTaskFromRecord(client *Client, taskRecord *types.QueryResultTaskRecordType) *Task {
+	task := NewTask(client)
+	task.Task.HREF = taskRecord.HREF
+	task.Task.Type = types.MimeTask
+	task.Task.ID = taskRecord.ID
+	if task.Task.ID == "" && taskRecord.HREF != "" {
+		task.Task.ID = "urn:vcloud:task:" + extractUuid(taskRecord.HREF)
+	}
+	task.Task.Name = taskRecord.Name
+	task.Task.Status = taskRecord.Status
+	task.Task.Operation = taskRecord.OperationFull
+	task.Task.Details = taskRecord.Message
+	task.Task.ServiceNamespace = taskRecord.ServiceNamespace
+	task.Task.StartTime = taskRecord.StartDate
+	task.Task.EndTime = taskRecord.EndDate
+	task.Task.Progress = taskRecord.Progress
+	if taskRecord.Object != "" {
+		task.Task.Owner = &types.Reference{
+			HREF: taskRecord.Object,
+			Type: taskRecord.ObjectType,
+			Name: taskRecord.ObjectName,
+		}
+	}
+	if taskRecord.Org != "" {
+		task.Task.Organization = &types.Reference{
+			HREF: taskRecord.Org,
+			Name: taskRecord.OrgName,
+		}
+	}
+	util.Logger.Printf("[TRACE] newTaskFromRecord: task %s (%s) with status %s", task.Task.Name, task.Task.HREF,
+		task.Task.Status)
+	return task
+}
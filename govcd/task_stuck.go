@@ -0,0 +1,116 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// defaultStuckTaskStatuses are the statuses searched by FindStuckTasks when none are given
+var defaultStuckTaskStatuses = []string{"running", "preRunning", "queued"}
+
+// StuckTask is a task that has been running or waiting to run for longer than expected
+type StuckTask struct {
+	Task      *types.QueryResultTaskRecordType
+	StartDate time.Time
+	Age       time.Duration
+	// Owner is the object the task operates on (e.g. the vApp being deployed)
+	Owner *types.Reference
+	// Cancelled and CancelError are set by CancelStuckTasks
+	Cancelled   bool
+	CancelError error
+}
+
+// FindStuckTasks returns the tasks with one of the given statuses that started longer than olderThan ago, sorted
+// from the oldest. When statuses is empty, it searches the tasks that are running, preRunning or queued. Only those
+// statuses are accepted, as tasks in any other status are no longer in progress.
+//
+// System administrators get the tasks of all Orgs, while other users get the tasks of their Org.
+// The tasks are not cancelled: use CancelStuckTasks on the result for that
+func (client *Client) FindStuckTasks(ctx context.Context, olderThan time.Duration, statuses []string) ([]*StuckTask, error) {
+	if olderThan <= 0 {
+		return nil, fmt.Errorf("the age of stuck tasks must be positive, got %s", olderThan)
+	}
+	if len(statuses) == 0 {
+		statuses = defaultStuckTaskStatuses
+	}
+	for _, status := range statuses {
+		if !isTaskRunning(status) {
+			return nil, fmt.Errorf("task status '%s' is not a status of tasks in progress (%s)", status,
+				strings.Join(defaultStuckTaskStatuses, ", "))
+		}
+	}
+
+	tasks, err := client.QueryTaskList(ctx, map[string]string{
+		"status": strings.Join(statuses, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterStuckTasks(tasks, olderThan, time.Now())
+}
+
+// CancelStuckTasks attempts the cancellation of the given tasks, recording the outcome in each StuckTask.
+// All tasks are processed even if some cancellations fail, and an error is returned if any of them failed
+func (client *Client) CancelStuckTasks(ctx context.Context, stuckTasks []*StuckTask) error {
+	var failed []string
+	for _, stuckTask := range stuckTasks {
+		task := NewTask(client)
+		task.Task.HREF = stuckTask.Task.HREF
+		task.Task.ID = stuckTask.Task.ID
+		stuckTask.CancelError = task.CancelTask(ctx)
+		stuckTask.Cancelled = stuckTask.CancelError == nil
+		if stuckTask.CancelError != nil {
+			failed = append(failed, fmt.Sprintf("%s (%s): %s", stuckTask.Task.Name, stuckTask.Task.HREF,
+				stuckTask.CancelError))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("error cancelling %d of %d tasks: %s", len(failed), len(stuckTasks),
+			strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// filterStuckTasks returns the tasks that started longer than olderThan before now, sorted from the oldest
+func filterStuckTasks(tasks []*types.QueryResultTaskRecordType, olderThan time.Duration, now time.Time) ([]*StuckTask, error) {
+	var stuckTasks []*StuckTask
+	for _, task := range tasks {
+		// Queued tasks may not have a start date yet
+		if task.StartDate == "" {
+			util.Logger.Printf("[DEBUG] filterStuckTasks: skipping task %s without start date", task.HREF)
+			continue
+		}
+		startDate, err := time.Parse(time.RFC3339, task.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing start date '%s' of task %s: %w", task.StartDate, task.HREF, err)
+		}
+		age := now.Sub(startDate)
+		if age < olderThan {
+			continue
+		}
+		stuckTasks = append(stuckTasks, &StuckTask{
+			Task:      task,
+			StartDate: startDate,
+			Age:       age,
+			Owner: &types.Reference{
+				HREF: task.Object,
+				Type: task.ObjectType,
+				Name: task.ObjectName,
+			},
+		})
+	}
+	sort.SliceStable(stuckTasks, func(i, j int) bool {
+		return stuckTasks[i].StartDate.Before(stuckTasks[j].StartDate)
+	})
+	return stuckTasks, nil
+}
@@ -0,0 +1,59 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_filterStuckTasks(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	tasks := []*types.QueryResultTaskRecordType{
+		{HREF: "recent", StartDate: "2023-06-01T11:50:00.000Z"},
+		{HREF: "old", StartDate: "2023-06-01T10:00:00.000Z", Object: "https://vcd/api/vApp/vapp-1", ObjectType: "vApp", ObjectName: "app1"},
+		{HREF: "queued"},
+		{HREF: "oldest", StartDate: "2023-06-01T11:00:00.000+02:00"},
+	}
+	stuckTasks, err := filterStuckTasks(tasks, time.Hour, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(stuckTasks) != 2 {
+		t.Fatalf("got %d stuck tasks, want 2", len(stuckTasks))
+	}
+	if stuckTasks[0].Task.HREF != "oldest" || stuckTasks[1].Task.HREF != "old" {
+		t.Errorf("got tasks %s and %s, want oldest and old", stuckTasks[0].Task.HREF, stuckTasks[1].Task.HREF)
+	}
+	if stuckTasks[0].Age != 3*time.Hour {
+		t.Errorf("got age %s, want 3h", stuckTasks[0].Age)
+	}
+	owner := stuckTasks[1].Owner
+	if owner.HREF != "https://vcd/api/vApp/vapp-1" || owner.Type != "vApp" || owner.Name != "app1" {
+		t.Errorf("unexpected owner %+v", owner)
+	}
+
+	_, err = filterStuckTasks([]*types.QueryResultTaskRecordType{{StartDate: "yesterday"}}, time.Hour, now)
+	if err == nil {
+		t.Errorf("expected error for invalid start date")
+	}
+}
+
+func TestClient_FindStuckTasksValidation(t *testing.T) {
+	client := &Client{}
+	_, err := client.FindStuckTasks(context.Background(), 0, nil)
+	if err == nil {
+		t.Errorf("expected error for zero age")
+	}
+	_, err = client.FindStuckTasks(context.Background(), time.Hour, []string{"success"})
+	if err == nil {
+		t.Errorf("expected error for status of completed tasks")
+	}
+}
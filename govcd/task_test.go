@@ -69,6 +69,54 @@ func (vcd *TestVCD) Test_QueryTaskList(check *C) {
 	}
 }
 
+func (vcd *TestVCD) Test_FindStuckTasks(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	// No task can have been running for so long
+	stuckTasks, err := vcd.client.Client.FindStuckTasks(ctx, 100*365*24*time.Hour, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(stuckTasks), Equals, 0)
+
+	stuckTasks, err = vcd.client.Client.FindStuckTasks(ctx, time.Second, []string{"running", "queued"})
+	check.Assert(err, IsNil)
+	for _, stuckTask := range stuckTasks {
+		check.Assert(stuckTask.Age >= time.Second, Equals, true)
+		check.Assert(isTaskRunning(stuckTask.Task.Status), Equals, true)
+		check.Assert(stuckTask.Owner, NotNil)
+	}
+	if testVerbose {
+		for _, stuckTask := range stuckTasks {
+			fmt.Printf("%s %s %s (%s)\n", stuckTask.Task.Name, stuckTask.Owner.Name, stuckTask.Age, stuckTask.Task.Status)
+		}
+	}
+
+	_, err = vcd.client.Client.FindStuckTasks(ctx, time.Hour, []string{"error"})
+	check.Assert(err, NotNil)
+}
+
+func (vcd *TestVCD) Test_GetAllTasks(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	tasks, err := vcd.client.GetAllTasks(ctx, map[string]string{"status": "success,error"})
+	check.Assert(err, IsNil)
+	for _, task := range tasks {
+		check.Assert(task.Task.HREF, Not(Equals), "")
+		check.Assert(isTaskCompleteOrError(task.Task.Status), Equals, true)
+		// Completed tasks can't be cancelled
+		check.Assert(task.Cancel(ctx), NotNil)
+	}
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	runningTasks, err := adminOrg.GetRunningTasks(ctx)
+	check.Assert(err, IsNil)
+	for _, task := range runningTasks {
+		check.Assert(isTaskRunning(task.Task.Status), Equals, true)
+		check.Assert(task.Task.Organization, NotNil)
+		check.Assert(task.Task.Organization.Name, Equals, adminOrg.AdminOrg.Name)
+	}
+}
+
 func init() {
 	testingTags["task"] = "task_test.go"
 }
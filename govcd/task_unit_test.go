@@ -0,0 +1,165 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_WaitTaskCompletionOptions(t *testing.T) {
+	// The task progresses by 25% at each refresh and succeeds at 100%, unless its path ends with /forever
+	var refreshes int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&refreshes, 1)
+		progress := int(count) * 25
+		status := "running"
+		if progress >= 100 && !strings.HasSuffix(r.URL.Path, "/forever") {
+			progress = 100
+			status = "success"
+		}
+		if progress > 100 {
+			progress = 99
+		}
+		w.Header().Set("Content-Type", types.MimeTask)
+		_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" name="task" operation="Syncing catalog" status="%s" href="https://%s%s"><Progress>%d</Progress></Task>`,
+			status, r.Host, r.URL.Path, progress)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+	newTask := func(name string) *Task {
+		return &Task{Task: &types.Task{HREF: server.URL + "/api/task/" + name}, client: client}
+	}
+
+	var progress []string
+	err = newTask("sync").WaitTaskCompletion(context.Background(),
+		WithTaskPollingInterval(time.Millisecond),
+		WithTaskProgressCallback(func(task *types.Task) {
+			progress = append(progress, fmt.Sprintf("%s:%d", task.Status, task.Progress))
+		}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := "[running:25 running:50 running:75 success:100]"
+	if fmt.Sprint(progress) != expected {
+		t.Errorf("expected progress %s, got %v", expected, progress)
+	}
+
+	// The context deadline stops waiting, with the last state of the task
+	atomic.StoreInt32(&refreshes, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = newTask("forever").WaitTaskCompletion(ctx, WithTaskPollingInterval(time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got %v", err)
+	}
+	var waitError *TaskWaitError
+	if !errors.As(err, &waitError) || waitError.Task.Status != "running" || waitError.Task.Progress == 0 {
+		t.Errorf("expected the error to hold the last state of the task, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Syncing catalog") || !strings.Contains(err.Error(), "status 'running'") {
+		t.Errorf("expected the error to describe the task, got '%s'", err)
+	}
+
+	err = newTask("sync").WaitTaskCompletion(context.Background(), WithTaskPollingInterval(0))
+	if err == nil {
+		t.Errorf("expected an error for an invalid polling interval")
+	}
+}
+
+func Test_GetRunningTasksAndCancel(t *testing.T) {
+	// Two running tasks of different Orgs: cancelling changes their status to aborted
+	var cancelled sync.Map
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/query":
+			if r.URL.Query().Get("type") != types.QtAdminTask {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", types.MimeQueryRecords)
+			_, _ = fmt.Fprintf(w, `<QueryResultRecords xmlns="http://www.vmware.com/vcloud/v1.5" total="2" pageSize="25" page="1">
+  <AdminTaskRecord href="https://%[1]s/api/task/aaaaaaaa-2222-3333-4444-555555555555" name="vappDeploy" operationFull="Starting vApp app1" status="running" startDate="2023-01-01T10:00:00.000Z" org="https://%[1]s/api/org/11111111-2222-3333-4444-555555555555" orgName="org1" object="https://%[1]s/api/vApp/vapp-1" objectType="vApp" objectName="app1"/>
+  <AdminTaskRecord href="https://%[1]s/api/task/bbbbbbbb-2222-3333-4444-555555555555" name="catalogSync" operationFull="Syncing catalog cat2" status="queued" org="https://%[1]s/api/org/99999999-2222-3333-4444-555555555555" orgName="org2"/>
+</QueryResultRecords>`, r.Host)
+		case strings.HasSuffix(r.URL.Path, "/action/cancel") && r.Method == http.MethodPost:
+			cancelled.Store(strings.TrimSuffix(r.URL.Path, "/action/cancel"), true)
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(r.URL.Path, "/api/task/") && r.Method == http.MethodGet:
+			status := "running"
+			if _, ok := cancelled.Load(r.URL.Path); ok {
+				status = "aborted"
+			}
+			w.Header().Set("Content-Type", types.MimeTask)
+			_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" name="vappDeploy" status="%s" href="https://%s%s"/>`,
+				status, r.Host, r.URL.Path)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.IsSysAdmin = true
+
+	allTasks, err := vcdClient.GetAllTasks(context.Background(), map[string]string{"status": "running,queued"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(allTasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(allTasks))
+	}
+
+	adminOrg := NewAdminOrg(&vcdClient.Client)
+	adminOrg.AdminOrg.ID = "urn:vcloud:org:11111111-2222-3333-4444-555555555555"
+	tasks, err := adminOrg.GetRunningTasks(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task of the Org, got %d", len(tasks))
+	}
+	task := tasks[0]
+	if task.Task.ID != "urn:vcloud:task:aaaaaaaa-2222-3333-4444-555555555555" || task.Task.Operation != "Starting vApp app1" ||
+		task.Task.Owner == nil || task.Task.Owner.Name != "app1" || task.Task.Organization.Name != "org1" {
+		t.Errorf("unexpected task: %+v", *task.Task)
+	}
+
+	err = task.Cancel(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if task.Task.Status != "aborted" {
+		t.Errorf("expected the cancelled task to be aborted, got status '%s'", task.Task.Status)
+	}
+
+	// A task that is no longer in progress can't be cancelled
+	err = task.Cancel(context.Background())
+	if err == nil {
+		t.Errorf("expected an error cancelling an aborted task")
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"container/list"
+	"sync"
+)
+
+// defaultTenantContextCacheSize is the maximum number of entities whose tenant context is kept in orgInfoCache
+const defaultTenantContextCacheSize = 1000
+
+// tenantContextCache is a concurrency safe, bounded cache of the tenant context (Org ID and name) of entities,
+// keyed by entity ID. When full, the least recently used entry is evicted.
+type tenantContextCache struct {
+	mu      sync.Mutex
+	maxSize int
+	entries map[string]*list.Element
+	order   *list.List // Most recently used entries at the front
+}
+
+// tenantContextCacheEntry is the value stored in tenantContextCache.order
+type tenantContextCacheEntry struct {
+	entityId      string
+	tenantContext TenantContext
+}
+
+// newTenantContextCache creates a cache holding at most maxSize entries
+func newTenantContextCache(maxSize int) *tenantContextCache {
+	return &tenantContextCache{
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get returns a copy of the tenant context cached for entityId, if any
+func (cache *tenantContextCache) get(entityId string) (*TenantContext, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	element, ok := cache.entries[entityId]
+	if !ok {
+		return nil, false
+	}
+	cache.order.MoveToFront(element)
+	tenantContext := element.Value.(*tenantContextCacheEntry).tenantContext
+	return &tenantContext, true
+}
+
+// set stores a copy of the tenant context of entityId, evicting the least recently used entry if needed
+func (cache *tenantContextCache) set(entityId string, tenantContext *TenantContext) {
+	if entityId == "" || tenantContext == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if element, ok := cache.entries[entityId]; ok {
+		element.Value.(*tenantContextCacheEntry).tenantContext = *tenantContext
+		cache.order.MoveToFront(element)
+		return
+	}
+	cache.entries[entityId] = cache.order.PushFront(&tenantContextCacheEntry{entityId: entityId, tenantContext: *tenantContext})
+	for cache.order.Len() > cache.maxSize {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*tenantContextCacheEntry).entityId)
+	}
+}
+
+// invalidate removes the entry of entityId
+func (cache *tenantContextCache) invalidate(entityId string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if element, ok := cache.entries[entityId]; ok {
+		cache.order.Remove(element)
+		delete(cache.entries, entityId)
+	}
+}
+
+// invalidateOrg removes all the entries belonging to the Org with the given bare ID
+func (cache *tenantContextCache) invalidateOrg(orgId string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for element := cache.order.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*tenantContextCacheEntry)
+		if entry.tenantContext.OrgId == orgId {
+			cache.order.Remove(element)
+			delete(cache.entries, entry.entityId)
+		}
+		element = next
+	}
+}
+
+// clear removes all entries
+func (cache *tenantContextCache) clear() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.entries = make(map[string]*list.Element)
+	cache.order.Init()
+}
+
+// InvalidateTenantContextCache removes the cached tenant context of the entity with the given ID (e.g. a vApp),
+// so that it is computed again on next use
+func InvalidateTenantContextCache(entityId string) {
+	orgInfoCache.invalidate(entityId)
+}
+
+// InvalidateTenantContextCacheForOrg removes the cached tenant context of all the entities belonging to an Org.
+// orgId can be either a URN or a bare ID. It is called automatically when an Org is updated or deleted through
+// the SDK, and should be called by users when an Org is renamed by other means.
+func InvalidateTenantContextCacheForOrg(orgId string) {
+	orgInfoCache.invalidateOrg(extractUuid(orgId))
+}
+
+// ClearTenantContextCache removes all cached tenant contexts
+func ClearTenantContextCache() {
+	orgInfoCache.clear()
+}
@@ -0,0 +1,153 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_tenantContextCache(t *testing.T) {
+	cache := newTenantContextCache(2)
+
+	cache.set("vapp1", &TenantContext{OrgId: "org1", OrgName: "first"})
+	cache.set("vapp2", &TenantContext{OrgId: "org2", OrgName: "second"})
+
+	got, ok := cache.get("vapp1")
+	if !ok || got.OrgName != "first" {
+		t.Fatalf("expected 'first' for vapp1, got %v (found: %t)", got, ok)
+	}
+	// Changing the returned value must not alter the cache
+	got.OrgName = "changed"
+	got, _ = cache.get("vapp1")
+	if got.OrgName != "first" {
+		t.Fatalf("cached value was modified through the returned pointer: %v", got)
+	}
+
+	// vapp2 is now the least recently used entry and must be evicted
+	cache.set("vapp3", &TenantContext{OrgId: "org1", OrgName: "first"})
+	if _, ok := cache.get("vapp2"); ok {
+		t.Fatalf("expected vapp2 to be evicted")
+	}
+	if _, ok := cache.get("vapp1"); !ok {
+		t.Fatalf("expected vapp1 to be kept")
+	}
+
+	cache.invalidateOrg("org1")
+	if _, ok := cache.get("vapp1"); ok {
+		t.Fatalf("expected vapp1 to be invalidated with its Org")
+	}
+	if _, ok := cache.get("vapp3"); ok {
+		t.Fatalf("expected vapp3 to be invalidated with its Org")
+	}
+
+	cache.set("vapp4", &TenantContext{OrgId: "org4"})
+	cache.invalidate("vapp4")
+	if _, ok := cache.get("vapp4"); ok {
+		t.Fatalf("expected vapp4 to be invalidated")
+	}
+
+	cache.set("vapp5", &TenantContext{OrgId: "org5"})
+	cache.clear()
+	if _, ok := cache.get("vapp5"); ok {
+		t.Fatalf("expected cache to be empty after clear")
+	}
+}
+
+func Test_tenantContextCacheConcurrency(t *testing.T) {
+	cache := newTenantContextCache(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("vapp%d", i%20)
+			cache.set(id, &TenantContext{OrgId: fmt.Sprintf("org%d", i%3)})
+			cache.get(id)
+			if i%7 == 0 {
+				cache.invalidateOrg("org1")
+			}
+		}(i)
+	}
+	wg.Wait()
+	if cache.order.Len() > 10 || len(cache.entries) != cache.order.Len() {
+		t.Fatalf("inconsistent cache size: %d entries, %d in order list", len(cache.entries), cache.order.Len())
+	}
+}
+
+func Test_AdminOrgUpdateInvalidatesTenantContextCache(t *testing.T) {
+	var server *httptest.Server
+	taskStatus := "running"
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", types.MimeTask)
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusAccepted)
+		}
+		_, _ = w.Write([]byte(`<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="` + taskStatus + `" ` +
+			`href="` + server.URL + `/api/task/1" name="task" operationName="orgUpdate"/>`))
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.APIVersion = "37.0"
+	ctx := context.Background()
+
+	orgId := "urn:vcloud:org:8c1ee5a9-9a30-4b67-b3ec-6a5d1c2b4a11"
+	adminOrg := NewAdminOrg(&vcdClient.Client)
+	adminOrg.AdminOrg.ID = orgId
+	adminOrg.AdminOrg.HREF = server.URL + "/api/admin/org/8c1ee5a9-9a30-4b67-b3ec-6a5d1c2b4a11"
+	adminOrg.AdminOrg.Name = "renamed"
+	adminOrg.AdminOrg.OrgSettings = &types.OrgSettings{}
+	orgInfoCache.set("vapp-in-org", &TenantContext{OrgId: extractUuid(orgId), OrgName: "original"})
+	defer orgInfoCache.invalidate("vapp-in-org")
+
+	task, err := adminOrg.Update(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The cached tenant context is kept while the update is not complete
+	if _, ok := orgInfoCache.get("vapp-in-org"); !ok {
+		t.Fatalf("tenant context invalidated before the update was complete")
+	}
+
+	taskStatus = "success"
+	err = task.WaitTaskCompletion(ctx, WithTaskPollingInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := orgInfoCache.get("vapp-in-org"); ok {
+		t.Errorf("tenant context not invalidated after the update")
+	}
+
+	// The same happens when the task is tracked with a TaskFuture
+	taskStatus = "running"
+	orgInfoCache.set("vapp-in-org", &TenantContext{OrgId: extractUuid(orgId), OrgName: "original"})
+	task, err = adminOrg.Update(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	taskStatus = "success"
+	_, err = task.Future(ctx).Result(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := orgInfoCache.get("vapp-in-org"); ok {
+		t.Errorf("tenant context not invalidated after the update tracked with a TaskFuture")
+	}
+}
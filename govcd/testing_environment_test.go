@@ -0,0 +1,160 @@
+//go:build api || openapi || functional || catalog || vapp || gateway || network || org || query || extnetwork || task || vm || vdc || system || disk || lb || lbAppRule || lbAppProfile || lbServerPool || lbServiceMonitor || lbVirtualServer || user || search || nsxv || nsxt || auth || affinity || role || alb || certificate || vdcGroup || metadata || providervdc || rde || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// testRunPrefix is added to the names of the resources created with testResourceName, so that concurrent runs
+// against the same VCD do not collide. When it is not set, a unique prefix is generated for every run.
+// A prefix set explicitly also separates the persistent cleanup file of the run (see makePersistentCleanupFileName)
+var testRunPrefix = ""
+
+// testRunPrefixExplicit records whether testRunPrefix was set with a flag or environment variable
+var testRunPrefixExplicit bool
+
+// testIsolation makes vcd.isolatedOrgVdc create a dedicated Org and VDC for each test that uses it
+var testIsolation bool
+
+func init() {
+	setStringFlag(&testRunPrefix, "vcd-run-prefix", "GOVCD_TEST_RUN_PREFIX", "Prefix for the names of the test resources")
+	setBoolFlag(&testIsolation, "vcd-isolated", "GOVCD_TEST_ISOLATED", "Creates an Org and VDC for each test that supports isolation")
+}
+
+// testCapabilities are the features of the VCD under test, discovered when the suite starts. Tests use them
+// (through skipWithoutNsxt, skipWithoutAlb and skipBelowApiVersion) to skip what VCD cannot run
+type testCapabilities struct {
+	// discovered is true when the NSX-T and ALB availability was retrieved. This requires system administrator
+	// privileges: when it is false, the tests rely only on the configuration file
+	discovered bool
+	apiVersion string // Highest API version supported by VCD
+	nsxt       bool   // At least one NSX-T Manager is registered in VCD
+	alb        bool   // VCD supports NSX-T ALB and has NSX-T Managers to use it with
+	// albControllers is the number of ALB Controllers already registered in VCD. Tests that need an existing
+	// Controller can check it instead of creating one from the configuration
+	albControllers int
+}
+
+// String returns a one-line summary of the capabilities, shown when the suite starts
+func (capabilities testCapabilities) String() string {
+	if !capabilities.discovered {
+		return fmt.Sprintf("API %s (NSX-T and ALB not discovered)", capabilities.apiVersion)
+	}
+	return fmt.Sprintf("API %s, NSX-T: %t, ALB: %t (%d controllers)", capabilities.apiVersion, capabilities.nsxt,
+		capabilities.alb, capabilities.albControllers)
+}
+
+// setUpTestRun initializes the run prefix and isolation settings once the flags have been parsed
+func setUpTestRun(config TestConfig) {
+	if testRunPrefix != "" {
+		testRunPrefixExplicit = true
+	} else {
+		// Seconds since the epoch in base 36 are short, unique for each run, and always sort in run order
+		testRunPrefix = "gt" + strconv.FormatInt(time.Now().Unix(), 36)
+	}
+	if config.VCD.IsolatedTests {
+		testIsolation = true
+	}
+}
+
+// discoverTestCapabilities retrieves the features of the VCD under test
+func discoverTestCapabilities(ctx context.Context, vcd *TestVCD) testCapabilities {
+	capabilities := testCapabilities{}
+	apiVersion, err := vcd.client.Client.MaxSupportedVersion()
+	if err == nil {
+		capabilities.apiVersion = apiVersion
+	}
+	if !vcd.client.Client.IsSysAdmin {
+		return capabilities
+	}
+
+	nsxtManagers, err := vcd.client.QueryWithNotEncodedParams(ctx, nil, map[string]string{
+		"type": "nsxTManager",
+	})
+	if err != nil {
+		fmt.Printf("error discovering NSX-T Managers: %s\n", err)
+		return capabilities
+	}
+	capabilities.nsxt = len(nsxtManagers.Results.NsxtManagerRecord) > 0
+
+	albEndpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbController
+	capabilities.alb = capabilities.nsxt &&
+		vcd.client.Client.APIVCDMaxVersionIs(ctx, ">= "+endpointMinApiVersions[albEndpoint])
+	if capabilities.alb {
+		albControllers, err := vcd.client.GetAllAlbControllers(ctx, nil)
+		if err != nil {
+			fmt.Printf("error discovering ALB Controllers: %s\n", err)
+			return capabilities
+		}
+		capabilities.albControllers = len(albControllers)
+	}
+
+	capabilities.discovered = true
+	return capabilities
+}
+
+// testResourceName returns the name for a resource created by the current test: the run prefix, the test name and
+// the optional suffix (needed when a test creates more resources of the same type)
+func testResourceName(check *C, suffix string) string {
+	name := testRunPrefix + "-" + check.TestName()
+	if suffix != "" {
+		name += "-" + suffix
+	}
+	return name
+}
+
+// setUpSuiteVappName returns the name of the vApp deployed by SetUpSuite
+func setUpSuiteVappName() string {
+	return testRunPrefix + "-" + TestSetUpSuite
+}
+
+// isolatedOrgVdc returns the Org and VDC that the current test should use. When isolation is enabled (with
+// -vcd-isolated, GOVCD_TEST_ISOLATED or vcd.isolatedTests in the configuration file) and the tests run as system
+// administrator, a new Org and VDC are created for the test and scheduled for removal at the end of the run.
+// Otherwise, the Org and VDC shared by all tests are returned.
+func (vcd *TestVCD) isolatedOrgVdc(check *C) (*Org, *Vdc) {
+	if !testIsolation || vcd.skipAdminTests {
+		return vcd.org, vcd.vdc
+	}
+	orgName := spawnTestOrg(vcd, check, "org")
+	org, err := vcd.client.GetOrgByName(ctx, orgName)
+	check.Assert(err, IsNil)
+	vdc := spawnTestVdc(vcd, check, orgName)
+	return org, vdc
+}
+
+// skipWithoutNsxt skips the test when the discovered capabilities show that VCD has no NSX-T Manager
+func skipWithoutNsxt(vcd *TestVCD, check *C) {
+	if vcd.capabilities.discovered && !vcd.capabilities.nsxt {
+		check.Skip("Skipping test because VCD has no NSX-T Manager")
+	}
+}
+
+// skipWithoutAlb skips the test when the discovered capabilities show that VCD cannot use NSX-T ALB
+func skipWithoutAlb(vcd *TestVCD, check *C) {
+	skipWithoutNsxt(vcd, check)
+	if vcd.capabilities.discovered && !vcd.capabilities.alb {
+		check.Skip(fmt.Sprintf("Skipping test because NSX-T ALB is not supported with API version %s",
+			vcd.capabilities.apiVersion))
+	}
+}
+
+// skipBelowApiVersion skips the test when VCD does not support at least the given API version (e.g. "37.0")
+func skipBelowApiVersion(vcd *TestVCD, check *C, minimumApiVersion string) {
+	if !vcd.client.Client.APIVCDMaxVersionIs(ctx, ">= "+minimumApiVersion) {
+		check.Skip(fmt.Sprintf("Skipping test because it requires API version %s or higher. Maximum supported version is %s",
+			minimumApiVersion, vcd.capabilities.apiVersion))
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
@@ -41,6 +42,28 @@ func (p *mutexedProgress) LockedGet() float64 {
 	return p.progress
 }
 
+// defaultUploadRetryDelay is the time waited before retrying a failed piece, when UploadOptions.RetryDelay is not set
+const defaultUploadRetryDelay = 5 * time.Second
+
+// UploadOptions are the settings of the uploads of OVA/OVF files and media images.
+//
+// The upload runs in background and uses the context given to the upload method: cancelling it aborts the piece
+// being uploaded, stops the upload and cancels the VCD import task. The error is then reported by the UploadTask.
+type UploadOptions struct {
+	// PieceSize is the size of the chunks in which each file is uploaded. Defaults to 1 MB
+	PieceSize int64
+	// ProgressFunc, when set, is called after each uploaded piece with the bytes uploaded so far and the total size
+	// of the files
+	ProgressFunc func(bytesUploaded, totalSize int64)
+	// MaxRetries is the number of times a failed piece is uploaded again before failing the upload. Defaults to 0
+	MaxRetries int
+	// RetryDelay is the time waited before retrying a failed piece. Defaults to 5 seconds
+	RetryDelay time.Duration
+	// Concurrency is the number of pieces of a file uploaded in parallel. Values lower than 2 upload the pieces
+	// sequentially. Each piece is retried independently, as defined by MaxRetries
+	Concurrency int
+}
+
 // uploadLink - vCD created temporary upload link
 // uploadedBytes - how much of file already uploaded
 // fileSizeToUpload - how much bytes will be uploaded
@@ -48,11 +71,17 @@ func (p *mutexedProgress) LockedGet() float64 {
 // uploadedBytesForCallback all uploaded bytes if multi disk in ova
 // allFilesSize overall sum of size if multi disk in ova
 // callBack a function with signature //function(bytesUpload, totalSize) to let the caller monitor progress of the upload operation.
+// maxRetries - how many times a failed part is uploaded again
+// retryDelay - time to wait before uploading a failed part again
+// concurrency - how many parts are uploaded in parallel
 type uploadDetails struct {
 	uploadLink                                                                               string
 	uploadedBytes, fileSizeToUpload, uploadPieceSize, uploadedBytesForCallback, allFilesSize int64
 	callBack                                                                                 func(bytesUpload, totalSize int64)
 	uploadError                                                                              *error
+	maxRetries                                                                               int
+	retryDelay                                                                               time.Duration
+	concurrency                                                                              int
 }
 
 // Upload file by parts which size is defined by user provided variable uploadPieceSize and
@@ -104,6 +133,17 @@ func uploadFile(ctx context.Context, client *Client, filePath string, uDetails u
 	}
 
 	util.Logger.Printf("[TRACE] Uploading will use piece size: %#v \n", pieceSize)
+
+	if uDetails.concurrency > 1 {
+		err = uploadFileParallel(ctx, client, file, fileSize, pieceSize, uDetails)
+		if err != nil {
+			util.Logger.Printf("[ERROR] during upload process: %s, error %s ", filePath, err)
+			*uDetails.uploadError = err
+			return 0, err
+		}
+		return fileSize, nil
+	}
+
 	part = make([]byte, pieceSize)
 
 	for {
@@ -137,6 +177,73 @@ func uploadFile(ctx context.Context, client *Client, filePath string, uDetails u
 	return fileSize, nil
 }
 
+// uploadFileParallel uploads the parts of file with uDetails.concurrency workers. The first part that fails after
+// its retries stops the upload.
+func uploadFileParallel(ctx context.Context, client *Client, file *os.File, fileSize, pieceSize int64, uDetails uploadDetails) error {
+	util.Logger.Printf("[TRACE] Uploading with %d parallel workers\n", uDetails.concurrency)
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		waitGroup     sync.WaitGroup
+		progressMutex sync.Mutex
+		uploadErr     error
+		errOnce       sync.Once
+	)
+	uploadedBytes := uDetails.uploadedBytesForCallback
+	setError := func(err error) {
+		errOnce.Do(func() {
+			uploadErr = err
+			cancel()
+		})
+	}
+
+	offsets := make(chan int64)
+	for worker := 0; worker < uDetails.concurrency; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			part := make([]byte, pieceSize)
+			for offset := range offsets {
+				count, err := file.ReadAt(part, offset)
+				if err != nil && err != io.EOF {
+					setError(err)
+					continue
+				}
+				partDetails := uDetails
+				partDetails.uploadedBytes = uDetails.uploadedBytes + offset
+				// Progress is reported below, once the uploaded bytes of all workers are counted
+				partDetails.callBack = func(bytesUpload, totalSize int64) {}
+				err = uploadPartFile(workerCtx, client, part[:count], int64(count), partDetails)
+				if err != nil {
+					setError(err)
+					continue
+				}
+				progressMutex.Lock()
+				uploadedBytes += int64(count)
+				uDetails.callBack(uploadedBytes, uDetails.allFilesSize)
+				progressMutex.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for offset := int64(0); offset < fileSize; offset += pieceSize {
+		select {
+		case offsets <- offset:
+		case <-workerCtx.Done():
+			break dispatch
+		}
+	}
+	close(offsets)
+	waitGroup.Wait()
+
+	if uploadErr != nil {
+		return uploadErr
+	}
+	return ctx.Err()
+}
+
 // Create Request with right headers and range settings. Support multi part file upload.
 // client - client for requests
 // requestUrl - upload url
@@ -149,7 +256,7 @@ func newFileUploadRequest(ctx context.Context, client *Client, requestUrl string
 
 	parsedRequestURL, err := url.ParseRequestURI(requestUrl)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding vdc response: %s", err)
+		return nil, fmt.Errorf("error decoding vdc response: %w", err)
 	}
 
 	uploadReq := client.NewRequestWitNotEncodedParams(ctx, nil, nil, http.MethodPut, *parsedRequestURL, bytes.NewReader(filePart))
@@ -173,7 +280,38 @@ func newFileUploadRequest(ctx context.Context, client *Client, requestUrl string
 // part - bytes of file part
 // partDataSize - how much bytes will be uploaded
 // uploadDetails - file upload settings and data
+// A failed part is uploaded again up to uDetails.maxRetries times, unless the context is cancelled.
 func uploadPartFile(ctx context.Context, client *Client, part []byte, partDataSize int64, uDetails uploadDetails) error {
+	retryDelay := uDetails.retryDelay
+	if retryDelay <= 0 {
+		retryDelay = defaultUploadRetryDelay
+	}
+
+	var err error
+	for attempt := 0; attempt <= uDetails.maxRetries; attempt++ {
+		if attempt > 0 {
+			util.Logger.Printf("[TRACE] Retrying upload of part at offset %d (attempt %d of %d) after error: %s\n",
+				uDetails.uploadedBytes, attempt, uDetails.maxRetries, err)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("file upload cancelled. Err: %s", ctx.Err())
+			case <-time.After(retryDelay):
+			}
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("file upload cancelled. Err: %s", ctx.Err())
+		}
+		err = uploadPartFileOnce(ctx, client, part, partDataSize, uDetails)
+		if err == nil {
+			uDetails.callBack(uDetails.uploadedBytesForCallback+partDataSize, uDetails.allFilesSize)
+			return nil
+		}
+	}
+	return err
+}
+
+// uploadPartFileOnce makes a single attempt to upload a file part
+func uploadPartFileOnce(ctx context.Context, client *Client, part []byte, partDataSize int64, uDetails uploadDetails) error {
 	// Avoids session time out, as the multi part upload is treated as one request
 	makeEmptyRequest(ctx, client)
 	request, err := newFileUploadRequest(ctx, client, uDetails.uploadLink, part, uDetails.uploadedBytes, partDataSize, uDetails.fileSizeToUpload)
@@ -183,15 +321,12 @@ func uploadPartFile(ctx context.Context, client *Client, part []byte, partDataSi
 
 	response, err := checkResp(client.Http.Do(request))
 	if err != nil {
-		return fmt.Errorf("file upload failed. Err: %s", err)
+		return fmt.Errorf("file upload failed. Err: %w", err)
 	}
 	err = response.Body.Close()
 	if err != nil {
-		return fmt.Errorf("file closing failed. Err: %s", err)
+		return fmt.Errorf("file closing failed. Err: %w", err)
 	}
-
-	uDetails.callBack(uDetails.uploadedBytesForCallback+partDataSize, uDetails.allFilesSize)
-
 	return nil
 }
 
@@ -239,21 +374,47 @@ func createTaskForVcdImport(ctx context.Context, client *Client, taskHREF string
 	task := NewTask(client)
 
 	if err = decodeBody(types.BodyTypeXML, response, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding Task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding Task response: %w", err)
 	}
 
 	// The request was successful
 	return *task, nil
 }
 
-func getProgressCallBackFunction() (func(int64, int64), *mutexedProgress) {
+// getProgressCallBackFunction returns the callback that records the progress of an UploadTask. When progressFunc is
+// not nil, it is called as well
+func getProgressCallBackFunction(progressFunc func(bytesUploaded, totalSize int64)) (func(int64, int64), *mutexedProgress) {
 	uploadProgress := &mutexedProgress{}
 	callback := func(bytesUploaded, totalSize int64) {
 		uploadProgress.LockedSet((float64(bytesUploaded) / float64(totalSize)) * 100)
+		if progressFunc != nil {
+			progressFunc(bytesUploaded, totalSize)
+		}
 	}
 	return callback, uploadProgress
 }
 
+// cancelImportTasksOnCancellation cancels the VCD import tasks of an upload when the upload failed because its
+// context was cancelled, so that VCD does not keep waiting for the missing files
+func cancelImportTasksOnCancellation(ctx context.Context, client *Client, tasks *types.TasksInProgress, uploadErr error) {
+	if ctx.Err() == nil || uploadErr == nil || tasks == nil {
+		return
+	}
+	// The upload context is no longer usable
+	cleanupCtx := context.Background()
+	for _, item := range tasks.Task {
+		task, err := createTaskForVcdImport(cleanupCtx, client, item.HREF)
+		if err != nil {
+			util.Logger.Printf("[ERROR] error retrieving import task %s: %s", item.HREF, err)
+			continue
+		}
+		err = task.CancelTask(cleanupCtx)
+		if err != nil {
+			util.Logger.Printf("[ERROR] error cancelling import task %s: %s", item.HREF, err)
+		}
+	}
+}
+
 func validateAndFixFilePath(file string) (string, error) {
 	absolutePath, err := filepath.Abs(file)
 	if err != nil {
@@ -0,0 +1,156 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newUploadTestServer returns a server that accepts uploads to /transfer/disk.vmdk, failing the first failures
+// attempts of each part, and the ranges of the accepted parts
+func newUploadTestServer(t *testing.T, failures int) (*httptest.Server, *Client, *[]string) {
+	var accepted []string
+	var mutex sync.Mutex
+	attempts := make(map[string]int)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/disk.vmdk") {
+			// Keep-alive requests made between parts
+			w.Header().Set("Content-Type", "application/vnd.vmware.vcloud.query.records+xml")
+			_, _ = fmt.Fprint(w, `<QueryResultRecords xmlns="http://www.vmware.com/vcloud/v1.5"/>`)
+			return
+		}
+		_, _ = io.Copy(io.Discard, r.Body)
+		mutex.Lock()
+		defer mutex.Unlock()
+		contentRange := r.Header.Get("Content-Range")
+		attempts[contentRange]++
+		if attempts[contentRange] <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		accepted = append(accepted, contentRange)
+	}))
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return server, &NewVCDClient(*serverUrl, true).Client, &accepted
+}
+
+func Test_uploadPartFileRetry(t *testing.T) {
+	server, client, accepted := newUploadTestServer(t, 2)
+	defer server.Close()
+
+	var progress int64
+	uDetails := uploadDetails{
+		uploadLink:       server.URL + "/transfer/disk.vmdk",
+		fileSizeToUpload: 2048,
+		allFilesSize:     2048,
+		callBack: func(bytesUploaded, totalSize int64) {
+			progress = bytesUploaded
+		},
+		maxRetries: 2,
+		retryDelay: time.Millisecond,
+	}
+	part := make([]byte, 1024)
+
+	err := uploadPartFile(context.Background(), client, part, 1024, uDetails)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if strings.Join(*accepted, ",") != "bytes 0-1023/2048" || progress != 1024 {
+		t.Errorf("unexpected upload: accepted %v, progress %d", *accepted, progress)
+	}
+
+	// Without enough retries, the part fails
+	uDetails.uploadedBytes = 1024
+	uDetails.maxRetries = 1
+	err = uploadPartFile(context.Background(), client, part, 1024, uDetails)
+	if err == nil {
+		t.Fatalf("expected error when the retries are exhausted")
+	}
+}
+
+func Test_uploadPartFileCancelled(t *testing.T) {
+	server, client, accepted := newUploadTestServer(t, 1)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uDetails := uploadDetails{
+		uploadLink:       server.URL + "/transfer/disk.vmdk",
+		fileSizeToUpload: 1024,
+		allFilesSize:     1024,
+		callBack:         func(bytesUploaded, totalSize int64) {},
+		maxRetries:       5,
+		retryDelay:       time.Hour,
+	}
+	err := uploadPartFile(ctx, client, make([]byte, 1024), 1024, uDetails)
+	if err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Fatalf("expected cancellation error, got: %v", err)
+	}
+	if len(*accepted) != 0 {
+		t.Errorf("expected no uploaded parts, got %v", *accepted)
+	}
+}
+
+func Test_uploadFileParallel(t *testing.T) {
+	server, client, accepted := newUploadTestServer(t, 1)
+	defer server.Close()
+
+	filePath := filepath.Join(t.TempDir(), "disk.vmdk")
+	err := os.WriteFile(filePath, []byte(strings.Repeat("0123456789", 500)), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var uploadError error
+	var progressMutex sync.Mutex
+	var progress []int64
+	count, err := uploadFile(context.Background(), client, filePath, uploadDetails{
+		uploadLink:       server.URL + "/transfer/disk.vmdk",
+		fileSizeToUpload: 5000,
+		uploadPieceSize:  2048,
+		allFilesSize:     5000,
+		callBack: func(bytesUploaded, totalSize int64) {
+			progressMutex.Lock()
+			defer progressMutex.Unlock()
+			progress = append(progress, bytesUploaded)
+		},
+		uploadError: &uploadError,
+		maxRetries:  1,
+		retryDelay:  time.Millisecond,
+		concurrency: 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if count != 5000 {
+		t.Errorf("expected 5000 uploaded bytes, got %d", count)
+	}
+	sort.Strings(*accepted)
+	expected := []string{"bytes 0-2047/5000", "bytes 2048-4095/5000", "bytes 4096-4999/5000"}
+	if strings.Join(*accepted, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected ranges %v, got %v", expected, *accepted)
+	}
+	if len(progress) != 3 || progress[2] != 5000 {
+		t.Errorf("unexpected progress: %v", progress)
+	}
+}
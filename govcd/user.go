@@ -230,7 +230,7 @@ func retrieveUserWithTimeout(ctx context.Context, adminOrg *AdminOrg, userName s
 	// If the user was not retrieved within the allocated time, we inform the user about the failure
 	// and the time it occurred to get to this point, so that they may try with a longer time
 	if err != nil {
-		return nil, fmt.Errorf("failure to retrieve a new user after %s : %s", elapsed, err)
+		return nil, fmt.Errorf("failure to retrieve a new user after %s : %w", elapsed, err)
 	}
 
 	return newUser, nil
@@ -251,7 +251,7 @@ func (adminOrg *AdminOrg) CreateUser(ctx context.Context, userConfiguration *typ
 
 	userCreateHREF, err := url.ParseRequestURI(adminOrg.AdminOrg.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing admin org url: %s", err)
+		return nil, fmt.Errorf("error parsing admin org url: %w", err)
 	}
 	userCreateHREF.Path += "/users"
 
@@ -355,7 +355,7 @@ func (user *OrgUser) Delete(ctx context.Context, takeOwnership bool) error {
 
 	userHREF, err := url.ParseRequestURI(user.User.Href)
 	if err != nil {
-		return fmt.Errorf("error getting HREF for user %s : %s", user.User.Name, err)
+		return fmt.Errorf("error getting HREF for user %s : %w", user.User.Name, err)
 	}
 	util.Logger.Printf("[TRACE] Url for deleting user : %#v and name: %s", userHREF, user.User.Name)
 
@@ -423,7 +423,7 @@ func (user *OrgUser) Update(ctx context.Context) error {
 
 	userHREF, err := url.ParseRequestURI(user.User.Href)
 	if err != nil {
-		return fmt.Errorf("error getting HREF for user %s : %s", user.User.Name, err)
+		return fmt.Errorf("error getting HREF for user %s : %w", user.User.Name, err)
 	}
 	util.Logger.Printf("[TRACE] Url for updating user : %#v and name: %s", userHREF, user.User.Name)
 
@@ -513,7 +513,7 @@ func (user *OrgUser) TakeOwnership(ctx context.Context) error {
 
 	userHREF, err := url.ParseRequestURI(user.User.Href + "/action/takeOwnership")
 	if err != nil {
-		return fmt.Errorf("error getting HREF for user %s : %s", user.User.Name, err)
+		return fmt.Errorf("error getting HREF for user %s : %w", user.User.Name, err)
 	}
 	util.Logger.Printf("[TRACE] Url for taking ownership from user : %#v and name: %s", userHREF, user.User.Name)
 
@@ -20,6 +20,7 @@ import (
 type VApp struct {
 	VApp   *types.VApp
 	client *Client
+	etag   string // ETag of the last retrieval with RefreshIfModified
 }
 
 func NewVApp(cli *Client) *VApp {
@@ -162,12 +163,12 @@ func (vapp *VApp) AddRawVM(ctx context.Context, vAppComposition *types.ReCompose
 	// Return the task
 	task, err := vapp.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost, types.MimeRecomposeVappParams, "error instantiating a new VM: %s", vAppComposition)
 	if err != nil {
-		return nil, fmt.Errorf("error instantiating a new VM: %s", err)
+		return nil, fmt.Errorf("error instantiating a new VM: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("VM creation task failed: %s", err)
+		return nil, fmt.Errorf("VM creation task failed: %w", err)
 	}
 
 	// VM task does not return any reference to VM therefore it must be looked up by name after
@@ -180,7 +181,7 @@ func (vapp *VApp) AddRawVM(ctx context.Context, vAppComposition *types.ReCompose
 
 	vm, err := vapp.GetVMByName(ctx, vmName, true)
 	if err != nil {
-		return nil, fmt.Errorf("error finding VM %s in vApp %s after creation: %s", vAppComposition.Name, vapp.VApp.Name, err)
+		return nil, fmt.Errorf("error finding VM %s in vApp %s after creation: %w", vAppComposition.Name, vapp.VApp.Name, err)
 	}
 
 	return vm, nil
@@ -289,7 +290,7 @@ func addNewVMW(ctx context.Context, vapp *VApp, name string, vappTemplate VAppTe
 func (vapp *VApp) RemoveVM(ctx context.Context, vm VM) error {
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return fmt.Errorf("error refreshing vApp before removing VM: %s", err)
+		return fmt.Errorf("error refreshing vApp before removing VM: %w", err)
 	}
 	task := NewTask(vapp.client)
 	if vapp.VApp.Tasks != nil {
@@ -300,7 +301,7 @@ func (vapp *VApp) RemoveVM(ctx context.Context, vm VM) error {
 			if task.Task.Status != "error" && task.Task.Status != "success" {
 				err := task.WaitTaskCompletion(ctx)
 				if err != nil {
-					return fmt.Errorf("error performing task: %s", err)
+					return fmt.Errorf("error performing task: %w", err)
 				}
 			}
 		}
@@ -326,17 +327,69 @@ func (vapp *VApp) RemoveVM(ctx context.Context, vm VM) error {
 
 	err = deleteTask.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error performing removing VM task: %s", err)
+		return fmt.Errorf("error performing removing VM task: %w", err)
 	}
 
 	return nil
 }
 
+// RemoveVMWithForce removes a VM from the vApp like RemoveVM, but it first takes care of the tasks that are
+// still active on the VM, which would otherwise make the recomposition fail.
+// When cancelBlockingTasks is true, the active tasks of the VM are cancelled before waiting for them to finish.
+// When it is false, the function only waits for their completion.
+func (vapp *VApp) RemoveVMWithForce(ctx context.Context, vm VM, cancelBlockingTasks bool) error {
+	if vm.VM == nil || vm.VM.HREF == "" {
+		return fmt.Errorf("cannot remove VM: VM or its HREF is empty")
+	}
+	if vm.client == nil {
+		vm.client = vapp.client
+	}
+
+	err := vm.Refresh(ctx)
+	if err != nil {
+		return fmt.Errorf("error refreshing VM '%s' before removing it: %w", vm.VM.Name, err)
+	}
+
+	err = finishActiveTasks(ctx, vapp.client, vm.VM.Tasks, cancelBlockingTasks)
+	if err != nil {
+		return fmt.Errorf("error handling active tasks of VM '%s': %w", vm.VM.Name, err)
+	}
+
+	return vapp.RemoveVM(ctx, vm)
+}
+
+// finishActiveTasks waits for the completion of all the tasks in tasksInProgress that are still running,
+// optionally cancelling them first. Tasks that end in error are ignored, as they no longer block the entity.
+func finishActiveTasks(ctx context.Context, client *Client, tasksInProgress *types.TasksInProgress, cancel bool) error {
+	if tasksInProgress == nil {
+		return nil
+	}
+	for _, taskItem := range tasksInProgress.Task {
+		if taskItem == nil || !isTaskRunning(taskItem.Status) {
+			continue
+		}
+		task := NewTask(client)
+		task.Task = taskItem
+		if cancel {
+			util.Logger.Printf("[TRACE] cancelling task %s (%s)\n", taskItem.HREF, taskItem.Operation)
+			err := task.CancelTask(ctx)
+			if err != nil {
+				return fmt.Errorf("error cancelling task %s: %w", taskItem.HREF, err)
+			}
+		}
+		err := task.WaitTaskCompletion(ctx)
+		if err != nil && task.Task.Status != "error" {
+			return err
+		}
+	}
+	return nil
+}
+
 func (vapp *VApp) PowerOn(ctx context.Context) (Task, error) {
 
 	err := vapp.BlockWhileStatus(ctx, "UNRESOLVED", vapp.client.MaxRetryTimeout)
 	if err != nil {
-		return Task{}, fmt.Errorf("error powering on vApp: %s", err)
+		return Task{}, fmt.Errorf("error powering on vApp: %w", err)
 	}
 
 	apiEndpoint := urlParseRequestURI(vapp.VApp.HREF)
@@ -347,6 +400,23 @@ func (vapp *VApp) PowerOn(ctx context.Context) (Task, error) {
 		"", "error powering on vApp: %s", nil)
 }
 
+// PowerOnAndForceCustomization deploys and powers on the vApp, forcing the guest customization of all its VMs,
+// as "Power On and Force Recustomization" does in the UI. The vApp must be undeployed for the customization to run.
+func (vapp *VApp) PowerOnAndForceCustomization(ctx context.Context) (Task, error) {
+	vu := &types.DeployVAppParams{
+		Xmlns:              types.XMLNamespaceVCloud,
+		PowerOn:            true,
+		ForceCustomization: true,
+	}
+
+	apiEndpoint := urlParseRequestURI(vapp.VApp.HREF)
+	apiEndpoint.Path += "/action/deploy"
+
+	// Return the task
+	return vapp.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost,
+		types.MimeDeployVappParams, "error powering on vApp with customization: %s", vu)
+}
+
 func (vapp *VApp) PowerOff(ctx context.Context) (Task, error) {
 
 	apiEndpoint := urlParseRequestURI(vapp.VApp.HREF)
@@ -428,6 +498,67 @@ func (vapp *VApp) Deploy(ctx context.Context) (Task, error) {
 		types.MimeDeployVappParams, "error deploy vApp: %s", vu)
 }
 
+// CreateSnapshotAsync starts the creation of a snapshot of all the VMs of the vApp, replacing their current
+// snapshot, and returns the task. When params is nil, the snapshot includes the memory of the VMs and quiesces
+// their file systems, which are the VCD defaults
+func (vapp *VApp) CreateSnapshotAsync(ctx context.Context, params *types.CreateSnapshotParams) (Task, error) {
+	if params == nil {
+		params = &types.CreateSnapshotParams{}
+	}
+	params.Xmlns = types.XMLNamespaceVCloud
+
+	apiEndpoint := urlParseRequestURI(vapp.VApp.HREF)
+	apiEndpoint.Path += "/action/createSnapshot"
+
+	return vapp.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost,
+		types.MimeCreateSnapshotParams, "error creating snapshot of vApp: %s", params)
+}
+
+// CreateSnapshot creates a snapshot of all the VMs of the vApp and waits for the task to complete
+func (vapp *VApp) CreateSnapshot(ctx context.Context, params *types.CreateSnapshotParams) error {
+	task, err := vapp.CreateSnapshotAsync(ctx, params)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
+
+// RevertToCurrentSnapshotAsync starts reverting all the VMs of the vApp to their current snapshot and returns the task
+func (vapp *VApp) RevertToCurrentSnapshotAsync(ctx context.Context) (Task, error) {
+	apiEndpoint := urlParseRequestURI(vapp.VApp.HREF)
+	apiEndpoint.Path += "/action/revertToCurrentSnapshot"
+
+	return vapp.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost,
+		"", "error reverting vApp to current snapshot: %s", nil)
+}
+
+// RevertToCurrentSnapshot reverts all the VMs of the vApp to their current snapshot and waits for the task to complete
+func (vapp *VApp) RevertToCurrentSnapshot(ctx context.Context) error {
+	task, err := vapp.RevertToCurrentSnapshotAsync(ctx)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
+
+// RemoveAllSnapshotsAsync starts removing the snapshots of all the VMs of the vApp and returns the task
+func (vapp *VApp) RemoveAllSnapshotsAsync(ctx context.Context) (Task, error) {
+	apiEndpoint := urlParseRequestURI(vapp.VApp.HREF)
+	apiEndpoint.Path += "/action/removeAllSnapshots"
+
+	return vapp.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost,
+		"", "error removing snapshots of vApp: %s", nil)
+}
+
+// RemoveAllSnapshots removes the snapshots of all the VMs of the vApp and waits for the task to complete
+func (vapp *VApp) RemoveAllSnapshots(ctx context.Context) error {
+	task, err := vapp.RemoveAllSnapshotsAsync(ctx)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
+
 func (vapp *VApp) Delete(ctx context.Context) (Task, error) {
 
 	// Return the task
@@ -435,6 +566,38 @@ func (vapp *VApp) Delete(ctx context.Context) (Task, error) {
 		"", "error deleting vApp: %s", nil)
 }
 
+// DeleteWithOptions deletes the vApp and its VMs, waiting for the deletion to complete.
+// With options.Force, a deployed vApp is undeployed (powering off its VMs) before the deletion, which would fail
+// otherwise. options.Recursive is accepted, as the VMs are always deleted with the vApp
+func (vapp *VApp) DeleteWithOptions(ctx context.Context, options DeleteOptions) error {
+	err := options.validate("vApp", true, true)
+	if err != nil {
+		return err
+	}
+	if options.Force {
+		err = vapp.Refresh(ctx)
+		if err != nil {
+			return err
+		}
+		if vapp.VApp.Deployed {
+			task, err := vapp.Undeploy(ctx)
+			if err != nil {
+				return err
+			}
+			err = task.WaitTaskCompletion(ctx)
+			if err != nil {
+				return fmt.Errorf("error undeploying vApp %s before deletion: %w", vapp.VApp.Name, err)
+			}
+		}
+	}
+
+	task, err := vapp.Delete(ctx)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
+
 func (vapp *VApp) RunCustomizationScript(ctx context.Context, computername, script string) (Task, error) {
 	return vapp.Customize(ctx, computername, script, false)
 }
@@ -445,7 +608,7 @@ func (vapp *VApp) RunCustomizationScript(ctx context.Context, computername, scri
 func (vapp *VApp) Customize(ctx context.Context, computername, script string, changeSid bool) (Task, error) {
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	// Check if VApp Children is populated
@@ -478,7 +641,7 @@ func (vapp *VApp) Customize(ctx context.Context, computername, script string, ch
 func (vapp *VApp) GetStatus(ctx context.Context) (string, error) {
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return "", fmt.Errorf("error refreshing vApp: %s", err)
+		return "", fmt.Errorf("error refreshing vApp: %w", err)
 	}
 	// Trying to make this function future-proof:
 	// If a new status is added to a future vCD API and the status map in types.go
@@ -496,25 +659,23 @@ func (vapp *VApp) GetStatus(ctx context.Context) (string, error) {
 // It sleeps 200 milliseconds between iterations and times out after timeOutAfterSeconds
 // of seconds.
 func (vapp *VApp) BlockWhileStatus(ctx context.Context, unwantedStatus string, timeOutAfterSeconds int) error {
-	timeoutAfter := time.After(time.Duration(timeOutAfterSeconds) * time.Second)
-	tick := time.NewTicker(200 * time.Millisecond)
-
-	for {
-		select {
-		case <-timeoutAfter:
-			return fmt.Errorf("timed out waiting for vApp to exit state %s after %d seconds",
-				unwantedStatus, timeOutAfterSeconds)
-		case <-tick.C:
-			currentStatus, err := vapp.GetStatus(ctx)
-
-			if err != nil {
-				return fmt.Errorf("could not get vApp status %s", err)
-			}
-			if currentStatus != unwantedStatus {
-				return nil
-			}
+	err := WaitForState(ctx, func() (bool, error) {
+		currentStatus, err := vapp.GetStatus(ctx)
+		if err != nil {
+			return false, fmt.Errorf("could not get vApp status %w", err)
 		}
+		return currentStatus != unwantedStatus, nil
+	}, WaitOptions{
+		InitialDelay:    200 * time.Millisecond,
+		InitialInterval: 200 * time.Millisecond,
+		Multiplier:      1,
+		Timeout:         time.Duration(timeOutAfterSeconds) * time.Second,
+	})
+	if errors.Is(err, ErrorWaitTimeout) {
+		return fmt.Errorf("timed out waiting for vApp to exit state %s after %d seconds",
+			unwantedStatus, timeOutAfterSeconds)
 	}
+	return err
 }
 
 func (vapp *VApp) GetNetworkConnectionSection(ctx context.Context) (*types.NetworkConnectionSection, error) {
@@ -549,7 +710,7 @@ func (vapp *VApp) ChangeCPUCountWithCore(ctx context.Context, virtualCpuCount in
 
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	// Check if VApp Children is populated
@@ -591,7 +752,7 @@ func (vapp *VApp) ChangeCPUCountWithCore(ctx context.Context, virtualCpuCount in
 func (vapp *VApp) ChangeStorageProfile(ctx context.Context, name string) (Task, error) {
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	if vapp.VApp.Children == nil || len(vapp.VApp.Children.VM) == 0 {
@@ -622,7 +783,7 @@ func (vapp *VApp) ChangeStorageProfile(ctx context.Context, name string) (Task,
 func (vapp *VApp) ChangeVMName(ctx context.Context, name string) (Task, error) {
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	if vapp.VApp.Children == nil {
@@ -645,7 +806,7 @@ func (vapp *VApp) ChangeVMName(ctx context.Context, name string) (Task, error) {
 func (vapp *VApp) SetOvf(ctx context.Context, parameters map[string]string) (Task, error) {
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	if vapp.VApp.Children == nil {
@@ -682,7 +843,7 @@ func (vapp *VApp) SetOvf(ctx context.Context, parameters map[string]string) (Tas
 func (vapp *VApp) ChangeNetworkConfig(ctx context.Context, networks []map[string]interface{}, ip string) (Task, error) {
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	if vapp.VApp.Children == nil {
@@ -742,7 +903,7 @@ func (vapp *VApp) ChangeMemorySize(ctx context.Context, size int) (Task, error)
 
 	err := vapp.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing vApp before running customization: %w", err)
 	}
 
 	// Check if VApp Children is populated
@@ -800,7 +961,7 @@ func (vapp *VApp) AddRAWNetworkConfig(ctx context.Context, orgvdcnetworks []*typ
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error getting vApp networks: %s", err)
+		return Task{}, fmt.Errorf("error getting vApp networks: %w", err)
 	}
 	networkConfigurations := vAppNetworkConfig.NetworkConfig
 
@@ -881,7 +1042,7 @@ func (vapp *VApp) CreateVappNetwork(ctx context.Context, newNetworkSettings *Vap
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vApp networks: %#v", err)
+		return nil, fmt.Errorf("error getting vApp networks: %w", err)
 	}
 
 	return vAppNetworkConfig, nil
@@ -963,7 +1124,7 @@ func (vapp *VApp) AddOrgNetwork(ctx context.Context, newNetworkSettings *VappNet
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vApp networks: %#v", err)
+		return nil, fmt.Errorf("error getting vApp networks: %w", err)
 	}
 
 	return vAppNetworkConfig, nil
@@ -1014,7 +1175,7 @@ func (vapp *VApp) UpdateNetwork(ctx context.Context, newNetworkSettings *VappNet
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vApp networks: %#v", err)
+		return nil, fmt.Errorf("error getting vApp networks: %w", err)
 	}
 
 	return vAppNetworkConfig, nil
@@ -1125,7 +1286,7 @@ func (vapp *VApp) UpdateOrgNetwork(ctx context.Context, newNetworkSettings *Vapp
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vApp networks: %#v", err)
+		return nil, fmt.Errorf("error getting vApp networks: %w", err)
 	}
 
 	return vAppNetworkConfig, nil
@@ -1219,7 +1380,7 @@ func (vapp *VApp) RemoveNetwork(ctx context.Context, identifier string) (*types.
 
 	vAppNetworkConfig, err := vapp.GetNetworkConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vApp networks: %#v", err)
+		return nil, fmt.Errorf("error getting vApp networks: %w", err)
 	}
 
 	return vAppNetworkConfig, nil
@@ -1237,7 +1398,7 @@ func (vapp *VApp) RemoveNetworkAsync(ctx context.Context, identifier string) (Ta
 	for _, networkConfig := range networkConfigurations {
 		networkId, err := GetUuidFromHref(networkConfig.Link.HREF, false)
 		if err != nil {
-			return Task{}, fmt.Errorf("unable to get network ID from HREF: %s", err)
+			return Task{}, fmt.Errorf("unable to get network ID from HREF: %w", err)
 		}
 		if networkId == extractUuid(identifier) || networkConfig.NetworkName == identifier {
 			deleteUrl := vapp.client.VCDHREF.String() + "/network/" + networkId
@@ -1313,7 +1474,7 @@ func (vapp *VApp) RemoveAllNetworks(ctx context.Context) (Task, error) {
 func (vapp *VApp) SetProductSectionList(ctx context.Context, productSection *types.ProductSectionList) (*types.ProductSectionList, error) {
 	err := setProductSectionList(ctx, vapp.client, vapp.VApp.HREF, productSection)
 	if err != nil {
-		return nil, fmt.Errorf("unable to set vApp product section: %s", err)
+		return nil, fmt.Errorf("unable to set vApp product section: %w", err)
 	}
 
 	return vapp.GetProductSectionList(ctx)
@@ -1333,7 +1494,7 @@ func (vapp *VApp) GetVMByName(ctx context.Context, vmName string, refresh bool)
 	if refresh {
 		err := vapp.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vApp: %s", err)
+			return nil, fmt.Errorf("error refreshing vApp: %w", err)
 		}
 	}
 
@@ -1361,7 +1522,7 @@ func (vapp *VApp) GetVMById(ctx context.Context, id string, refresh bool) (*VM,
 	if refresh {
 		err := vapp.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vApp: %s", err)
+			return nil, fmt.Errorf("error refreshing vApp: %w", err)
 		}
 	}
 
@@ -1404,7 +1565,7 @@ func (client *Client) QueryVappList(ctx context.Context) ([]*types.QueryResultVA
 	}
 	vappResult, err := client.cumulativeQuery(ctx, queryType, nil, params)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vApp list : %s", err)
+		return nil, fmt.Errorf("error getting vApp list : %w", err)
 	}
 	vappList = vappResult.Results.VAppRecord
 	if client.IsSysAdmin {
@@ -1415,16 +1576,25 @@ func (client *Client) QueryVappList(ctx context.Context) ([]*types.QueryResultVA
 
 // getOrgInfo finds the organization to which the vApp belongs (through the VDC), and returns its name and ID
 func (vapp *VApp) getOrgInfo(ctx context.Context) (*TenantContext, error) {
-	previous, exists := orgInfoCache[vapp.VApp.ID]
-	if exists {
-		return previous, nil
+	useCache := !vapp.client.DisableTenantContextCache
+	if useCache {
+		previous, exists := orgInfoCache.get(vapp.VApp.ID)
+		if exists {
+			return previous, nil
+		}
 	}
-	var err error
 	vdc, err := vapp.getParentVDC(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return vdc.getTenantContext()
+	tenantContext, err := vdc.getTenantContext()
+	if err != nil {
+		return nil, err
+	}
+	if useCache {
+		orgInfoCache.set(vapp.VApp.ID, tenantContext)
+	}
+	return tenantContext, nil
 }
 
 // UpdateNameDescription can change the name and the description of a vApp
@@ -1471,12 +1641,12 @@ func (vapp *VApp) UpdateNameDescription(ctx context.Context, newName, newDescrip
 		opType, "error updating vapp: %s", recomposeParams)
 
 	if err != nil {
-		return fmt.Errorf("unable to update vApp: %s", err)
+		return fmt.Errorf("unable to update vApp: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("task for updating vApp failed: %s", err)
+		return fmt.Errorf("task for updating vApp failed: %w", err)
 	}
 	return vapp.Refresh(ctx)
 }
@@ -1537,12 +1707,12 @@ func (vapp *VApp) RenewLease(ctx context.Context, deploymentLeaseInSeconds, stor
 		types.MimeLeaseSettingSection, "error updating vapp lease : %s", &leaseSettings)
 
 	if err != nil {
-		return fmt.Errorf("unable to update vApp lease: %s", err)
+		return fmt.Errorf("unable to update vApp lease: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("task for updating vApp lease failed: %s", err)
+		return fmt.Errorf("task for updating vApp lease failed: %w", err)
 	}
 	return vapp.Refresh(ctx)
 }
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// VappLeaseReportEntry contains ownership, power state and lease information of a single vApp
+type VappLeaseReportEntry struct {
+	VappName                  string
+	VappHref                  string
+	OwnerName                 string
+	VdcName                   string
+	VdcHref                   string
+	Status                    string // Power state of the vApp, as returned by the query (e.g. POWERED_ON, POWERED_OFF)
+	Deployed                  bool
+	Expired                   bool
+	NumberOfVMs               int
+	DeploymentLeaseInSeconds  int    // 0 means that the runtime lease never expires
+	DeploymentLeaseExpiration string // Empty when the runtime lease never expires or the vApp is not deployed
+	StorageLeaseInSeconds     int    // 0 means that the storage lease never expires
+	StorageLeaseExpiration    string // Empty when the storage lease never expires
+}
+
+// VappLeaseReport is a list of VappLeaseReportEntry, sorted by VDC name and vApp name
+type VappLeaseReport []*VappLeaseReportEntry
+
+// GetVappLeaseReport produces a lease report for every vApp in the Org. vApps are retrieved with paginated
+// queries, one VDC at a time, and their lease settings are retrieved individually.
+func (adminOrg *AdminOrg) GetVappLeaseReport(ctx context.Context) (VappLeaseReport, error) {
+	if adminOrg.AdminOrg.Vdcs == nil {
+		return VappLeaseReport{}, nil
+	}
+
+	var report VappLeaseReport
+	for _, vdcRef := range adminOrg.AdminOrg.Vdcs.Vdcs {
+		records, err := adminOrg.queryVappsInVdc(ctx, vdcRef.HREF)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving vApps from VDC '%s': %w", vdcRef.Name, err)
+		}
+
+		for _, record := range records {
+			entry := &VappLeaseReportEntry{
+				VappName:    record.Name,
+				VappHref:    record.HREF,
+				OwnerName:   record.OwnerName,
+				VdcName:     record.VdcName,
+				VdcHref:     record.VdcHREF,
+				Status:      record.Status,
+				Deployed:    record.Deployed,
+				Expired:     record.Expired,
+				NumberOfVMs: record.NumberOfVMs,
+			}
+			if entry.VdcName == "" {
+				entry.VdcName = vdcRef.Name
+			}
+
+			lease, err := adminOrg.getVappLeaseByHref(ctx, record.HREF)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving lease of vApp '%s': %w", record.Name, err)
+			}
+			entry.DeploymentLeaseInSeconds = lease.DeploymentLeaseInSeconds
+			entry.DeploymentLeaseExpiration = lease.DeploymentLeaseExpiration
+			entry.StorageLeaseInSeconds = lease.StorageLeaseInSeconds
+			entry.StorageLeaseExpiration = lease.StorageLeaseExpiration
+
+			report = append(report, entry)
+		}
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].VdcName != report[j].VdcName {
+			return report[i].VdcName < report[j].VdcName
+		}
+		return report[i].VappName < report[j].VappName
+	})
+
+	return report, nil
+}
+
+// ExpiringBefore returns the entries of the report having a runtime or storage lease that expires before the
+// given deadline. Entries with leases that never expire are not included.
+func (report VappLeaseReport) ExpiringBefore(deadline time.Time) (VappLeaseReport, error) {
+	var result VappLeaseReport
+	for _, entry := range report {
+		expiring := false
+		for _, expiration := range []string{entry.DeploymentLeaseExpiration, entry.StorageLeaseExpiration} {
+			if expiration == "" {
+				continue
+			}
+			expirationTime, err := time.Parse(time.RFC3339, expiration)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing lease expiration '%s' of vApp '%s': %w", expiration, entry.VappName, err)
+			}
+			if expirationTime.Before(deadline) {
+				expiring = true
+			}
+		}
+		if expiring {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// queryVappsInVdc retrieves all the vApp records of a VDC, following all the result pages
+func (adminOrg *AdminOrg) queryVappsInVdc(ctx context.Context, vdcHref string) ([]*types.QueryResultVAppRecordType, error) {
+	client := adminOrg.client
+	queryType := client.GetQueryType(types.QtVapp)
+	params := map[string]string{
+		"type":          queryType,
+		"filter":        "vdc==" + url.QueryEscape(vdcHref),
+		"filterEncoded": "true",
+	}
+	tenantContext, err := adminOrg.tenantContext()
+	if err != nil {
+		return nil, err
+	}
+	results, err := client.cumulativeQueryWithHeaders(ctx, queryType, nil, params, getTenantContextHeader(tenantContext))
+	if err != nil {
+		return nil, err
+	}
+	if client.IsSysAdmin {
+		return results.Results.AdminVAppRecord, nil
+	}
+	return results.Results.VAppRecord, nil
+}
+
+// getVappLeaseByHref retrieves the lease settings of the vApp identified by the given HREF, without
+// retrieving the whole vApp structure
+func (adminOrg *AdminOrg) getVappLeaseByHref(ctx context.Context, vappHref string) (*types.LeaseSettingsSection, error) {
+	var leaseSettings types.LeaseSettingsSection
+	href := strings.TrimSuffix(vappHref, "/") + "/leaseSettingsSection/"
+	_, err := adminOrg.client.ExecuteRequest(ctx, href, http.MethodGet, types.MimeLeaseSettingSection,
+		"error getting vApp lease info: %s", nil, &leaseSettings)
+	if err != nil {
+		return nil, err
+	}
+	return &leaseSettings, nil
+}
@@ -0,0 +1,35 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVappLeaseReport_ExpiringBefore(t *testing.T) {
+	report := VappLeaseReport{
+		{VappName: "never-expires"},
+		{VappName: "runtime-soon", DeploymentLeaseExpiration: "2023-05-10T10:00:00.000Z"},
+		{VappName: "storage-soon", StorageLeaseExpiration: "2023-05-10T12:00:00.000+02:00"},
+		{VappName: "later", DeploymentLeaseExpiration: "2023-06-10T10:00:00.000Z", StorageLeaseExpiration: "2023-07-10T10:00:00Z"},
+	}
+
+	deadline := time.Date(2023, 5, 11, 0, 0, 0, 0, time.UTC)
+	expiring, err := report.ExpiringBefore(deadline)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(expiring) != 2 || expiring[0].VappName != "runtime-soon" || expiring[1].VappName != "storage-soon" {
+		t.Fatalf("unexpected result: %d entries", len(expiring))
+	}
+
+	_, err = VappLeaseReport{{VappName: "invalid", StorageLeaseExpiration: "not-a-date"}}.ExpiringBefore(deadline)
+	if err == nil {
+		t.Fatalf("expected error for invalid expiration date")
+	}
+}
@@ -71,7 +71,7 @@ func (vapp *VApp) GetVappNetworkById(ctx context.Context, id string, refresh boo
 	if refresh {
 		err := vapp.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vApp: %s", err)
+			return nil, fmt.Errorf("error refreshing vApp: %w", err)
 		}
 	}
 
@@ -112,7 +112,7 @@ func (vapp *VApp) GetVappNetworkByName(ctx context.Context, vappNetworkName stri
 	if refresh {
 		err := vapp.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vApp: %s", err)
+			return nil, fmt.Errorf("error refreshing vApp: %w", err)
 		}
 	}
 
@@ -303,3 +303,243 @@ func (vapp *VApp) RemoveAllNetworkStaticRoutes(ctx context.Context, networkId st
 	}
 	return nil
 }
+
+// AddNetworkNatRule adds a NAT rule at the end of the NAT rules of a vApp network, keeping the existing ones.
+// The rule kind must match the NAT type of the network: OneToOneVMRule for "ipTranslation" and VMRule for
+// "portForwarding". When the network has no NAT type yet, it is set from the rule.
+// Returns the rule as saved by VCD, which includes its ID
+func (vapp *VApp) AddNetworkNatRule(ctx context.Context, networkId string, natRule *types.NatRule) (*types.NatRule, error) {
+	if natRule == nil {
+		return nil, fmt.Errorf("cannot add an empty NAT rule")
+	}
+	ruleNatType := natRuleNatType(natRule)
+	if ruleNatType == "" {
+		return nil, fmt.Errorf("NAT rule must define either OneToOneVMRule or VMRule")
+	}
+
+	position := 0
+	vappNetwork, err := vapp.updateVappNetworkFeatures(ctx, networkId, "NAT rules", func(features *types.NetworkFeatures) error {
+		if features.NatService == nil {
+			if features.FirewallService == nil {
+				return fmt.Errorf("provided network isn't connected to org network or isn't fenced")
+			}
+			features.NatService = &types.NatService{}
+		}
+		if features.NatService.NatType == "" {
+			features.NatService.NatType = ruleNatType
+		}
+		if features.NatService.NatType != ruleNatType {
+			return fmt.Errorf("a NAT rule of type '%s' cannot be added to a network using NAT type '%s'",
+				ruleNatType, features.NatService.NatType)
+		}
+		position = len(features.NatService.NatRule)
+		features.NatService.NatRule = append(features.NatService.NatRule, natRule)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	natService := vappNetwork.Configuration.Features.NatService
+	if natService == nil || len(natService.NatRule) <= position {
+		return nil, fmt.Errorf("NAT rule not found in vApp network after creation")
+	}
+	return natService.NatRule[position], nil
+}
+
+// UpdateNetworkNatRule replaces the NAT rule of a vApp network with the same ID as natRule
+// Returns the rule as saved by VCD
+func (vapp *VApp) UpdateNetworkNatRule(ctx context.Context, networkId string, natRule *types.NatRule) (*types.NatRule, error) {
+	if natRule == nil || natRule.ID == "" {
+		return nil, fmt.Errorf("cannot update a NAT rule without ID")
+	}
+
+	position := 0
+	vappNetwork, err := vapp.updateVappNetworkFeatures(ctx, networkId, "NAT rules", func(features *types.NetworkFeatures) error {
+		if features.NatService == nil {
+			return fmt.Errorf("%s: NAT rule with ID '%s'", ErrorEntityNotFound, natRule.ID)
+		}
+		position = natRuleIndex(features.NatService.NatRule, natRule.ID)
+		if position == -1 {
+			return fmt.Errorf("%s: NAT rule with ID '%s'", ErrorEntityNotFound, natRule.ID)
+		}
+		ruleNatType := natRuleNatType(natRule)
+		if ruleNatType != "" && ruleNatType != features.NatService.NatType {
+			return fmt.Errorf("a NAT rule of type '%s' cannot be used in a network using NAT type '%s'",
+				ruleNatType, features.NatService.NatType)
+		}
+		features.NatService.NatRule[position] = natRule
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	natService := vappNetwork.Configuration.Features.NatService
+	if natService == nil || len(natService.NatRule) <= position {
+		return nil, fmt.Errorf("NAT rule not found in vApp network after update")
+	}
+	return natService.NatRule[position], nil
+}
+
+// DeleteNetworkNatRule removes the NAT rule with the given ID from a vApp network, keeping the other ones
+func (vapp *VApp) DeleteNetworkNatRule(ctx context.Context, networkId, natRuleId string) error {
+	_, err := vapp.updateVappNetworkFeatures(ctx, networkId, "NAT rules", func(features *types.NetworkFeatures) error {
+		position := -1
+		if features.NatService != nil {
+			position = natRuleIndex(features.NatService.NatRule, natRuleId)
+		}
+		if position == -1 {
+			return fmt.Errorf("%s: NAT rule with ID '%s'", ErrorEntityNotFound, natRuleId)
+		}
+		features.NatService.NatRule = append(features.NatService.NatRule[:position], features.NatService.NatRule[position+1:]...)
+		return nil
+	})
+	return err
+}
+
+// AddNetworkFirewallRule adds a firewall rule at the end of the firewall rules of a vApp network, keeping the
+// existing ones.
+// Returns the rule as saved by VCD
+func (vapp *VApp) AddNetworkFirewallRule(ctx context.Context, networkId string, firewallRule *types.FirewallRule) (*types.FirewallRule, error) {
+	if firewallRule == nil {
+		return nil, fmt.Errorf("cannot add an empty firewall rule")
+	}
+
+	position := 0
+	vappNetwork, err := vapp.updateVappNetworkFeatures(ctx, networkId, "firewall rules", func(features *types.NetworkFeatures) error {
+		// See UpdateNetworkFirewallRulesAsync: without firewall service, the network is not fenced nor connected
+		if features.FirewallService == nil {
+			return fmt.Errorf("provided network isn't connected to org network or isn't fenced")
+		}
+		position = len(features.FirewallService.FirewallRule)
+		features.FirewallService.FirewallRule = append(features.FirewallService.FirewallRule, firewallRule)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	firewallService := vappNetwork.Configuration.Features.FirewallService
+	if firewallService == nil || len(firewallService.FirewallRule) <= position {
+		return nil, fmt.Errorf("firewall rule not found in vApp network after creation")
+	}
+	return firewallService.FirewallRule[position], nil
+}
+
+// UpdateNetworkFirewallRule replaces the firewall rule of a vApp network with the same ID as firewallRule
+// Returns the rule as saved by VCD
+func (vapp *VApp) UpdateNetworkFirewallRule(ctx context.Context, networkId string, firewallRule *types.FirewallRule) (*types.FirewallRule, error) {
+	if firewallRule == nil || firewallRule.ID == "" {
+		return nil, fmt.Errorf("cannot update a firewall rule without ID")
+	}
+
+	position := 0
+	vappNetwork, err := vapp.updateVappNetworkFeatures(ctx, networkId, "firewall rules", func(features *types.NetworkFeatures) error {
+		position = -1
+		if features.FirewallService != nil {
+			position = firewallRuleIndex(features.FirewallService.FirewallRule, firewallRule.ID)
+		}
+		if position == -1 {
+			return fmt.Errorf("%s: firewall rule with ID '%s'", ErrorEntityNotFound, firewallRule.ID)
+		}
+		features.FirewallService.FirewallRule[position] = firewallRule
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	firewallService := vappNetwork.Configuration.Features.FirewallService
+	if firewallService == nil || len(firewallService.FirewallRule) <= position {
+		return nil, fmt.Errorf("firewall rule not found in vApp network after update")
+	}
+	return firewallService.FirewallRule[position], nil
+}
+
+// DeleteNetworkFirewallRule removes the firewall rule with the given ID from a vApp network, keeping the other ones
+func (vapp *VApp) DeleteNetworkFirewallRule(ctx context.Context, networkId, firewallRuleId string) error {
+	_, err := vapp.updateVappNetworkFeatures(ctx, networkId, "firewall rules", func(features *types.NetworkFeatures) error {
+		position := -1
+		if features.FirewallService != nil {
+			position = firewallRuleIndex(features.FirewallService.FirewallRule, firewallRuleId)
+		}
+		if position == -1 {
+			return fmt.Errorf("%s: firewall rule with ID '%s'", ErrorEntityNotFound, firewallRuleId)
+		}
+		features.FirewallService.FirewallRule = append(features.FirewallService.FirewallRule[:position],
+			features.FirewallService.FirewallRule[position+1:]...)
+		return nil
+	})
+	return err
+}
+
+// updateVappNetworkFeatures retrieves a vApp network, lets changeFeatures modify its network services and saves it,
+// waiting for the task to complete.
+// Returns the updated vApp network
+func (vapp *VApp) updateVappNetworkFeatures(ctx context.Context, networkId, description string, changeFeatures func(features *types.NetworkFeatures) error) (*types.VAppNetwork, error) {
+	uuid := extractUuid(networkId)
+	networkToUpdate, err := vapp.GetVappNetworkById(ctx, uuid, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if networkToUpdate.Configuration.Features == nil {
+		networkToUpdate.Configuration.Features = &types.NetworkFeatures{}
+	}
+	networkToUpdate.Xmlns = types.XMLNamespaceVCloud
+
+	err = changeFeatures(networkToUpdate.Configuration.Features)
+	if err != nil {
+		return nil, err
+	}
+
+	// here we use `PUT /network/{id}` which allow to change vApp network.
+	// But `GET /network/{id}` can return org VDC network or vApp network.
+	apiEndpoint := vapp.client.VCDHREF
+	apiEndpoint.Path += "/network/" + uuid
+
+	task, err := vapp.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPut,
+		types.MimeVappNetwork, "error updating vApp Network "+description+": %s", networkToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s", combinedTaskErrorMessage(task.Task, err))
+	}
+
+	return vapp.GetVappNetworkById(ctx, uuid, false)
+}
+
+// natRuleNatType returns the NAT type of the network service that can hold natRule ("ipTranslation" or
+// "portForwarding"), or an empty string when the rule defines neither a OneToOneVMRule nor a VMRule
+func natRuleNatType(natRule *types.NatRule) string {
+	switch {
+	case natRule.OneToOneVMRule != nil:
+		return "ipTranslation"
+	case natRule.VMRule != nil:
+		return "portForwarding"
+	}
+	return ""
+}
+
+// natRuleIndex returns the position of the NAT rule with the given ID, or -1 when it is not found
+func natRuleIndex(natRules []*types.NatRule, id string) int {
+	for index, natRule := range natRules {
+		if natRule != nil && natRule.ID == id {
+			return index
+		}
+	}
+	return -1
+}
+
+// firewallRuleIndex returns the position of the firewall rule with the given ID, or -1 when it is not found
+func firewallRuleIndex(firewallRules []*types.FirewallRule, id string) int {
+	for index, firewallRule := range firewallRules {
+		if firewallRule != nil && firewallRule.ID == id {
+			return index
+		}
+	}
+	return -1
+}
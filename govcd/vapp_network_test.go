@@ -266,6 +266,97 @@ func (vcd *TestVCD) Test_UpdateNetworkNatRules(check *C) {
 	check.Assert(task.Task.Status, Equals, "success")
 }
 
+func (vcd *TestVCD) Test_UpdateNetworkIndividualRules(check *C) {
+	vapp, networkName, _, err := vcd.prepareVappWithVappNetwork(check, "Test_UpdateNetworkIndividualRules", vcd.config.VCD.Network.Net1)
+	check.Assert(err, IsNil)
+	vappNetwork, err := vapp.GetVappNetworkByName(ctx, networkName, true)
+	check.Assert(err, IsNil)
+	networkId := vappNetwork.ID
+
+	// Firewall rules
+	firstRule, err := vapp.AddNetworkFirewallRule(ctx, networkId, &types.FirewallRule{Description: "firstRule", IsEnabled: true,
+		Policy: "allow", DestinationPortRange: "Any", DestinationIP: "Any", SourcePortRange: "Any", SourceIP: "Any",
+		Protocols: &types.FirewallRuleProtocols{TCP: true}})
+	check.Assert(err, IsNil)
+	check.Assert(firstRule.Description, Equals, "firstRule")
+	secondRule, err := vapp.AddNetworkFirewallRule(ctx, networkId, &types.FirewallRule{Description: "secondRule", IsEnabled: true,
+		Policy: "drop", DestinationPortRange: "Any", DestinationIP: "Any", SourcePortRange: "Any", SourceIP: "Any",
+		Protocols: &types.FirewallRuleProtocols{Any: true}})
+	check.Assert(err, IsNil)
+	check.Assert(secondRule.Description, Equals, "secondRule")
+
+	if firstRule.ID != "" {
+		firstRule.Description = "firstRuleUpdated"
+		firstRule.IsEnabled = false
+		updatedRule, err := vapp.UpdateNetworkFirewallRule(ctx, networkId, firstRule)
+		check.Assert(err, IsNil)
+		check.Assert(updatedRule.ID, Equals, firstRule.ID)
+		check.Assert(updatedRule.Description, Equals, "firstRuleUpdated")
+		check.Assert(updatedRule.IsEnabled, Equals, false)
+
+		err = vapp.DeleteNetworkFirewallRule(ctx, networkId, firstRule.ID)
+		check.Assert(err, IsNil)
+		vappNetwork, err = vapp.GetVappNetworkById(ctx, networkId, true)
+		check.Assert(err, IsNil)
+		check.Assert(len(vappNetwork.Configuration.Features.FirewallService.FirewallRule), Equals, 1)
+		check.Assert(vappNetwork.Configuration.Features.FirewallService.FirewallRule[0].Description, Equals, "secondRule")
+
+		err = vapp.DeleteNetworkFirewallRule(ctx, networkId, firstRule.ID)
+		check.Assert(ContainsNotFound(err), Equals, true)
+	}
+
+	// NAT rules
+	catalog, err := vcd.org.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+	catalogItem, err := catalog.GetCatalogItemByName(ctx, vcd.config.VCD.Catalog.CatalogItem, false)
+	check.Assert(err, IsNil)
+	vappTemplate, err := catalogItem.GetVAppTemplate(ctx)
+	check.Assert(err, IsNil)
+	desiredNetConfig := types.NetworkConnectionSection{}
+	desiredNetConfig.NetworkConnection = append(desiredNetConfig.NetworkConnection,
+		&types.NetworkConnection{
+			IsConnected:             true,
+			IPAddressAllocationMode: types.IPAllocationModePool,
+			Network:                 networkName,
+			NetworkConnectionIndex:  0,
+		})
+	vm, err := spawnVM(ctx, "FirstNode", 512, *vcd.vdc, *vapp, desiredNetConfig, vappTemplate, check, "", false)
+	check.Assert(err, IsNil)
+
+	natRule, err := vapp.AddNetworkNatRule(ctx, networkId, &types.NatRule{VMRule: &types.NatVMRule{
+		ExternalPort: -1, InternalPort: 22, VMNicID: 0, VAppScopedVMID: vm.VM.VAppScopedLocalID, Protocol: "TCP"}})
+	check.Assert(err, IsNil)
+	check.Assert(natRule.ID, Not(Equals), "")
+	check.Assert(natRule.VMRule.InternalPort, Equals, 22)
+
+	// A rule of a different kind cannot be mixed with port forwarding rules
+	_, err = vapp.AddNetworkNatRule(ctx, networkId, &types.NatRule{OneToOneVMRule: &types.NatOneToOneVMRule{
+		MappingMode: "automatic", VMNicID: 0, VAppScopedVMID: vm.VM.VAppScopedLocalID}})
+	check.Assert(err, NotNil)
+
+	natRule.VMRule.InternalPort = 2222
+	natRule, err = vapp.UpdateNetworkNatRule(ctx, networkId, natRule)
+	check.Assert(err, IsNil)
+	check.Assert(natRule.VMRule.InternalPort, Equals, 2222)
+
+	err = vapp.DeleteNetworkNatRule(ctx, networkId, natRule.ID)
+	check.Assert(err, IsNil)
+	vappNetwork, err = vapp.GetVappNetworkById(ctx, networkId, true)
+	check.Assert(err, IsNil)
+	check.Assert(len(vappNetwork.Configuration.Features.NatService.NatRule), Equals, 0)
+
+	//cleanup
+	task, err := vapp.RemoveAllNetworks(ctx)
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+	task, err = vapp.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(task.Task.Status, Equals, "success")
+}
+
 func createRoutedNetwork(vcd *TestVCD, check *C, networkName string) {
 	edgeGWName := vcd.config.VCD.EdgeGateway
 	if edgeGWName == "" {
@@ -0,0 +1,51 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_natRuleNatType(t *testing.T) {
+	tests := []struct {
+		name    string
+		natRule *types.NatRule
+		want    string
+	}{
+		{name: "OneToOneVMRule", natRule: &types.NatRule{OneToOneVMRule: &types.NatOneToOneVMRule{}}, want: "ipTranslation"},
+		{name: "VMRule", natRule: &types.NatRule{VMRule: &types.NatVMRule{}}, want: "portForwarding"},
+		{name: "Empty", natRule: &types.NatRule{}, want: ""},
+		{name: "GatewayNatRule", natRule: &types.NatRule{GatewayNatRule: &types.GatewayNatRule{}}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := natRuleNatType(tt.natRule); got != tt.want {
+				t.Errorf("natRuleNatType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_vappNetworkRuleIndex(t *testing.T) {
+	natRules := []*types.NatRule{{ID: "65537"}, nil, {ID: "65538"}}
+	if got := natRuleIndex(natRules, "65538"); got != 2 {
+		t.Errorf("natRuleIndex() = %d, want 2", got)
+	}
+	if got := natRuleIndex(natRules, "65539"); got != -1 {
+		t.Errorf("natRuleIndex() = %d, want -1", got)
+	}
+
+	firewallRules := []*types.FirewallRule{{ID: "1"}, {ID: "2"}}
+	if got := firewallRuleIndex(firewallRules, "1"); got != 0 {
+		t.Errorf("firewallRuleIndex() = %d, want 0", got)
+	}
+	if got := firewallRuleIndex(firewallRules, ""); got != -1 {
+		t.Errorf("firewallRuleIndex() = %d, want -1", got)
+	}
+}
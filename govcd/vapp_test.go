@@ -307,6 +307,45 @@ func (vcd *TestVCD) Test_Deploy(check *C) {
 	check.Assert(task.Task.Status, Equals, "success")
 }
 
+func (vcd *TestVCD) Test_VappSnapshot(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vApp was not successfully created at setup")
+	}
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	vmType, vmName := vcd.findFirstVm(*vcd.vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm := NewVM(&vcd.client.Client)
+	vm.VM = &vmType
+
+	// Memory and quiescing are disabled, as they depend on the state of the VMs and on their guest tools
+	err := vcd.vapp.CreateSnapshot(ctx, &types.CreateSnapshotParams{
+		Name:    check.TestName(),
+		Memory:  addrOf(false),
+		Quiesce: addrOf(false),
+	})
+	check.Assert(err, IsNil)
+
+	err = vm.Refresh(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(vm.VM.Snapshots, NotNil)
+	check.Assert(len(vm.VM.Snapshots.Snapshot), Equals, 1)
+
+	err = vcd.vapp.RevertToCurrentSnapshot(ctx)
+	check.Assert(err, IsNil)
+
+	err = vcd.vapp.RemoveAllSnapshots(ctx)
+	check.Assert(err, IsNil)
+
+	err = vm.Refresh(ctx)
+	check.Assert(err, IsNil)
+	if vm.VM.Snapshots != nil {
+		check.Assert(len(vm.VM.Snapshots.Snapshot), Equals, 0)
+	}
+}
+
 // TODO: Find out if there is a way to check if the vapp is on without
 // powering it on.
 func (vcd *TestVCD) Test_PowerOff(check *C) {
@@ -487,6 +526,48 @@ func (vcd *TestVCD) Test_AddNewVMNilNIC(check *C) {
 	check.Assert(task.Task.Status, Equals, "success")
 }
 
+// Test_RemoveVMWithForce checks that a VM with an active task can be removed from the vApp
+func (vcd *TestVCD) Test_RemoveVMWithForce(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	cat, err := vcd.org.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+	catitem, err := cat.GetCatalogItemByName(ctx, vcd.config.VCD.Catalog.CatalogItem, false)
+	check.Assert(err, IsNil)
+	vapptemplate, err := catitem.GetVAppTemplate(ctx)
+	check.Assert(err, IsNil)
+
+	vapp, err := deployVappForTest(ctx, vcd, check.TestName())
+	check.Assert(err, IsNil)
+	check.Assert(vapp, NotNil)
+	task, err := vapp.AddNewVM(ctx, check.TestName(), vapptemplate, nil, true)
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+
+	vm, err := vapp.GetVMByName(ctx, check.TestName(), true)
+	check.Assert(err, IsNil)
+
+	// Start a task on the VM without waiting for it
+	_, err = vm.UpdateVmCpuAndMemoryHotAddAsync(ctx, true, true)
+	check.Assert(err, IsNil)
+
+	err = vapp.RemoveVMWithForce(ctx, *vm, true)
+	check.Assert(err, IsNil)
+
+	_, err = vapp.GetVMByName(ctx, check.TestName(), true)
+	check.Assert(ContainsNotFound(err), Equals, true)
+
+	task, err = vapp.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(task.Task.Status, Equals, "success")
+}
+
 // Test_AddNewVMMultiNIC creates a new VM in vApp with multiple network cards
 func (vcd *TestVCD) Test_AddNewVMMultiNIC(check *C) {
 
@@ -1853,3 +1934,37 @@ func (vcd *TestVCD) Test_Vapp_LeaseUpdate(check *C) {
 	err = task.WaitTaskCompletion(ctx)
 	check.Assert(err, IsNil)
 }
+
+func (vcd *TestVCD) Test_GetVappLeaseReport(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vApp wasn't properly created")
+	}
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	report, err := adminOrg.GetVappLeaseReport(ctx)
+	check.Assert(err, IsNil)
+
+	lease, err := vcd.vapp.GetLease(ctx)
+	check.Assert(err, IsNil)
+
+	found := false
+	for _, entry := range report {
+		if entry.VappHref == vcd.vapp.VApp.HREF {
+			found = true
+			check.Assert(entry.VappName, Equals, vcd.vapp.VApp.Name)
+			check.Assert(entry.VdcName, Equals, vcd.vdc.Vdc.Name)
+			check.Assert(entry.OwnerName, Not(Equals), "")
+			check.Assert(entry.DeploymentLeaseInSeconds, Equals, lease.DeploymentLeaseInSeconds)
+			check.Assert(entry.StorageLeaseInSeconds, Equals, lease.StorageLeaseInSeconds)
+		}
+	}
+	check.Assert(found, Equals, true)
+
+	// Nothing can be expired in the past
+	expiring, err := report.ExpiringBefore(time.Now().AddDate(-10, 0, 0))
+	check.Assert(err, IsNil)
+	check.Assert(len(expiring), Equals, 0)
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/vmware/go-vcloud-director/v2/util"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
 )
 
 type VAppTemplate struct {
@@ -28,29 +30,372 @@ func NewVAppTemplate(cli *Client) *VAppTemplate {
 func (vdc *Vdc) InstantiateVAppTemplate(ctx context.Context, template *types.InstantiateVAppTemplateParams) error {
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return fmt.Errorf("error getting vdc href: %s", err)
+		return fmt.Errorf("error getting vdc href: %w", err)
 	}
 	vdcHref.Path += "/action/instantiateVAppTemplate"
 
 	var vapp types.VApp
 
 	_, err = vdc.client.ExecuteRequest(ctx, vdcHref.String(), http.MethodPost,
-		types.MimeInstantiateVappTemplateParams, "error instantiating a new vApp Template: %s", template, vapp)
+		types.MimeInstantiateVappTemplateParams, "error instantiating a new vApp Template: %s", template, &vapp)
 	if err != nil {
 		return err
 	}
+	if vapp.Tasks == nil {
+		return nil
+	}
 
 	task := NewTask(vdc.client)
 	for _, taskItem := range vapp.Tasks.Task {
 		task.Task = taskItem
 		err = task.WaitTaskCompletion(ctx)
 		if err != nil {
-			return fmt.Errorf("error performing task: %s", err)
+			return fmt.Errorf("error performing task: %w", err)
+		}
+	}
+	return nil
+}
+
+// VAppTemplateStorageProfiles defines the storage profiles of the VMs of a vApp template to use at instantiation,
+// instead of the defaults of the target VDC. Storage profiles are identified by name.
+type VAppTemplateStorageProfiles struct {
+	// Vms maps the name of a template VM to the storage profile of the VM
+	Vms map[string]string
+	// Disks maps the name of a template VM to the storage profiles of its disks, keyed by the InstanceID of the
+	// disk in the VirtualHardwareSection of the template VM. Disks not listed use the storage profile of the VM
+	Disks map[string]map[int]string
+}
+
+// InstantiateVAppTemplateWithStorageProfiles instantiates a vApp template like InstantiateVAppTemplate, placing
+// VMs and disks on the storage profiles set in storageProfiles. All the storage profiles are validated against
+// the ones available in the VDC before the request is sent, so that no relocation is needed after creation.
+// If params.Source is empty, it is set to vAppTemplate. Sourced items already in params are updated in place.
+func (vdc *Vdc) InstantiateVAppTemplateWithStorageProfiles(ctx context.Context, params *types.InstantiateVAppTemplateParams,
+	vAppTemplate *VAppTemplate, storageProfiles VAppTemplateStorageProfiles) error {
+	if params == nil || vAppTemplate == nil || vAppTemplate.VAppTemplate == nil {
+		return fmt.Errorf("instantiation parameters and vApp template are required")
+	}
+
+	err := vdc.Refresh(ctx)
+	if err != nil {
+		return fmt.Errorf("error refreshing VDC: %w", err)
+	}
+	var vdcStorageProfiles []*types.Reference
+	if vdc.Vdc.VdcStorageProfiles != nil {
+		vdcStorageProfiles = vdc.Vdc.VdcStorageProfiles.VdcStorageProfile
+	}
+
+	err = setSourcedItemStorageProfiles(&params.SourcedItem, vAppTemplate.VAppTemplate, vdcStorageProfiles, storageProfiles)
+	if err != nil {
+		return fmt.Errorf("error setting storage profiles for vApp template '%s' in VDC '%s': %s",
+			vAppTemplate.VAppTemplate.Name, vdc.Vdc.Name, err)
+	}
+	if params.Source == nil {
+		params.Source = &types.Reference{HREF: vAppTemplate.VAppTemplate.HREF, Name: vAppTemplate.VAppTemplate.Name}
+	}
+	return vdc.InstantiateVAppTemplate(ctx, params)
+}
+
+// setSourcedItemStorageProfiles adds to sourcedItems, the sourced items of an instantiation or of a composition, the
+// items needed to set the VM and disk storage profiles requested in storageProfiles, after checking that VMs, disks and
+// storage profiles exist
+func setSourcedItemStorageProfiles(sourcedItems *[]*types.SourcedCompositionItemParam, vAppTemplate *types.VAppTemplate,
+	vdcStorageProfiles []*types.Reference, storageProfiles VAppTemplateStorageProfiles) error {
+	profilesByName := make(map[string]*types.Reference, len(vdcStorageProfiles))
+	for _, profile := range vdcStorageProfiles {
+		profilesByName[profile.Name] = profile
+	}
+	getProfile := func(name string) (*types.Reference, error) {
+		profile, ok := profilesByName[name]
+		if !ok {
+			available := make([]string, 0, len(profilesByName))
+			for profileName := range profilesByName {
+				available = append(available, profileName)
+			}
+			sort.Strings(available)
+			return nil, fmt.Errorf("storage profile '%s' not available in VDC (available: %s)", name, strings.Join(available, ", "))
+		}
+		return &types.Reference{HREF: profile.HREF, ID: profile.ID, Name: profile.Name}, nil
+	}
+
+	vmsByName := make(map[string]*types.VAppTemplate)
+	if vAppTemplate.Children != nil {
+		for _, vm := range vAppTemplate.Children.VM {
+			vmsByName[vm.Name] = vm
+		}
+	}
+	getVm := func(name string) (*types.VAppTemplate, error) {
+		vm, ok := vmsByName[name]
+		if !ok {
+			return nil, fmt.Errorf("VM '%s' not found in vApp template", name)
+		}
+		return vm, nil
+	}
+	// Everything is validated before params is changed
+	vmProfiles := make(map[*types.VAppTemplate]*types.Reference)
+	for vmName, profileName := range storageProfiles.Vms {
+		vm, err := getVm(vmName)
+		if err != nil {
+			return err
+		}
+		vmProfiles[vm], err = getProfile(profileName)
+		if err != nil {
+			return fmt.Errorf("VM '%s': %w", vmName, err)
+		}
+	}
+	diskItems := make(map[*types.VAppTemplate][]*types.InstantiationDiskItem)
+	for vmName, diskProfiles := range storageProfiles.Disks {
+		vm, err := getVm(vmName)
+		if err != nil {
+			return err
+		}
+		items, err := getInstantiationDiskItems(vm, diskProfiles, getProfile)
+		if err != nil {
+			return fmt.Errorf("VM '%s': %w", vmName, err)
+		}
+		diskItems[vm] = items
+	}
+
+	if len(vmProfiles) == 0 && len(diskItems) == 0 {
+		return nil
+	}
+	// Sourced items are added in the order of the template VMs
+	for _, vm := range vAppTemplate.Children.VM {
+		if profile, ok := vmProfiles[vm]; ok {
+			getSourcedItem(sourcedItems, vm).StorageProfile = profile
+		}
+		items, ok := diskItems[vm]
+		if !ok {
+			continue
+		}
+		item := getSourcedItem(sourcedItems, vm)
+		if item.InstantiationParams == nil {
+			item.InstantiationParams = &types.InstantiationParams{}
+		}
+		item.InstantiationParams.VirtualHardwareSection = &types.InstantiationVirtualHardwareSection{
+			XmlnsRasd:   types.XMLNamespaceRASD,
+			XmlnsVCloud: types.XMLNamespaceVCloud,
+			Info:        "Virtual hardware requirements",
+			Item:        items,
 		}
 	}
 	return nil
 }
 
+// getSourcedItem returns the sourced item of sourcedItems for the template VM vm, adding it when it does not exist
+func getSourcedItem(sourcedItems *[]*types.SourcedCompositionItemParam, vm *types.VAppTemplate) *types.SourcedCompositionItemParam {
+	for _, item := range *sourcedItems {
+		if item.Source != nil && item.Source.HREF == vm.HREF {
+			return item
+		}
+	}
+	item := &types.SourcedCompositionItemParam{Source: &types.Reference{HREF: vm.HREF, Name: vm.Name}}
+	*sourcedItems = append(*sourcedItems, item)
+	return item
+}
+
+// getInstantiationDiskItems returns the disk items that place the disks of a template VM on the storage profiles
+// given in diskProfiles (disk InstanceID to storage profile name)
+func getInstantiationDiskItems(vm *types.VAppTemplate, diskProfiles map[int]string,
+	getProfile func(name string) (*types.Reference, error)) ([]*types.InstantiationDiskItem, error) {
+	disksById := make(map[int]*types.VirtualHardwareItem)
+	if vm.VirtualHardwareSection != nil {
+		for _, item := range vm.VirtualHardwareSection.Item {
+			if item.ResourceType == types.ResourceTypeDisk {
+				disksById[item.InstanceID] = item
+			}
+		}
+	}
+
+	instanceIds := make([]int, 0, len(diskProfiles))
+	for instanceId := range diskProfiles {
+		instanceIds = append(instanceIds, instanceId)
+	}
+	sort.Ints(instanceIds)
+
+	var items []*types.InstantiationDiskItem
+	for _, instanceId := range instanceIds {
+		disk, ok := disksById[instanceId]
+		if !ok {
+			return nil, fmt.Errorf("disk with InstanceID %d not found", instanceId)
+		}
+		profile, err := getProfile(diskProfiles[instanceId])
+		if err != nil {
+			return nil, fmt.Errorf("disk %d: %w", instanceId, err)
+		}
+		hostResource := &types.InstantiationDiskHostResource{
+			StorageProfile:    profile.HREF,
+			OverrideVmDefault: true,
+		}
+		if len(disk.HostResource) > 0 {
+			hostResource.BusType = disk.HostResource[0].BusType
+			hostResource.BusSubType = disk.HostResource[0].BusSubType
+			hostResource.Capacity = disk.HostResource[0].Capacity
+		}
+		items = append(items, &types.InstantiationDiskItem{
+			AddressOnParent: disk.AddressOnParent,
+			ElementName:     disk.ElementName,
+			HostResource:    hostResource,
+			InstanceID:      disk.InstanceID,
+			Parent:          disk.Parent,
+			ResourceType:    disk.ResourceType,
+		})
+	}
+	return items, nil
+}
+
+// VAppTemplateProductProperties defines the values of the OVF properties (product sections) of a vApp template to
+// set at instantiation. Properties are identified by key and must be user configurable.
+type VAppTemplateProductProperties struct {
+	// VApp maps the key of an OVF property of the vApp template to its value
+	VApp map[string]string
+	// Vms maps the name of a template VM to the values of its OVF properties, keyed by property key
+	Vms map[string]map[string]string
+}
+
+// InstantiateVAppTemplateWithProperties instantiates a vApp template like InstantiateVAppTemplate, setting the
+// values of the OVF properties given in properties, so that appliances with mandatory properties can be deployed
+// and powered on in one call, without changing the product sections after creation.
+// The properties are validated against the ones defined in the vApp template and in its VMs before the request is
+// sent. If params.Source is empty, it is set to vAppTemplate. Sourced items already in params are updated in place.
+func (vdc *Vdc) InstantiateVAppTemplateWithProperties(ctx context.Context, params *types.InstantiateVAppTemplateParams,
+	vAppTemplate *VAppTemplate, properties VAppTemplateProductProperties) error {
+	if params == nil || vAppTemplate == nil || vAppTemplate.VAppTemplate == nil {
+		return fmt.Errorf("instantiation parameters and vApp template are required")
+	}
+
+	// Product sections of the template and of its VMs, keyed by HREF
+	definitions := make(map[string]*types.ProductSectionList)
+	var err error
+	if len(properties.VApp) > 0 {
+		definitions[vAppTemplate.VAppTemplate.HREF], err = getProductSectionList(ctx, vdc.client, vAppTemplate.VAppTemplate.HREF)
+		if err != nil {
+			return fmt.Errorf("error retrieving product section of vApp template '%s': %w", vAppTemplate.VAppTemplate.Name, err)
+		}
+	}
+	if vAppTemplate.VAppTemplate.Children != nil {
+		for _, vm := range vAppTemplate.VAppTemplate.Children.VM {
+			if _, ok := properties.Vms[vm.Name]; !ok {
+				continue
+			}
+			definitions[vm.HREF], err = getProductSectionList(ctx, vdc.client, vm.HREF)
+			if err != nil {
+				return fmt.Errorf("error retrieving product section of template VM '%s': %w", vm.Name, err)
+			}
+		}
+	}
+
+	err = setInstantiationProductSections(params, vAppTemplate.VAppTemplate, definitions, properties)
+	if err != nil {
+		return fmt.Errorf("error setting OVF properties for vApp template '%s': %w", vAppTemplate.VAppTemplate.Name, err)
+	}
+	return vdc.InstantiateVAppTemplate(ctx, params)
+}
+
+// setInstantiationProductSections adds to params the product sections that set the OVF properties requested in
+// properties, after checking them against definitions (the product sections of the template and its VMs, keyed by
+// HREF)
+func setInstantiationProductSections(params *types.InstantiateVAppTemplateParams, vAppTemplate *types.VAppTemplate,
+	definitions map[string]*types.ProductSectionList, properties VAppTemplateProductProperties) error {
+	vmsByName := make(map[string]*types.VAppTemplate)
+	if vAppTemplate.Children != nil {
+		for _, vm := range vAppTemplate.Children.VM {
+			vmsByName[vm.Name] = vm
+		}
+	}
+
+	// Everything is validated before params is changed
+	var vAppSection *types.InstantiationProductSection
+	if len(properties.VApp) > 0 {
+		var err error
+		vAppSection, err = getInstantiationProductSection(definitions[vAppTemplate.HREF], properties.VApp)
+		if err != nil {
+			return fmt.Errorf("vApp: %w", err)
+		}
+	}
+	vmSections := make(map[*types.VAppTemplate]*types.InstantiationProductSection)
+	for vmName, values := range properties.Vms {
+		vm, ok := vmsByName[vmName]
+		if !ok {
+			return fmt.Errorf("VM '%s' not found in vApp template", vmName)
+		}
+		section, err := getInstantiationProductSection(definitions[vm.HREF], values)
+		if err != nil {
+			return fmt.Errorf("VM '%s': %w", vmName, err)
+		}
+		vmSections[vm] = section
+	}
+
+	if params.Source == nil {
+		params.Source = &types.Reference{HREF: vAppTemplate.HREF, Name: vAppTemplate.Name}
+	}
+	if vAppSection != nil {
+		if params.InstantiationParams == nil {
+			params.InstantiationParams = &types.InstantiationParams{}
+		}
+		params.InstantiationParams.InstantiationProductSection = vAppSection
+	}
+	if len(vmSections) == 0 {
+		return nil
+	}
+	// Sourced items are added in the order of the template VMs
+	for _, vm := range vAppTemplate.Children.VM {
+		section, ok := vmSections[vm]
+		if !ok {
+			continue
+		}
+		item := getSourcedItem(&params.SourcedItem, vm)
+		if item.InstantiationParams == nil {
+			item.InstantiationParams = &types.InstantiationParams{}
+		}
+		item.InstantiationParams.InstantiationProductSection = section
+	}
+	return nil
+}
+
+// getInstantiationProductSection returns the product section that sets the OVF properties in values (property key
+// to value), checking that they are defined in definition and user configurable
+func getInstantiationProductSection(definition *types.ProductSectionList, values map[string]string) (*types.InstantiationProductSection, error) {
+	propertiesByKey := make(map[string]*types.Property)
+	if definition != nil && definition.ProductSection != nil {
+		for _, property := range definition.ProductSection.Property {
+			propertiesByKey[property.Key] = property
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	section := &types.InstantiationProductSection{
+		XmlnsOvf: types.XMLNamespaceOVF,
+		Info:     "Information about the installed software",
+	}
+	for _, key := range keys {
+		property, ok := propertiesByKey[key]
+		if !ok {
+			available := make([]string, 0, len(propertiesByKey))
+			for propertyKey := range propertiesByKey {
+				available = append(available, propertyKey)
+			}
+			sort.Strings(available)
+			return nil, fmt.Errorf("OVF property '%s' not found (available: %s)", key, strings.Join(available, ", "))
+		}
+		if !property.UserConfigurable {
+			return nil, fmt.Errorf("OVF property '%s' is not user configurable", key)
+		}
+		section.Property = append(section.Property, &types.InstantiationProperty{
+			Key:              key,
+			Type:             property.Type,
+			UserConfigurable: true,
+			Value:            values[key],
+		})
+	}
+	return section, nil
+}
+
 // Refresh refreshes the vApp template item information by href
 func (vAppTemplate *VAppTemplate) Refresh(ctx context.Context) error {
 
@@ -113,6 +458,36 @@ func (vAppTemplate *VAppTemplate) GetVappTemplateRecord(ctx context.Context) (*t
 	return queriedVappTemplates[0], nil
 }
 
+// getOrgInfo finds the organization to which the vApp template belongs, and returns its name and ID
+func (vAppTemplate *VAppTemplate) getOrgInfo(ctx context.Context) (*TenantContext, error) {
+	useCache := !vAppTemplate.client.DisableTenantContextCache
+	if useCache {
+		previous, exists := orgInfoCache.get(vAppTemplate.VAppTemplate.ID)
+		if exists {
+			return previous, nil
+		}
+	}
+	record, err := vAppTemplate.GetVappTemplateRecord(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if record.Org == "" {
+		return nil, fmt.Errorf("no organization found for vApp template %s", vAppTemplate.VAppTemplate.Name)
+	}
+	org, err := getOrgByHref(ctx, vAppTemplate.client, record.Org)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving organization of vApp template %s: %w", vAppTemplate.VAppTemplate.Name, err)
+	}
+	tenantContext, err := org.tenantContext()
+	if err != nil {
+		return nil, err
+	}
+	if useCache {
+		orgInfoCache.set(vAppTemplate.VAppTemplate.ID, tenantContext)
+	}
+	return tenantContext, nil
+}
+
 // Update updates the vApp template item information.
 // VCD also updates the associated Catalog Item, in order to be in sync with the receiver vApp Template entity.
 // For example, updating a vApp Template name "A" to "B" will make VCD to also update the Catalog Item to be renamed to "B".
@@ -133,15 +508,25 @@ func (vAppTemplate *VAppTemplate) Update(ctx context.Context) (*VAppTemplate, er
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for task completion after updating vApp Template %s: %s", vAppTemplate.VAppTemplate.Name, err)
+		return nil, fmt.Errorf("error waiting for task completion after updating vApp Template %s: %w", vAppTemplate.VAppTemplate.Name, err)
 	}
 	err = vAppTemplate.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing vApp Template %s: %s", vAppTemplate.VAppTemplate.Name, err)
+		return nil, fmt.Errorf("error refreshing vApp Template %s: %w", vAppTemplate.VAppTemplate.Name, err)
 	}
 	return vAppTemplate, nil
 }
 
+// SetGoldMaster sets or removes the gold master flag of the vApp template, which marks it as the reference
+// template of its catalog
+func (vAppTemplate *VAppTemplate) SetGoldMaster(ctx context.Context, goldMaster bool) (*VAppTemplate, error) {
+	if vAppTemplate.VAppTemplate == nil {
+		return nil, fmt.Errorf("cannot update, Object is empty")
+	}
+	vAppTemplate.VAppTemplate.GoldMaster = goldMaster
+	return vAppTemplate.Update(ctx)
+}
+
 // UpdateAsync updates the vApp template item information
 // Returns Task and error.
 func (vAppTemplate *VAppTemplate) UpdateAsync(ctx context.Context) (Task, error) {
@@ -191,7 +576,7 @@ func (vAppTemplate *VAppTemplate) Delete(ctx context.Context) error {
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error waiting for task completion after deleting vApp Template %s: %s", vAppTemplate.VAppTemplate.Name, err)
+		return fmt.Errorf("error waiting for task completion after deleting vApp Template %s: %w", vAppTemplate.VAppTemplate.Name, err)
 	}
 	return nil
 }
@@ -226,7 +611,7 @@ func (vcdClient *VCDClient) QuerySynchronizedVAppTemplateById(ctx context.Contex
 			";status!=FAILED_CREATION;status!=UNKNOWN;status!=UNRECOGNIZED;status!=UNRESOLVED;status!=LOCAL_COPY_UNAVAILABLE&links=true",
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("[QueryVAppTemplateById] error quering vApp templates with ID %s: %s", vAppTemplateId, err)
+		return nil, fmt.Errorf("[QueryVAppTemplateById] error quering vApp templates with ID %s: %w", vAppTemplateId, err)
 	}
 
 	vAppTemplateRecords := results.Results.VappTemplateRecord
@@ -260,7 +645,7 @@ func (vcdClient *VCDClient) QueryVmInVAppTemplateByHref(ctx context.Context, vAp
 			";isVAppTemplate==true;status!=FAILED_CREATION;status!=UNKNOWN;status!=UNRECOGNIZED;status!=UNRESOLVED&links=true;",
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("[QueryVmInVAppTemplateByHref] error quering vApp templates with HREF %s:, VM name: %s: Error: %s", vAppTemplateHref, vmNameInTemplate, err)
+		return nil, fmt.Errorf("[QueryVmInVAppTemplateByHref] error quering vApp templates with HREF %s:, VM name: %s: Error: %w", vAppTemplateHref, vmNameInTemplate, err)
 	}
 
 	vmResults := results.Results.VMRecord
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// VAppTemplateDownloadOptions are the optional settings of VAppTemplate.Download and VAppTemplate.DownloadOva
+type VAppTemplateDownloadOptions struct {
+	// DownloadPieceSize is the size of the ranges in which each file is downloaded. Defaults to 1 MB
+	DownloadPieceSize int64
+	// ProgressCallback, when set, is called after each downloaded piece with the bytes downloaded so far and the
+	// total size of the files
+	ProgressCallback func(bytesDownloaded, totalSize int64)
+	// KeepDownloadEnabled leaves the vApp template enabled for download after the download completes
+	KeepDownloadEnabled bool
+}
+
+// EnableDownload makes the vApp template available for download and waits for VCD to prepare its files
+func (vAppTemplate *VAppTemplate) EnableDownload(ctx context.Context) error {
+	task, err := vAppTemplate.client.ExecuteTaskRequest(ctx, vAppTemplate.VAppTemplate.HREF+"/action/enableDownload",
+		http.MethodPost, "", "error enabling download of vApp Template: %s", nil)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return fmt.Errorf("error enabling download of vApp Template %s: %w", vAppTemplate.VAppTemplate.Name, err)
+	}
+	return vAppTemplate.Refresh(ctx)
+}
+
+// DisableDownload makes the vApp template no longer available for download
+func (vAppTemplate *VAppTemplate) DisableDownload(ctx context.Context) error {
+	return vAppTemplate.client.ExecuteRequestWithoutResponse(ctx, vAppTemplate.VAppTemplate.HREF+"/action/disableDownload",
+		http.MethodPost, "", "error disabling download of vApp Template: %s", nil)
+}
+
+// Download exports the vApp template as OVF into directory, which is created if it does not exist.
+// The OVF descriptor is saved with the name of the vApp template and the files it references are saved next to it.
+// Returns the path of the OVF descriptor.
+func (vAppTemplate *VAppTemplate) Download(ctx context.Context, directory string, options VAppTemplateDownloadOptions) (string, error) {
+	descriptor, files, err := vAppTemplate.prepareDownload(ctx, options)
+	if err != nil {
+		return "", err
+	}
+	if !options.KeepDownloadEnabled {
+		defer vAppTemplate.disableDownloadAfterUse(ctx)
+	}
+
+	err = os.MkdirAll(directory, 0750)
+	if err != nil {
+		return "", err
+	}
+	descriptorPath := filepath.Join(directory, vAppTemplate.VAppTemplate.Name+".ovf")
+	err = os.WriteFile(descriptorPath, descriptor, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	dDetails := newOvfDownloadDetails(files, options)
+	for _, file := range files {
+		err = func() error {
+			// Files are only saved in directory, regardless of the path in the descriptor
+			outFile, err := os.Create(filepath.Join(directory, path.Base(file.name)))
+			if err != nil {
+				return err
+			}
+			defer safeClose(outFile)
+			dDetails.downloadLink = file.link
+			dDetails.fileSize = file.size
+			count, err := downloadFile(ctx, vAppTemplate.client, outFile, dDetails)
+			dDetails.downloadedBytesForCallback += count
+			return err
+		}()
+		if err != nil {
+			return "", err
+		}
+	}
+	return descriptorPath, nil
+}
+
+// DownloadOva exports the vApp template as OVA, writing the archive into writer.
+// The OVF descriptor declares the size of its files when exported by VCD, which is needed to build the archive.
+func (vAppTemplate *VAppTemplate) DownloadOva(ctx context.Context, writer io.Writer, options VAppTemplateDownloadOptions) error {
+	descriptor, files, err := vAppTemplate.prepareDownload(ctx, options)
+	if err != nil {
+		return err
+	}
+	if !options.KeepDownloadEnabled {
+		defer vAppTemplate.disableDownloadAfterUse(ctx)
+	}
+
+	for _, file := range files {
+		if file.size == 0 {
+			return fmt.Errorf("size of file '%s' not found in OVF descriptor: use Download to export the vApp template as OVF", file.name)
+		}
+	}
+
+	// The OVF descriptor must be the first file of the archive
+	archive := tar.NewWriter(writer)
+	now := time.Now()
+	err = archive.WriteHeader(&tar.Header{Name: vAppTemplate.VAppTemplate.Name + ".ovf", Mode: 0600, Size: int64(len(descriptor)), ModTime: now})
+	if err != nil {
+		return err
+	}
+	_, err = archive.Write(descriptor)
+	if err != nil {
+		return err
+	}
+
+	dDetails := newOvfDownloadDetails(files, options)
+	for _, file := range files {
+		err = archive.WriteHeader(&tar.Header{Name: file.name, Mode: 0600, Size: file.size, ModTime: now})
+		if err != nil {
+			return err
+		}
+		dDetails.downloadLink = file.link
+		dDetails.fileSize = file.size
+		count, err := downloadFile(ctx, vAppTemplate.client, archive, dDetails)
+		if err != nil {
+			return err
+		}
+		dDetails.downloadedBytesForCallback += count
+	}
+	return archive.Close()
+}
+
+// prepareDownload enables the download of the vApp template and retrieves its OVF descriptor and the files to download
+func (vAppTemplate *VAppTemplate) prepareDownload(ctx context.Context, options VAppTemplateDownloadOptions) ([]byte, []ovfDownloadFile, error) {
+	if vAppTemplate.VAppTemplate == nil || vAppTemplate.VAppTemplate.HREF == "" {
+		return nil, nil, fmt.Errorf("vApp Template is empty")
+	}
+	err := vAppTemplate.EnableDownload(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	descriptorLink := vAppTemplate.VAppTemplate.Link.Find(func(link *types.Link) bool {
+		return link != nil && link.Rel == types.RelDownloadDefault
+	})
+	if descriptorLink == nil {
+		if !options.KeepDownloadEnabled {
+			vAppTemplate.disableDownloadAfterUse(ctx)
+		}
+		return nil, nil, fmt.Errorf("download link not found for vApp Template %s", vAppTemplate.VAppTemplate.Name)
+	}
+
+	descriptor, err := downloadBytes(ctx, vAppTemplate.client, descriptorLink.HREF)
+	if err == nil {
+		var files []ovfDownloadFile
+		files, err = getOvfDownloadFiles(descriptor, descriptorLink.HREF)
+		if err == nil {
+			return descriptor, files, nil
+		}
+	}
+	if !options.KeepDownloadEnabled {
+		vAppTemplate.disableDownloadAfterUse(ctx)
+	}
+	return nil, nil, err
+}
+
+// disableDownloadAfterUse disables the download of the vApp template, logging any error
+func (vAppTemplate *VAppTemplate) disableDownloadAfterUse(ctx context.Context) {
+	err := vAppTemplate.DisableDownload(ctx)
+	if err != nil {
+		util.Logger.Printf("[ERROR] error disabling download of vApp Template %s: %s", vAppTemplate.VAppTemplate.Name, err)
+	}
+}
+
+// newOvfDownloadDetails returns the download settings shared by all the files of an OVF
+func newOvfDownloadDetails(files []ovfDownloadFile, options VAppTemplateDownloadOptions) downloadDetails {
+	var allFilesSize int64
+	for _, file := range files {
+		allFilesSize += file.size
+	}
+	return downloadDetails{
+		downloadPieceSize: options.DownloadPieceSize,
+		allFilesSize:      allFilesSize,
+		callBack:          options.ProgressCallback,
+	}
+}
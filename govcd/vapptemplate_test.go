@@ -7,9 +7,15 @@
 package govcd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"strconv"
+
 	. "gopkg.in/check.v1"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
 
 // TODO: Write test for InstantiateVAppTemplate
@@ -136,9 +142,182 @@ func testUploadAndDeleteVAppTemplate(vcd *TestVCD, check *C, isOvfLink bool) {
 	check.Assert(vAppTemplate.VAppTemplate.Description, Equals, descriptionForUpdate)
 	check.Assert(vAppTemplate.VAppTemplate.GoldMaster, Equals, true)
 
+	vAppTemplate, err = vAppTemplate.SetGoldMaster(ctx, false)
+	check.Assert(err, IsNil)
+	check.Assert(vAppTemplate.VAppTemplate.GoldMaster, Equals, false)
+
 	err = vAppTemplate.Delete(ctx)
 	check.Assert(err, IsNil)
 	vAppTemplate, err = catalog.GetVAppTemplateByName(ctx, itemName)
 	check.Assert(err, NotNil)
 	check.Assert(vAppTemplate, IsNil)
 }
+
+func (vcd *TestVCD) Test_DownloadVAppTemplate(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	cat, err := vcd.org.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	if err != nil {
+		check.Skip("Test_DownloadVAppTemplate: Catalog not found. Test can't proceed")
+		return
+	}
+	vAppTemplate, err := cat.GetVAppTemplateByName(ctx, vcd.config.VCD.Catalog.CatalogItem)
+	check.Assert(err, IsNil)
+
+	var progressCalls int
+	var lastProgress, totalSize int64
+	options := VAppTemplateDownloadOptions{
+		ProgressCallback: func(bytesDownloaded, total int64) {
+			progressCalls++
+			lastProgress, totalSize = bytesDownloaded, total
+		},
+	}
+
+	directory := check.MkDir()
+	descriptorPath, err := vAppTemplate.Download(ctx, directory, options)
+	check.Assert(err, IsNil)
+	_, err = os.Stat(descriptorPath)
+	check.Assert(err, IsNil)
+	entries, err := os.ReadDir(directory)
+	check.Assert(err, IsNil)
+	check.Assert(len(entries) > 1, Equals, true)
+	check.Assert(progressCalls > 0, Equals, true)
+	check.Assert(lastProgress, Equals, totalSize)
+
+	var ova bytes.Buffer
+	err = vAppTemplate.DownloadOva(ctx, &ova, options)
+	check.Assert(err, IsNil)
+	check.Assert(int64(ova.Len()) > totalSize, Equals, true)
+}
+
+func (vcd *TestVCD) Test_InstantiateVAppTemplateWithStorageProfiles(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	vAppTemplate, storageProfiles := getStorageProfilesTestTemplate(vcd, check)
+
+	vAppName := testResourceName(check, "")
+	params := &types.InstantiateVAppTemplateParams{
+		Ovf:              types.XMLNamespaceOVF,
+		Xmlns:            types.XMLNamespaceVCloud,
+		Name:             vAppName,
+		AllEULAsAccepted: true,
+	}
+	err := vcd.vdc.InstantiateVAppTemplateWithStorageProfiles(ctx, params, vAppTemplate, storageProfiles)
+	check.Assert(err, IsNil)
+	AddToCleanupList(vAppName, "vapp", "", check.TestName())
+
+	vApp, err := vcd.vdc.GetVAppByName(ctx, vAppName, true)
+	check.Assert(err, IsNil)
+	checkVAppStorageProfiles(check, vApp, storageProfiles)
+
+	task, err := vApp.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+}
+
+func (vcd *TestVCD) Test_ComposeVAppWithStorageProfiles(check *C) {
+	if vcd.config.VCD.Network.Net1 == "" {
+		check.Skip("Skipping test because no network was given")
+	}
+	fmt.Printf("Running: %s\n", check.TestName())
+	vAppTemplate, storageProfiles := getStorageProfilesTestTemplate(vcd, check)
+
+	network, err := vcd.vdc.GetOrgVdcNetworkByName(ctx, vcd.config.VCD.Network.Net1, false)
+	check.Assert(err, IsNil)
+
+	vAppName := testResourceName(check, "")
+	vApp, err := vcd.vdc.ComposeVAppWithOptions(ctx, ComposeVAppOptions{
+		Name:            vAppName,
+		Description:     check.TestName(),
+		VAppTemplate:    vAppTemplate,
+		Networks:        []*types.OrgVDCNetwork{network.OrgVDCNetwork},
+		StorageProfiles: storageProfiles,
+		AcceptAllEulas:  true,
+	})
+	check.Assert(err, IsNil)
+	AddToCleanupList(vAppName, "vapp", "", check.TestName())
+	check.Assert(vApp.VApp.Name, Equals, vAppName)
+	check.Assert(vApp.VApp.Children, NotNil)
+	check.Assert(len(vApp.VApp.Children.VM), Equals, len(vAppTemplate.VAppTemplate.Children.VM))
+	checkVAppStorageProfiles(check, vApp, storageProfiles)
+
+	// A storage profile that is not available in the VDC is rejected before the request is sent
+	_, err = vcd.vdc.ComposeVAppWithOptions(ctx, ComposeVAppOptions{
+		Name:         vAppName + "-invalid",
+		VAppTemplate: vAppTemplate,
+		StorageProfiles: VAppTemplateStorageProfiles{
+			Vms: map[string]string{vAppTemplate.VAppTemplate.Children.VM[0].Name: "not existing storage profile"},
+		},
+	})
+	check.Assert(err, NotNil)
+
+	task, err := vApp.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+}
+
+// getStorageProfilesTestTemplate returns the vApp template of the configured catalog item, and the storage profiles
+// that place its first VM on the second configured storage profile and the first disk of that VM on the first one
+func getStorageProfilesTestTemplate(vcd *TestVCD, check *C) (*VAppTemplate, VAppTemplateStorageProfiles) {
+	if vcd.config.VCD.StorageProfile.SP1 == "" || vcd.config.VCD.StorageProfile.SP2 == "" {
+		check.Skip("Skipping test because two storage profiles are needed")
+	}
+	if vcd.config.VCD.Catalog.CatalogItem == "" {
+		check.Skip("Skipping test because no catalog item was given")
+	}
+	catalog, err := vcd.org.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+	catalogItem, err := catalog.GetCatalogItemByName(ctx, vcd.config.VCD.Catalog.CatalogItem, false)
+	check.Assert(err, IsNil)
+	vAppTemplate, err := catalogItem.GetVAppTemplate(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(vAppTemplate.VAppTemplate.Children, NotNil)
+	check.Assert(len(vAppTemplate.VAppTemplate.Children.VM) > 0, Equals, true)
+
+	vm := vAppTemplate.VAppTemplate.Children.VM[0]
+	diskId := 0
+	if vm.VirtualHardwareSection != nil {
+		for _, item := range vm.VirtualHardwareSection.Item {
+			if item.ResourceType == types.ResourceTypeDisk {
+				diskId = item.InstanceID
+				break
+			}
+		}
+	}
+	if diskId == 0 {
+		check.Skip(fmt.Sprintf("Skipping test because VM '%s' of the vApp template has no disks", vm.Name))
+	}
+
+	return &vAppTemplate, VAppTemplateStorageProfiles{
+		Vms:   map[string]string{vm.Name: vcd.config.VCD.StorageProfile.SP2},
+		Disks: map[string]map[int]string{vm.Name: {diskId: vcd.config.VCD.StorageProfile.SP1}},
+	}
+}
+
+// checkVAppStorageProfiles checks that the VMs and disks of vApp are placed on the storage profiles set in
+// storageProfiles
+func checkVAppStorageProfiles(check *C, vApp *VApp, storageProfiles VAppTemplateStorageProfiles) {
+	for vmName, storageProfile := range storageProfiles.Vms {
+		vm, err := vApp.GetVMByName(ctx, vmName, true)
+		check.Assert(err, IsNil)
+		check.Assert(vm.VM.StorageProfile, NotNil)
+		check.Assert(vm.VM.StorageProfile.Name, Equals, storageProfile)
+	}
+	for vmName, diskProfiles := range storageProfiles.Disks {
+		vm, err := vApp.GetVMByName(ctx, vmName, true)
+		check.Assert(err, IsNil)
+		check.Assert(vm.VM.VmSpecSection, NotNil)
+		check.Assert(vm.VM.VmSpecSection.DiskSection, NotNil)
+		for diskId, storageProfile := range diskProfiles {
+			found := false
+			for _, disk := range vm.VM.VmSpecSection.DiskSection.DiskSettings {
+				if disk.DiskId == strconv.Itoa(diskId) {
+					found = true
+					check.Assert(disk.StorageProfile, NotNil)
+					check.Assert(disk.StorageProfile.Name, Equals, storageProfile)
+				}
+			}
+			check.Assert(found, Equals, true)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_setSourcedItemStorageProfiles(t *testing.T) {
+	vdcStorageProfiles := []*types.Reference{
+		{Name: "gold", HREF: "https://vcd/api/vdcStorageProfile/1"},
+		{Name: "silver", HREF: "https://vcd/api/vdcStorageProfile/2"},
+	}
+	newTemplate := func() *types.VAppTemplate {
+		return &types.VAppTemplate{
+			Name: "template",
+			HREF: "https://vcd/api/vAppTemplate/vappTemplate-1",
+			Children: &types.VAppTemplateChildren{VM: []*types.VAppTemplate{
+				{
+					Name: "vm1",
+					HREF: "https://vcd/api/vAppTemplate/vm-1",
+					VirtualHardwareSection: &types.VirtualHardwareSection{Item: []*types.VirtualHardwareItem{
+						{ResourceType: types.ResourceTypeDisk, InstanceID: 2000, ElementName: "Hard disk 1",
+							HostResource: []*types.VirtualHardwareHostResource{{BusType: 6, BusSubType: "lsilogic", Capacity: 1024}}},
+						{ResourceType: types.ResourceTypeDisk, InstanceID: 2001, ElementName: "Hard disk 2", AddressOnParent: 1},
+					}},
+				},
+				{Name: "vm2", HREF: "https://vcd/api/vAppTemplate/vm-2"},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		storageProfiles VAppTemplateStorageProfiles
+		wantErr         string
+	}{
+		{name: "UnknownVm", storageProfiles: VAppTemplateStorageProfiles{Vms: map[string]string{"vm3": "gold"}}, wantErr: "VM 'vm3' not found"},
+		{name: "UnknownProfile", storageProfiles: VAppTemplateStorageProfiles{Vms: map[string]string{"vm1": "bronze"}}, wantErr: "available: gold, silver"},
+		{name: "UnknownDisk", storageProfiles: VAppTemplateStorageProfiles{Disks: map[string]map[int]string{"vm1": {2002: "gold"}}}, wantErr: "InstanceID 2002"},
+		{name: "DiskOfVmWithoutHardware", storageProfiles: VAppTemplateStorageProfiles{Disks: map[string]map[int]string{"vm2": {2000: "gold"}}}, wantErr: "InstanceID 2000"},
+		{name: "UnknownDiskProfile", storageProfiles: VAppTemplateStorageProfiles{Disks: map[string]map[int]string{"vm1": {2000: "bronze"}}}, wantErr: "storage profile 'bronze'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &types.InstantiateVAppTemplateParams{}
+			err := setSourcedItemStorageProfiles(&params.SourcedItem, newTemplate(), vdcStorageProfiles, tt.storageProfiles)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(params.SourcedItem) > 0 {
+				t.Errorf("parameters changed despite validation error")
+			}
+		})
+	}
+
+	t.Run("VmAndDiskProfiles", func(t *testing.T) {
+		existingItem := &types.SourcedCompositionItemParam{
+			Source:          &types.Reference{HREF: "https://vcd/api/vAppTemplate/vm-2"},
+			VMGeneralParams: &types.VMGeneralParams{Name: "renamed"},
+		}
+		params := &types.InstantiateVAppTemplateParams{SourcedItem: []*types.SourcedCompositionItemParam{existingItem}}
+		err := setSourcedItemStorageProfiles(&params.SourcedItem, newTemplate(), vdcStorageProfiles, VAppTemplateStorageProfiles{
+			Vms:   map[string]string{"vm1": "silver", "vm2": "gold"},
+			Disks: map[string]map[int]string{"vm1": {2000: "gold"}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(params.SourcedItem) != 2 {
+			t.Fatalf("expected 2 sourced items, got %d", len(params.SourcedItem))
+		}
+		if existingItem.StorageProfile == nil || existingItem.StorageProfile.Name != "gold" || existingItem.VMGeneralParams.Name != "renamed" {
+			t.Errorf("existing sourced item not updated in place: %#v", existingItem)
+		}
+		vm1Item := params.SourcedItem[1]
+		if vm1Item.StorageProfile == nil || vm1Item.StorageProfile.Name != "silver" {
+			t.Errorf("expected storage profile 'silver' for vm1, got %#v", vm1Item.StorageProfile)
+		}
+
+		payload, err := xml.Marshal(vm1Item)
+		if err != nil {
+			t.Fatalf("error marshalling sourced item: %s", err)
+		}
+		for _, expected := range []string{
+			"<ovf:VirtualHardwareSection",
+			"<rasd:InstanceID>2000</rasd:InstanceID>",
+			`vcloud:storageProfileHref="https://vcd/api/vdcStorageProfile/1"`,
+			`vcloud:storageProfileOverrideVmDefault="true"`,
+			`vcloud:capacity="1024"`,
+		} {
+			if !strings.Contains(string(payload), expected) {
+				t.Errorf("expected %q in payload %s", expected, payload)
+			}
+		}
+		if strings.Contains(string(payload), "2001") {
+			t.Errorf("unexpected disk 2001 in payload %s", payload)
+		}
+	})
+}
+
+func Test_ComposeVAppStorageProfiles(t *testing.T) {
+	template := &types.VAppTemplate{
+		Name: "template",
+		HREF: "https://vcd/api/vAppTemplate/vappTemplate-1",
+		Children: &types.VAppTemplateChildren{VM: []*types.VAppTemplate{
+			{
+				Name:                     "vm1",
+				HREF:                     "https://vcd/api/vAppTemplate/vm-1",
+				NetworkConnectionSection: &types.NetworkConnectionSection{PrimaryNetworkConnectionIndex: 1},
+				VirtualHardwareSection: &types.VirtualHardwareSection{Item: []*types.VirtualHardwareItem{
+					{ResourceType: types.ResourceTypeDisk, InstanceID: 2000, ElementName: "Hard disk 1"},
+				}},
+			},
+			{Name: "vm2", HREF: "https://vcd/api/vAppTemplate/vm-2"},
+		}},
+	}
+	networks := []*types.OrgVDCNetwork{{Name: "net1", HREF: "https://vcd/api/network/1"}}
+
+	vcomp := &types.ComposeVAppParams{InstantiationParams: newComposeInstantiationParams(networks)}
+	for _, vm := range template.Children.VM {
+		vcomp.SourcedItem = append(vcomp.SourcedItem, newComposeSourcedItem(vm, networks))
+	}
+	err := setSourcedItemStorageProfiles(&vcomp.SourcedItem, template,
+		[]*types.Reference{{Name: "gold", HREF: "https://vcd/api/vdcStorageProfile/1"}},
+		VAppTemplateStorageProfiles{
+			Vms:   map[string]string{"vm2": "gold"},
+			Disks: map[string]map[int]string{"vm1": {2000: "gold"}},
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The existing sourced items are updated, without adding new ones
+	if len(vcomp.SourcedItem) != 2 {
+		t.Fatalf("expected 2 sourced items, got %d", len(vcomp.SourcedItem))
+	}
+	vm1Item, vm2Item := vcomp.SourcedItem[0], vcomp.SourcedItem[1]
+	if vm1Item.StorageProfile != nil || vm2Item.StorageProfile == nil || vm2Item.StorageProfile.Name != "gold" {
+		t.Errorf("unexpected VM storage profiles: %#v, %#v", vm1Item.StorageProfile, vm2Item.StorageProfile)
+	}
+	connections := vm1Item.InstantiationParams.NetworkConnectionSection
+	if connections.PrimaryNetworkConnectionIndex != 1 || len(connections.NetworkConnection) != 1 ||
+		connections.NetworkConnection[0].Network != "net1" || len(vm1Item.NetworkAssignment) != 1 {
+		t.Errorf("network connections of the template VM not kept: %#v", connections)
+	}
+
+	payload, err := xml.Marshal(vcomp)
+	if err != nil {
+		t.Fatalf("error marshalling composition parameters: %s", err)
+	}
+	for _, expected := range []string{
+		`<Source href="https://vcd/api/vAppTemplate/vm-1" name="vm1">`,
+		`<Source href="https://vcd/api/vAppTemplate/vm-2" name="vm2">`,
+		`vcloud:storageProfileHref="https://vcd/api/vdcStorageProfile/1"`,
+		`<StorageProfile href="https://vcd/api/vdcStorageProfile/1" name="gold">`,
+	} {
+		if !strings.Contains(string(payload), expected) {
+			t.Errorf("expected %q in payload %s", expected, payload)
+		}
+	}
+}
+
+func Test_setInstantiationProductSections(t *testing.T) {
+	template := &types.VAppTemplate{
+		Name: "appliance",
+		HREF: "https://vcd/api/vAppTemplate/vappTemplate-1",
+		Children: &types.VAppTemplateChildren{VM: []*types.VAppTemplate{
+			{Name: "vm1", HREF: "https://vcd/api/vAppTemplate/vm-1"},
+		}},
+	}
+	newDefinition := func(properties ...*types.Property) *types.ProductSectionList {
+		return &types.ProductSectionList{ProductSection: &types.ProductSection{Property: properties}}
+	}
+	definitions := map[string]*types.ProductSectionList{
+		"https://vcd/api/vAppTemplate/vappTemplate-1": newDefinition(
+			&types.Property{Key: "hostname", Type: "string", UserConfigurable: true},
+		),
+		"https://vcd/api/vAppTemplate/vm-1": newDefinition(
+			&types.Property{Key: "ip0", Type: "string", UserConfigurable: true},
+			&types.Property{Key: "password", Type: "password", UserConfigurable: true},
+			&types.Property{Key: "version", Type: "string"},
+		),
+	}
+
+	tests := []struct {
+		name       string
+		properties VAppTemplateProductProperties
+		wantErr    string
+	}{
+		{name: "UnknownVm", properties: VAppTemplateProductProperties{Vms: map[string]map[string]string{"vm2": {"ip0": "10.0.0.1"}}}, wantErr: "VM 'vm2' not found"},
+		{name: "UnknownVAppProperty", properties: VAppTemplateProductProperties{VApp: map[string]string{"domain": "example.com"}}, wantErr: "available: hostname"},
+		{name: "UnknownVmProperty", properties: VAppTemplateProductProperties{Vms: map[string]map[string]string{"vm1": {"ip1": "10.0.0.1"}}}, wantErr: "available: ip0, password, version"},
+		{name: "NotUserConfigurable", properties: VAppTemplateProductProperties{Vms: map[string]map[string]string{"vm1": {"version": "2"}}}, wantErr: "'version' is not user configurable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &types.InstantiateVAppTemplateParams{}
+			err := setInstantiationProductSections(params, template, definitions, tt.properties)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if params.Source != nil || params.InstantiationParams != nil || len(params.SourcedItem) > 0 {
+				t.Errorf("parameters changed despite validation error")
+			}
+		})
+	}
+
+	t.Run("VAppAndVmProperties", func(t *testing.T) {
+		params := &types.InstantiateVAppTemplateParams{
+			Ovf:         types.XMLNamespaceOVF,
+			Xmlns:       types.XMLNamespaceVCloud,
+			Name:        "appliance-1",
+			PowerOn:     true,
+			Deploy:      true,
+			SourcedItem: []*types.SourcedCompositionItemParam{{Source: &types.Reference{HREF: "https://vcd/api/vAppTemplate/vm-1"}}},
+		}
+		err := setInstantiationProductSections(params, template, definitions, VAppTemplateProductProperties{
+			VApp: map[string]string{"hostname": "appliance-1"},
+			Vms:  map[string]map[string]string{"vm1": {"password": "secret", "ip0": "10.0.0.1"}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if params.Source == nil || params.Source.HREF != template.HREF {
+			t.Errorf("expected source to be set to the vApp template, got %#v", params.Source)
+		}
+		if len(params.SourcedItem) != 1 {
+			t.Fatalf("expected existing sourced item to be updated, got %d sourced items", len(params.SourcedItem))
+		}
+
+		payload, err := xml.Marshal(params)
+		if err != nil {
+			t.Fatalf("error marshalling instantiation parameters: %s", err)
+		}
+		for _, expected := range []string{
+			`<InstantiationParams><ovf:ProductSection xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1"><ovf:Info>`,
+			`<ovf:Property ovf:key="hostname" ovf:type="string" ovf:userConfigurable="true" ovf:value="appliance-1"></ovf:Property>`,
+			// Properties are sorted by key
+			`<ovf:Property ovf:key="ip0" ovf:type="string" ovf:userConfigurable="true" ovf:value="10.0.0.1"></ovf:Property>` +
+				`<ovf:Property ovf:key="password" ovf:type="password" ovf:userConfigurable="true" ovf:value="secret"></ovf:Property>`,
+		} {
+			if !strings.Contains(string(payload), expected) {
+				t.Errorf("expected %q in payload %s", expected, payload)
+			}
+		}
+	})
+}
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
@@ -45,7 +44,7 @@ func (vdc *Vdc) getVdcVAppbyHREF(ctx context.Context, vappHREF *url.URL) (*VApp,
 func (vdc *Vdc) undeployAllVdcVApps(ctx context.Context) error {
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return fmt.Errorf("error refreshing vdc: %s", err)
+		return fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, resents := range vdc.Vdc.ResourceEntities {
 		for _, resent := range resents.ResourceEntity {
@@ -56,7 +55,7 @@ func (vdc *Vdc) undeployAllVdcVApps(ctx context.Context) error {
 				}
 				vapp, err := vdc.getVdcVAppbyHREF(ctx, vappHREF)
 				if err != nil {
-					return fmt.Errorf("error retrieving vapp with url: %s and with error %s", vappHREF.Path, err)
+					return fmt.Errorf("error retrieving vapp with url: %s and with error %w", vappHREF.Path, err)
 				}
 				task, err := vapp.Undeploy(ctx)
 				if err != nil {
@@ -79,7 +78,7 @@ func (vdc *Vdc) undeployAllVdcVApps(ctx context.Context) error {
 func (vdc *Vdc) removeAllVdcVApps(ctx context.Context) error {
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return fmt.Errorf("error refreshing vdc: %s", err)
+		return fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, resents := range vdc.Vdc.ResourceEntities {
 		for _, resent := range resents.ResourceEntity {
@@ -90,15 +89,15 @@ func (vdc *Vdc) removeAllVdcVApps(ctx context.Context) error {
 				}
 				vapp, err := vdc.getVdcVAppbyHREF(ctx, vappHREF)
 				if err != nil {
-					return fmt.Errorf("error retrieving vapp with url: %s and with error %s", vappHREF.Path, err)
+					return fmt.Errorf("error retrieving vapp with url: %s and with error %w", vappHREF.Path, err)
 				}
 				task, err := vapp.Delete(ctx)
 				if err != nil {
-					return fmt.Errorf("error deleting vapp: %s", err)
+					return fmt.Errorf("error deleting vapp: %w", err)
 				}
 				err = task.WaitTaskCompletion(ctx)
 				if err != nil {
-					return fmt.Errorf("couldn't finish removing vapp %s", err)
+					return fmt.Errorf("couldn't finish removing vapp %w", err)
 				}
 			}
 		}
@@ -139,20 +138,18 @@ func (vdc *Vdc) Delete(ctx context.Context, force bool, recursive bool) (Task, e
 
 	vdcUrl, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return Task{}, fmt.Errorf("error parsing vdc url: %s", err)
+		return Task{}, fmt.Errorf("error parsing vdc url: %w", err)
 	}
 
-	req := vdc.client.NewRequest(ctx, map[string]string{
-		"force":     strconv.FormatBool(force),
-		"recursive": strconv.FormatBool(recursive),
-	}, http.MethodDelete, *vdcUrl, nil)
+	req := vdc.client.NewRequest(ctx, DeleteOptions{Force: force, Recursive: recursive}.queryParams(),
+		http.MethodDelete, *vdcUrl, nil)
 	resp, err := checkResp(vdc.client.Http.Do(req))
 	if err != nil {
-		return Task{}, fmt.Errorf("error deleting vdc: %s", err)
+		return Task{}, fmt.Errorf("error deleting vdc: %w", err)
 	}
 	task := NewTask(vdc.client)
 	if err = decodeBody(types.BodyTypeXML, resp, task.Task); err != nil {
-		return Task{}, fmt.Errorf("error decoding task response: %s", err)
+		return Task{}, fmt.Errorf("error decoding task response: %w", err)
 	}
 	if task.Task.Status == "error" {
 		return Task{}, fmt.Errorf("vdc not properly destroyed")
@@ -168,17 +165,27 @@ func (vdc *Vdc) DeleteWait(ctx context.Context, force bool, recursive bool) erro
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("couldn't finish removing vdc %s", err)
+		return fmt.Errorf("couldn't finish removing vdc %w", err)
 	}
 	return nil
 }
 
+// DeleteWithOptions deletes the vdc, passing options.Force and options.Recursive to VCD, and waits for the
+// asynchronous task to complete
+func (vdc *Vdc) DeleteWithOptions(ctx context.Context, options DeleteOptions) error {
+	err := options.validate("VDC", true, true)
+	if err != nil {
+		return err
+	}
+	return vdc.DeleteWait(ctx, options.Force, options.Recursive)
+}
+
 // Deprecated: use GetOrgVdcNetworkByName
 func (vdc *Vdc) FindVDCNetwork(ctx context.Context, network string) (OrgVDCNetwork, error) {
 
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return OrgVDCNetwork{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return OrgVDCNetwork{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, an := range vdc.Vdc.AvailableNetworks {
 		for _, reference := range an.Network {
@@ -217,7 +224,7 @@ func (vdc *Vdc) GetOrgVdcNetworkByName(ctx context.Context, name string, refresh
 	if refresh {
 		err := vdc.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vdc: %s", err)
+			return nil, fmt.Errorf("error refreshing vdc: %w", err)
 		}
 	}
 	for _, an := range vdc.Vdc.AvailableNetworks {
@@ -237,7 +244,7 @@ func (vdc *Vdc) GetOrgVdcNetworkById(ctx context.Context, id string, refresh boo
 	if refresh {
 		err := vdc.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing vdc: %s", err)
+			return nil, fmt.Errorf("error refreshing vdc: %w", err)
 		}
 	}
 	for _, an := range vdc.Vdc.AvailableNetworks {
@@ -271,7 +278,7 @@ func (vdc *Vdc) FindStorageProfileReference(ctx context.Context, name string) (t
 
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return types.Reference{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return types.Reference{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, sp := range vdc.Vdc.VdcStorageProfiles.VdcStorageProfile {
 		if sp.Name == name {
@@ -286,7 +293,7 @@ func (vdc *Vdc) FindStorageProfileReference(ctx context.Context, name string) (t
 func (vdc *Vdc) GetDefaultStorageProfileReference(ctx context.Context, storageprofiles *types.QueryResultRecordsType) (types.Reference, error) {
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return types.Reference{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return types.Reference{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, spr := range storageprofiles.OrgVdcStorageProfileRecord {
 		if spr.IsDefaultStorageProfile {
@@ -301,7 +308,7 @@ func (vdc *Vdc) FindEdgeGateway(ctx context.Context, edgegateway string) (EdgeGa
 
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return EdgeGateway{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return EdgeGateway{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 	for _, av := range vdc.Vdc.Link {
 		if av.Rel == "edgeGateways" && av.Type == types.MimeQueryRecords {
@@ -412,7 +419,7 @@ func (vdc *Vdc) QueryEdgeGatewayList(ctx context.Context) ([]*types.QueryResultE
 func (vdc *Vdc) GetEdgeGatewayRecordsType(ctx context.Context, refresh bool) (*types.QueryResultEdgeGatewayRecordsType, error) {
 	items, err := vdc.QueryEdgeGatewayList(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving edge gateway list: %s", err)
+		return nil, fmt.Errorf("error retrieving edge gateway list: %w", err)
 	}
 	return &types.QueryResultEdgeGatewayRecordsType{
 		Total:             float64(len(items)),
@@ -426,7 +433,7 @@ func (vdc *Vdc) GetEdgeGatewayRecordsType(ctx context.Context, refresh bool) (*t
 func (vdc *Vdc) GetEdgeGatewayByName(ctx context.Context, name string, refresh bool) (*EdgeGateway, error) {
 	edgeGatewayList, err := vdc.QueryEdgeGatewayList(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving edge gateways list: %s", err)
+		return nil, fmt.Errorf("error retrieving edge gateways list: %w", err)
 	}
 
 	for _, edge := range edgeGatewayList {
@@ -444,7 +451,7 @@ func (vdc *Vdc) GetEdgeGatewayByName(ctx context.Context, name string, refresh b
 func (vdc *Vdc) GetEdgeGatewayById(ctx context.Context, id string, refresh bool) (*EdgeGateway, error) {
 	edgeGatewayList, err := vdc.QueryEdgeGatewayList(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving edge gateways list: %s", err)
+		return nil, fmt.Errorf("error retrieving edge gateways list: %w", err)
 	}
 
 	for _, edge := range edgeGatewayList {
@@ -486,7 +493,7 @@ func (vdc *Vdc) ComposeRawVApp(ctx context.Context, name string, description str
 
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return fmt.Errorf("error getting vdc href: %s", err)
+		return fmt.Errorf("error getting vdc href: %w", err)
 	}
 	vdcHref.Path += "/action/composeVApp"
 
@@ -494,12 +501,12 @@ func (vdc *Vdc) ComposeRawVApp(ctx context.Context, name string, description str
 	task, err := vdc.client.ExecuteTaskRequest(ctx, vdcHref.String(), http.MethodPost,
 		types.MimeComposeVappParams, "error instantiating a new vApp:: %s", vcomp)
 	if err != nil {
-		return fmt.Errorf("error executing task request: %s", err)
+		return fmt.Errorf("error executing task request: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error performing task: %s", err)
+		return fmt.Errorf("error performing task: %w", err)
 	}
 
 	return nil
@@ -519,7 +526,7 @@ func (vdc *Vdc) CreateRawVApp(ctx context.Context, name string, description stri
 
 	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error getting vdc href: %s", err)
+		return nil, fmt.Errorf("error getting vdc href: %w", err)
 	}
 	vdcHref.Path += "/action/composeVApp"
 
@@ -528,7 +535,7 @@ func (vdc *Vdc) CreateRawVApp(ctx context.Context, name string, description stri
 	_, err = vdc.client.ExecuteRequest(ctx, vdcHref.String(), http.MethodPost,
 		types.MimeComposeVappParams, "error instantiating a new vApp:: %s", vcomp, &vAppContents)
 	if err != nil {
-		return nil, fmt.Errorf("error executing task request: %s", err)
+		return nil, fmt.Errorf("error executing task request: %w", err)
 	}
 
 	if vAppContents.Tasks != nil {
@@ -538,7 +545,7 @@ func (vdc *Vdc) CreateRawVApp(ctx context.Context, name string, description stri
 				task.Task = innerTask
 				err = task.WaitTaskCompletion(ctx)
 				if err != nil {
-					return nil, fmt.Errorf("error performing task: %s", err)
+					return nil, fmt.Errorf("error performing task: %w", err)
 				}
 			}
 		}
@@ -569,87 +576,193 @@ func (vdc *Vdc) ComposeVApp(ctx context.Context, orgvdcnetworks []*types.OrgVDCN
 		return Task{}, fmt.Errorf("can't compose a new vApp, objects passed are not valid")
 	}
 
-	// Determine primary network connection index number. We normally depend on it being inherited from vApp template
-	// but in the case when vApp template does not have network card it would fail on the index being undefined. We
-	// set the value to 0 (first NIC instead)
-	primaryNetworkConnectionIndex := 0
-	if vapptemplate.VAppTemplate.Children != nil && len(vapptemplate.VAppTemplate.Children.VM) > 0 &&
-		vapptemplate.VAppTemplate.Children.VM[0].NetworkConnectionSection != nil {
-		primaryNetworkConnectionIndex = vapptemplate.VAppTemplate.Children.VM[0].NetworkConnectionSection.PrimaryNetworkConnectionIndex
-	}
-
 	// Build request XML
 	vcomp := &types.ComposeVAppParams{
-		Ovf:         types.XMLNamespaceOVF,
-		Xsi:         types.XMLNamespaceXSI,
-		Xmlns:       types.XMLNamespaceVCloud,
-		Deploy:      false,
-		Name:        name,
-		PowerOn:     false,
-		Description: description,
-		InstantiationParams: &types.InstantiationParams{
-			NetworkConfigSection: &types.NetworkConfigSection{
-				Info: "Configuration parameters for logical networks",
-			},
+		Ovf:                 types.XMLNamespaceOVF,
+		Xsi:                 types.XMLNamespaceXSI,
+		Xmlns:               types.XMLNamespaceVCloud,
+		Deploy:              false,
+		Name:                name,
+		PowerOn:             false,
+		Description:         description,
+		InstantiationParams: newComposeInstantiationParams(orgvdcnetworks),
+		AllEULAsAccepted:    acceptalleulas,
+		SourcedItem: []*types.SourcedCompositionItemParam{
+			newComposeSourcedItem(vapptemplate.VAppTemplate.Children.VM[0], orgvdcnetworks),
 		},
-		AllEULAsAccepted: acceptalleulas,
-		SourcedItem: &types.SourcedCompositionItemParam{
-			Source: &types.Reference{
-				HREF: vapptemplate.VAppTemplate.Children.VM[0].HREF,
-				Name: vapptemplate.VAppTemplate.Children.VM[0].Name,
-			},
-			InstantiationParams: &types.InstantiationParams{
-				NetworkConnectionSection: &types.NetworkConnectionSection{
-					Info:                          "Network config for sourced item",
-					PrimaryNetworkConnectionIndex: primaryNetworkConnectionIndex,
-				},
-			},
+	}
+	if storageprofileref.HREF != "" {
+		vcomp.SourcedItem[0].StorageProfile = &storageprofileref
+	}
+
+	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
+	if err != nil {
+		return Task{}, fmt.Errorf("error getting vdc href: %w", err)
+	}
+	vdcHref.Path += "/action/composeVApp"
+
+	// Like ComposeRawVApp, this function returns a task, while it should be returning a vApp
+	// Since we don't use this function in terraform-provider-vcd, we are not going to
+	// replace it.
+	return vdc.client.ExecuteTaskRequest(ctx, vdcHref.String(), http.MethodPost,
+		types.MimeComposeVappParams, "error instantiating a new vApp: %s", vcomp)
+}
+
+// ComposeVAppOptions defines the vApp created by Vdc.ComposeVAppWithOptions
+type ComposeVAppOptions struct {
+	Name        string
+	Description string
+	// VAppTemplate is the vApp template whose VMs are added to the vApp
+	VAppTemplate *VAppTemplate
+	// Networks are the Org VDC networks connected to the vApp. The NIC of each VM with index N is connected to the
+	// network with index N
+	Networks []*types.OrgVDCNetwork
+	// StorageProfiles defines the storage profiles of VMs and disks, instead of the defaults of the VDC
+	StorageProfiles VAppTemplateStorageProfiles
+	// AcceptAllEulas accepts the EULAs of the vApp template
+	AcceptAllEulas bool
+}
+
+// ComposeVAppWithOptions creates a vApp with all the VMs of options.VAppTemplate, connected to options.Networks.
+// VMs and disks are placed on the storage profiles set in options.StorageProfiles, which are validated against the
+// ones available in the VDC before the request is sent. It waits for the composition to complete and returns the
+// vApp
+func (vdc *Vdc) ComposeVAppWithOptions(ctx context.Context, options ComposeVAppOptions) (*VApp, error) {
+	if options.Name == "" {
+		return nil, fmt.Errorf("vApp name is required")
+	}
+	if options.VAppTemplate == nil || options.VAppTemplate.VAppTemplate == nil ||
+		options.VAppTemplate.VAppTemplate.Children == nil || len(options.VAppTemplate.VAppTemplate.Children.VM) == 0 {
+		return nil, fmt.Errorf("a vApp template with at least one VM is required")
+	}
+	vAppTemplate := options.VAppTemplate.VAppTemplate
+
+	err := vdc.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing VDC: %w", err)
+	}
+	var vdcStorageProfiles []*types.Reference
+	if vdc.Vdc.VdcStorageProfiles != nil {
+		vdcStorageProfiles = vdc.Vdc.VdcStorageProfiles.VdcStorageProfile
+	}
+
+	vcomp := &types.ComposeVAppParams{
+		Ovf:                 types.XMLNamespaceOVF,
+		Xsi:                 types.XMLNamespaceXSI,
+		Xmlns:               types.XMLNamespaceVCloud,
+		Deploy:              false,
+		Name:                options.Name,
+		PowerOn:             false,
+		Description:         options.Description,
+		InstantiationParams: newComposeInstantiationParams(options.Networks),
+		AllEULAsAccepted:    options.AcceptAllEulas,
+	}
+	for _, vm := range vAppTemplate.Children.VM {
+		vcomp.SourcedItem = append(vcomp.SourcedItem, newComposeSourcedItem(vm, options.Networks))
+	}
+	err = setSourcedItemStorageProfiles(&vcomp.SourcedItem, vAppTemplate, vdcStorageProfiles, options.StorageProfiles)
+	if err != nil {
+		return nil, fmt.Errorf("error setting storage profiles for vApp template '%s' in VDC '%s': %w",
+			vAppTemplate.Name, vdc.Vdc.Name, err)
+	}
+
+	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
+	if err != nil {
+		return nil, fmt.Errorf("error getting vdc href: %w", err)
+	}
+	vdcHref.Path += "/action/composeVApp"
+
+	vapp := NewVApp(vdc.client)
+	_, err = vdc.client.ExecuteRequest(ctx, vdcHref.String(), http.MethodPost,
+		types.MimeComposeVappParams, "error composing vApp: %s", vcomp, vapp.VApp)
+	if err != nil {
+		return nil, err
+	}
+
+	if vapp.VApp.Tasks != nil {
+		for _, innerTask := range vapp.VApp.Tasks.Task {
+			task := NewTask(vdc.client)
+			task.Task = innerTask
+			err = task.WaitTaskCompletion(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error composing vApp '%s': %w", options.Name, err)
+			}
+		}
+	}
+
+	err = vapp.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp '%s' after composition: %w", options.Name, err)
+	}
+	return vapp, nil
+}
+
+// newComposeInstantiationParams returns the instantiation parameters of a composed vApp, which bridge the vApp to
+// the given Org VDC networks
+func newComposeInstantiationParams(orgVdcNetworks []*types.OrgVDCNetwork) *types.InstantiationParams {
+	params := &types.InstantiationParams{
+		NetworkConfigSection: &types.NetworkConfigSection{
+			Info: "Configuration parameters for logical networks",
 		},
 	}
-	for index, orgvdcnetwork := range orgvdcnetworks {
-		vcomp.InstantiationParams.NetworkConfigSection.NetworkConfig = append(vcomp.InstantiationParams.NetworkConfigSection.NetworkConfig,
+	for _, orgVdcNetwork := range orgVdcNetworks {
+		params.NetworkConfigSection.NetworkConfig = append(params.NetworkConfigSection.NetworkConfig,
 			types.VAppNetworkConfiguration{
-				NetworkName: orgvdcnetwork.Name,
+				NetworkName: orgVdcNetwork.Name,
 				Configuration: &types.NetworkConfiguration{
 					FenceMode: types.FenceModeBridged,
 					ParentNetwork: &types.Reference{
-						HREF: orgvdcnetwork.HREF,
-						Name: orgvdcnetwork.Name,
-						Type: orgvdcnetwork.Type,
+						HREF: orgVdcNetwork.HREF,
+						Name: orgVdcNetwork.Name,
+						Type: orgVdcNetwork.Type,
 					},
 				},
 			},
 		)
-		vcomp.SourcedItem.InstantiationParams.NetworkConnectionSection.NetworkConnection = append(vcomp.SourcedItem.InstantiationParams.NetworkConnectionSection.NetworkConnection,
+	}
+	return params
+}
+
+// newComposeSourcedItem returns the sourced item that adds the template VM vm to a composed vApp, connecting its NIC
+// with index N to the Org VDC network with index N
+func newComposeSourcedItem(vm *types.VAppTemplate, orgVdcNetworks []*types.OrgVDCNetwork) *types.SourcedCompositionItemParam {
+	// Determine primary network connection index number. We normally depend on it being inherited from vApp template
+	// but in the case when vApp template does not have network card it would fail on the index being undefined. We
+	// set the value to 0 (first NIC instead)
+	primaryNetworkConnectionIndex := 0
+	if vm.NetworkConnectionSection != nil {
+		primaryNetworkConnectionIndex = vm.NetworkConnectionSection.PrimaryNetworkConnectionIndex
+	}
+
+	item := &types.SourcedCompositionItemParam{
+		Source: &types.Reference{
+			HREF: vm.HREF,
+			Name: vm.Name,
+		},
+		InstantiationParams: &types.InstantiationParams{
+			NetworkConnectionSection: &types.NetworkConnectionSection{
+				Info:                          "Network config for sourced item",
+				PrimaryNetworkConnectionIndex: primaryNetworkConnectionIndex,
+			},
+		},
+	}
+	for index, orgVdcNetwork := range orgVdcNetworks {
+		item.InstantiationParams.NetworkConnectionSection.NetworkConnection = append(item.InstantiationParams.NetworkConnectionSection.NetworkConnection,
 			&types.NetworkConnection{
-				Network:                 orgvdcnetwork.Name,
+				Network:                 orgVdcNetwork.Name,
 				NetworkConnectionIndex:  index,
 				IsConnected:             true,
 				IPAddressAllocationMode: types.IPAllocationModePool,
 			},
 		)
-		vcomp.SourcedItem.NetworkAssignment = append(vcomp.SourcedItem.NetworkAssignment,
+		item.NetworkAssignment = append(item.NetworkAssignment,
 			&types.NetworkAssignment{
-				InnerNetwork:     orgvdcnetwork.Name,
-				ContainerNetwork: orgvdcnetwork.Name,
+				InnerNetwork:     orgVdcNetwork.Name,
+				ContainerNetwork: orgVdcNetwork.Name,
 			},
 		)
 	}
-	if storageprofileref.HREF != "" {
-		vcomp.SourcedItem.StorageProfile = &storageprofileref
-	}
-
-	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
-	if err != nil {
-		return Task{}, fmt.Errorf("error getting vdc href: %s", err)
-	}
-	vdcHref.Path += "/action/composeVApp"
-
-	// Like ComposeRawVApp, this function returns a task, while it should be returning a vApp
-	// Since we don't use this function in terraform-provider-vcd, we are not going to
-	// replace it.
-	return vdc.client.ExecuteTaskRequest(ctx, vdcHref.String(), http.MethodPost,
-		types.MimeComposeVappParams, "error instantiating a new vApp: %s", vcomp)
+	return item
 }
 
 // Deprecated: use vdc.GetVAppByName instead
@@ -657,7 +770,7 @@ func (vdc *Vdc) FindVAppByName(ctx context.Context, vapp string) (VApp, error) {
 
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return VApp{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return VApp{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 
 	for _, resents := range vdc.Vdc.ResourceEntities {
@@ -683,12 +796,12 @@ func (vdc *Vdc) FindVMByName(ctx context.Context, vapp VApp, vm string) (VM, err
 
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return VM{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return VM{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 
 	err = vapp.Refresh(ctx)
 	if err != nil {
-		return VM{}, fmt.Errorf("error refreshing vApp: %s", err)
+		return VM{}, fmt.Errorf("error refreshing vApp: %w", err)
 	}
 
 	//vApp Might Not Have Any VMs
@@ -736,7 +849,7 @@ func (vdc *Vdc) QueryVM(ctx context.Context, vappName, vmName string) (VMRecord,
 		"filter":        "name==" + url.QueryEscape(vmName) + ";containerName==" + url.QueryEscape(vappName),
 		"filterEncoded": "true"})
 	if err != nil {
-		return VMRecord{}, fmt.Errorf("error querying vm %s", err)
+		return VMRecord{}, fmt.Errorf("error querying vm %w", err)
 	}
 
 	vmResults := results.Results.VMRecord
@@ -763,7 +876,7 @@ func (vdc *Vdc) FindVAppByID(ctx context.Context, vappid string) (VApp, error) {
 
 	err := vdc.Refresh(ctx)
 	if err != nil {
-		return VApp{}, fmt.Errorf("error refreshing vdc: %s", err)
+		return VApp{}, fmt.Errorf("error refreshing vdc: %w", err)
 	}
 
 	urnslice := strings.SplitAfter(vappid, ":")
@@ -844,7 +957,7 @@ func (vdc *Vdc) GetVAppByName(ctx context.Context, vappName string, refresh bool
 	if refresh {
 		err := vdc.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing VDC: %s", err)
+			return nil, fmt.Errorf("error refreshing VDC: %w", err)
 		}
 	}
 
@@ -865,7 +978,7 @@ func (vdc *Vdc) GetVAppById(ctx context.Context, id string, refresh bool) (*VApp
 	if refresh {
 		err := vdc.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing VDC: %s", err)
+			return nil, fmt.Errorf("error refreshing VDC: %w", err)
 		}
 	}
 
@@ -896,7 +1009,7 @@ func (vdc *Vdc) GetVAppByNameOrId(ctx context.Context, identifier string, refres
 func (vdc *Vdc) buildNsxvNetworkServiceEndpointURL(optionalSuffix string) (string, error) {
 	apiEndpoint, err := url.ParseRequestURI(vdc.Vdc.HREF)
 	if err != nil {
-		return "", fmt.Errorf("unable to process vDC URL: %s", err)
+		return "", fmt.Errorf("unable to process vDC URL: %w", err)
 	}
 
 	hostname := apiEndpoint.Scheme + "://" + apiEndpoint.Host + "/network/services"
@@ -913,6 +1026,12 @@ func (vdc *Vdc) QueryMediaList(ctx context.Context) ([]*types.MediaRecordType, e
 	return getExistingMedia(ctx, vdc)
 }
 
+// QueryMediaListWithMetadata retrieves a list of media items for the VDC, filtered server side by the metadata
+// filters of options and with the requested metadata fields populated in each record
+func (vdc *Vdc) QueryMediaListWithMetadata(ctx context.Context, options QueryMetadataOptions) ([]*types.MediaRecordType, error) {
+	return queryMediaListWithMetadata(ctx, vdc.client, "vdc=="+url.QueryEscape(vdc.Vdc.HREF), options)
+}
+
 // QueryVappVmTemplate Finds VM template using catalog name, vApp template name, VN name in template.
 // Returns types.QueryResultVMRecordType if it finds the VM. Returns ErrorEntityNotFound
 // if it's not found. Returns other error if it finds more than one or the search fails.
@@ -928,7 +1047,7 @@ func (vdc *Vdc) QueryVappVmTemplate(ctx context.Context, catalogName, vappTempla
 			";isVAppTemplate==true;status!=FAILED_CREATION;status!=UNKNOWN;status!=UNRECOGNIZED;status!=UNRESOLVED&links=true;",
 		"filterEncoded": "true"})
 	if err != nil {
-		return nil, fmt.Errorf("error quering all vApp templates: %s", err)
+		return nil, fmt.Errorf("error quering all vApp templates: %w", err)
 	}
 
 	vmResults := results.Results.VMRecord
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Resources that can prevent a VDC from accommodating a VM
+const (
+	VmCapacityResourceCpu            = "CPU"
+	VmCapacityResourceMemory         = "memory"
+	VmCapacityResourceStorage        = "storage"
+	VmCapacityResourceStorageProfile = "storage profile"
+)
+
+// VmSpec describes the resources required by a VM that is going to be created in a VDC
+type VmSpec struct {
+	Cpus               int    // Number of virtual CPUs
+	CpuSpeedMhz        int64  // Speed of each virtual CPU. When 0, the speed of the sizing policy is used, if any
+	MemoryMB           int64  // Memory of the VM
+	StorageMB          int64  // Total size of the VM disks
+	StorageProfileName string // Storage profile of the VM disks. When empty, the default storage profile of the VDC is used
+	// SizingPolicy is the VM Sizing Policy that will be assigned to the VM. When set, its CPU count, CPU speed
+	// and memory take precedence over Cpus, CpuSpeedMhz and MemoryMB
+	SizingPolicy *types.VdcComputePolicyV2
+}
+
+// VmCapacityLimit is a resource of the VDC that is not sufficient to accommodate a VM
+type VmCapacityLimit struct {
+	Resource  string // One of the VmCapacityResource* constants
+	Requested int64  // Amount requested by the VM, in Units
+	Available int64  // Amount still available in the VDC, in Units
+	Units     string // MHz for CPU and MB for memory and storage
+	Reason    string // Human readable description of the limit
+}
+
+// VmCapacityCheck is the result of Vdc.CanAccommodate
+type VmCapacityCheck struct {
+	CanAccommodate  bool              // True when none of the VDC limits is exceeded
+	LimitingFactors []VmCapacityLimit // The resources that are not sufficient, when CanAccommodate is false
+	Warnings        []string          // Checks that could not be performed, such as CPU without a known CPU speed
+}
+
+// CanAccommodate checks whether the VDC has enough CPU, memory and storage left to create a VM with the given
+// spec, comparing the requested resources with the remaining VDC allocation and the remaining storage of the
+// storage profile. Resources with no limit, such as CPU and memory in a Pay-As-You-Go VDC, are always sufficient.
+// The VDC is refreshed to get the current usage.
+// Note that the result is an estimation, as VCD also accounts for overhead and reservation guarantees.
+func (vdc *Vdc) CanAccommodate(ctx context.Context, spec VmSpec) (*VmCapacityCheck, error) {
+	if spec.Cpus < 0 || spec.MemoryMB < 0 || spec.StorageMB < 0 {
+		return nil, fmt.Errorf("VM spec cannot contain negative values")
+	}
+	err := vdc.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing VDC '%s': %w", vdc.Vdc.Name, err)
+	}
+
+	storageProfile, err := vdc.getStorageProfileForCapacity(ctx, spec.StorageProfileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkVmCapacity(vdc.Vdc, storageProfile, spec), nil
+}
+
+// getStorageProfileForCapacity retrieves the storage profile of the VDC with the given name, or the default one
+// when name is empty
+func (vdc *Vdc) getStorageProfileForCapacity(ctx context.Context, name string) (*types.VdcStorageProfile, error) {
+	if vdc.Vdc.VdcStorageProfiles == nil {
+		return nil, fmt.Errorf("no storage profiles found in VDC '%s'", vdc.Vdc.Name)
+	}
+	for _, reference := range vdc.Vdc.VdcStorageProfiles.VdcStorageProfile {
+		if name != "" && reference.Name != name {
+			continue
+		}
+		storageProfile, err := vdc.client.GetStorageProfileByHref(ctx, reference.HREF)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving storage profile '%s': %w", reference.Name, err)
+		}
+		if name != "" || storageProfile.Default {
+			return storageProfile, nil
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%s: no default storage profile found in VDC '%s'", ErrorEntityNotFound, vdc.Vdc.Name)
+	}
+	return nil, fmt.Errorf("%s: storage profile '%s' not found in VDC '%s'", ErrorEntityNotFound, name, vdc.Vdc.Name)
+}
+
+// checkVmCapacity compares the resources requested by spec with the ones still available in the VDC and in the
+// storage profile
+func checkVmCapacity(vdc *types.Vdc, storageProfile *types.VdcStorageProfile, spec VmSpec) *VmCapacityCheck {
+	result := &VmCapacityCheck{}
+
+	cpus := int64(spec.Cpus)
+	cpuSpeed := spec.CpuSpeedMhz
+	memory := spec.MemoryMB
+	if spec.SizingPolicy != nil {
+		if spec.SizingPolicy.CPUCount != nil {
+			cpus = int64(*spec.SizingPolicy.CPUCount)
+		}
+		if spec.SizingPolicy.CPUSpeed != nil && cpuSpeed == 0 {
+			cpuSpeed = int64(*spec.SizingPolicy.CPUSpeed)
+		}
+		if spec.SizingPolicy.Memory != nil {
+			memory = int64(*spec.SizingPolicy.Memory)
+		}
+	}
+
+	var cpuCapacity, memoryCapacity *types.CapacityWithUsage
+	if len(vdc.ComputeCapacity) > 0 && vdc.ComputeCapacity[0] != nil {
+		cpuCapacity = vdc.ComputeCapacity[0].CPU
+		memoryCapacity = vdc.ComputeCapacity[0].Memory
+	}
+
+	if cpuCapacity != nil && cpuCapacity.Limit > 0 && cpus > 0 {
+		if cpuSpeed == 0 {
+			result.Warnings = append(result.Warnings, "CPU capacity not checked, as the CPU speed is unknown")
+		} else {
+			result.addLimit(VmCapacityResourceCpu, cpus*cpuSpeed, cpuCapacity.Limit-cpuCapacity.Used, "MHz",
+				"the CPU allocation of VDC '%s' is exceeded", vdc.Name)
+		}
+	}
+
+	if memoryCapacity != nil && memoryCapacity.Limit > 0 && memory > 0 {
+		result.addLimit(VmCapacityResourceMemory, memory, capacityToMB(memoryCapacity.Limit-memoryCapacity.Used, memoryCapacity.Units),
+			"MB", "the memory allocation of VDC '%s' is exceeded", vdc.Name)
+	}
+
+	if storageProfile.Enabled != nil && !*storageProfile.Enabled {
+		result.LimitingFactors = append(result.LimitingFactors, VmCapacityLimit{
+			Resource: VmCapacityResourceStorageProfile,
+			Reason:   fmt.Sprintf("storage profile '%s' is disabled", storageProfile.Name),
+		})
+	}
+	if storageProfile.Limit > 0 && spec.StorageMB > 0 {
+		result.addLimit(VmCapacityResourceStorage, spec.StorageMB,
+			capacityToMB(storageProfile.Limit, storageProfile.Units)-storageProfile.StorageUsedMB, "MB",
+			"the limit of storage profile '%s' is exceeded", storageProfile.Name)
+	}
+
+	result.CanAccommodate = len(result.LimitingFactors) == 0
+	return result
+}
+
+// addLimit adds a limiting factor to the result when requested is greater than available
+func (result *VmCapacityCheck) addLimit(resource string, requested, available int64, units, reasonFormat string, args ...interface{}) {
+	if available < 0 {
+		available = 0
+	}
+	if requested <= available {
+		return
+	}
+	result.LimitingFactors = append(result.LimitingFactors, VmCapacityLimit{
+		Resource:  resource,
+		Requested: requested,
+		Available: available,
+		Units:     units,
+		Reason:    fmt.Sprintf(reasonFormat, args...),
+	})
+}
+
+// capacityToMB converts a memory or storage amount expressed in units (MB, GB or TB) to MB
+func capacityToMB(amount int64, units string) int64 {
+	switch strings.ToUpper(units) {
+	case "GB":
+		return amount * 1024
+	case "TB":
+		return amount * 1024 * 1024
+	default:
+		return amount
+	}
+}
@@ -0,0 +1,103 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_checkVmCapacity(t *testing.T) {
+	vdc := &types.Vdc{
+		Name: "vdc",
+		ComputeCapacity: []*types.ComputeCapacity{{
+			CPU:    &types.CapacityWithUsage{Units: "MHz", Limit: 10000, Used: 6000},
+			Memory: &types.CapacityWithUsage{Units: "MB", Limit: 8192, Used: 4096},
+		}},
+	}
+	payAsYouGoVdc := &types.Vdc{
+		Name:            "payg",
+		ComputeCapacity: []*types.ComputeCapacity{{CPU: &types.CapacityWithUsage{Units: "MHz"}, Memory: &types.CapacityWithUsage{Units: "MB"}}},
+	}
+	disabled := false
+	storageProfile := &types.VdcStorageProfile{Name: "*", Units: "MB", Limit: 102400, StorageUsedMB: 92160}
+	unlimitedStorageProfile := &types.VdcStorageProfile{Name: "*", Units: "MB"}
+	disabledStorageProfile := &types.VdcStorageProfile{Name: "*", Units: "MB", Enabled: &disabled}
+	cpuCount, cpuSpeed, memory := 8, 1000, 2048
+
+	tests := []struct {
+		name           string
+		vdc            *types.Vdc
+		storageProfile *types.VdcStorageProfile
+		spec           VmSpec
+		wantLimits     []string
+		wantWarnings   int
+	}{
+		{
+			name:           "Fits",
+			vdc:            vdc,
+			storageProfile: storageProfile,
+			spec:           VmSpec{Cpus: 2, CpuSpeedMhz: 1000, MemoryMB: 2048, StorageMB: 10240},
+		},
+		{
+			name:           "AllExceeded",
+			vdc:            vdc,
+			storageProfile: storageProfile,
+			spec:           VmSpec{Cpus: 6, CpuSpeedMhz: 1000, MemoryMB: 8192, StorageMB: 20480},
+			wantLimits:     []string{VmCapacityResourceCpu, VmCapacityResourceMemory, VmCapacityResourceStorage},
+		},
+		{
+			name:           "SizingPolicyOverridesSpec",
+			vdc:            vdc,
+			storageProfile: storageProfile,
+			spec: VmSpec{Cpus: 1, MemoryMB: 512, SizingPolicy: &types.VdcComputePolicyV2{
+				VdcComputePolicy: types.VdcComputePolicy{CPUCount: &cpuCount, CPUSpeed: &cpuSpeed, Memory: &memory},
+			}},
+			wantLimits: []string{VmCapacityResourceCpu},
+		},
+		{
+			name:           "UnknownCpuSpeed",
+			vdc:            vdc,
+			storageProfile: storageProfile,
+			spec:           VmSpec{Cpus: 64, MemoryMB: 1024},
+			wantWarnings:   1,
+		},
+		{
+			name:           "PayAsYouGo",
+			vdc:            payAsYouGoVdc,
+			storageProfile: unlimitedStorageProfile,
+			spec:           VmSpec{Cpus: 64, CpuSpeedMhz: 3000, MemoryMB: 1048576, StorageMB: 1048576},
+		},
+		{
+			name:           "DisabledStorageProfile",
+			vdc:            payAsYouGoVdc,
+			storageProfile: disabledStorageProfile,
+			spec:           VmSpec{Cpus: 1, MemoryMB: 1024},
+			wantLimits:     []string{VmCapacityResourceStorageProfile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkVmCapacity(tt.vdc, tt.storageProfile, tt.spec)
+			if result.CanAccommodate != (len(tt.wantLimits) == 0) {
+				t.Errorf("expected CanAccommodate %t, got %t", len(tt.wantLimits) == 0, result.CanAccommodate)
+			}
+			if len(result.LimitingFactors) != len(tt.wantLimits) {
+				t.Fatalf("expected limiting factors %v, got %+v", tt.wantLimits, result.LimitingFactors)
+			}
+			for index, limit := range result.LimitingFactors {
+				if limit.Resource != tt.wantLimits[index] {
+					t.Errorf("expected limiting factor %s, got %s", tt.wantLimits[index], limit.Resource)
+				}
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("expected %d warnings, got %v", tt.wantWarnings, result.Warnings)
+			}
+		})
+	}
+}
@@ -187,7 +187,7 @@ func (vdcGroup *VdcGroup) Delete(ctx context.Context) error {
 	err = vdcGroup.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting VDC group: %s", err)
+		return fmt.Errorf("error deleting VDC group: %w", err)
 	}
 
 	return nil
@@ -318,6 +318,18 @@ func (adminOrg *AdminOrg) GetVdcGroupById(ctx context.Context, id string) (*VdcG
 	return vdcGroup, nil
 }
 
+// GetVdcGroupByNameOrId retrieves a VDC group by Name or ID. ID lookup is only attempted if the
+// identifier looks like a UUID or URN.
+func (adminOrg *AdminOrg) GetVdcGroupByNameOrId(ctx context.Context, identifier string) (*VdcGroup, error) {
+	getByName := func(name string, refresh bool) (interface{}, error) { return adminOrg.GetVdcGroupByName(ctx, name) }
+	getById := func(id string, refresh bool) (interface{}, error) { return adminOrg.GetVdcGroupById(ctx, id) }
+	entity, err := getEntityByNameOrIdSkipNonId(getByName, getById, identifier, false)
+	if entity == nil {
+		return nil, err
+	}
+	return entity.(*VdcGroup), err
+}
+
 // GetVdcGroupById Returns VDC group using provided ID
 func (org *Org) GetVdcGroupById(ctx context.Context, id string) (*VdcGroup, error) {
 	if id == "" {
@@ -403,7 +415,7 @@ func (vdcGroup *VdcGroup) GenericUpdate(ctx context.Context) (*VdcGroup, error)
 	err = vdcGroup.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, vdcGroup.VdcGroup,
 		returnVdcGroup.VdcGroup, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC group: %s", err)
+		return nil, fmt.Errorf("error updating VDC group: %w", err)
 	}
 
 	return returnVdcGroup, nil
@@ -433,7 +445,7 @@ func (vdcGroup *VdcGroup) UpdateDfwPolicies(ctx context.Context, dfwPolicies typ
 	err = vdcGroup.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, dfwPolicies,
 		nil, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC group Dfw policies: %s", err)
+		return nil, fmt.Errorf("error updating VDC group Dfw policies: %w", err)
 	}
 
 	adminOrg := vdcGroup.parent.fullObject().(*AdminOrg)
@@ -465,7 +477,7 @@ func (vdcGroup *VdcGroup) UpdateDefaultDfwPolicies(ctx context.Context, defaultD
 	err = vdcGroup.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, defaultDfwPolicies,
 		nil, getTenantContextHeader(tenantContext))
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC group default DFW policies: %s", err)
+		return nil, fmt.Errorf("error updating VDC group default DFW policies: %w", err)
 	}
 
 	adminOrg := vdcGroup.parent.fullObject().(*AdminOrg)
@@ -119,7 +119,7 @@ func updateVdcNetworkProfile(ctx context.Context, client *Client, vdcId string,
 	returnObject := &types.VdcNetworkProfile{}
 	err = client.OpenApiPutItem(ctx, apiVersion, urlRef, nil, vdcNetworkProfileConfig, returnObject, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC Network Profile configuration: %s", err)
+		return nil, fmt.Errorf("error updating VDC Network Profile configuration: %w", err)
 	}
 
 	return returnObject, nil
@@ -140,7 +140,7 @@ func deleteVdcNetworkProfile(ctx context.Context, client *Client, vdcId string)
 	err = client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting VDC Network Profile configuration: %s", err)
+		return fmt.Errorf("error deleting VDC Network Profile configuration: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Kinds of references checked by Vdc.CheckReferences
+const (
+	ReferenceKindCatalog        = "Catalog"
+	ReferenceKindStorageProfile = "Storage Profile"
+	ReferenceKindNetwork        = "Network"
+	ReferenceKindComputePolicy  = "Compute Policy"
+)
+
+// ReferencePlan lists the entities, by name or ID, that a planned VDC move or vApp template instantiation refers to
+type ReferencePlan struct {
+	Catalogs        []string // Catalogs of the parent Org of the VDC
+	StorageProfiles []string // Storage profiles of the VDC
+	Networks        []string // Org VDC networks available to the VDC
+	ComputePolicies []string // Compute policies (sizing or placement) assigned to the VDC
+}
+
+// ReferenceCheck is the result of the check of a single reference
+type ReferenceCheck struct {
+	Kind       string           // One of the ReferenceKind* values
+	Identifier string           // Name or ID of the entity, as given in the ReferencePlan
+	Found      bool             // True if the entity exists and is accessible from the VDC
+	Reference  *types.Reference // Reference to the entity, if found
+	Message    string           // Details about the failure, if any
+}
+
+// ReferenceIntegrityReport aggregates the reference checks of a ReferencePlan against a VDC
+type ReferenceIntegrityReport struct {
+	VdcName string
+	VdcId   string
+	Checks  []ReferenceCheck
+}
+
+// Broken returns the checks in the report whose entity was not found
+func (report *ReferenceIntegrityReport) Broken() []ReferenceCheck {
+	var broken []ReferenceCheck
+	for _, check := range report.Checks {
+		if !check.Found {
+			broken = append(broken, check)
+		}
+	}
+	return broken
+}
+
+// CheckReferences verifies that all the entities referenced in plan exist and are accessible from the VDC, so that
+// broken references are found before moving workloads to the VDC or instantiating a vApp template in it.
+// A failed lookup is recorded in the report and does not stop the other checks. An error is returned only when the
+// VDC cannot be refreshed.
+func (vdc *Vdc) CheckReferences(ctx context.Context, plan *ReferencePlan) (*ReferenceIntegrityReport, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("reference plan is empty")
+	}
+	err := vdc.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing VDC '%s': %w", vdc.Vdc.Name, err)
+	}
+
+	report := &ReferenceIntegrityReport{VdcName: vdc.Vdc.Name, VdcId: vdc.Vdc.ID}
+	report.Checks = append(report.Checks, vdc.checkCatalogReferences(ctx, plan.Catalogs)...)
+	report.Checks = append(report.Checks, vdc.checkStorageProfileReferences(plan.StorageProfiles)...)
+	report.Checks = append(report.Checks, vdc.checkNetworkReferences(ctx, plan.Networks)...)
+	report.Checks = append(report.Checks, vdc.checkComputePolicyReferences(ctx, plan.ComputePolicies)...)
+	return report, nil
+}
+
+// GetReferencePlan returns the storage profiles and Org VDC networks that the vApp template and its VMs refer to,
+// to be checked with Vdc.CheckReferences before instantiating the template
+func (vAppTemplate *VAppTemplate) GetReferencePlan() *ReferencePlan {
+	plan := &ReferencePlan{}
+	templates := []*types.VAppTemplate{vAppTemplate.VAppTemplate}
+	if vAppTemplate.VAppTemplate.Children != nil {
+		templates = append(templates, vAppTemplate.VAppTemplate.Children.VM...)
+	}
+	for _, template := range templates {
+		if template.DefaultStorageProfile != "" && !contains(template.DefaultStorageProfile, plan.StorageProfiles) {
+			plan.StorageProfiles = append(plan.StorageProfiles, template.DefaultStorageProfile)
+		}
+		if template.NetworkConfigSection == nil {
+			continue
+		}
+		for _, networkConfig := range template.NetworkConfigSection.NetworkConfig {
+			if networkConfig.Configuration == nil || networkConfig.Configuration.ParentNetwork == nil {
+				continue
+			}
+			networkName := networkConfig.Configuration.ParentNetwork.Name
+			if networkName != "" && !contains(networkName, plan.Networks) {
+				plan.Networks = append(plan.Networks, networkName)
+			}
+		}
+	}
+	return plan
+}
+
+// checkCatalogReferences checks that the catalogs exist in the parent Org of the VDC
+func (vdc *Vdc) checkCatalogReferences(ctx context.Context, identifiers []string) []ReferenceCheck {
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	var getCatalog func(identifier string) (*Catalog, error)
+	parentOrg, parentErr := vdc.getParentOrg(ctx)
+	switch org := parentOrg.(type) {
+	case *Org:
+		getCatalog = func(identifier string) (*Catalog, error) {
+			return org.GetCatalogByNameOrId(ctx, identifier, false)
+		}
+	case *AdminOrg:
+		getCatalog = func(identifier string) (*Catalog, error) {
+			return org.GetCatalogByNameOrId(ctx, identifier, false)
+		}
+	default:
+		if parentErr == nil {
+			parentErr = fmt.Errorf("unexpected parent type %T", parentOrg)
+		}
+	}
+
+	checks := make([]ReferenceCheck, len(identifiers))
+	for index, identifier := range identifiers {
+		checks[index] = ReferenceCheck{Kind: ReferenceKindCatalog, Identifier: identifier}
+		if getCatalog == nil {
+			checks[index].Message = fmt.Sprintf("error retrieving parent Org of VDC: %s", parentErr)
+			continue
+		}
+		catalog, err := getCatalog(identifier)
+		if err != nil {
+			checks[index].Message = err.Error()
+			continue
+		}
+		checks[index].Found = true
+		checks[index].Reference = &types.Reference{
+			HREF: catalog.Catalog.HREF,
+			ID:   catalog.Catalog.ID,
+			Name: catalog.Catalog.Name,
+			Type: catalog.Catalog.Type,
+		}
+	}
+	return checks
+}
+
+// checkStorageProfileReferences checks that the storage profiles are available in the VDC
+func (vdc *Vdc) checkStorageProfileReferences(identifiers []string) []ReferenceCheck {
+	var storageProfiles []*types.Reference
+	if vdc.Vdc.VdcStorageProfiles != nil {
+		storageProfiles = vdc.Vdc.VdcStorageProfiles.VdcStorageProfile
+	}
+	return checkReferencesInList(ReferenceKindStorageProfile, identifiers, storageProfiles)
+}
+
+// checkNetworkReferences checks that the Org VDC networks are available to the VDC
+func (vdc *Vdc) checkNetworkReferences(ctx context.Context, identifiers []string) []ReferenceCheck {
+	checks := make([]ReferenceCheck, len(identifiers))
+	for index, identifier := range identifiers {
+		checks[index] = ReferenceCheck{Kind: ReferenceKindNetwork, Identifier: identifier}
+		network, err := vdc.GetOrgVdcNetworkByNameOrId(ctx, identifier, false)
+		if err != nil {
+			checks[index].Message = err.Error()
+			continue
+		}
+		checks[index].Found = true
+		checks[index].Reference = &types.Reference{
+			HREF: network.OrgVDCNetwork.HREF,
+			ID:   network.OrgVDCNetwork.ID,
+			Name: network.OrgVDCNetwork.Name,
+			Type: network.OrgVDCNetwork.Type,
+		}
+	}
+	return checks
+}
+
+// checkComputePolicyReferences checks that the compute policies are assigned to the VDC
+func (vdc *Vdc) checkComputePolicyReferences(ctx context.Context, identifiers []string) []ReferenceCheck {
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	var err error
+	href := vdc.getLinkHref("down", types.MimeVdcComputePolicyReferences)
+	computePolicies := &types.VdcComputePolicyReferences{}
+	if href == "" {
+		err = fmt.Errorf("VDC '%s' has no compute policy references", vdc.Vdc.Name)
+	} else {
+		_, err = vdc.client.ExecuteRequest(ctx, href, http.MethodGet, types.MimeVdcComputePolicyReferences,
+			"error retrieving compute policies of VDC: %s", nil, computePolicies)
+	}
+	if err != nil {
+		checks := make([]ReferenceCheck, len(identifiers))
+		for index, identifier := range identifiers {
+			checks[index] = ReferenceCheck{Kind: ReferenceKindComputePolicy, Identifier: identifier, Message: err.Error()}
+		}
+		return checks
+	}
+	return checkReferencesInList(ReferenceKindComputePolicy, identifiers, computePolicies.VdcComputePolicyReference)
+}
+
+// checkReferencesInList checks that each identifier matches the name or the ID of one of the references
+func checkReferencesInList(kind string, identifiers []string, references []*types.Reference) []ReferenceCheck {
+	checks := make([]ReferenceCheck, len(identifiers))
+	for index, identifier := range identifiers {
+		checks[index] = ReferenceCheck{Kind: kind, Identifier: identifier}
+		for _, reference := range references {
+			if reference != nil && (reference.Name == identifier || (reference.ID != "" && reference.ID == identifier)) {
+				checks[index].Found = true
+				checks[index].Reference = reference
+				break
+			}
+		}
+		if !checks[index].Found {
+			checks[index].Message = fmt.Sprintf("%s '%s' not found in VDC", kind, identifier)
+		}
+	}
+	return checks
+}
@@ -0,0 +1,92 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_checkReferencesInList(t *testing.T) {
+	references := []*types.Reference{
+		{Name: "gold", ID: "urn:vcloud:vdcstorageProfile:11111111-1111-1111-1111-111111111111"},
+		nil,
+		{Name: "silver", ID: "urn:vcloud:vdcstorageProfile:22222222-2222-2222-2222-222222222222"},
+	}
+	checks := checkReferencesInList(ReferenceKindStorageProfile,
+		[]string{"gold", "urn:vcloud:vdcstorageProfile:22222222-2222-2222-2222-222222222222", "bronze", ""}, references)
+
+	wantFound := []bool{true, true, false, false}
+	if len(checks) != len(wantFound) {
+		t.Fatalf("got %d checks, expected %d", len(checks), len(wantFound))
+	}
+	for index, check := range checks {
+		if check.Kind != ReferenceKindStorageProfile {
+			t.Errorf("check %d: got kind '%s', expected '%s'", index, check.Kind, ReferenceKindStorageProfile)
+		}
+		if check.Found != wantFound[index] {
+			t.Errorf("check %d (%s): got found %t, expected %t", index, check.Identifier, check.Found, wantFound[index])
+		}
+		if check.Found && check.Reference == nil {
+			t.Errorf("check %d (%s): found check has no reference", index, check.Identifier)
+		}
+		if !check.Found && check.Message == "" {
+			t.Errorf("check %d (%s): broken check has no message", index, check.Identifier)
+		}
+	}
+	if checks[1].Reference.Name != "silver" {
+		t.Errorf("got reference '%s' by ID, expected 'silver'", checks[1].Reference.Name)
+	}
+
+	report := ReferenceIntegrityReport{Checks: checks}
+	broken := report.Broken()
+	if len(broken) != 2 || broken[0].Identifier != "bronze" || broken[1].Identifier != "" {
+		t.Errorf("unexpected broken checks: %+v", broken)
+	}
+}
+
+func Test_VAppTemplateGetReferencePlan(t *testing.T) {
+	networkConfig := func(parentNetwork string) types.VAppNetworkConfiguration {
+		configuration := &types.NetworkConfiguration{FenceMode: types.FenceModeBridged}
+		if parentNetwork != "" {
+			configuration.ParentNetwork = &types.Reference{Name: parentNetwork}
+		}
+		return types.VAppNetworkConfiguration{NetworkName: "vapp-" + parentNetwork, Configuration: configuration}
+	}
+
+	vAppTemplate := NewVAppTemplate(nil)
+	vAppTemplate.VAppTemplate = &types.VAppTemplate{
+		Name:                  "template",
+		DefaultStorageProfile: "gold",
+		NetworkConfigSection: &types.NetworkConfigSection{
+			NetworkConfig: []types.VAppNetworkConfiguration{networkConfig("net1"), networkConfig("")},
+		},
+		Children: &types.VAppTemplateChildren{
+			VM: []*types.VAppTemplate{
+				{Name: "vm1", DefaultStorageProfile: "gold"},
+				{
+					Name:                  "vm2",
+					DefaultStorageProfile: "silver",
+					NetworkConfigSection: &types.NetworkConfigSection{
+						NetworkConfig: []types.VAppNetworkConfiguration{networkConfig("net1"), networkConfig("net2")},
+					},
+				},
+			},
+		},
+	}
+
+	plan := vAppTemplate.GetReferencePlan()
+	expected := &ReferencePlan{
+		StorageProfiles: []string{"gold", "silver"},
+		Networks:        []string{"net1", "net2"},
+	}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Errorf("got plan %+v, expected %+v", plan, expected)
+	}
+}
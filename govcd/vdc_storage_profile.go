@@ -0,0 +1,189 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// VdcStorageProfile is a storage profile of an Org VDC. Its metadata is always managed through the admin endpoint
+type VdcStorageProfile struct {
+	VdcStorageProfile *types.VdcStorageProfile
+	client            *Client
+}
+
+// NewVdcStorageProfile creates an empty VdcStorageProfile
+func NewVdcStorageProfile(cli *Client) *VdcStorageProfile {
+	return &VdcStorageProfile{
+		VdcStorageProfile: new(types.VdcStorageProfile),
+		client:            cli,
+	}
+}
+
+// AdminVdcStorageProfile is the admin view of a storage profile of an Org VDC
+type AdminVdcStorageProfile struct {
+	AdminVdcStorageProfile *types.AdminVdcStorageProfile
+	client                 *Client
+}
+
+// NewAdminVdcStorageProfile creates an empty AdminVdcStorageProfile
+func NewAdminVdcStorageProfile(cli *Client) *AdminVdcStorageProfile {
+	return &AdminVdcStorageProfile{
+		AdminVdcStorageProfile: new(types.AdminVdcStorageProfile),
+		client:                 cli,
+	}
+}
+
+// GetStorageProfileByName retrieves the storage profile of the VDC with the given name
+func (adminVdc *AdminVdc) GetStorageProfileByName(ctx context.Context, name string) (*VdcStorageProfile, error) {
+	reference, err := findStorageProfileReference(&adminVdc.AdminVdc.Vdc, name, "")
+	if err != nil {
+		return nil, err
+	}
+	return adminVdc.getStorageProfileByHref(ctx, reference.HREF)
+}
+
+// GetStorageProfileById retrieves the storage profile of the VDC with the given ID.
+// The ID can be either a URN (urn:vcloud:vdcstorageProfile:<uuid>) or a plain UUID
+func (adminVdc *AdminVdc) GetStorageProfileById(ctx context.Context, id string) (*VdcStorageProfile, error) {
+	reference, err := findStorageProfileReference(&adminVdc.AdminVdc.Vdc, "", id)
+	if err != nil {
+		return nil, err
+	}
+	return adminVdc.getStorageProfileByHref(ctx, reference.HREF)
+}
+
+// GetAdminStorageProfileByName retrieves the admin view of the storage profile of the VDC with the given name
+func (adminVdc *AdminVdc) GetAdminStorageProfileByName(ctx context.Context, name string) (*AdminVdcStorageProfile, error) {
+	reference, err := findStorageProfileReference(&adminVdc.AdminVdc.Vdc, name, "")
+	if err != nil {
+		return nil, err
+	}
+	return getAdminStorageProfileByHref(ctx, adminVdc.client, reference.HREF)
+}
+
+// GetAdminStorageProfileById retrieves the admin view of the storage profile of the VDC with the given ID.
+// The ID can be either a URN (urn:vcloud:vdcstorageProfile:<uuid>) or a plain UUID
+func (adminVdc *AdminVdc) GetAdminStorageProfileById(ctx context.Context, id string) (*AdminVdcStorageProfile, error) {
+	reference, err := findStorageProfileReference(&adminVdc.AdminVdc.Vdc, "", id)
+	if err != nil {
+		return nil, err
+	}
+	return getAdminStorageProfileByHref(ctx, adminVdc.client, reference.HREF)
+}
+
+// GetStorageProfileByName retrieves the storage profile of the VDC with the given name.
+// Its metadata is managed through the admin endpoint, which requires Org administrator rights to be modified
+func (vdc *Vdc) GetStorageProfileByName(ctx context.Context, name string) (*VdcStorageProfile, error) {
+	reference, err := findStorageProfileReference(vdc.Vdc, name, "")
+	if err != nil {
+		return nil, err
+	}
+	return getStorageProfileByHref(ctx, vdc.client, reference.HREF)
+}
+
+// GetStorageProfileById retrieves the storage profile of the VDC with the given ID.
+// The ID can be either a URN (urn:vcloud:vdcstorageProfile:<uuid>) or a plain UUID
+func (vdc *Vdc) GetStorageProfileById(ctx context.Context, id string) (*VdcStorageProfile, error) {
+	reference, err := findStorageProfileReference(vdc.Vdc, "", id)
+	if err != nil {
+		return nil, err
+	}
+	return getStorageProfileByHref(ctx, vdc.client, reference.HREF)
+}
+
+// findStorageProfileReference returns the reference of the storage profile of the VDC with the given name or,
+// when name is empty, with the given ID
+func findStorageProfileReference(vdc *types.Vdc, name, id string) (*types.Reference, error) {
+	uuid := ""
+	if name == "" {
+		uuid = extractUuid(id)
+		if uuid == "" {
+			return nil, fmt.Errorf("invalid storage profile ID '%s'", id)
+		}
+	}
+	if vdc.VdcStorageProfiles == nil {
+		return nil, ErrorEntityNotFound
+	}
+	for _, reference := range vdc.VdcStorageProfiles.VdcStorageProfile {
+		if name != "" && reference.Name == name {
+			return reference, nil
+		}
+		if uuid != "" && (extractUuid(reference.ID) == uuid || extractUuid(reference.HREF) == uuid) {
+			return reference, nil
+		}
+	}
+	return nil, ErrorEntityNotFound
+}
+
+// getStorageProfileByHref retrieves a storage profile using the given HREF
+func getStorageProfileByHref(ctx context.Context, client *Client, href string) (*VdcStorageProfile, error) {
+	storageProfile, err := client.GetStorageProfileByHref(ctx, href)
+	if err != nil {
+		return nil, err
+	}
+	if storageProfile.HREF == "" {
+		storageProfile.HREF = href
+	}
+	return &VdcStorageProfile{
+		VdcStorageProfile: storageProfile,
+		client:            client,
+	}, nil
+}
+
+// getStorageProfileByHref retrieves a storage profile using its admin HREF, as metadata for storage profiles
+// can only be managed through the admin endpoint
+func (adminVdc *AdminVdc) getStorageProfileByHref(ctx context.Context, href string) (*VdcStorageProfile, error) {
+	return getStorageProfileByHref(ctx, adminVdc.client, getAdminURL(href))
+}
+
+// getAdminStorageProfileByHref retrieves the admin view of a storage profile using its HREF
+func getAdminStorageProfileByHref(ctx context.Context, client *Client, href string) (*AdminVdcStorageProfile, error) {
+	adminStorageProfile := NewAdminVdcStorageProfile(client)
+	// only from 35.0 API version IOPS settings are added to response
+	_, err := client.ExecuteRequestWithApiVersion(ctx, getAdminURL(href), http.MethodGet, "",
+		"error retrieving storage profile: %s", nil, adminStorageProfile.AdminVdcStorageProfile,
+		client.GetSpecificApiVersionOnCondition(ctx, ">= 35.0", "35.0"))
+	if err != nil {
+		return nil, err
+	}
+	if adminStorageProfile.AdminVdcStorageProfile.HREF == "" {
+		adminStorageProfile.AdminVdcStorageProfile.HREF = getAdminURL(href)
+	}
+	return adminStorageProfile, nil
+}
+
+// Refresh retrieves the storage profile again from VCD
+func (storageProfile *VdcStorageProfile) Refresh(ctx context.Context) error {
+	if storageProfile.VdcStorageProfile == nil || storageProfile.VdcStorageProfile.HREF == "" {
+		return fmt.Errorf("cannot refresh storage profile without HREF")
+	}
+	refreshed, err := storageProfile.client.GetStorageProfileByHref(ctx, storageProfile.VdcStorageProfile.HREF)
+	if err != nil {
+		return err
+	}
+	if refreshed.HREF == "" {
+		refreshed.HREF = storageProfile.VdcStorageProfile.HREF
+	}
+	storageProfile.VdcStorageProfile = refreshed
+	return nil
+}
+
+// Refresh retrieves the storage profile again from VCD
+func (adminStorageProfile *AdminVdcStorageProfile) Refresh(ctx context.Context) error {
+	if adminStorageProfile.AdminVdcStorageProfile == nil || adminStorageProfile.AdminVdcStorageProfile.HREF == "" {
+		return fmt.Errorf("cannot refresh storage profile without HREF")
+	}
+	refreshed, err := getAdminStorageProfileByHref(ctx, adminStorageProfile.client, adminStorageProfile.AdminVdcStorageProfile.HREF)
+	if err != nil {
+		return err
+	}
+	adminStorageProfile.AdminVdcStorageProfile = refreshed.AdminVdcStorageProfile
+	return nil
+}
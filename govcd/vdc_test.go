@@ -353,7 +353,7 @@ func (vcd *TestVCD) Test_GetVApp(check *C) {
 		parentType:    "Vdc",
 		parentName:    vcd.config.VCD.Vdc,
 		entityType:    "VApp",
-		entityName:    TestSetUpSuite,
+		entityName:    setUpSuiteVappName(),
 		getByName:     getByName,
 		getById:       getById,
 		getByNameOrId: getByNameOrId,
@@ -439,7 +439,7 @@ func (vcd *TestVCD) TestGetVappList(check *C) {
 
 	// Use the search engine to find the known vApp
 	criteria := NewFilterDef()
-	err = criteria.AddFilter(types.FilterNameRegex, TestSetUpSuite)
+	err = criteria.AddFilter(types.FilterNameRegex, setUpSuiteVappName())
 	check.Assert(err, IsNil)
 	queryType := vcd.client.Client.GetQueryType(types.QtVapp)
 	queryItems, _, err := vcd.client.Client.SearchByFilter(ctx, queryType, criteria)
@@ -687,3 +687,52 @@ func (vcd *TestVCD) TestMediaRetrieval(check *C) {
 	check.Assert(err, NotNil)
 	check.Assert(mediaFromVdc, IsNil)
 }
+
+func (vcd *TestVCD) Test_VdcCanAccommodate(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	result, err := vcd.vdc.CanAccommodate(ctx, VmSpec{Cpus: 1, MemoryMB: 512, StorageMB: 1024})
+	check.Assert(err, IsNil)
+	check.Assert(result, NotNil)
+	if !result.CanAccommodate {
+		check.Assert(len(result.LimitingFactors) > 0, Equals, true)
+	}
+
+	if vcd.config.VCD.StorageProfile.SP1 != "" {
+		_, err = vcd.vdc.CanAccommodate(ctx, VmSpec{Cpus: 1, MemoryMB: 512, StorageProfileName: vcd.config.VCD.StorageProfile.SP1})
+		check.Assert(err, IsNil)
+	}
+
+	_, err = vcd.vdc.CanAccommodate(ctx, VmSpec{Cpus: 1, MemoryMB: 512, StorageProfileName: "non-existing-" + check.TestName()})
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
+
+func (vcd *TestVCD) Test_VdcCheckReferences(check *C) {
+	if vcd.config.VCD.Catalog.Name == "" || vcd.config.VCD.StorageProfile.SP1 == "" || vcd.config.VCD.Network.Net1 == "" {
+		check.Skip("Skipping test because catalog, storage profile or network were not given")
+	}
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	invalidName := "non-existing-" + check.TestName()
+	report, err := vcd.vdc.CheckReferences(ctx, &ReferencePlan{
+		Catalogs:        []string{vcd.config.VCD.Catalog.Name, invalidName},
+		StorageProfiles: []string{vcd.config.VCD.StorageProfile.SP1, invalidName},
+		Networks:        []string{vcd.config.VCD.Network.Net1, invalidName},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(report.VdcName, Equals, vcd.vdc.Vdc.Name)
+	check.Assert(len(report.Checks), Equals, 6)
+	for _, referenceCheck := range report.Checks {
+		check.Assert(referenceCheck.Found, Equals, referenceCheck.Identifier != invalidName)
+		if referenceCheck.Found {
+			check.Assert(referenceCheck.Reference, NotNil)
+			check.Assert(referenceCheck.Reference.HREF, Not(Equals), "")
+		}
+	}
+
+	broken := report.Broken()
+	check.Assert(len(broken), Equals, 3)
+	for _, referenceCheck := range broken {
+		check.Assert(referenceCheck.Message, Not(Equals), "")
+	}
+}
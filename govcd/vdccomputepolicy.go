@@ -153,7 +153,7 @@ func (client *Client) CreateVdcComputePolicy(ctx context.Context, newVdcComputeP
 
 	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, newVdcComputePolicy, returnVdcComputePolicy.VdcComputePolicy, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating VDC compute policy: %s", err)
+		return nil, fmt.Errorf("error creating VDC compute policy: %w", err)
 	}
 
 	return returnVdcComputePolicy, nil
@@ -184,7 +184,7 @@ func (vdcComputePolicy *VdcComputePolicy) Update(ctx context.Context) (*VdcCompu
 
 	err = vdcComputePolicy.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, vdcComputePolicy.VdcComputePolicy, returnVdcComputePolicy.VdcComputePolicy, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC compute policy: %s", err)
+		return nil, fmt.Errorf("error updating VDC compute policy: %w", err)
 	}
 
 	return returnVdcComputePolicy, nil
@@ -211,7 +211,7 @@ func (vdcComputePolicy *VdcComputePolicy) Delete(ctx context.Context) error {
 	err = vdcComputePolicy.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting VDC compute policy: %s", err)
+		return fmt.Errorf("error deleting VDC compute policy: %w", err)
 	}
 
 	return nil
@@ -6,6 +6,7 @@ package govcd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -24,13 +25,13 @@ type VdcComputePolicyV2 struct {
 
 // GetVdcComputePolicyV2ById retrieves VDC Compute Policy (V2) by given ID
 func (client *VCDClient) GetVdcComputePolicyV2ById(ctx context.Context, id string) (*VdcComputePolicyV2, error) {
-	return getVdcComputePolicyV2ById(ctx, client, id)
+	return getVdcComputePolicyV2ById(ctx, &client.Client, id)
 }
 
 // getVdcComputePolicyV2ById retrieves VDC Compute Policy (V2) by given ID
-func getVdcComputePolicyV2ById(ctx context.Context, client *VCDClient, id string) (*VdcComputePolicyV2, error) {
+func getVdcComputePolicyV2ById(ctx context.Context, client *Client, id string) (*VdcComputePolicyV2, error) {
 	endpoint := types.OpenApiPathVersion2_0_0 + types.OpenApiEndpointVdcComputePolicies
-	minimumApiVersion, err := client.Client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +40,7 @@ func getVdcComputePolicyV2ById(ctx context.Context, client *VCDClient, id string
 		return nil, fmt.Errorf("empty VDC id")
 	}
 
-	urlRef, err := client.Client.OpenApiBuildEndpoint(endpoint, id)
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
 
 	if err != nil {
 		return nil, err
@@ -48,10 +49,10 @@ func getVdcComputePolicyV2ById(ctx context.Context, client *VCDClient, id string
 	vdcComputePolicy := &VdcComputePolicyV2{
 		VdcComputePolicyV2: &types.VdcComputePolicyV2{},
 		Href:               urlRef.String(),
-		client:             &client.Client,
+		client:             client,
 	}
 
-	err = client.Client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, vdcComputePolicy.VdcComputePolicyV2, nil)
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, vdcComputePolicy.VdcComputePolicyV2, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +149,7 @@ func (vdcComputePolicy *VdcComputePolicyV2) Update(ctx context.Context) (*VdcCom
 
 	err = vdcComputePolicy.client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, vdcComputePolicy.VdcComputePolicyV2, returnVdcComputePolicy.VdcComputePolicyV2, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error updating VDC Compute Policy: %s", err)
+		return nil, fmt.Errorf("error updating VDC Compute Policy: %w", err)
 	}
 
 	return returnVdcComputePolicy, nil
@@ -174,7 +175,7 @@ func (vdcComputePolicy *VdcComputePolicyV2) Delete(ctx context.Context) error {
 	err = vdcComputePolicy.client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("error deleting VDC Compute Policy: %s", err)
+		return fmt.Errorf("error deleting VDC Compute Policy: %w", err)
 	}
 
 	return nil
@@ -239,12 +240,12 @@ func (vdc *AdminVdc) SetAssignedComputePolicies(ctx context.Context, computePoli
 
 	adminVdcPolicyHREF, err := url.ParseRequestURI(vdc.AdminVdc.HREF)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing VDC URL: %s", err)
+		return nil, fmt.Errorf("error parsing VDC URL: %w", err)
 	}
 
 	vdcId, err := GetUuidFromHref(vdc.AdminVdc.HREF, true)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get vdc ID from HREF: %s", err)
+		return nil, fmt.Errorf("unable to get vdc ID from HREF: %w", err)
 	}
 	adminVdcPolicyHREF.Path = "/api/admin/vdc/" + vdcId + "/computePolicies"
 
@@ -265,7 +266,7 @@ func (vdc *AdminVdc) SetAssignedComputePolicies(ctx context.Context, computePoli
 // hard to read and understand, so this function simplifies the message.
 // Note: This function should not be needed anymore once VCD 10.4.0 is discontinued (this issue is fixed in 10.4.1).
 func getFriendlyErrorIfVmPlacementPolicyAlreadyExists(vmPlacementPolicyName string, err error) error {
-	if err != nil && strings.Contains(err.Error(), "already exists") && strings.Contains(err.Error(), "duplicate key") {
+	if errors.Is(mapServerError(err, serverErrorScopeComputePolicy), ErrorEntityAlreadyExists) {
 		return fmt.Errorf("VM Placement Policy with name '%s' already exists", vmPlacementPolicyName)
 	}
 	return err
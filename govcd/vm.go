@@ -6,6 +6,7 @@ package govcd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -45,7 +46,7 @@ func NewVMRecord(cli *Client) *VMRecord {
 func (vm *VM) GetStatus(ctx context.Context) (string, error) {
 	err := vm.Refresh(ctx)
 	if err != nil {
-		return "", fmt.Errorf("error refreshing VM: %s", err)
+		return "", fmt.Errorf("error refreshing VM: %w", err)
 	}
 	return types.VAppStatuses[vm.VM.Status], nil
 }
@@ -54,7 +55,7 @@ func (vm *VM) GetStatus(ctx context.Context) (string, error) {
 func (vm *VM) IsDeployed(ctx context.Context) (bool, error) {
 	err := vm.Refresh(ctx)
 	if err != nil {
-		return false, fmt.Errorf("error refreshing VM: %s", err)
+		return false, fmt.Errorf("error refreshing VM: %w", err)
 	}
 	return vm.VM.Deployed, nil
 }
@@ -120,7 +121,7 @@ func (vm *VM) UpdateNetworkConnectionSection(ctx context.Context, networks *type
 	// Retrieve current network configuration so that we are not altering any other internal fields
 	updateNetwork, err := vm.GetNetworkConnectionSection(ctx)
 	if err != nil {
-		return fmt.Errorf("cannot read network section for update: %s", err)
+		return fmt.Errorf("cannot read network section for update: %w", err)
 	}
 	updateNetwork.PrimaryNetworkConnectionIndex = networks.PrimaryNetworkConnectionIndex
 	updateNetwork.NetworkConnection = networks.NetworkConnection
@@ -133,7 +134,7 @@ func (vm *VM) UpdateNetworkConnectionSection(ctx context.Context, networks *type
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error waiting for task completion after network update for vm %s: %s", vm.VM.Name, err)
+		return fmt.Errorf("error waiting for task completion after network update for vm %s: %w", vm.VM.Name, err)
 	}
 
 	return nil
@@ -193,7 +194,7 @@ func (vm *VM) PowerOnAndForceCustomization(ctx context.Context) error {
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return fmt.Errorf("error waiting for task completion after power on with customization %s: %s", vm.VM.Name, err)
+		return fmt.Errorf("error waiting for task completion after power on with customization %s: %w", vm.VM.Name, err)
 	}
 
 	return nil
@@ -226,7 +227,7 @@ func (vm *VM) ChangeCPUCount(ctx context.Context, virtualCpuCount int) (Task, er
 func (vm *VM) ChangeCPUCountWithCore(ctx context.Context, virtualCpuCount int, coresPerSocket *int) (Task, error) {
 	err := vm.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	newCpu := &types.OVFItem{
@@ -330,17 +331,17 @@ func (vm *VM) updateNicParameters(networks []map[string]interface{}, networkSect
 func (vm *VM) ChangeNetworkConfig(ctx context.Context, networks []map[string]interface{}) (Task, error) {
 	err := vm.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	networkSection, err := vm.GetNetworkConnectionSection(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("could not retrieve network connection for VM: %s", err)
+		return Task{}, fmt.Errorf("could not retrieve network connection for VM: %w", err)
 	}
 
 	err = vm.updateNicParameters(networks, networkSection)
 	if err != nil {
-		return Task{}, fmt.Errorf("failed processing NIC parameters: %s", err)
+		return Task{}, fmt.Errorf("failed processing NIC parameters: %w", err)
 	}
 
 	networkSection.Xmlns = types.XMLNamespaceVCloud
@@ -360,7 +361,7 @@ func (vm *VM) ChangeMemorySize(ctx context.Context, size int) (Task, error) {
 
 	err := vm.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	newMem := &types.OVFItem{
@@ -421,24 +422,23 @@ func (vm *VM) BlockWhileGuestCustomizationStatus(ctx context.Context, unwantedSt
 		return fmt.Errorf("timeOutAfterSeconds must be in range 4<X<7200")
 	}
 
-	timeoutAfter := time.After(time.Duration(timeOutAfterSeconds) * time.Second)
-	tick := time.NewTicker(3 * time.Second)
-
-	for {
-		select {
-		case <-timeoutAfter:
-			return fmt.Errorf("timed out waiting for VM guest customization status to exit state %s after %d seconds",
-				unwantedStatus, timeOutAfterSeconds)
-		case <-tick.C:
-			currentStatus, err := vm.GetGuestCustomizationStatus(ctx)
-			if err != nil {
-				return fmt.Errorf("could not get VM customization status %s", err)
-			}
-			if currentStatus != unwantedStatus {
-				return nil
-			}
+	err := WaitForState(ctx, func() (bool, error) {
+		currentStatus, err := vm.GetGuestCustomizationStatus(ctx)
+		if err != nil {
+			return false, fmt.Errorf("could not get VM customization status %w", err)
 		}
+		return currentStatus != unwantedStatus, nil
+	}, WaitOptions{
+		InitialDelay:    3 * time.Second,
+		InitialInterval: 3 * time.Second,
+		Multiplier:      1,
+		Timeout:         time.Duration(timeOutAfterSeconds) * time.Second,
+	})
+	if errors.Is(err, ErrorWaitTimeout) {
+		return fmt.Errorf("timed out waiting for VM guest customization status to exit state %s after %d seconds",
+			unwantedStatus, timeOutAfterSeconds)
 	}
+	return err
 }
 
 // Customize function allows to set ComputerName, apply customization script and enable or disable the changeSid option
@@ -447,7 +447,7 @@ func (vm *VM) BlockWhileGuestCustomizationStatus(ctx context.Context, unwantedSt
 func (vm *VM) Customize(ctx context.Context, computerName, script string, changeSid bool) (Task, error) {
 	err := vm.Refresh(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("error refreshing VM before running customization: %s", err)
+		return Task{}, fmt.Errorf("error refreshing VM before running customization: %w", err)
 	}
 
 	vu := &types.GuestCustomizationSection{
@@ -601,18 +601,18 @@ func (vm *VM) HandleInsertMedia(ctx context.Context, org *Org, catalogName, medi
 func (vm *VM) HandleEjectMediaAndAnswer(ctx context.Context, org *Org, catalogName, mediaName string, answerYes bool) (*VM, error) {
 	task, err := vm.HandleEjectMedia(ctx, org, catalogName, mediaName)
 	if err != nil {
-		return nil, fmt.Errorf("error: %s", err)
+		return nil, fmt.Errorf("error: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx, answerYes)
 	if err != nil {
-		return nil, fmt.Errorf("error: %s", err)
+		return nil, fmt.Errorf("error: %w", err)
 	}
 
 	for i := 0; i < 10; i++ {
 		err = vm.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error: %s", err)
+			return nil, fmt.Errorf("error: %w", err)
 		}
 		if !isMediaInjected(vm.VM.VirtualHardwareSection.Item) {
 			return vm, nil
@@ -737,7 +737,7 @@ func (vm *VM) GetQuestion(ctx context.Context) (types.VmPendingQuestion, error)
 
 	resp, err := vm.client.Http.Do(req)
 	if err != nil {
-		return types.VmPendingQuestion{}, fmt.Errorf("error getting VM question: %s", err)
+		return types.VmPendingQuestion{}, fmt.Errorf("error getting VM question: %w", err)
 	}
 
 	// vCD security feature - on no question return 403 access error
@@ -753,7 +753,7 @@ func (vm *VM) GetQuestion(ctx context.Context) (types.VmPendingQuestion, error)
 	question := &types.VmPendingQuestion{}
 
 	if err = decodeBody(types.BodyTypeXML, resp, question); err != nil {
-		return types.VmPendingQuestion{}, fmt.Errorf("error decoding question response: %s", err)
+		return types.VmPendingQuestion{}, fmt.Errorf("error decoding question response: %w", err)
 	}
 
 	// The request was successful
@@ -790,7 +790,7 @@ func (vm *VM) AnswerQuestion(ctx context.Context, questionId string, choiceId in
 func (vm *VM) ToggleHardwareVirtualization(ctx context.Context, isEnabled bool) (Task, error) {
 	vmStatus, err := vm.GetStatus(ctx)
 	if err != nil {
-		return Task{}, fmt.Errorf("unable to toggle hardware virtualization: %s", err)
+		return Task{}, fmt.Errorf("unable to toggle hardware virtualization: %w", err)
 	}
 	if vmStatus != "POWERED_OFF" && vmStatus != "PARTIALLY_POWERED_OFF" {
 		return Task{}, fmt.Errorf("hardware virtualization can be changed from powered off state, status: %s", vmStatus)
@@ -814,7 +814,7 @@ func (vm *VM) ToggleHardwareVirtualization(ctx context.Context, isEnabled bool)
 func (vm *VM) SetProductSectionList(ctx context.Context, productSection *types.ProductSectionList) (*types.ProductSectionList, error) {
 	err := setProductSectionList(ctx, vm.client, vm.VM.HREF, productSection)
 	if err != nil {
-		return nil, fmt.Errorf("unable to set VM product section: %s", err)
+		return nil, fmt.Errorf("unable to set VM product section: %w", err)
 	}
 
 	return vm.GetProductSectionList(ctx)
@@ -832,7 +832,7 @@ func (vm *VM) GetProductSectionList(ctx context.Context) (*types.ProductSectionL
 func (vm *VM) GetEnvironment(ctx context.Context) (*types.OvfEnvironment, error) {
 	vmStatus, err := vm.GetStatus(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get OVF environment: %s", err)
+		return nil, fmt.Errorf("unable to get OVF environment: %w", err)
 	}
 
 	if vmStatus != "POWERED_ON" {
@@ -853,7 +853,7 @@ func (vm *VM) GetGuestCustomizationSection(ctx context.Context) (*types.GuestCus
 		types.MimeGuestCustomizationSection, "error retrieving guest customization section : %s", nil, guestCustomizationSection)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve guest customization section: %s", err)
+		return nil, fmt.Errorf("unable to retrieve guest customization section: %w", err)
 	}
 
 	return guestCustomizationSection, nil
@@ -872,12 +872,12 @@ func (vm *VM) SetGuestCustomizationSection(ctx context.Context, guestCustomizati
 		types.MimeGuestCustomizationSection, "error setting product section: %s", guestCustomizationSection)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to set guest customization section: %s", err)
+		return nil, fmt.Errorf("unable to set guest customization section: %w", err)
 	}
 
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("task for setting guest customization section failed: %s", err)
+		return nil, fmt.Errorf("task for setting guest customization section failed: %w", err)
 	}
 
 	return vm.GetGuestCustomizationSection(ctx)
@@ -899,7 +899,7 @@ func (vm *VM) GetParentVApp(ctx context.Context) (*VApp, error) {
 			err := vapp.Refresh(ctx)
 
 			if err != nil {
-				return nil, fmt.Errorf("could not refresh parent vApp for VM %s: %s", vm.VM.Name, err)
+				return nil, fmt.Errorf("could not refresh parent vApp for VM %s: %w", vm.VM.Name, err)
 			}
 
 			return vapp, nil
@@ -917,12 +917,12 @@ func (vm *VM) GetParentVdc(ctx context.Context) (*Vdc, error) {
 
 	vapp, err := vm.GetParentVApp(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not find parent vApp for VM %s: %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("could not find parent vApp for VM %s: %w", vm.VM.Name, err)
 	}
 
 	vdc, err := vapp.getParentVDC(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not find parent vApp for VM %s: %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("could not find parent vApp for VM %s: %w", vm.VM.Name, err)
 	}
 
 	return &vdc, nil
@@ -934,7 +934,7 @@ func (vm *VM) getEdgeGatewaysForRoutedNics(ctx context.Context, nicDhcpConfigs [
 	// Lookup parent vDC for VM
 	vdc, err := vm.GetParentVdc(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not find parent vDC for VM %s: %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("could not find parent vDC for VM %s: %w", vm.VM.Name, err)
 	}
 
 	for index, nic := range nicDhcpConfigs {
@@ -1044,7 +1044,7 @@ func (vm *VM) WaitForDhcpIpByNicIndexes(ctx context.Context, nicIndexes []int, m
 		// Lookup edge gateways for routed networks and store them
 		nicStates, err = vm.getEdgeGatewaysForRoutedNics(ctx, nicStates)
 		if err != nil {
-			return []string{}, false, fmt.Errorf("unable to validate if NICs are attached to edge gateway: %s", err)
+			return []string{}, false, fmt.Errorf("unable to validate if NICs are attached to edge gateway: %w", err)
 		}
 	}
 
@@ -1109,7 +1109,7 @@ func (vm *VM) getEdgeGatewayNameForNic(ctx context.Context, nicIndex int) (strin
 
 	networkConnnectionSection, err := vm.GetNetworkConnectionSection(ctx)
 	if err != nil {
-		return "", fmt.Errorf("could not get IP address for NIC %d: %s", nicIndex, err)
+		return "", fmt.Errorf("could not get IP address for NIC %d: %w", nicIndex, err)
 	}
 
 	// Find NIC
@@ -1127,7 +1127,7 @@ func (vm *VM) getEdgeGatewayNameForNic(ctx context.Context, nicIndex int) (strin
 	// Validate if the VM is attached to routed org vdc network
 	vdc, err := vm.GetParentVdc(ctx)
 	if err != nil {
-		return "", fmt.Errorf("could not find parent vDC for VM %s: %s", vm.VM.Name, err)
+		return "", fmt.Errorf("could not find parent vDC for VM %s: %w", vm.VM.Name, err)
 	}
 
 	edgeGatewayName, err := vdc.FindEdgeGatewayNameByNetwork(ctx, networkConnection.Network)
@@ -1185,7 +1185,7 @@ func (vm *VM) getIpsByDhcpLeaseMacs(ctx context.Context, nicConfigs []nicDhcpCon
 func (vm *VM) getIpsMacsByNicIndexes(ctx context.Context, nicConfigs []nicDhcpConfig) ([]nicDhcpConfig, error) {
 	networkConnnectionSection, err := vm.GetNetworkConnectionSection(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not get IP configuration for VM %s : %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("could not get IP configuration for VM %s : %w", vm.VM.Name, err)
 	}
 
 	// Find NICs and populate their IPs and MACs
@@ -1207,7 +1207,7 @@ func (vm *VM) getIpsMacsByNicIndexes(ctx context.Context, nicConfigs []nicDhcpCo
 func (vm *VM) AddInternalDisk(ctx context.Context, diskData *types.DiskSettings) (string, error) {
 	err := vm.Refresh(ctx)
 	if err != nil {
-		return "", fmt.Errorf("error refreshing VM: %s", err)
+		return "", fmt.Errorf("error refreshing VM: %w", err)
 	}
 
 	err = vm.validateInternalDiskInput(diskData, vm.VM.Name, vm.VM.ID)
@@ -1282,7 +1282,7 @@ func (vm *VM) GetInternalDiskById(ctx context.Context, diskId string, refresh bo
 	if refresh {
 		err := vm.Refresh(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error refreshing VM: %s", err)
+			return nil, fmt.Errorf("error refreshing VM: %w", err)
 		}
 	}
 
@@ -1305,7 +1305,7 @@ func (vm *VM) GetInternalDiskById(ctx context.Context, diskId string, refresh bo
 func (vm *VM) DeleteInternalDisk(ctx context.Context, diskId string) error {
 	err := vm.Refresh(ctx)
 	if err != nil {
-		return fmt.Errorf("error refreshing VM: %s", err)
+		return fmt.Errorf("error refreshing VM: %w", err)
 	}
 
 	diskSettings := vm.VM.VmSpecSection.DiskSection.DiskSettings
@@ -1332,12 +1332,124 @@ func (vm *VM) DeleteInternalDisk(ctx context.Context, diskId string) error {
 
 	_, err = vm.UpdateInternalDisks(ctx, vmSpecSection)
 	if err != nil {
-		return fmt.Errorf("error deleting VM %s internal disk %s: %s", vm.VM.Name, diskId, err)
+		return fmt.Errorf("error deleting VM %s internal disk %s: %w", vm.VM.Name, diskId, err)
 	}
 
 	return nil
 }
 
+// InternalDiskUpdate holds the changes applied to an internal disk by VM.UpdateInternalDisk. Nil fields are left
+// unchanged
+type InternalDiskUpdate struct {
+	SizeMb         *int64           // New size of the disk in MB. Disks can only grow
+	StorageProfile *types.Reference // Storage profile of the disk. A profile other than the VM one overrides it
+	Iops           *int64
+}
+
+// AddInternalDiskWithStorageProfile creates an internal disk like AddInternalDisk, placing it on the given storage
+// profile with the given IOPS. When storageProfile is nil, the disk uses the storage profile of the VM. When iops is
+// nil, the value in diskData is kept. ThinProvisioned defaults to true. diskData is not modified.
+// Returns the settings of the new disk as saved by VCD.
+// Runs synchronously, VM is ready for another operation after this function returns.
+func (vm *VM) AddInternalDiskWithStorageProfile(ctx context.Context, diskData *types.DiskSettings, storageProfile *types.Reference, iops *int64) (*types.DiskSettings, error) {
+	if diskData == nil {
+		return nil, fmt.Errorf("cannot add internal disk - disk settings are empty")
+	}
+	err := vm.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing VM: %w", err)
+	}
+
+	newDisk := *diskData
+	if storageProfile == nil {
+		storageProfile = vm.VM.StorageProfile
+	}
+	if storageProfile != nil {
+		newDisk.StorageProfile = storageProfile
+		newDisk.OverrideVmDefault = !sameReference(storageProfile, vm.VM.StorageProfile)
+	}
+	if iops != nil {
+		newDisk.Iops = iops
+	}
+	if newDisk.ThinProvisioned == nil {
+		newDisk.ThinProvisioned = addrOf(true)
+	}
+
+	diskId, err := vm.AddInternalDisk(ctx, &newDisk)
+	if err != nil {
+		return nil, err
+	}
+	return vm.GetInternalDiskById(ctx, diskId, false)
+}
+
+// UpdateInternalDisk changes the size, storage profile or IOPS of the internal disk with the given ID, keeping the
+// other disks as they are. A disk that only grows is expanded in place, keeping its data. A new storage profile
+// moves the disk to a datastore of that profile. Shrinking a disk is not supported by VCD and returns an error.
+// Returns the settings of the disk as saved by VCD.
+// Runs synchronously, VM is ready for another operation after this function returns.
+func (vm *VM) UpdateInternalDisk(ctx context.Context, diskId string, update InternalDiskUpdate) (*types.DiskSettings, error) {
+	disk, err := vm.GetInternalDiskById(ctx, diskId, true)
+	if err != nil {
+		return nil, err
+	}
+	if disk.Disk != nil {
+		return nil, fmt.Errorf("disk %s of VM %s is an independent disk and cannot be updated as internal disk", diskId, vm.VM.Name)
+	}
+
+	changed, err := applyInternalDiskUpdate(disk, update, vm.VM.StorageProfile)
+	if err != nil {
+		return nil, fmt.Errorf("error updating VM %s internal disk %s: %w", vm.VM.Name, diskId, err)
+	}
+	if !changed {
+		return disk, nil
+	}
+
+	// disk points to the settings in vm.VM.VmSpecSection, which holds the current state of all the other disks
+	_, err = vm.UpdateInternalDisks(ctx, vm.VM.VmSpecSection)
+	if err != nil {
+		return nil, err
+	}
+	return vm.GetInternalDiskById(ctx, diskId, false)
+}
+
+// applyInternalDiskUpdate changes the disk settings as requested by update.
+// Returns whether anything changed
+func applyInternalDiskUpdate(disk *types.DiskSettings, update InternalDiskUpdate, vmStorageProfile *types.Reference) (bool, error) {
+	changed := false
+	if update.SizeMb != nil && *update.SizeMb != disk.SizeMb {
+		if *update.SizeMb < disk.SizeMb {
+			return false, fmt.Errorf("disk size can only grow (from %d MB to %d MB requested)", disk.SizeMb, *update.SizeMb)
+		}
+		disk.SizeMb = *update.SizeMb
+		// The size is also reported in VirtualQuantity, which would conflict with the new SizeMb
+		disk.VirtualQuantity = nil
+		disk.VirtualQuantityUnit = ""
+		changed = true
+	}
+	if update.StorageProfile != nil && !sameReference(update.StorageProfile, disk.StorageProfile) {
+		disk.StorageProfile = update.StorageProfile
+		disk.OverrideVmDefault = !sameReference(update.StorageProfile, vmStorageProfile)
+		changed = true
+	}
+	if update.Iops != nil && (disk.Iops == nil || *disk.Iops != *update.Iops) {
+		disk.Iops = update.Iops
+		changed = true
+	}
+	return changed, nil
+}
+
+// sameReference checks whether two references point to the same entity, comparing their IDs or HREFs
+func sameReference(first, second *types.Reference) bool {
+	if first == nil || second == nil {
+		return false
+	}
+	firstId, secondId := first.ID, second.ID
+	if firstId == "" || secondId == "" {
+		firstId, secondId = first.HREF, second.HREF
+	}
+	return firstId != "" && extractUuid(firstId) == extractUuid(secondId)
+}
+
 // UpdateInternalDisks applies disks configuration for the VM.
 // types.VmSpecSection has to have all internal disk state. Disks which don't match provided ones in types.VmSpecSection
 // will be deleted. Matched internal disk will be updated. New internal disk description found
@@ -1354,11 +1466,11 @@ func (vm *VM) UpdateInternalDisks(ctx context.Context, disksSettingToUpdate *typ
 	}
 	err = task.WaitTaskCompletion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for task completion after internal disks update for VM %s: %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("error waiting for task completion after internal disks update for VM %s: %w", vm.VM.Name, err)
 	}
 	err = vm.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing VM %s: %s", vm.VM.Name, err)
+		return nil, fmt.Errorf("error refreshing VM %s: %w", vm.VM.Name, err)
 	}
 	return vm.VM.VmSpecSection, nil
 }
@@ -1521,6 +1633,67 @@ func (vm *VM) UpdateComputePolicyV2(ctx context.Context, sizingPolicyId, placeme
 
 }
 
+// VmComputePolicies are the compute policies assigned to a VM, as returned by VM.GetComputePolicies
+type VmComputePolicies struct {
+	SizingPolicy    *VdcComputePolicyV2 // nil when the VM has no sizing policy
+	PlacementPolicy *VdcComputePolicyV2 // nil when the VM has no placement policy
+	// SizingPolicyFinal and PlacementPolicyFinal are true when the policy cannot be removed from the VM
+	SizingPolicyFinal    bool
+	PlacementPolicyFinal bool
+}
+
+// GetComputePolicyIds returns the IDs of the sizing and placement policies of the VM, which are empty when the VM
+// has no such policy. The IDs are those of the VM structure: refresh the VM to get the current ones.
+func (vm *VM) GetComputePolicyIds() (sizingPolicyId, placementPolicyId string) {
+	if vm.VM.ComputePolicy == nil {
+		return "", ""
+	}
+	return computePolicyIdFromReference(vm.VM.ComputePolicy.VmSizingPolicy),
+		computePolicyIdFromReference(vm.VM.ComputePolicy.VmPlacementPolicy)
+}
+
+// GetComputePolicies retrieves the sizing and placement policies assigned to the VM. The policies are those of the
+// VM structure: refresh the VM to get the current ones.
+func (vm *VM) GetComputePolicies(ctx context.Context) (*VmComputePolicies, error) {
+	sizingPolicyId, placementPolicyId := vm.GetComputePolicyIds()
+	policies := &VmComputePolicies{}
+	if vm.VM.ComputePolicy != nil {
+		policies.SizingPolicyFinal = vm.VM.ComputePolicy.VmSizingPolicyFinal != nil && *vm.VM.ComputePolicy.VmSizingPolicyFinal
+		policies.PlacementPolicyFinal = vm.VM.ComputePolicy.VmPlacementPolicyFinal != nil && *vm.VM.ComputePolicy.VmPlacementPolicyFinal
+	}
+
+	var err error
+	if sizingPolicyId != "" {
+		policies.SizingPolicy, err = getVdcComputePolicyV2ById(ctx, vm.client, sizingPolicyId)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving sizing policy of VM %s: %w", vm.VM.Name, err)
+		}
+	}
+	if placementPolicyId != "" {
+		policies.PlacementPolicy, err = getVdcComputePolicyV2ById(ctx, vm.client, placementPolicyId)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving placement policy of VM %s: %w", vm.VM.Name, err)
+		}
+	}
+	return policies, nil
+}
+
+// computePolicyIdFromReference returns the ID of the compute policy of a VM compute policy reference, whose HREF
+// ends with the policy URN
+func computePolicyIdFromReference(reference *types.Reference) string {
+	if reference == nil {
+		return ""
+	}
+	if reference.ID != "" {
+		return reference.ID
+	}
+	uuid := extractUuid(reference.HREF)
+	if uuid == "" {
+		return ""
+	}
+	return "urn:vcloud:vdcComputePolicy:" + uuid
+}
+
 // UpdateComputePolicy updates VM compute policy and returns refreshed VM or error.
 // Deprecated: Use VM.UpdateComputePolicyV2 instead
 func (vm *VM) UpdateComputePolicy(ctx context.Context, computePolicy *types.VdcComputePolicy) (*VM, error) {
@@ -1641,7 +1814,7 @@ func (client *Client) QueryVmList(ctx context.Context, filter types.VmQueryFilte
 	}
 	vmResult, err := client.cumulativeQuery(ctx, queryType, nil, params)
 	if err != nil {
-		return nil, fmt.Errorf("error getting VM list : %s", err)
+		return nil, fmt.Errorf("error getting VM list : %w", err)
 	}
 	vmList = vmResult.Results.VMRecord
 	if client.IsSysAdmin {
@@ -1668,19 +1841,10 @@ func queryVmList(ctx context.Context, filter types.VmQueryFilter, client *Client
 		"type":          queryType,
 		"filterEncoded": "true",
 	}
-	filterText := ""
-	if filter.String() != "" {
-		filterText = filter.String()
-	}
-	if filterText == "" {
-		filterText = fmt.Sprintf("%s==%s", filterParent, filterParentHref)
-	} else {
-		filterText = fmt.Sprintf("%s;%s==%s", filterText, filterParent, filterParentHref)
-	}
-	params["filter"] = filterText
+	params["filter"] = vmQueryFilterWithParent(filter, filterParent, filterParentHref)
 	vmResult, err := client.cumulativeQuery(ctx, queryType, nil, params)
 	if err != nil {
-		return nil, fmt.Errorf("error getting VM list : %s", err)
+		return nil, fmt.Errorf("error getting VM list : %w", err)
 	}
 	vmList = vmResult.Results.VMRecord
 	if client.IsSysAdmin {
@@ -1792,7 +1956,7 @@ func addEmptyVmV10(ctx context.Context, vapp *VApp, reComposeVAppParams *types.R
 
 	err = vapp.Refresh(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error refreshing vApp: %s", err)
+		return nil, fmt.Errorf("error refreshing vApp: %w", err)
 	}
 
 	//vApp Might Not Have Any VMs
@@ -1897,6 +2061,304 @@ func (vm *VM) UpdateStorageProfileAsync(ctx context.Context, storageProfileHref
 		})
 }
 
+// ConsolidateDisksAsync starts the consolidation of VM disks, merging the delta disks left behind by snapshots
+// into their base disks, and returns a Task.
+func (vm *VM) ConsolidateDisksAsync(ctx context.Context) (Task, error) {
+	if vm.VM.HREF == "" {
+		return Task{}, fmt.Errorf("cannot consolidate VM disks, VM HREF is unset")
+	}
+
+	apiEndpoint := urlParseRequestURI(vm.VM.HREF)
+	apiEndpoint.Path += "/action/consolidate"
+
+	return vm.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost,
+		"", "error consolidating VM disks: %s", nil)
+}
+
+// ConsolidateDisks consolidates VM disks and waits for the task to complete.
+func (vm *VM) ConsolidateDisks(ctx context.Context) error {
+	task, err := vm.ConsolidateDisksAsync(ctx)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
+
+// RelocateAsync starts the relocation of the VM to the datastore identified by datastoreHref and returns a Task.
+// Only System Administrator can perform this operation. To move a VM within the storage profiles available to a
+// tenant, use UpdateStorageProfileAsync, which also triggers storage relocation when needed.
+func (vm *VM) RelocateAsync(ctx context.Context, datastoreHref string) (Task, error) {
+	if vm.VM.HREF == "" {
+		return Task{}, fmt.Errorf("cannot relocate VM, VM HREF is unset")
+	}
+	if datastoreHref == "" {
+		return Task{}, fmt.Errorf("cannot relocate VM, datastore HREF is unset")
+	}
+
+	apiEndpoint := urlParseRequestURI(vm.VM.HREF)
+	apiEndpoint.Path += "/action/relocate"
+
+	return vm.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost,
+		types.MimeRelocateVmParams, "error relocating VM: %s", &types.RelocateParams{
+			Xmlns:     types.XMLNamespaceVCloud,
+			Datastore: &types.Reference{HREF: datastoreHref},
+		})
+}
+
+// Relocate relocates the VM to the datastore identified by datastoreHref and waits for the task to complete.
+// Only System Administrator can perform this operation.
+func (vm *VM) Relocate(ctx context.Context, datastoreHref string) error {
+	task, err := vm.RelocateAsync(ctx, datastoreHref)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return err
+	}
+	return vm.Refresh(ctx)
+}
+
+// GetBootOptions retrieves the boot options of the VM. Available since API 37.1 (VCD 10.4.1)
+func (vm *VM) GetBootOptions(ctx context.Context) (*types.BootOptions, error) {
+	err := vm.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing VM: %w", err)
+	}
+	if vm.VM.BootOptions == nil {
+		return nil, fmt.Errorf("boot options not found for VM %s", vm.VM.Name)
+	}
+	return vm.VM.BootOptions, nil
+}
+
+// UpdateBootOptions updates the boot options of the VM and returns the updated ones.
+// Available since API 37.1 (VCD 10.4.1)
+func (vm *VM) UpdateBootOptions(ctx context.Context, bootOptions *types.BootOptions) (*types.BootOptions, error) {
+	task, err := vm.UpdateBootOptionsAsync(ctx, bootOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return vm.GetBootOptions(ctx)
+}
+
+// UpdateBootOptionsAsync updates the boot options of the VM and returns Task and error.
+// Available since API 37.1 (VCD 10.4.1)
+func (vm *VM) UpdateBootOptionsAsync(ctx context.Context, bootOptions *types.BootOptions) (Task, error) {
+	if vm.VM.HREF == "" {
+		return Task{}, fmt.Errorf("cannot update VM boot options, VM HREF is unset")
+	}
+	if bootOptions == nil {
+		return Task{}, fmt.Errorf("cannot update VM boot options, boot options are nil")
+	}
+
+	// Sections not included in the request body will not be updated by `reconfigureVm`
+	return vm.client.ExecuteTaskRequest(ctx, vm.VM.HREF+"/action/reconfigureVm", http.MethodPost,
+		types.MimeVM, "error updating VM boot options: %s", &types.Vm{
+			Xmlns:       types.XMLNamespaceVCloud,
+			Ovf:         types.XMLNamespaceOVF,
+			Name:        vm.VM.Name,
+			Description: vm.VM.Description,
+			BootOptions: bootOptions,
+		})
+}
+
+// SetEnterBiosSetupOnNextBoot makes the VM enter the BIOS (or EFI) setup on its next boot. VCD resets the
+// setting after that boot, so it only applies once.
+func (vm *VM) SetEnterBiosSetupOnNextBoot(ctx context.Context, enterBiosSetup bool) error {
+	bootOptions, err := vm.GetBootOptions(ctx)
+	if err != nil {
+		return err
+	}
+	bootOptions.EnterBiosSetup = &enterBiosSetup
+
+	_, err = vm.UpdateBootOptions(ctx, bootOptions)
+	if err != nil {
+		return fmt.Errorf("error setting BIOS setup on next boot for VM %s: %w", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// PowerCycleWithBootOptions powers off the VM (if it is deployed), applies temporaryBootOptions, powers the
+// VM on and then restores the boot options the VM had before the call. The original boot options are restored
+// also when the power on fails.
+func (vm *VM) PowerCycleWithBootOptions(ctx context.Context, temporaryBootOptions *types.BootOptions) error {
+	return vm.powerCycleWithBootOptions(ctx, temporaryBootOptions, nil)
+}
+
+// powerCycleWithBootOptions is PowerCycleWithBootOptions, calling afterPowerOn (when not nil) once the VM is
+// powered on and before the original boot options are restored
+func (vm *VM) powerCycleWithBootOptions(ctx context.Context, temporaryBootOptions *types.BootOptions, afterPowerOn func() error) error {
+	if temporaryBootOptions == nil {
+		return fmt.Errorf("cannot power cycle VM %s, temporary boot options are nil", vm.VM.Name)
+	}
+
+	originalBootOptions, err := vm.GetBootOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = vm.undeployIfDeployed(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = vm.UpdateBootOptions(ctx, temporaryBootOptions)
+	if err != nil {
+		return fmt.Errorf("error applying temporary boot options to VM %s: %w", vm.VM.Name, err)
+	}
+
+	task, powerOnErr := vm.PowerOn(ctx)
+	if powerOnErr == nil {
+		powerOnErr = task.WaitTaskCompletion(ctx)
+	}
+	if powerOnErr == nil && afterPowerOn != nil {
+		powerOnErr = afterPowerOn()
+	}
+
+	_, err = vm.UpdateBootOptions(ctx, originalBootOptions)
+	if powerOnErr != nil {
+		if err != nil {
+			return fmt.Errorf("error powering on VM %s: %s (restoring boot options also failed: %w)", vm.VM.Name, powerOnErr, err)
+		}
+		return fmt.Errorf("error powering on VM %s: %w", vm.VM.Name, powerOnErr)
+	}
+	if err != nil {
+		return fmt.Errorf("error restoring boot options of VM %s: %w", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// bootOrderKey is the extra configuration entry holding the firmware boot order of a VM, such as "ethernet0,hdd"
+const bootOrderKey = "bios.bootOrder"
+
+// pxeBootOrderReadDelay is how long RebootIntoPxe waits, after the power on and the boot delay of the VM, for the
+// firmware to read the boot order before restoring it
+var pxeBootOrderReadDelay = 15 * time.Second
+
+// RebootIntoPxe power cycles the VM so that it boots from the network (PXE) using the given protocol ("IPv4" or
+// "IPv6"), then restores the previous boot order and boot options:
+//  1. The NIC of the primary network connection is moved first in the firmware boot order (the bios.bootOrder
+//     extra configuration entry), followed by the previous boot order or by the disks when it was not set
+//  2. The network boot protocol is set and boot retry is disabled, so that the firmware does not retry the disks
+//  3. The VM is powered off (if it is deployed) and on
+//  4. Once the VM is powered on and the firmware has read the boot order (after the boot delay of the VM and
+//     pxeBootOrderReadDelay), the previous boot options and boot order are restored
+//
+// Setting the boot order usually requires System administrator privileges, like other extra configuration entries.
+// Available since API 37.1 (VCD 10.4.1)
+func (vm *VM) RebootIntoPxe(ctx context.Context, protocol string) error {
+	if protocol != "IPv4" && protocol != "IPv6" {
+		return fmt.Errorf("invalid network boot protocol '%s': must be 'IPv4' or 'IPv6'", protocol)
+	}
+
+	bootOptions, err := vm.GetBootOptions(ctx)
+	if err != nil {
+		return err
+	}
+	networkConnectionSection, err := vm.GetNetworkConnectionSection(ctx)
+	if err != nil {
+		return err
+	}
+	if len(networkConnectionSection.NetworkConnection) == 0 {
+		return fmt.Errorf("VM %s has no NIC to boot from the network", vm.VM.Name)
+	}
+	originalBootOrder, err := vm.getExtraConfigValue(ctx, bootOrderKey)
+	if err != nil {
+		return fmt.Errorf("error retrieving boot order of VM %s: %w", vm.VM.Name, err)
+	}
+
+	networkDevice := fmt.Sprintf("ethernet%d", networkConnectionSection.PrimaryNetworkConnectionIndex)
+	err = vm.setExtraConfigValue(ctx, bootOrderKey, networkFirstBootOrder(originalBootOrder, networkDevice))
+	if err != nil {
+		return fmt.Errorf("error setting network boot order of VM %s: %w", vm.VM.Name, err)
+	}
+
+	temporaryBootOptions := *bootOptions
+	temporaryBootOptions.NetworkBootProtocol = protocol
+	temporaryBootOptions.BootRetryEnabled = takeBoolPointer(false)
+	temporaryBootOptions.BootRetryDelay = nil
+
+	waitForBootOrderRead := func() error {
+		bootDelay := time.Duration(0)
+		if bootOptions.BootDelay != nil {
+			bootDelay = time.Duration(*bootOptions.BootDelay) * time.Millisecond
+		}
+		return WaitForState(ctx, func() (bool, error) {
+			status, err := vm.GetStatus(ctx)
+			if err != nil {
+				return false, err
+			}
+			return status == "POWERED_ON", nil
+		}, WaitOptions{InitialDelay: bootDelay + pxeBootOrderReadDelay, Timeout: 5 * time.Minute})
+	}
+	powerCycleErr := vm.powerCycleWithBootOptions(ctx, &temporaryBootOptions, waitForBootOrderRead)
+
+	err = vm.setExtraConfigValue(ctx, bootOrderKey, originalBootOrder)
+	if powerCycleErr != nil {
+		if err != nil {
+			return fmt.Errorf("%s (restoring boot order also failed: %w)", powerCycleErr, err)
+		}
+		return powerCycleErr
+	}
+	if err != nil {
+		return fmt.Errorf("error restoring boot order of VM %s: %w", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// networkFirstBootOrder returns the boot order bootOrder with networkDevice moved first. An empty boot order
+// becomes networkDevice followed by the disks
+func networkFirstBootOrder(bootOrder, networkDevice string) string {
+	devices := []string{networkDevice}
+	for _, device := range strings.Split(bootOrder, ",") {
+		device = strings.TrimSpace(device)
+		if device != "" && device != networkDevice {
+			devices = append(devices, device)
+		}
+	}
+	if len(devices) == 1 {
+		devices = append(devices, "hdd")
+	}
+	return strings.Join(devices, ",")
+}
+
+// RerunGuestCustomization powers off the VM (if it is deployed) and powers it on again forcing the guest
+// customization to run, as "Power On and Force Recustomization" does in the UI.
+func (vm *VM) RerunGuestCustomization(ctx context.Context) error {
+	err := vm.undeployIfDeployed(ctx)
+	if err != nil {
+		return err
+	}
+	return vm.PowerOnAndForceCustomization(ctx)
+}
+
+// undeployIfDeployed undeploys and powers off the VM if it is deployed
+func (vm *VM) undeployIfDeployed(ctx context.Context) error {
+	vmIsDeployed, err := vm.IsDeployed(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to check if VM %s is deployed: %w", vm.VM.Name, err)
+	}
+	if !vmIsDeployed {
+		return nil
+	}
+
+	task, err := vm.Undeploy(ctx)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return fmt.Errorf("error undeploying VM %s: %w", vm.VM.Name, err)
+	}
+	return nil
+}
+
 // DeleteAsync starts a standalone VM deletion, returning a task
 func (vm *VM) DeleteAsync(ctx context.Context) (Task, error) {
 	if vm.VM.HREF == "" {
@@ -1942,7 +2404,7 @@ func (vm *VM) ChangeMemory(ctx context.Context, sizeInMb int64) error {
 
 	_, err := vm.UpdateVmSpecSection(ctx, vmSpecSection, description)
 	if err != nil {
-		return fmt.Errorf("error changing memory size: %s", err)
+		return fmt.Errorf("error changing memory size: %w", err)
 	}
 	return nil
 }
@@ -1964,7 +2426,7 @@ func (vm *VM) ChangeCPU(ctx context.Context, cpus, cpuCores int) error {
 
 	_, err := vm.UpdateVmSpecSection(ctx, vmSpecSection, description)
 	if err != nil {
-		return fmt.Errorf("error changing cpu size: %s", err)
+		return fmt.Errorf("error changing cpu size: %w", err)
 	}
 	return nil
 }
@@ -1983,7 +2445,7 @@ func (vm *VM) ChangeCPUAndCoreCount(ctx context.Context, cpus, cpuCores *int) er
 
 	_, err := vm.UpdateVmSpecSection(ctx, vmSpecSection, description)
 	if err != nil {
-		return fmt.Errorf("error changing CPU size: %s", err)
+		return fmt.Errorf("error changing CPU size: %w", err)
 	}
 	return nil
 }
@@ -183,7 +183,7 @@ func validateAffinityRule(ctx context.Context, client *Client, affinityRuleDef *
 	if checkVMs {
 		vmList, err := client.QueryVmList(ctx, types.VmQueryFilterOnlyDeployed)
 		if err != nil {
-			return nil, fmt.Errorf("error getting VM list : %s", err)
+			return nil, fmt.Errorf("error getting VM list : %w", err)
 		}
 		for _, vm := range vmList {
 			allVmMap[extractUuid(vm.HREF)] = true
@@ -235,7 +235,7 @@ func (vdc *Vdc) CreateVmAffinityRuleAsync(ctx context.Context, affinityRuleDef *
 	// We validate the input, without a strict check on the VMs
 	affinityRuleDef, err = validateAffinityRule(ctx, vdc.client, affinityRuleDef, false)
 	if err != nil {
-		return Task{}, fmt.Errorf("[CreateVmAffinityRuleAsync] %s", err)
+		return Task{}, fmt.Errorf("[CreateVmAffinityRuleAsync] %w", err)
 	}
 
 	affinityRuleDef.Xmlns = types.XMLNamespaceVCloud
@@ -280,7 +280,7 @@ func (vdc *Vdc) CreateVmAffinityRule(ctx context.Context, affinityRuleDef *types
 	// Retrieving the newly created rule using the ID from the task
 	vmAffinityRule, err := vdc.GetVmAffinityRuleById(ctx, ruleId)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving VmAffinityRule %s using ID %s: %s", affinityRuleDef.Name, ruleId, err)
+		return nil, fmt.Errorf("error retrieving VmAffinityRule %s using ID %s: %w", affinityRuleDef.Name, ruleId, err)
 	}
 	return vmAffinityRule, nil
 }
@@ -338,7 +338,7 @@ func (vmar *VmAffinityRule) Update(ctx context.Context) error {
 	// We validate the input, without a strict check on the VMs
 	affinityRuleDef, err = validateAffinityRule(ctx, vmar.client, vmar.VmAffinityRule, false)
 	if err != nil {
-		return fmt.Errorf("[Update] %s", err)
+		return fmt.Errorf("[Update] %w", err)
 	}
 	vmar.VmAffinityRule = affinityRuleDef
 
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// defaultScreenTicketPort is the port of the MKS service of ESXi hosts, used when the screen ticket doesn't specify one
+const defaultScreenTicketPort = 902
+
+// VmConsoleTicket is a screen ticket of a VM, split in the parts needed to connect to its console
+type VmConsoleTicket struct {
+	Host    string // Host running the VM
+	Port    int    // Port of the MKS service of the host
+	VmMoref string // Managed Object Reference of the VM (e.g. vm-1234)
+	Ticket  string // Ticket to authenticate the connection, URL decoded
+	Url     string // Ticket as returned by VCD, in the form mks://host/vm-moref/ticket
+}
+
+// GetVMConsoleTicket acquires a screen ticket to access the console of the VM. The VM must be powered on.
+// The ticket is valid for a short time and for a single connection, so it must be acquired right before connecting.
+func (vm *VM) GetVMConsoleTicket(ctx context.Context) (*VmConsoleTicket, error) {
+	if vm.VM.HREF == "" {
+		return nil, fmt.Errorf("cannot acquire a screen ticket, VM HREF is empty")
+	}
+
+	screenTicket := &types.ScreenTicket{}
+	_, err := vm.client.ExecuteRequest(ctx, vm.VM.HREF+"/screen/action/acquireTicket", http.MethodPost,
+		"", "error acquiring screen ticket: %s", nil, screenTicket)
+	if err != nil {
+		return nil, err
+	}
+	return parseScreenTicket(screenTicket.Value)
+}
+
+// GetMksTicket acquires an MKS ticket to access the console of the VM through the VMware Remote Console or WebMKS,
+// which is what console proxies are built on. The VM must be powered on.
+// The ticket is valid for a short time and for a single connection, so it must be acquired right before connecting.
+func (vm *VM) GetMksTicket(ctx context.Context) (*types.MksTicket, error) {
+	if vm.VM.HREF == "" {
+		return nil, fmt.Errorf("cannot acquire an MKS ticket, VM HREF is empty")
+	}
+
+	mksTicket := &types.MksTicket{}
+	_, err := vm.client.ExecuteRequest(ctx, vm.VM.HREF+"/screen/action/acquireMksTicket", http.MethodPost,
+		"", "error acquiring MKS ticket: %s", nil, mksTicket)
+	if err != nil {
+		return nil, err
+	}
+	if mksTicket.Host == "" || mksTicket.Ticket == "" {
+		return nil, fmt.Errorf("MKS ticket of VM '%s' is incomplete", vm.VM.Name)
+	}
+	return mksTicket, nil
+}
+
+// parseScreenTicket splits a screen ticket in the form mks://host[:port]/vm-moref/ticket
+func parseScreenTicket(rawTicket string) (*VmConsoleTicket, error) {
+	rawTicket = strings.TrimSpace(rawTicket)
+	ticketUrl, err := url.Parse(rawTicket)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing screen ticket: %w", err)
+	}
+	if ticketUrl.Scheme != "mks" || ticketUrl.Hostname() == "" {
+		return nil, fmt.Errorf("screen ticket is not in the form mks://host/vm-moref/ticket")
+	}
+
+	// The ticket may contain encoded slashes, so the path is split before decoding
+	parts := strings.SplitN(strings.TrimPrefix(ticketUrl.EscapedPath(), "/"), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("screen ticket is not in the form mks://host/vm-moref/ticket")
+	}
+	ticket, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding screen ticket: %w", err)
+	}
+
+	port := defaultScreenTicketPort
+	if ticketUrl.Port() != "" {
+		port, err = strconv.Atoi(ticketUrl.Port())
+		if err != nil {
+			return nil, fmt.Errorf("error parsing port of screen ticket: %w", err)
+		}
+	}
+
+	return &VmConsoleTicket{
+		Host:    ticketUrl.Hostname(),
+		Port:    port,
+		VmMoref: parts[0],
+		Ticket:  ticket,
+		Url:     rawTicket,
+	}, nil
+}
@@ -0,0 +1,71 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_parseScreenTicket(t *testing.T) {
+	screenTicketXml := `<ScreenTicket xmlns="http://www.vmware.com/vcloud/v1.5">mks://10.0.0.21/vm-1234/cst-A1b%2Fc%3D%3D-vcd</ScreenTicket>`
+	screenTicket := &types.ScreenTicket{}
+	err := xml.Unmarshal([]byte(screenTicketXml), screenTicket)
+	if err != nil {
+		t.Fatalf("error unmarshalling screen ticket: %s", err)
+	}
+
+	ticket, err := parseScreenTicket(screenTicket.Value)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := VmConsoleTicket{
+		Host:    "10.0.0.21",
+		Port:    defaultScreenTicketPort,
+		VmMoref: "vm-1234",
+		Ticket:  "cst-A1b/c==-vcd",
+		Url:     "mks://10.0.0.21/vm-1234/cst-A1b%2Fc%3D%3D-vcd",
+	}
+	if *ticket != expected {
+		t.Errorf("expected %+v, got %+v", expected, *ticket)
+	}
+
+	ticket, err = parseScreenTicket("mks://esx1.example.com:9902/vm-5/ticket")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ticket.Host != "esx1.example.com" || ticket.Port != 9902 || ticket.Ticket != "ticket" {
+		t.Errorf("unexpected ticket with port: %+v", *ticket)
+	}
+
+	for _, invalid := range []string{"", "https://10.0.0.21/vm-1234/ticket", "mks://10.0.0.21/vm-1234", "mks:///vm-1/ticket"} {
+		_, err = parseScreenTicket(invalid)
+		if err == nil {
+			t.Errorf("expected an error parsing screen ticket '%s'", invalid)
+		}
+	}
+}
+
+func Test_MksTicketUnmarshal(t *testing.T) {
+	mksTicketXml := `<MksTicket xmlns="http://www.vmware.com/vcloud/v1.5">
+  <Host>10.0.0.21</Host>
+  <Vmx>/vmfs/volumes/datastore1/vm1/vm1.vmx</Vmx>
+  <Ticket>AbCdEf123</Ticket>
+  <Port>443</Port>
+</MksTicket>`
+	mksTicket := &types.MksTicket{}
+	err := xml.Unmarshal([]byte(mksTicketXml), mksTicket)
+	if err != nil {
+		t.Fatalf("error unmarshalling MKS ticket: %s", err)
+	}
+	if mksTicket.Host != "10.0.0.21" || mksTicket.Port != 443 || mksTicket.Ticket != "AbCdEf123" ||
+		mksTicket.Vmx != "/vmfs/volumes/datastore1/vm1/vm1.vmx" {
+		t.Errorf("unexpected MKS ticket: %+v", *mksTicket)
+	}
+}
@@ -38,11 +38,11 @@ func (vcdClient *VCDClient) GetVmGroupByNamedVmGroupIdAndProviderVdcUrn(ctx cont
 	id := extractUuid(namedVmGroupId)
 	resourcePools, err := getResourcePools(ctx, vcdClient, pvdcUrn)
 	if err != nil {
-		return nil, fmt.Errorf("could not get VM Group with namedVmGroupId=%s: %s", id, err)
+		return nil, fmt.Errorf("could not get VM Group with namedVmGroupId=%s: %w", id, err)
 	}
 	filter, err := buildFilterForVmGroups(resourcePools, "namedVmGroupId", id)
 	if err != nil {
-		return nil, fmt.Errorf("could not get VM Group with namedVmGroupId=%s: %s", id, err)
+		return nil, fmt.Errorf("could not get VM Group with namedVmGroupId=%s: %w", id, err)
 	}
 	return getVmGroupWithFilter(ctx, vcdClient, filter)
 }
@@ -53,11 +53,11 @@ func (vcdClient *VCDClient) GetVmGroupByNamedVmGroupIdAndProviderVdcUrn(ctx cont
 func (vcdClient *VCDClient) GetVmGroupByNameAndProviderVdcUrn(ctx context.Context, name, pvdcUrn string) (*VmGroup, error) {
 	resourcePools, err := getResourcePools(ctx, vcdClient, pvdcUrn)
 	if err != nil {
-		return nil, fmt.Errorf("could not get VM Group with vmGroupName=%s: %s", name, err)
+		return nil, fmt.Errorf("could not get VM Group with vmGroupName=%s: %w", name, err)
 	}
 	filter, err := buildFilterForVmGroups(resourcePools, "vmGroupName", name)
 	if err != nil {
-		return nil, fmt.Errorf("could not get VM Group with vmGroupName=%s: %s", name, err)
+		return nil, fmt.Errorf("could not get VM Group with vmGroupName=%s: %w", name, err)
 	}
 	return getVmGroupWithFilter(ctx, vcdClient, filter)
 }
@@ -116,7 +116,7 @@ func (vcdClient *VCDClient) GetLogicalVmGroupById(ctx context.Context, logicalVm
 
 	err = vcdClient.Client.OpenApiGetItem(ctx, apiVersion, urlRef, nil, result.LogicalVmGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting Logical VM Group: %s", err)
+		return nil, fmt.Errorf("error getting Logical VM Group: %w", err)
 	}
 
 	return result, nil
@@ -143,7 +143,7 @@ func (vcdClient *VCDClient) CreateLogicalVmGroup(ctx context.Context, logicalVmG
 
 	err = vcdClient.Client.OpenApiPostItem(ctx, apiVersion, urlRef, nil, logicalVmGroup, result.LogicalVmGroup, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating the Logical VM Group: %s", err)
+		return nil, fmt.Errorf("error creating the Logical VM Group: %w", err)
 	}
 
 	return result, nil
@@ -169,7 +169,7 @@ func (logicalVmGroup *LogicalVmGroup) Delete(ctx context.Context) error {
 
 	err = logicalVmGroup.client.OpenApiDeleteItem(ctx, apiVersion, urlRef, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting the Logical VM Group: %s", err)
+		return fmt.Errorf("error deleting the Logical VM Group: %w", err)
 	}
 	return nil
 }
@@ -207,7 +207,7 @@ func getResourcePools(ctx context.Context, vcdClient *VCDClient, pvdcUrn string)
 		"filterEncoded": "true",
 	})
 	if err != nil {
-		return nil, fmt.Errorf("could not get the Resource pool: %s", err)
+		return nil, fmt.Errorf("could not get the Resource pool: %w", err)
 	}
 	if len(foundResourcePools.Results.ResourcePoolRecord) == 0 {
 		return nil, ErrorEntityNotFound
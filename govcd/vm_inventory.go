@@ -0,0 +1,122 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// vmInventoryFields are the fields requested by the VM inventory queries. They include the primary network and IP
+// address of the VM, which would otherwise require retrieving each VM
+var vmInventoryFields = []string{"name", "container", "containerName", "vdc", "status", "isDeployed",
+	"isVAppTemplate", "guestOs", "numberOfCpus", "memoryMB", "ipAddress", "networkName", "storageProfileName",
+	"vmSizingPolicyId", "vmPlacementPolicyId"}
+
+// vmInventoryAdminFields are the fields requested by the VM inventory queries in addition to vmInventoryFields
+// when the client is a system administrator
+var vmInventoryAdminFields = []string{"hostName"}
+
+// VmInventoryRecord contains the details of a VM usually needed for reporting, as returned by a single query
+type VmInventoryRecord struct {
+	Name                string
+	Href                string
+	VappName            string // Name of the vApp or vApp template that contains the VM
+	VappHref            string
+	VdcHref             string
+	Status              string // Power state of the VM, as returned by the query (e.g. POWERED_ON, POWERED_OFF)
+	Deployed            bool
+	VAppTemplate        bool // True if the VM is part of a vApp template
+	GuestOS             string
+	Cpus                int
+	MemoryMB            int
+	IpAddress           string // IP address of the VM on the primary network, empty if not configured
+	NetworkName         string // Name of the primary network of the VM
+	StorageProfileName  string
+	VmSizingPolicyId    string
+	VmPlacementPolicyId string
+	HostName            string // ESXi host running the VM. Only available to system administrators
+}
+
+// NewVmInventoryRecord creates a VmInventoryRecord from a VM query record
+func NewVmInventoryRecord(record *types.QueryResultVMRecordType) *VmInventoryRecord {
+	return &VmInventoryRecord{
+		Name:                record.Name,
+		Href:                record.HREF,
+		VappName:            record.ContainerName,
+		VappHref:            record.ContainerID,
+		VdcHref:             record.VdcHREF,
+		Status:              record.Status,
+		Deployed:            record.Deployed,
+		VAppTemplate:        record.VAppTemplate,
+		GuestOS:             record.GuestOS,
+		Cpus:                record.Cpus,
+		MemoryMB:            record.MemoryMB,
+		IpAddress:           record.IpAddress,
+		NetworkName:         record.NetworkName,
+		StorageProfileName:  record.StorageProfileName,
+		VmSizingPolicyId:    record.VmSizingPolicyId,
+		VmPlacementPolicyId: record.VmPlacementPolicyId,
+		HostName:            record.HostName,
+	}
+}
+
+// QueryVmInventory returns the inventory records of all VMs in all the organizations available to the caller
+func (client *Client) QueryVmInventory(ctx context.Context, filter types.VmQueryFilter) ([]*VmInventoryRecord, error) {
+	return queryVmInventory(ctx, client, filter.String())
+}
+
+// QueryVmInventory returns the inventory records of all VMs in a given Org
+func (org *Org) QueryVmInventory(ctx context.Context, filter types.VmQueryFilter) ([]*VmInventoryRecord, error) {
+	return queryVmInventory(ctx, org.client, vmQueryFilterWithParent(filter, "org", org.Org.HREF))
+}
+
+// QueryVmInventory returns the inventory records of all VMs in a given VDC
+func (vdc *Vdc) QueryVmInventory(ctx context.Context, filter types.VmQueryFilter) ([]*VmInventoryRecord, error) {
+	return queryVmInventory(ctx, vdc.client, vmQueryFilterWithParent(filter, "vdc", vdc.Vdc.HREF))
+}
+
+// queryVmInventory runs a VM query requesting the inventory fields, and converts the results into VmInventoryRecord
+func queryVmInventory(ctx context.Context, client *Client, filterText string) ([]*VmInventoryRecord, error) {
+	queryType := client.GetQueryType(types.QtVm)
+	fields := vmInventoryFields
+	if client.IsSysAdmin {
+		fields = append(append([]string{}, vmInventoryFields...), vmInventoryAdminFields...)
+	}
+	params := map[string]string{
+		"type":          queryType,
+		"filterEncoded": "true",
+		"fields":        strings.Join(fields, ","),
+	}
+	if filterText != "" {
+		params["filter"] = filterText
+	}
+	vmResult, err := client.cumulativeQuery(ctx, queryType, nil, params)
+	if err != nil {
+		return nil, fmt.Errorf("error getting VM inventory: %w", err)
+	}
+	vmList := vmResult.Results.VMRecord
+	if client.IsSysAdmin {
+		vmList = vmResult.Results.AdminVMRecord
+	}
+
+	inventory := make([]*VmInventoryRecord, len(vmList))
+	for index, record := range vmList {
+		inventory[index] = NewVmInventoryRecord(record)
+	}
+	return inventory, nil
+}
+
+// vmQueryFilterWithParent returns the text of filter restricted to the VMs of the given parent
+func vmQueryFilterWithParent(filter types.VmQueryFilter, filterParent, filterParentHref string) string {
+	parentFilter := fmt.Sprintf("%s==%s", filterParent, filterParentHref)
+	if filter.String() == "" {
+		return parentFilter
+	}
+	return fmt.Sprintf("%s;%s", filter.String(), parentFilter)
+}
@@ -0,0 +1,69 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_queryVmInventory(t *testing.T) {
+	var requestedQuery string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The filter is not encoded and contains ';', which url.Query() would discard
+		requestedQuery = r.URL.RawQuery
+		recordName := "VMRecord"
+		if strings.Contains(requestedQuery, "type="+types.QtAdminVm) {
+			recordName = "AdminVMRecord"
+		}
+		w.Header().Set("Content-Type", types.MimeQueryRecords)
+		_, _ = fmt.Fprintf(w, `<QueryResultRecords xmlns="http://www.vmware.com/vcloud/v1.5" total="1" pageSize="25" page="1">
+	<%s name="vm1" href="https://%s/api/vApp/vm-1" containerName="vapp1" container="https://%s/api/vApp/vapp-1"
+		status="POWERED_ON" isDeployed="true" numberOfCpus="2" memoryMB="1024" ipAddress="10.0.0.10"
+		networkName="net1" storageProfileName="*" hostName="esxi-1"/>
+</QueryResultRecords>`, recordName, r.Host, r.Host)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &NewVCDClient(*serverUrl, true).Client
+
+	for _, isSysAdmin := range []bool{false, true} {
+		client.IsSysAdmin = isSysAdmin
+		inventory, err := queryVmInventory(context.Background(), client, vmQueryFilterWithParent(types.VmQueryFilterOnlyDeployed, "vdc", "https://vdc"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(inventory) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(inventory))
+		}
+		record := inventory[0]
+		if record.Name != "vm1" || record.VappName != "vapp1" || record.IpAddress != "10.0.0.10" ||
+			record.NetworkName != "net1" || record.StorageProfileName != "*" || record.Cpus != 2 || !record.Deployed {
+			t.Errorf("unexpected record: %+v", record)
+		}
+		if !strings.Contains(requestedQuery, "ipAddress") || !strings.Contains(requestedQuery, "networkName") {
+			t.Errorf("expected network fields to be requested, got '%s'", requestedQuery)
+		}
+		if strings.Contains(requestedQuery, "hostName") != isSysAdmin {
+			t.Errorf("unexpected hostName field for system administrator %t: '%s'", isSysAdmin, requestedQuery)
+		}
+		if !strings.Contains(requestedQuery, "filter=isVAppTemplate==false;vdc==https://vdc") {
+			t.Errorf("unexpected filter in '%s'", requestedQuery)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Latency sensitivity levels of a VM, as accepted by VM.SetLatencySensitivity
+const (
+	LatencySensitivityNormal = "normal"
+	LatencySensitivityHigh   = "high"
+)
+
+// latencySensitivityKey is the vSphere advanced setting holding the latency sensitivity of a VM
+const latencySensitivityKey = "sched.cpu.latencySensitivity"
+
+// Shares levels of the CPU and memory of a VM
+const (
+	SharesLevelLow    = "LOW"
+	SharesLevelNormal = "NORMAL"
+	SharesLevelHigh   = "HIGH"
+	SharesLevelCustom = "CUSTOM"
+)
+
+// VmResourceSettings are the CPU and memory allocation settings of a VM, used by VM.UpdateResourceSettings.
+// Nil and empty fields are left unchanged. Limits can be set to -1 for unlimited resources
+type VmResourceSettings struct {
+	CpuReservationMhz *int64
+	CpuLimitMhz       *int64
+	CpuSharesLevel    string // One of SharesLevelLow, SharesLevelNormal, SharesLevelHigh, SharesLevelCustom
+	CpuShares         *int   // Only accepted with the CUSTOM shares level
+
+	MemoryReservationMb *int64
+	MemoryLimitMb       *int64
+	MemorySharesLevel   string // One of SharesLevelLow, SharesLevelNormal, SharesLevelHigh, SharesLevelCustom
+	MemoryShares        *int   // Only accepted with the CUSTOM shares level
+}
+
+// UpdateResourceSettings updates the CPU and memory reservation, limit and shares of the VM, and returns the
+// refreshed VM. The settings are validated against the configured CPU and memory of the VM before the update
+func (vm *VM) UpdateResourceSettings(ctx context.Context, settings *VmResourceSettings) (*VM, error) {
+	if settings == nil {
+		return nil, fmt.Errorf("resource settings are empty")
+	}
+	if vm.VM.VmSpecSection == nil || vm.VM.VmSpecSection.MemoryResourceMb == nil {
+		return nil, fmt.Errorf("VM '%s' has no VM spec section", vm.VM.Name)
+	}
+
+	vmSpecSection := vm.VM.VmSpecSection
+	// update treats same values as changes and fails, with no values provided - no changes are made for that section
+	vmSpecSection.DiskSection = nil
+	if vmSpecSection.CpuResourceMhz == nil {
+		vmSpecSection.CpuResourceMhz = &types.CpuResourceMhz{}
+	}
+
+	cpu := vmSpecSection.CpuResourceMhz
+	err := applyResourceSettings("CPU", &cpu.Reservation, &cpu.Limit, &cpu.SharesLevel, &cpu.Shares,
+		cpu.Configured, settings.CpuReservationMhz, settings.CpuLimitMhz, settings.CpuSharesLevel, settings.CpuShares)
+	if err != nil {
+		return nil, err
+	}
+	memory := vmSpecSection.MemoryResourceMb
+	err = applyResourceSettings("memory", &memory.Reservation, &memory.Limit, &memory.SharesLevel, &memory.Shares,
+		memory.Configured, settings.MemoryReservationMb, settings.MemoryLimitMb, settings.MemorySharesLevel,
+		settings.MemoryShares)
+	if err != nil {
+		return nil, err
+	}
+
+	return vm.UpdateVmSpecSection(ctx, vmSpecSection, vm.VM.Description)
+}
+
+// GetLatencySensitivity returns the latency sensitivity of the VM, which is LatencySensitivityNormal unless set
+// otherwise. The extra configuration of the VM must be visible to the user, which usually requires System
+// administrator privileges
+func (vm *VM) GetLatencySensitivity(ctx context.Context) (string, error) {
+	value, err := vm.getExtraConfigValue(ctx, latencySensitivityKey)
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return LatencySensitivityNormal, nil
+	}
+	return strings.ToLower(value), nil
+}
+
+// SetLatencySensitivity sets the latency sensitivity of the VM, which is stored in its extra configuration as
+// sched.cpu.latencySensitivity. With LatencySensitivityHigh, vSphere requires all the memory of the VM to be
+// reserved (see UpdateResourceSettings), so that is checked beforehand. A reservation of all the CPU is also
+// recommended. Setting the extra configuration usually requires System administrator privileges
+func (vm *VM) SetLatencySensitivity(ctx context.Context, level string) error {
+	if vm.VM.HREF == "" {
+		return fmt.Errorf("cannot set latency sensitivity, VM HREF is unset")
+	}
+	level = strings.ToLower(level)
+	if level != LatencySensitivityNormal && level != LatencySensitivityHigh {
+		return fmt.Errorf("invalid latency sensitivity '%s': must be one of %s, %s", level,
+			LatencySensitivityNormal, LatencySensitivityHigh)
+	}
+	if level == LatencySensitivityHigh {
+		memory := vm.VM.VmSpecSection.MemoryResourceMb
+		if memory == nil || memory.Reservation == nil || *memory.Reservation < memory.Configured {
+			return fmt.Errorf("latency sensitivity '%s' requires a memory reservation of all the memory of VM '%s'",
+				level, vm.VM.Name)
+		}
+	}
+
+	err := vm.setExtraConfigValue(ctx, latencySensitivityKey, level)
+	if err != nil {
+		return fmt.Errorf("error setting latency sensitivity: %w", err)
+	}
+	return vm.Refresh(ctx)
+}
+
+// getExtraConfigValue returns the value of the extra configuration entry of the VM with the given key, or an empty
+// string when the entry is not set
+func (vm *VM) getExtraConfigValue(ctx context.Context, key string) (string, error) {
+	virtualHardwareSection, err := vm.GetVirtualHardwareSection(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, extraConfig := range virtualHardwareSection.ExtraConfig {
+		if extraConfig.Key == key {
+			return extraConfig.Value, nil
+		}
+	}
+	return "", nil
+}
+
+// setExtraConfigValue sets the extra configuration entry of the VM with the given key and waits for the task to
+// complete. vSphere removes the entry when the value is empty
+func (vm *VM) setExtraConfigValue(ctx context.Context, key, value string) error {
+	task, err := vm.client.ExecuteTaskRequest(ctx, vm.VM.HREF+"/action/reconfigureVm", http.MethodPost,
+		types.MimeVM, "error setting extra configuration: %s", &types.VmExtraConfigUpdate{
+			Xmlns: types.XMLNamespaceVCloud,
+			Ovf:   types.XMLNamespaceOVF,
+			Vmw:   types.XMLNamespaceVMW,
+			Name:  vm.VM.Name,
+			VirtualHardwareSection: &types.VirtualHardwareSectionExtraConfig{
+				Info: "Virtual hardware requirements",
+				ExtraConfig: []*types.ExtraConfigUpdate{
+					{Key: key, Value: value},
+				},
+			},
+		})
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
+
+// applyResourceSettings validates the wanted reservation, limit and shares of a resource of a VM and sets them in
+// the fields of its VM spec section. Unset values are left unchanged
+func applyResourceSettings(resource string, reservation, limit **int64, sharesLevel *string, shares **int,
+	configured int64, wantedReservation, wantedLimit *int64, wantedSharesLevel string, wantedShares *int) error {
+
+	newReservation := *reservation
+	if wantedReservation != nil {
+		if *wantedReservation < 0 {
+			return fmt.Errorf("%s reservation can't be negative: %d", resource, *wantedReservation)
+		}
+		if configured > 0 && *wantedReservation > configured {
+			return fmt.Errorf("%s reservation %d is greater than the configured %s %d", resource,
+				*wantedReservation, resource, configured)
+		}
+		newReservation = wantedReservation
+	}
+
+	newLimit := *limit
+	if wantedLimit != nil {
+		if *wantedLimit != -1 && *wantedLimit <= 0 {
+			return fmt.Errorf("%s limit must be positive or -1 (unlimited): %d", resource, *wantedLimit)
+		}
+		newLimit = wantedLimit
+	}
+	if newLimit != nil && *newLimit != -1 && newReservation != nil && *newLimit < *newReservation {
+		return fmt.Errorf("%s limit %d is lower than the reservation %d", resource, *newLimit, *newReservation)
+	}
+
+	newSharesLevel := *sharesLevel
+	if wantedSharesLevel != "" {
+		newSharesLevel = strings.ToUpper(wantedSharesLevel)
+		switch newSharesLevel {
+		case SharesLevelLow, SharesLevelNormal, SharesLevelHigh, SharesLevelCustom:
+		default:
+			return fmt.Errorf("invalid %s shares level '%s': must be one of %s, %s, %s, %s", resource,
+				wantedSharesLevel, SharesLevelLow, SharesLevelNormal, SharesLevelHigh, SharesLevelCustom)
+		}
+	}
+	newShares := *shares
+	if wantedShares != nil {
+		if newSharesLevel != SharesLevelCustom {
+			return fmt.Errorf("%s shares can only be set with the %s shares level", resource, SharesLevelCustom)
+		}
+		if *wantedShares <= 0 {
+			return fmt.Errorf("%s shares must be positive: %d", resource, *wantedShares)
+		}
+		newShares = wantedShares
+	}
+	if newSharesLevel != SharesLevelCustom && newSharesLevel != "" {
+		// The shares of predefined levels are computed by vSphere and read-only
+		newShares = nil
+	}
+	if newSharesLevel == SharesLevelCustom && newShares == nil {
+		return fmt.Errorf("%s shares are required with the %s shares level", resource, SharesLevelCustom)
+	}
+
+	*reservation = newReservation
+	*limit = newLimit
+	*sharesLevel = newSharesLevel
+	*shares = newShares
+	return nil
+}
@@ -0,0 +1,129 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"encoding/xml"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_applyResourceSettings(t *testing.T) {
+	type testCase struct {
+		name        string
+		reservation *int64
+		limit       *int64
+		sharesLevel string
+		shares      *int
+		wantErr     bool
+	}
+	testCases := []testCase{
+		{name: "unchanged"},
+		{name: "full reservation", reservation: addrOf(int64(4096))},
+		{name: "reservation above configured", reservation: addrOf(int64(4097)), wantErr: true},
+		{name: "negative reservation", reservation: addrOf(int64(-1)), wantErr: true},
+		{name: "unlimited", limit: addrOf(int64(-1))},
+		{name: "limit below reservation", reservation: addrOf(int64(2048)), limit: addrOf(int64(1024)), wantErr: true},
+		{name: "zero limit", limit: addrOf(int64(0)), wantErr: true},
+		{name: "custom shares", sharesLevel: "custom", shares: addrOf(5000)},
+		{name: "custom level keeps the current shares", sharesLevel: SharesLevelCustom},
+		{name: "shares without custom level", sharesLevel: SharesLevelHigh, shares: addrOf(5000), wantErr: true},
+		{name: "invalid shares level", sharesLevel: "HIGHEST", wantErr: true},
+		{name: "invalid shares", sharesLevel: SharesLevelCustom, shares: addrOf(0), wantErr: true},
+	}
+	for _, tc := range testCases {
+		memory := types.MemoryResourceMb{Configured: 4096, Reservation: addrOf(int64(0)), SharesLevel: SharesLevelNormal,
+			Shares: addrOf(40960)}
+		err := applyResourceSettings("memory", &memory.Reservation, &memory.Limit, &memory.SharesLevel, &memory.Shares,
+			memory.Configured, tc.reservation, tc.limit, tc.sharesLevel, tc.shares)
+		if tc.wantErr != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", tc.name, tc.wantErr, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if tc.reservation != nil && *memory.Reservation != *tc.reservation {
+			t.Errorf("%s: expected reservation %d, got %d", tc.name, *tc.reservation, *memory.Reservation)
+		}
+		if tc.shares != nil && (memory.SharesLevel != SharesLevelCustom || *memory.Shares != *tc.shares) {
+			t.Errorf("%s: expected custom shares %d, got %s %v", tc.name, *tc.shares, memory.SharesLevel, memory.Shares)
+		}
+	}
+
+	// Predefined shares levels drop the read-only shares
+	memory := types.MemoryResourceMb{Configured: 4096, SharesLevel: SharesLevelCustom, Shares: addrOf(5000)}
+	err := applyResourceSettings("memory", &memory.Reservation, &memory.Limit, &memory.SharesLevel, &memory.Shares,
+		memory.Configured, nil, nil, SharesLevelHigh, nil)
+	if err != nil || memory.Shares != nil || memory.SharesLevel != SharesLevelHigh {
+		t.Errorf("expected HIGH shares level without shares, got %s %v (%v)", memory.SharesLevel, memory.Shares, err)
+	}
+
+	// The CUSTOM shares level needs shares, when the VM has none
+	err = applyResourceSettings("memory", &memory.Reservation, &memory.Limit, &memory.SharesLevel, &memory.Shares,
+		memory.Configured, nil, nil, SharesLevelCustom, nil)
+	if err == nil {
+		t.Errorf("expected an error for CUSTOM shares level without shares")
+	}
+}
+
+func Test_LatencySensitivityExtraConfig(t *testing.T) {
+	virtualHardwareXml := `<ovf:VirtualHardwareSection xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:vmw="http://www.vmware.com/schema/ovf">
+  <ovf:Info>Virtual hardware requirements</ovf:Info>
+  <vmw:ExtraConfig ovf:required="false" vmw:key="sched.cpu.latencySensitivity" vmw:value="HIGH"/>
+</ovf:VirtualHardwareSection>`
+	virtualHardwareSection := &types.VirtualHardwareSection{}
+	err := xml.Unmarshal([]byte(virtualHardwareXml), virtualHardwareSection)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(virtualHardwareSection.ExtraConfig) != 1 || virtualHardwareSection.ExtraConfig[0].Key != latencySensitivityKey ||
+		virtualHardwareSection.ExtraConfig[0].Value != "HIGH" {
+		t.Errorf("unexpected extra configuration %+v", virtualHardwareSection.ExtraConfig)
+	}
+
+	update := &types.VmExtraConfigUpdate{
+		Xmlns: types.XMLNamespaceVCloud,
+		Ovf:   types.XMLNamespaceOVF,
+		Vmw:   types.XMLNamespaceVMW,
+		Name:  "vm1",
+		VirtualHardwareSection: &types.VirtualHardwareSectionExtraConfig{
+			Info:        "Virtual hardware requirements",
+			ExtraConfig: []*types.ExtraConfigUpdate{{Key: latencySensitivityKey, Value: LatencySensitivityHigh}},
+		},
+	}
+	body, err := xml.Marshal(update)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `<Vm xmlns="http://www.vmware.com/vcloud/v1.5" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" ` +
+		`xmlns:vmw="http://www.vmware.com/schema/ovf" name="vm1"><ovf:VirtualHardwareSection>` +
+		`<ovf:Info>Virtual hardware requirements</ovf:Info>` +
+		`<vmw:ExtraConfig vmw:key="sched.cpu.latencySensitivity" vmw:value="high" ovf:required="false"></vmw:ExtraConfig>` +
+		`</ovf:VirtualHardwareSection></Vm>`
+	if string(body) != expected {
+		t.Errorf("unexpected XML:\n%s\nexpected:\n%s", body, expected)
+	}
+
+	// High latency sensitivity requires a full memory reservation, which is checked before any request
+	vm := &VM{VM: &types.Vm{
+		HREF: "https://vcd.example.com/api/vApp/vm-1",
+		VmSpecSection: &types.VmSpecSection{
+			MemoryResourceMb: &types.MemoryResourceMb{Configured: 4096, Reservation: addrOf(int64(1024))},
+		},
+	}}
+	err = vm.SetLatencySensitivity(context.Background(), LatencySensitivityHigh)
+	if err == nil {
+		t.Errorf("expected an error for high latency sensitivity without full memory reservation")
+	}
+	err = vm.SetLatencySensitivity(context.Background(), "low")
+	if err == nil {
+		t.Errorf("expected an error for an invalid latency sensitivity")
+	}
+}
@@ -901,6 +901,65 @@ func (vcd *TestVCD) Test_PowerOnAndForceCustomization(check *C) {
 	check.Assert(err, IsNil)
 }
 
+func (vcd *TestVCD) Test_VMBootOptions(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	// VM boot options require VCD 10.4.1
+	skipBelowApiVersion(vcd, check, "37.1")
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+
+	originalBootOptions, err := vm.GetBootOptions(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(originalBootOptions, NotNil)
+
+	err = vm.SetEnterBiosSetupOnNextBoot(ctx, true)
+	check.Assert(err, IsNil)
+	bootOptions, err := vm.GetBootOptions(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(bootOptions.EnterBiosSetup, NotNil)
+	check.Assert(*bootOptions.EnterBiosSetup, Equals, true)
+
+	err = vm.SetEnterBiosSetupOnNextBoot(ctx, false)
+	check.Assert(err, IsNil)
+
+	err = vm.RebootIntoPxe(ctx, "IPv5")
+	check.Assert(err, NotNil)
+
+	originalBootOrder, err := vm.getExtraConfigValue(ctx, bootOrderKey)
+	check.Assert(err, IsNil)
+
+	err = vm.RebootIntoPxe(ctx, "IPv4")
+	check.Assert(err, IsNil)
+
+	// The boot options and the boot order are restored after the power cycle
+	bootOptions, err = vm.GetBootOptions(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(bootOptions.NetworkBootProtocol, Equals, originalBootOptions.NetworkBootProtocol)
+	check.Assert(bootOptions.BootRetryEnabled, DeepEquals, originalBootOptions.BootRetryEnabled)
+	check.Assert(bootOptions.BootRetryDelay, DeepEquals, originalBootOptions.BootRetryDelay)
+	bootOrder, err := vm.getExtraConfigValue(ctx, bootOrderKey)
+	check.Assert(err, IsNil)
+	check.Assert(bootOrder, Equals, originalBootOrder)
+
+	err = vm.RerunGuestCustomization(ctx)
+	check.Assert(err, IsNil)
+	vmIsDeployed, err := vm.IsDeployed(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(vmIsDeployed, Equals, true)
+
+	task, err := vm.Undeploy(ctx)
+	check.Assert(err, IsNil)
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+}
+
 func (vcd *TestVCD) Test_BlockWhileGuestCustomizationStatus(check *C) {
 	if vcd.skipVappTests {
 		check.Skip("Skipping test because vApp wasn't properly created")
@@ -1200,6 +1259,58 @@ func (vcd *TestVCD) Test_UpdateInternalDisk(check *C) {
 	check.Assert(err, IsNil)
 }
 
+// Test expansion of an internal disk and creation of a disk on the VM storage profile
+func (vcd *TestVCD) Test_ExpandInternalDisk(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+
+	// In general VM internal disks works with Org users, but due we need change VDC fast provisioning value, we have to be sys admins
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+	ctx := context.Background()
+	vmName := "Test_ExpandInternalDisk"
+	vm, storageProfile, diskSettings, diskId, previousProvisioningValue, err := vcd.createInternalDisk(ctx, check, vmName, 1)
+	check.Assert(err, IsNil)
+
+	disk, err := vm.UpdateInternalDisk(ctx, diskId, InternalDiskUpdate{SizeMb: addrOf(int64(2048))})
+	check.Assert(err, IsNil)
+	check.Assert(disk.DiskId, Equals, diskId)
+	check.Assert(disk.SizeMb, Equals, int64(2048))
+	check.Assert(disk.StorageProfile.HREF, Equals, storageProfile.HREF)
+	check.Assert(disk.UnitNumber, Equals, diskSettings.UnitNumber)
+	check.Assert(disk.BusNumber, Equals, diskSettings.BusNumber)
+
+	_, err = vm.UpdateInternalDisk(ctx, diskId, InternalDiskUpdate{SizeMb: addrOf(int64(1024))})
+	check.Assert(err, NotNil)
+
+	// A disk without storage profile gets the VM one, without overriding it
+	newDisk, err := vm.AddInternalDiskWithStorageProfile(ctx, &types.DiskSettings{
+		SizeMb:      512,
+		UnitNumber:  1,
+		BusNumber:   1,
+		AdapterType: diskSettings.AdapterType,
+	}, nil, nil)
+	check.Assert(err, IsNil)
+	check.Assert(newDisk.DiskId, Not(Equals), "")
+	check.Assert(newDisk.SizeMb, Equals, int64(512))
+	check.Assert(newDisk.OverrideVmDefault, Equals, false)
+	check.Assert(newDisk.StorageProfile, NotNil)
+	check.Assert(newDisk.StorageProfile.HREF, Equals, vm.VM.StorageProfile.HREF)
+
+	//cleanup
+	err = vm.DeleteInternalDisk(ctx, newDisk.DiskId)
+	check.Assert(err, IsNil)
+	err = vm.DeleteInternalDisk(ctx, diskId)
+	check.Assert(err, IsNil)
+
+	// disable fast provisioning if needed
+	updateVdcFastProvisioning(ctx, vcd, check, previousProvisioningValue)
+
+	// delete Vapp early to avoid env capacity issue
+	err = deleteVapp(ctx, vcd, vmName)
+	check.Assert(err, IsNil)
+}
+
 func attachIndependentDisk(ctx context.Context, vcd *TestVCD, check *C) (*Disk, error) {
 	// Find VM
 	vapp := vcd.findFirstVapp(ctx)
@@ -1482,7 +1593,7 @@ func (vcd *TestVCD) Test_QueryVmList(check *C) {
 	ctx := context.Background()
 
 	// Get the setUp vApp using traditional methods
-	vapp, err := vcd.vdc.GetVAppByName(ctx, TestSetUpSuite, true)
+	vapp, err := vcd.vdc.GetVAppByName(ctx, setUpSuiteVappName(), true)
 	check.Assert(err, IsNil)
 	vmName := ""
 	for _, vm := range vapp.VApp.Children.VM {
@@ -1511,6 +1622,40 @@ func (vcd *TestVCD) Test_QueryVmList(check *C) {
 	}
 }
 
+func (vcd *TestVCD) Test_QueryVmInventory(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Test_QueryVmInventory needs an existing vApp to run")
+		return
+	}
+	ctx := context.Background()
+
+	vapp, err := vcd.vdc.GetVAppByName(ctx, setUpSuiteVappName(), true)
+	check.Assert(err, IsNil)
+	if vapp.VApp.Children == nil || len(vapp.VApp.Children.VM) == 0 {
+		check.Skip("No VMs found")
+		return
+	}
+	vm := vapp.VApp.Children.VM[0]
+
+	inventory, err := vcd.vdc.QueryVmInventory(ctx, types.VmQueryFilterOnlyDeployed)
+	check.Assert(err, IsNil)
+	var foundVm *VmInventoryRecord
+	for _, record := range inventory {
+		if record.Href == vm.HREF {
+			foundVm = record
+			break
+		}
+	}
+	check.Assert(foundVm, NotNil)
+	check.Assert(foundVm.Name, Equals, vm.Name)
+	check.Assert(foundVm.VappName, Equals, vapp.VApp.Name)
+	check.Assert(foundVm.VAppTemplate, Equals, false)
+	check.Assert(foundVm.StorageProfileName, Not(Equals), "")
+	if vcd.client.Client.IsSysAdmin {
+		check.Assert(foundVm.HostName, Not(Equals), "")
+	}
+}
+
 // Test update of VM Capabilities
 func (vcd *TestVCD) Test_UpdateVmCpuAndMemoryHotAdd(check *C) {
 	fmt.Printf("Running: %s\n", check.TestName())
@@ -1727,6 +1872,12 @@ func (vcd *TestVCD) Test_VMUpdateStorageProfile(check *C) {
 	check.Assert(updatedVm, NotNil)
 	check.Assert(createdVm.VM.StorageProfile.HREF, Equals, storageProfile2.HREF)
 
+	// Consolidation is only offered by VCD when the VM has a consolidate link
+	if createdVm.VM.Link.ForType("", types.RelConsolidate) != nil {
+		err = createdVm.ConsolidateDisks(ctx)
+		check.Assert(err, IsNil)
+	}
+
 	// Cleanup
 	var task Task
 	err = vapp.RemoveVM(ctx, *createdVm)
@@ -1744,6 +1895,55 @@ func (vcd *TestVCD) Test_VMUpdateStorageProfile(check *C) {
 	check.Assert(task.Task.Status, Equals, "success")
 }
 
+// Test_VMRelocate moves a VM to another datastore of the same vCenter and checks that the datastore of the VM changed
+func (vcd *TestVCD) Test_VMRelocate(check *C) {
+	if !vcd.client.Client.IsSysAdmin {
+		check.Skip("Skipping test because VM relocation requires system administrator privileges")
+	}
+	vappName := check.TestName()
+
+	vapp, err := deployVappForTest(ctx, vcd, vappName)
+	check.Assert(err, IsNil)
+	check.Assert(vapp, NotNil)
+
+	vm, err := vapp.GetVMByName(ctx, "test_vm", true)
+	check.Assert(err, IsNil)
+
+	identifiers, err := vm.GetVimIdentifiers(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(identifiers.DatastoreMoref, Not(Equals), "")
+
+	results, err := vcd.client.Client.QueryWithNotEncodedParams(ctx, nil, map[string]string{
+		"type":     types.QtDatastore,
+		"pageSize": "128",
+	})
+	check.Assert(err, IsNil)
+
+	var target *types.QueryResultDatastoreRecordType
+	for _, datastore := range results.Results.DatastoreRecord {
+		if datastore.IsEnabled && !datastore.IsDeleted && datastore.Moref != identifiers.DatastoreMoref &&
+			equalIds(identifiers.VcenterHref, "", datastore.Vc) {
+			target = datastore
+			break
+		}
+	}
+	if target == nil {
+		err = deleteVapp(ctx, vcd, vappName)
+		check.Assert(err, IsNil)
+		check.Skip("Skipping test because no other datastore is available in the vCenter of the VM")
+	}
+
+	err = vm.Relocate(ctx, target.HREF)
+	check.Assert(err, IsNil)
+
+	identifiers, err = vm.GetVimIdentifiers(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(identifiers.DatastoreMoref, Equals, target.Moref)
+
+	err = deleteVapp(ctx, vcd, vappName)
+	check.Assert(err, IsNil)
+}
+
 func (vcd *TestVCD) Test_VMUpdateComputePolicies(check *C) {
 
 	providerVdc, err := vcd.client.GetProviderVdcByName(ctx, vcd.config.VCD.NsxtProviderVdc.Name)
@@ -1858,11 +2058,19 @@ func (vcd *TestVCD) Test_VMUpdateComputePolicies(check *C) {
 	check.Assert(vm.VM.ComputePolicy.VmSizingPolicy.ID, Equals, sizingPolicies[1].VdcComputePolicyV2.ID)
 	check.Assert(vm.VM.ComputePolicy.VmPlacementPolicy.ID, Equals, placementPolicies[1].VdcComputePolicyV2.ID)
 
+	vmComputePolicies, err := vm.GetComputePolicies(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(vmComputePolicies.SizingPolicy.VdcComputePolicyV2.ID, Equals, sizingPolicies[1].VdcComputePolicyV2.ID)
+	check.Assert(vmComputePolicies.PlacementPolicy.VdcComputePolicyV2.ID, Equals, placementPolicies[1].VdcComputePolicyV2.ID)
+
 	// Remove Placement Policy
 	vm, err = vm.UpdateComputePolicyV2(ctx, sizingPolicies[1].VdcComputePolicyV2.ID, "", "")
 	check.Assert(err, IsNil)
 	check.Assert(vm.VM.ComputePolicy.VmSizingPolicy.ID, Equals, sizingPolicies[1].VdcComputePolicyV2.ID)
 	check.Assert(vm.VM.ComputePolicy.VmPlacementPolicy, IsNil)
+	sizingPolicyId, placementPolicyId := vm.GetComputePolicyIds()
+	check.Assert(sizingPolicyId, Equals, sizingPolicies[1].VdcComputePolicyV2.ID)
+	check.Assert(placementPolicyId, Equals, "")
 
 	// Remove Sizing Policy
 	vm, err = vm.UpdateComputePolicyV2(ctx, "", placementPolicies[1].VdcComputePolicyV2.ID, "")
@@ -2369,3 +2577,105 @@ func (vcd *TestVCD) Test_GetOvfEnvironment(check *C) {
 		check.Assert(task.Task.Status, Equals, "success")
 	}
 }
+
+func (vcd *TestVCD) Test_VMConsoleTickets(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+
+	vmStatus, err := vm.GetStatus(ctx)
+	check.Assert(err, IsNil)
+	if vmStatus != "POWERED_ON" {
+		task, err := vm.PowerOn(ctx)
+		check.Assert(err, IsNil)
+		err = task.WaitTaskCompletion(ctx)
+		check.Assert(err, IsNil)
+		check.Assert(task.Task.Status, Equals, "success")
+	}
+
+	consoleTicket, err := vm.GetVMConsoleTicket(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(consoleTicket.Host, Not(Equals), "")
+	check.Assert(consoleTicket.Port > 0, Equals, true)
+	check.Assert(strings.HasPrefix(consoleTicket.VmMoref, "vm-"), Equals, true)
+	check.Assert(consoleTicket.Ticket, Not(Equals), "")
+
+	mksTicket, err := vm.GetMksTicket(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(mksTicket.Host, Not(Equals), "")
+	check.Assert(mksTicket.Port > 0, Equals, true)
+	check.Assert(mksTicket.Ticket, Not(Equals), "")
+
+	// Leave things as they were
+	if vmStatus == "POWERED_OFF" {
+		task, err := vm.PowerOff(ctx)
+		check.Assert(err, IsNil)
+		err = task.WaitTaskCompletion(ctx)
+		check.Assert(err, IsNil)
+		check.Assert(task.Task.Status, Equals, "success")
+	}
+}
+
+func (vcd *TestVCD) Test_VMResourceSettings(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+
+	memory := vm.VM.VmSpecSection.MemoryResourceMb
+	originalSettings := &VmResourceSettings{
+		MemoryReservationMb: addrOf(int64(0)),
+		MemorySharesLevel:   memory.SharesLevel,
+		MemoryShares:        memory.Shares,
+	}
+	if memory.Reservation != nil {
+		originalSettings.MemoryReservationMb = memory.Reservation
+	}
+	if memory.SharesLevel != SharesLevelCustom {
+		originalSettings.MemoryShares = nil
+	}
+
+	// Invalid settings are rejected before reaching VCD
+	_, err = vm.UpdateResourceSettings(ctx, &VmResourceSettings{MemoryReservationMb: addrOf(memory.Configured + 1)})
+	check.Assert(err, NotNil)
+	_, err = vm.UpdateResourceSettings(ctx, &VmResourceSettings{CpuSharesLevel: SharesLevelHigh, CpuShares: addrOf(2000)})
+	check.Assert(err, NotNil)
+
+	vm, err = vm.UpdateResourceSettings(ctx, &VmResourceSettings{
+		MemoryReservationMb: addrOf(memory.Configured),
+		MemorySharesLevel:   SharesLevelCustom,
+		MemoryShares:        addrOf(20000),
+	})
+	check.Assert(err, IsNil)
+	check.Assert(*vm.VM.VmSpecSection.MemoryResourceMb.Reservation, Equals, memory.Configured)
+	check.Assert(vm.VM.VmSpecSection.MemoryResourceMb.SharesLevel, Equals, SharesLevelCustom)
+	check.Assert(*vm.VM.VmSpecSection.MemoryResourceMb.Shares, Equals, 20000)
+
+	if vcd.client.Client.IsSysAdmin {
+		err = vm.SetLatencySensitivity(ctx, LatencySensitivityHigh)
+		check.Assert(err, IsNil)
+		latencySensitivity, err := vm.GetLatencySensitivity(ctx)
+		check.Assert(err, IsNil)
+		check.Assert(latencySensitivity, Equals, LatencySensitivityHigh)
+
+		err = vm.SetLatencySensitivity(ctx, LatencySensitivityNormal)
+		check.Assert(err, IsNil)
+	}
+
+	// Leave things as they were
+	_, err = vm.UpdateResourceSettings(ctx, originalSettings)
+	check.Assert(err, IsNil)
+}
@@ -372,3 +372,111 @@ func TestProductSectionList_SortByPropertyKeyName(t *testing.T) {
 		})
 	}
 }
+
+func Test_computePolicyIdFromReference(t *testing.T) {
+	policyId := "urn:vcloud:vdcComputePolicy:0a2d5e1c-7a3b-4b1f-9c1d-3c0f6b2a8e11"
+	tests := []struct {
+		name      string
+		reference *types.Reference
+		expected  string
+	}{
+		{"nil", nil, ""},
+		{"id", &types.Reference{ID: policyId}, policyId},
+		{"href", &types.Reference{HREF: "https://vcd.example.com/cloudapi/2.0.0/vdcComputePolicies/" + policyId}, policyId},
+		{"invalid-href", &types.Reference{HREF: "https://vcd.example.com/cloudapi/2.0.0/vdcComputePolicies/"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computePolicyIdFromReference(tt.reference); got != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_applyInternalDiskUpdate(t *testing.T) {
+	vmProfile := &types.Reference{HREF: "https://vcd/api/vdcStorageProfile/11111111-1111-1111-1111-111111111111"}
+	otherProfile := &types.Reference{ID: "urn:vcloud:vdcstorageProfile:22222222-2222-2222-2222-222222222222"}
+	newDisk := func() *types.DiskSettings {
+		return &types.DiskSettings{
+			SizeMb:              1024,
+			StorageProfile:      &types.Reference{HREF: vmProfile.HREF},
+			Iops:                addrOf(int64(0)),
+			VirtualQuantity:     addrOf(int64(1024 * 1024 * 1024)),
+			VirtualQuantityUnit: "byte",
+		}
+	}
+
+	// Growing only changes the size
+	disk := newDisk()
+	changed, err := applyInternalDiskUpdate(disk, InternalDiskUpdate{SizeMb: addrOf(int64(2048))}, vmProfile)
+	if err != nil || !changed {
+		t.Fatalf("expected change without error, got %t, %v", changed, err)
+	}
+	if disk.SizeMb != 2048 || disk.VirtualQuantity != nil || disk.OverrideVmDefault {
+		t.Errorf("unexpected disk after expansion: %#v", disk)
+	}
+
+	// Shrinking is rejected
+	_, err = applyInternalDiskUpdate(newDisk(), InternalDiskUpdate{SizeMb: addrOf(int64(512))}, vmProfile)
+	if err == nil {
+		t.Errorf("expected error when shrinking disk")
+	}
+
+	// Same values are not a change
+	changed, err = applyInternalDiskUpdate(newDisk(), InternalDiskUpdate{SizeMb: addrOf(int64(1024)),
+		StorageProfile: vmProfile, Iops: addrOf(int64(0))}, vmProfile)
+	if err != nil || changed {
+		t.Errorf("expected no change, got %t, %v", changed, err)
+	}
+
+	// A different storage profile overrides the VM one, and going back to the VM one removes the override
+	disk = newDisk()
+	changed, err = applyInternalDiskUpdate(disk, InternalDiskUpdate{StorageProfile: otherProfile, Iops: addrOf(int64(500))}, vmProfile)
+	if err != nil || !changed {
+		t.Fatalf("expected change without error, got %t, %v", changed, err)
+	}
+	if !disk.OverrideVmDefault || disk.StorageProfile != otherProfile || *disk.Iops != 500 {
+		t.Errorf("unexpected disk after storage profile change: %#v", disk)
+	}
+	changed, err = applyInternalDiskUpdate(disk, InternalDiskUpdate{StorageProfile: vmProfile}, vmProfile)
+	if err != nil || !changed || disk.OverrideVmDefault {
+		t.Errorf("expected storage profile override to be removed, got %t, %v, %#v", changed, err, disk)
+	}
+}
+
+func Test_sameReference(t *testing.T) {
+	byHref := &types.Reference{HREF: "https://vcd/api/vdcStorageProfile/11111111-1111-1111-1111-111111111111"}
+	byId := &types.Reference{ID: "urn:vcloud:vdcstorageProfile:11111111-1111-1111-1111-111111111111",
+		HREF: byHref.HREF}
+	other := &types.Reference{ID: "urn:vcloud:vdcstorageProfile:22222222-2222-2222-2222-222222222222"}
+
+	if !sameReference(byHref, byId) {
+		t.Errorf("references with the same HREF should match")
+	}
+	if sameReference(byId, other) {
+		t.Errorf("references with different IDs should not match")
+	}
+	if sameReference(byHref, nil) || sameReference(&types.Reference{}, &types.Reference{}) {
+		t.Errorf("empty references should not match")
+	}
+}
+
+func Test_networkFirstBootOrder(t *testing.T) {
+	tests := []struct {
+		bootOrder     string
+		networkDevice string
+		want          string
+	}{
+		{"", "ethernet0", "ethernet0,hdd"},
+		{"cdrom,hdd", "ethernet0", "ethernet0,cdrom,hdd"},
+		{"hdd, ethernet1 ,cdrom", "ethernet1", "ethernet1,hdd,cdrom"},
+		{"ethernet1,hdd", "ethernet1", "ethernet1,hdd"},
+	}
+	for _, tt := range tests {
+		got := networkFirstBootOrder(tt.bootOrder, tt.networkDevice)
+		if got != tt.want {
+			t.Errorf("networkFirstBootOrder(%q, %q) = %q, expected %q", tt.bootOrder, tt.networkDevice, got, tt.want)
+		}
+	}
+}
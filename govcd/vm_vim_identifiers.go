@@ -0,0 +1,155 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// VmVimIdentifiers are the vSphere identifiers of a VM, needed by tools working at vSphere level (e.g. backup
+// software) to find the VM and its disks
+type VmVimIdentifiers struct {
+	VmMoref   string // Managed Object Reference of the VM (e.g. vm-1234)
+	HostMoref string // Managed Object Reference of the host running the VM
+	// DatastoreMoref is the datastore of the VM home, which holds its configuration files
+	DatastoreMoref      string
+	VcenterHref         string // HREF of the vCenter in VCD
+	VcenterName         string
+	VcenterInstanceUuid string // Instance UUID of the vCenter
+	// IndependentDiskDatastoreMorefs maps the HREF of each independent disk attached to the VM to the Managed
+	// Object Reference of its datastore
+	IndependentDiskDatastoreMorefs map[string]string
+}
+
+// GetVimIdentifiers retrieves the vCenter Managed Object References of the VM, of its host and datastore, the
+// instance UUID of its vCenter and the datastores of the independent disks attached to the VM.
+//
+// Note. Requires system administrator privileges, as the vSphere information is only returned to providers.
+// Internal disks that override the VM storage policy may be placed on a datastore other than the VM home one, which
+// VCD does not expose per disk.
+func (vm *VM) GetVimIdentifiers(ctx context.Context) (*VmVimIdentifiers, error) {
+	if !vm.client.IsSysAdmin {
+		return nil, fmt.Errorf("functionality requires System Administrator privileges")
+	}
+	if vm.VM == nil || vm.VM.HREF == "" {
+		return nil, fmt.Errorf("cannot retrieve vSphere identifiers of a VM without HREF")
+	}
+
+	// The VM structure does not keep the VCloudExtension element, so the VM is retrieved again only to read it
+	vmExtensions := &vmVimExtensions{}
+	_, err := vm.client.ExecuteRequest(ctx, vm.VM.HREF, http.MethodGet, "", "error retrieving VM: %s", nil, vmExtensions)
+	if err != nil {
+		return nil, err
+	}
+
+	vimInfo := vmExtensions.vmVimInfo()
+	if vimInfo == nil || vimInfo.VmVimObjectRef == nil {
+		return nil, fmt.Errorf("no vSphere information found for VM '%s'", vm.VM.Name)
+	}
+
+	identifiers := &VmVimIdentifiers{
+		VmMoref:                        vimInfo.VmVimObjectRef.MoRef,
+		IndependentDiskDatastoreMorefs: make(map[string]string),
+	}
+	if vimInfo.HostVimObjectRef != nil {
+		identifiers.HostMoref = vimInfo.HostVimObjectRef.MoRef
+	}
+	if vimInfo.DatastoreVimObjectRef != nil {
+		identifiers.DatastoreMoref = vimInfo.DatastoreVimObjectRef.MoRef
+	}
+	if vimInfo.VmVimObjectRef.VimServerRef != nil {
+		identifiers.VcenterHref = vimInfo.VmVimObjectRef.VimServerRef.HREF
+		identifiers.VcenterName = vimInfo.VmVimObjectRef.VimServerRef.Name
+		identifiers.VcenterInstanceUuid, err = vm.client.getVcenterInstanceUuid(ctx, identifiers.VcenterHref)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vm.VM.VmSpecSection != nil && vm.VM.VmSpecSection.DiskSection != nil {
+		for _, diskSettings := range vm.VM.VmSpecSection.DiskSection.DiskSettings {
+			if diskSettings.Disk == nil {
+				continue
+			}
+			identifiers.IndependentDiskDatastoreMorefs[diskSettings.Disk.HREF], err =
+				vm.client.getIndependentDiskDatastoreMoref(ctx, diskSettings.Disk.HREF)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return identifiers, nil
+}
+
+// vmVimExtensions reads the VCloudExtension elements of a VM
+type vmVimExtensions struct {
+	VCloudExtension []struct {
+		VmVimInfo *types.VmVimInfo `xml:"VmVimInfo"`
+	} `xml:"VCloudExtension"`
+}
+
+// vmVimInfo returns the vSphere information found in the extensions, if any
+func (extensions *vmVimExtensions) vmVimInfo() *types.VmVimInfo {
+	for _, extension := range extensions.VCloudExtension {
+		if extension.VmVimInfo != nil {
+			return extension.VmVimInfo
+		}
+	}
+	return nil
+}
+
+// getVcenterInstanceUuid returns the instance UUID of the vCenter with the given HREF
+func (client *Client) getVcenterInstanceUuid(ctx context.Context, vcenterHref string) (string, error) {
+	results, err := client.QueryWithNotEncodedParams(ctx, nil, map[string]string{
+		"type": "virtualCenter",
+	})
+	if err != nil {
+		return "", fmt.Errorf("error retrieving vCenters: %w", err)
+	}
+	for _, vcenter := range results.Results.VirtualCenterRecord {
+		if equalIds(vcenterHref, "", vcenter.HREF) {
+			return vcenter.UUID, nil
+		}
+	}
+	return "", fmt.Errorf("%s: vCenter '%s'", ErrorEntityNotFound, vcenterHref)
+}
+
+// getIndependentDiskDatastoreMoref returns the Managed Object Reference of the datastore of an independent disk
+func (client *Client) getIndependentDiskDatastoreMoref(ctx context.Context, diskHref string) (string, error) {
+	results, err := client.QueryWithNotEncodedParams(ctx, nil, map[string]string{
+		"type":          types.QtAdminDisk,
+		"filter":        "id==" + url.QueryEscape(extractUuid(diskHref)),
+		"filterEncoded": "true",
+	})
+	if err != nil {
+		return "", fmt.Errorf("error querying independent disk '%s': %w", diskHref, err)
+	}
+	if len(results.Results.AdminDiskRecord) != 1 {
+		return "", fmt.Errorf("expected 1 independent disk with HREF '%s', found %d", diskHref,
+			len(results.Results.AdminDiskRecord))
+	}
+	datastoreHref := results.Results.AdminDiskRecord[0].DataStore
+	if datastoreHref == "" {
+		return "", fmt.Errorf("no datastore found for independent disk '%s'", diskHref)
+	}
+
+	datastore := struct {
+		VimObjectRef *types.VimObjectRef `xml:"VimObjectRef"`
+	}{}
+	_, err = client.ExecuteRequest(ctx, datastoreHref, http.MethodGet, "", "error retrieving datastore: %s", nil, &datastore)
+	if err != nil {
+		return "", err
+	}
+	if datastore.VimObjectRef == nil {
+		return "", fmt.Errorf("no vSphere reference found for datastore '%s'", datastoreHref)
+	}
+	return datastore.VimObjectRef.MoRef, nil
+}
@@ -0,0 +1,55 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestVmVimExtensions_vmVimInfo(t *testing.T) {
+	vmXml := `<Vm xmlns="http://www.vmware.com/vcloud/v1.5" xmlns:vmext="http://www.vmware.com/vcloud/extension/v1.5" name="vm1">
+  <VCloudExtension required="false">
+    <vmext:VmVimInfo>
+      <vmext:VmVimObjectRef>
+        <vmext:VimServerRef href="https://vcd.example.com/api/admin/extension/vimServer/3c2a7ad2-6c54-4b0f-b6a0-0d5f1b7a1b11" name="vc1" type="application/vnd.vmware.admin.vmwvirtualcenter+xml"/>
+        <vmext:MoRef>vm-1234</vmext:MoRef>
+        <vmext:VimObjectType>VIRTUAL_MACHINE</vmext:VimObjectType>
+      </vmext:VmVimObjectRef>
+      <vmext:DatastoreVimObjectRef>
+        <vmext:MoRef>datastore-12</vmext:MoRef>
+        <vmext:VimObjectType>DATASTORE</vmext:VimObjectType>
+      </vmext:DatastoreVimObjectRef>
+      <vmext:HostVimObjectRef>
+        <vmext:MoRef>host-34</vmext:MoRef>
+        <vmext:VimObjectType>HOST</vmext:VimObjectType>
+      </vmext:HostVimObjectRef>
+      <vmext:VirtualDisksMaxChainLength>1</vmext:VirtualDisksMaxChainLength>
+    </vmext:VmVimInfo>
+  </VCloudExtension>
+</Vm>`
+
+	extensions := &vmVimExtensions{}
+	err := xml.Unmarshal([]byte(vmXml), extensions)
+	if err != nil {
+		t.Fatalf("error unmarshalling VM: %s", err)
+	}
+	vimInfo := extensions.vmVimInfo()
+	if vimInfo == nil {
+		t.Fatalf("no vSphere information found")
+	}
+	if vimInfo.VmVimObjectRef.MoRef != "vm-1234" || vimInfo.VmVimObjectRef.VimServerRef.Name != "vc1" {
+		t.Errorf("unexpected VM reference: %+v", vimInfo.VmVimObjectRef)
+	}
+	if vimInfo.DatastoreVimObjectRef.MoRef != "datastore-12" || vimInfo.HostVimObjectRef.MoRef != "host-34" {
+		t.Errorf("unexpected datastore or host reference: %+v %+v", vimInfo.DatastoreVimObjectRef, vimInfo.HostVimObjectRef)
+	}
+
+	if (&vmVimExtensions{}).vmVimInfo() != nil {
+		t.Errorf("expected no vSphere information without extensions")
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// ErrorWaitTimeout is returned by WaitForState when the wanted state is not reached within the timeout or the
+// maximum number of attempts
+var ErrorWaitTimeout = errors.New("timed out waiting for state")
+
+// Defaults of WaitOptions
+const (
+	defaultWaitInitialInterval = time.Second
+	defaultWaitMaxInterval     = 30 * time.Second
+	defaultWaitMultiplier      = 2.0
+)
+
+// WaitOptions configure the polling of WaitForState. Zero values select the defaults
+type WaitOptions struct {
+	InitialDelay    time.Duration // Delay before the first probe. Default: none
+	InitialInterval time.Duration // Delay between the first and the second probe. Default: 1 second
+	MaxInterval     time.Duration // Maximum delay between two probes. Default: 30 seconds
+	Multiplier      float64       // Growth of the delay after each probe (1 for a constant delay). Default: 2
+	Timeout         time.Duration // Maximum time to wait, on top of the deadline of the context. Default: none
+	MaxAttempts     int           // Maximum number of probes. Default: unlimited
+}
+
+// WaitForState calls probe until it reports that the wanted state is reached, waiting between the calls with an
+// exponential backoff, and returns nil when the state is reached. It returns the error of probe, which stops the
+// waiting, the error of the context, or an error wrapping ErrorWaitTimeout when opts.Timeout or opts.MaxAttempts are
+// exceeded. probe can ignore transient errors by returning (false, nil)
+func WaitForState(ctx context.Context, probe func() (done bool, err error), opts WaitOptions) error {
+	if probe == nil {
+		return fmt.Errorf("state probe is nil")
+	}
+	if opts.InitialDelay < 0 || opts.InitialInterval < 0 || opts.MaxInterval < 0 || opts.Timeout < 0 ||
+		opts.MaxAttempts < 0 {
+		return fmt.Errorf("wait options can't be negative: %+v", opts)
+	}
+	if opts.Multiplier != 0 && opts.Multiplier < 1 {
+		return fmt.Errorf("wait multiplier must be at least 1, got %g", opts.Multiplier)
+	}
+	if opts.InitialInterval == 0 {
+		opts.InitialInterval = defaultWaitInitialInterval
+	}
+	if opts.MaxInterval == 0 {
+		opts.MaxInterval = defaultWaitMaxInterval
+	}
+	if opts.MaxInterval < opts.InitialInterval {
+		opts.MaxInterval = opts.InitialInterval
+	}
+	if opts.Multiplier == 0 {
+		opts.Multiplier = defaultWaitMultiplier
+	}
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	interval := opts.InitialInterval
+	delay := opts.InitialDelay
+	startTime := time.Now()
+	for attempt := 1; ; attempt++ {
+		if delay > 0 {
+			sleep := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				sleep.Stop()
+				return ctx.Err()
+			case <-timeout:
+				sleep.Stop()
+				return fmt.Errorf("%w after %s (%d attempts)", ErrorWaitTimeout, time.Since(startTime).Round(time.Millisecond), attempt-1)
+			case <-sleep.C:
+			}
+		}
+
+		done, err := probe()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if opts.MaxAttempts > 0 && attempt >= opts.MaxAttempts {
+			return fmt.Errorf("%w after %d attempts", ErrorWaitTimeout, attempt)
+		}
+
+		util.Logger.Printf("[TRACE] WaitForState: state not reached after attempt %d, waiting %s", attempt, interval)
+		delay = interval
+		interval = time.Duration(float64(interval) * opts.Multiplier)
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
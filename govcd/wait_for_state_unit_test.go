@@ -0,0 +1,141 @@
+//go:build unit || ALL
+
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForState(t *testing.T) {
+	probeError := errors.New("probe failed")
+	fastOptions := WaitOptions{InitialInterval: time.Millisecond, MaxInterval: 4 * time.Millisecond}
+
+	tests := []struct {
+		name         string
+		doneAt       int // attempt reaching the state, 0 for never
+		failAt       int // attempt returning an error, 0 for never
+		options      WaitOptions
+		wantErr      error
+		wantAttempts int
+	}{
+		{name: "Immediate", doneAt: 1, options: fastOptions, wantAttempts: 1},
+		{name: "AfterRetries", doneAt: 5, options: fastOptions, wantAttempts: 5},
+		{name: "ProbeError", doneAt: 5, failAt: 3, options: fastOptions, wantErr: probeError, wantAttempts: 3},
+		{
+			name:         "MaxAttempts",
+			options:      WaitOptions{InitialInterval: time.Millisecond, Multiplier: 1, MaxAttempts: 4},
+			wantErr:      ErrorWaitTimeout,
+			wantAttempts: 4,
+		},
+		{
+			name:    "Timeout",
+			options: WaitOptions{InitialInterval: time.Millisecond, Multiplier: 1, Timeout: 20 * time.Millisecond},
+			wantErr: ErrorWaitTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := WaitForState(context.Background(), func() (bool, error) {
+				attempts++
+				if attempts == tt.failAt {
+					return false, probeError
+				}
+				return attempts == tt.doneAt, nil
+			}, tt.options)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, expected %v", err, tt.wantErr)
+			}
+			if tt.wantAttempts > 0 && attempts != tt.wantAttempts {
+				t.Errorf("got %d attempts, expected %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestWaitForStateBackoff(t *testing.T) {
+	var probeTimes []time.Time
+	err := WaitForState(context.Background(), func() (bool, error) {
+		probeTimes = append(probeTimes, time.Now())
+		return len(probeTimes) == 5, nil
+	}, WaitOptions{InitialInterval: 10 * time.Millisecond, MaxInterval: 40 * time.Millisecond, Multiplier: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Delays are 10, 20, 40 and 40 (capped) milliseconds
+	minimumDelays := []time.Duration{10, 20, 40, 40}
+	for index, minimumDelay := range minimumDelays {
+		delay := probeTimes[index+1].Sub(probeTimes[index])
+		if delay < minimumDelay*time.Millisecond {
+			t.Errorf("delay %d is %s, expected at least %dms", index+1, delay, minimumDelay)
+		}
+	}
+}
+
+func TestWaitForStateContextAndOptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := WaitForState(ctx, func() (bool, error) { return false, nil },
+		WaitOptions{InitialInterval: time.Millisecond, Multiplier: 1})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	invalidOptions := []WaitOptions{
+		{InitialInterval: -time.Second},
+		{Multiplier: 0.5},
+		{MaxAttempts: -1},
+	}
+	for _, options := range invalidOptions {
+		err = WaitForState(context.Background(), func() (bool, error) { return true, nil }, options)
+		if err == nil {
+			t.Errorf("expected error for options %+v", options)
+		}
+	}
+	err = WaitForState(context.Background(), nil, WaitOptions{})
+	if err == nil {
+		t.Errorf("expected error for nil probe")
+	}
+}
+
+// Test_pollPreCreatedRdeContext checks that pollPreCreatedRde reports the end of the context when the RDE is
+// retrieved without errors, but is not in PRE_CREATED state
+func Test_pollPreCreatedRdeContext(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/cloudapi/1.0.0/entities/types/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"resultTotal":1,"pageCount":1,"page":1,"pageSize":128,"values":[` +
+			`{"id":"urn:vcloud:entity:vmware:test:1","name":"rde","state":"RESOLVED"}]}`))
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vcdClient := NewVCDClient(*serverUrl, true)
+	vcdClient.Client.supportedVersions = renderSupportedVersions([]string{"37.0"})
+	vcdClient.Client.APIVersion = "37.0"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = pollPreCreatedRde(ctx, &vcdClient.Client, "vmware", "test", "1.0.0", "rde", 5)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error wrapping the end of the context, got %v", err)
+	}
+}
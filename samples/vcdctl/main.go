@@ -0,0 +1,313 @@
+/*
+ * Copyright 2023 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// connection contains the flags shared by all the commands
+type connection struct {
+	username    string
+	password    string
+	token       string
+	org         string
+	apiEndpoint string
+	insecure    bool
+	timeout     time.Duration
+}
+
+// command is a vcdctl sub-command
+type command struct {
+	description string
+	run         func(ctx context.Context, vcdClient *govcd.VCDClient, conn connection, args []string) error
+}
+
+var commands = map[string]command{
+	"login":        {description: "checks the credentials and shows the VCD version", run: runLogin},
+	"inventory":    {description: "lists the VMs of an Org or VDC with their network details", run: runInventory},
+	"tag":          {description: "adds a metadata entry to several entities at once", run: runTag},
+	"catalog-sync": {description: "synchronises a subscribed catalog, showing the task progress", run: runCatalogSync},
+}
+
+// Usage:
+// # go build -o vcdctl
+// # ./vcdctl <command> --username my_user --password my_secret_password --org my-org --endpoint https://192.168.1.160/api [command flags]
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Printf("unknown command '%s'\n", os.Args[1])
+		usage()
+		os.Exit(1)
+	}
+
+	conn := connection{}
+	flags := flag.NewFlagSet("vcdctl "+os.Args[1], flag.ExitOnError)
+	flags.StringVar(&conn.username, "username", os.Getenv("VCD_USER"), "Username")
+	flags.StringVar(&conn.password, "password", os.Getenv("VCD_PASSWORD"), "Password")
+	flags.StringVar(&conn.token, "token", os.Getenv("VCD_TOKEN"), "Bearer token, used instead of username and password")
+	flags.StringVar(&conn.org, "org", "System", "Org used to log in. Default is 'System'")
+	flags.StringVar(&conn.apiEndpoint, "endpoint", os.Getenv("VCD_URL"), "API endpoint (e.g. 'https://hostname/api')")
+	flags.BoolVar(&conn.insecure, "insecure", false, "Skip the validation of the VCD certificate")
+	flags.DurationVar(&conn.timeout, "timeout", 10*time.Minute, "Maximum duration of the command")
+
+	// The command flags are parsed by each command, after the connection flags
+	args := splitConnectionFlags(flags, os.Args[2:])
+	err := flags.Parse(args.connection)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), conn.timeout)
+	defer cancel()
+
+	vcdClient, err := conn.login(ctx)
+	if err != nil {
+		fmt.Printf("error logging in: %s\n", err)
+		os.Exit(2)
+	}
+
+	err = cmd.run(ctx, vcdClient, conn, args.command)
+	if err != nil {
+		fmt.Printf("error running '%s': %s\n", os.Args[1], err)
+		os.Exit(3)
+	}
+}
+
+func usage() {
+	fmt.Println("Usage: vcdctl <command> [connection flags] [command flags]")
+	fmt.Println("Commands:")
+	for _, name := range []string{"login", "inventory", "tag", "catalog-sync"} {
+		fmt.Printf("  %-14s %s\n", name, commands[name].description)
+	}
+	fmt.Println("Run 'vcdctl <command> --help' to see the flags of a command")
+}
+
+// splitArgs contains the arguments of the connection and the ones of the command
+type splitArgs struct {
+	connection []string
+	command    []string
+}
+
+// splitConnectionFlags separates the connection flags, defined in flags, from the flags of the command
+func splitConnectionFlags(flags *flag.FlagSet, args []string) splitArgs {
+	result := splitArgs{}
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		name := strings.TrimLeft(arg, "-")
+		hasValue := strings.Contains(name, "=")
+		name = strings.SplitN(name, "=", 2)[0]
+		if name == "help" || name == "h" {
+			result.command = append(result.command, arg)
+			continue
+		}
+		definition := flags.Lookup(name)
+		if definition == nil || !strings.HasPrefix(arg, "-") {
+			result.command = append(result.command, arg)
+			continue
+		}
+		result.connection = append(result.connection, arg)
+		_, isBool := definition.Value.(interface{ IsBoolFlag() bool })
+		if !hasValue && !isBool && index+1 < len(args) {
+			index++
+			result.connection = append(result.connection, args[index])
+		}
+	}
+	return result
+}
+
+// login creates a client and authenticates with either a token or username and password
+func (conn connection) login(ctx context.Context) (*govcd.VCDClient, error) {
+	if conn.apiEndpoint == "" {
+		return nil, fmt.Errorf("'endpoint' must be specified")
+	}
+	vcdURL, err := url.Parse(conn.apiEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing supplied endpoint %s: %s", conn.apiEndpoint, err)
+	}
+
+	vcdClient := govcd.NewVCDClient(*vcdURL, conn.insecure)
+	if conn.token != "" {
+		err = vcdClient.SetToken(ctx, conn.org, govcd.BearerTokenHeader, conn.token)
+	} else {
+		if conn.username == "" || conn.password == "" {
+			return nil, fmt.Errorf("either 'token' or 'username' and 'password' must be specified")
+		}
+		err = vcdClient.Authenticate(ctx, conn.username, conn.password, conn.org)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vcdClient, nil
+}
+
+// runLogin shows the VCD version and the kind of user that logged in
+func runLogin(ctx context.Context, vcdClient *govcd.VCDClient, conn connection, args []string) error {
+	flags := flag.NewFlagSet("vcdctl login", flag.ExitOnError)
+	_ = flags.Parse(args)
+
+	version, buildTime, err := vcdClient.Client.GetVcdVersion(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("VCD version: %s (built %s)\n", version, buildTime.Format(time.RFC3339))
+	fmt.Printf("API version: %s\n", vcdClient.Client.APIVersion)
+	fmt.Printf("System administrator: %t\n", vcdClient.Client.IsSysAdmin)
+	return vcdClient.Disconnect(ctx)
+}
+
+// runInventory lists the VMs of an Org or of one of its VDCs. The query results are paginated by VCD and are
+// retrieved page by page by the SDK. A system administrator can list the VMs of any Org with the 'tenant' flag.
+func runInventory(ctx context.Context, vcdClient *govcd.VCDClient, conn connection, args []string) error {
+	var tenant, vdcName string
+	var includeTemplates bool
+	flags := flag.NewFlagSet("vcdctl inventory", flag.ExitOnError)
+	flags.StringVar(&tenant, "tenant", "", "Org whose VMs are listed. Defaults to the Org used to log in")
+	flags.StringVar(&vdcName, "vdc", "", "VDC whose VMs are listed. When empty, the VMs of all the VDCs of the Org are listed")
+	flags.BoolVar(&includeTemplates, "templates", false, "Also list the VMs of vApp templates")
+	_ = flags.Parse(args)
+
+	if tenant == "" {
+		tenant = conn.org
+	}
+	org, err := vcdClient.GetOrgByName(ctx, tenant)
+	if err != nil {
+		return fmt.Errorf("error retrieving Org '%s': %s", tenant, err)
+	}
+
+	filter := types.VmQueryFilterOnlyDeployed
+	if includeTemplates {
+		filter = types.VmQueryFilterAll
+	}
+
+	var inventory []*govcd.VmInventoryRecord
+	if vdcName != "" {
+		vdc, err := org.GetVDCByName(ctx, vdcName, false)
+		if err != nil {
+			return fmt.Errorf("error retrieving VDC '%s': %s", vdcName, err)
+		}
+		inventory, err = vdc.QueryVmInventory(ctx, filter)
+		if err != nil {
+			return err
+		}
+	} else {
+		inventory, err = org.QueryVmInventory(ctx, filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "VM\tvApp\tSTATUS\tCPUS\tMEMORY MB\tNETWORK\tIP ADDRESS\tSTORAGE PROFILE\tHOST")
+	for _, vm := range inventory {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\n", vm.Name, vm.VappName, vm.Status, vm.Cpus,
+			vm.MemoryMB, vm.NetworkName, vm.IpAddress, vm.StorageProfileName, vm.HostName)
+	}
+	_ = writer.Flush()
+	fmt.Printf("%d VMs\n", len(inventory))
+	return nil
+}
+
+// runTag merges a metadata entry into several entities, identified by their HREFs, with concurrent requests
+func runTag(ctx context.Context, vcdClient *govcd.VCDClient, conn connection, args []string) error {
+	var hrefs, key, value string
+	var concurrency int
+	flags := flag.NewFlagSet("vcdctl tag", flag.ExitOnError)
+	flags.StringVar(&hrefs, "hrefs", "", "Comma separated list of HREFs of the entities to tag")
+	flags.StringVar(&key, "key", "", "Metadata key")
+	flags.StringVar(&value, "value", "", "Metadata value")
+	flags.IntVar(&concurrency, "concurrency", 0, "Maximum number of entities tagged at the same time")
+	_ = flags.Parse(args)
+
+	if hrefs == "" || key == "" {
+		return fmt.Errorf("'hrefs' and 'key' must be specified")
+	}
+
+	metadata := map[string]types.MetadataValue{
+		key: {TypedValue: &types.MetadataTypedValue{Value: value, XsiType: types.MetadataStringValue}},
+	}
+	results := vcdClient.BatchApplyMetadata(ctx, strings.Split(hrefs, ","), metadata, concurrency)
+
+	failures := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failures++
+			fmt.Printf("FAILED %s: %s\n", result.Href, result.Error)
+			continue
+		}
+		fmt.Printf("OK     %s\n", result.Href)
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d entities could not be tagged", failures, len(results))
+	}
+	return nil
+}
+
+// runCatalogSync starts the synchronisation of a subscribed catalog and tracks the task without blocking, so that
+// its progress can be shown while waiting
+func runCatalogSync(ctx context.Context, vcdClient *govcd.VCDClient, conn connection, args []string) error {
+	var tenant, catalogName string
+	flags := flag.NewFlagSet("vcdctl catalog-sync", flag.ExitOnError)
+	flags.StringVar(&tenant, "tenant", "", "Org of the catalog. Defaults to the Org used to log in")
+	flags.StringVar(&catalogName, "catalog", "", "Name of the subscribed catalog")
+	_ = flags.Parse(args)
+
+	if catalogName == "" {
+		return fmt.Errorf("'catalog' must be specified")
+	}
+	if tenant == "" {
+		tenant = conn.org
+	}
+	adminOrg, err := vcdClient.GetAdminOrgByName(ctx, tenant)
+	if err != nil {
+		return fmt.Errorf("error retrieving Org '%s': %s", tenant, err)
+	}
+	catalog, err := adminOrg.GetAdminCatalogByName(ctx, catalogName, false)
+	if err != nil {
+		return fmt.Errorf("error retrieving catalog '%s': %s", catalogName, err)
+	}
+
+	task, err := catalog.LaunchSync(ctx)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		fmt.Println("A synchronisation is already running")
+		return nil
+	}
+
+	future := task.Future(ctx)
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-future.Done():
+			if future.Err() != nil {
+				return future.Err()
+			}
+			fmt.Printf("Catalog '%s' synchronised\n", catalogName)
+			return nil
+		case <-ticker.C:
+			// Task is a separate copy from the one tracked by the future, so it can be refreshed here
+			err = task.Refresh(ctx)
+			if err == nil {
+				fmt.Printf("Synchronising catalog '%s': %d%%\n", catalogName, task.Task.Progress)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+#!/usr/bin/env bash
+
+# This script runs the functional tests for several build tags in parallel,
+# one "go test" process per tag. Each process gets its own run prefix, so that
+# the names of the test resources and the persistent cleanup files do not collide.
+#
+# Usage: ./scripts/test-parallel.sh tag1 tag2 ...
+# The output of each process is saved in govcd/test-parallel-<tag>.log
+
+if [ -z "$1" ]
+then
+    echo "Usage: $0 tag1 [tag2 ...]"
+    exit 1
+fi
+
+if [ ! -d govcd ]
+then
+    echo "./govcd directory missing"
+    exit 1
+fi
+cd govcd
+
+start=$(date +%s)
+run_id=$(date +%s)
+pids=""
+
+echo "=== RUN ParallelTest"
+for tag in "$@"
+do
+    GOVCD_TEST_RUN_PREFIX="gt${run_id}-${tag}" go test -tags "$tag" -timeout 0 -check.vv \
+        > "test-parallel-${tag}.log" 2>&1 &
+    pids="$pids $!:$tag"
+done
+
+for item in $pids
+do
+    pid=${item%%:*}
+    tag=${item#*:}
+    if wait "$pid"
+    then
+        echo "  --- PASS: ParallelTest/$tag"
+    else
+        echo "  --- FAIL: ParallelTest/$tag (see govcd/test-parallel-${tag}.log)"
+        failed="$failed $tag"
+    fi
+done
+
+end=$(date +%s)
+elapsed=$((end-start))
+if [ -n "$failed" ]
+then
+    echo "--- FAIL: ParallelTest - Tests for tags [$failed] have failed (${elapsed}s)"
+    exit 1
+fi
+echo "--- PASS: ParallelTest (${elapsed}s)"
+exit 0
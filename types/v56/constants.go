@@ -97,8 +97,16 @@ const (
 	MimeUndeployVappParams = "application/vnd.vmware.vcloud.undeployVAppParams+xml"
 	// Mime for deploy vApp params
 	MimeDeployVappParams = "application/vnd.vmware.vcloud.deployVAppParams+xml"
+	// Mime for create snapshot params
+	MimeCreateSnapshotParams = "application/vnd.vmware.vcloud.createSnapshotParams+xml"
+	// Mime for screen ticket
+	MimeScreenTicket = "application/vnd.vmware.vcloud.screenTicket+xml"
+	// Mime for MKS ticket
+	MimeMksTicket = "application/vnd.vmware.vcloud.mksTicket+xml"
 	// Mime for VM
 	MimeVM = "application/vnd.vmware.vcloud.vm+xml"
+	// Mime for relocate VM params
+	MimeRelocateVmParams = "application/vnd.vmware.vcloud.relocateVmParams+xml"
 	// Mime for instantiate vApp template params
 	MimeInstantiateVappTemplateParams = "application/vnd.vmware.vcloud.instantiateVAppTemplateParams+xml"
 	// Mime for product section
@@ -117,6 +125,10 @@ const (
 	MimeAdminGroup = "application/vnd.vmware.admin.group+xml"
 	// MimeOrgLdapSettings
 	MimeOrgLdapSettings = "application/vnd.vmware.admin.organizationldapsettings+xml"
+	// MimeOrgFederationSettings is the mime of the SAML settings of an Org
+	MimeOrgFederationSettings = "application/vnd.vmware.admin.organizationFederationSettings+xml"
+	// MimeOrgOAuthSettings is the mime of the OpenID Connect settings of an Org
+	MimeOrgOAuthSettings = "application/vnd.vmware.admin.organizationOAuthSettings+xml"
 	// Mime of vApp network
 	MimeVappNetwork = "application/vnd.vmware.vcloud.vAppNetwork+xml"
 	// Mime of access control
@@ -141,6 +153,8 @@ const (
 	MimeSubscribeToExternalCatalog = "application/vnd.vmware.admin.externalCatalogSubscriptionParams+json"
 	// Mime to identify a media item
 	MimeMediaItem = "application/vnd.vmware.vcloud.media+xml"
+	// Mime to copy or move a catalog item
+	MimeCopyOrMoveCatalogItemParams = "application/vnd.vmware.vcloud.copyOrMoveCatalogItemParams+xml"
 )
 
 const (
@@ -266,6 +280,9 @@ const (
 	QtAdminOrgVdcStorageProfile = "adminOrgVdcStorageProfile" // StorageProfile of VDC as admin
 	QtTask                      = "task"                      // Task
 	QtAdminTask                 = "adminTask"                 // Task as admin
+	QtDisk                      = "disk"                      // Independent disk
+	QtAdminDisk                 = "adminDisk"                 // Independent disk as admin
+	QtDatastore                 = "datastore"                 // Datastore, only available to system administrators
 )
 
 // AdminQueryTypes returns the corresponding "admin" query type for each regular type
@@ -279,6 +296,9 @@ var AdminQueryTypes = map[string]string{
 	QtVm:            QtAdminVm,
 	QtVapp:          QtAdminVapp,
 	QtOrgVdc:        QtAdminOrgVdc,
+	QtDisk:          QtAdminDisk,
+
+	QtOrgVdcStorageProfile: QtAdminOrgVdcStorageProfile,
 }
 
 const (
@@ -321,6 +341,20 @@ const (
 	LdapModeCustom = "CUSTOM"
 )
 
+// LDAP connector types for custom LDAP settings of an Organization
+const (
+	LdapConnectorActiveDirectory = "ACTIVE_DIRECTORY"
+	LdapConnectorOpenLdap        = "OPEN_LDAP"
+)
+
+// LDAP authentication mechanisms for custom LDAP settings of an Organization
+const (
+	LdapAuthenticationSimple   = "SIMPLE"
+	LdapAuthenticationKerberos = "KERBEROS"
+	LdapAuthenticationMd5      = "MD5DIGEST"
+	LdapAuthenticationNtlm     = "NTLM"
+)
+
 // Access control modes
 const (
 	ControlAccessReadOnly    = "ReadOnly"
@@ -378,9 +412,13 @@ const (
 	OpenApiEndpointIpSecVpnTunnel                     = "edgeGateways/%s/ipsec/tunnels/"
 	OpenApiEndpointIpSecVpnTunnelConnectionProperties = "edgeGateways/%s/ipsec/tunnels/%s/connectionProperties"
 	OpenApiEndpointIpSecVpnTunnelStatus               = "edgeGateways/%s/ipsec/tunnels/%s/status"
+	OpenApiEndpointL2VpnTunnel                        = "edgeGateways/%s/l2vpn/tunnels/"
 	OpenApiEndpointSSLCertificateLibrary              = "ssl/certificateLibrary/"
 	OpenApiEndpointSSLCertificateLibraryOld           = "ssl/cetificateLibrary/"
 	OpenApiEndpointSessionCurrent                     = "sessions/current"
+	OpenApiEndpointTokens                             = "tokens/"
+	OpenApiEndpointServiceAccounts                    = "serviceAccounts/"
+	OpenApiEndpointServiceAccountGrant                = "deviceLookup/grant"
 	OpenApiEndpointVdcGroups                          = "vdcGroups/"
 	OpenApiEndpointVdcGroupsCandidateVdcs             = "vdcGroups/networkingCandidateVdcs"
 	OpenApiEndpointVdcGroupsDfwPolicies               = "vdcGroups/%s/dfwPolicies"
@@ -394,11 +432,16 @@ const (
 	OpenApiEndpointEdgeBgpNeighbor                    = "edgeGateways/%s/routing/bgp/neighbors/"   // '%s' is NSX-T Edge Gateway ID
 	OpenApiEndpointEdgeBgpConfigPrefixLists           = "edgeGateways/%s/routing/bgp/prefixLists/" // '%s' is NSX-T Edge Gateway ID
 	OpenApiEndpointEdgeBgpConfig                      = "edgeGateways/%s/routing/bgp"              // '%s' is NSX-T Edge Gateway ID
+	OpenApiEndpointEdgeSlaacProfile                   = "edgeGateways/%s/slaacProfile"             // '%s' is NSX-T Edge Gateway ID
+	OpenApiEndpointEdgeDhcpForwarder                  = "edgeGateways/%s/dhcpForwarder"            // '%s' is NSX-T Edge Gateway ID
+	OpenApiEndpointEdgeStaticRoutes                   = "edgeGateways/%s/routing/staticRoutes/"    // '%s' is NSX-T Edge Gateway ID
 	OpenApiEndpointRdeInterfaces                      = "interfaces/"
 	OpenApiEndpointRdeEntityTypes                     = "entityTypes/"
 	OpenApiEndpointRdeEntities                        = "entities/"
 	OpenApiEndpointRdeEntitiesTypes                   = "entities/types/"
 	OpenApiEndpointRdeEntitiesResolve                 = "entities/%s/resolve"
+	OpenApiEndpointEntityMetadata                     = "entities/%s/metadata/" // '%s' is the URN of any entity supporting OpenAPI metadata
+	OpenApiEndpointFeatureFlags                       = "featureFlags/"
 
 	// NSX-T ALB related endpoints
 
@@ -414,7 +457,10 @@ const (
 	// however only the summary endpoint can list all available pools for an edge gateway
 	OpenApiEndpointAlbPoolSummaries                 = "edgeGateways/%s/loadBalancer/poolSummaries" // %s contains edge gateway
 	OpenApiEndpointAlbVirtualServices               = "loadBalancer/virtualServices/"
-	OpenApiEndpointAlbVirtualServiceSummaries       = "edgeGateways/%s/loadBalancer/virtualServiceSummaries" // %s contains edge gateway
+	OpenApiEndpointAlbVirtualServiceSummaries       = "edgeGateways/%s/loadBalancer/virtualServiceSummaries"  // %s contains edge gateway
+	OpenApiEndpointAlbVsHttpRequestRules            = "loadBalancer/virtualServices/%s/httpPolicies/request"  // %s contains virtual service ID
+	OpenApiEndpointAlbVsHttpResponseRules           = "loadBalancer/virtualServices/%s/httpPolicies/response" // %s contains virtual service ID
+	OpenApiEndpointAlbVsHttpSecurityRules           = "loadBalancer/virtualServices/%s/httpPolicies/security" // %s contains virtual service ID
 	OpenApiEndpointAlbServiceEngineGroupAssignments = "loadBalancer/serviceEngineGroups/assignments/"
 	OpenApiEndpointAlbEdgeGateway                   = "edgeGateways/%s/loadBalancer"
 )
@@ -427,6 +473,14 @@ const (
 	HeaderAuthContext = "X-VMWARE-VCLOUD-AUTH-CONTEXT"
 )
 
+// Keys of the informational headers returned by VCD
+const (
+	// HeaderRequestId identifies the request in the VCD logs
+	HeaderRequestId = "X-VMWARE-VCLOUD-REQUEST-ID"
+	// HeaderRequestExecutionTime is the time in milliseconds spent by VCD processing the request
+	HeaderRequestExecutionTime = "X-VMWARE-VCLOUD-REQUEST-EXECUTION-TIME"
+)
+
 const (
 	// ExternalNetworkBackingTypeNsxtTier0Router defines backing type of NSX-T Tier-0 router
 	ExternalNetworkBackingTypeNsxtTier0Router = "NSXT_TIER0"
@@ -523,6 +577,45 @@ const (
 	NsxtAlbCloudBackingTypeNsxtAlb = "NSXALB_NSXT"
 )
 
+// NSX-T ALB feature sets of Service Engine Groups (types.NsxtAlbServiceEngineGroup.SupportedFeatureSet)
+const (
+	NsxtAlbFeatureSetStandard = "STANDARD"
+	NsxtAlbFeatureSetPremium  = "PREMIUM"
+)
+
+// NSX-T ALB Pool health monitor types, used in types.NsxtAlbPoolHealthMonitor
+const (
+	NsxtAlbHealthMonitorHttp  = "HTTP"
+	NsxtAlbHealthMonitorHttps = "HTTPS"
+	NsxtAlbHealthMonitorTcp   = "TCP"
+	NsxtAlbHealthMonitorUdp   = "UDP"
+	NsxtAlbHealthMonitorPing  = "PING"
+)
+
+// NSX-T ALB Pool persistence profile types, used in types.NsxtAlbPoolPersistenceProfile
+const (
+	NsxtAlbPersistenceClientIp         = "CLIENT_IP"
+	NsxtAlbPersistenceHttpCookie       = "HTTP_COOKIE"
+	NsxtAlbPersistenceCustomHttpHeader = "CUSTOM_HTTP_HEADER"
+	NsxtAlbPersistenceAppCookie        = "APP_COOKIE"
+	NsxtAlbPersistenceTls              = "TLS"
+)
+
+// NSX-T ALB Virtual Service application profile types, used in types.NsxtAlbVirtualServiceApplicationProfile
+const (
+	NsxtAlbApplicationProfileHttp  = "HTTP"
+	NsxtAlbApplicationProfileHttps = "HTTPS"
+	NsxtAlbApplicationProfileL4    = "L4"
+	NsxtAlbApplicationProfileL4Tls = "L4_TLS" // Requires the PREMIUM feature set
+)
+
+// NSX-T ALB Virtual Service TCP/UDP profile types, used in types.NsxtAlbVirtualServicePortTcpUdpProfile
+const (
+	NsxtAlbTcpUdpProfileTcpProxy    = "TCP_PROXY"
+	NsxtAlbTcpUdpProfileTcpFastPath = "TCP_FAST_PATH"
+	NsxtAlbTcpUdpProfileUdpFastPath = "UDP_FAST_PATH"
+)
+
 const (
 	// UrnTypeVdcGroup is the third segment of URN for VDC Group
 	UrnTypeVdcGroup = "vdcGroup"
@@ -542,6 +635,19 @@ const (
 	MetadataReadWriteVisibility string = "READWRITE"
 )
 
+// OpenAPI metadata constants
+const (
+	OpenApiMetadataStringEntry = "StringEntry"
+	OpenApiMetadataNumberEntry = "NumberEntry"
+	OpenApiMetadataBoolEntry   = "BoolEntry"
+	OpenApiMetadataJsonEntry   = "JsonEntry"
+
+	// OpenApiMetadataTenantDomain is the equivalent of the GENERAL domain of XML metadata
+	OpenApiMetadataTenantDomain = "TENANT"
+	// OpenApiMetadataProviderDomain is the equivalent of the SYSTEM domain of XML metadata
+	OpenApiMetadataProviderDomain = "PROVIDER"
+)
+
 const (
 	// DistributedFirewallPolicyDefault is a constant for "default" Distributed Firewall Policy
 	DistributedFirewallPolicyDefault = "default"
@@ -604,14 +710,73 @@ const (
 	NsxtDhcpBindingTypeIpv6 = "IPV6"
 )
 
+// NSX-T Edge Gateway SLAAC profile modes
+const (
+	NsxtEdgeSlaacProfileModeSlaac  = "SLAAC"
+	NsxtEdgeSlaacProfileModeDhcpv6 = "DHCPv6"
+)
+
+// NSX-T Firewall rule IP protocols
+const (
+	NsxtFirewallRuleIpProtocolIpv4     = "IPV4"
+	NsxtFirewallRuleIpProtocolIpv6     = "IPV6"
+	NsxtFirewallRuleIpProtocolIpv4Ipv6 = "IPV4_IPV6"
+)
+
 // NSX-T IPSec VPN authentication modes
 const (
 	NsxtIpSecVpnAuthenticationModePSK         = "PSK"
 	NsxtIpSecVpnAuthenticationModeCertificate = "CERTIFICATE"
 )
 
+// NSX-T L2 VPN session modes
+const (
+	NsxtL2VpnSessionModeServer = "SERVER"
+	NsxtL2VpnSessionModeClient = "CLIENT"
+)
+
 // Org VDC network backing types
 const (
 	OpenApiOrgVdcNetworkBackingTypeNsxv = "VIRTUAL_WIRE"
 	OpenApiOrgVdcNetworkBackingTypeNsxt = "NSXT_FLEXIBLE_SEGMENT"
 )
+
+// Independent disk sharing types
+const (
+	DiskSharingTypeNone              = "None"
+	DiskSharingTypeDiskSharing       = "DiskSharing"       // The disk can be attached to several VMs
+	DiskSharingTypeControllerSharing = "ControllerSharing" // The disk and its controller are shared by several VMs
+)
+
+// Independent disk bus types and subtypes, which determine the controller of the VM where the disk is attached
+const (
+	DiskBusTypeIde  = "5"
+	DiskBusTypeScsi = "6"
+	DiskBusTypeSata = "20" // Also used by NVMe controllers, with DiskBusSubTypeNvme
+
+	DiskBusSubTypeIde         = "ide"
+	DiskBusSubTypeBusLogic    = "buslogic"
+	DiskBusSubTypeLsiLogic    = "lsilogic"
+	DiskBusSubTypeLsiLogicSas = "lsilogicsas"
+	DiskBusSubTypeParavirtual = "VirtualSCSI"
+	DiskBusSubTypeSata        = "vmware.sata.ahci"
+	DiskBusSubTypeNvme        = "vmware.nvme.controller"
+)
+
+// Realization statuses of NSX-T backed OpenAPI entities, such as Edge Gateways and Org VDC networks, reported in
+// their Status field
+const (
+	RealizationStatusPending     = "PENDING"
+	RealizationStatusConfiguring = "CONFIGURING"
+	RealizationStatusRealized    = "REALIZED"
+	RealizationStatusFailed      = "REALIZATION_FAILED"
+	RealizationStatusUnknown     = "UNKNOWN"
+)
+
+// Statuses of a Service Account, reported in types.ServiceAccount.Status
+const (
+	ServiceAccountStatusCreated   = "CREATED"   // The Service Account exists but was never authorized
+	ServiceAccountStatusRequested = "REQUESTED" // Access was requested with the device authorization flow
+	ServiceAccountStatusGranted   = "GRANTED"   // Access was granted, and the first API token can be retrieved
+	ServiceAccountStatusActive    = "ACTIVE"    // The API token of the Service Account was retrieved
+)
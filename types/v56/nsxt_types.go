@@ -842,6 +842,55 @@ type NsxtIpSecVpnTunnelProfileDpdConfiguration struct {
 	ProbeInterval int `json:"probeInterval"`
 }
 
+// NsxtL2VpnTunnel defines the L2 VPN Tunnel configuration of an NSX-T Edge Gateway. L2 VPN extends Org VDC networks
+// across sites, so that VMs keep the same subnet when moving between them. One side of the tunnel runs in SERVER
+// session mode and generates a peer code, which is used to configure the other side in CLIENT session mode.
+type NsxtL2VpnTunnel struct {
+	// ID unique for L2 VPN tunnel. On updates, the ID is required for the tunnel, while for create a new ID will be
+	// generated.
+	ID string `json:"id,omitempty"`
+	// Name for the L2 VPN Tunnel
+	Name string `json:"name"`
+	// Description for the L2 VPN Tunnel
+	Description string `json:"description,omitempty"`
+	// SessionMode is the mode of the tunnel on this Edge Gateway. One of SERVER or CLIENT (see
+	// NsxtL2VpnSessionMode* constants)
+	SessionMode string `json:"sessionMode"`
+	// Enabled describes whether the L2 VPN Tunnel is enabled or not. The default is true.
+	Enabled bool `json:"enabled"`
+	// ConnectorInitiationMode is the mode used by the local endpoint to establish an IKE Connection with the remote
+	// site. Only used in SERVER session mode. Possible values are: INITIATOR , RESPOND_ONLY , ON_DEMAND
+	ConnectorInitiationMode string `json:"connectorInitiationMode,omitempty"`
+	// LocalEndpointIp is the IP of the Edge Gateway terminating the tunnel. It must be sub-allocated to the Edge
+	// Gateway
+	LocalEndpointIp string `json:"localEndpointIP"`
+	// RemoteEndpointIp is the IP of the remote endpoint of the tunnel
+	RemoteEndpointIp string `json:"remoteEndpointIP"`
+	// TunnelInterface is the network CIDR block over which the session interfaces. Only used in SERVER session mode
+	TunnelInterface string `json:"tunnelInterface,omitempty"`
+	// PreSharedKey is used for authentication. Only used in SERVER session mode; in CLIENT session mode it is taken
+	// from PeerCode
+	PreSharedKey string `json:"preSharedKey,omitempty"`
+	// PeerCode is generated by the SERVER side and encodes the configuration of the tunnel. It is only returned when
+	// retrieving a SERVER tunnel by ID, and it is required to create a CLIENT tunnel
+	PeerCode string `json:"peerCode,omitempty"`
+	// StretchedNetworks are the Org VDC networks stretched by the tunnel
+	StretchedNetworks []NsxtL2VpnStretchedNetwork `json:"stretchedNetworks,omitempty"`
+	// Logging sets whether logging for the tunnel is enabled or not. The default is false.
+	Logging bool `json:"logging"`
+	// Version of the L2 VPN Tunnel, required on updates
+	Version *VersionField `json:"version,omitempty"`
+}
+
+// NsxtL2VpnStretchedNetwork is an Org VDC network stretched by an NsxtL2VpnTunnel
+type NsxtL2VpnStretchedNetwork struct {
+	// NetworkRef points to the Org VDC network. Only routed networks of the Edge Gateway can be stretched
+	NetworkRef OpenApiReference `json:"networkRef"`
+	// TunnelId identifies the network within the tunnel. It must match on both sides of the tunnel. When not set
+	// in SERVER session mode, it is assigned by VCD
+	TunnelId int `json:"tunnelId,omitempty"`
+}
+
 // NsxtAlbController helps to integrate VMware Cloud Director with NSX-T Advanced Load Balancer deployment.
 // Controller instances are registered with VMware Cloud Director instance. Controller instances serve as a central
 // control plane for the load-balancing services provided by NSX-T Advanced Load Balancer.
@@ -1015,6 +1064,16 @@ type NsxtAlbConfig struct {
 	// TransparentModeEnabled allows to configure Preserve Client IP on a Virtual Service
 	// This field is only available for VCD 10.4.1+ (v37.1+)
 	TransparentModeEnabled *bool `json:"transparentModeEnabled,omitempty"`
+
+	// AnalyticsEnabled toggles collection of ALB analytics (metrics and logs) for Virtual Services
+	// on the Edge Gateway
+	// This field is only available for VCD 10.5.0+ (v38.0+)
+	AnalyticsEnabled *bool `json:"analyticsEnabled,omitempty"`
+
+	// WafEnabled toggles Web Application Firewall policies for Virtual Services on the Edge
+	// Gateway. It requires SupportedFeatureSet to be "PREMIUM"
+	// This field is only available for VCD 10.5.0+ (v38.0+)
+	WafEnabled *bool `json:"wafEnabled,omitempty"`
 }
 
 // NsxtAlbServiceEngineGroupAssignment configures Service Engine Group assignments to Edge Gateway. The only mandatory
@@ -1308,6 +1367,222 @@ type NsxtAlbVirtualServiceApplicationProfile struct {
 	Type string `json:"type"`
 }
 
+// AlbVsHttpRequestRules are the HTTP request rules of an NSX-T ALB Virtual Service. They are evaluated in order and
+// can redirect the request, rewrite its URL or change its headers.
+type AlbVsHttpRequestRules struct {
+	Values []AlbVsHttpRequestRule `json:"values"`
+}
+
+// AlbVsHttpRequestRule is a single HTTP request rule. Only one of RedirectAction, RewriteURLAction and HeaderActions
+// can be set.
+type AlbVsHttpRequestRule struct {
+	// Name of the rule. Must be unique within the rules of a Virtual Service
+	Name string `json:"name"`
+	// Active defines if the rule is evaluated
+	Active bool `json:"active"`
+	// Logging defines if the requests matching the rule are logged
+	Logging bool `json:"logging"`
+	// MatchCriteria defines the requests to which the rule applies
+	MatchCriteria AlbVsHttpRequestRuleMatchCriteria `json:"matchCriteria"`
+
+	// RedirectAction redirects the request
+	RedirectAction *AlbVsHttpRequestRuleRedirectAction `json:"redirectAction,omitempty"`
+	// RewriteURLAction rewrites the URL of the request
+	RewriteURLAction *AlbVsHttpRequestRuleRewriteURLAction `json:"rewriteUrlAction,omitempty"`
+	// HeaderActions add, remove or replace headers of the request
+	HeaderActions []*AlbVsHttpRuleHeaderAction `json:"headerActions,omitempty"`
+}
+
+// AlbVsHttpRequestRuleMatchCriteria defines the requests to which a request or security rule applies. All the
+// criteria that are set must match.
+type AlbVsHttpRequestRuleMatchCriteria struct {
+	ClientIPMatch    *AlbVsHttpRuleClientIPMatch    `json:"clientIpMatch,omitempty"`
+	ServicePortMatch *AlbVsHttpRuleServicePortMatch `json:"servicePortMatch,omitempty"`
+	MethodMatch      *AlbVsHttpRuleMethodMatch      `json:"methodMatch,omitempty"`
+	// Protocol is one of HTTP or HTTPS
+	Protocol    string                     `json:"protocol,omitempty"`
+	PathMatch   *AlbVsHttpRuleValueMatch   `json:"pathMatch,omitempty"`
+	HeaderMatch []AlbVsHttpRuleHeaderMatch `json:"headerMatch,omitempty"`
+	CookieMatch *AlbVsHttpRuleCookieMatch  `json:"cookieMatch,omitempty"`
+	QueryMatch  []string                   `json:"queryMatch,omitempty"`
+}
+
+// AlbVsHttpRuleClientIPMatch matches the client IP address. MatchCriteria is one of IS_IN or IS_NOT_IN
+type AlbVsHttpRuleClientIPMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	// Addresses can be IP addresses, CIDRs or ranges
+	Addresses []string `json:"addresses"`
+}
+
+// AlbVsHttpRuleServicePortMatch matches the port of the Virtual Service. MatchCriteria is one of IS_IN or IS_NOT_IN
+type AlbVsHttpRuleServicePortMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	Ports         []int  `json:"ports"`
+}
+
+// AlbVsHttpRuleMethodMatch matches the HTTP method. MatchCriteria is one of IS_IN or IS_NOT_IN
+type AlbVsHttpRuleMethodMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	// Methods are HTTP methods, such as GET, POST or PUT
+	Methods []string `json:"methods"`
+}
+
+// AlbVsHttpRuleValueMatch matches a string value, such as the path of a request or a Location header. MatchCriteria
+// is one of BEGINS_WITH, DOES_NOT_BEGIN_WITH, CONTAINS, DOES_NOT_CONTAIN, ENDS_WITH, DOES_NOT_END_WITH, EQUALS,
+// DOES_NOT_EQUAL, REGEX_MATCH or REGEX_DOES_NOT_MATCH
+type AlbVsHttpRuleValueMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	MatchStrings  []string `json:"matchStrings"`
+}
+
+// AlbVsHttpRuleHeaderMatch matches the value of a header. MatchCriteria also accepts EXISTS and DOES_NOT_EXIST, in
+// which case Value is not used
+type AlbVsHttpRuleHeaderMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Key           string   `json:"key"`
+	Value         []string `json:"value,omitempty"`
+}
+
+// AlbVsHttpRuleCookieMatch matches the value of a cookie
+type AlbVsHttpRuleCookieMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	Key           string `json:"key"`
+	Value         string `json:"value,omitempty"`
+}
+
+// AlbVsHttpRequestRuleRedirectAction redirects a request. Fields that are not set keep the value of the request
+type AlbVsHttpRequestRuleRedirectAction struct {
+	Host      string `json:"host,omitempty"`
+	KeepQuery bool   `json:"keepQuery"`
+	Path      string `json:"path,omitempty"`
+	Port      *int   `json:"port,omitempty"`
+	// Protocol is one of HTTP or HTTPS
+	Protocol string `json:"protocol"`
+	// StatusCode is one of 301, 302 or 307
+	StatusCode int `json:"statusCode"`
+}
+
+// AlbVsHttpRequestRuleRewriteURLAction rewrites the URL of a request
+type AlbVsHttpRequestRuleRewriteURLAction struct {
+	Host         string `json:"host,omitempty"`
+	ExistingPath string `json:"existingPath,omitempty"`
+	KeepQuery    bool   `json:"keepQuery"`
+}
+
+// AlbVsHttpRuleHeaderAction changes a header of a request or a response. Action is one of ADD, REMOVE or REPLACE
+type AlbVsHttpRuleHeaderAction struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	Value  string `json:"value,omitempty"`
+}
+
+// AlbVsHttpResponseRules are the HTTP response rules of an NSX-T ALB Virtual Service. They are evaluated in order and
+// can change the headers of the response or rewrite its Location header.
+type AlbVsHttpResponseRules struct {
+	Values []AlbVsHttpResponseRule `json:"values"`
+}
+
+// AlbVsHttpResponseRule is a single HTTP response rule
+type AlbVsHttpResponseRule struct {
+	// Name of the rule. Must be unique within the rules of a Virtual Service
+	Name string `json:"name"`
+	// Active defines if the rule is evaluated
+	Active bool `json:"active"`
+	// Logging defines if the responses matching the rule are logged
+	Logging bool `json:"logging"`
+	// MatchCriteria defines the responses to which the rule applies
+	MatchCriteria AlbVsHttpResponseRuleMatchCriteria `json:"matchCriteria"`
+
+	// HeaderActions add, remove or replace headers of the response
+	HeaderActions []*AlbVsHttpRuleHeaderAction `json:"headerActions,omitempty"`
+	// RewriteLocationHeaderAction rewrites the Location header of the response
+	RewriteLocationHeaderAction *AlbVsHttpResponseRuleRewriteLocationHeaderAction `json:"rewriteLocationHeaderAction,omitempty"`
+}
+
+// AlbVsHttpResponseRuleMatchCriteria defines the responses to which a response rule applies. All the criteria that
+// are set must match.
+type AlbVsHttpResponseRuleMatchCriteria struct {
+	ClientIPMatch    *AlbVsHttpRuleClientIPMatch    `json:"clientIpMatch,omitempty"`
+	ServicePortMatch *AlbVsHttpRuleServicePortMatch `json:"servicePortMatch,omitempty"`
+	MethodMatch      *AlbVsHttpRuleMethodMatch      `json:"methodMatch,omitempty"`
+	// Protocol is one of HTTP or HTTPS
+	Protocol            string                           `json:"protocol,omitempty"`
+	PathMatch           *AlbVsHttpRuleValueMatch         `json:"pathMatch,omitempty"`
+	CookieMatch         *AlbVsHttpRuleCookieMatch        `json:"cookieMatch,omitempty"`
+	LocationHeaderMatch *AlbVsHttpRuleValueMatch         `json:"locationHeaderMatch,omitempty"`
+	RequestHeaderMatch  []AlbVsHttpRuleHeaderMatch       `json:"requestHeaderMatch,omitempty"`
+	ResponseHeaderMatch []AlbVsHttpRuleHeaderMatch       `json:"responseHeaderMatch,omitempty"`
+	StatusCodeMatch     *AlbVsHttpResponseRuleStatusCode `json:"statusCodeMatch,omitempty"`
+}
+
+// AlbVsHttpResponseRuleStatusCode matches the status code of a response. MatchCriteria is one of IS_IN or IS_NOT_IN.
+// StatusCodes can contain single codes (e.g. "404") or ranges (e.g. "500-599")
+type AlbVsHttpResponseRuleStatusCode struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	StatusCodes   []string `json:"statusCodes"`
+}
+
+// AlbVsHttpResponseRuleRewriteLocationHeaderAction rewrites the Location header of a response
+type AlbVsHttpResponseRuleRewriteLocationHeaderAction struct {
+	// Protocol is one of HTTP or HTTPS
+	Protocol  string `json:"protocol"`
+	Host      string `json:"host,omitempty"`
+	Port      *int   `json:"port,omitempty"`
+	Path      string `json:"path,omitempty"`
+	KeepQuery bool   `json:"keepQuery"`
+}
+
+// AlbVsHttpSecurityRules are the HTTP security rules of an NSX-T ALB Virtual Service. They are evaluated in order and
+// can allow or close the connection, redirect to HTTPS, send a local response or limit the rate of requests.
+type AlbVsHttpSecurityRules struct {
+	Values []AlbVsHttpSecurityRule `json:"values"`
+}
+
+// AlbVsHttpSecurityRule is a single HTTP security rule. Only one of the actions can be set.
+type AlbVsHttpSecurityRule struct {
+	// Name of the rule. Must be unique within the rules of a Virtual Service
+	Name string `json:"name"`
+	// Active defines if the rule is evaluated
+	Active bool `json:"active"`
+	// Logging defines if the requests matching the rule are logged
+	Logging bool `json:"logging"`
+	// MatchCriteria defines the requests to which the rule applies
+	MatchCriteria AlbVsHttpRequestRuleMatchCriteria `json:"matchCriteria"`
+
+	// AllowOrCloseConnectionAction is one of ALLOW or CLOSE
+	AllowOrCloseConnectionAction string `json:"allowOrCloseConnectionAction,omitempty"`
+	// RedirectToHTTPSAction redirects the request to HTTPS on the given port
+	RedirectToHTTPSAction *AlbVsHttpSecurityRuleRedirectToHTTPSAction `json:"redirectToHTTPSAction,omitempty"`
+	// SendResponseAction answers the request with a local response
+	SendResponseAction *AlbVsHttpSecurityRuleSendResponseAction `json:"sendResponseAction,omitempty"`
+	// RateLimitAction limits the rate of the requests
+	RateLimitAction *AlbVsHttpSecurityRuleRateLimitAction `json:"rateLimitAction,omitempty"`
+}
+
+// AlbVsHttpSecurityRuleRedirectToHTTPSAction redirects a request to HTTPS
+type AlbVsHttpSecurityRuleRedirectToHTTPSAction struct {
+	Port int `json:"port"`
+}
+
+// AlbVsHttpSecurityRuleSendResponseAction answers a request with a local response. Content is base64 encoded
+type AlbVsHttpSecurityRuleSendResponseAction struct {
+	Content     string `json:"content,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	// StatusCode is one of 200, 204, 403, 404, 429 or 501
+	StatusCode int `json:"statusCode"`
+}
+
+// AlbVsHttpSecurityRuleRateLimitAction allows Count requests every Period seconds. The requests exceeding the limit
+// are handled by one of the actions, or just reported when no action is set.
+type AlbVsHttpSecurityRuleRateLimitAction struct {
+	Count  int `json:"count"`
+	Period int `json:"period"`
+	// CloseConnectionAction is CLOSE to close the connection of the requests exceeding the limit
+	CloseConnectionAction string                                   `json:"closeConnectionAction,omitempty"`
+	RedirectAction        *AlbVsHttpRequestRuleRedirectAction      `json:"redirectAction,omitempty"`
+	LocalResponseAction   *AlbVsHttpSecurityRuleSendResponseAction `json:"localResponseAction,omitempty"`
+}
+
 // DistributedFirewallRule represents a single Distributed Firewall rule
 type DistributedFirewallRule struct {
 	ID   string `json:"id,omitempty"`
@@ -1697,3 +1972,70 @@ type VcenterImportableDvpg struct {
 	VirtualCenter *OpenApiReference `json:"virtualCenter"`
 	Vlan          string            `json:"vlan"`
 }
+
+// NsxtEdgeGatewaySlaacProfile defines the IPv6 profile of an NSX-T Edge Gateway, which configures how addresses
+// are assigned to IPv6 hosts connected to its routed networks. Available since VCD 10.4 (API 37.0)
+type NsxtEdgeGatewaySlaacProfile struct {
+	// Enabled shows if the profile is in effect
+	Enabled bool `json:"enabled"`
+	// Mode is one of:
+	// * SLAAC - Stateless Address Autoconfiguration. Constant `types.NsxtEdgeSlaacProfileModeSlaac`
+	// * DHCPv6 - addresses are assigned by the DHCPv6 service of the networks. Constant
+	// `types.NsxtEdgeSlaacProfileModeDhcpv6`
+	Mode string `json:"mode"`
+	// DNSConfig contains the DNS settings advertised to hosts. Only used with SLAAC mode
+	DNSConfig NsxtEdgeGatewaySlaacProfileDNSConfig `json:"dnsConfig"`
+}
+
+// NsxtEdgeGatewayDhcpForwarder defines the DHCP forwarding (relay) configuration of an NSX-T Edge Gateway. When enabled,
+// DHCP requests of the routed networks that use relay mode are forwarded to the listed servers. Available since
+// VCD 10.4.1 (API 37.1)
+type NsxtEdgeGatewayDhcpForwarder struct {
+	// Enabled shows if DHCP forwarding is in effect
+	Enabled bool `json:"enabled"`
+	// DhcpServers is the list of IP addresses of the DHCP servers that receive the forwarded requests
+	DhcpServers []string `json:"dhcpServers,omitempty"`
+	// Version of the configuration, used by VCD to detect concurrent updates
+	Version VersionField `json:"version"`
+}
+
+// NsxtEdgeGatewaySlaacProfileDNSConfig contains the DNS settings advertised by an NSX-T Edge Gateway SLAAC profile
+type NsxtEdgeGatewaySlaacProfileDNSConfig struct {
+	// DNSServerIpv6Addresses is the list of IPv6 addresses of DNS servers
+	DNSServerIpv6Addresses []string `json:"dnsServerIpv6Addresses,omitempty"`
+	// DomainNames is the list of DNS search domains
+	DomainNames []string `json:"domainNames,omitempty"`
+}
+
+// NsxtEdgeGatewayStaticRoute defines a static route of an NSX-T Edge Gateway. Available since VCD 10.4 (API 37.0)
+type NsxtEdgeGatewayStaticRoute struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// NetworkCidr is the destination network of the route, in CIDR format (e.g. 192.168.1.0/24)
+	NetworkCidr string `json:"networkCidr"`
+	// NextHops is the list of next hops of the route
+	NextHops []NsxtEdgeGatewayStaticRouteNextHops `json:"nextHops"`
+	// SystemOwned shows if the route is managed by VCD. Read-only
+	SystemOwned *bool `json:"systemOwned,omitempty"`
+	// Version of the route, used by VCD to detect concurrent updates
+	Version *VersionField `json:"version,omitempty"`
+}
+
+// NsxtEdgeGatewayStaticRouteNextHops defines a next hop of an NSX-T Edge Gateway static route
+type NsxtEdgeGatewayStaticRouteNextHops struct {
+	// IPAddress of the next hop
+	IPAddress string `json:"ipAddress"`
+	// AdminDistance is the priority of the next hop, lower values being preferred. Default: 1
+	AdminDistance int `json:"adminDistance,omitempty"`
+	// Scope optionally restricts the next hop to a network or an external network
+	Scope *NsxtEdgeGatewayStaticRouteNextHopScope `json:"scope,omitempty"`
+}
+
+// NsxtEdgeGatewayStaticRouteNextHopScope is the network in which the next hop of a static route is reachable
+type NsxtEdgeGatewayStaticRouteNextHopScope struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// ScopeType is one of NETWORK, SYSTEM_OWNED or EXTERNAL_NETWORK
+	ScopeType string `json:"scopeType,omitempty"`
+}
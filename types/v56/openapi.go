@@ -465,3 +465,84 @@ type DefinedEntity struct {
 	Owner      *OpenApiReference      `json:"owner,omitempty"`      // The owner of the defined entity
 	Org        *OpenApiReference      `json:"org,omitempty"`        // The organization of the defined entity.
 }
+
+// OpenApiMetadataEntry represents a metadata entry of an entity retrieved with OpenAPI (VCD 10.4+)
+type OpenApiMetadataEntry struct {
+	ID           string                  `json:"id,omitempty"`         // UUID for OpenApiMetadataEntry. This is immutable
+	IsPersistent bool                    `json:"persistent,omitempty"` // Persistent entries can be copied over on some entity operations, for example: Creating a copy of an Org VDC, capturing a vApp to a template, instantiating a catalog item as a VM...
+	IsReadOnly   bool                    `json:"readOnly,omitempty"`   // The kind of level of access organizations of the entry’s domain have
+	KeyValue     OpenApiMetadataKeyValue `json:"keyValue,omitempty"`   // Contains core metadata entry data
+}
+
+// OpenApiMetadataKeyValue contains core metadata entry data
+type OpenApiMetadataKeyValue struct {
+	Domain    string                    `json:"domain,omitempty"`    // Only meaningful for providers. Allows them to share entries with their tenants. Currently, accepted values are: `TENANT`, `PROVIDER`
+	Key       string                    `json:"key,omitempty"`       // Key of the metadata entry
+	Value     OpenApiMetadataTypedValue `json:"value,omitempty"`     // Value of the metadata entry
+	Namespace string                    `json:"namespace,omitempty"` // Namespace of the metadata entry
+}
+
+// OpenApiMetadataTypedValue the type and value of the metadata entry
+type OpenApiMetadataTypedValue struct {
+	Value interface{} `json:"value,omitempty"` // The Value is anything because it depends on the Type field
+	Type  string      `json:"type,omitempty"`  // One of OpenApiMetadataStringEntry, OpenApiMetadataNumberEntry, OpenApiMetadataBoolEntry, OpenApiMetadataJsonEntry
+}
+
+// FeatureFlag represents a VCD feature flag, which enables features that are not generally available in a given
+// VCD version
+type FeatureFlag struct {
+	ID                 string `json:"id,omitempty"`
+	Name               string `json:"name,omitempty"`               // Name of the feature flag
+	Usage              string `json:"usage,omitempty"`              // Usage of the feature flag, such as ALPHA or PREVIEW
+	Enabled            bool   `json:"enabled"`                      // Whether the feature flag is enabled
+	DisplayName        string `json:"displayName,omitempty"`        // User friendly name
+	DisplayDescription string `json:"displayDescription,omitempty"` // User friendly description
+}
+
+// AuditTrailEvent is an event of the VCD audit trail
+type AuditTrailEvent struct {
+	EventID          string            `json:"eventId"`
+	Description      string            `json:"description,omitempty"`
+	OperatingOrg     *OpenApiReference `json:"operatingOrg,omitempty"` // Org in which the event happened
+	User             *OpenApiReference `json:"user,omitempty"`         // User who performed the operation
+	EventEntity      *OpenApiReference `json:"eventEntity,omitempty"`  // Entity affected by the event
+	TaskID           string            `json:"taskId,omitempty"`
+	TaskCellID       string            `json:"taskCellId,omitempty"`
+	CellID           string            `json:"cellId,omitempty"`
+	EventType        string            `json:"eventType,omitempty"`        // e.g. com/vmware/cloud/event/vm/create
+	ServiceNamespace string            `json:"serviceNamespace,omitempty"` // e.g. com.vmware.cloud
+	EventStatus      string            `json:"eventStatus,omitempty"`      // One of SUCCESS, FAILURE, ...
+	Timestamp        string            `json:"timestamp,omitempty"`
+	External         bool              `json:"external,omitempty"`
+	// AdditionalProperties contains details that depend on the event type
+	AdditionalProperties map[string]interface{} `json:"additionalProperties,omitempty"`
+}
+
+// ApiToken is an API token (refresh token) of a user, as listed by the tokens endpoint. The token itself is only
+// returned when it is created
+type ApiToken struct {
+	ID                string           `json:"id,omitempty"`
+	Name              string           `json:"name"`
+	Type              string           `json:"type,omitempty"` // REFRESH for API tokens
+	Owner             OpenApiReference `json:"owner,omitempty"`
+	Org               OpenApiReference `json:"org,omitempty"`
+	ExpirationTimeUtc string           `json:"expirationTimeUtc,omitempty"`
+}
+
+// ServiceAccount is an account used by applications to authenticate to VCD with an API token obtained through the
+// OAuth device authorization flow
+type ServiceAccount struct {
+	ID              string            `json:"id,omitempty"`
+	Name            string            `json:"name"`
+	SoftwareID      string            `json:"softwareId"`                // UUID identifying the application
+	SoftwareVersion string            `json:"softwareVersion,omitempty"` // Version of the application
+	Role            *OpenApiReference `json:"role,omitempty"`            // Role of the Service Account
+	Uri             string            `json:"uri,omitempty"`             // URI of the application
+	Org             *OpenApiReference `json:"org,omitempty"`
+	Status          string            `json:"status,omitempty"` // One of the ServiceAccountStatus* values
+}
+
+// ServiceAccountGrant is the user code of a device authorization request, used to grant access to a Service Account
+type ServiceAccountGrant struct {
+	UserCode string `json:"userCode"`
+}
@@ -366,6 +366,11 @@ type InstantiationParams struct {
 	NetworkConfigSection         *NetworkConfigSection         `xml:"NetworkConfigSection,omitempty"`
 	NetworkConnectionSection     *NetworkConnectionSection     `xml:"NetworkConnectionSection,omitempty"`
 	ProductSection               *ProductSection               `xml:"ProductSection,omitempty"`
+	// VirtualHardwareSection is only used for sourced VMs, to override disk settings at instantiation
+	VirtualHardwareSection *InstantiationVirtualHardwareSection `xml:"ovf:VirtualHardwareSection,omitempty"`
+	// InstantiationProductSection sets the values of OVF properties at instantiation, for the vApp or, in a sourced
+	// item, for a VM. Unlike ProductSection, it is encoded with the namespace prefixes expected by VCD in requests
+	InstantiationProductSection *InstantiationProductSection `xml:"ovf:ProductSection,omitempty"`
 	// TODO: Not Implemented
 	// SnapshotSection              SnapshotSection              `xml:"SnapshotSection,omitempty"`
 }
@@ -579,6 +584,8 @@ type VdcStorageProfileConfiguration struct {
 	Limit                     int64      `xml:"Limit"`
 	Default                   bool       `xml:"Default"`
 	ProviderVdcStorageProfile *Reference `xml:"ProviderVdcStorageProfile"`
+	// IopsSettings allows setting IOPS limits for the storage profile at VDC creation time
+	IopsSettings *VdcStorageProfileIopsSettings `xml:"IopsSettings,omitempty"`
 }
 
 // VdcStorageProfile represents the parameters for fetched storage profile
@@ -588,6 +595,8 @@ type VdcStorageProfileConfiguration struct {
 // https://vdc-repo.vmware.com/vmwb-repository/dcr-public/71e12563-bc11-4d64-821d-92d30f8fcfa1/7424bf8e-aec2-44ad-be7d-b98feda7bae0/doc/doc/types/AdminVdcStorageProfileType.html
 type VdcStorageProfile struct {
 	Xmlns                     string                         `xml:"xmlns,attr"`
+	HREF                      string                         `xml:"href,attr,omitempty"`
+	ID                        string                         `xml:"id,attr,omitempty"`
 	Name                      string                         `xml:"name,attr"`
 	Enabled                   *bool                          `xml:"Enabled,omitempty"`
 	Units                     string                         `xml:"Units"`
@@ -605,6 +614,8 @@ type VdcStorageProfile struct {
 // https://vdc-repo.vmware.com/vmwb-repository/dcr-public/71e12563-bc11-4d64-821d-92d30f8fcfa1/7424bf8e-aec2-44ad-be7d-b98feda7bae0/doc/doc/types/AdminVdcStorageProfileType.html
 type AdminVdcStorageProfile struct {
 	Xmlns                     string                         `xml:"xmlns,attr"`
+	HREF                      string                         `xml:"href,attr,omitempty"`
+	ID                        string                         `xml:"id,attr,omitempty"`
 	Name                      string                         `xml:"name,attr"`
 	Enabled                   *bool                          `xml:"Enabled,omitempty"`
 	Units                     string                         `xml:"Units"`
@@ -924,12 +935,95 @@ type VAppLeaseSettings struct {
 	PowerOffOnRuntimeLeaseExpiration *bool `xml:"PowerOffOnRuntimeLeaseExpiration,omitempty"`
 }
 
+// OrgFederationSettings represents the SAML federation settings of a VMware Cloud Director organization.
+// Type: OrgFederationSettingsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Represents the SAML identity provider settings of a VMware Cloud Director organization.
+// Since: 5.1
+// Note. Order of these fields matter and API will error if it is changed
 type OrgFederationSettings struct {
-	HREF string   `xml:"href,attr,omitempty"` // The URI of the entity.
-	Type string   `xml:"type,attr,omitempty"` // The MIME type of the entity.
-	Link LinkList `xml:"Link,omitempty"`      // A reference to an entity or operation associated with this object.
+	XMLName xml.Name `xml:"OrgFederationSettings"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	HREF    string   `xml:"href,attr,omitempty"` // The URI of the entity.
+	Type    string   `xml:"type,attr,omitempty"` // The MIME type of the entity.
+	Link    LinkList `xml:"Link,omitempty"`      // A reference to an entity or operation associated with this object.
 
-	Enabled bool `xml:"Enabled,omitempty"`
+	SAMLMetadata         string                `xml:"SAMLMetadata,omitempty"`         // Metadata XML of the SAML identity provider
+	Enabled              bool                  `xml:"Enabled"`                        // Whether SAML authentication is enabled for the Org
+	SamlAttributeMapping *SamlAttributeMapping `xml:"SamlAttributeMapping,omitempty"` // Names of the SAML assertion attributes holding the user details
+	SamlSPEntityId       string                `xml:"SamlSPEntityId,omitempty"`       // Entity ID of VCD as SAML service provider
+}
+
+// SamlAttributeMapping defines the names of the SAML assertion attributes used to import users and their groups
+// Type: SamlAttributeMappingType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Since: 9.7
+type SamlAttributeMapping struct {
+	EmailAttributeName     string `xml:"EmailAttributeName,omitempty"`
+	UserNameAttributeName  string `xml:"UserNameAttributeName,omitempty"`
+	FirstNameAttributeName string `xml:"FirstNameAttributeName,omitempty"`
+	SurnameAttributeName   string `xml:"SurnameAttributeName,omitempty"`
+	FullNameAttributeName  string `xml:"FullNameAttributeName,omitempty"`
+	GroupAttributeName     string `xml:"GroupAttributeName,omitempty"` // Attribute holding the groups of the user, mapped to VCD groups
+	RoleAttributeName      string `xml:"RoleAttributeName,omitempty"`  // Attribute holding the roles of the user
+}
+
+// OrgOAuthSettings represents the OpenID Connect settings of a VMware Cloud Director organization.
+// Type: OrgOAuthSettingsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Represents the OpenID Connect identity provider settings of a VMware Cloud Director organization.
+// Since: 10.0
+// Note. Order of these fields matter and API will error if it is changed
+type OrgOAuthSettings struct {
+	XMLName xml.Name `xml:"OrgOAuthSettings"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	HREF    string   `xml:"href,attr,omitempty"` // The URI of the entity.
+	Type    string   `xml:"type,attr,omitempty"` // The MIME type of the entity.
+	Link    LinkList `xml:"Link,omitempty"`      // A reference to an entity or operation associated with this object.
+
+	IssuerId                  string                  `xml:"IssuerId,omitempty"`                  // Issuer of the ID tokens
+	OAuthKeyConfigurations    *OAuthKeyConfigurations `xml:"OAuthKeyConfigurations,omitempty"`    // Keys used to verify the tokens
+	Enabled                   bool                    `xml:"Enabled"`                             // Whether OpenID Connect authentication is enabled for the Org
+	ClientId                  string                  `xml:"ClientId,omitempty"`                  // Client ID of VCD in the identity provider
+	ClientSecret              string                  `xml:"ClientSecret,omitempty"`              // Client secret of VCD in the identity provider
+	UserAuthorizationEndpoint string                  `xml:"UserAuthorizationEndpoint,omitempty"` // Authorization endpoint of the identity provider
+	AccessTokenEndpoint       string                  `xml:"AccessTokenEndpoint,omitempty"`       // Token endpoint of the identity provider
+	UserInfoEndpoint          string                  `xml:"UserInfoEndpoint,omitempty"`          // User info endpoint of the identity provider
+	ScimEndpoint              string                  `xml:"ScimEndpoint,omitempty"`              // SCIM endpoint of the identity provider
+	Scope                     []string                `xml:"Scope,omitempty"`                     // Scopes requested to the identity provider
+	OIDCAttributeMapping      *OIDCAttributeMapping   `xml:"OIDCAttributeMapping,omitempty"`      // Names of the claims holding the user details
+	MaxClockSkew              int                     `xml:"MaxClockSkew,omitempty"`              // Allowed clock skew in seconds
+	WellKnownEndpoint         string                  `xml:"WellKnownEndpoint,omitempty"`         // OpenID Connect discovery endpoint of the identity provider
+}
+
+// OAuthKeyConfigurations is a list of keys used to verify the tokens of an OpenID Connect identity provider
+type OAuthKeyConfigurations struct {
+	OAuthKeyConfiguration []*OAuthKeyConfiguration `xml:"OAuthKeyConfiguration,omitempty"`
+}
+
+// OAuthKeyConfiguration is a key used to verify the tokens of an OpenID Connect identity provider
+// Type: OAuthKeyConfigurationType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Since: 10.0
+type OAuthKeyConfiguration struct {
+	KeyId          string `xml:"KeyId"`                    // Identifier of the key, as in the 'kid' header of the tokens
+	Algorithm      string `xml:"Algorithm"`                // Algorithm of the key, such as RSA
+	Key            string `xml:"Key"`                      // Public key in PEM format
+	ExpirationDate string `xml:"ExpirationDate,omitempty"` // Expiration date of the key
+}
+
+// OIDCAttributeMapping defines the names of the OpenID Connect claims used to import users and their groups
+// Type: OIDCAttributeMappingType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Since: 10.0
+type OIDCAttributeMapping struct {
+	SubjectAttributeName   string `xml:"SubjectAttributeName,omitempty"`
+	EmailAttributeName     string `xml:"EmailAttributeName,omitempty"`
+	FullNameAttributeName  string `xml:"FullNameAttributeName,omitempty"`
+	FirstNameAttributeName string `xml:"FirstNameAttributeName,omitempty"`
+	LastNameAttributeName  string `xml:"LastNameAttributeName,omitempty"`
+	GroupsAttributeName    string `xml:"GroupsAttributeName,omitempty"` // Claim holding the groups of the user, mapped to VCD groups
+	RolesAttributeName     string `xml:"RolesAttributeName,omitempty"`  // Claim holding the roles of the user
 }
 
 // OrgLdapSettingsType represents the ldap settings for a VMware Cloud Director organization.
@@ -1057,6 +1151,29 @@ type CatalogItem struct {
 	Link          LinkList         `xml:"Link,omitempty"`
 	Tasks         *TasksInProgress `xml:"Tasks,omitempty"`
 	VersionNumber int64            `xml:"VersionNumber,omitempty"`
+	// Property contains user-defined key/value pairs describing the item (e.g. release or build of the template)
+	Property []*CatalogItemProperty `xml:"Property,omitempty"`
+}
+
+// CatalogItemProperty is a user-defined key/value pair of a catalog item
+type CatalogItemProperty struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// CatalogItemForUpdate contains the fields of a catalog item that can be updated. The version number is increased by
+// VCD at each update.
+type CatalogItemForUpdate struct {
+	XMLName xml.Name `xml:"CatalogItem"`
+	// Attributes
+	Xmlns string `xml:"xmlns,attr,omitempty"`
+	HREF  string `xml:"href,attr,omitempty"`
+	ID    string `xml:"id,attr,omitempty"`
+	Name  string `xml:"name,attr"`
+	// Elements
+	Description string                 `xml:"Description,omitempty"`
+	Entity      *Reference             `xml:"Entity"`
+	Property    []*CatalogItemProperty `xml:"Property,omitempty"`
 }
 
 // Entity is a basic entity type in the vCloud object model. Includes a name, an optional description, and an optional list of links.
@@ -1243,6 +1360,43 @@ type UndeployVAppParams struct {
 	UndeployPowerAction string `xml:"UndeployPowerAction,omitempty"`
 }
 
+// RelocateParams represents the parameters to relocate a VM to a different datastore.
+// Type: RelocateParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters to a relocate VM request.
+// Since: 5.1
+type RelocateParams struct {
+	XMLName   xml.Name   `xml:"RelocateParams"`
+	Xmlns     string     `xml:"xmlns,attr"`
+	Datastore *Reference `xml:"Datastore"` // Reference to the destination datastore
+}
+
+// CopyOrMoveCatalogItemParams represents the parameters to copy or move a catalog item into a catalog.
+// Type: CopyOrMoveCatalogItemParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters for a copy or move catalog item request.
+// Since: 5.5
+type CopyOrMoveCatalogItemParams struct {
+	XMLName     xml.Name   `xml:"CopyOrMoveCatalogItemParams"`
+	Xmlns       string     `xml:"xmlns,attr"`
+	Name        string     `xml:"name,attr,omitempty"` // Name of the new catalog item
+	Description string     `xml:"Description,omitempty"`
+	Source      *Reference `xml:"Source"` // Reference to the catalog item to copy or move
+}
+
+// UploadVAppTemplateParams represents the parameters to create a vApp template in a catalog from an OVF.
+// Type: UploadVAppTemplateParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters for an upload vApp template request.
+// Since: 0.9
+type UploadVAppTemplateParams struct {
+	XMLName     xml.Name `xml:"UploadVAppTemplateParams"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Name        string   `xml:"name,attr"`
+	SourceHref  string   `xml:"sourceHref,attr,omitempty"` // URL of the OVF descriptor, when VCD retrieves it
+	Description string   `xml:"Description,omitempty"`
+}
+
 // VmCapabilities allows you to specify certain capabilities of this virtual machine.
 // Type: VmCapabilitiesType
 // Namespace: http://www.vmware.com/vcloud/v1.5
@@ -1289,11 +1443,11 @@ type ComposeVAppParams struct {
 	PowerOn     bool   `xml:"powerOn,attr"`               // True if the vApp should be powered-on at instantiation. Defaults to true.
 	LinkedClone bool   `xml:"linkedClone,attr,omitempty"` // Reserved. Unimplemented.
 	// Elements
-	Description         string                       `xml:"Description,omitempty"`         // Optional description.
-	VAppParent          *Reference                   `xml:"VAppParent,omitempty"`          // Reserved. Unimplemented.
-	InstantiationParams *InstantiationParams         `xml:"InstantiationParams,omitempty"` // Instantiation parameters for the composed vApp.
-	SourcedItem         *SourcedCompositionItemParam `xml:"SourcedItem,omitempty"`         // Composition item. One of: vApp vAppTemplate VM.
-	AllEULAsAccepted    bool                         `xml:"AllEULAsAccepted,omitempty"`    // True confirms acceptance of all EULAs in a vApp template. Instantiation fails if this element is missing, empty, or set to false and one or more EulaSection elements are present.
+	Description         string                         `xml:"Description,omitempty"`         // Optional description.
+	VAppParent          *Reference                     `xml:"VAppParent,omitempty"`          // Reserved. Unimplemented.
+	InstantiationParams *InstantiationParams           `xml:"InstantiationParams,omitempty"` // Instantiation parameters for the composed vApp.
+	SourcedItem         []*SourcedCompositionItemParam `xml:"SourcedItem,omitempty"`         // Composition items. One of: vApp vAppTemplate VM.
+	AllEULAsAccepted    bool                           `xml:"AllEULAsAccepted,omitempty"`    // True confirms acceptance of all EULAs in a vApp template. Instantiation fails if this element is missing, empty, or set to false and one or more EulaSection elements are present.
 }
 
 type ReComposeVAppParams struct {
@@ -1576,6 +1730,7 @@ type VAppTemplate struct {
 	NetworkConnectionSection *NetworkConnectionSection `xml:"NetworkConnectionSection,omitempty"`
 	LeaseSettingsSection     *LeaseSettingsSection     `xml:"LeaseSettingsSection,omitempty"`
 	CustomizationSection     *CustomizationSection     `xml:"CustomizationSection,omitempty"`
+	VirtualHardwareSection   *VirtualHardwareSection   `xml:"VirtualHardwareSection,omitempty"` // Only for VMs
 	// OVF Section needs to be added
 	// Section               Section              `xml:"Section,omitempty"`
 }
@@ -1687,6 +1842,8 @@ type VirtualHardwareSection struct {
 	HREF string                 `xml:"href,attr,omitempty"`
 	Type string                 `xml:"type,attr,omitempty"`
 	Item []*VirtualHardwareItem `xml:"Item,omitempty"`
+	// ExtraConfig holds the vmw:ExtraConfig entries (vSphere advanced settings) visible to the user
+	ExtraConfig []*ExtraConfig `xml:"ExtraConfig,omitempty"`
 }
 
 // Each ovf:Item parsed from the ovf:VirtualHardwareSection
@@ -1774,6 +1931,55 @@ type OVFItem struct {
 	Link           *Link `xml:"vcloud:Link"`
 }
 
+// InstantiationVirtualHardwareSection is the ovf:VirtualHardwareSection that can be sent in the InstantiationParams
+// of a sourced VM to override the settings of its disks, such as the storage profile.
+// Like OVFItem, it uses namespace prefixes, which are declared in the section itself.
+type InstantiationVirtualHardwareSection struct {
+	XMLName     xml.Name                 `xml:"ovf:VirtualHardwareSection"`
+	XmlnsRasd   string                   `xml:"xmlns:rasd,attr"`
+	XmlnsVCloud string                   `xml:"xmlns:vcloud,attr"`
+	Info        string                   `xml:"ovf:Info"`
+	Item        []*InstantiationDiskItem `xml:"ovf:Item"`
+}
+
+// InstantiationDiskItem is a hard disk item of InstantiationVirtualHardwareSection. The elements follow the
+// (alphabetical) order required by the OVF schema.
+type InstantiationDiskItem struct {
+	AddressOnParent int                            `xml:"rasd:AddressOnParent"`
+	ElementName     string                         `xml:"rasd:ElementName"`
+	HostResource    *InstantiationDiskHostResource `xml:"rasd:HostResource"`
+	InstanceID      int                            `xml:"rasd:InstanceID"`
+	Parent          int                            `xml:"rasd:Parent,omitempty"`
+	ResourceType    int                            `xml:"rasd:ResourceType"`
+}
+
+// InstantiationDiskHostResource contains the attributes of the host resource of InstantiationDiskItem
+type InstantiationDiskHostResource struct {
+	BusType           int    `xml:"vcloud:busType,attr,omitempty"`
+	BusSubType        string `xml:"vcloud:busSubType,attr,omitempty"`
+	Capacity          int    `xml:"vcloud:capacity,attr,omitempty"`
+	StorageProfile    string `xml:"vcloud:storageProfileHref,attr,omitempty"`
+	OverrideVmDefault bool   `xml:"vcloud:storageProfileOverrideVmDefault,attr"`
+}
+
+// InstantiationProductSection is the ovf:ProductSection that can be sent in the InstantiationParams of a vApp or of
+// a sourced VM to set the values of its OVF properties. Like InstantiationVirtualHardwareSection, it uses namespace
+// prefixes, which are declared in the section itself.
+type InstantiationProductSection struct {
+	XMLName  xml.Name                 `xml:"ovf:ProductSection"`
+	XmlnsOvf string                   `xml:"xmlns:ovf,attr"`
+	Info     string                   `xml:"ovf:Info"`
+	Property []*InstantiationProperty `xml:"ovf:Property"`
+}
+
+// InstantiationProperty is the value of an OVF property in InstantiationProductSection
+type InstantiationProperty struct {
+	Key              string `xml:"ovf:key,attr"`
+	Type             string `xml:"ovf:type,attr,omitempty"`
+	UserConfigurable bool   `xml:"ovf:userConfigurable,attr"`
+	Value            string `xml:"ovf:value,attr"`
+}
+
 // DeployVAppParams are the parameters to a deploy vApp request
 // Type: DeployVAppParamsType
 // Namespace: http://www.vmware.com/vcloud/v1.5
@@ -1788,6 +1994,47 @@ type DeployVAppParams struct {
 	ForceCustomization     bool `xml:"forceCustomization,attr,omitempty"`     // Used to specify whether to force customization on deployment, if not set default value is false
 }
 
+// CreateSnapshotParams are the parameters to a create snapshot request for a vApp or a VM
+// Type: CreateSnapshotParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters for a create snapshot request.
+// Since: 5.1
+type CreateSnapshotParams struct {
+	XMLName xml.Name `xml:"CreateSnapshotParams"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	// Attributes
+	Memory  *bool  `xml:"memory,attr,omitempty"`  // Whether to include the memory of the VMs in the snapshot. The default is true.
+	Name    string `xml:"name,attr,omitempty"`    // Name of the snapshot
+	Quiesce *bool  `xml:"quiesce,attr,omitempty"` // Whether to quiesce the file system of the VMs before the snapshot. The default is true.
+	// Elements
+	Description string `xml:"Description,omitempty"` // Description of the snapshot
+}
+
+// ScreenTicket is a ticket to access the console of a VM, in the form mks://host/vm-moref/ticket
+// Type: ScreenTicketType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: A ticket that can be used to access the console of a VM.
+// Since: 0.9
+type ScreenTicket struct {
+	XMLName xml.Name `xml:"ScreenTicket"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Value   string   `xml:",chardata"`
+}
+
+// MksTicket is a ticket to access the console of a VM through the VMware Remote Console or WebMKS
+// Type: MksTicketType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: A ticket that can be used to establish a Remote Console connection to a VM.
+// Since: 5.5
+type MksTicket struct {
+	XMLName xml.Name `xml:"MksTicket"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Host    string   `xml:"Host,omitempty"`   // Host the console connection is established with
+	Vmx     string   `xml:"Vmx,omitempty"`    // Path of the VMX file of the VM
+	Ticket  string   `xml:"Ticket,omitempty"` // Ticket to authenticate the connection
+	Port    int      `xml:"Port,omitempty"`   // Port the console connection is established with
+}
+
 // GuestCustomizationStatusSection holds information about guest customization status
 // https://vdc-repo.vmware.com/vmwb-repository/dcr-public/76f491b4-679c-4e1e-8428-f813d668297a/a2555a1b-22f1-4cca-b481-2a98ab874022/doc/doc/operations/GET-GuestCustStatus.html
 type GuestCustomizationStatusSection struct {
@@ -1850,13 +2097,13 @@ type InstantiateVAppTemplateParams struct {
 	PowerOn     bool   `xml:"powerOn,attr"`               // True if the vApp should be powered-on at instantiation. Defaults to true.
 	LinkedClone bool   `xml:"linkedClone,attr,omitempty"` // Reserved. Unimplemented.
 	// Elements
-	Description         string                       `xml:"Description,omitempty"`         // Optional description.
-	VAppParent          *Reference                   `xml:"VAppParent,omitempty"`          // Reserved. Unimplemented.
-	InstantiationParams *InstantiationParams         `xml:"InstantiationParams,omitempty"` // Instantiation parameters for the composed vApp.
-	Source              *Reference                   `xml:"Source"`                        // A reference to a source object such as a vApp or vApp template.
-	IsSourceDelete      bool                         `xml:"IsSourceDelete,omitempty"`      // Set to true to delete the source object after the operation completes.
-	SourcedItem         *SourcedCompositionItemParam `xml:"SourcedItem,omitempty"`         // Composition item. One of: vApp vAppTemplate VM.
-	AllEULAsAccepted    bool                         `xml:"AllEULAsAccepted,omitempty"`    // True confirms acceptance of all EULAs in a vApp template. Instantiation fails if this element is missing, empty, or set to false and one or more EulaSection elements are present.
+	Description         string                         `xml:"Description,omitempty"`         // Optional description.
+	VAppParent          *Reference                     `xml:"VAppParent,omitempty"`          // Reserved. Unimplemented.
+	InstantiationParams *InstantiationParams           `xml:"InstantiationParams,omitempty"` // Instantiation parameters for the composed vApp.
+	Source              *Reference                     `xml:"Source"`                        // A reference to a source object such as a vApp or vApp template.
+	IsSourceDelete      bool                           `xml:"IsSourceDelete,omitempty"`      // Set to true to delete the source object after the operation completes.
+	SourcedItem         []*SourcedCompositionItemParam `xml:"SourcedItem,omitempty"`         // Composition items. Each one can set parameters of a VM of the vApp template.
+	AllEULAsAccepted    bool                           `xml:"AllEULAsAccepted,omitempty"`    // True confirms acceptance of all EULAs in a vApp template. Instantiation fails if this element is missing, empty, or set to false and one or more EulaSection elements are present.
 }
 
 // EdgeGateway represents a gateway.
@@ -2346,6 +2593,7 @@ type QueryResultRecordsType struct {
 	VmGroupsRecord                  []*QueryResultVmGroupsRecordType                  `xml:"VmGroupsRecord"`                  // A record representing a VM Group
 	TaskRecord                      []*QueryResultTaskRecordType                      `xml:"TaskRecord"`                      // A record representing a Task
 	AdminTaskRecord                 []*QueryResultTaskRecordType                      `xml:"AdminTaskRecord"`                 // A record representing an Admin Task
+	DatastoreRecord                 []*QueryResultDatastoreRecordType                 `xml:"DatastoreRecord"`                 // A record representing a datastore
 }
 
 // QueryResultVmGroupsRecordType represent a VM Groups record
@@ -2725,6 +2973,25 @@ type QueryResultVirtualCenterRecordType struct {
 	VsmIP         string `xml:"vsmIP,attr,omitempty"`
 }
 
+// Type: QueryResultDatastoreRecordType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// https://vdc-repo.vmware.com/vmwb-repository/dcr-public/7a028e78-bd37-4a6a-8298-9c26c7eeb9aa/09142237-dd46-4dee-8326-e07212fb63a8/doc/doc/types/QueryResultDatastoreRecordType.html
+// Description: Type for a single datastore query result in records format.
+// Since: 1.5
+type QueryResultDatastoreRecordType struct {
+	HREF                 string `xml:"href,attr,omitempty"`
+	Name                 string `xml:"name,attr,omitempty"`
+	Moref                string `xml:"moref,attr,omitempty"`
+	DatastoreType        string `xml:"datastoreType,attr,omitempty"`
+	IsEnabled            bool   `xml:"isEnabled,attr,omitempty"`
+	IsDeleted            bool   `xml:"isDeleted,attr,omitempty"`
+	StorageUsedMB        int64  `xml:"storageUsedMB,attr,omitempty"`
+	ProvisionedStorageMB int64  `xml:"provisionedStorageMB,attr,omitempty"`
+	RequestedStorageMB   int64  `xml:"requestedStorageMB,attr,omitempty"`
+	Vc                   string `xml:"vc,attr,omitempty"`
+	VcName               string `xml:"vcName,attr,omitempty"`
+}
+
 // Namespace: http://www.vmware.com/vcloud/v1.5
 // Retrieve a list of extension objects and operations.
 // Since: 1.0
@@ -2760,6 +3027,17 @@ type VimObjectRef struct {
 	VimObjectType string     `xml:"VimObjectType"`
 }
 
+// Type: VmVimInfoType
+// Namespace: http://www.vmware.com/vcloud/extension/v1.5
+// Description: vSphere information of a VM, included in the VCloudExtension of a VM retrieved by a system administrator.
+// Since: 1.5
+type VmVimInfo struct {
+	VmVimObjectRef             *VimObjectRef `xml:"VmVimObjectRef,omitempty"`        // The VM in vCenter
+	DatastoreVimObjectRef      *VimObjectRef `xml:"DatastoreVimObjectRef,omitempty"` // The datastore of the VM home
+	HostVimObjectRef           *VimObjectRef `xml:"HostVimObjectRef,omitempty"`      // The host running the VM
+	VirtualDisksMaxChainLength int           `xml:"VirtualDisksMaxChainLength,omitempty"`
+}
+
 // Type: VimObjectRefsType
 // Namespace: http://www.vmware.com/vcloud/extension/v1.5
 // https://vdc-repo.vmware.com/vmwb-repository/dcr-public/7a028e78-bd37-4a6a-8298-9c26c7eeb9aa/09142237-dd46-4dee-8326-e07212fb63a8/doc/doc/types/VimObjectRefsType.html
@@ -3269,6 +3547,38 @@ type ApiTokenRefresh struct {
 	UpdatedOn    string `json:"updated_on,omitempty"`
 }
 
+// ApiTokenParams contains the name of an API token to be registered as an OAuth client
+type ApiTokenParams struct {
+	ClientName string `json:"client_name"`
+}
+
+// ServiceAccountParams describes a Service Account to be registered as an OAuth client
+type ServiceAccountParams struct {
+	ClientName      string `json:"client_name"`
+	ClientURI       string `json:"client_uri,omitempty"`
+	SoftwareID      string `json:"software_id"`
+	SoftwareVersion string `json:"software_version,omitempty"`
+	Scope           string `json:"scope,omitempty"` // Role of the Service Account, as "urn:vcloud:role:<Role name>"
+}
+
+// ServiceAccountDeviceAuthorization is the response of an OAuth device authorization request of a Service Account.
+// The user code must be granted access before the first API token can be retrieved with the device code
+type ServiceAccountDeviceAuthorization struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"` // Seconds before the device code expires
+	Interval        int    `json:"interval"`   // Minimum seconds between two token requests
+}
+
+// ApiTokenClientMsg is the OAuth client registered for an API token
+type ApiTokenClientMsg struct {
+	ClientID                string   `json:"client_id"`
+	ClientName              string   `json:"client_name"`
+	GrantTypes              []string `json:"grant_types,omitempty"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+}
+
 /**/
 type QueryResultTaskRecordType struct {
 	HREF             string    `xml:"href,attr,omitempty"`             // Contains the URI to the resource.
@@ -56,6 +56,9 @@ type Vm struct {
 
 	VmSpecSection *VmSpecSection `xml:"VmSpecSection,omitempty"`
 
+	// BootOptions contains the firmware boot settings of the VM
+	BootOptions *BootOptions `xml:"BootOptions,omitempty"`
+
 	// GuestCustomizationSection contains settings for VM customization like admin password, SID
 	// changes, domain join configuration, etc
 	GuestCustomizationSection *GuestCustomizationSection `xml:"GuestCustomizationSection,omitempty"`
@@ -67,6 +70,49 @@ type Vm struct {
 	Media          *Reference      `xml:"Media,omitempty"`         // Reference to the media object to insert in a new VM.
 }
 
+// BootOptions allows to specify the firmware boot settings of a VM
+type BootOptions struct {
+	BootDelay            *int   `xml:"BootDelay,omitempty"`            // Delay in milliseconds between power-on and boot of the VM
+	EnterBiosSetup       *bool  `xml:"EnterBIOSSetup,omitempty"`       // Enter BIOS setup on next boot. VCD resets it to false after the boot
+	BootRetryEnabled     *bool  `xml:"BootRetryEnabled,omitempty"`     // Retry boot when no boot device is found. Available since API 37.1
+	BootRetryDelay       *int   `xml:"BootRetryDelay,omitempty"`       // Delay in milliseconds before retrying boot. Available since API 37.1
+	EfiSecureBootEnabled *bool  `xml:"EfiSecureBootEnabled,omitempty"` // Available since API 37.1. Only applies to EFI firmware
+	NetworkBootProtocol  string `xml:"NetworkBootProtocol,omitempty"`  // "IPv4" or "IPv6". Available since API 37.1
+}
+
+// ExtraConfig is a vmw:ExtraConfig entry of an ovf:VirtualHardwareSection, which holds a vSphere advanced setting
+// of a VM, such as sched.cpu.latencySensitivity. It is only used to read the entries: see ExtraConfigUpdate
+type ExtraConfig struct {
+	Key      string `xml:"key,attr"`
+	Value    string `xml:"value,attr"`
+	Required bool   `xml:"required,attr,omitempty"`
+}
+
+// VmExtraConfigUpdate is the payload of the reconfigureVm action that sets vmw:ExtraConfig entries of a VM.
+// Only the given entries are changed
+type VmExtraConfigUpdate struct {
+	XMLName xml.Name `xml:"Vm"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Ovf     string   `xml:"xmlns:ovf,attr"`
+	Vmw     string   `xml:"xmlns:vmw,attr"`
+	Name    string   `xml:"name,attr"`
+
+	VirtualHardwareSection *VirtualHardwareSectionExtraConfig `xml:"ovf:VirtualHardwareSection"`
+}
+
+// VirtualHardwareSectionExtraConfig is an ovf:VirtualHardwareSection holding only vmw:ExtraConfig entries
+type VirtualHardwareSectionExtraConfig struct {
+	Info        string               `xml:"ovf:Info"`
+	ExtraConfig []*ExtraConfigUpdate `xml:"vmw:ExtraConfig"`
+}
+
+// ExtraConfigUpdate is a vmw:ExtraConfig entry to be sent to VCD
+type ExtraConfigUpdate struct {
+	Key      string `xml:"vmw:key,attr"`
+	Value    string `xml:"vmw:value,attr"`
+	Required bool   `xml:"ovf:required,attr"`
+}
+
 type RuntimeInfoSection struct {
 	Ns10        string `xml:"ns10,attr"`
 	Type        string `xml:"type,attr"`